@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/go-tangra/go-tangra-inventory/internal/backup"
+	"github.com/go-tangra/go-tangra-inventory/internal/server"
+)
+
+var backupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Back up to object storage",
+}
+
+var backupRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Upload a snapshot and the new inventories to backup_url now",
+	Long: `Upload a snapshot of the database and the inventories stored since the
+previous backup to backup_url, then delete the backups beyond the retention,
+as the collector does every backup_interval.`,
+	Args: cobra.NoArgs,
+	RunE: runBackupRun,
+}
+
+var backupListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the snapshots and segments in backup_url",
+	Args:  cobra.NoArgs,
+	RunE:  runBackupList,
+}
+
+func init() {
+	backupCmd.AddCommand(backupRunCmd, backupListCmd)
+	rootCmd.AddCommand(backupCmd)
+}
+
+// newBackuper opens the store and the configured backup bucket. The
+// returned function closes the store.
+func newBackuper(cmd *cobra.Command) (*backup.Backuper, func(), error) {
+	cfg, err := loadConfig(cmd)
+	if err != nil {
+		return nil, nil, err
+	}
+	if cfg.BackupURL == "" {
+		return nil, nil, errors.New("backup_url is not configured")
+	}
+	db, err := openStore(cmd)
+	if err != nil {
+		return nil, nil, err
+	}
+	b, err := server.NewBackuper(cfg, db)
+	if err != nil {
+		db.Close()
+		return nil, nil, err
+	}
+	return b, func() { db.Close() }, nil
+}
+
+func runBackupRun(cmd *cobra.Command, _ []string) error {
+	b, done, err := newBackuper(cmd)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	res, err := b.Run(context.Background())
+	if res.Snapshot != "" {
+		fmt.Printf("Uploaded snapshot %s (%s)\n", res.Snapshot, formatBytes(res.SnapshotSize))
+	}
+	for _, key := range res.Segments {
+		fmt.Printf("Uploaded segment %s\n", key)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Backed up %d new inventories, deleted %d expired backups\n", res.Inventories, res.Deleted)
+	return nil
+}
+
+func runBackupList(cmd *cobra.Command, _ []string) error {
+	b, done, err := newBackuper(cmd)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	ctx := context.Background()
+	snapshots, err := b.Snapshots(ctx)
+	if err != nil {
+		return err
+	}
+	segments, err := b.Segments(ctx)
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "KEY\tSIZE\tUPLOADED")
+	for _, o := range append(snapshots, segments...) {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", o.Key, formatBytes(o.Size), o.Modified.Local().Format(time.DateTime))
+	}
+	return tw.Flush()
+}
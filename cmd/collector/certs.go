@@ -0,0 +1,132 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/go-tangra/go-tangra-inventory/internal/certs"
+)
+
+var certsCmd = &cobra.Command{
+	Use:   "certs",
+	Short: "Manage TLS certificates",
+}
+
+var certsFlags struct {
+	dir        string
+	hosts      []string
+	clientName string
+	days       int
+	force      bool
+}
+
+var certsInitCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Create a CA with server and client certificates for TLS and mutual TLS",
+	Long: `Create a private CA, a server certificate for the collector and a client
+certificate for agents, and print the settings that enable them.
+
+The server certificate is issued for every --host; by default these are the
+host of the configured listen address, or this machine's hostname, plus
+localhost and 127.0.0.1. Agents must connect with one of these names.
+
+ca-key.pem is only needed to issue further certificates and is best kept
+offline.`,
+	Args: cobra.NoArgs,
+	RunE: runCertsInit,
+}
+
+func init() {
+	f := certsInitCmd.Flags()
+	f.StringVar(&certsFlags.dir, "dir", "certs", "directory to write the certificates and keys to")
+	f.StringSliceVar(&certsFlags.hosts, "host", nil, "DNS name or IP address of the collector (repeatable)")
+	f.StringVar(&certsFlags.clientName, "client-name", "inventory-agent", "common name of the client certificate")
+	f.IntVar(&certsFlags.days, "days", 825, "validity of the server and client certificates in days (the CA: twice as long)")
+	f.BoolVar(&certsFlags.force, "force", false, "overwrite existing certificates and keys")
+
+	certsCmd.AddCommand(certsInitCmd)
+	rootCmd.AddCommand(certsCmd)
+}
+
+func runCertsInit(cmd *cobra.Command, _ []string) error {
+	if certsFlags.days <= 0 {
+		return errors.New("--days must be positive")
+	}
+	cfg, err := loadConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	hosts := certsFlags.hosts
+	if len(hosts) == 0 {
+		hosts = defaultCertHosts(cfg.Listen)
+	}
+	err = certs.Init(certsFlags.dir, certs.Options{
+		Hosts:      hosts,
+		ClientName: certsFlags.clientName,
+		Validity:   time.Duration(certsFlags.days) * 24 * time.Hour,
+		Overwrite:  certsFlags.force,
+	})
+	if err != nil {
+		return err
+	}
+
+	dir, err := filepath.Abs(certsFlags.dir)
+	if err != nil {
+		return err
+	}
+	port := "9550"
+	if _, p, err := net.SplitHostPort(cfg.Listen); err == nil {
+		port = p
+	}
+	addr := net.JoinHostPort(hosts[0], port)
+
+	fmt.Printf(`Created a CA with server and client certificates in %s,
+valid for %d days for %s.
+
+Enable TLS in the collector configuration, then restart the collector:
+
+  tls_cert_file: %q
+  tls_key_file: %q
+  # Require client certificates (mutual TLS); leave out for TLS only.
+  tls_client_ca_file: %q
+
+Copy %s, %s and %s to each agent and run it with:
+
+  inventory -daemon -collector %s -ca-cert %s -client-cert %s -client-key %s
+
+Without tls_client_ca_file, agents need only -ca-cert. inventoryctl takes the
+same files as --ca-cert, --client-cert and --client-key.
+`,
+		dir, certsFlags.days, strings.Join(hosts, ", "),
+		filepath.Join(dir, certs.ServerCertFile), filepath.Join(dir, certs.ServerKeyFile), filepath.Join(dir, certs.CAFile),
+		certs.CAFile, certs.ClientCertFile, certs.ClientKeyFile,
+		addr, certs.CAFile, certs.ClientCertFile, certs.ClientKeyFile,
+	)
+	return nil
+}
+
+// defaultCertHosts returns the names agents are likely to reach the
+// collector listening on listen by: the listen host, or the hostname for a
+// wildcard address, plus the loopback names.
+func defaultCertHosts(listen string) []string {
+	host, _, err := net.SplitHostPort(listen)
+	if err != nil || host == "" || host == "0.0.0.0" || host == "::" {
+		host, _ = os.Hostname()
+	}
+	var hosts []string
+	for _, h := range []string{host, "localhost", "127.0.0.1"} {
+		if h != "" && !slices.Contains(hosts, h) {
+			hosts = append(hosts, h)
+		}
+	}
+	return hosts
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/go-tangra/go-tangra-inventory/internal/config"
+	"github.com/go-tangra/go-tangra-inventory/internal/server"
+	"github.com/go-tangra/go-tangra-inventory/internal/store"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect the collector configuration",
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check the configuration and print the effective settings",
+	Long: `Load the configuration file, environment and command-line overrides as
+'serve' would, print the effective configuration with secrets redacted, and
+check the listen addresses, the TLS certificate and key, the remaining
+settings, and that the database can be opened for writing.
+
+Nothing is opened for listening and the database is not modified, so the
+check is safe to run next to a serving collector. The exit status is non-zero
+when a problem is found.`,
+	Args: cobra.NoArgs,
+	RunE: runConfigValidate,
+}
+
+func init() {
+	configCmd.AddCommand(configValidateCmd)
+	rootCmd.AddCommand(configCmd)
+}
+
+func runConfigValidate(cmd *cobra.Command, _ []string) error {
+	cmd.SilenceUsage = true
+	cfg, err := loadConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	if f := config.File(); f != "" {
+		fmt.Printf("# Config file: %s\n", f)
+	} else {
+		fmt.Println("# No config file found; using defaults and environment")
+	}
+	doc, err := configYAML(cfg.Redacted())
+	if err != nil {
+		return err
+	}
+	os.Stdout.Write(doc)
+
+	problems := server.CheckConfig(cfg)
+	if cfg.DatabasePath != "" {
+		if err := store.CheckAccess(context.Background(), cfg.DatabasePath); err != nil {
+			problems = append(problems, fmt.Errorf("database: %w", err))
+		}
+	}
+
+	fmt.Println()
+	if len(problems) == 0 {
+		fmt.Println("Configuration is valid.")
+		return nil
+	}
+	for _, p := range problems {
+		fmt.Printf("  %v\n", p)
+	}
+	return fmt.Errorf("found %d configuration problems", len(problems))
+}
+
+// configYAML renders cfg as YAML with the config file keys, in the order of
+// the Config fields.
+func configYAML(cfg *config.Config) ([]byte, error) {
+	doc := &yaml.Node{Kind: yaml.MappingNode}
+	v := reflect.ValueOf(cfg).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		var val yaml.Node
+		if err := val.Encode(v.Field(i).Interface()); err != nil {
+			return nil, fmt.Errorf("encode config: %w", err)
+		}
+		key := yaml.Node{Kind: yaml.ScalarNode, Value: v.Type().Field(i).Tag.Get("mapstructure")}
+		doc.Content = append(doc.Content, &key, &val)
+	}
+	return yaml.Marshal(doc)
+}
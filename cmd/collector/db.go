@@ -0,0 +1,220 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/go-tangra/go-tangra-inventory/internal/store"
+)
+
+var dbCmd = &cobra.Command{
+	Use:   "db",
+	Short: "Maintain the SQLite database",
+}
+
+var backupOut string
+
+var dbBackupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Back up the database while the collector is running",
+	Long: `Back up the database with SQLite's online backup API. The copy is a
+consistent snapshot even while the collector is serving, so scheduled backups
+need neither a service stop nor a copy of the write-ahead log.`,
+	Args: cobra.NoArgs,
+	RunE: runDBBackup,
+}
+
+var dbVerifyCmd = &cobra.Command{
+	Use:   "verify [path]",
+	Short: "Check the integrity of the database or of a backup",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runDBVerify,
+}
+
+var dbCompactCmd = &cobra.Command{
+	Use:   "compact",
+	Short: "Reclaim free space, e.g. after a large purge",
+	Long: `Rebuild the database file with VACUUM and truncate the write-ahead log,
+reporting the space reclaimed. Writes by a running collector wait until the
+compaction is done.
+
+Compacting a database created by an older collector once also lets later
+purges return the space they free as they go.`,
+	Args: cobra.NoArgs,
+	RunE: runDBCompact,
+}
+
+var migrateFlags struct {
+	to     int
+	down   bool
+	status bool
+}
+
+var dbMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Apply or revert database schema migrations",
+	Long: `Bring the database schema to the latest version, or to the version given
+with --to, printing each migration applied. With --down, revert migrations:
+the latest applied one, or every one above --to.
+
+The collector migrates at startup unless auto_migrate is false; set it to
+false to make upgrades an explicit step. --status lists the migrations and
+when each was applied, without applying or reverting any.`,
+	Args: cobra.NoArgs,
+	RunE: runDBMigrate,
+}
+
+func init() {
+	f := dbMigrateCmd.Flags()
+	f.IntVar(&migrateFlags.to, "to", -1, "target schema version (default: latest, or one below the current with --down)")
+	f.BoolVar(&migrateFlags.down, "down", false, "revert migrations")
+	f.BoolVar(&migrateFlags.status, "status", false, "list the migrations and their state only")
+
+	dbBackupCmd.Flags().StringVar(&backupOut, "out", "", "path of the backup file (required)")
+	dbBackupCmd.MarkFlagRequired("out")
+
+	dbCmd.AddCommand(dbBackupCmd, dbVerifyCmd, dbCompactCmd, dbMigrateCmd)
+	rootCmd.AddCommand(dbCmd)
+}
+
+func runDBBackup(cmd *cobra.Command, _ []string) error {
+	db, err := openStore(cmd)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	if err := db.Backup(context.Background(), backupOut); err != nil {
+		return err
+	}
+	fmt.Printf("Backed up database to %s\n", backupOut)
+	return nil
+}
+
+func runDBVerify(cmd *cobra.Command, args []string) error {
+	path := ""
+	if len(args) > 0 {
+		path = args[0]
+	} else {
+		cfg, err := loadConfig(cmd)
+		if err != nil {
+			return err
+		}
+		path = cfg.DatabasePath
+	}
+
+	res, err := store.Verify(context.Background(), path)
+	if err != nil {
+		return err
+	}
+	for _, p := range res.Problems {
+		fmt.Println(p)
+	}
+	if len(res.Problems) > 0 {
+		return fmt.Errorf("%s failed the integrity check", path)
+	}
+	fmt.Printf("%s: ok, %d inventories, %d alerts\n", path, res.Inventories, res.Alerts)
+	return nil
+}
+
+func runDBCompact(cmd *cobra.Command, _ []string) error {
+	db, err := openStore(cmd)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	before, after, err := db.Compact(context.Background())
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Compacted database from %s to %s (%s reclaimed)\n", formatBytes(before), formatBytes(after), formatBytes(max(before-after, 0)))
+	return nil
+}
+
+// formatBytes renders n in binary units, e.g. 1.5 MiB.
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+func runDBMigrate(cmd *cobra.Command, _ []string) error {
+	cfg, err := loadConfig(cmd)
+	if err != nil {
+		return err
+	}
+	db, err := store.Open(cfg.DatabasePath)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	if migrateFlags.status {
+		list, err := db.Migrations(ctx)
+		if err != nil {
+			return err
+		}
+		tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "VERSION\tNAME\tAPPLIED")
+		for _, m := range list {
+			applied := "pending"
+			if !m.AppliedAt.IsZero() {
+				applied = m.AppliedAt.Local().Format(time.DateTime)
+			}
+			fmt.Fprintf(tw, "%d\t%s\t%s\n", m.Version, m.Name, applied)
+		}
+		return tw.Flush()
+	}
+
+	current, err := db.SchemaVersion(ctx)
+	if err != nil {
+		return err
+	}
+	target := migrateFlags.to
+	switch {
+	case migrateFlags.down && target < 0:
+		target = current - 1
+	case migrateFlags.down && target >= current:
+		return fmt.Errorf("--down needs a version below the current %d", current)
+	case !migrateFlags.down && target < 0:
+		target = store.LatestVersion()
+	case !migrateFlags.down && target < current:
+		return fmt.Errorf("version %d is below the current %d; use --down to revert", target, current)
+	}
+	if target < 0 {
+		return errors.New("no migrations to revert")
+	}
+
+	ran, err := db.MigrateTo(ctx, target)
+	for _, m := range ran {
+		verb := "Applied"
+		if migrateFlags.down {
+			verb = "Reverted"
+		}
+		fmt.Printf("%s migration %d: %s\n", verb, m.Version, m.Name)
+	}
+	if err != nil {
+		return err
+	}
+
+	if current, err = db.SchemaVersion(ctx); err != nil {
+		return err
+	}
+	fmt.Printf("Schema is at version %d (latest %d)\n", current, store.LatestVersion())
+	return nil
+}
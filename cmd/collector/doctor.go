@@ -0,0 +1,250 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/go-tangra/go-tangra-inventory/internal/server"
+	"github.com/go-tangra/go-tangra-inventory/internal/store"
+)
+
+var doctorFlags struct {
+	maxSkew time.Duration
+}
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose the configuration, database and host of the collector",
+	Long: `Run a series of health checks and print a warning with a suggested fix
+for each problem found:
+
+  - the configuration, as 'config validate' checks it
+  - whether the listen addresses can be bound
+  - database integrity (PRAGMA integrity_check), schema version and indexes
+  - the size of the write-ahead log
+  - free disk space next to the database
+  - the system clock, and agents whose clock runs ahead of it
+
+Nothing is modified, so doctor is safe to run next to a serving collector;
+addresses it is listening on are reported as in use. The exit status is
+non-zero when a check fails, but not for warnings.`,
+	Args: cobra.NoArgs,
+	RunE: runDoctor,
+}
+
+func init() {
+	doctorCmd.Flags().DurationVar(&doctorFlags.maxSkew, "max-clock-skew", 5*time.Minute, "how far agent clocks may run ahead of the collector's")
+
+	rootCmd.AddCommand(doctorCmd)
+}
+
+// Thresholds of the doctor checks.
+const (
+	walWarnSize    = 64 << 20
+	diskFailFree   = 100 << 20
+	diskWarnFree   = 1 << 30
+	diskWarnShare  = 0.10
+	clockSaneAfter = 2024
+)
+
+// doctor collects the findings of the checks and prints them as they come.
+type doctor struct {
+	warnings, failures int
+}
+
+func (d *doctor) ok(format string, args ...any) {
+	fmt.Printf("[ok]   %s\n", fmt.Sprintf(format, args...))
+}
+
+func (d *doctor) warn(format string, args ...any) {
+	d.warnings++
+	fmt.Printf("[warn] %s\n", fmt.Sprintf(format, args...))
+}
+
+func (d *doctor) fail(format string, args ...any) {
+	d.failures++
+	fmt.Printf("[FAIL] %s\n", fmt.Sprintf(format, args...))
+}
+
+func runDoctor(cmd *cobra.Command, _ []string) error {
+	cmd.SilenceUsage = true
+	cfg, err := loadConfig(cmd)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	d := &doctor{}
+
+	problems := server.CheckConfig(cfg)
+	for _, p := range problems {
+		d.fail("config: %v", p)
+	}
+	if len(problems) == 0 {
+		d.ok("configuration is valid")
+	}
+
+	for _, l := range []struct{ key, addr string }{
+		{"listen", cfg.Listen},
+		{"http_listen", cfg.HTTPListen},
+		{"admin_listen", cfg.AdminListen},
+	} {
+		if l.addr == "" {
+			continue
+		}
+		switch err := server.CheckListen(l.addr); {
+		case err == nil:
+			d.ok("%s %s can be bound", l.key, l.addr)
+		case errors.Is(err, server.ErrAddrInUse):
+			d.warn("%s %s is in use: fine if this collector is running, otherwise stop the process listening there or change %s", l.key, l.addr, l.key)
+		default:
+			d.fail("%s %s cannot be bound: %v", l.key, l.addr, err)
+		}
+	}
+
+	var diag *store.Diagnosis
+	if cfg.DatabasePath != "" {
+		diag = d.checkDatabase(ctx, cfg.DatabasePath)
+		d.checkDisk(cfg.DatabasePath, diag)
+	}
+	d.checkClock(diag)
+
+	fmt.Println()
+	if d.failures > 0 {
+		return fmt.Errorf("checks failed: %d, warnings: %d", d.failures, d.warnings)
+	}
+	if d.warnings > 0 {
+		fmt.Printf("No check failed; warnings: %d\n", d.warnings)
+		return nil
+	}
+	fmt.Println("All checks passed.")
+	return nil
+}
+
+// checkDatabase checks the database at path and returns its diagnosis, or
+// nil when it does not exist or cannot be read.
+func (d *doctor) checkDatabase(ctx context.Context, path string) *store.Diagnosis {
+	if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+		d.warn("database %s does not exist yet: serve creates it; check database_path if one is expected", path)
+		return nil
+	}
+	diag, err := store.Diagnose(ctx, path, doctorFlags.maxSkew)
+	if err != nil {
+		d.fail("database %s cannot be read: %v", path, err)
+		return nil
+	}
+
+	if len(diag.Problems) == 0 {
+		d.ok("database integrity check passed (%d inventories, %d alerts)", diag.Inventories, diag.Alerts)
+	} else {
+		const shown = 10
+		for _, p := range diag.Problems[:min(len(diag.Problems), shown)] {
+			d.fail("database integrity: %s", p)
+		}
+		if len(diag.Problems) > shown {
+			fmt.Printf("       ... and %d more integrity errors\n", len(diag.Problems)-shown)
+		}
+		fmt.Println("       Stop the collector and restore the latest backup (see 'db backup').")
+	}
+
+	switch latest := store.LatestVersion(); {
+	case diag.SchemaVersion == 0:
+		d.warn("database schema version is not recorded: run 'inventory-collector db migrate'")
+	case diag.SchemaVersion < latest:
+		d.warn("database schema is at version %d, this collector expects %d: run 'inventory-collector db migrate'", diag.SchemaVersion, latest)
+	case diag.SchemaVersion > latest:
+		d.fail("database schema is at version %d, newer than this collector's %d: upgrade the collector", diag.SchemaVersion, latest)
+	default:
+		d.ok("database schema is at version %d", diag.SchemaVersion)
+	}
+
+	if len(diag.MissingIndexes) == 0 {
+		if diag.SchemaVersion > 0 {
+			d.ok("database indexes are present")
+		}
+	} else {
+		d.warn("database lacks indexes, which slows queries down; recreate them with the sqlite3 shell while the collector is stopped:")
+		for _, stmt := range diag.MissingIndexes {
+			fmt.Printf("         %s;\n", strings.Join(strings.Fields(stmt), " "))
+		}
+	}
+
+	if diag.WALSize > walWarnSize {
+		d.warn("write-ahead log is %s: a long-lived reader may be blocking checkpoints; run 'inventory-collector db compact' to truncate it", formatBytes(diag.WALSize))
+	} else {
+		d.ok("write-ahead log is %s", formatBytes(diag.WALSize))
+	}
+	return diag
+}
+
+// checkDisk checks the free space on the file system of the database at
+// path.
+func (d *doctor) checkDisk(path string, diag *store.Diagnosis) {
+	dir := filepath.Dir(path)
+	free, total, err := diskSpace(dir)
+	if err != nil {
+		d.warn("cannot determine the free disk space in %s: %v", dir, err)
+		return
+	}
+	var dbSize int64
+	if diag != nil {
+		dbSize = diag.Size + diag.WALSize
+	}
+
+	msg := fmt.Sprintf("%s free of %s in %s", formatBytes(int64(free)), formatBytes(int64(total)), dir)
+	switch {
+	case free < diskFailFree:
+		d.fail("%s: the collector is about to run out of space; free some or lower retention and purge", msg)
+	case free < diskWarnFree || float64(free) < diskWarnShare*float64(total):
+		d.warn("%s: free some space or lower retention and purge", msg)
+	case free < uint64(dbSize):
+		d.warn("%s: less than the database size, which 'db compact' and 'db backup' next to it need", msg)
+	default:
+		d.ok("%s", msg)
+	}
+}
+
+// checkClock checks that the system clock is set and synchronized, against
+// the timestamps in diag if there is one.
+func (d *doctor) checkClock(diag *store.Diagnosis) {
+	now := time.Now()
+	if now.Year() < clockSaneAfter {
+		d.fail("system clock reads %s: set the time and enable time synchronization", now.Format(time.RFC3339))
+		return
+	}
+	sane := true
+	if synced, known := clockSynced(); known && !synced {
+		sane = false
+		d.warn("system clock is not synchronized: enable NTP, since timestamps, retention and certificate checks rely on it")
+	}
+	if diag == nil {
+		if sane {
+			d.ok("system clock looks sane")
+		}
+		return
+	}
+
+	if ahead := diag.NewestStoredAt.Sub(now); ahead > time.Minute {
+		sane = false
+		d.fail("newest inventory was stored at %s, %s ahead of the system clock: the clock was set back; correct it before agents report",
+			diag.NewestStoredAt.Local().Format(time.DateTime), ahead.Round(time.Second))
+	}
+	if n := len(diag.ClockAhead); n > 0 {
+		sane = false
+		hosts := strings.Join(diag.ClockAhead[:min(n, 5)], ", ")
+		if n > 5 {
+			hosts += ", ..."
+		}
+		d.warn("hosts report collection times more than %s ahead of the collector (%d: %s): check time synchronization on them", doctorFlags.maxSkew, n, hosts)
+	}
+	if sane {
+		d.ok("system clock looks sane")
+	}
+}
@@ -0,0 +1,25 @@
+package main
+
+import "golang.org/x/sys/unix"
+
+// diskSpace returns the bytes available to the collector and the total
+// size of the file system holding dir.
+func diskSpace(dir string) (free, total uint64, err error) {
+	var st unix.Statfs_t
+	if err := unix.Statfs(dir, &st); err != nil {
+		return 0, 0, err
+	}
+	return st.Bavail * uint64(st.Bsize), st.Blocks * uint64(st.Bsize), nil
+}
+
+// clockSynced reports whether the kernel considers the system clock
+// synchronized, e.g. by NTP; known is false where that cannot be told.
+func clockSynced() (synced, known bool) {
+	var tx unix.Timex
+	state, err := unix.Adjtimex(&tx)
+	if err != nil {
+		return false, false
+	}
+	// TIME_ERROR: the clock is not synchronized.
+	return state != 5, true
+}
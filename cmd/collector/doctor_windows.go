@@ -0,0 +1,22 @@
+package main
+
+import "golang.org/x/sys/windows"
+
+// diskSpace returns the bytes available to the collector and the total
+// size of the volume holding dir.
+func diskSpace(dir string) (free, total uint64, err error) {
+	p, err := windows.UTF16PtrFromString(dir)
+	if err != nil {
+		return 0, 0, err
+	}
+	if err := windows.GetDiskFreeSpaceEx(p, &free, &total, nil); err != nil {
+		return 0, 0, err
+	}
+	return free, total, nil
+}
+
+// clockSynced reports whether the system clock is synchronized; Windows
+// does not tell, so known is false.
+func clockSynced() (synced, known bool) {
+	return false, false
+}
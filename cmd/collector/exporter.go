@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"github.com/go-tangra/go-tangra-inventory/internal/logging"
+	"github.com/go-tangra/go-tangra-inventory/internal/metrics"
+	"github.com/go-tangra/go-tangra-inventory/internal/server"
+)
+
+var exporterCmd = &cobra.Command{
+	Use:   "exporter",
+	Short: "Serve Prometheus fleet metrics without running the collector",
+	Long: `Serve the Prometheus fleet metrics of the database on metrics_listen
+(or --metrics-listen) until interrupted, without accepting inventories, e.g.
+next to a collector on a copy of its database. The collector serves the same
+metrics itself when metrics_listen is set:
+
+  tangra_host_info{site,hostname,device_class,manufacturer,model,serial_number}
+  tangra_host_memory_bytes{site,hostname}
+  tangra_host_last_seen_timestamp{site,hostname}
+  tangra_hosts{site}`,
+	Args: cobra.NoArgs,
+	RunE: runExporter,
+}
+
+func init() {
+	exporterCmd.Flags().String("metrics-listen", "", "metrics listen address or unix:///path socket (default metrics_listen)")
+	rootCmd.AddCommand(exporterCmd)
+}
+
+func runExporter(cmd *cobra.Command, _ []string) error {
+	cfg, err := loadConfig(cmd)
+	if err != nil {
+		return err
+	}
+	if v, _ := cmd.Flags().GetString("metrics-listen"); v != "" {
+		cfg.MetricsListen = v
+	}
+	if cfg.MetricsListen == "" {
+		return errors.New("metrics_listen is not configured (or pass --metrics-listen)")
+	}
+	if err := (logging.Options{Level: cfg.LogLevel, Format: cfg.LogFormat}).Setup(); err != nil {
+		return err
+	}
+	db, err := openStore(cmd)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	slog.Info("Prometheus metrics available", "addr", cfg.MetricsListen, "path", metrics.Path)
+	return server.ServeMetrics(ctx, cfg, db)
+}
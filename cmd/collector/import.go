@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/go-tangra/go-tangra-inventory/internal/config"
+	"github.com/go-tangra/go-tangra-inventory/internal/importer"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import device inventory from Configuration Manager or Intune",
+	Long: `Import the devices of another inventory system, so a migration to the
+collector starts with the existing fleet. A device matching a host of --site
+by system UUID, serial number or hostname is stored as that host, unless the host already has an inventory at least as recent as the
+source's last inventory of the device; other devices are stored as new hosts
+of --site. Imported inventories are marked with their source (sccm or
+intune), do not raise alerts or events, and are skipped when imported again.`,
+}
+
+var importSCCMCmd = &cobra.Command{
+	Use:   "sccm",
+	Short: "Import the clients of a Configuration Manager site database (sccm_dsn)",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return runImport(cmd, func(cfg *config.Config) (importer.Source, error) {
+			if cfg.SCCMDSN == "" {
+				return nil, errors.New("sccm_dsn is not configured")
+			}
+			return &importer.SCCM{DSN: cfg.SCCMDSN}, nil
+		})
+	},
+}
+
+var importIntuneCmd = &cobra.Command{
+	Use:   "intune",
+	Short: "Import the Windows and macOS devices managed by Intune (intune_*)",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return runImport(cmd, func(cfg *config.Config) (importer.Source, error) {
+			if cfg.IntuneTenantID == "" || cfg.IntuneClientID == "" || cfg.IntuneClientSecret == "" {
+				return nil, errors.New("intune_tenant_id, intune_client_id and intune_client_secret are required")
+			}
+			return &importer.Intune{
+				TenantID:     cfg.IntuneTenantID,
+				ClientID:     cfg.IntuneClientID,
+				ClientSecret: cfg.IntuneClientSecret,
+			}, nil
+		})
+	},
+}
+
+var importFlags struct {
+	site   string
+	dryRun bool
+}
+
+func init() {
+	importCmd.PersistentFlags().StringVar(&importFlags.site, "site", "", "site of the imported devices that match no stored host")
+	importCmd.PersistentFlags().BoolVar(&importFlags.dryRun, "dry-run", false, "report what would be imported without storing anything")
+	importCmd.AddCommand(importSCCMCmd, importIntuneCmd)
+	rootCmd.AddCommand(importCmd)
+}
+
+func runImport(cmd *cobra.Command, source func(*config.Config) (importer.Source, error)) error {
+	cfg, err := loadConfig(cmd)
+	if err != nil {
+		return err
+	}
+	src, err := source(cfg)
+	if err != nil {
+		return err
+	}
+	db, err := openStore(cmd)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	res, err := importer.Import(context.Background(), db, src, importFlags.site, importFlags.dryRun)
+	if err != nil {
+		return err
+	}
+	verb := "Imported"
+	if importFlags.dryRun {
+		verb = "Would import"
+	}
+	fmt.Printf("Read %d devices from %s. %s %d (%d matching stored hosts), skipped %d\n",
+		res.Devices, src.Name(), verb, res.Imported, res.Matched, res.Skipped)
+	return nil
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/go-tangra/go-tangra-inventory/internal/server"
+)
+
+var ldapCmd = &cobra.Command{
+	Use:   "ldap",
+	Short: "Enrich host records from Active Directory or LDAP",
+}
+
+var ldapSyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Look the hosts and their users up in the directory now",
+	Long: `Look up the computer object of every host and the user object of the
+user of its latest inventory in the directory at ldap_url, as the collector
+does every ldap_interval, and store the container (OU), description, display
+name and department found for ListHosts to return.`,
+	Args: cobra.NoArgs,
+	RunE: runLDAPSync,
+}
+
+func init() {
+	ldapCmd.AddCommand(ldapSyncCmd)
+	rootCmd.AddCommand(ldapCmd)
+}
+
+func runLDAPSync(cmd *cobra.Command, _ []string) error {
+	cfg, err := loadConfig(cmd)
+	if err != nil {
+		return err
+	}
+	if cfg.LDAPURL == "" {
+		return errors.New("ldap_url is not configured")
+	}
+	db, err := openStore(cmd)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	enricher, err := server.NewDirectoryEnricher(cfg, db)
+	if err != nil {
+		return err
+	}
+	res, err := enricher.Enrich(context.Background())
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Looked up %d hosts: %d computers and %d users found\n", res.Hosts, res.Computers, res.Users)
+	return nil
+}
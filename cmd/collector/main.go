@@ -3,9 +3,11 @@ package main
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
 	"os/signal"
+	"regexp"
+	"strings"
 	"syscall"
 	"time"
 
@@ -13,6 +15,7 @@ import (
 
 	"github.com/go-tangra/go-tangra-inventory/cmd/collector/assets"
 	"github.com/go-tangra/go-tangra-inventory/internal/config"
+	"github.com/go-tangra/go-tangra-inventory/internal/logging"
 	"github.com/go-tangra/go-tangra-inventory/internal/server"
 	"github.com/go-tangra/go-tangra-inventory/internal/store"
 	"github.com/go-tangra/go-tangra-inventory/internal/winsvc"
@@ -53,10 +56,24 @@ var versionCmd = &cobra.Command{
 var purgeCmd = &cobra.Command{
 	Use:   "purge",
 	Short: "Purge inventory records older than the specified number of days",
-	RunE:  runPurge,
+	Long: `Purge inventory records older than --days, together with their alerts.
+
+With --hostname or --uuid only the records of that host are purged, of any
+age unless --days is given as well. --hostname also takes a glob pattern
+such as 'LAB-*', and --hostname-regex a regular expression, to purge every
+matching host at once, e.g. a decommissioned lab. --dry-run reports what
+would be purged without deleting anything.`,
+	RunE: runPurge,
 }
 
-var purgeDays int
+var purgeFlags struct {
+	days     int
+	hostname string
+	regex    string
+	uuid     string
+	site     string
+	dryRun   bool
+}
 
 const serviceName = "TangraInventoryCollector"
 
@@ -79,13 +96,21 @@ var serviceUninstallCmd = &cobra.Command{
 
 func init() {
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default: ./configs/collector.yaml)")
-	rootCmd.PersistentFlags().String("listen", "", "gRPC listen address (default :9550)")
-	rootCmd.PersistentFlags().String("http-listen", "", "HTTP listen address for Swagger UI (default :9551)")
+	rootCmd.PersistentFlags().String("listen", "", "gRPC listen address or unix:///path socket (default :9550)")
+	rootCmd.PersistentFlags().String("http-listen", "", "HTTP listen address or unix:///path socket (default :9551)")
 	rootCmd.PersistentFlags().String("database", "", "SQLite database path (default inventory.db)")
 	rootCmd.PersistentFlags().String("client-secret", "", "secret for gRPC inventory agents (empty = no auth)")
 	rootCmd.PersistentFlags().String("api-secret", "", "secret for REST API clients (empty = no auth)")
+	rootCmd.PersistentFlags().String("admin-listen", "", "separate gRPC listen address for admin RPCs (empty = share main port)")
+	rootCmd.PersistentFlags().String("log-level", "", "log level: debug, info, warn or error (default info)")
+	rootCmd.PersistentFlags().String("log-format", "", "log format: text or json (default text)")
 
-	purgeCmd.Flags().IntVar(&purgeDays, "days", 90, "purge records older than this many days")
+	purgeCmd.Flags().IntVar(&purgeFlags.days, "days", 90, "purge records older than this many days")
+	purgeCmd.Flags().StringVar(&purgeFlags.hostname, "hostname", "", "only purge records of this host, or of the hosts matching a glob such as 'LAB-*'")
+	purgeCmd.Flags().StringVar(&purgeFlags.regex, "hostname-regex", "", "only purge records of the hosts whose hostname matches this regular expression")
+	purgeCmd.Flags().StringVar(&purgeFlags.uuid, "uuid", "", "only purge records of the host with this system UUID")
+	purgeCmd.Flags().StringVar(&purgeFlags.site, "site", "", "only purge records of this site")
+	purgeCmd.Flags().BoolVar(&purgeFlags.dryRun, "dry-run", false, "report what would be purged without deleting it")
 
 	serviceCmd.AddCommand(serviceInstallCmd)
 	serviceCmd.AddCommand(serviceUninstallCmd)
@@ -103,31 +128,19 @@ func main() {
 }
 
 func runServe(cmd *cobra.Command, args []string) error {
-	cfg, err := config.Load(cfgFile)
+	cfg, err := loadConfig(cmd)
 	if err != nil {
-		return fmt.Errorf("load config: %w", err)
+		return err
 	}
 
-	// CLI flag overrides.
-	if v, _ := cmd.Flags().GetString("listen"); v != "" {
-		cfg.Listen = v
-	}
-	if v, _ := cmd.Flags().GetString("http-listen"); v != "" {
-		cfg.HTTPListen = v
-	}
-	if v, _ := cmd.Flags().GetString("database"); v != "" {
-		cfg.DatabasePath = v
-	}
-	if v, _ := cmd.Flags().GetString("client-secret"); v != "" {
-		cfg.ClientSecret = v
-	}
-	if v, _ := cmd.Flags().GetString("api-secret"); v != "" {
-		cfg.ApiSecret = v
+	logOpts := logging.Options{Level: cfg.LogLevel, Format: cfg.LogFormat}
+	if err := logOpts.Setup(); err != nil {
+		return err
 	}
 
 	// Windows service mode.
 	if winsvc.IsWindowsService() {
-		winsvc.SetupEventLog(serviceName)
+		winsvc.SetupEventLog(serviceName, logOpts)
 		return winsvc.RunService(serviceName, func(ctx context.Context) error {
 			return server.Run(ctx, cfg, assets.OpenApiData)
 		})
@@ -162,7 +175,7 @@ func runServiceInstall(_ *cobra.Command, _ []string) error {
 		return err
 	}
 
-	log.Printf("Service %s installed successfully", serviceName)
+	slog.Info("Service installed successfully", "service", serviceName)
 	return nil
 }
 
@@ -170,30 +183,124 @@ func runServiceUninstall(_ *cobra.Command, _ []string) error {
 	if err := winsvc.Uninstall(serviceName); err != nil {
 		return err
 	}
-	log.Printf("Service %s uninstalled successfully", serviceName)
+	slog.Info("Service uninstalled successfully", "service", serviceName)
 	return nil
 }
 
+// purgeProgressInterval is how often purge reports its progress.
+const purgeProgressInterval = 5 * time.Second
+
 func runPurge(cmd *cobra.Command, args []string) error {
-	cfg, err := config.Load(cfgFile)
-	if err != nil {
-		return fmt.Errorf("load config: %w", err)
+	f := store.PurgeFilter{
+		OlderThan:  time.Duration(purgeFlags.days) * 24 * time.Hour,
+		Hostname:   purgeFlags.hostname,
+		SystemUUID: purgeFlags.uuid,
+		DryRun:     purgeFlags.dryRun,
 	}
-	if v, _ := cmd.Flags().GetString("database"); v != "" {
-		cfg.DatabasePath = v
+	if purgeFlags.regex != "" {
+		re, err := regexp.Compile(purgeFlags.regex)
+		if err != nil {
+			return fmt.Errorf("--hostname-regex: %w", err)
+		}
+		f.HostnameRegexp = re
+	}
+	// A host is purged entirely unless --days is given explicitly.
+	if (f.Hostname != "" || f.HostnameRegexp != nil || f.SystemUUID != "") && !cmd.Flags().Changed("days") {
+		f.OlderThan = 0
+	} else if purgeFlags.days <= 0 {
+		return fmt.Errorf("--days must be positive")
+	}
+	if purgeFlags.site != "" {
+		f.Sites = []string{purgeFlags.site}
 	}
 
-	db, err := store.New(cfg.DatabasePath)
+	db, err := openStore(cmd)
 	if err != nil {
-		return fmt.Errorf("open database: %w", err)
+		return err
 	}
 	defer db.Close()
 
-	n, err := db.Purge(context.Background(), time.Duration(purgeDays)*24*time.Hour)
+	if !f.DryRun {
+		next := time.Now().Add(purgeProgressInterval)
+		f.Progress = func(res store.PurgeResult) {
+			if time.Now().After(next) {
+				next = time.Now().Add(purgeProgressInterval)
+				fmt.Fprintf(os.Stderr, "Purged %d records and %d alerts so far\n", res.Inventories, res.Alerts)
+			}
+		}
+	}
+	res, err := db.Purge(context.Background(), f)
 	if err != nil {
+		if res.Inventories > 0 || res.Alerts > 0 {
+			fmt.Fprintf(os.Stderr, "Purged %d records and %d alerts before the error\n", res.Inventories, res.Alerts)
+		}
 		return fmt.Errorf("purge: %w", err)
 	}
 
-	fmt.Printf("Purged %d records older than %d days\n", n, purgeDays)
+	var scope []string
+	if f.OlderThan > 0 {
+		scope = append(scope, fmt.Sprintf("older than %d days", purgeFlags.days))
+	}
+	hosts := "hosts"
+	if res.Hosts == 1 {
+		hosts = "host"
+	}
+	switch {
+	case f.Hostname != "" && store.IsGlob(f.Hostname):
+		scope = append(scope, fmt.Sprintf("of %d %s matching %s", res.Hosts, hosts, f.Hostname))
+	case f.Hostname != "":
+		scope = append(scope, "of host "+f.Hostname)
+	}
+	if f.HostnameRegexp != nil {
+		scope = append(scope, fmt.Sprintf("of %d %s matching /%s/", res.Hosts, hosts, purgeFlags.regex))
+	}
+	if f.SystemUUID != "" {
+		scope = append(scope, "of system UUID "+f.SystemUUID)
+	}
+	if purgeFlags.site != "" {
+		scope = append(scope, "of site "+purgeFlags.site)
+	}
+	verb := "Purged"
+	if f.DryRun {
+		verb = "Would purge"
+	}
+	fmt.Printf("%s %d records and %d alerts %s\n", verb, res.Inventories, res.Alerts, strings.Join(scope, " "))
 	return nil
 }
+
+// loadConfig loads the configuration file and applies the command-line
+// overrides.
+func loadConfig(cmd *cobra.Command) (*config.Config, error) {
+	cfg, err := config.Load(cfgFile)
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+
+	// CLI flag overrides.
+	if v, _ := cmd.Flags().GetString("listen"); v != "" {
+		cfg.Listen = v
+	}
+	if v, _ := cmd.Flags().GetString("http-listen"); v != "" {
+		cfg.HTTPListen = v
+	}
+	if v, _ := cmd.Flags().GetString("database"); v != "" {
+		cfg.DatabasePath = v
+	}
+	if v, _ := cmd.Flags().GetString("client-secret"); v != "" {
+		cfg.ClientSecret = v
+	}
+	if v, _ := cmd.Flags().GetString("api-secret"); v != "" {
+		cfg.ApiSecret = v
+	}
+	if v, _ := cmd.Flags().GetString("admin-listen"); v != "" {
+		cfg.AdminListen = v
+	}
+	if v, _ := cmd.Flags().GetString("log-level"); v != "" {
+		cfg.LogLevel = v
+	}
+	if v, _ := cmd.Flags().GetString("log-format"); v != "" {
+		cfg.LogFormat = v
+	}
+
+	return cfg, nil
+}
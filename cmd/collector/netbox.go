@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/go-tangra/go-tangra-inventory/internal/server"
+)
+
+var netBoxCmd = &cobra.Command{
+	Use:   "netbox",
+	Short: "Synchronize devices to NetBox",
+}
+
+var netBoxDryRun bool
+
+var netBoxSyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Create or update the NetBox devices of the latest inventories now",
+	Long: `Create or update a NetBox device for the latest inventory of every host
+of the sites configured in netbox_sites, as the collector does every
+netbox_interval, creating device types and manufacturers as needed. Devices
+are matched by serial number, or by name within the NetBox site for hosts
+without a usable serial number; hosts matching several devices are skipped
+and logged.
+
+--dry-run looks the devices up and reports what would be created or updated
+without writing to NetBox.`,
+	Args: cobra.NoArgs,
+	RunE: runNetBoxSync,
+}
+
+func init() {
+	netBoxSyncCmd.Flags().BoolVar(&netBoxDryRun, "dry-run", false, "report what would change without writing to NetBox")
+
+	netBoxCmd.AddCommand(netBoxSyncCmd)
+	rootCmd.AddCommand(netBoxCmd)
+}
+
+func runNetBoxSync(cmd *cobra.Command, _ []string) error {
+	cfg, err := loadConfig(cmd)
+	if err != nil {
+		return err
+	}
+	if cfg.NetBoxURL == "" {
+		return errors.New("netbox_url is not configured")
+	}
+	db, err := openStore(cmd)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	syncer, err := server.NewNetBoxSyncer(cfg, db)
+	if err != nil {
+		return err
+	}
+	res, err := syncer.Sync(context.Background(), netBoxDryRun)
+	if err != nil {
+		return err
+	}
+	verb := "Synchronized"
+	if netBoxDryRun {
+		verb = "Would synchronize"
+	}
+	fmt.Printf("%s NetBox devices: %d created, %d updated, %d unchanged, %d skipped\n", verb, res.Created, res.Updated, res.Unchanged, res.Skipped)
+	return nil
+}
@@ -0,0 +1,266 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+	"github.com/go-tangra/go-tangra-inventory/internal/convert"
+	"github.com/go-tangra/go-tangra-inventory/internal/output"
+	"github.com/go-tangra/go-tangra-inventory/internal/store"
+)
+
+// The query subcommands read the database directly, without going through
+// the API, for quick inspection on the collector host.
+
+var listFlags struct {
+	site     string
+	hostname string
+	username string
+	uuid     string
+	class    string
+	after    string
+	before   string
+	pageSize int
+	page     int
+	output   string
+}
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List stored inventories from the database, newest first",
+	Args:  cobra.NoArgs,
+	RunE:  runList,
+}
+
+var getCmd = &cobra.Command{
+	Use:   "get <id>",
+	Short: "Print a stored inventory from the database as JSON or YAML",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runGet,
+}
+
+var getOutput string
+
+var showSite string
+
+var showCmd = &cobra.Command{
+	Use:   "show <hostname>",
+	Short: "Summarize the latest stored inventory of a host",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runShow,
+}
+
+func init() {
+	f := listCmd.Flags()
+	f.StringVar(&listFlags.site, "site", "", "only inventories of this site")
+	f.StringVar(&listFlags.hostname, "hostname", "", "only inventories of this host")
+	f.StringVar(&listFlags.username, "username", "", "only inventories collected for this user")
+	f.StringVar(&listFlags.uuid, "uuid", "", "only inventories with this system UUID")
+	f.StringVar(&listFlags.class, "device-class", "", "only inventories of this device class (computer, switch, printer, ups or network)")
+	f.StringVar(&listFlags.after, "after", "", "only inventories collected after this time (2006-01-02 or RFC 3339)")
+	f.StringVar(&listFlags.before, "before", "", "only inventories collected before this time (2006-01-02 or RFC 3339)")
+	f.IntVar(&listFlags.pageSize, "page-size", 50, "inventories per page")
+	f.IntVar(&listFlags.page, "page", 1, "page number")
+	f.StringVarP(&listFlags.output, "output", "o", output.Table, output.ListUsage)
+
+	getCmd.Flags().StringVarP(&getOutput, "output", "o", output.JSON, "output format: json or yaml")
+
+	showCmd.Flags().StringVar(&showSite, "site", "", "only consider inventories of this site")
+
+	rootCmd.AddCommand(listCmd, getCmd, showCmd)
+}
+
+// openStore opens the database named by the config file or the --database
+// flag.
+func openStore(cmd *cobra.Command) (*store.Store, error) {
+	cfg, err := loadConfig(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	open := store.New
+	if !cfg.AutoMigrate {
+		open = store.OpenCurrent
+	}
+	db, err := open(cfg.DatabasePath)
+	if err != nil {
+		return nil, fmt.Errorf("open database: %w", err)
+	}
+	db.PurgeBatches(cfg.PurgeBatchSize, cfg.PurgeBatchPause)
+	return db, nil
+}
+
+func runList(cmd *cobra.Command, _ []string) error {
+	if err := output.CheckList(listFlags.output); err != nil {
+		return err
+	}
+
+	filter := store.ListFilter{
+		Hostname:    listFlags.hostname,
+		Username:    listFlags.username,
+		SystemUUID:  listFlags.uuid,
+		DeviceClass: listFlags.class,
+		PageSize:    listFlags.pageSize,
+		Page:        listFlags.page,
+	}
+	if listFlags.site != "" {
+		filter.Sites = []string{listFlags.site}
+	}
+	if listFlags.after != "" {
+		t, err := parseTime(listFlags.after)
+		if err != nil {
+			return fmt.Errorf("--after: %w", err)
+		}
+		filter.CollectedAfter = &t
+	}
+	if listFlags.before != "" {
+		t, err := parseTime(listFlags.before)
+		if err != nil {
+			return fmt.Errorf("--before: %w", err)
+		}
+		filter.CollectedBefore = &t
+	}
+
+	db, err := openStore(cmd)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	records, total, err := db.List(context.Background(), filter)
+	if err != nil {
+		return err
+	}
+
+	summaries := make([]*collectorv1.InventorySummary, len(records))
+	for i := range records {
+		summaries[i] = convert.RecordToSummary(&records[i])
+	}
+	if err := output.List(os.Stdout, listFlags.output, summaries, output.InventoryColumns); err != nil {
+		return err
+	}
+	if total > len(records) {
+		fmt.Fprintf(os.Stderr, "showing %d of %d inventories (page %d)\n", len(records), total, listFlags.page)
+	}
+	return nil
+}
+
+func runGet(cmd *cobra.Command, args []string) error {
+	if getOutput != output.JSON && getOutput != output.YAML {
+		return fmt.Errorf("unknown output format %q (use json or yaml)", getOutput)
+	}
+	id, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil || id <= 0 {
+		return fmt.Errorf("invalid inventory ID %q", args[0])
+	}
+
+	db, err := openStore(cmd)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	rec, err := db.Get(context.Background(), id, nil)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("inventory %d not found", id)
+	}
+	if err != nil {
+		return fmt.Errorf("get inventory: %w", err)
+	}
+
+	// Round-trip through the proto so that the output matches the API.
+	inv, err := convert.RecordToInventory(rec)
+	if err != nil {
+		return err
+	}
+	return output.Message(os.Stdout, getOutput, inv)
+}
+
+func runShow(cmd *cobra.Command, args []string) error {
+	hostname := args[0]
+	var sites []string
+	if showSite != "" {
+		sites = []string{showSite}
+	}
+
+	db, err := openStore(cmd)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	rec, err := db.GetLatestByHostname(context.Background(), hostname, sites)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("no inventory found for hostname %q", hostname)
+	}
+	if err != nil {
+		return fmt.Errorf("get latest inventory: %w", err)
+	}
+	inv, err := convert.RecordToInventory(rec)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	row := func(label, value string) { fmt.Fprintf(w, "%s:\t%s\n", label, orDash(value)) }
+	row("Inventory", strconv.FormatInt(rec.ID, 10))
+	row("Hostname", rec.Hostname)
+	row("Site", rec.Site)
+	row("Username", rec.Username)
+	row("Collected", rec.CollectedAt.Local().Format(time.DateTime))
+	row("Stored", rec.StoredAt.Local().Format(time.DateTime))
+	if s := inv.System; s != nil {
+		row("System", strings.TrimSpace(s.Manufacturer+" "+s.ProductName))
+		row("Serial", s.SerialNumber)
+		row("UUID", s.Uuid)
+	}
+	if b := inv.Bios; b != nil {
+		bios := strings.TrimSpace(b.Vendor + " " + b.Version)
+		if b.ReleaseDate != "" {
+			bios += " (" + b.ReleaseDate + ")"
+		}
+		row("BIOS", bios)
+	}
+	for _, p := range inv.Processors {
+		if !p.SocketPopulated {
+			continue
+		}
+		row("Processor", fmt.Sprintf("%s, %d cores, %d threads", strings.TrimSpace(p.Version), p.CoreCount, p.ThreadCount))
+	}
+	if m := inv.Memory; m != nil {
+		row("Memory", fmt.Sprintf("%.1f GB in %d modules", m.TotalPhysicalGb, len(m.Modules)))
+	}
+	for _, mon := range inv.Monitor {
+		row("Monitor", strings.TrimSpace(mon.Manufacturer+" "+mon.Model))
+	}
+	return w.Flush()
+}
+
+func parseTime(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation(time.DateOnly, s, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q (use 2006-01-02 or RFC 3339)", s)
+	}
+	return t, nil
+}
+
+// orDash renders empty table cells as "-".
+func orDash(s string) string {
+	if strings.TrimSpace(s) == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/spf13/cobra"
+
+	"github.com/go-tangra/go-tangra-inventory/internal/report"
+	"github.com/go-tangra/go-tangra-inventory/internal/server"
+	"github.com/go-tangra/go-tangra-inventory/internal/store"
+)
+
+var reportsCmd = &cobra.Command{
+	Use:   "reports",
+	Short: "Send or render the email reports",
+}
+
+var reportsSendCmd = &cobra.Command{
+	Use:   "send [name...]",
+	Short: "Mail the email reports now",
+	Long: `Mail the email reports configured in email_reports with these names, or
+all of them, to their recipients now instead of at their scheduled time.`,
+	RunE: runReportsSend,
+}
+
+var reportsRenderDir string
+
+var reportsRenderCmd = &cobra.Command{
+	Use:   "render <name>",
+	Short: "Write an email report as HTML and CSV files",
+	Long: `Build the email report configured in email_reports with this name and
+write the message body as report.html, and each table as the CSV file it
+would be attached as, to --dir, without mailing it.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runReportsRender,
+}
+
+func init() {
+	reportsRenderCmd.Flags().StringVar(&reportsRenderDir, "dir", ".", "directory to write the files to")
+
+	reportsCmd.AddCommand(reportsSendCmd, reportsRenderCmd)
+	rootCmd.AddCommand(reportsCmd)
+}
+
+// reportJobs opens the store and returns the configured SMTP server and the
+// report jobs with the given names, or all of them. The returned function
+// closes the store.
+func reportJobs(cmd *cobra.Command, names []string) (*store.Store, *report.SMTP, []report.Job, func(), error) {
+	cfg, err := loadConfig(cmd)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	smtp, jobs, err := server.NewReportJobs(cfg)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	if len(jobs) == 0 {
+		return nil, nil, nil, nil, errors.New("email_reports is not configured")
+	}
+	if len(names) > 0 {
+		var selected []report.Job
+		for _, name := range names {
+			i := slices.IndexFunc(jobs, func(j report.Job) bool { return j.Spec.Name == name })
+			if i < 0 {
+				return nil, nil, nil, nil, fmt.Errorf("no email report named %q", name)
+			}
+			selected = append(selected, jobs[i])
+		}
+		jobs = selected
+	}
+
+	db, err := openStore(cmd)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	return db, smtp, jobs, func() { db.Close() }, nil
+}
+
+func runReportsSend(cmd *cobra.Command, args []string) error {
+	db, smtp, jobs, done, err := reportJobs(cmd, args)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	for _, job := range jobs {
+		if err := report.Mail(context.Background(), smtp, db, job); err != nil {
+			return fmt.Errorf("%s: %w", job.Spec.Name, err)
+		}
+		fmt.Printf("Sent %s to %d recipients\n", job.Spec.Name, len(job.To))
+	}
+	return nil
+}
+
+func runReportsRender(cmd *cobra.Command, args []string) error {
+	db, _, jobs, done, err := reportJobs(cmd, args)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	r, err := report.Build(context.Background(), db, jobs[0].Spec)
+	if err != nil {
+		return err
+	}
+	html, err := r.HTML()
+	if err != nil {
+		return err
+	}
+	write := func(name string, data []byte) error {
+		path := filepath.Join(reportsRenderDir, name)
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			return err
+		}
+		fmt.Println("Wrote", path)
+		return nil
+	}
+	if err := write("report.html", html); err != nil {
+		return err
+	}
+	for i := range r.Tables {
+		data, err := r.Tables[i].CSV()
+		if err != nil {
+			return err
+		}
+		if err := write(r.Tables[i].File, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
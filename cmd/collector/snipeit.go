@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/go-tangra/go-tangra-inventory/internal/server"
+)
+
+var snipeITCmd = &cobra.Command{
+	Use:   "snipeit",
+	Short: "Synchronize assets to Snipe-IT",
+}
+
+var snipeITDryRun bool
+
+var snipeITSyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Create or update the Snipe-IT assets of the latest inventories now",
+	Long: `Create or update a Snipe-IT asset for the latest inventory of every host
+of the sites configured in snipeit_sites, as the collector does every
+snipeit_interval. Assets are matched by serial number; hosts without a
+usable serial number or matching several assets are skipped and logged.
+
+--dry-run looks the assets up and reports what would be created or updated
+without writing to Snipe-IT.`,
+	Args: cobra.NoArgs,
+	RunE: runSnipeITSync,
+}
+
+func init() {
+	snipeITSyncCmd.Flags().BoolVar(&snipeITDryRun, "dry-run", false, "report what would change without writing to Snipe-IT")
+
+	snipeITCmd.AddCommand(snipeITSyncCmd)
+	rootCmd.AddCommand(snipeITCmd)
+}
+
+func runSnipeITSync(cmd *cobra.Command, _ []string) error {
+	cfg, err := loadConfig(cmd)
+	if err != nil {
+		return err
+	}
+	if cfg.SnipeITURL == "" {
+		return errors.New("snipeit_url is not configured")
+	}
+	db, err := openStore(cmd)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	syncer, err := server.NewSnipeITSyncer(cfg, db)
+	if err != nil {
+		return err
+	}
+	res, err := syncer.Sync(context.Background(), snipeITDryRun)
+	if err != nil {
+		return err
+	}
+	verb := "Synchronized"
+	if snipeITDryRun {
+		verb = "Would synchronize"
+	}
+	fmt.Printf("%s Snipe-IT assets: %d created, %d updated, %d unchanged, %d skipped\n", verb, res.Created, res.Updated, res.Unchanged, res.Skipped)
+	return nil
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/go-tangra/go-tangra-inventory/internal/server"
+)
+
+var snmpCmd = &cobra.Command{
+	Use:   "snmp",
+	Short: "Inventory network devices over SNMP",
+}
+
+var snmpPollCmd = &cobra.Command{
+	Use:   "poll",
+	Short: "Poll the SNMP targets now",
+	Long: `Poll every device in snmp_targets, as the collector does every
+snmp_interval, and store an inventory of each device that answers: its
+sysName, sysDescr, manufacturer, model and serial number (from ENTITY-MIB,
+Printer-MIB or UPS-MIB) under the switch, printer, ups or network device
+class. Inventories stored this way do not raise alerts or events.`,
+	Args: cobra.NoArgs,
+	RunE: runSNMPPoll,
+}
+
+func init() {
+	snmpCmd.AddCommand(snmpPollCmd)
+	rootCmd.AddCommand(snmpCmd)
+}
+
+func runSNMPPoll(cmd *cobra.Command, _ []string) error {
+	cfg, err := loadConfig(cmd)
+	if err != nil {
+		return err
+	}
+	if len(cfg.SNMPTargets) == 0 {
+		return errors.New("snmp_targets is not configured")
+	}
+	db, err := openStore(cmd)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	poller, err := server.NewSNMPPoller(cfg, server.NewHandler(db, server.NewCommandRegistry(), nil, nil))
+	if err != nil {
+		return err
+	}
+	res, err := poller.Poll(context.Background())
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Polled %d devices, %d failed\n", res.Polled, res.Failed)
+	return nil
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/go-tangra/go-tangra-inventory/internal/cache"
+	"github.com/go-tangra/go-tangra-inventory/internal/collector"
+	"github.com/go-tangra/go-tangra-inventory/internal/diff"
+	"github.com/go-tangra/go-tangra-inventory/internal/sender"
+)
+
+// defaultCachePath returns where the last submitted inventory is kept: next
+// to the spool under ProgramData on Windows, /var/lib elsewhere.
+func defaultCachePath() string {
+	if runtime.GOOS == "windows" {
+		dir := os.Getenv("ProgramData")
+		if dir == "" {
+			dir = `C:\ProgramData`
+		}
+		return filepath.Join(dir, "Tangra Inventory", "last-sent.json")
+	}
+	return "/var/lib/tangra-inventory/last-sent.json"
+}
+
+// runDiff is the "inventory diff" subcommand: it collects the inventory and
+// prints the hardware changes since the one cached at path.
+func runDiff(path string) error {
+	if path == "" {
+		return errors.New("no -cache file configured")
+	}
+	prev, err := cache.Load(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("no inventory has been submitted yet (%s does not exist)", path)
+	}
+	if err != nil {
+		return err
+	}
+
+	cur, collectErr := collector.Collect()
+	if collectErr != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", collectErr)
+	}
+
+	changes := diff.Compare(sender.ToProto(prev), sender.ToProto(cur))
+	since := prev.CollectedAt.Local().Format(time.DateTime)
+	if len(changes) == 0 {
+		fmt.Printf("No hardware changes since the inventory submitted on %s.\n", since)
+		return nil
+	}
+	fmt.Printf("Hardware changes since the inventory submitted on %s:\n", since)
+	for _, c := range changes {
+		fmt.Printf("  %s\n", c)
+	}
+	return nil
+}
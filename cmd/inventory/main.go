@@ -2,10 +2,9 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"flag"
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
 	"os/signal"
 	"path/filepath"
@@ -13,52 +12,230 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/go-tangra/go-tangra-inventory/internal/cache"
 	"github.com/go-tangra/go-tangra-inventory/internal/collector"
+	"github.com/go-tangra/go-tangra-inventory/internal/compression"
 	"github.com/go-tangra/go-tangra-inventory/internal/daemon"
+	"github.com/go-tangra/go-tangra-inventory/internal/logging"
+	"github.com/go-tangra/go-tangra-inventory/internal/output"
+	"github.com/go-tangra/go-tangra-inventory/internal/schedule"
 	"github.com/go-tangra/go-tangra-inventory/internal/sender"
+	"github.com/go-tangra/go-tangra-inventory/internal/spool"
+	"github.com/go-tangra/go-tangra-inventory/internal/update"
 	"github.com/go-tangra/go-tangra-inventory/internal/winsvc"
 )
 
 // Set via ldflags.
 var version = "dev"
 
-const serviceName = "TangraInventoryAgent"
+const (
+	serviceName = "TangraInventoryAgent"
+	taskName    = "Tangra Inventory Agent"
+)
 
 func main() {
-	outputDir := flag.String("o", "", "directory path to save inventory JSON (filename: HOSTNAME-DATE-TIME.json)")
-	collectorAddr := flag.String("collector", "", "inventory collector gRPC address (e.g. 192.168.1.10:9550)")
+	outputDir := flag.String("o", "", "directory path to save the inventory to (filename: HOSTNAME-DATE-TIME.EXT)")
+	format := flag.String("format", output.JSON, "one-shot output format: json, yaml, csv or table")
+	collectorAddr := flag.String("collector", "", "inventory collector gRPC address (e.g. 192.168.1.10:9550) or srv:NAME to discover it via DNS SRV records; a comma-separated list is tried in order for failover")
 	collectorSecret := flag.String("secret", "", "client secret for collector authentication")
+	site := flag.String("site", "", "site/tenant this host belongs to (optional when the secret is bound to one site)")
+	useTLS := flag.Bool("tls", false, "connect to the collector over TLS")
+	caCert := flag.String("ca-cert", "", "PEM CA bundle to verify the collector certificate with instead of the system roots (implies -tls)")
+	pinSHA256 := flag.String("pin-sha256", "", "comma-separated SHA-256 pins (hex or base64) of accepted collector public keys (implies -tls)")
+	clientCert := flag.String("client-cert", "", "PEM client certificate for collectors that require mutual TLS (implies -tls; needs -client-key)")
+	clientKey := flag.String("client-key", "", "PEM private key of -client-cert")
+	proxyURL := flag.String("proxy", "", "proxy for the collector connection: http://, https:// or socks5://[user:pass@]host:port (default: HTTPS_PROXY; \"none\" = direct)")
+	compressionName := flag.String("compression", compression.Zstd, "compression for submissions: gzip, zstd or none")
+	maxUploadRate := flag.String("max-upload-rate", "", "cap the upload rate to the collector in bytes per second, e.g. 256k or 1m (empty = unlimited)")
+	cachePath := flag.String("cache", defaultCachePath(), "file to keep a copy of the last inventory submitted to the collector in, for \"diff\" (empty = disabled)")
+	spoolDir := flag.String("spool", "", "directory to keep inventories in while the collector is unreachable; they are replayed oldest first once it is back (empty = disabled)")
+	skipUnchanged := flag.Bool("skip-unchanged", false, "daemon mode: skip scheduled submissions when the inventory has not changed since the last one")
+	maxUnchanged := flag.Duration("max-unchanged", 7*24*time.Hour, "with -skip-unchanged: submit an unchanged inventory anyway after this long (0 = never)")
+	dryRun := flag.Bool("dry-run", false, "collect and validate the inventory and print what would be sent, without contacting the collector (overrides -daemon)")
 	daemonMode := flag.Bool("daemon", false, "run in daemon mode: stay connected and accept refresh commands")
-	serviceAction := flag.String("service", "", "Windows service action: install or uninstall")
+	interval := flag.Duration("interval", 0, "daemon mode: also re-collect periodically, e.g. 12h, with ±10% jitter (0 = only on refresh commands)")
+	jitter := flag.Duration("jitter", 0, "random delay of up to this long before submitting, e.g. 30m, to spread load across agents")
+	windows := flag.String("window", "", "daemon mode: only submit within these local-time windows, e.g. 01:00-05:00[,13:00-14:00] (refresh commands are always answered)")
+	backoffBase := flag.Duration("backoff-base", daemon.DefaultBackoffBase, "daemon mode: delay before the first reconnect once every collector failed; it doubles per failed round")
+	backoffMax := flag.Duration("backoff-max", daemon.DefaultBackoffMax, "daemon mode: maximum reconnect delay")
+	backoffJitter := flag.Bool("backoff-jitter", true, "daemon mode: wait a random time up to the reconnect delay, so that agents do not reconnect in lockstep after a collector restart")
+	maxRetries := flag.Int("max-retries", 0, "daemon mode: exit with an error after this many consecutive failed reconnect rounds (0 = retry forever)")
+	allowCommands := flag.String("allow-commands", "", "daemon mode: comma-separated command types the agent executes: refresh, update, pause, resume, logs, ping (empty = all)")
+	updateKey := flag.String("update-key", "", "daemon mode: base64 Ed25519 public key; enables self-update to releases signed with it")
+	statusAddr := flag.String("status-addr", "", "daemon mode: serve agent status as JSON on http://ADDR/status; must be a loopback address, e.g. 127.0.0.1:9555 (empty = disabled)")
+	stateFile := flag.String("state", "", "daemon mode: file to keep agent state in, such as a pause by the collector, across restarts (empty = not persisted)")
+	logLevel := flag.String("log-level", "info", "log level: debug, info, warn or error")
+	logFormat := flag.String("log-format", logging.FormatText, "log format: text or json")
+	logBuffer := flag.Int("log-buffer", 256, "daemon mode: KiB of recent log output to keep in memory for the collector's logs command (0 = none)")
+	serviceAction := flag.String("service", "", "Windows service action: install, uninstall, install-task or uninstall-task (a daily scheduled task instead of the always-on service)")
+	taskTime := flag.String("task-time", "03:00", "with -service install-task: local time of day to run the task, HH:MM")
+	// "inventory verify|diff [flags]" runs a subcommand instead of
+	// collecting and submitting.
+	var subcommand string
+	if len(os.Args) > 1 && (os.Args[1] == "verify" || os.Args[1] == "diff") {
+		subcommand = os.Args[1]
+		os.Args = append(os.Args[:1], os.Args[2:]...)
+	}
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), `usage: %s [verify|diff] [flags]
+
+verify runs every collection module and checks the collector connection and
+credentials, exiting non-zero on problems. diff prints the hardware changes
+since the last inventory submitted to the collector.
+
+flags:
+`, filepath.Base(os.Args[0]))
+		flag.PrintDefaults()
+	}
 	flag.Parse()
 
+	if subcommand == "diff" {
+		if err := runDiff(*cachePath); err != nil {
+			fmt.Fprintf(os.Stderr, "error: diff: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	logOpts := logging.Options{Level: *logLevel, Format: *logFormat}
+	if err := logOpts.Setup(); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+
+	ext, err := output.Ext(*format)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: -format: %v\n", err)
+		os.Exit(1)
+	}
+
 	// Service install/uninstall actions.
 	if *serviceAction != "" {
-		if err := handleServiceAction(*serviceAction, *collectorAddr, *collectorSecret); err != nil {
+		if err := handleServiceAction(*serviceAction, *collectorAddr, *taskTime); err != nil {
 			fmt.Fprintf(os.Stderr, "error: service %s: %v\n", *serviceAction, err)
 			os.Exit(1)
 		}
 		return
 	}
 
+	tlsCfg := sender.TLS{
+		Enabled:  *useTLS || *caCert != "" || *pinSHA256 != "" || *clientCert != "",
+		CAFile:   *caCert,
+		Pins:     strings.FieldsFunc(*pinSHA256, func(r rune) bool { return r == ',' }),
+		CertFile: *clientCert,
+		KeyFile:  *clientKey,
+	}
+
+	maxRate, err := sender.ParseRate(*maxUploadRate)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: -max-upload-rate: %v\n", err)
+		os.Exit(1)
+	}
+
+	var sp *spool.Spool
+	if *spoolDir != "" {
+		var err error
+		if sp, err = spool.New(*spoolDir); err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	sendOpts := sender.Options{
+		Secret:      *collectorSecret,
+		Site:        *site,
+		Compression: *compressionName,
+		TLS:         tlsCfg,
+		Proxy:       *proxyURL,
+		MaxRate:     maxRate,
+	}
+
+	if subcommand == "verify" {
+		if !runVerify(*collectorAddr, sendOpts) {
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Daemon mode: requires -collector, stays connected via streaming.
-	if *daemonMode {
+	if *daemonMode && !*dryRun {
 		if *collectorAddr == "" {
 			fmt.Fprintln(os.Stderr, "error: -collector is required in daemon mode")
 			os.Exit(1)
 		}
 
+		submitWindows, err := schedule.ParseWindows(*windows)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: -window: %v\n", err)
+			os.Exit(1)
+		}
+
+		allowedCommands, err := daemon.ParseCommands(*allowCommands)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: -allow-commands: %v\n", err)
+			os.Exit(1)
+		}
+
+		var updater *update.Updater
+		if *updateKey != "" {
+			key, err := update.ParseKey(*updateKey)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "error: -update-key: %v\n", err)
+				os.Exit(1)
+			}
+			updater = &update.Updater{Key: key, Version: version, Proxy: *proxyURL}
+		}
+
+		// The system UUID survives renames and tells apart machines that
+		// share a hostname.
 		hostname, _ := os.Hostname()
+		clientID := collector.SystemUUID()
+		if clientID == "" {
+			clientID = hostname
+		}
 		daemonCfg := daemon.Config{
-			CollectorAddr: *collectorAddr,
-			ClientSecret:  *collectorSecret,
-			ClientID:      hostname,
-			Version:       version,
+			Collectors:      sender.ParseAddrs(*collectorAddr),
+			ClientSecret:    *collectorSecret,
+			ClientID:        clientID,
+			Hostname:        hostname,
+			Site:            *site,
+			Compression:     *compressionName,
+			TLS:             tlsCfg,
+			Proxy:           *proxyURL,
+			MaxRate:         maxRate,
+			Version:         version,
+			Interval:        *interval,
+			Schedule:        schedule.Policy{Jitter: *jitter, Windows: submitWindows},
+			Spool:           sp,
+			SkipUnchanged:   *skipUnchanged,
+			MaxUnchanged:    *maxUnchanged,
+			Updater:         updater,
+			StatusAddr:      *statusAddr,
+			StateFile:       *stateFile,
+			Cache:           *cachePath,
+			AllowedCommands: allowedCommands,
+			Backoff: daemon.Backoff{
+				Base:       *backoffBase,
+				Max:        *backoffMax,
+				Jitter:     *backoffJitter,
+				MaxRetries: *maxRetries,
+			},
+		}
+		if *logBuffer > 0 {
+			daemonCfg.Logs = logging.NewRing(*logBuffer << 10)
+		}
+		// Keep the recent log output for logs commands, alongside the event
+		// log in service mode. The options were validated by Setup.
+		captureLogs := func() {
+			if daemonCfg.Logs != nil {
+				_ = logOpts.Capture(daemonCfg.Logs)
+			}
 		}
 
 		// Windows service mode.
 		if winsvc.IsWindowsService() {
-			winsvc.SetupEventLog(serviceName)
+			winsvc.SetupEventLog(serviceName, logOpts)
+			captureLogs()
 			if err := winsvc.RunService(serviceName, func(ctx context.Context) error {
 				return daemon.Run(ctx, daemonCfg)
 			}); err != nil {
@@ -72,7 +249,10 @@ func main() {
 		ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 		defer stop()
 
+		captureLogs()
 		if err := daemon.Run(ctx, daemonCfg); err != nil {
+			// A self-update also exits non-zero so that a supervisor
+			// restarts the agent on the new executable.
 			fmt.Fprintf(os.Stderr, "error: daemon: %v\n", err)
 			os.Exit(1)
 		}
@@ -80,23 +260,43 @@ func main() {
 	}
 
 	// One-shot mode (original behavior).
-	inv, err := collector.Collect()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+	inv, collectErr := collector.Collect()
+	if collectErr != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", collectErr)
 	}
 
 	// Send to collector if address is provided.
-	if *collectorAddr != "" {
-		id, err := sender.Send(context.Background(), *collectorAddr, *collectorSecret, inv)
+	if *collectorAddr != "" && !*dryRun {
+		if delay := (schedule.Policy{Jitter: *jitter}).Delay(time.Now(), 0); delay > 0 {
+			fmt.Fprintf(os.Stderr, "waiting %s before submitting (jitter)\n", delay.Round(time.Second))
+			time.Sleep(delay)
+		}
+
+		var id int64
+		var addr string
+		spooled, err := sp.Submit(context.Background(), inv, func(ctx context.Context, inv *collector.Inventory) error {
+			var err error
+			id, addr, err = sender.SendFailover(ctx, sender.ParseAddrs(*collectorAddr), sendOpts, inv)
+			return err
+		})
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "error: sending to collector: %v\n", err)
 			os.Exit(1)
 		}
-		fmt.Fprintf(os.Stderr, "inventory submitted to %s (id: %d)\n", *collectorAddr, id)
+		if spooled {
+			fmt.Fprintf(os.Stderr, "collector %s unreachable; inventory spooled to %s\n", *collectorAddr, *spoolDir)
+		} else {
+			fmt.Fprintf(os.Stderr, "inventory submitted to %s (id: %d)\n", addr, id)
+			if *cachePath != "" {
+				if err := cache.Save(*cachePath, inv); err != nil {
+					fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+				}
+			}
+		}
 	}
 
 	// Write to file or stdout (skip if collector-only mode with no -o).
-	if *collectorAddr != "" && *outputDir == "" {
+	if *collectorAddr != "" && *outputDir == "" && !*dryRun {
 		return
 	}
 
@@ -114,12 +314,12 @@ func main() {
 		}
 		hostname = strings.ReplaceAll(hostname, string(os.PathSeparator), "_")
 		timestamp := time.Now().Format("20060102-150405")
-		filename := fmt.Sprintf("%s-%s.json", hostname, timestamp)
+		filename := fmt.Sprintf("%s-%s.%s", hostname, timestamp, ext)
 		user, err := collector.GetUserInfo()
 		if err != nil {
-			fmt.Printf("warning: cannot get user info: %v\n", err)
+			fmt.Fprintf(os.Stderr, "warning: cannot get user info: %v\n", err)
 		} else {
-			filename = fmt.Sprintf("%s-%s.json", user, timestamp)
+			filename = fmt.Sprintf("%s-%s.%s", user, timestamp, ext)
 		}
 		outputPath = filepath.Join(*outputDir, filename)
 
@@ -134,9 +334,7 @@ func main() {
 		w = os.Stdout
 	}
 
-	enc := json.NewEncoder(w)
-	enc.SetIndent("", "  ")
-	if err := enc.Encode(inv); err != nil {
+	if err := output.Write(w, inv, *format); err != nil {
 		fmt.Fprintf(os.Stderr, "error: encoding inventory: %v\n", err)
 		os.Exit(1)
 	}
@@ -144,9 +342,45 @@ func main() {
 	if outputPath != "" {
 		fmt.Fprintf(os.Stderr, "inventory written to %s\n", outputPath)
 	}
+
+	if *dryRun {
+		if !reportDryRun(*collectorAddr, sendOpts, inv, collectErr) {
+			os.Exit(1)
+		}
+	}
+}
+
+// reportDryRun prints to stderr what a submission of inv would send and
+// which modules failed. It reports false if the submission would fail.
+func reportDryRun(addr string, opts sender.Options, inv *collector.Inventory, collectErr error) bool {
+	if addr == "" {
+		addr = "(no -collector set)"
+	}
+	fmt.Fprintln(os.Stderr, "dry run: nothing was sent")
+	fmt.Fprintf(os.Stderr, "  collector:      %s\n", addr)
+	fmt.Fprintf(os.Stderr, "  hostname:       %s\n", inv.Hostname)
+
+	failed := collector.FailedModules(collectErr)
+	if len(failed) == 0 {
+		fmt.Fprintf(os.Stderr, "  failed modules: none\n")
+	} else {
+		fmt.Fprintf(os.Stderr, "  failed modules: %s\n", strings.Join(failed, ", "))
+	}
+
+	size, compressed, err := sender.Preview(opts, inv)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "  validation:     FAILED: %v\n", err)
+		return false
+	}
+	fmt.Fprintf(os.Stderr, "  payload:        %d bytes", size)
+	if opts.Compression != "" && opts.Compression != compression.None {
+		fmt.Fprintf(os.Stderr, " (%d bytes with %s)", compressed, opts.Compression)
+	}
+	fmt.Fprintf(os.Stderr, "\n  validation:     ok\n")
+	return true
 }
 
-func handleServiceAction(action, collectorAddr, secret string) error {
+func handleServiceAction(action, collectorAddr, taskTime string) error {
 	switch action {
 	case "install":
 		if collectorAddr == "" {
@@ -156,27 +390,77 @@ func handleServiceAction(action, collectorAddr, secret string) error {
 		if err != nil {
 			return err
 		}
-		args := []string{"-collector", collectorAddr, "-secret", secret, "-daemon"}
 		if err := winsvc.Install(
 			serviceName,
 			"Tangra Inventory Agent",
 			"Collects hardware inventory and streams commands from the collector.",
 			exePath,
-			args,
+			serviceArgs(),
 		); err != nil {
 			return err
 		}
-		log.Printf("Service %s installed successfully", serviceName)
+		slog.Info("Service installed successfully", "service", serviceName)
 		return nil
 
 	case "uninstall":
 		if err := winsvc.Uninstall(serviceName); err != nil {
 			return err
 		}
-		log.Printf("Service %s uninstalled successfully", serviceName)
+		slog.Info("Service uninstalled successfully", "service", serviceName)
+		return nil
+
+	case "install-task":
+		if collectorAddr == "" {
+			return fmt.Errorf("-collector is required for task install")
+		}
+		at, err := time.Parse("15:04", taskTime)
+		if err != nil {
+			return fmt.Errorf("invalid -task-time %q (use HH:MM)", taskTime)
+		}
+		exePath, err := winsvc.ExePath()
+		if err != nil {
+			return err
+		}
+		if err := winsvc.InstallTask(
+			taskName,
+			"Collects hardware inventory daily and submits it to the collector.",
+			exePath,
+			installArgs(),
+			at.Format("15:04"),
+		); err != nil {
+			return err
+		}
+		slog.Info("Scheduled task installed successfully", "task", taskName, "at", at.Format("15:04"))
+		return nil
+
+	case "uninstall-task":
+		if err := winsvc.UninstallTask(taskName); err != nil {
+			return err
+		}
+		slog.Info("Scheduled task uninstalled successfully", "task", taskName)
 		return nil
 
 	default:
-		return fmt.Errorf("unknown service action %q (use install or uninstall)", action)
+		return fmt.Errorf("unknown service action %q (use install, uninstall, install-task or uninstall-task)", action)
 	}
 }
+
+// serviceArgs returns the command line of the installed service: daemon mode
+// plus the flags given at install time.
+func serviceArgs() []string {
+	return append([]string{"-daemon"}, installArgs()...)
+}
+
+// installArgs returns every flag given explicitly at install time, except
+// those that only make sense interactively.
+func installArgs() []string {
+	var args []string
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "service", "task-time", "daemon", "o", "dry-run":
+			return
+		}
+		args = append(args, "-"+f.Name+"="+f.Value.String())
+	})
+	return args
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/go-tangra/go-tangra-inventory/internal/collector"
+	"github.com/go-tangra/go-tangra-inventory/internal/compression"
+	"github.com/go-tangra/go-tangra-inventory/internal/sender"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// runVerify is the "inventory verify" self-test: it runs every collection
+// module, validates the resulting submission and checks connectivity and
+// credentials against each collector, printing the results to stdout. It
+// reports false if anything failed.
+func runVerify(collectorAddrs string, opts sender.Options) bool {
+	ok := true
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	defer w.Flush()
+
+	inv, _ := collector.Collect()
+	fmt.Fprintln(w, "MODULE\tRESULT\tDURATION\tERROR")
+	for _, m := range inv.Telemetry.Modules {
+		result := "ok"
+		if m.Error != "" {
+			result, ok = "FAILED", false
+		}
+		fmt.Fprintf(w, "%s\t%s\t%dms\t%s\n", m.Name, result, m.DurationMs, m.Error)
+	}
+	fmt.Fprintf(w, "collection\t\t%dms\t\n", inv.Telemetry.DurationMs)
+	w.Flush()
+
+	fmt.Println()
+	size, compressed, err := sender.Preview(opts, inv)
+	if err != nil {
+		fmt.Printf("payload:   FAILED: %v\n", err)
+		ok = false
+	} else if opts.Compression != "" && opts.Compression != compression.None {
+		fmt.Printf("payload:   ok, %d bytes (%d bytes with %s)\n", size, compressed, opts.Compression)
+	} else {
+		fmt.Printf("payload:   ok, %d bytes\n", size)
+	}
+
+	if collectorAddrs == "" {
+		fmt.Println("collector: not checked (no -collector set)")
+		return ok
+	}
+
+	ctx := context.Background()
+	addrs, err := sender.Resolve(ctx, sender.ParseAddrs(collectorAddrs))
+	if err != nil {
+		fmt.Printf("collector: FAILED: %v\n", err)
+		return false
+	}
+	for _, addr := range addrs {
+		start := time.Now()
+		site, err := sender.Check(ctx, addr, opts)
+		elapsed := time.Since(start).Milliseconds()
+		switch {
+		case status.Code(err) == codes.Unimplemented:
+			fmt.Printf("collector: %s reachable in %dms; credentials not checked (collector too old)\n", addr, elapsed)
+		case err != nil:
+			fmt.Printf("collector: %s FAILED: %v\n", addr, err)
+			ok = false
+		default:
+			fmt.Printf("collector: %s ok in %dms (site %q)\n", addr, elapsed, site)
+		}
+	}
+	return ok
+}
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+	"github.com/go-tangra/go-tangra-inventory/internal/output"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	agentsSite  string
+	agentsWatch bool
+)
+
+var agentsCmd = &cobra.Command{
+	Use:   "agents",
+	Short: "List the agents connected to the collector",
+	Long: `List the agents connected to the collector.
+
+With --watch, keep following them: on a terminal a table of the connected
+agents with their version, stream uptime and last submission is refreshed
+continuously, with the latest connects, disconnects and submissions below
+it. Otherwise each event is printed as it happens, as a line of text or,
+with -o json or yaml, as a document.`,
+	Args: cobra.NoArgs,
+	RunE: runAgents,
+}
+
+var refreshFlags struct {
+	site        string
+	clientID    string
+	all         bool
+	wait        bool
+	waitTimeout time.Duration
+}
+
+var refreshCmd = &cobra.Command{
+	Use:   "refresh [hostname|uuid]",
+	Short: "Ask connected agents to collect and submit their inventory now",
+	Long: `Ask connected agents to collect and submit their inventory now. A single
+agent is selected by hostname or by client ID (system UUID); --all selects
+every connected agent, optionally limited to one --site.
+
+With --wait, refresh waits for each agent's new inventory to be stored and
+prints its ID.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runRefresh,
+}
+
+func init() {
+	agentsCmd.Flags().StringVar(&agentsSite, "site", "", "only agents of this site")
+	agentsCmd.Flags().BoolVarP(&agentsWatch, "watch", "w", false, "follow connects, disconnects and submissions")
+
+	f := refreshCmd.Flags()
+	f.StringVar(&refreshFlags.site, "site", "", "site of the agent, or of the agents with --all")
+	f.StringVar(&refreshFlags.clientID, "client-id", "", "client ID (system UUID) of the agent")
+	f.BoolVar(&refreshFlags.all, "all", false, "refresh every connected agent")
+	f.BoolVar(&refreshFlags.wait, "wait", false, "wait for the new inventories to arrive")
+	f.DurationVar(&refreshFlags.waitTimeout, "wait-timeout", 2*time.Minute, "how long to wait with --wait")
+
+	rootCmd.AddCommand(agentsCmd, refreshCmd)
+}
+
+func runAgents(cmd *cobra.Command, _ []string) error {
+	if agentsWatch {
+		return watchAgents(cmd)
+	}
+	ctx, client, done, err := adminClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	resp, err := client.ListConnectedAgents(ctx, &collectorv1.ListConnectedAgentsRequest{})
+	if err != nil {
+		return fmt.Errorf("list agents: %w", err)
+	}
+
+	agents := resp.Agents
+	if agentsSite != "" {
+		agents = slices.DeleteFunc(agents, func(a *collectorv1.ConnectedAgent) bool { return a.Site != agentsSite })
+	}
+	slices.SortFunc(agents, func(a, b *collectorv1.ConnectedAgent) int {
+		return strings.Compare(a.Site+"/"+a.Hostname, b.Site+"/"+b.Hostname)
+	})
+
+	return output.List(os.Stdout, outputFormat, agents, output.AgentColumns)
+}
+
+// refreshTarget identifies an agent to refresh. latestID is the ID of its
+// newest stored inventory before the refresh, 0 if none.
+type refreshTarget struct {
+	hostname string
+	site     string
+	clientID string
+	latestID int64
+}
+
+func (t *refreshTarget) String() string {
+	if t.hostname != "" {
+		return t.hostname
+	}
+	return t.clientID
+}
+
+func runRefresh(cmd *cobra.Command, args []string) error {
+	var targets []*refreshTarget
+	switch {
+	case refreshFlags.all:
+		if len(args) > 0 || refreshFlags.clientID != "" {
+			return fmt.Errorf("--all cannot be combined with a hostname or --client-id")
+		}
+	case len(args) > 0:
+		t := &refreshTarget{site: refreshFlags.site, clientID: refreshFlags.clientID}
+		if _, err := uuid.Parse(args[0]); err == nil && t.clientID == "" {
+			t.clientID = args[0]
+		} else {
+			t.hostname = args[0]
+		}
+		targets = append(targets, t)
+	case refreshFlags.clientID != "":
+		targets = append(targets, &refreshTarget{site: refreshFlags.site, clientID: refreshFlags.clientID})
+	default:
+		return fmt.Errorf("a hostname, UUID, --client-id or --all is required")
+	}
+
+	if refreshFlags.wait {
+		// --timeout bounds the whole command; leave room for the wait.
+		timeout += refreshFlags.waitTimeout
+	}
+
+	ctx, admin, done, err := adminClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	if refreshFlags.all {
+		resp, err := admin.ListConnectedAgents(ctx, &collectorv1.ListConnectedAgentsRequest{})
+		if err != nil {
+			return fmt.Errorf("list agents: %w", err)
+		}
+		for _, a := range resp.Agents {
+			if refreshFlags.site == "" || a.Site == refreshFlags.site {
+				targets = append(targets, &refreshTarget{hostname: a.Hostname, site: a.Site, clientID: a.ClientId})
+			}
+		}
+		if len(targets) == 0 {
+			return fmt.Errorf("no connected agents")
+		}
+	}
+
+	var (
+		cctx      context.Context
+		collector collectorv1.InventoryCollectorServiceClient
+	)
+	if refreshFlags.wait {
+		var cdone func()
+		cctx, collector, cdone, err = collectorClient(cmd)
+		if err != nil {
+			return err
+		}
+		defer cdone()
+		for _, t := range targets {
+			if t.latestID, err = latestInventoryID(cctx, collector, t); err != nil {
+				return err
+			}
+		}
+	}
+
+	var failed int
+	sent := targets[:0]
+	for _, t := range targets {
+		resp, err := admin.RefreshInventory(ctx, &collectorv1.RefreshInventoryRequest{
+			Hostname: t.hostname,
+			Site:     t.site,
+			ClientId: t.clientID,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: refresh: %v\n", t, err)
+			failed++
+			continue
+		}
+		if !refreshFlags.wait {
+			fmt.Printf("%s: refresh command %s sent\n", t, resp.CommandId)
+		}
+		sent = append(sent, t)
+	}
+
+	if refreshFlags.wait {
+		failed += waitForInventories(cctx, collector, sent)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d agents failed", failed, len(targets))
+	}
+	return nil
+}
+
+// refreshPollInterval is how often --wait checks for new inventories.
+const refreshPollInterval = 2 * time.Second
+
+// waitForInventories polls until every target has stored an inventory newer
+// than its latestID, printing each new ID, and returns the number of targets
+// that did not report within --wait-timeout.
+func waitForInventories(ctx context.Context, client collectorv1.InventoryCollectorServiceClient, targets []*refreshTarget) int {
+	deadline := time.Now().Add(refreshFlags.waitTimeout)
+	pending := targets
+	for len(pending) > 0 && time.Now().Before(deadline) {
+		time.Sleep(refreshPollInterval)
+		remaining := pending[:0]
+		for _, t := range pending {
+			id, err := latestInventoryID(ctx, client, t)
+			if err != nil || id <= t.latestID {
+				remaining = append(remaining, t)
+				continue
+			}
+			fmt.Printf("%s: inventory %d\n", t, id)
+		}
+		pending = remaining
+	}
+	for _, t := range pending {
+		fmt.Fprintf(os.Stderr, "%s: no new inventory within %s\n", t, refreshFlags.waitTimeout)
+	}
+	return len(pending)
+}
+
+// latestInventoryID returns the ID of the newest inventory stored for t, or
+// 0 if there is none.
+func latestInventoryID(ctx context.Context, client collectorv1.InventoryCollectorServiceClient, t *refreshTarget) (int64, error) {
+	var (
+		id  int64
+		err error
+	)
+	if t.hostname != "" {
+		var resp *collectorv1.GetLatestByHostnameResponse
+		resp, err = client.GetLatestByHostname(ctx, &collectorv1.GetLatestByHostnameRequest{Hostname: t.hostname, Site: t.site})
+		id = resp.GetId()
+	} else {
+		var resp *collectorv1.GetLatestBySystemUUIDResponse
+		resp, err = client.GetLatestBySystemUUID(ctx, &collectorv1.GetLatestBySystemUUIDRequest{SystemUuid: t.clientID, Site: t.site})
+		id = resp.GetId()
+	}
+	if status.Code(err) == codes.NotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("%s: get latest inventory: %w", t, err)
+	}
+	return id, nil
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+	"github.com/go-tangra/go-tangra-inventory/internal/output"
+	"github.com/go-tangra/go-tangra-inventory/internal/siem"
+)
+
+var alertsFlags struct {
+	site     string
+	hostname string
+	unacked  bool
+	pageSize int32
+	page     int32
+	siem     string
+}
+
+var alertsCmd = &cobra.Command{
+	Use:   "alerts",
+	Short: "List hardware change alerts, newest first",
+	Args:  cobra.NoArgs,
+	RunE:  runAlerts,
+}
+
+func init() {
+	f := alertsCmd.Flags()
+	f.StringVar(&alertsFlags.site, "site", "", "only alerts of this site")
+	f.StringVar(&alertsFlags.hostname, "hostname", "", "only alerts of this host")
+	f.BoolVar(&alertsFlags.unacked, "unacked", false, "only unacknowledged alerts")
+	f.Int32Var(&alertsFlags.pageSize, "page-size", 50, "alerts per page")
+	f.Int32Var(&alertsFlags.page, "page", 1, "page number")
+	f.StringVar(&alertsFlags.siem, "siem", "", "print one cef or leef event per alert instead of a listing, e.g. to feed a SIEM")
+
+	rootCmd.AddCommand(alertsCmd)
+}
+
+func runAlerts(cmd *cobra.Command, _ []string) error {
+	if alertsFlags.siem != "" {
+		if err := siem.Check(alertsFlags.siem); err != nil {
+			return err
+		}
+	}
+
+	ctx, client, done, err := collectorClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	resp, err := client.ListAlerts(ctx, &collectorv1.ListAlertsRequest{
+		Site:               alertsFlags.site,
+		Hostname:           alertsFlags.hostname,
+		UnacknowledgedOnly: alertsFlags.unacked,
+		PageSize:           alertsFlags.pageSize,
+		Page:               alertsFlags.page,
+	})
+	if err != nil {
+		return fmt.Errorf("list alerts: %w", err)
+	}
+
+	if alertsFlags.siem != "" {
+		for _, a := range resp.Alerts {
+			event, err := siem.Format(alertsFlags.siem, a)
+			if err != nil {
+				return err
+			}
+			fmt.Println(event)
+		}
+	} else if err := output.List(os.Stdout, outputFormat, resp.Alerts, output.AlertColumns); err != nil {
+		return err
+	}
+	if int(resp.TotalCount) > len(resp.Alerts) {
+		fmt.Fprintf(os.Stderr, "showing %d of %d alerts (page %d)\n", len(resp.Alerts), resp.TotalCount, alertsFlags.page)
+	}
+	return nil
+}
@@ -0,0 +1,450 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+	"github.com/go-tangra/go-tangra-inventory/internal/output"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var benchCmd = &cobra.Command{
+	Use:   "bench",
+	Short: "Load-test a collector",
+	Long: `Load-test a collector: submissions of a simulated fleet (submit), command
+delivery to its streams (fanout) and inventory listing (list).
+
+With --local each run starts its own collector, by default the
+inventory-collector on the PATH, on a temporary database and loopback ports,
+and stops it at the end, so that results are comparable between builds;
+"make bench" runs all three that way and prints JSON reports.`,
+}
+
+var benchSubmitFlags struct {
+	agents      int
+	rate        string
+	duration    time.Duration
+	connections int
+	stream      bool
+	site        string
+	prefix      string
+	seed        uint64
+}
+
+var benchSubmitCmd = &cobra.Command{
+	Use:   "submit",
+	Short: "Simulate agents submitting inventories and report latencies",
+	Long: `Simulate --agents agents for --duration: each holds a StreamCommands
+stream open like a daemon agent and submits a generated inventory at an even
+share of --rate, so the collector sees the load of a fleet that size. At the
+end the latency percentiles of SubmitInventory and the error rates are
+printed, for sizing collector hardware.
+
+The agents spread over --connections gRPC connections; by default each has
+its own, like real agents. Their inventories are generated like seed does,
+so the hosts they store can be removed again with
+"inventory-collector purge --hostname 'bench-*'".
+
+--timeout applies to each submission rather than to the whole run.`,
+	Args: cobra.NoArgs,
+	RunE: runBenchSubmit,
+}
+
+func init() {
+	f := benchSubmitCmd.Flags()
+	f.IntVar(&benchSubmitFlags.agents, "agents", 100, "number of simulated agents")
+	f.StringVar(&benchSubmitFlags.rate, "rate", "10/s", "total submission rate, such as 50/s, 600/m or 1000/h")
+	f.DurationVar(&benchSubmitFlags.duration, "duration", time.Minute, "how long to run")
+	f.IntVar(&benchSubmitFlags.connections, "connections", 0, "number of gRPC connections the agents share (0 = one per agent)")
+	f.BoolVar(&benchSubmitFlags.stream, "stream", true, "hold a StreamCommands stream open per agent")
+	f.StringVar(&benchSubmitFlags.site, "site", "", "site of the simulated agents")
+	f.StringVar(&benchSubmitFlags.prefix, "prefix", "bench-", "hostname prefix of the simulated agents")
+	f.Uint64Var(&benchSubmitFlags.seed, "seed", 1, "seed of the generated inventories")
+
+	benchCmd.AddCommand(benchSubmitCmd)
+	rootCmd.AddCommand(benchCmd)
+}
+
+// benchReport is the result of a bench submit run.
+type benchReport struct {
+	Agents      int     `json:"agents"`
+	DurationSec float64 `json:"duration_seconds"`
+	TargetRate  float64 `json:"target_rate"`
+	Submit      struct {
+		Requests int            `json:"requests"`
+		Errors   int            `json:"errors"`
+		Rate     float64        `json:"rate"`
+		ByCode   map[string]int `json:"errors_by_code,omitempty"`
+		Latency  benchLatency   `json:"latency_ms"`
+	} `json:"submit"`
+	Stream *benchStreamReport `json:"stream,omitempty"`
+}
+
+type benchLatency struct {
+	Min  float64 `json:"min"`
+	Mean float64 `json:"mean"`
+	P50  float64 `json:"p50"`
+	P90  float64 `json:"p90"`
+	P95  float64 `json:"p95"`
+	P99  float64 `json:"p99"`
+	Max  float64 `json:"max"`
+}
+
+type benchStreamReport struct {
+	Opened    int64 `json:"opened"`
+	Errors    int64 `json:"errors"`
+	Connected int   `json:"connected"`
+	Commands  int64 `json:"commands"`
+}
+
+// benchStats collects the results of the simulated agents.
+type benchStats struct {
+	mu        sync.Mutex
+	latencies []time.Duration
+	errors    map[codes.Code]int
+
+	streamsOpened atomic.Int64
+	streamErrors  atomic.Int64
+	commands      atomic.Int64
+}
+
+func (s *benchStats) submitted(d time.Duration, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err != nil {
+		s.errors[status.Code(err)]++
+		return
+	}
+	s.latencies = append(s.latencies, d)
+}
+
+func runBenchSubmit(cmd *cobra.Command, _ []string) error {
+	f := benchSubmitFlags
+	rate, err := parseRate(f.rate)
+	if err != nil {
+		return err
+	}
+	switch {
+	case f.agents <= 0:
+		return errors.New("--agents must be positive")
+	case f.duration <= 0:
+		return errors.New("--duration must be positive")
+	case f.connections < 0:
+		return errors.New("--connections must not be negative")
+	}
+	conns := f.connections
+	if conns == 0 || conns > f.agents {
+		conns = f.agents
+	}
+
+	stopLocal, err := startLocal(cmd)
+	if err != nil {
+		return err
+	}
+	defer stopLocal()
+
+	perCall := timeout
+	timeout = 0
+	ctx, clients, done, err := benchClients(cmd, conns)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	stats := &benchStats{errors: make(map[codes.Code]int)}
+	interval := max(time.Duration(float64(f.agents)/rate*float64(time.Second)), 1)
+	runCtx, stop := context.WithTimeout(ctx, f.duration)
+	defer stop()
+	streamCtx, closeStreams := context.WithCancel(ctx)
+	defer closeStreams()
+
+	fmt.Fprintf(os.Stderr, "Running %d agents at %g submissions/s for %s\n", f.agents, rate, f.duration)
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := range f.agents {
+		r := rand.New(rand.NewPCG(f.seed, uint64(i)))
+		inv := newSeedInventory(r, fmt.Sprintf("%s%05d", f.prefix, i+1), f.site)
+		client := clients[i%conns]
+
+		if f.stream {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				benchStream(streamCtx, client, inv, stats)
+			}()
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Start at a random phase so that the submissions spread evenly.
+			phase := time.Duration(r.Int64N(int64(interval)))
+			benchSubmit(runCtx, client, inv, phase, interval, perCall, stats)
+		}()
+	}
+
+	<-runCtx.Done()
+	elapsed := time.Since(start)
+	connected := -1
+	if f.stream {
+		connected, err = benchConnected(ctx, clients[0], f.prefix)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+	}
+	closeStreams()
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	rep := stats.report(f.agents, rate, elapsed)
+	if f.stream {
+		rep.Stream = &benchStreamReport{
+			Opened:    stats.streamsOpened.Load(),
+			Errors:    stats.streamErrors.Load(),
+			Connected: connected,
+			Commands:  stats.commands.Load(),
+		}
+	}
+	if outputFormat == output.JSON || outputFormat == output.YAML {
+		return output.Write(os.Stdout, rep, outputFormat)
+	}
+	printBenchReport(rep)
+	return nil
+}
+
+// benchClients opens n connections to the collector. The caller must call
+// the returned cleanup function.
+func benchClients(cmd *cobra.Command, n int) (context.Context, []collectorv1.InventoryCollectorServiceClient, func(), error) {
+	clients := make([]collectorv1.InventoryCollectorServiceClient, n)
+	var ctx context.Context
+	var dones []func()
+	done := func() {
+		for _, d := range dones {
+			d()
+		}
+	}
+	for i := range clients {
+		c, client, d, err := collectorClient(cmd)
+		if err != nil {
+			done()
+			return nil, nil, nil, err
+		}
+		dones = append(dones, d)
+		ctx, clients[i] = c, client
+	}
+	return ctx, clients, done, nil
+}
+
+// benchSubmit submits inv every interval, after phase, until ctx ends. A
+// submission slower than interval delays the next one, like an agent.
+func benchSubmit(ctx context.Context, client collectorv1.InventoryCollectorServiceClient, inv *collectorv1.Inventory, phase, interval, perCall time.Duration, stats *benchStats) {
+	t := time.NewTimer(phase)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+		inv.CollectedAt = timestamppb.Now()
+
+		callCtx, cancel := ctx, context.CancelFunc(func() {})
+		if perCall > 0 {
+			callCtx, cancel = context.WithTimeout(ctx, perCall)
+		}
+		start := time.Now()
+		_, err := client.SubmitInventory(callCtx, &collectorv1.SubmitInventoryRequest{Inventory: inv})
+		d := time.Since(start)
+		cancel()
+		// Calls cut short by the end of the run are not the collector's fault.
+		if ctx.Err() != nil {
+			return
+		}
+		stats.submitted(d, err)
+		t.Reset(max(interval-d, 0))
+	}
+}
+
+// benchStream holds a command stream open for inv's host until ctx ends,
+// reconnecting after a second when it fails.
+func benchStream(ctx context.Context, client collectorv1.InventoryCollectorServiceClient, inv *collectorv1.Inventory, stats *benchStats) {
+	req := &collectorv1.StreamCommandsRequest{
+		ClientId:      inv.System.Uuid,
+		ClientVersion: "bench",
+		Site:          inv.Site,
+		Hostname:      inv.Hostname,
+	}
+	for ctx.Err() == nil {
+		stream, err := client.StreamCommands(ctx, req)
+		if err == nil {
+			stats.streamsOpened.Add(1)
+			for {
+				if _, err = stream.Recv(); err != nil {
+					break
+				}
+				stats.commands.Add(1)
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		stats.streamErrors.Add(1)
+		select {
+		case <-ctx.Done():
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// benchConnected returns how many simulated agents the collector lists as
+// connected.
+func benchConnected(ctx context.Context, client collectorv1.InventoryCollectorServiceClient, prefix string) (int, error) {
+	resp, err := client.ListConnectedAgents(ctx, &collectorv1.ListConnectedAgentsRequest{})
+	if err != nil {
+		return -1, fmt.Errorf("list connected agents: %w", err)
+	}
+	var n int
+	for _, a := range resp.Agents {
+		if a.Version == "bench" && strings.HasPrefix(a.Hostname, prefix) {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (s *benchStats) report(agents int, rate float64, elapsed time.Duration) *benchReport {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rep := &benchReport{Agents: agents, DurationSec: elapsed.Seconds(), TargetRate: rate}
+	for code, n := range s.errors {
+		if rep.Submit.ByCode == nil {
+			rep.Submit.ByCode = make(map[string]int)
+		}
+		rep.Submit.ByCode[code.String()] = n
+		rep.Submit.Errors += n
+	}
+	rep.Submit.Requests = len(s.latencies) + rep.Submit.Errors
+	rep.Submit.Rate = float64(rep.Submit.Requests) / elapsed.Seconds()
+	rep.Submit.Latency = summarizeLatencies(s.latencies)
+	return rep
+}
+
+// summarizeLatencies returns the percentiles of lat, sorting it.
+func summarizeLatencies(lat []time.Duration) benchLatency {
+	if len(lat) == 0 {
+		return benchLatency{}
+	}
+	slices.Sort(lat)
+	var sum time.Duration
+	for _, d := range lat {
+		sum += d
+	}
+	pct := func(p float64) float64 {
+		i := int(p*float64(len(lat))+0.5) - 1
+		return ms(lat[min(max(i, 0), len(lat)-1)])
+	}
+	return benchLatency{
+		Min:  ms(lat[0]),
+		Mean: ms(sum / time.Duration(len(lat))),
+		P50:  pct(0.50),
+		P90:  pct(0.90),
+		P95:  pct(0.95),
+		P99:  pct(0.99),
+		Max:  ms(lat[len(lat)-1]),
+	}
+}
+
+func ms(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
+
+func printBenchReport(rep *benchReport) {
+	s := rep.Submit
+	fmt.Printf("Agents: %d, duration: %.1fs\n", rep.Agents, rep.DurationSec)
+	fmt.Printf("Submissions: %d (%.1f/s, target %g/s), errors: %d (%.2f%%)\n",
+		s.Requests, s.Rate, rep.TargetRate, s.Errors, 100*float64(s.Errors)/float64(max(s.Requests, 1)))
+
+	fmt.Println()
+	printLatencies([]string{""}, []benchLatency{s.Latency})
+
+	if len(s.ByCode) > 0 {
+		fmt.Println()
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ERROR\tCOUNT")
+		names := make([]string, 0, len(s.ByCode))
+		for code := range s.ByCode {
+			names = append(names, code)
+		}
+		slices.Sort(names)
+		for _, code := range names {
+			fmt.Fprintf(w, "%s\t%d\n", code, s.ByCode[code])
+		}
+		w.Flush()
+	}
+
+	if st := rep.Stream; st != nil {
+		connected := "unknown"
+		if st.Connected >= 0 {
+			connected = strconv.Itoa(st.Connected)
+		}
+		fmt.Printf("\nStreams: %d opened, %s connected at the end, %d failed, %d commands received\n",
+			st.Opened, connected, st.Errors, st.Commands)
+	}
+}
+
+// printLatencies prints a table of latency percentiles, a row per label.
+// A single empty label prints the row without a label column.
+func printLatencies(labels []string, lats []benchLatency) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	head := "MIN\tMEAN\tP50\tP90\tP95\tP99\tMAX\t"
+	labelled := len(labels) != 1 || labels[0] != ""
+	if labelled {
+		head = "\t" + head
+	}
+	fmt.Fprintln(w, head)
+	for i, l := range lats {
+		if labelled {
+			fmt.Fprintf(w, "%s\t", labels[i])
+		}
+		fmt.Fprintf(w, "%.1fms\t%.1fms\t%.1fms\t%.1fms\t%.1fms\t%.1fms\t%.1fms\t\n", l.Min, l.Mean, l.P50, l.P90, l.P95, l.P99, l.Max)
+	}
+	w.Flush()
+}
+
+// parseRate parses a rate such as 50/s, 600/m or 50 into events per second.
+func parseRate(s string) (float64, error) {
+	n, unit, _ := strings.Cut(s, "/")
+	per := time.Second
+	switch unit {
+	case "", "s":
+	case "m":
+		per = time.Minute
+	case "h":
+		per = time.Hour
+	default:
+		return 0, fmt.Errorf("invalid rate %q (use 50/s, 600/m or 1000/h)", s)
+	}
+	v, err := strconv.ParseFloat(n, 64)
+	if err != nil || v <= 0 {
+		return 0, fmt.Errorf("invalid rate %q (use 50/s, 600/m or 1000/h)", s)
+	}
+	return v / per.Seconds(), nil
+}
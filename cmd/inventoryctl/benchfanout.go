@@ -0,0 +1,284 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+	"github.com/go-tangra/go-tangra-inventory/internal/output"
+)
+
+var benchFanoutFlags struct {
+	agents      int
+	rounds      int
+	connections int
+	concurrency int
+	wait        time.Duration
+	site        string
+	prefix      string
+	seed        uint64
+}
+
+var benchFanoutCmd = &cobra.Command{
+	Use:   "fanout",
+	Short: "Measure how long commands take to reach connected agents",
+	Long: `Connect --agents simulated agents over StreamCommands, then send a refresh
+command to every one of them at once, --rounds times, and report how long the
+commands took to arrive: for each agent from the start of its round, and for
+each round until its last agent had the command. This is the delay of
+refreshes and update rollouts across a fleet.
+
+The commands are sent with RefreshInventory by client ID, --concurrency at a
+time, to the simulated agents only; they do not submit inventories in
+response, so nothing is stored.`,
+	Args: cobra.NoArgs,
+	RunE: runBenchFanout,
+}
+
+func init() {
+	f := benchFanoutCmd.Flags()
+	f.IntVar(&benchFanoutFlags.agents, "agents", 1000, "number of simulated agents")
+	f.IntVar(&benchFanoutFlags.rounds, "rounds", 10, "number of times to send a command to every agent")
+	f.IntVar(&benchFanoutFlags.connections, "connections", 0, "number of gRPC connections the agents share (0 = one per agent)")
+	f.IntVar(&benchFanoutFlags.concurrency, "concurrency", 32, "number of commands sent in parallel")
+	f.DurationVar(&benchFanoutFlags.wait, "wait", 10*time.Second, "how long to wait for the commands of a round to arrive")
+	f.StringVar(&benchFanoutFlags.site, "site", "", "site of the simulated agents")
+	f.StringVar(&benchFanoutFlags.prefix, "prefix", "bench-", "hostname prefix of the simulated agents")
+	f.Uint64Var(&benchFanoutFlags.seed, "seed", 1, "seed of the simulated agents")
+
+	benchCmd.AddCommand(benchFanoutCmd)
+}
+
+// benchConnectTimeout bounds how long fanout waits for its agents to be
+// listed as connected.
+const benchConnectTimeout = time.Minute
+
+// benchFanoutReport is the result of a bench fanout run.
+type benchFanoutReport struct {
+	Agents     int          `json:"agents"`
+	Rounds     int          `json:"rounds"`
+	Sent       int          `json:"sent"`
+	SendErrors int          `json:"send_errors"`
+	Lost       int          `json:"lost"`
+	Delivery   benchLatency `json:"delivery_ms"`
+	Round      benchLatency `json:"round_ms"`
+}
+
+// benchArrival is a command received by a simulated agent.
+type benchArrival struct {
+	commandID string
+	at        time.Time
+}
+
+func runBenchFanout(cmd *cobra.Command, _ []string) error {
+	f := benchFanoutFlags
+	switch {
+	case f.agents <= 0:
+		return errors.New("--agents must be positive")
+	case f.rounds <= 0:
+		return errors.New("--rounds must be positive")
+	case f.connections < 0:
+		return errors.New("--connections must not be negative")
+	case f.concurrency <= 0:
+		return errors.New("--concurrency must be positive")
+	}
+	conns := f.connections
+	if conns == 0 || conns > f.agents {
+		conns = f.agents
+	}
+
+	stopLocal, err := startLocal(cmd)
+	if err != nil {
+		return err
+	}
+	defer stopLocal()
+
+	perCall := timeout
+	timeout = 0
+	ctx, clients, done, err := benchClients(cmd, conns)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	streamCtx, closeStreams := context.WithCancel(ctx)
+	// Room for a round of commands and the stragglers of the previous one.
+	arrivals := make(chan benchArrival, 2*f.agents)
+	var streamErrors atomic.Int64
+	var wg sync.WaitGroup
+	agents := make([]*collectorv1.Inventory, f.agents)
+	for i := range agents {
+		r := rand.New(rand.NewPCG(f.seed, uint64(i)))
+		agents[i] = newSeedInventory(r, fmt.Sprintf("%s%05d", f.prefix, i+1), f.site)
+		client := clients[i%conns]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := benchReceive(streamCtx, client, agents[i], arrivals); err != nil {
+				streamErrors.Add(1)
+			}
+		}()
+	}
+	defer wg.Wait()
+	defer closeStreams()
+
+	fmt.Fprintf(os.Stderr, "Connecting %d agents\n", f.agents)
+	if err := benchAwaitConnected(ctx, clients[0], f.prefix, f.agents); err != nil {
+		return err
+	}
+
+	rep := &benchFanoutReport{Agents: f.agents, Rounds: f.rounds}
+	var delivery, rounds []time.Duration
+	for round := range f.rounds {
+		fmt.Fprintf(os.Stderr, "Round %d of %d\n", round+1, f.rounds)
+		sent, start, errs := benchSendRound(ctx, clients, agents, f.concurrency, perCall)
+		rep.Sent += len(sent)
+		rep.SendErrors += errs
+
+		// Commands may arrive before RefreshInventory returns their ID, so
+		// arrivals are matched up once all are sent.
+		got := make(map[string]time.Time)
+		timer := time.NewTimer(f.wait)
+	collect:
+		for len(got) < len(sent) {
+			select {
+			case a := <-arrivals:
+				if sent[a.commandID] {
+					got[a.commandID] = a.at
+				}
+			case <-timer.C:
+				break collect
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			}
+		}
+		timer.Stop()
+
+		rep.Lost += len(sent) - len(got)
+		var last time.Duration
+		for _, at := range got {
+			d := at.Sub(start)
+			delivery = append(delivery, d)
+			last = max(last, d)
+		}
+		if len(got) > 0 {
+			rounds = append(rounds, last)
+		}
+	}
+	if n := streamErrors.Load(); n > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: %d command streams failed\n", n)
+	}
+
+	rep.Delivery = summarizeLatencies(delivery)
+	rep.Round = summarizeLatencies(rounds)
+	if outputFormat == output.JSON || outputFormat == output.YAML {
+		return output.Write(os.Stdout, rep, outputFormat)
+	}
+	fmt.Printf("Agents: %d, rounds: %d\n", rep.Agents, rep.Rounds)
+	fmt.Printf("Commands: %d sent, %d failed to send, %d not received within %s\n\n",
+		rep.Sent, rep.SendErrors, rep.Lost, f.wait)
+	printLatencies([]string{"per agent", "per round"}, []benchLatency{rep.Delivery, rep.Round})
+	return nil
+}
+
+// benchReceive holds a command stream open for inv's host until ctx ends,
+// reporting the commands it receives to arrivals.
+func benchReceive(ctx context.Context, client collectorv1.InventoryCollectorServiceClient, inv *collectorv1.Inventory, arrivals chan<- benchArrival) error {
+	stream, err := client.StreamCommands(ctx, &collectorv1.StreamCommandsRequest{
+		ClientId:      inv.System.Uuid,
+		ClientVersion: "bench",
+		Site:          inv.Site,
+		Hostname:      inv.Hostname,
+	})
+	if err != nil {
+		return err
+	}
+	for {
+		cmd, err := stream.Recv()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		select {
+		case arrivals <- benchArrival{commandID: cmd.CommandId, at: time.Now()}:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// benchAwaitConnected waits until the collector lists n simulated agents
+// with prefix as connected.
+func benchAwaitConnected(ctx context.Context, client collectorv1.InventoryCollectorServiceClient, prefix string, n int) error {
+	deadline := time.Now().Add(benchConnectTimeout)
+	for {
+		connected, err := benchConnected(ctx, client, prefix)
+		if err != nil {
+			return err
+		}
+		if connected >= n {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("only %d of %d agents connected after %s", connected, n, benchConnectTimeout)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+// benchSendRound sends a refresh command to every agent, concurrency at a
+// time, and returns the IDs of the commands sent, when it started and the
+// number of failed sends.
+func benchSendRound(ctx context.Context, clients []collectorv1.InventoryCollectorServiceClient, agents []*collectorv1.Inventory, concurrency int, perCall time.Duration) (map[string]bool, time.Time, int) {
+	var mu sync.Mutex
+	sent := make(map[string]bool, len(agents))
+	var errs int
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	start := time.Now()
+	for range min(concurrency, len(agents)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				callCtx, cancel := ctx, context.CancelFunc(func() {})
+				if perCall > 0 {
+					callCtx, cancel = context.WithTimeout(ctx, perCall)
+				}
+				resp, err := clients[i%len(clients)].RefreshInventory(callCtx, &collectorv1.RefreshInventoryRequest{
+					Site:     agents[i].Site,
+					ClientId: agents[i].System.Uuid,
+				})
+				cancel()
+				mu.Lock()
+				if err != nil {
+					errs++
+				} else {
+					sent[resp.CommandId] = true
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for i := range agents {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return sent, start, errs
+}
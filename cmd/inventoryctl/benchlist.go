@@ -0,0 +1,229 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+	"github.com/go-tangra/go-tangra-inventory/internal/output"
+
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+var benchListFlags struct {
+	requests    int
+	concurrency int
+	pageSize    int32
+	hosts       int
+	history     int
+	prefix      string
+	seed        uint64
+}
+
+var benchListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Measure ListInventories latencies",
+	Long: `Time --requests ListInventories calls of each of these kinds, --concurrency
+at a time, and report their latency percentiles:
+
+  first page   the newest --page-size inventories
+  next page    the following pages, walking the list with page tokens
+  last page    the oldest inventories, by page number
+  hostname     the history of one host
+  inventories  the first page with the complete inventories
+
+--hosts first submits generated inventories of that many hosts, with
+--history days each, like seed does; a --local collector starts empty and
+needs them. Otherwise the collector's inventories are listed as they are.`,
+	Args: cobra.NoArgs,
+	RunE: runBenchList,
+}
+
+func init() {
+	f := benchListCmd.Flags()
+	f.IntVar(&benchListFlags.requests, "requests", 200, "number of calls of each kind")
+	f.IntVar(&benchListFlags.concurrency, "concurrency", 4, "number of calls in parallel")
+	f.Int32Var(&benchListFlags.pageSize, "page-size", 50, "inventories per page")
+	f.IntVar(&benchListFlags.hosts, "hosts", 0, "number of generated hosts to submit inventories of first")
+	f.IntVar(&benchListFlags.history, "history", 10, "days of history to submit per generated host")
+	f.StringVar(&benchListFlags.prefix, "prefix", "bench-", "hostname prefix of the generated hosts")
+	f.Uint64Var(&benchListFlags.seed, "seed", 1, "seed of the generated hosts")
+
+	benchCmd.AddCommand(benchListCmd)
+}
+
+// benchListReport is the result of a bench list run.
+type benchListReport struct {
+	Inventories int                    `json:"inventories"`
+	PageSize    int32                  `json:"page_size"`
+	Queries     []benchListQueryReport `json:"queries"`
+}
+
+type benchListQueryReport struct {
+	Name     string       `json:"name"`
+	Requests int          `json:"requests"`
+	Errors   int          `json:"errors"`
+	Latency  benchLatency `json:"latency_ms"`
+}
+
+// benchListQuery is a kind of ListInventories call. request returns the
+// call to time given the response to the previous call of the same worker,
+// nil at first.
+type benchListQuery struct {
+	name    string
+	request func(r *rand.Rand, prev *collectorv1.ListInventoriesResponse) *collectorv1.ListInventoriesRequest
+}
+
+func runBenchList(cmd *cobra.Command, _ []string) error {
+	f := benchListFlags
+	switch {
+	case f.requests <= 0:
+		return errors.New("--requests must be positive")
+	case f.concurrency <= 0:
+		return errors.New("--concurrency must be positive")
+	case f.pageSize <= 0:
+		return errors.New("--page-size must be positive")
+	case f.hosts < 0:
+		return errors.New("--hosts must not be negative")
+	case f.hosts > 0 && f.history <= 0:
+		return errors.New("--history must be positive")
+	}
+
+	stopLocal, err := startLocal(cmd)
+	if err != nil {
+		return err
+	}
+	defer stopLocal()
+
+	perCall := timeout
+	timeout = 0
+	ctx, client, done, err := collectorClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	if f.hosts > 0 {
+		seedFlags.hosts, seedFlags.history, seedFlags.prefix = f.hosts, f.history, f.prefix
+		seedFlags.site, seedFlags.seed, seedFlags.concurrency = "", f.seed, 8
+		fmt.Fprintf(os.Stderr, "Submitting %d inventories\n", f.hosts*f.history)
+		if _, err := seedFleet(ctx, client, perCall); err != nil {
+			return err
+		}
+	}
+
+	first, err := client.ListInventories(ctx, &collectorv1.ListInventoriesRequest{PageSize: f.pageSize})
+	if err != nil {
+		return fmt.Errorf("list inventories: %w", err)
+	}
+	if len(first.Inventories) == 0 {
+		return errors.New("the collector holds no inventories; submit some with --hosts")
+	}
+	lastPage := (first.TotalCount + f.pageSize - 1) / f.pageSize
+	hostnames := make([]string, 0, len(first.Inventories))
+	for _, s := range first.Inventories {
+		hostnames = append(hostnames, s.Hostname)
+	}
+
+	queries := []benchListQuery{
+		{"first page", func(*rand.Rand, *collectorv1.ListInventoriesResponse) *collectorv1.ListInventoriesRequest {
+			return &collectorv1.ListInventoriesRequest{PageSize: f.pageSize}
+		}},
+		{"next page", func(_ *rand.Rand, prev *collectorv1.ListInventoriesResponse) *collectorv1.ListInventoriesRequest {
+			// Start over from the first page at the end of the list.
+			return &collectorv1.ListInventoriesRequest{PageSize: f.pageSize, PageToken: prev.GetNextPageToken()}
+		}},
+		{"last page", func(*rand.Rand, *collectorv1.ListInventoriesResponse) *collectorv1.ListInventoriesRequest {
+			return &collectorv1.ListInventoriesRequest{PageSize: f.pageSize, Page: lastPage}
+		}},
+		{"hostname", func(r *rand.Rand, _ *collectorv1.ListInventoriesResponse) *collectorv1.ListInventoriesRequest {
+			return &collectorv1.ListInventoriesRequest{PageSize: f.pageSize, Hostname: hostnames[r.IntN(len(hostnames))]}
+		}},
+		{"inventories", func(*rand.Rand, *collectorv1.ListInventoriesResponse) *collectorv1.ListInventoriesRequest {
+			return &collectorv1.ListInventoriesRequest{PageSize: f.pageSize, ReadMask: &fieldmaskpb.FieldMask{Paths: []string{"inventory"}}}
+		}},
+	}
+
+	rep := &benchListReport{Inventories: int(first.TotalCount), PageSize: f.pageSize}
+	for _, q := range queries {
+		fmt.Fprintf(os.Stderr, "Timing %s\n", q.name)
+		qr, err := benchListRun(ctx, client, q, f.requests, f.concurrency, f.seed, perCall)
+		if err != nil {
+			return err
+		}
+		rep.Queries = append(rep.Queries, qr)
+	}
+
+	if outputFormat == output.JSON || outputFormat == output.YAML {
+		return output.Write(os.Stdout, rep, outputFormat)
+	}
+	fmt.Printf("Inventories: %d, page size: %d\n\n", rep.Inventories, rep.PageSize)
+	labels := make([]string, len(rep.Queries))
+	lats := make([]benchLatency, len(rep.Queries))
+	var errs int
+	for i, qr := range rep.Queries {
+		labels[i], lats[i] = qr.Name, qr.Latency
+		errs += qr.Errors
+	}
+	printLatencies(labels, lats)
+	if errs > 0 {
+		fmt.Printf("\nErrors: %d\n", errs)
+	}
+	return nil
+}
+
+// benchListRun makes requests calls of q, concurrency at a time.
+func benchListRun(ctx context.Context, client collectorv1.InventoryCollectorServiceClient, q benchListQuery, requests, concurrency int, seed uint64, perCall time.Duration) (benchListQueryReport, error) {
+	qr := benchListQueryReport{Name: q.name, Requests: requests}
+	var mu sync.Mutex
+	var latencies []time.Duration
+	jobs := make(chan struct{})
+	var wg sync.WaitGroup
+	for w := range min(concurrency, requests) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r := rand.New(rand.NewPCG(seed, uint64(w)))
+			var prev *collectorv1.ListInventoriesResponse
+			for range jobs {
+				callCtx, cancel := ctx, context.CancelFunc(func() {})
+				if perCall > 0 {
+					callCtx, cancel = context.WithTimeout(ctx, perCall)
+				}
+				start := time.Now()
+				resp, err := client.ListInventories(callCtx, q.request(r, prev))
+				d := time.Since(start)
+				cancel()
+				prev = resp
+
+				mu.Lock()
+				if err != nil {
+					qr.Errors++
+				} else {
+					latencies = append(latencies, d)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for range requests {
+		select {
+		case jobs <- struct{}{}:
+		case <-ctx.Done():
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return qr, err
+	}
+	qr.Latency = summarizeLatencies(latencies)
+	return qr, nil
+}
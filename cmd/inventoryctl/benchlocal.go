@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+)
+
+var benchLocalFlags struct {
+	local     bool
+	collector string
+}
+
+func init() {
+	pf := benchCmd.PersistentFlags()
+	pf.BoolVar(&benchLocalFlags.local, "local", false, "start a collector with a temporary database for the run instead of using --server")
+	pf.StringVar(&benchLocalFlags.collector, "collector", "inventory-collector", "collector executable started by --local")
+}
+
+// localCollectorStartup bounds how long a --local collector may take to
+// accept connections.
+const localCollectorStartup = 30 * time.Second
+
+// startLocal starts a collector with a temporary database when --local is
+// set and points the connection flags at it, so that benchmarks start from
+// a known state without disturbing a real collector. The returned function
+// stops the collector and removes the database.
+func startLocal(cmd *cobra.Command) (func(), error) {
+	if !benchLocalFlags.local {
+		return func() {}, nil
+	}
+	path, err := exec.LookPath(benchLocalFlags.collector)
+	if err != nil {
+		return nil, fmt.Errorf("find collector: %w (build it with make build-collector and pass --collector)", err)
+	}
+	dir, err := os.MkdirTemp("", "inventoryctl-bench-*")
+	if err != nil {
+		return nil, err
+	}
+	grpcAddr, err := freeAddr()
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	httpAddr, err := freeAddr()
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+
+	cfgPath := filepath.Join(dir, "collector.yaml")
+	cfg := fmt.Sprintf("listen: %q\nhttp_listen: %q\ndatabase: %q\nenable_swagger: false\n",
+		grpcAddr, httpAddr, filepath.Join(dir, "inventory.db"))
+	if err := os.WriteFile(cfgPath, []byte(cfg), 0o600); err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	logPath := filepath.Join(dir, "collector.log")
+	log, err := os.Create(logPath)
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+
+	c := exec.Command(path, "serve", "--config", cfgPath, "--log-level", "warn")
+	c.Stdout, c.Stderr = log, log
+	if err := c.Start(); err != nil {
+		log.Close()
+		os.RemoveAll(dir)
+		return nil, fmt.Errorf("start collector: %w", err)
+	}
+	exited := make(chan struct{})
+	go func() {
+		c.Wait()
+		close(exited)
+	}()
+	stop := func() {
+		if err := c.Process.Signal(os.Interrupt); err != nil {
+			c.Process.Kill()
+		}
+		select {
+		case <-exited:
+		case <-time.After(10 * time.Second):
+			c.Process.Kill()
+			<-exited
+		}
+		log.Close()
+		os.RemoveAll(dir)
+	}
+
+	serverAddr, adminAddr, apiSecret, adminSecret = grpcAddr, "", "", ""
+	useTLS, caCert, clientCert, clientKey = false, "", "", ""
+	if err := waitLocal(cmd, exited); err != nil {
+		if out, rerr := os.ReadFile(logPath); rerr == nil && len(out) > 0 {
+			os.Stderr.Write(out)
+		}
+		stop()
+		return nil, err
+	}
+	fmt.Fprintf(os.Stderr, "Started a local collector on %s\n", grpcAddr)
+	return stop, nil
+}
+
+// waitLocal waits until the local collector answers on serverAddr, or
+// exits.
+func waitLocal(cmd *cobra.Command, exited <-chan struct{}) error {
+	ctx, client, done, err := collectorClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer done()
+	deadline := time.Now().Add(localCollectorStartup)
+	for {
+		callCtx, cancel := context.WithTimeout(ctx, time.Second)
+		_, err := client.ListConnectedAgents(callCtx, &collectorv1.ListConnectedAgentsRequest{})
+		cancel()
+		if err == nil {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("local collector did not start: %w", err)
+		}
+		select {
+		case <-exited:
+			return errors.New("local collector exited")
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+// freeAddr returns a loopback address with a port that was free a moment
+// ago.
+func freeAddr() (string, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", err
+	}
+	defer l.Close()
+	return l.Addr().String(), nil
+}
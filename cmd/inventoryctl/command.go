@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+	"github.com/go-tangra/go-tangra-inventory/internal/output"
+
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var commandFlags struct {
+	site    string
+	payload string
+	wait    time.Duration
+}
+
+var commandCmd = &cobra.Command{
+	Use:   "cmd <hostname|uuid> <type>",
+	Short: "Send any command to a connected agent",
+	Long: `Send a command of any type the agents support to a connected agent and
+wait for the agent to acknowledge it: ping, refresh, pause, resume or update.
+The agent is selected by hostname or by client ID (system UUID).
+
+--payload sets further fields of the command as JSON, e.g. the release of an
+update command:
+
+  inventoryctl cmd pc-042 update --payload '{"update": {"version": "1.4.0",
+    "downloadUrl": "https://...", "sha256": "...", "signature": "...",
+    "os": "windows", "arch": "amd64"}}'
+
+sign-update prints the payload of a signed release.
+
+The command is reported as sent once it is queued for the agent, and as
+acknowledged when the agent has carried it out, with the failure it reports
+if any. Agents that predate acknowledgements never acknowledge; --wait 0
+returns as soon as the command is sent. Logs are fetched with the logs
+command instead.`,
+	Args: cobra.ExactArgs(2),
+	RunE: runCommand,
+}
+
+func init() {
+	f := commandCmd.Flags()
+	f.StringVar(&commandFlags.site, "site", "", "site of the agent")
+	f.StringVar(&commandFlags.payload, "payload", "", "further command fields as JSON")
+	f.DurationVar(&commandFlags.wait, "wait", 10*time.Second, "how long to wait for the acknowledgement (0 = do not wait)")
+
+	rootCmd.AddCommand(commandCmd)
+}
+
+func runCommand(cmd *cobra.Command, args []string) error {
+	command := &collectorv1.InventoryCommand{}
+	if commandFlags.payload != "" {
+		if err := protojson.Unmarshal([]byte(commandFlags.payload), command); err != nil {
+			return fmt.Errorf("--payload: %w", err)
+		}
+	}
+	cmdType, err := parseCommandType(args[1])
+	if err != nil {
+		return err
+	}
+	command.CommandType = cmdType
+
+	req := &collectorv1.SendCommandRequest{
+		Site:    commandFlags.site,
+		Command: command,
+		WaitMs:  commandFlags.wait.Milliseconds(),
+	}
+	if _, err := uuid.Parse(args[0]); err == nil {
+		req.ClientId = args[0]
+	} else {
+		req.Hostname = args[0]
+	}
+
+	// Wait for the acknowledgement on top of --timeout.
+	if timeout > 0 {
+		timeout += commandFlags.wait
+	}
+	ctx, client, done, err := adminClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	resp, err := client.SendCommand(ctx, req)
+	if err != nil {
+		return fmt.Errorf("send command: %w", err)
+	}
+
+	if outputFormat == output.JSON || outputFormat == output.YAML {
+		return output.Message(os.Stdout, outputFormat, resp)
+	}
+	fmt.Printf("%s: %s command %s sent\n", args[0], args[1], resp.CommandId)
+	switch {
+	case commandFlags.wait == 0:
+	case !resp.Acknowledged:
+		return fmt.Errorf("%s: no acknowledgement within %s; the agent may be busy or predate acknowledgements", args[0], commandFlags.wait)
+	case resp.Error != "":
+		return fmt.Errorf("%s: acknowledged after %dms: failed: %s", args[0], resp.DurationMs, resp.Error)
+	default:
+		fmt.Printf("%s: acknowledged after %dms\n", args[0], resp.DurationMs)
+	}
+	return nil
+}
+
+// parseCommandType parses a command type name such as "refresh".
+func parseCommandType(name string) (collectorv1.InventoryCommandType, error) {
+	const prefix = "INVENTORY_COMMAND_TYPE_"
+	if v, ok := collectorv1.InventoryCommandType_value[prefix+strings.ToUpper(name)]; ok {
+		return collectorv1.InventoryCommandType(v), nil
+	}
+	var known []string
+	for n := range collectorv1.InventoryCommandType_value {
+		known = append(known, strings.ToLower(strings.TrimPrefix(n, prefix)))
+	}
+	slices.Sort(known)
+	return 0, fmt.Errorf("unknown command type %q (use %s)", name, strings.Join(known, ", "))
+}
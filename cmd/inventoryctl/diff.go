@@ -0,0 +1,121 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+	"github.com/go-tangra/go-tangra-inventory/internal/output"
+)
+
+var diffFlags struct {
+	site    string
+	last    int32
+	noColor bool
+}
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <id> [id] | diff <hostname>",
+	Short: "Show the hardware changes between two inventories",
+	Long: `Show the hardware changes between two stored inventories:
+
+  diff <from-id> <to-id>   between two inventories
+  diff <id>                between an inventory and the previous one of its host
+  diff <hostname>          across the host's last --last inventories
+
+Added components are shown in green, removed ones in red and modified ones in
+yellow when writing to a terminal, unless --no-color or NO_COLOR is set.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runDiff,
+}
+
+func init() {
+	f := diffCmd.Flags()
+	f.StringVar(&diffFlags.site, "site", "", "site of the host")
+	f.Int32Var(&diffFlags.last, "last", 2, "with a hostname, compare the oldest of its last N inventories with the newest")
+	f.BoolVar(&diffFlags.noColor, "no-color", false, "do not color the output")
+
+	rootCmd.AddCommand(diffCmd)
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	ctx, client, done, err := collectorClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	req := &collectorv1.DiffInventoriesRequest{}
+	switch id, err := strconv.ParseInt(args[0], 10, 64); {
+	case len(args) == 2:
+		if req.FromId, err = parseID(args[0]); err != nil {
+			return err
+		}
+		if req.ToId, err = parseID(args[1]); err != nil {
+			return err
+		}
+	case err == nil:
+		req.ToId = id
+	default:
+		if diffFlags.last < 2 {
+			return errors.New("--last must be at least 2")
+		}
+		resp, err := client.ListInventories(ctx, &collectorv1.ListInventoriesRequest{
+			Hostname: args[0],
+			Site:     diffFlags.site,
+			PageSize: diffFlags.last,
+		})
+		if err != nil {
+			return fmt.Errorf("list inventories: %w", err)
+		}
+		if len(resp.Inventories) < 2 {
+			return fmt.Errorf("host %q has fewer than two inventories", args[0])
+		}
+		req.ToId = resp.Inventories[0].Id
+		req.FromId = resp.Inventories[len(resp.Inventories)-1].Id
+	}
+
+	resp, err := client.DiffInventories(ctx, req)
+	if err != nil {
+		return fmt.Errorf("diff inventories: %w", err)
+	}
+	if outputFormat == output.JSON || outputFormat == output.YAML {
+		return output.Message(os.Stdout, outputFormat, resp)
+	}
+
+	if len(resp.Changes) == 0 {
+		fmt.Printf("No hardware changes from inventory %d to %d.\n", resp.FromId, resp.ToId)
+		return nil
+	}
+	color := useColor()
+	fmt.Printf("Hardware changes from inventory %d to %d:\n", resp.FromId, resp.ToId)
+	for _, c := range resp.Changes {
+		mark, code := "~", "33"
+		switch c.Type {
+		case "added":
+			mark, code = "+", "32"
+		case "removed":
+			mark, code = "-", "31"
+		}
+		line := mark + " " + c.Description
+		if color {
+			line = "\x1b[" + code + "m" + line + "\x1b[0m"
+		}
+		fmt.Println("  " + line)
+	}
+	return nil
+}
+
+// useColor reports whether to color the output: only on a terminal, and
+// neither with --no-color nor with NO_COLOR set.
+func useColor() bool {
+	if diffFlags.noColor || os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	fi, err := os.Stdout.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/spf13/cobra"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+)
+
+var enrichFlags struct {
+	csv    string
+	key    string
+	site   string
+	dryRun bool
+}
+
+var enrichCmd = &cobra.Command{
+	Use:   "enrich",
+	Short: "Set custom host fields, such as owner or location, from a CSV file",
+	Long: `Set custom fields on hosts from a CSV file, such as the spreadsheets
+procurement keeps of owners, locations and cost centers. The first row names
+the columns: the --key column (serial or uuid, also serial_number or
+system_uuid) identifies each host by the serial number or system UUID of its
+latest inventory, and every other column is a field. Column names are
+lower-cased with spaces and dashes turned into underscores, so "Cost Center"
+becomes cost_center.
+
+Fields are added to those the host already has; an empty cell removes the
+field. Rows that match no host are listed on stderr. ListHosts returns the
+fields with each host.
+
+  inventoryctl enrich --csv owners.csv --key serial
+
+--timeout applies to each batch of rows rather than to the whole file.`,
+	Args: cobra.NoArgs,
+	RunE: runEnrich,
+}
+
+// enrichBatchSize is the number of rows sent per request.
+const enrichBatchSize = 500
+
+// keyColumns lists the column names accepted for each --key.
+var keyColumns = map[string][]string{
+	"serial": {"serial", "serial_number", "system_serial"},
+	"uuid":   {"uuid", "system_uuid"},
+}
+
+func init() {
+	f := enrichCmd.Flags()
+	f.StringVar(&enrichFlags.csv, "csv", "", "CSV file to read (- = stdin)")
+	f.StringVar(&enrichFlags.key, "key", "serial", "what rows are matched by: serial or uuid")
+	f.StringVar(&enrichFlags.site, "site", "", "only match the hosts of this site")
+	f.BoolVar(&enrichFlags.dryRun, "dry-run", false, "match the rows without storing their fields")
+	enrichCmd.MarkFlagRequired("csv")
+
+	rootCmd.AddCommand(enrichCmd)
+}
+
+func runEnrich(cmd *cobra.Command, _ []string) error {
+	names, ok := keyColumns[enrichFlags.key]
+	if !ok {
+		return fmt.Errorf("unknown --key %q (use serial or uuid)", enrichFlags.key)
+	}
+
+	var r io.Reader = os.Stdin
+	if enrichFlags.csv != "-" {
+		f, err := os.Open(enrichFlags.csv)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+	hosts, err := readEnrichCSV(r, names)
+	if err != nil {
+		return fmt.Errorf("%s: %w", enrichFlags.csv, err)
+	}
+
+	perCall := timeout
+	timeout = 0
+	ctx, client, done, err := adminClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	var matched int
+	var unmatched []string
+	for start := 0; start < len(hosts); start += enrichBatchSize {
+		batch := hosts[start:min(start+enrichBatchSize, len(hosts))]
+		callCtx, cancel := ctx, context.CancelFunc(func() {})
+		if perCall > 0 {
+			callCtx, cancel = context.WithTimeout(ctx, perCall)
+		}
+		resp, err := client.EnrichHosts(callCtx, &collectorv1.EnrichHostsRequest{
+			Key:    enrichFlags.key,
+			Hosts:  batch,
+			Site:   enrichFlags.site,
+			DryRun: enrichFlags.dryRun,
+		})
+		cancel()
+		if err != nil {
+			return fmt.Errorf("enrich hosts: %w", err)
+		}
+		matched += int(resp.Matched)
+		unmatched = append(unmatched, resp.Unmatched...)
+	}
+
+	for _, key := range unmatched {
+		fmt.Fprintf(os.Stderr, "no host with %s %q\n", enrichFlags.key, key)
+	}
+	verb := "Enriched"
+	if enrichFlags.dryRun {
+		verb = "Would enrich"
+	}
+	fmt.Printf("%s %d hosts (%d of %d rows unmatched)\n", verb, matched, len(unmatched), len(hosts))
+	return nil
+}
+
+// readEnrichCSV reads the rows of a CSV file whose header has one of the
+// key column names; the other columns are fields.
+func readEnrichCSV(r io.Reader, keyNames []string) ([]*collectorv1.HostEnrichment, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("empty file")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	keyCol := -1
+	fields := make([]string, len(header))
+	for i, h := range header {
+		name := fieldColumn(h)
+		for _, k := range keyNames {
+			if name == k && keyCol < 0 {
+				keyCol = i
+			}
+		}
+		fields[i] = name
+	}
+	if keyCol < 0 {
+		return nil, fmt.Errorf("no %s column", strings.Join(keyNames, " or "))
+	}
+
+	var hosts []*collectorv1.HostEnrichment
+	for {
+		rec, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return hosts, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		h := &collectorv1.HostEnrichment{Key: rec[keyCol], Fields: make(map[string]string)}
+		for i, v := range rec {
+			if i != keyCol && fields[i] != "" {
+				h.Fields[fields[i]] = strings.TrimSpace(v)
+			}
+		}
+		hosts = append(hosts, h)
+	}
+}
+
+// fieldColumn turns a column name into a field name: lower case, with runs
+// of spaces, dashes and other punctuation as underscores. A byte order mark,
+// as spreadsheet applications write, is dropped.
+func fieldColumn(s string) string {
+	var b strings.Builder
+	sep := false
+	for _, r := range strings.ToLower(strings.TrimSpace(strings.TrimPrefix(s, "\ufeff"))) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if sep && b.Len() > 0 {
+				b.WriteByte('_')
+			}
+			b.WriteRune(r)
+			sep = false
+			continue
+		}
+		sep = true
+	}
+	return b.String()
+}
@@ -0,0 +1,215 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+	"github.com/go-tangra/go-tangra-inventory/internal/export"
+	"github.com/go-tangra/go-tangra-inventory/internal/glpi"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var exportFlags struct {
+	inventoryFilter
+	format string
+	out    string
+}
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export matching inventories as NDJSON, CSV or GLPI XML",
+	Long: `Export the inventories matching the filter flags, newest first, page by
+page from the API. NDJSON writes one complete inventory per line; CSV writes
+one row per inventory with the summary fields and the main hardware details.
+
+GLPI writes the latest matching inventory of each host as a FusionInventory
+XML document, which GLPI and OCS Inventory NG import, so that both can be fed
+from the same agents during a migration. --out names a directory that gets
+one <hostname>-<time>.xml file per host, for bulk imports such as
+fusioninventory-injector -d; with --out - the documents are written to
+stdout, which suits a single host:
+
+  inventoryctl export --format glpi --hostname pc-042 > pc-042.xml
+
+--timeout applies to each page rather than to the whole export.`,
+	Args: cobra.NoArgs,
+	RunE: runExport,
+}
+
+const (
+	// exportPageSize is the number of inventories fetched per request.
+	exportPageSize = 100
+	// exportMaxMsgSize bounds a page of complete inventories.
+	exportMaxMsgSize = 64 << 20
+)
+
+func init() {
+	exportFlags.addFlags(exportCmd)
+	f := exportCmd.Flags()
+	f.StringVar(&exportFlags.format, "format", "ndjson", "export format: ndjson, csv or glpi")
+	f.StringVar(&exportFlags.out, "out", "-", "output file, or directory for glpi (- = stdout)")
+
+	rootCmd.AddCommand(exportCmd)
+}
+
+func runExport(cmd *cobra.Command, _ []string) error {
+	if exportFlags.format != "ndjson" && exportFlags.format != "csv" && exportFlags.format != "glpi" {
+		return fmt.Errorf("unknown export format %q (use ndjson, csv or glpi)", exportFlags.format)
+	}
+	req, err := exportFlags.request()
+	if err != nil {
+		return err
+	}
+	// Pin the upper bound so that inventories arriving during the export do
+	// not shift the pages.
+	if req.CollectedBefore == nil {
+		req.CollectedBefore = timestamppb.Now()
+	}
+	req.PageSize = exportPageSize
+	req.ReadMask = &fieldmaskpb.FieldMask{Paths: []string{
+		"id", "site", "hostname", "username", "system_uuid", "system_serial", "collected_at", "stored_at", "inventory",
+	}}
+
+	perCall := timeout
+	timeout = 0
+	ctx, client, done, err := collectorClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	var w io.Writer = os.Stdout
+	dir := ""
+	if exportFlags.format == "glpi" && exportFlags.out != "-" {
+		dir = exportFlags.out
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("create export directory: %w", err)
+		}
+	} else if exportFlags.out != "-" {
+		f, err := os.Create(exportFlags.out)
+		if err != nil {
+			return fmt.Errorf("create export file: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+	bw := bufio.NewWriter(w)
+
+	n, err := exportPages(ctx, client, req, perCall, bw, dir)
+	if ferr := bw.Flush(); err == nil {
+		err = ferr
+	}
+	if err != nil {
+		if exportFlags.out != "-" && dir == "" {
+			os.Remove(exportFlags.out)
+		}
+		return err
+	}
+	if exportFlags.out != "-" {
+		fmt.Fprintf(os.Stderr, "Exported %d inventories to %s\n", n, exportFlags.out)
+	}
+	return nil
+}
+
+// exportPages writes every page of req to w in the --format and returns the
+// number of inventories written. GLPI documents are written to files in dir
+// instead, unless it is empty.
+func exportPages(ctx context.Context, client collectorv1.InventoryCollectorServiceClient, req *collectorv1.ListInventoriesRequest, perCall time.Duration, w io.Writer, dir string) (int, error) {
+	var cw *csv.Writer
+	if exportFlags.format == "csv" {
+		cw = csv.NewWriter(w)
+		cw.Write(export.CSVHeader)
+	}
+	// hosts are the hosts whose latest inventory was written, for GLPI.
+	hosts := make(map[[2]string]bool)
+
+	var n, listed int
+	for req.Page = 1; ; req.Page++ {
+		resp, err := listPage(ctx, client, req, perCall)
+		if err != nil {
+			return n, fmt.Errorf("list inventories: %w", err)
+		}
+		listed += len(resp.Inventories)
+		for _, s := range resp.Inventories {
+			if exportFlags.format == "glpi" {
+				key := [2]string{s.Site, s.Hostname}
+				if hosts[key] {
+					continue
+				}
+				hosts[key] = true
+				if err := exportGLPI(s, w, dir); err != nil {
+					return n, err
+				}
+				n++
+				continue
+			}
+			if cw != nil {
+				cw.Write(export.CSVRow(s))
+				continue
+			}
+			if err := export.WriteNDJSON(w, s); err != nil {
+				return n, fmt.Errorf("write inventory %d: %w", s.Id, err)
+			}
+			n++
+		}
+		if len(resp.Inventories) < int(req.PageSize) {
+			break
+		}
+		// Older collectors return no token, and page numbers still work
+		// with them; the total, which may be cached, only ends the list
+		// then.
+		if resp.NextPageToken == "" && listed >= int(resp.TotalCount) {
+			break
+		}
+		req.PageToken = resp.NextPageToken
+	}
+
+	if cw != nil {
+		cw.Flush()
+		return n, cw.Error()
+	}
+	return n, nil
+}
+
+// exportGLPI writes s as a GLPI document to w, or to a file in dir unless
+// it is empty.
+func exportGLPI(s *collectorv1.InventorySummary, w io.Writer, dir string) error {
+	inv := s.Inventory
+	if inv == nil {
+		return fmt.Errorf("inventory %d: no inventory data", s.Id)
+	}
+	if dir == "" {
+		return glpi.Write(w, inv)
+	}
+	path := filepath.Join(dir, glpi.DeviceID(inv)+".xml")
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("create export file: %w", err)
+	}
+	if err := glpi.Write(f, inv); err != nil {
+		f.Close()
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	return f.Close()
+}
+
+func listPage(ctx context.Context, client collectorv1.InventoryCollectorServiceClient, req *collectorv1.ListInventoriesRequest, perCall time.Duration) (*collectorv1.ListInventoriesResponse, error) {
+	if perCall > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, perCall)
+		defer cancel()
+	}
+	return client.ListInventories(ctx, req, grpc.MaxCallRecvMsgSize(exportMaxMsgSize))
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+	"github.com/go-tangra/go-tangra-inventory/internal/output"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// inventoryFilter holds the inventory filter flags of list and export.
+type inventoryFilter struct {
+	site     string
+	hostname string
+	username string
+	uuid     string
+	class    string
+	after    string
+	before   string
+}
+
+func (f *inventoryFilter) addFlags(cmd *cobra.Command) {
+	fs := cmd.Flags()
+	fs.StringVar(&f.site, "site", "", "only inventories of this site")
+	fs.StringVar(&f.hostname, "hostname", "", "only inventories of this host")
+	fs.StringVar(&f.username, "username", "", "only inventories collected for this user")
+	fs.StringVar(&f.uuid, "uuid", "", "only inventories with this system UUID")
+	fs.StringVar(&f.class, "device-class", "", "only inventories of this device class (computer, switch, printer, ups or network)")
+	fs.StringVar(&f.after, "after", "", "only inventories collected after this time (2006-01-02 or RFC 3339)")
+	fs.StringVar(&f.before, "before", "", "only inventories collected before this time (2006-01-02 or RFC 3339)")
+}
+
+// request returns a ListInventoriesRequest for the filter.
+func (f *inventoryFilter) request() (*collectorv1.ListInventoriesRequest, error) {
+	req := &collectorv1.ListInventoriesRequest{
+		Site:        f.site,
+		Hostname:    f.hostname,
+		Username:    f.username,
+		SystemUuid:  f.uuid,
+		DeviceClass: f.class,
+	}
+	if f.after != "" {
+		t, err := parseTime(f.after)
+		if err != nil {
+			return nil, fmt.Errorf("--after: %w", err)
+		}
+		req.CollectedAfter = timestamppb.New(t)
+	}
+	if f.before != "" {
+		t, err := parseTime(f.before)
+		if err != nil {
+			return nil, fmt.Errorf("--before: %w", err)
+		}
+		req.CollectedBefore = timestamppb.New(t)
+	}
+	return req, nil
+}
+
+var listFlags struct {
+	inventoryFilter
+	pageSize   int32
+	page       int32
+	pageToken  string
+	exactCount bool
+}
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List stored inventories, newest first",
+	Args:  cobra.NoArgs,
+	RunE:  runList,
+}
+
+var getCmd = &cobra.Command{
+	Use:   "get <id>",
+	Short: "Print a stored inventory as JSON, or YAML with -o yaml",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runGet,
+}
+
+var deleteCmd = &cobra.Command{
+	Use:   "delete <id>...",
+	Short: "Delete stored inventories",
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  runDelete,
+}
+
+func init() {
+	listFlags.addFlags(listCmd)
+	f := listCmd.Flags()
+	f.Int32Var(&listFlags.pageSize, "page-size", 50, "inventories per page")
+	f.Int32Var(&listFlags.page, "page", 1, "page number")
+	f.StringVar(&listFlags.pageToken, "page-token", "", "continue after the page that printed this token, instead of --page")
+	f.BoolVar(&listFlags.exactCount, "exact-count", false, "count the matching inventories rather than accept a count the collector cached")
+
+	rootCmd.AddCommand(listCmd, getCmd, deleteCmd)
+}
+
+func runList(cmd *cobra.Command, _ []string) error {
+	req, err := listFlags.request()
+	if err != nil {
+		return err
+	}
+	req.PageSize, req.Page, req.PageToken = listFlags.pageSize, listFlags.page, listFlags.pageToken
+	req.ExactCount = listFlags.exactCount
+
+	ctx, client, done, err := collectorClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	resp, err := client.ListInventories(ctx, req)
+	if err != nil {
+		return fmt.Errorf("list inventories: %w", err)
+	}
+
+	if err := output.List(os.Stdout, outputFormat, resp.Inventories, output.InventoryColumns); err != nil {
+		return err
+	}
+	switch {
+	case resp.NextPageToken != "":
+		fmt.Fprintf(os.Stderr, "showing %d of %d inventories; next page: --page-token %s\n", len(resp.Inventories), resp.TotalCount, resp.NextPageToken)
+	case int(resp.TotalCount) > len(resp.Inventories) && listFlags.pageToken == "":
+		fmt.Fprintf(os.Stderr, "showing %d of %d inventories (page %d)\n", len(resp.Inventories), resp.TotalCount, listFlags.page)
+	}
+	return nil
+}
+
+func runGet(cmd *cobra.Command, args []string) error {
+	id, err := parseID(args[0])
+	if err != nil {
+		return err
+	}
+
+	ctx, client, done, err := collectorClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	resp, err := client.GetInventory(ctx, &collectorv1.GetInventoryRequest{Id: id})
+	if err != nil {
+		return fmt.Errorf("get inventory %d: %w", id, err)
+	}
+
+	return output.Message(os.Stdout, outputFormat, resp)
+}
+
+func runDelete(cmd *cobra.Command, args []string) error {
+	ids := make([]int64, len(args))
+	for i, arg := range args {
+		id, err := parseID(arg)
+		if err != nil {
+			return err
+		}
+		ids[i] = id
+	}
+
+	ctx, client, done, err := adminClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	for _, id := range ids {
+		if _, err := client.DeleteInventory(ctx, &collectorv1.DeleteInventoryRequest{Id: id}); err != nil {
+			return fmt.Errorf("delete inventory %d: %w", id, err)
+		}
+		fmt.Printf("Deleted inventory %d\n", id)
+	}
+	return nil
+}
+
+func parseID(s string) (int64, error) {
+	id, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid inventory ID %q", s)
+	}
+	return id, nil
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+	"github.com/go-tangra/go-tangra-inventory/internal/output"
+)
+
+var logsFlags struct {
+	site     string
+	clientID string
+	kib      int64
+}
+
+var logsCmd = &cobra.Command{
+	Use:   "logs [hostname|uuid]",
+	Short: "Fetch the recent log output of a connected agent",
+	Long: `Ask a connected agent for the most recent output of its log and print it,
+to troubleshoot an endpoint without logging on to it. The agent is selected
+by hostname or by client ID (system UUID) like refresh selects it.
+
+Agents keep the last 256 KiB of their log in memory by default (see the
+agent's -log-buffer flag); --kib fetches up to 1024 KiB of it. Output from
+before a restart of the agent is lost.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runLogs,
+}
+
+func init() {
+	f := logsCmd.Flags()
+	f.StringVar(&logsFlags.site, "site", "", "site of the agent")
+	f.StringVar(&logsFlags.clientID, "client-id", "", "client ID (system UUID) of the agent")
+	f.Int64Var(&logsFlags.kib, "kib", 64, "KiB of the most recent log output to fetch")
+
+	rootCmd.AddCommand(logsCmd)
+}
+
+func runLogs(cmd *cobra.Command, args []string) error {
+	if logsFlags.kib <= 0 {
+		return errors.New("--kib must be positive")
+	}
+	req := &collectorv1.GetAgentLogsRequest{
+		Site:     logsFlags.site,
+		ClientId: logsFlags.clientID,
+		MaxBytes: logsFlags.kib << 10,
+	}
+	switch {
+	case len(args) == 0 && req.ClientId == "":
+		return errors.New("a hostname, UUID or --client-id is required")
+	case len(args) > 0:
+		if _, err := uuid.Parse(args[0]); err == nil && req.ClientId == "" {
+			req.ClientId = args[0]
+		} else {
+			req.Hostname = args[0]
+		}
+	}
+
+	ctx, client, done, err := adminClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	resp, err := client.GetAgentLogs(ctx, req)
+	if err != nil {
+		return fmt.Errorf("get agent logs: %w", err)
+	}
+
+	if outputFormat == output.JSON || outputFormat == output.YAML {
+		return output.Message(os.Stdout, outputFormat, resp)
+	}
+	if resp.Truncated {
+		fmt.Fprintln(os.Stderr, "(older output omitted)")
+	}
+	_, err = os.Stdout.Write(resp.Logs)
+	return err
+}
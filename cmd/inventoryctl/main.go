@@ -0,0 +1,162 @@
+// Command inventoryctl manages an inventory collector through its gRPC API:
+// listing, inspecting and deleting stored inventories, and refreshing
+// connected agents.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+	"github.com/go-tangra/go-tangra-inventory/internal/output"
+	"github.com/go-tangra/go-tangra-inventory/internal/sender"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+var (
+	version    = "dev"
+	commitHash = "unknown"
+	buildDate  = "unknown"
+)
+
+// Connection settings shared by all commands.
+var (
+	serverAddr  string
+	adminAddr   string
+	apiSecret   string
+	adminSecret string
+	useTLS      bool
+	caCert      string
+	clientCert  string
+	clientKey   string
+	timeout     time.Duration
+)
+
+// outputFormat is the -o format of listings and of get.
+var outputFormat string
+
+var rootCmd = &cobra.Command{
+	Use:   "inventoryctl",
+	Short: "Manage an inventory collector through its gRPC API",
+	Long: `inventoryctl talks to an inventory collector's gRPC API for day-to-day
+operations: listing, inspecting and deleting inventories, and refreshing
+connected agents.
+
+Connection flags default to the INVENTORYCTL_SERVER, INVENTORYCTL_ADMIN_SERVER,
+INVENTORYCTL_API_SECRET and INVENTORYCTL_ADMIN_SECRET environment variables.`,
+	SilenceUsage: true,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return output.CheckList(outputFormat)
+	},
+}
+
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Print version information",
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Printf("inventoryctl %s (commit: %s, built: %s)\n", version, commitHash, buildDate)
+	},
+}
+
+func init() {
+	pf := rootCmd.PersistentFlags()
+	pf.StringVar(&serverAddr, "server", envOr("INVENTORYCTL_SERVER", "localhost:9550"), "collector gRPC address")
+	pf.StringVar(&adminAddr, "admin-server", os.Getenv("INVENTORYCTL_ADMIN_SERVER"), "collector admin gRPC address, when admin_listen is configured (default --server)")
+	pf.StringVar(&apiSecret, "api-secret", os.Getenv("INVENTORYCTL_API_SECRET"), "API secret or site token")
+	pf.StringVar(&adminSecret, "admin-secret", os.Getenv("INVENTORYCTL_ADMIN_SECRET"), "secret for the admin listener (default --api-secret)")
+	pf.BoolVar(&useTLS, "tls", false, "connect over TLS")
+	pf.StringVar(&caCert, "ca-cert", "", "PEM CA bundle to verify the collector certificate with (implies --tls)")
+	pf.StringVar(&clientCert, "client-cert", "", "PEM client certificate for collectors that require mutual TLS (implies --tls)")
+	pf.StringVar(&clientKey, "client-key", "", "PEM private key of --client-cert")
+	pf.DurationVar(&timeout, "timeout", 30*time.Second, "timeout for each API call (0 = none)")
+	pf.StringVarP(&outputFormat, "output", "o", output.Table, output.ListUsage)
+
+	rootCmd.AddCommand(versionCmd)
+}
+
+func main() {
+	if err := rootCmd.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+func envOr(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
+
+// dial connects to addr and returns a context carrying secret as the
+// x-api-secret header, bounded by --timeout if set. The caller must call the
+// returned cleanup function.
+func dial(cmd *cobra.Command, addr, secret string) (context.Context, *grpc.ClientConn, func(), error) {
+	opts := sender.Options{
+		TLS: sender.TLS{
+			Enabled:  useTLS || caCert != "" || clientCert != "",
+			CAFile:   caCert,
+			CertFile: clientCert,
+			KeyFile:  clientKey,
+		},
+	}
+	conn, err := sender.Dial(addr, opts)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	ctx, cancel := cmd.Context(), context.CancelFunc(func() {})
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	}
+	if secret != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "x-api-secret", secret)
+	}
+	return ctx, conn, func() {
+		cancel()
+		conn.Close()
+	}, nil
+}
+
+// collectorClient connects to the collector service.
+func collectorClient(cmd *cobra.Command) (context.Context, collectorv1.InventoryCollectorServiceClient, func(), error) {
+	ctx, conn, done, err := dial(cmd, serverAddr, apiSecret)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return ctx, collectorv1.NewInventoryCollectorServiceClient(conn), done, nil
+}
+
+// adminClient connects to the admin service, on the admin listener when one
+// is configured.
+func adminClient(cmd *cobra.Command) (context.Context, collectorv1.InventoryAdminServiceClient, func(), error) {
+	addr, secret := serverAddr, apiSecret
+	if adminAddr != "" {
+		addr = adminAddr
+	}
+	if adminSecret != "" {
+		secret = adminSecret
+	}
+	ctx, conn, done, err := dial(cmd, addr, secret)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return ctx, collectorv1.NewInventoryAdminServiceClient(conn), done, nil
+}
+
+// parseTime accepts an RFC 3339 timestamp or a local date (2006-01-02).
+func parseTime(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation(time.DateOnly, s, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q (use 2006-01-02 or RFC 3339)", s)
+	}
+	return t, nil
+}
@@ -0,0 +1,473 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var seedFlags struct {
+	hosts       int
+	history     int
+	site        string
+	prefix      string
+	seed        uint64
+	concurrency int
+}
+
+var seedCmd = &cobra.Command{
+	Use:   "seed",
+	Short: "Submit generated inventories of a fake fleet",
+	Long: `Generate realistic inventories for a fleet of fake hosts and submit them,
+so that performance and UI work can be done without real agents.
+
+Hosts get a mix of laptops, desktops and servers from several vendors, with
+varied processors, memory and monitors. --history submits one inventory per
+host per day for that many days, oldest first, with the occasional memory
+upgrade, BIOS update, monitor swap or new user along the way, so that
+history, diffs and alerts have something to show.
+
+The same --seed generates the same fleet. Hostnames start with --prefix;
+remove the hosts again with "inventory-collector purge --hostname 'seed-*'".
+
+--timeout applies to each submission rather than to the whole run.`,
+	Args: cobra.NoArgs,
+	RunE: runSeed,
+}
+
+func init() {
+	f := seedCmd.Flags()
+	f.IntVar(&seedFlags.hosts, "hosts", 100, "number of hosts to generate")
+	f.IntVar(&seedFlags.history, "history", 1, "days of history to submit per host (1 = current inventory only)")
+	f.StringVar(&seedFlags.site, "site", "", "site of the generated hosts")
+	f.StringVar(&seedFlags.prefix, "prefix", "seed-", "hostname prefix of the generated hosts")
+	f.Uint64Var(&seedFlags.seed, "seed", 1, "seed of the generated fleet")
+	f.IntVar(&seedFlags.concurrency, "concurrency", 8, "number of hosts submitted in parallel")
+
+	rootCmd.AddCommand(seedCmd)
+}
+
+func runSeed(cmd *cobra.Command, _ []string) error {
+	switch {
+	case seedFlags.hosts <= 0:
+		return errors.New("--hosts must be positive")
+	case seedFlags.history <= 0:
+		return errors.New("--history must be positive")
+	case seedFlags.concurrency <= 0:
+		return errors.New("--concurrency must be positive")
+	}
+
+	perCall := timeout
+	timeout = 0
+	ctx, client, done, err := collectorClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	start := time.Now()
+	submitted, err := seedFleet(ctx, client, perCall)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Submitted %d inventories for %d hosts in %s\n",
+		submitted, seedFlags.hosts, time.Since(start).Round(time.Millisecond))
+	return nil
+}
+
+// seedFleet submits the fleet described by seedFlags and returns the number
+// of inventories submitted.
+func seedFleet(ctx context.Context, client collectorv1.InventoryCollectorServiceClient, perCall time.Duration) (int64, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		submitted atomic.Int64
+		once      sync.Once
+		firstErr  error
+		wg        sync.WaitGroup
+	)
+	jobs := make(chan int)
+	now := time.Now()
+
+	for range min(seedFlags.concurrency, seedFlags.hosts) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if err := seedHost(ctx, client, i, now, perCall, &submitted); err != nil {
+					once.Do(func() {
+						firstErr = err
+						cancel()
+					})
+				}
+			}
+		}()
+	}
+
+	ticker := time.NewTicker(5 * time.Second)
+	defer ticker.Stop()
+	total := int64(seedFlags.hosts * seedFlags.history)
+feed:
+	for i := range seedFlags.hosts {
+		for {
+			select {
+			case jobs <- i:
+				continue feed
+			case <-ctx.Done():
+				break feed
+			case <-ticker.C:
+				fmt.Fprintf(os.Stderr, "Submitted %d of %d inventories\n", submitted.Load(), total)
+			}
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if firstErr != nil {
+		return submitted.Load(), fmt.Errorf("seed host: %w", firstErr)
+	}
+	return submitted.Load(), ctx.Err()
+}
+
+// seedHost generates host i and submits its history, oldest first, the last
+// inventory collected at about now.
+func seedHost(ctx context.Context, client collectorv1.InventoryCollectorServiceClient, i int, now time.Time, perCall time.Duration, submitted *atomic.Int64) error {
+	r := rand.New(rand.NewPCG(seedFlags.seed, uint64(i)))
+	inv := newSeedInventory(r, fmt.Sprintf("%s%05d", seedFlags.prefix, i+1), seedFlags.site)
+
+	for day := seedFlags.history - 1; day >= 0; day-- {
+		if day < seedFlags.history-1 {
+			evolveSeedInventory(r, inv)
+		}
+		// Spread the collections over the day like agents started at random.
+		collected := now.AddDate(0, 0, -day).Add(-time.Duration(r.Int64N(int64(6 * time.Hour))))
+		inv.CollectedAt = timestamppb.New(collected)
+
+		callCtx, cancel := ctx, context.CancelFunc(func() {})
+		if perCall > 0 {
+			callCtx, cancel = context.WithTimeout(ctx, perCall)
+		}
+		_, err := client.SubmitInventory(callCtx, &collectorv1.SubmitInventoryRequest{Inventory: inv})
+		cancel()
+		if err != nil {
+			return fmt.Errorf("%s: %w", inv.Hostname, err)
+		}
+		submitted.Add(1)
+	}
+	return nil
+}
+
+// seedModel is a machine model the generated hosts are drawn from.
+type seedModel struct {
+	manufacturer string
+	product      string
+	family       string
+	board        string
+	biosVendor   string
+	// biosVersion formats the BIOS version from a release counter.
+	biosVersion string
+	kind        seedKind
+}
+
+type seedKind int
+
+const (
+	seedLaptop seedKind = iota
+	seedDesktop
+	seedServer
+)
+
+// seedModels weighs laptops and desktops over servers, roughly like an
+// office fleet.
+var seedModels = []seedModel{
+	{"Dell Inc.", "Latitude 5520", "Latitude", "0R6JFH", "Dell Inc.", "1.%d.0", seedLaptop},
+	{"Dell Inc.", "Latitude 7430", "Latitude", "0M4RJP", "Dell Inc.", "1.%d.1", seedLaptop},
+	{"Dell Inc.", "OptiPlex 7090", "OptiPlex", "0X9VWP", "Dell Inc.", "1.%d.2", seedDesktop},
+	{"HP", "HP EliteBook 840 G8 Notebook PC", "103C_5336AN HP EliteBook", "880D", "HP", "T37 Ver. 01.%02d.00", seedLaptop},
+	{"HP", "HP ProDesk 600 G6 Small Form Factor PC", "103C_53307F HP ProDesk", "8715", "HP", "S22 Ver. 02.%02d.00", seedDesktop},
+	{"LENOVO", "20W0005QGE", "ThinkPad T14 Gen 2i", "20W0005QGE", "LENOVO", "N34ET%dW (1.%[1]d )", seedLaptop},
+	{"LENOVO", "11DT0041GE", "ThinkCentre M70q", "3140", "LENOVO", "M3CKT%dA", seedDesktop},
+	{"Dell Inc.", "PowerEdge R650", "PowerEdge", "0PYXKY", "Dell Inc.", "1.%d.2", seedServer},
+	{"HPE", "ProLiant DL360 Gen10", "ProLiant", "ProLiant DL360 Gen10", "HPE", "U32 v2.%02d", seedServer},
+	{"Supermicro", "SYS-1029P-WTR", "SMC X11", "X11DDW-L", "American Megatrends Inc.", "3.%d", seedServer},
+}
+
+type seedCPU struct {
+	manufacturer string
+	version      string
+	speedMHz     uint32
+	cores        uint32
+	threads      uint32
+}
+
+var (
+	seedClientCPUs = []seedCPU{
+		{"Intel(R) Corporation", "11th Gen Intel(R) Core(TM) i5-1145G7 @ 2.60GHz", 2600, 4, 8},
+		{"Intel(R) Corporation", "11th Gen Intel(R) Core(TM) i7-1185G7 @ 3.00GHz", 3000, 4, 8},
+		{"Intel(R) Corporation", "11th Gen Intel(R) Core(TM) i7-11700 @ 2.50GHz", 2500, 8, 16},
+		{"Intel(R) Corporation", "12th Gen Intel(R) Core(TM) i5-1245U", 1600, 10, 12},
+		{"Advanced Micro Devices, Inc.", "AMD Ryzen 7 PRO 5850U with Radeon Graphics", 1900, 8, 16},
+		{"Advanced Micro Devices, Inc.", "AMD Ryzen 5 PRO 5650G with Radeon Graphics", 3900, 6, 12},
+	}
+	seedServerCPUs = []seedCPU{
+		{"Intel(R) Corporation", "Intel(R) Xeon(R) Gold 6338 CPU @ 2.00GHz", 2000, 32, 64},
+		{"Intel(R) Corporation", "Intel(R) Xeon(R) Silver 4314 CPU @ 2.40GHz", 2400, 16, 32},
+		{"Intel(R) Corporation", "Intel(R) Xeon(R) Gold 6226R CPU @ 2.90GHz", 2900, 16, 32},
+	}
+)
+
+type seedDIMM struct {
+	manufacturer string
+	partNumber   string
+}
+
+var seedDIMMs = []seedDIMM{
+	{"Samsung", "M471A2K43EB1-CWE"},
+	{"SK Hynix", "HMA82GS6DJR8N-XN"},
+	{"Micron", "MTA16ATF2G64HZ-3G2J1"},
+	{"Kingston", "KF432C16BB/16"},
+}
+
+var seedMonitors = []*collectorv1.MonitorInfo{
+	{Manufacturer: "DEL", Model: "DELL P2419H"},
+	{Manufacturer: "DEL", Model: "DELL U2720Q"},
+	{Manufacturer: "SAM", Model: "S24R650"},
+	{Manufacturer: "LEN", Model: "T24i-20"},
+	{Manufacturer: "HPN", Model: "HP E24 G4"},
+	{Manufacturer: "GSM", Model: "LG ULTRAFINE"},
+}
+
+var seedUsers = []string{
+	"anna", "bjorn", "chen", "dana", "emil", "farah", "georg", "hana", "ivan", "julia",
+	"kofi", "lena", "marek", "nadia", "oskar", "priya", "quinn", "rosa", "sven", "tariq",
+}
+
+const gib = 1 << 30
+
+// newSeedInventory generates the initial inventory of a host.
+func newSeedInventory(r *rand.Rand, hostname, site string) *collectorv1.Inventory {
+	m := seedModels[r.IntN(len(seedModels))]
+	serial := seedSerial(r)
+
+	inv := &collectorv1.Inventory{
+		Hostname:      hostname,
+		Site:          site,
+		SmbiosVersion: &collectorv1.VersionInfo{Major: 3, Minor: int32(2 + r.IntN(3))},
+		Bios: &collectorv1.BIOSInfo{
+			Vendor: m.biosVendor,
+		},
+		System: &collectorv1.SystemInfo{
+			Manufacturer: m.manufacturer,
+			ProductName:  m.product,
+			SerialNumber: serial,
+			Uuid:         seedUUID(r),
+			WakeUpType:   "Power Switch",
+			SkuNumber:    m.product,
+			Family:       m.family,
+		},
+		Baseboard: &collectorv1.BaseboardInfo{
+			Manufacturer: m.manufacturer,
+			Product:      m.board,
+			Version:      fmt.Sprintf("A%02d", r.IntN(10)),
+			SerialNumber: "/" + serial + "/CNWS" + seedSerial(r) + "/",
+			BoardType:    "Motherboard",
+		},
+		Chassis: &collectorv1.ChassisInfo{
+			Manufacturer: m.manufacturer,
+			SerialNumber: serial,
+		},
+		Privileges: &collectorv1.AgentPrivileges{Elevated: true},
+	}
+	setSeedBIOS(inv, m, 5+r.IntN(15), r)
+
+	var cpu seedCPU
+	sockets := 1
+	if m.kind == seedServer {
+		cpu = seedServerCPUs[r.IntN(len(seedServerCPUs))]
+		sockets = 1 + r.IntN(2)
+	} else {
+		cpu = seedClientCPUs[r.IntN(len(seedClientCPUs))]
+	}
+	for s := range sockets {
+		inv.Processors = append(inv.Processors, &collectorv1.ProcessorInfo{
+			SocketDesignation: fmt.Sprintf("CPU%d", s+1),
+			Manufacturer:      cpu.manufacturer,
+			Version:           cpu.version,
+			MaxSpeedMhz:       4800,
+			CurrentSpeedMhz:   cpu.speedMHz,
+			SocketPopulated:   true,
+			CoreCount:         cpu.cores,
+			CoreEnabled:       cpu.cores,
+			ThreadCount:       cpu.threads,
+		})
+	}
+
+	inv.Memory = newSeedMemory(r, m.kind, sockets)
+
+	monitors := 0
+	switch m.kind {
+	case seedLaptop:
+		monitors = r.IntN(2)
+	case seedDesktop:
+		monitors = 1 + r.IntN(2)
+	}
+	for range monitors {
+		inv.Monitor = append(inv.Monitor, newSeedMonitor(r))
+	}
+	if m.kind != seedServer {
+		inv.Username = seedUsers[r.IntN(len(seedUsers))]
+	}
+	return inv
+}
+
+// newSeedMemory generates the memory of a host, half of its slots filled.
+func newSeedMemory(r *rand.Rand, kind seedKind, sockets int) *collectorv1.MemoryInfo {
+	slots, formFactor := 2, "SODIMM"
+	sizes := []uint64{8, 16, 32}
+	switch kind {
+	case seedDesktop:
+		slots, formFactor = 4, "DIMM"
+	case seedServer:
+		slots, formFactor = 16*sockets, "DIMM"
+		sizes = []uint64{32, 64}
+	}
+
+	mem := &collectorv1.MemoryInfo{
+		Array: &collectorv1.PhysicalMemoryArray{
+			Location:              "System Board Or Motherboard",
+			Use:                   "System Memory",
+			ErrorCorrection:       "None",
+			MaximumCapacity:       fmt.Sprintf("%d GB", uint64(slots)*sizes[len(sizes)-1]),
+			NumberOfMemoryDevices: uint32(slots),
+		},
+	}
+	if kind == seedServer {
+		mem.Array.ErrorCorrection = "Multi-bit ECC"
+	}
+
+	dimm := seedDIMMs[r.IntN(len(seedDIMMs))]
+	size := sizes[r.IntN(len(sizes))] * gib
+	speed := []uint32{2666, 3200}[r.IntN(2)]
+	for s := range max(1, slots/2) {
+		mem.Modules = append(mem.Modules, newSeedModule(r, s, formFactor, dimm, size, speed))
+	}
+	sumSeedMemory(mem)
+	return mem
+}
+
+func newSeedModule(r *rand.Rand, slot int, formFactor string, dimm seedDIMM, size uint64, speed uint32) *collectorv1.MemoryModule {
+	return &collectorv1.MemoryModule{
+		DeviceLocator:      fmt.Sprintf("DIMM %c%d", 'A'+slot/2, slot%2+1),
+		BankLocator:        fmt.Sprintf("BANK %d", slot),
+		CapacityBytes:      size,
+		FormFactor:         formFactor,
+		MemoryType:         "DDR4",
+		TypeDetail:         "Synchronous",
+		SpeedMtS:           speed,
+		ConfiguredSpeedMtS: speed,
+		Manufacturer:       dimm.manufacturer,
+		SerialNumber:       fmt.Sprintf("%08X", r.Uint32()),
+		PartNumber:         dimm.partNumber,
+		MinimumVoltage:     "1.2 V",
+		MaximumVoltage:     "1.2 V",
+		ConfiguredVoltage:  "1.2 V",
+		TotalWidth:         "64 bits",
+		DataWidth:          "64 bits",
+	}
+}
+
+func sumSeedMemory(mem *collectorv1.MemoryInfo) {
+	mem.TotalPhysicalBytes = 0
+	for _, m := range mem.Modules {
+		mem.TotalPhysicalBytes += m.CapacityBytes
+	}
+	mem.TotalPhysicalGb = float64(mem.TotalPhysicalBytes) / gib
+}
+
+func newSeedMonitor(r *rand.Rand) *collectorv1.MonitorInfo {
+	m := seedMonitors[r.IntN(len(seedMonitors))]
+	return &collectorv1.MonitorInfo{
+		Manufacturer: m.Manufacturer,
+		Model:        m.Model,
+		SerialNumber: seedSerial(r),
+	}
+}
+
+// setSeedBIOS sets the BIOS version of release, released about a quarter
+// apart.
+func setSeedBIOS(inv *collectorv1.Inventory, m seedModel, release int, r *rand.Rand) {
+	inv.Bios.Version = fmt.Sprintf(m.biosVersion, release)
+	date := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 3*release, r.IntN(28))
+	inv.Bios.ReleaseDate = date.Format("01/02/2006")
+}
+
+// evolveSeedInventory applies the occasional change a host sees from one
+// day to the next.
+func evolveSeedInventory(r *rand.Rand, inv *collectorv1.Inventory) {
+	switch n := r.IntN(1000); {
+	case n < 10:
+		// Memory upgrade: fill the free slots with modules like the
+		// installed ones.
+		mem := inv.Memory
+		first := mem.Modules[0]
+		for s := len(mem.Modules); s < int(mem.Array.NumberOfMemoryDevices); s++ {
+			dimm := seedDIMM{first.Manufacturer, first.PartNumber}
+			mem.Modules = append(mem.Modules, newSeedModule(r, s, first.FormFactor, dimm, first.CapacityBytes, first.SpeedMtS))
+		}
+		sumSeedMemory(mem)
+	case n < 25:
+		// BIOS update to the next release after the installed one.
+		released, err := time.Parse("01/02/2006", inv.Bios.ReleaseDate)
+		if err != nil {
+			return
+		}
+		release := (released.Year()-2019)*4 + int(released.Month()-1)/3 + 1
+		for _, m := range seedModels {
+			if m.product == inv.System.ProductName {
+				setSeedBIOS(inv, m, release, r)
+				break
+			}
+		}
+	case n < 35:
+		if len(inv.Monitor) > 0 {
+			inv.Monitor[r.IntN(len(inv.Monitor))] = newSeedMonitor(r)
+		}
+	case n < 40:
+		if inv.Username != "" {
+			inv.Username = seedUsers[r.IntN(len(seedUsers))]
+		}
+	}
+}
+
+// seedSerial returns a 7-character service tag style serial number.
+func seedSerial(r *rand.Rand) string {
+	const alphabet = "0123456789BCDFGHJKLMNPQRSTVWXYZ"
+	b := make([]byte, 7)
+	for i := range b {
+		b[i] = alphabet[r.IntN(len(alphabet))]
+	}
+	return string(b)
+}
+
+// seedUUID returns a random version 4 UUID in SMBIOS notation.
+func seedUUID(r *rand.Rand) string {
+	var b [16]byte
+	for i := range b {
+		b[i] = byte(r.Uint32())
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%X-%X-%X-%X-%X", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
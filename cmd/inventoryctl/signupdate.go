@@ -0,0 +1,104 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+	"github.com/go-tangra/go-tangra-inventory/internal/update"
+
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var signUpdateFlags struct {
+	key         string
+	version     string
+	os          string
+	arch        string
+	downloadURL string
+}
+
+var signUpdateCmd = &cobra.Command{
+	Use:   "sign-update <executable>",
+	Short: "Sign an agent release for self-update",
+	Long: `Sign an agent release with the private update key and print it as the
+payload of an update command:
+
+  inventoryctl cmd pc-042 update --payload "$(inventoryctl sign-update \
+    --key update.key --version v1.5.0 --os windows --arch amd64 \
+    --download-url https://... inventory.exe)"
+
+The signature covers the version, the platform and the executable's SHA-256
+digest, so agents refuse a release altered in any of them. Agents also refuse
+releases that are not newer than the version they run. --key names a file
+holding the base64 Ed25519 private key or its 32-byte seed; agents are given
+the matching public key with -update-key. Nothing is sent to the collector.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSignUpdate,
+}
+
+func init() {
+	f := signUpdateCmd.Flags()
+	f.StringVar(&signUpdateFlags.key, "key", "", "file holding the base64 Ed25519 private update key")
+	f.StringVar(&signUpdateFlags.version, "version", "", "version of the release, e.g. v1.5.0")
+	f.StringVar(&signUpdateFlags.os, "os", "", "GOOS the executable was built for, e.g. windows")
+	f.StringVar(&signUpdateFlags.arch, "arch", "", "GOARCH the executable was built for, e.g. amd64")
+	f.StringVar(&signUpdateFlags.downloadURL, "download-url", "", "URL agents download the executable from")
+	for _, name := range []string{"key", "version", "os", "arch", "download-url"} {
+		signUpdateCmd.MarkFlagRequired(name)
+	}
+
+	rootCmd.AddCommand(signUpdateCmd)
+}
+
+func runSignUpdate(cmd *cobra.Command, args []string) error {
+	key, err := readPrivateKey(signUpdateFlags.key)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(data)
+
+	u := &collectorv1.AgentUpdate{
+		Version:     signUpdateFlags.version,
+		DownloadUrl: signUpdateFlags.downloadURL,
+		Sha256:      hex.EncodeToString(sum[:]),
+		Os:          signUpdateFlags.os,
+		Arch:        signUpdateFlags.arch,
+	}
+	u.Signature = update.Sign(key, u)
+
+	payload, err := protojson.Marshal(&collectorv1.InventoryCommand{Update: u})
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(payload))
+	return nil
+}
+
+// readPrivateKey reads a base64 Ed25519 private key, or its seed, from path.
+func readPrivateKey(path string) (ed25519.PrivateKey, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(b)))
+	switch {
+	case err != nil:
+	case len(key) == ed25519.SeedSize:
+		return ed25519.NewKeyFromSeed(key), nil
+	case len(key) == ed25519.PrivateKeySize:
+		return ed25519.PrivateKey(key), nil
+	}
+	return nil, fmt.Errorf("%s: expected a base64 Ed25519 private key", path)
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+	"github.com/go-tangra/go-tangra-inventory/internal/output"
+)
+
+var statsSite string
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Print fleet breakdowns by model, memory size and agent version",
+	Long: `Print fleet breakdowns from the latest inventory of each host: hardware
+models, installed memory rounded up to a power of two GiB, the versions of
+connected agents, and online and offline host counts.`,
+	Args: cobra.NoArgs,
+	RunE: runStats,
+}
+
+func init() {
+	statsCmd.Flags().StringVar(&statsSite, "site", "", "only hosts of this site")
+
+	rootCmd.AddCommand(statsCmd)
+}
+
+func runStats(cmd *cobra.Command, _ []string) error {
+	ctx, client, done, err := collectorClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	resp, err := client.GetFleetReport(ctx, &collectorv1.GetFleetReportRequest{Site: statsSite})
+	if err != nil {
+		return fmt.Errorf("get fleet report: %w", err)
+	}
+	if outputFormat == output.JSON || outputFormat == output.YAML {
+		return output.Message(os.Stdout, outputFormat, resp)
+	}
+
+	fmt.Printf("Hosts: %d (%d online, %d offline)\n", resp.Hosts, resp.Online, resp.Hosts-resp.Online)
+	printCounts("MODEL", resp.Models, resp.Hosts)
+	printCounts("MEMORY", resp.Memory, resp.Hosts)
+	printCounts("AGENT VERSION", resp.AgentVersions, resp.Online)
+	return nil
+}
+
+// printCounts prints one breakdown as a table with each value's share of
+// total.
+func printCounts(title string, counts []*collectorv1.FleetCount, total int32) {
+	if len(counts) == 0 {
+		return
+	}
+	fmt.Println()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "%s\tHOSTS\tSHARE\n", title)
+	for _, c := range counts {
+		fmt.Fprintf(w, "%s\t%d\t%.1f%%\n", c.Value, c.Hosts, 100*float64(c.Hosts)/float64(max(total, 1)))
+	}
+	w.Flush()
+}
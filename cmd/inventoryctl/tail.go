@@ -0,0 +1,119 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+	"github.com/go-tangra/go-tangra-inventory/internal/output"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var tailFlags struct {
+	site  string
+	count int
+}
+
+var tailCmd = &cobra.Command{
+	Use:   "tail [hostname]",
+	Short: "Print inventories as they are submitted",
+	Long: `Follow the inventories stored by the collector and print one line per
+submission: when it was stored, the host and its user, and the hardware
+changes from the host's previous inventory. Useful to watch a rollout or a
+refresh arrive.
+
+Only submissions from now on are shown. With -o json or yaml each submission
+is printed as a document instead. Stop with Ctrl-C, or after --count
+submissions.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runTail,
+}
+
+func init() {
+	f := tailCmd.Flags()
+	f.StringVar(&tailFlags.site, "site", "", "only inventories of this site")
+	f.IntVarP(&tailFlags.count, "count", "n", 0, "exit after this many submissions (0 = never)")
+
+	rootCmd.AddCommand(tailCmd)
+}
+
+func runTail(cmd *cobra.Command, args []string) error {
+	if tailFlags.count < 0 {
+		return errors.New("--count must not be negative")
+	}
+	req := &collectorv1.WatchInventoriesRequest{Site: tailFlags.site}
+	if len(args) > 0 {
+		req.Hostname = args[0]
+	}
+
+	timeout = 0
+	ctx, client, done, err := collectorClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer done()
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	stream, err := client.WatchInventories(ctx, req)
+	if err != nil {
+		return fmt.Errorf("watch inventories: %w", err)
+	}
+	for n := 0; tailFlags.count == 0 || n < tailFlags.count; n++ {
+		sub, err := stream.Recv()
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, io.EOF) || status.Code(err) == codes.Canceled {
+				return nil
+			}
+			return fmt.Errorf("watch inventories: %w", err)
+		}
+
+		if outputFormat == output.JSON || outputFormat == output.YAML {
+			if outputFormat == output.YAML {
+				fmt.Println("---")
+			}
+			if err := output.Message(os.Stdout, outputFormat, sub); err != nil {
+				return err
+			}
+			continue
+		}
+		fmt.Println(submissionLine(sub))
+	}
+	return nil
+}
+
+// submissionLine describes sub in one line.
+func submissionLine(sub *collectorv1.InventorySubmission) string {
+	host := sub.Hostname
+	if sub.Site != "" {
+		host = sub.Site + "/" + host
+	}
+	var changes string
+	switch {
+	case sub.First:
+		changes = "first inventory"
+	case len(sub.Changes) == 0:
+		changes = "no changes"
+	default:
+		descs := make([]string, len(sub.Changes))
+		for i, c := range sub.Changes {
+			descs[i] = c.Description
+		}
+		noun := "changes"
+		if len(descs) == 1 {
+			noun = "change"
+		}
+		changes = fmt.Sprintf("%d %s: %s", len(descs), noun, strings.Join(descs, "; "))
+	}
+	return fmt.Sprintf("%s  %-6d %s  user=%s  %s", sub.StoredAt.AsTime().Local().Format(time.TimeOnly),
+		sub.Id, host, output.OrDash(sub.Username), changes)
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+	"github.com/go-tangra/go-tangra-inventory/internal/output"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var tokenCmd = &cobra.Command{
+	Use:   "token",
+	Short: "Manage API tokens",
+	Long: `Manage the collector's API tokens. Tokens are accepted wherever
+client_secret or api_secret is, so that credentials can be issued, rotated
+and revoked without editing the configuration and restarting the collector.
+
+A token's role decides what it may do:
+
+  agent   submit inventories and receive commands, like client_secret
+  api     every RPC and REST call except the management ones
+  admin   everything, also on the admin listener
+
+Issuing the first token turns on authentication on a collector that has no
+secrets configured.`,
+}
+
+var tokenCreateFlags struct {
+	role    string
+	sites   []string
+	expires string
+}
+
+var tokenCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Issue a new token and print it",
+	Long: `Issue a new token and print it. The token cannot be shown again; the
+collector only keeps a hash of it.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTokenCreate,
+}
+
+var tokenListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the issued tokens",
+	Args:  cobra.NoArgs,
+	RunE:  runTokenList,
+}
+
+var tokenRevokeCmd = &cobra.Command{
+	Use:   "revoke <id>...",
+	Short: "Revoke tokens with immediate effect",
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  runTokenRevoke,
+}
+
+func init() {
+	f := tokenCreateCmd.Flags()
+	f.StringVar(&tokenCreateFlags.role, "role", "api", "role of the token: agent, api or admin")
+	f.StringSliceVar(&tokenCreateFlags.sites, "site", nil, "restrict the token to this site (repeatable)")
+	f.StringVar(&tokenCreateFlags.expires, "expires", "", "expiry as a duration such as 90d or 12h, or a date (default never)")
+
+	tokenCmd.AddCommand(tokenCreateCmd, tokenListCmd, tokenRevokeCmd)
+	rootCmd.AddCommand(tokenCmd)
+}
+
+func runTokenCreate(cmd *cobra.Command, args []string) error {
+	req := &collectorv1.CreateTokenRequest{
+		Name:  args[0],
+		Role:  tokenCreateFlags.role,
+		Sites: tokenCreateFlags.sites,
+	}
+	if tokenCreateFlags.expires != "" {
+		t, err := parseExpiry(tokenCreateFlags.expires)
+		if err != nil {
+			return err
+		}
+		req.ExpiresAt = timestamppb.New(t)
+	}
+
+	ctx, client, done, err := adminClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	resp, err := client.CreateToken(ctx, req)
+	if err != nil {
+		return fmt.Errorf("create token: %w", err)
+	}
+	if outputFormat == output.JSON || outputFormat == output.YAML {
+		return output.Message(os.Stdout, outputFormat, resp)
+	}
+
+	fmt.Println(resp.Secret)
+	fmt.Fprintf(os.Stderr, "Created %s token %d (%s). Store it now; it cannot be shown again.\n",
+		resp.Token.Role, resp.Token.Id, resp.Token.Name)
+	return nil
+}
+
+func runTokenList(cmd *cobra.Command, _ []string) error {
+	ctx, client, done, err := adminClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	resp, err := client.ListTokens(ctx, &collectorv1.ListTokensRequest{})
+	if err != nil {
+		return fmt.Errorf("list tokens: %w", err)
+	}
+	return output.List(os.Stdout, outputFormat, resp.Tokens, output.TokenColumns)
+}
+
+func runTokenRevoke(cmd *cobra.Command, args []string) error {
+	ids := make([]int64, len(args))
+	for i, arg := range args {
+		id, err := strconv.ParseInt(arg, 10, 64)
+		if err != nil || id <= 0 {
+			return fmt.Errorf("invalid token ID %q", arg)
+		}
+		ids[i] = id
+	}
+
+	ctx, client, done, err := adminClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	var failed int
+	for _, id := range ids {
+		if _, err := client.RevokeToken(ctx, &collectorv1.RevokeTokenRequest{Id: id}); err != nil {
+			fmt.Fprintf(os.Stderr, "token %d: %v\n", id, err)
+			failed++
+			continue
+		}
+		fmt.Printf("Revoked token %d\n", id)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d tokens not revoked", failed, len(ids))
+	}
+	return nil
+}
+
+// parseExpiry accepts a duration from now, with d for days (90d), or a time
+// as accepted by parseTime.
+func parseExpiry(s string) (time.Time, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n > 0 {
+			return time.Now().AddDate(0, 0, n), nil
+		}
+	}
+	if d, err := time.ParseDuration(s); err == nil && d > 0 {
+		return time.Now().Add(d), nil
+	}
+	t, err := parseTime(s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --expires %q (use a duration such as 90d or 12h, or a date)", s)
+	}
+	return t, nil
+}
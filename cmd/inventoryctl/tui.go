@@ -0,0 +1,467 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+	"github.com/go-tangra/go-tangra-inventory/internal/output"
+)
+
+var tuiCmd = &cobra.Command{
+	Use:   "tui",
+	Short: "Browse hosts and their inventories interactively",
+	Long: `Browse hosts and their latest inventories in a full-screen terminal UI.
+
+Keys:
+  up/down, j/k      select a host
+  pgup/pgdn, u/d    scroll the detail pane
+  /                 search hosts by hostname, site or UUID; esc clears
+  r                 reload the host list
+  R                 ask the selected host's agent to submit a fresh inventory
+  q, ctrl-c         quit
+
+--timeout applies to each API call rather than to the whole session.`,
+	Args: cobra.NoArgs,
+	RunE: runTUI,
+}
+
+// tuiMaxHosts bounds the host list loaded into the UI.
+const tuiMaxHosts = 5000
+
+func init() {
+	rootCmd.AddCommand(tuiCmd)
+}
+
+// tui is the state of the terminal UI.
+type tui struct {
+	// ctx and adminCtx carry the credentials of the collector and admin
+	// services.
+	ctx       context.Context
+	adminCtx  context.Context
+	perCall   time.Duration
+	collector collectorv1.InventoryCollectorServiceClient
+	admin     collectorv1.InventoryAdminServiceClient
+
+	hosts    []*collectorv1.HostSummary
+	visible  []*collectorv1.HostSummary
+	selected int
+	top      int
+
+	// details caches the rendered detail pane by inventory ID.
+	details map[int64][]string
+	scroll  int
+
+	search    string
+	searching bool
+	status    string
+
+	width, height int
+}
+
+func runTUI(cmd *cobra.Command, _ []string) error {
+	t := &tui{perCall: timeout, details: map[int64][]string{}}
+	timeout = 0
+
+	var cdone, adone func()
+	var err error
+	t.ctx, t.collector, cdone, err = collectorClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer cdone()
+	t.adminCtx, t.admin, adone, err = adminClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer adone()
+
+	if t.width, t.height, err = termSize(); err != nil {
+		return fmt.Errorf("tui needs a terminal: %w", err)
+	}
+	if err := t.reload(); err != nil {
+		return err
+	}
+
+	restore, err := makeRaw()
+	if err != nil {
+		return fmt.Errorf("tui needs a terminal: %w", err)
+	}
+	defer restore()
+	// Switch to the alternate screen and hide the cursor; undo on exit.
+	fmt.Print("\x1b[?1049h\x1b[?25l")
+	defer fmt.Print("\x1b[?25h\x1b[?1049l")
+
+	keys := make(chan string)
+	go readKeys(keys)
+	resize := time.NewTicker(500 * time.Millisecond)
+	defer resize.Stop()
+
+	t.draw()
+	for {
+		select {
+		case k, ok := <-keys:
+			if !ok || !t.handle(k) {
+				return nil
+			}
+		case <-resize.C:
+			w, h, err := termSize()
+			if err != nil || (w == t.width && h == t.height) {
+				continue
+			}
+			t.width, t.height = w, h
+		}
+		t.draw()
+	}
+}
+
+// call returns a context for one API call bounded by --timeout.
+func (t *tui) call(ctx context.Context) (context.Context, context.CancelFunc) {
+	if t.perCall > 0 {
+		return context.WithTimeout(ctx, t.perCall)
+	}
+	return context.WithCancel(ctx)
+}
+
+// reload fetches the host list, keeping the selected host if it is still
+// listed.
+func (t *tui) reload() error {
+	ctx, cancel := t.call(t.ctx)
+	defer cancel()
+	resp, err := t.collector.ListHosts(ctx, &collectorv1.ListHostsRequest{PageSize: tuiMaxHosts})
+	if err != nil {
+		return fmt.Errorf("list hosts: %w", err)
+	}
+
+	var current *collectorv1.HostSummary
+	if t.selected < len(t.visible) {
+		current = t.visible[t.selected]
+	}
+	t.hosts = resp.Hosts
+	t.filter()
+	for i, h := range t.visible {
+		if current != nil && h.Site == current.Site && h.Hostname == current.Hostname {
+			t.selected = i
+		}
+	}
+	t.status = fmt.Sprintf("%d hosts loaded at %s", len(t.hosts), time.Now().Format(time.TimeOnly))
+	if int(resp.TotalCount) > len(resp.Hosts) {
+		t.status = fmt.Sprintf("showing %d of %d hosts", len(resp.Hosts), resp.TotalCount)
+	}
+	return nil
+}
+
+// filter applies the search to the host list.
+func (t *tui) filter() {
+	t.visible = t.visible[:0]
+	q := strings.ToLower(t.search)
+	for _, h := range t.hosts {
+		if q == "" || strings.Contains(strings.ToLower(h.Hostname), q) ||
+			strings.Contains(strings.ToLower(h.Site), q) || strings.Contains(strings.ToLower(h.SystemUuid), q) {
+			t.visible = append(t.visible, h)
+		}
+	}
+	t.selected, t.top, t.scroll = 0, 0, 0
+}
+
+// handle applies one key and reports whether the UI should keep running.
+func (t *tui) handle(k string) bool {
+	if t.searching {
+		switch k {
+		case "enter":
+			t.searching = false
+		case "esc":
+			t.searching, t.search = false, ""
+			t.filter()
+		case "backspace":
+			if t.search != "" {
+				_, n := utf8.DecodeLastRuneInString(t.search)
+				t.search = t.search[:len(t.search)-n]
+				t.filter()
+			}
+		case "ctrl-c":
+			return false
+		default:
+			if utf8.RuneCountInString(k) == 1 {
+				t.search += k
+				t.filter()
+			}
+		}
+		return true
+	}
+
+	switch k {
+	case "q", "ctrl-c":
+		return false
+	case "up", "k":
+		t.move(-1)
+	case "down", "j":
+		t.move(1)
+	case "home":
+		t.move(-len(t.visible))
+	case "end":
+		t.move(len(t.visible))
+	case "pgup", "u":
+		t.scroll = max(t.scroll-t.paneHeight()/2, 0)
+	case "pgdn", "d":
+		t.scroll += t.paneHeight() / 2
+	case "/":
+		t.searching = true
+	case "esc":
+		t.search = ""
+		t.filter()
+	case "r":
+		t.details = map[int64][]string{}
+		if err := t.reload(); err != nil {
+			t.status = err.Error()
+		}
+	case "R":
+		t.refreshAgent()
+	}
+	return true
+}
+
+func (t *tui) move(delta int) {
+	t.selected = min(max(t.selected+delta, 0), max(len(t.visible)-1, 0))
+	t.scroll = 0
+}
+
+// refreshAgent sends a refresh command to the selected host's agent.
+func (t *tui) refreshAgent() {
+	if t.selected >= len(t.visible) {
+		return
+	}
+	h := t.visible[t.selected]
+	ctx, cancel := t.call(t.adminCtx)
+	defer cancel()
+	_, err := t.admin.RefreshInventory(ctx, &collectorv1.RefreshInventoryRequest{Hostname: h.Hostname, Site: h.Site})
+	if err != nil {
+		t.status = fmt.Sprintf("refresh %s: %v", h.Hostname, err)
+		return
+	}
+	t.status = fmt.Sprintf("refresh sent to %s; press r to reload", h.Hostname)
+}
+
+// paneHeight is the number of rows available to the panes, between the
+// title and the status line.
+func (t *tui) paneHeight() int {
+	return max(t.height-2, 1)
+}
+
+// draw repaints the whole screen.
+func (t *tui) draw() {
+	var b strings.Builder
+	b.WriteString("\x1b[H")
+	listWidth := min(max(t.width*2/5, 20), 48)
+	detailWidth := max(t.width-listWidth-3, 0)
+	rows := t.paneHeight()
+
+	title := fmt.Sprintf(" inventoryctl - %s", serverAddr)
+	if t.search != "" || t.searching {
+		title += fmt.Sprintf("  [search: %s]", t.search)
+	}
+	writeLine(&b, 1, "\x1b[7m"+pad(title, t.width)+"\x1b[0m")
+
+	// Keep the selection inside the visible part of the list.
+	if t.selected < t.top {
+		t.top = t.selected
+	}
+	if t.selected >= t.top+rows {
+		t.top = t.selected - rows + 1
+	}
+	detail := t.detail()
+	t.scroll = min(t.scroll, max(len(detail)-rows, 0))
+
+	for i := range rows {
+		var left string
+		if n := t.top + i; n < len(t.visible) {
+			h := t.visible[n]
+			name := h.Hostname
+			if h.Site != "" {
+				name += " (" + h.Site + ")"
+			}
+			left = pad(" "+name, listWidth)
+			if n == t.selected {
+				left = "\x1b[7m" + left + "\x1b[0m"
+			}
+		} else {
+			left = pad("", listWidth)
+		}
+		var right string
+		if n := t.scroll + i; n < len(detail) {
+			right = pad(detail[n], detailWidth)
+		}
+		writeLine(&b, i+2, left+" | "+right)
+	}
+
+	footer := " " + t.status + "  |  / search  r reload  R refresh agent  q quit"
+	if t.searching {
+		footer = " search: " + t.search + "_  (enter to keep, esc to clear)"
+	}
+	writeLine(&b, t.height, "\x1b[7m"+pad(footer, t.width)+"\x1b[0m")
+	os.Stdout.WriteString(b.String())
+}
+
+// detail returns the detail pane of the selected host, loading its latest
+// inventory on first use.
+func (t *tui) detail() []string {
+	if t.selected >= len(t.visible) {
+		if t.search != "" {
+			return []string{"No hosts match the search."}
+		}
+		return []string{"No hosts."}
+	}
+	h := t.visible[t.selected]
+	if lines, ok := t.details[h.LatestId]; ok {
+		return lines
+	}
+
+	ctx, cancel := t.call(t.ctx)
+	defer cancel()
+	resp, err := t.collector.GetInventory(ctx, &collectorv1.GetInventoryRequest{Id: h.LatestId})
+	if err != nil {
+		return []string{fmt.Sprintf("get inventory %d: %v", h.LatestId, err)}
+	}
+	lines := detailLines(h, resp)
+	t.details[h.LatestId] = lines
+	return lines
+}
+
+// detailLines renders the latest inventory of h for the detail pane.
+func detailLines(h *collectorv1.HostSummary, resp *collectorv1.GetInventoryResponse) []string {
+	inv := resp.Inventory
+	var lines []string
+	add := func(label, value string) {
+		lines = append(lines, fmt.Sprintf("%-12s %s", label+":", output.OrDash(value)))
+	}
+	section := func(title string) { lines = append(lines, "", title) }
+
+	add("Hostname", h.Hostname)
+	add("Site", h.Site)
+	add("Username", inv.GetUsername())
+	add("Inventory", fmt.Sprint(resp.Id))
+	add("Collected", output.Time(inv.GetCollectedAt()))
+	add("Stored", output.Time(resp.StoredAt))
+
+	if d := h.GetDirectory(); d != nil {
+		section("Directory")
+		add("OU", d.Ou)
+		add("Description", d.Description)
+		add("User", d.UserDisplayName)
+		add("Department", d.Department)
+	}
+
+	section("System")
+	s := inv.GetSystem()
+	add("Model", strings.TrimSpace(s.GetManufacturer()+" "+s.GetProductName()))
+	add("Serial", s.GetSerialNumber())
+	add("UUID", s.GetUuid())
+	add("BIOS", strings.TrimSpace(inv.GetBios().GetVendor()+" "+inv.GetBios().GetVersion()))
+	add("Baseboard", strings.TrimSpace(inv.GetBaseboard().GetManufacturer()+" "+inv.GetBaseboard().GetProduct()))
+	add("Chassis", inv.GetChassis().GetSerialNumber())
+
+	section("Processors")
+	for _, p := range inv.GetProcessors() {
+		if p.SocketPopulated {
+			add(p.SocketDesignation, fmt.Sprintf("%s, %d cores, %d threads", strings.TrimSpace(p.Version), p.CoreCount, p.ThreadCount))
+		}
+	}
+
+	section(fmt.Sprintf("Memory (%.1f GB)", inv.GetMemory().GetTotalPhysicalGb()))
+	for _, m := range inv.GetMemory().GetModules() {
+		if m.CapacityBytes > 0 {
+			add(m.DeviceLocator, fmt.Sprintf("%d MiB %s %d MT/s %s", m.CapacityBytes>>20, m.MemoryType, m.SpeedMtS, strings.TrimSpace(m.PartNumber)))
+		}
+	}
+
+	if len(inv.GetMonitor()) > 0 {
+		section("Monitors")
+		for _, m := range inv.GetMonitor() {
+			add(strings.TrimSpace(m.Manufacturer), strings.TrimSpace(m.Model+" "+m.SerialNumber))
+		}
+	}
+	return lines
+}
+
+// writeLine writes s at row, clearing the rest of the line.
+func writeLine(b *strings.Builder, row int, s string) {
+	fmt.Fprintf(b, "\x1b[%d;1H%s\x1b[K", row, s)
+}
+
+// pad truncates or pads s to exactly width runes.
+func pad(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if n := utf8.RuneCountInString(s); n < width {
+		return s + strings.Repeat(" ", width-n)
+	}
+	return string([]rune(s)[:width])
+}
+
+// readKeys decodes the raw terminal input into key names and sends them to
+// keys until stdin is closed.
+func readKeys(keys chan<- string) {
+	defer close(keys)
+	buf := make([]byte, 64)
+	for {
+		n, err := os.Stdin.Read(buf)
+		if err != nil {
+			return
+		}
+		for in := buf[:n]; len(in) > 0; {
+			k, size := decodeKey(in)
+			in = in[size:]
+			if k != "" {
+				keys <- k
+			}
+		}
+	}
+}
+
+// escapeKeys maps the escape sequences of special keys to their names.
+var escapeKeys = map[string]string{
+	"\x1b[A": "up", "\x1b[B": "down",
+	"\x1b[5~": "pgup", "\x1b[6~": "pgdn",
+	"\x1b[H": "home", "\x1b[1~": "home", "\x1bOH": "home",
+	"\x1b[F": "end", "\x1b[4~": "end", "\x1bOF": "end",
+}
+
+// decodeKey returns the name of the first key in in and its length in bytes.
+func decodeKey(in []byte) (string, int) {
+	switch in[0] {
+	case 0x1b:
+		for seq, name := range escapeKeys {
+			if strings.HasPrefix(string(in), seq) {
+				return name, len(seq)
+			}
+		}
+		if len(in) > 2 && (in[1] == '[' || in[1] == 'O') {
+			// Skip an unknown sequence up to its final byte.
+			for i := 2; i < len(in); i++ {
+				if in[i] >= 0x40 && in[i] <= 0x7e {
+					return "", i + 1
+				}
+			}
+			return "", len(in)
+		}
+		return "esc", 1
+	case '\r', '\n':
+		return "enter", 1
+	case 0x7f, 0x08:
+		return "backspace", 1
+	case 0x03:
+		return "ctrl-c", 1
+	}
+	r, size := utf8.DecodeRune(in)
+	if r < 0x20 {
+		return "", size
+	}
+	return string(r), size
+}
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+	"github.com/go-tangra/go-tangra-inventory/internal/output"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// watchEventLines is the number of recent events shown below the table.
+const watchEventLines = 8
+
+// agentWatch is the state of agents --watch: the connected agents and the
+// most recent events.
+type agentWatch struct {
+	agents map[string]*collectorv1.ConnectedAgent
+	events []string
+}
+
+// watchAgents streams agent events from the collector. On a terminal it
+// keeps a table of the connected agents up to date; otherwise, and with
+// -o json or yaml, it prints one line or document per event.
+func watchAgents(cmd *cobra.Command) error {
+	timeout = 0
+	ctx, client, done, err := adminClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer done()
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	stream, err := client.WatchAgents(ctx, &collectorv1.WatchAgentsRequest{Site: agentsSite})
+	if err != nil {
+		return fmt.Errorf("watch agents: %w", err)
+	}
+	events := make(chan *collectorv1.AgentEvent)
+	errc := make(chan error, 1)
+	go func() {
+		for {
+			ev, err := stream.Recv()
+			if err != nil {
+				errc <- err
+				return
+			}
+			events <- ev
+		}
+	}()
+
+	_, _, termErr := termSize()
+	live := termErr == nil && outputFormat != output.JSON && outputFormat != output.YAML
+	if live {
+		// Switch to the alternate screen and hide the cursor; undo on exit.
+		fmt.Print("\x1b[?1049h\x1b[?25l")
+		defer fmt.Print("\x1b[?25h\x1b[?1049l")
+	}
+
+	w := &agentWatch{agents: make(map[string]*collectorv1.ConnectedAgent)}
+	tick := time.NewTicker(time.Second)
+	defer tick.Stop()
+	if live {
+		w.draw()
+	}
+	for {
+		select {
+		case ev := <-events:
+			line := w.apply(ev)
+			switch {
+			case live:
+				if ev.Snapshot {
+					continue
+				}
+			case outputFormat == output.JSON || outputFormat == output.YAML:
+				if outputFormat == output.YAML {
+					fmt.Println("---")
+				}
+				if err := output.Message(os.Stdout, outputFormat, ev); err != nil {
+					return err
+				}
+				continue
+			default:
+				fmt.Println(line)
+				continue
+			}
+		case err := <-errc:
+			if ctx.Err() != nil || errors.Is(err, io.EOF) || status.Code(err) == codes.Canceled {
+				return nil
+			}
+			return fmt.Errorf("watch agents: %w", err)
+		case <-tick.C:
+			if !live {
+				continue
+			}
+		}
+		w.draw()
+	}
+}
+
+// apply records ev and returns its description.
+func (w *agentWatch) apply(ev *collectorv1.AgentEvent) string {
+	a := ev.Agent
+	key := a.Site + "\x00" + a.ClientId
+	var what string
+	switch ev.Type {
+	case collectorv1.AgentEventType_AGENT_EVENT_TYPE_CONNECTED:
+		w.agents[key] = a
+		what = "connected, version " + output.OrDash(a.Version)
+	case collectorv1.AgentEventType_AGENT_EVENT_TYPE_DISCONNECTED:
+		delete(w.agents, key)
+		what = "disconnected after " + formatUptime(a.ConnectedAt.AsTime(), ev.Time.AsTime())
+	case collectorv1.AgentEventType_AGENT_EVENT_TYPE_SUBMITTED:
+		w.agents[key] = a
+		what = "submitted an inventory"
+	case collectorv1.AgentEventType_AGENT_EVENT_TYPE_UPDATED:
+		w.agents[key] = a
+		what = "resumed"
+		if a.Paused {
+			what = "paused"
+		}
+	}
+
+	name := a.Hostname
+	if a.Site != "" {
+		name = a.Site + "/" + name
+	}
+	line := fmt.Sprintf("%s  %s %s", ev.Time.AsTime().Local().Format(time.TimeOnly), name, what)
+	if !ev.Snapshot {
+		w.events = append(w.events, line)
+		if len(w.events) > watchEventLines {
+			w.events = w.events[len(w.events)-watchEventLines:]
+		}
+	}
+	return line
+}
+
+// draw redraws the screen: the agents table, cut to the terminal height,
+// and the recent events.
+func (w *agentWatch) draw() {
+	width, height, err := termSize()
+	if err != nil {
+		return
+	}
+	agents := make([]*collectorv1.ConnectedAgent, 0, len(w.agents))
+	for _, a := range w.agents {
+		agents = append(agents, a)
+	}
+	slices.SortFunc(agents, func(a, b *collectorv1.ConnectedAgent) int {
+		return strings.Compare(a.Site+"/"+a.Hostname, b.Site+"/"+b.Hostname)
+	})
+
+	now := time.Now()
+	var table bytes.Buffer
+	tw := tabwriter.NewWriter(&table, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "HOSTNAME\tSITE\tVERSION\tUPTIME\tLAST SUBMISSION\tPAUSED")
+	for _, a := range agents {
+		last := "-"
+		if a.LastSubmittedAt != nil {
+			last = formatUptime(a.LastSubmittedAt.AsTime(), now) + " ago"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%t\n", a.Hostname, output.OrDash(a.Site), output.OrDash(a.Version),
+			formatUptime(a.ConnectedAt.AsTime(), now), last, a.Paused)
+	}
+	tw.Flush()
+	rows := strings.Split(strings.TrimSuffix(table.String(), "\n"), "\n")
+
+	var b strings.Builder
+	b.WriteString("\x1b[H\x1b[2J")
+	line := func(s string) {
+		if width > 0 && len(s) > width {
+			s = s[:width]
+		}
+		b.WriteString(s + "\n")
+	}
+	line(fmt.Sprintf("%d agents connected, %s (Ctrl-C to quit)", len(agents), now.Format(time.TimeOnly)))
+	line("")
+	// Leave room for the header, the events and their title.
+	room := max(height-len(w.events)-5, 2)
+	for i, r := range rows {
+		if i == room-1 && len(rows) > room {
+			line(fmt.Sprintf("... %d more", len(rows)-i))
+			break
+		}
+		line(r)
+	}
+	if len(w.events) > 0 {
+		line("")
+		line("RECENT EVENTS")
+		for _, e := range w.events {
+			line(e)
+		}
+	}
+	fmt.Print(b.String())
+}
+
+// formatUptime renders the time from since to now compactly, e.g. 3h12m.
+func formatUptime(since, now time.Time) string {
+	d := now.Sub(since)
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", max(int(d.Seconds()), 0))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+	default:
+		return fmt.Sprintf("%dd%02dh", int(d.Hours())/24, int(d.Hours())%24)
+	}
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+	"github.com/go-tangra/go-tangra-inventory/internal/output"
+)
+
+var webhooksCmd = &cobra.Command{
+	Use:   "webhooks",
+	Short: "Inspect and replay alert webhook deliveries",
+	Long: `Inspect and replay the deliveries of alerts to the webhook endpoints
+configured in alert_webhook_url and alert_webhooks. The collector records
+every delivery with the outcome of its last attempt; failed deliveries can be
+replayed once the endpoint is reachable again.`,
+}
+
+var webhooksListFlags struct {
+	status   string
+	pageSize int32
+	page     int32
+}
+
+var webhooksListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List webhook deliveries, newest first",
+	Args:  cobra.NoArgs,
+	RunE:  runWebhooksList,
+}
+
+var webhooksReplayCmd = &cobra.Command{
+	Use:   "replay <id>...",
+	Short: "Post failed deliveries again",
+	Long: `Post failed deliveries again, signed with the current secret of their
+endpoint. Deliveries keep their ID, sent as X-Tangra-Delivery, so that
+receivers can drop duplicates.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runWebhooksReplay,
+}
+
+func init() {
+	f := webhooksListCmd.Flags()
+	f.StringVar(&webhooksListFlags.status, "status", "", "only deliveries in this state: pending, delivered or failed")
+	f.Int32Var(&webhooksListFlags.pageSize, "page-size", 50, "deliveries per page")
+	f.Int32Var(&webhooksListFlags.page, "page", 1, "page number")
+
+	webhooksCmd.AddCommand(webhooksListCmd, webhooksReplayCmd)
+	rootCmd.AddCommand(webhooksCmd)
+}
+
+func runWebhooksList(cmd *cobra.Command, _ []string) error {
+	ctx, client, done, err := adminClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	resp, err := client.ListWebhookDeliveries(ctx, &collectorv1.ListWebhookDeliveriesRequest{
+		Status:   webhooksListFlags.status,
+		PageSize: webhooksListFlags.pageSize,
+		Page:     webhooksListFlags.page,
+	})
+	if err != nil {
+		return fmt.Errorf("list webhook deliveries: %w", err)
+	}
+
+	if err := output.List(os.Stdout, outputFormat, resp.Deliveries, output.DeliveryColumns); err != nil {
+		return err
+	}
+	if int(resp.TotalCount) > len(resp.Deliveries) {
+		fmt.Fprintf(os.Stderr, "showing %d of %d deliveries (page %d)\n", len(resp.Deliveries), resp.TotalCount, webhooksListFlags.page)
+	}
+	return nil
+}
+
+func runWebhooksReplay(cmd *cobra.Command, args []string) error {
+	ids := make([]int64, len(args))
+	for i, arg := range args {
+		id, err := strconv.ParseInt(arg, 10, 64)
+		if err != nil || id <= 0 {
+			return fmt.Errorf("invalid delivery ID %q", arg)
+		}
+		ids[i] = id
+	}
+
+	ctx, client, done, err := adminClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	var failed int
+	for _, id := range ids {
+		resp, err := client.ReplayWebhookDelivery(ctx, &collectorv1.ReplayWebhookDeliveryRequest{Id: id})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "delivery %d: %v\n", id, err)
+			failed++
+			continue
+		}
+		if d := resp.Delivery; d.Status != "delivered" {
+			fmt.Fprintf(os.Stderr, "delivery %d: %s\n", id, d.Error)
+			failed++
+			continue
+		}
+		fmt.Printf("Delivered %d\n", id)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d deliveries not delivered", failed, len(ids))
+	}
+	return nil
+}
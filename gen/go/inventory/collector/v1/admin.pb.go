@@ -0,0 +1,1917 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v5.28.3
+// source: inventory/collector/v1/admin.proto
+
+package collectorv1
+
+import (
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type AgentEventType int32
+
+const (
+	AgentEventType_AGENT_EVENT_TYPE_CONNECTED    AgentEventType = 0
+	AgentEventType_AGENT_EVENT_TYPE_DISCONNECTED AgentEventType = 1
+	// SUBMITTED reports an inventory stored for the agent.
+	AgentEventType_AGENT_EVENT_TYPE_SUBMITTED AgentEventType = 2
+	// UPDATED reports that the agent was paused or resumed.
+	AgentEventType_AGENT_EVENT_TYPE_UPDATED AgentEventType = 3
+)
+
+// Enum value maps for AgentEventType.
+var (
+	AgentEventType_name = map[int32]string{
+		0: "AGENT_EVENT_TYPE_CONNECTED",
+		1: "AGENT_EVENT_TYPE_DISCONNECTED",
+		2: "AGENT_EVENT_TYPE_SUBMITTED",
+		3: "AGENT_EVENT_TYPE_UPDATED",
+	}
+	AgentEventType_value = map[string]int32{
+		"AGENT_EVENT_TYPE_CONNECTED":    0,
+		"AGENT_EVENT_TYPE_DISCONNECTED": 1,
+		"AGENT_EVENT_TYPE_SUBMITTED":    2,
+		"AGENT_EVENT_TYPE_UPDATED":      3,
+	}
+)
+
+func (x AgentEventType) Enum() *AgentEventType {
+	p := new(AgentEventType)
+	*p = x
+	return p
+}
+
+func (x AgentEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AgentEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_inventory_collector_v1_admin_proto_enumTypes[0].Descriptor()
+}
+
+func (AgentEventType) Type() protoreflect.EnumType {
+	return &file_inventory_collector_v1_admin_proto_enumTypes[0]
+}
+
+func (x AgentEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AgentEventType.Descriptor instead.
+func (AgentEventType) EnumDescriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_admin_proto_rawDescGZIP(), []int{0}
+}
+
+type PurgeInventoriesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// older_than_days selects records older than this many days (0 = any age,
+	// only with hostname or system_uuid).
+	OlderThanDays int32 `protobuf:"varint,1,opt,name=older_than_days,json=olderThanDays,proto3" json:"older_than_days,omitempty"`
+	// hostname and system_uuid limit the purge to one host. hostname may
+	// instead be a glob pattern such as "LAB-*", selecting every matching host.
+	Hostname   string `protobuf:"bytes,2,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	SystemUuid string `protobuf:"bytes,3,opt,name=system_uuid,json=systemUuid,proto3" json:"system_uuid,omitempty"`
+	// site limits the purge to one site (empty = all sites).
+	Site string `protobuf:"bytes,4,opt,name=site,proto3" json:"site,omitempty"`
+	// dry_run counts the matching records without deleting them.
+	DryRun bool `protobuf:"varint,5,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// hostname_regex limits the purge to the hosts whose hostname matches this
+	// regular expression (RE2 syntax).
+	HostnameRegex string `protobuf:"bytes,6,opt,name=hostname_regex,json=hostnameRegex,proto3" json:"hostname_regex,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeInventoriesRequest) Reset() {
+	*x = PurgeInventoriesRequest{}
+	mi := &file_inventory_collector_v1_admin_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeInventoriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeInventoriesRequest) ProtoMessage() {}
+
+func (x *PurgeInventoriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_admin_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeInventoriesRequest.ProtoReflect.Descriptor instead.
+func (*PurgeInventoriesRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_admin_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PurgeInventoriesRequest) GetOlderThanDays() int32 {
+	if x != nil {
+		return x.OlderThanDays
+	}
+	return 0
+}
+
+func (x *PurgeInventoriesRequest) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+func (x *PurgeInventoriesRequest) GetSystemUuid() string {
+	if x != nil {
+		return x.SystemUuid
+	}
+	return ""
+}
+
+func (x *PurgeInventoriesRequest) GetSite() string {
+	if x != nil {
+		return x.Site
+	}
+	return ""
+}
+
+func (x *PurgeInventoriesRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *PurgeInventoriesRequest) GetHostnameRegex() string {
+	if x != nil {
+		return x.HostnameRegex
+	}
+	return ""
+}
+
+type PurgeInventoriesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// purged is the number of inventories deleted, or that would be deleted
+	// in a dry run.
+	Purged int64 `protobuf:"varint,1,opt,name=purged,proto3" json:"purged,omitempty"`
+	// alerts_purged is the number of alerts deleted alongside.
+	AlertsPurged int64 `protobuf:"varint,2,opt,name=alerts_purged,json=alertsPurged,proto3" json:"alerts_purged,omitempty"`
+	DryRun       bool  `protobuf:"varint,3,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// hosts_purged is the number of distinct hosts whose inventories were
+	// purged.
+	HostsPurged   int64 `protobuf:"varint,4,opt,name=hosts_purged,json=hostsPurged,proto3" json:"hosts_purged,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeInventoriesResponse) Reset() {
+	*x = PurgeInventoriesResponse{}
+	mi := &file_inventory_collector_v1_admin_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeInventoriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeInventoriesResponse) ProtoMessage() {}
+
+func (x *PurgeInventoriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_admin_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeInventoriesResponse.ProtoReflect.Descriptor instead.
+func (*PurgeInventoriesResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_admin_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PurgeInventoriesResponse) GetPurged() int64 {
+	if x != nil {
+		return x.Purged
+	}
+	return 0
+}
+
+func (x *PurgeInventoriesResponse) GetAlertsPurged() int64 {
+	if x != nil {
+		return x.AlertsPurged
+	}
+	return 0
+}
+
+func (x *PurgeInventoriesResponse) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *PurgeInventoriesResponse) GetHostsPurged() int64 {
+	if x != nil {
+		return x.HostsPurged
+	}
+	return 0
+}
+
+type AdvertiseAgentUpdateRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Update *AgentUpdate           `protobuf:"bytes,1,opt,name=update,proto3" json:"update,omitempty"`
+	// site limits the rollout to agents of one site (empty = all sites).
+	Site          string `protobuf:"bytes,2,opt,name=site,proto3" json:"site,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdvertiseAgentUpdateRequest) Reset() {
+	*x = AdvertiseAgentUpdateRequest{}
+	mi := &file_inventory_collector_v1_admin_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdvertiseAgentUpdateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdvertiseAgentUpdateRequest) ProtoMessage() {}
+
+func (x *AdvertiseAgentUpdateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_admin_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdvertiseAgentUpdateRequest.ProtoReflect.Descriptor instead.
+func (*AdvertiseAgentUpdateRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_admin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AdvertiseAgentUpdateRequest) GetUpdate() *AgentUpdate {
+	if x != nil {
+		return x.Update
+	}
+	return nil
+}
+
+func (x *AdvertiseAgentUpdateRequest) GetSite() string {
+	if x != nil {
+		return x.Site
+	}
+	return ""
+}
+
+type AdvertiseAgentUpdateResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// notified is the number of connected agents sent an update command.
+	Notified      int32 `protobuf:"varint,1,opt,name=notified,proto3" json:"notified,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdvertiseAgentUpdateResponse) Reset() {
+	*x = AdvertiseAgentUpdateResponse{}
+	mi := &file_inventory_collector_v1_admin_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdvertiseAgentUpdateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdvertiseAgentUpdateResponse) ProtoMessage() {}
+
+func (x *AdvertiseAgentUpdateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_admin_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdvertiseAgentUpdateResponse.ProtoReflect.Descriptor instead.
+func (*AdvertiseAgentUpdateResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_admin_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *AdvertiseAgentUpdateResponse) GetNotified() int32 {
+	if x != nil {
+		return x.Notified
+	}
+	return 0
+}
+
+// SendCommandRequest targets an agent like RefreshInventoryRequest does.
+type SendCommandRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Hostname string                 `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Site     string                 `protobuf:"bytes,2,opt,name=site,proto3" json:"site,omitempty"`
+	ClientId string                 `protobuf:"bytes,3,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	// command is the command to send; the collector assigns its command_id.
+	// LOGS commands are sent with GetAgentLogs instead.
+	Command *InventoryCommand `protobuf:"bytes,4,opt,name=command,proto3" json:"command,omitempty"`
+	// wait_ms is how long to wait for the acknowledgement (0 = do not wait,
+	// at most 5 minutes).
+	WaitMs        int64 `protobuf:"varint,5,opt,name=wait_ms,json=waitMs,proto3" json:"wait_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendCommandRequest) Reset() {
+	*x = SendCommandRequest{}
+	mi := &file_inventory_collector_v1_admin_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendCommandRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendCommandRequest) ProtoMessage() {}
+
+func (x *SendCommandRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_admin_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendCommandRequest.ProtoReflect.Descriptor instead.
+func (*SendCommandRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_admin_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SendCommandRequest) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+func (x *SendCommandRequest) GetSite() string {
+	if x != nil {
+		return x.Site
+	}
+	return ""
+}
+
+func (x *SendCommandRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *SendCommandRequest) GetCommand() *InventoryCommand {
+	if x != nil {
+		return x.Command
+	}
+	return nil
+}
+
+func (x *SendCommandRequest) GetWaitMs() int64 {
+	if x != nil {
+		return x.WaitMs
+	}
+	return 0
+}
+
+type SendCommandResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	CommandId string                 `protobuf:"bytes,1,opt,name=command_id,json=commandId,proto3" json:"command_id,omitempty"`
+	ClientId  string                 `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	// acknowledged is set when the agent acknowledged the command within
+	// wait_ms.
+	Acknowledged bool `protobuf:"varint,3,opt,name=acknowledged,proto3" json:"acknowledged,omitempty"`
+	// error is the failure the agent reported in its acknowledgement.
+	Error string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	// duration_ms is the time from sending the command to its
+	// acknowledgement.
+	DurationMs    int64 `protobuf:"varint,5,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendCommandResponse) Reset() {
+	*x = SendCommandResponse{}
+	mi := &file_inventory_collector_v1_admin_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendCommandResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendCommandResponse) ProtoMessage() {}
+
+func (x *SendCommandResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_admin_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendCommandResponse.ProtoReflect.Descriptor instead.
+func (*SendCommandResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_admin_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SendCommandResponse) GetCommandId() string {
+	if x != nil {
+		return x.CommandId
+	}
+	return ""
+}
+
+func (x *SendCommandResponse) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *SendCommandResponse) GetAcknowledged() bool {
+	if x != nil {
+		return x.Acknowledged
+	}
+	return false
+}
+
+func (x *SendCommandResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *SendCommandResponse) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+// GetAgentLogsRequest targets an agent like RefreshInventoryRequest does.
+type GetAgentLogsRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Hostname string                 `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Site     string                 `protobuf:"bytes,2,opt,name=site,proto3" json:"site,omitempty"`
+	ClientId string                 `protobuf:"bytes,3,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	// max_bytes limits the log output returned (0 = 64 KiB, at most 1 MiB).
+	MaxBytes      int64 `protobuf:"varint,4,opt,name=max_bytes,json=maxBytes,proto3" json:"max_bytes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAgentLogsRequest) Reset() {
+	*x = GetAgentLogsRequest{}
+	mi := &file_inventory_collector_v1_admin_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAgentLogsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAgentLogsRequest) ProtoMessage() {}
+
+func (x *GetAgentLogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_admin_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAgentLogsRequest.ProtoReflect.Descriptor instead.
+func (*GetAgentLogsRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_admin_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetAgentLogsRequest) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+func (x *GetAgentLogsRequest) GetSite() string {
+	if x != nil {
+		return x.Site
+	}
+	return ""
+}
+
+func (x *GetAgentLogsRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *GetAgentLogsRequest) GetMaxBytes() int64 {
+	if x != nil {
+		return x.MaxBytes
+	}
+	return 0
+}
+
+type GetAgentLogsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Logs  []byte                 `protobuf:"bytes,1,opt,name=logs,proto3" json:"logs,omitempty"`
+	// truncated is set when older output was left out or has been discarded
+	// by the agent.
+	Truncated     bool   `protobuf:"varint,2,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	ClientId      string `protobuf:"bytes,3,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAgentLogsResponse) Reset() {
+	*x = GetAgentLogsResponse{}
+	mi := &file_inventory_collector_v1_admin_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAgentLogsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAgentLogsResponse) ProtoMessage() {}
+
+func (x *GetAgentLogsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_admin_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAgentLogsResponse.ProtoReflect.Descriptor instead.
+func (*GetAgentLogsResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_admin_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetAgentLogsResponse) GetLogs() []byte {
+	if x != nil {
+		return x.Logs
+	}
+	return nil
+}
+
+func (x *GetAgentLogsResponse) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+func (x *GetAgentLogsResponse) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+type WatchAgentsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// site limits the events to agents of one site (empty = all sites).
+	Site          string `protobuf:"bytes,1,opt,name=site,proto3" json:"site,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchAgentsRequest) Reset() {
+	*x = WatchAgentsRequest{}
+	mi := &file_inventory_collector_v1_admin_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchAgentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchAgentsRequest) ProtoMessage() {}
+
+func (x *WatchAgentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_admin_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchAgentsRequest.ProtoReflect.Descriptor instead.
+func (*WatchAgentsRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_admin_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *WatchAgentsRequest) GetSite() string {
+	if x != nil {
+		return x.Site
+	}
+	return ""
+}
+
+// AgentEvent is a change to the connected agents.
+type AgentEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Type  AgentEventType         `protobuf:"varint,1,opt,name=type,proto3,enum=inventory.collector.v1.AgentEventType" json:"type,omitempty"`
+	// agent is the state of the agent after the event.
+	Agent *ConnectedAgent      `protobuf:"bytes,2,opt,name=agent,proto3" json:"agent,omitempty"`
+	Time  *timestamp.Timestamp `protobuf:"bytes,3,opt,name=time,proto3" json:"time,omitempty"`
+	// snapshot marks the CONNECTED events describing the agents that were
+	// connected when the watch started.
+	Snapshot      bool `protobuf:"varint,4,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AgentEvent) Reset() {
+	*x = AgentEvent{}
+	mi := &file_inventory_collector_v1_admin_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentEvent) ProtoMessage() {}
+
+func (x *AgentEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_admin_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentEvent.ProtoReflect.Descriptor instead.
+func (*AgentEvent) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_admin_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *AgentEvent) GetType() AgentEventType {
+	if x != nil {
+		return x.Type
+	}
+	return AgentEventType_AGENT_EVENT_TYPE_CONNECTED
+}
+
+func (x *AgentEvent) GetAgent() *ConnectedAgent {
+	if x != nil {
+		return x.Agent
+	}
+	return nil
+}
+
+func (x *AgentEvent) GetTime() *timestamp.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *AgentEvent) GetSnapshot() bool {
+	if x != nil {
+		return x.Snapshot
+	}
+	return false
+}
+
+// ApiToken describes a managed API token.
+type ApiToken struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// role is agent (agent RPCs only, like client_secret), api (every RPC but
+	// the management ones) or admin (every RPC, also on the admin listener).
+	Role string `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	// sites restricts the token to these sites (empty = all sites).
+	Sites     []string             `protobuf:"bytes,4,rep,name=sites,proto3" json:"sites,omitempty"`
+	CreatedAt *timestamp.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// expires_at is unset for tokens that do not expire.
+	ExpiresAt *timestamp.Timestamp `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// revoked_at is unset for tokens that are not revoked.
+	RevokedAt     *timestamp.Timestamp `protobuf:"bytes,7,opt,name=revoked_at,json=revokedAt,proto3" json:"revoked_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApiToken) Reset() {
+	*x = ApiToken{}
+	mi := &file_inventory_collector_v1_admin_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApiToken) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApiToken) ProtoMessage() {}
+
+func (x *ApiToken) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_admin_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApiToken.ProtoReflect.Descriptor instead.
+func (*ApiToken) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_admin_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ApiToken) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ApiToken) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ApiToken) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *ApiToken) GetSites() []string {
+	if x != nil {
+		return x.Sites
+	}
+	return nil
+}
+
+func (x *ApiToken) GetCreatedAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ApiToken) GetExpiresAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *ApiToken) GetRevokedAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.RevokedAt
+	}
+	return nil
+}
+
+type CreateTokenRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Role  string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	Sites []string               `protobuf:"bytes,3,rep,name=sites,proto3" json:"sites,omitempty"`
+	// expires_at is the expiry of the token (unset = never).
+	ExpiresAt     *timestamp.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTokenRequest) Reset() {
+	*x = CreateTokenRequest{}
+	mi := &file_inventory_collector_v1_admin_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTokenRequest) ProtoMessage() {}
+
+func (x *CreateTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_admin_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTokenRequest.ProtoReflect.Descriptor instead.
+func (*CreateTokenRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_admin_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CreateTokenRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateTokenRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *CreateTokenRequest) GetSites() []string {
+	if x != nil {
+		return x.Sites
+	}
+	return nil
+}
+
+func (x *CreateTokenRequest) GetExpiresAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type CreateTokenResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Token *ApiToken              `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// secret is the token to authenticate with. It cannot be retrieved later.
+	Secret        string `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTokenResponse) Reset() {
+	*x = CreateTokenResponse{}
+	mi := &file_inventory_collector_v1_admin_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTokenResponse) ProtoMessage() {}
+
+func (x *CreateTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_admin_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTokenResponse.ProtoReflect.Descriptor instead.
+func (*CreateTokenResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_admin_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CreateTokenResponse) GetToken() *ApiToken {
+	if x != nil {
+		return x.Token
+	}
+	return nil
+}
+
+func (x *CreateTokenResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+type ListTokensRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTokensRequest) Reset() {
+	*x = ListTokensRequest{}
+	mi := &file_inventory_collector_v1_admin_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTokensRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTokensRequest) ProtoMessage() {}
+
+func (x *ListTokensRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_admin_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTokensRequest.ProtoReflect.Descriptor instead.
+func (*ListTokensRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_admin_proto_rawDescGZIP(), []int{13}
+}
+
+type ListTokensResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tokens        []*ApiToken            `protobuf:"bytes,1,rep,name=tokens,proto3" json:"tokens,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTokensResponse) Reset() {
+	*x = ListTokensResponse{}
+	mi := &file_inventory_collector_v1_admin_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTokensResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTokensResponse) ProtoMessage() {}
+
+func (x *ListTokensResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_admin_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTokensResponse.ProtoReflect.Descriptor instead.
+func (*ListTokensResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_admin_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListTokensResponse) GetTokens() []*ApiToken {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
+}
+
+type RevokeTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeTokenRequest) Reset() {
+	*x = RevokeTokenRequest{}
+	mi := &file_inventory_collector_v1_admin_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeTokenRequest) ProtoMessage() {}
+
+func (x *RevokeTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_admin_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeTokenRequest.ProtoReflect.Descriptor instead.
+func (*RevokeTokenRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_admin_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RevokeTokenRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type RevokeTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeTokenResponse) Reset() {
+	*x = RevokeTokenResponse{}
+	mi := &file_inventory_collector_v1_admin_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeTokenResponse) ProtoMessage() {}
+
+func (x *RevokeTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_admin_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeTokenResponse.ProtoReflect.Descriptor instead.
+func (*RevokeTokenResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_admin_proto_rawDescGZIP(), []int{16}
+}
+
+// WebhookDelivery is a payload posted to an alert webhook endpoint.
+type WebhookDelivery struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Url   string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	// status is pending, delivered or failed.
+	Status   string `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Attempts int32  `protobuf:"varint,4,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	// status_code is the HTTP status of the last attempt, 0 if there was no
+	// response.
+	StatusCode int32 `protobuf:"varint,5,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	// error is the failure of the last attempt.
+	Error     string               `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	CreatedAt *timestamp.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// last_attempt_at is unset before the first attempt.
+	LastAttemptAt *timestamp.Timestamp `protobuf:"bytes,8,opt,name=last_attempt_at,json=lastAttemptAt,proto3" json:"last_attempt_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookDelivery) Reset() {
+	*x = WebhookDelivery{}
+	mi := &file_inventory_collector_v1_admin_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookDelivery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookDelivery) ProtoMessage() {}
+
+func (x *WebhookDelivery) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_admin_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookDelivery.ProtoReflect.Descriptor instead.
+func (*WebhookDelivery) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_admin_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *WebhookDelivery) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetStatusCode() int32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetCreatedAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *WebhookDelivery) GetLastAttemptAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.LastAttemptAt
+	}
+	return nil
+}
+
+type ListWebhookDeliveriesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// status limits the deliveries to pending, delivered or failed ones
+	// (empty = all).
+	Status        string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	PageSize      int32  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Page          int32  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhookDeliveriesRequest) Reset() {
+	*x = ListWebhookDeliveriesRequest{}
+	mi := &file_inventory_collector_v1_admin_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhookDeliveriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhookDeliveriesRequest) ProtoMessage() {}
+
+func (x *ListWebhookDeliveriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_admin_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhookDeliveriesRequest.ProtoReflect.Descriptor instead.
+func (*ListWebhookDeliveriesRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_admin_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListWebhookDeliveriesRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListWebhookDeliveriesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListWebhookDeliveriesRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+type ListWebhookDeliveriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deliveries    []*WebhookDelivery     `protobuf:"bytes,1,rep,name=deliveries,proto3" json:"deliveries,omitempty"`
+	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhookDeliveriesResponse) Reset() {
+	*x = ListWebhookDeliveriesResponse{}
+	mi := &file_inventory_collector_v1_admin_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhookDeliveriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhookDeliveriesResponse) ProtoMessage() {}
+
+func (x *ListWebhookDeliveriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_admin_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhookDeliveriesResponse.ProtoReflect.Descriptor instead.
+func (*ListWebhookDeliveriesResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_admin_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListWebhookDeliveriesResponse) GetDeliveries() []*WebhookDelivery {
+	if x != nil {
+		return x.Deliveries
+	}
+	return nil
+}
+
+func (x *ListWebhookDeliveriesResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+type ReplayWebhookDeliveryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayWebhookDeliveryRequest) Reset() {
+	*x = ReplayWebhookDeliveryRequest{}
+	mi := &file_inventory_collector_v1_admin_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayWebhookDeliveryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayWebhookDeliveryRequest) ProtoMessage() {}
+
+func (x *ReplayWebhookDeliveryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_admin_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayWebhookDeliveryRequest.ProtoReflect.Descriptor instead.
+func (*ReplayWebhookDeliveryRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_admin_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ReplayWebhookDeliveryRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ReplayWebhookDeliveryResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// delivery is the delivery after the attempt; its status tells whether
+	// the attempt succeeded.
+	Delivery      *WebhookDelivery `protobuf:"bytes,1,opt,name=delivery,proto3" json:"delivery,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayWebhookDeliveryResponse) Reset() {
+	*x = ReplayWebhookDeliveryResponse{}
+	mi := &file_inventory_collector_v1_admin_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayWebhookDeliveryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayWebhookDeliveryResponse) ProtoMessage() {}
+
+func (x *ReplayWebhookDeliveryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_admin_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayWebhookDeliveryResponse.ProtoReflect.Descriptor instead.
+func (*ReplayWebhookDeliveryResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_admin_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ReplayWebhookDeliveryResponse) GetDelivery() *WebhookDelivery {
+	if x != nil {
+		return x.Delivery
+	}
+	return nil
+}
+
+// HostEnrichment is the custom fields of the host identified by key.
+type HostEnrichment struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// key is the serial number or system UUID of the host, as selected by
+	// EnrichHostsRequest.key.
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// fields maps field names (lower-case letters, digits and underscores) to
+	// values; an empty value removes the field.
+	Fields        map[string]string `protobuf:"bytes,2,rep,name=fields,proto3" json:"fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HostEnrichment) Reset() {
+	*x = HostEnrichment{}
+	mi := &file_inventory_collector_v1_admin_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HostEnrichment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HostEnrichment) ProtoMessage() {}
+
+func (x *HostEnrichment) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_admin_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HostEnrichment.ProtoReflect.Descriptor instead.
+func (*HostEnrichment) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_admin_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *HostEnrichment) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *HostEnrichment) GetFields() map[string]string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+type EnrichHostsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// key is what the hosts are matched by: serial or uuid.
+	Key   string            `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Hosts []*HostEnrichment `protobuf:"bytes,2,rep,name=hosts,proto3" json:"hosts,omitempty"`
+	// site limits the matching to the hosts of one site (empty = all sites
+	// the caller may access).
+	Site string `protobuf:"bytes,3,opt,name=site,proto3" json:"site,omitempty"`
+	// dry_run matches the hosts without storing their fields.
+	DryRun        bool `protobuf:"varint,4,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnrichHostsRequest) Reset() {
+	*x = EnrichHostsRequest{}
+	mi := &file_inventory_collector_v1_admin_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrichHostsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrichHostsRequest) ProtoMessage() {}
+
+func (x *EnrichHostsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_admin_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrichHostsRequest.ProtoReflect.Descriptor instead.
+func (*EnrichHostsRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_admin_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *EnrichHostsRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *EnrichHostsRequest) GetHosts() []*HostEnrichment {
+	if x != nil {
+		return x.Hosts
+	}
+	return nil
+}
+
+func (x *EnrichHostsRequest) GetSite() string {
+	if x != nil {
+		return x.Site
+	}
+	return ""
+}
+
+func (x *EnrichHostsRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type EnrichHostsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// matched is the number of entries that matched a host.
+	Matched int32 `protobuf:"varint,1,opt,name=matched,proto3" json:"matched,omitempty"`
+	// unmatched lists the keys of the entries that matched no host.
+	Unmatched     []string `protobuf:"bytes,2,rep,name=unmatched,proto3" json:"unmatched,omitempty"`
+	DryRun        bool     `protobuf:"varint,3,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnrichHostsResponse) Reset() {
+	*x = EnrichHostsResponse{}
+	mi := &file_inventory_collector_v1_admin_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrichHostsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrichHostsResponse) ProtoMessage() {}
+
+func (x *EnrichHostsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_admin_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrichHostsResponse.ProtoReflect.Descriptor instead.
+func (*EnrichHostsResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_admin_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *EnrichHostsResponse) GetMatched() int32 {
+	if x != nil {
+		return x.Matched
+	}
+	return 0
+}
+
+func (x *EnrichHostsResponse) GetUnmatched() []string {
+	if x != nil {
+		return x.Unmatched
+	}
+	return nil
+}
+
+func (x *EnrichHostsResponse) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+var File_inventory_collector_v1_admin_proto protoreflect.FileDescriptor
+
+const file_inventory_collector_v1_admin_proto_rawDesc = "" +
+	"\n" +
+	"\"inventory/collector/v1/admin.proto\x12\x16inventory.collector.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a&inventory/collector/v1/collector.proto\"\xd2\x01\n" +
+	"\x17PurgeInventoriesRequest\x12&\n" +
+	"\x0folder_than_days\x18\x01 \x01(\x05R\rolderThanDays\x12\x1a\n" +
+	"\bhostname\x18\x02 \x01(\tR\bhostname\x12\x1f\n" +
+	"\vsystem_uuid\x18\x03 \x01(\tR\n" +
+	"systemUuid\x12\x12\n" +
+	"\x04site\x18\x04 \x01(\tR\x04site\x12\x17\n" +
+	"\adry_run\x18\x05 \x01(\bR\x06dryRun\x12%\n" +
+	"\x0ehostname_regex\x18\x06 \x01(\tR\rhostnameRegex\"\x93\x01\n" +
+	"\x18PurgeInventoriesResponse\x12\x16\n" +
+	"\x06purged\x18\x01 \x01(\x03R\x06purged\x12#\n" +
+	"\ralerts_purged\x18\x02 \x01(\x03R\falertsPurged\x12\x17\n" +
+	"\adry_run\x18\x03 \x01(\bR\x06dryRun\x12!\n" +
+	"\fhosts_purged\x18\x04 \x01(\x03R\vhostsPurged\"n\n" +
+	"\x1bAdvertiseAgentUpdateRequest\x12;\n" +
+	"\x06update\x18\x01 \x01(\v2#.inventory.collector.v1.AgentUpdateR\x06update\x12\x12\n" +
+	"\x04site\x18\x02 \x01(\tR\x04site\":\n" +
+	"\x1cAdvertiseAgentUpdateResponse\x12\x1a\n" +
+	"\bnotified\x18\x01 \x01(\x05R\bnotified\"\xbe\x01\n" +
+	"\x12SendCommandRequest\x12\x1a\n" +
+	"\bhostname\x18\x01 \x01(\tR\bhostname\x12\x12\n" +
+	"\x04site\x18\x02 \x01(\tR\x04site\x12\x1b\n" +
+	"\tclient_id\x18\x03 \x01(\tR\bclientId\x12B\n" +
+	"\acommand\x18\x04 \x01(\v2(.inventory.collector.v1.InventoryCommandR\acommand\x12\x17\n" +
+	"\await_ms\x18\x05 \x01(\x03R\x06waitMs\"\xac\x01\n" +
+	"\x13SendCommandResponse\x12\x1d\n" +
+	"\n" +
+	"command_id\x18\x01 \x01(\tR\tcommandId\x12\x1b\n" +
+	"\tclient_id\x18\x02 \x01(\tR\bclientId\x12\"\n" +
+	"\facknowledged\x18\x03 \x01(\bR\facknowledged\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x12\x1f\n" +
+	"\vduration_ms\x18\x05 \x01(\x03R\n" +
+	"durationMs\"\x7f\n" +
+	"\x13GetAgentLogsRequest\x12\x1a\n" +
+	"\bhostname\x18\x01 \x01(\tR\bhostname\x12\x12\n" +
+	"\x04site\x18\x02 \x01(\tR\x04site\x12\x1b\n" +
+	"\tclient_id\x18\x03 \x01(\tR\bclientId\x12\x1b\n" +
+	"\tmax_bytes\x18\x04 \x01(\x03R\bmaxBytes\"e\n" +
+	"\x14GetAgentLogsResponse\x12\x12\n" +
+	"\x04logs\x18\x01 \x01(\fR\x04logs\x12\x1c\n" +
+	"\ttruncated\x18\x02 \x01(\bR\ttruncated\x12\x1b\n" +
+	"\tclient_id\x18\x03 \x01(\tR\bclientId\"(\n" +
+	"\x12WatchAgentsRequest\x12\x12\n" +
+	"\x04site\x18\x01 \x01(\tR\x04site\"\xd2\x01\n" +
+	"\n" +
+	"AgentEvent\x12:\n" +
+	"\x04type\x18\x01 \x01(\x0e2&.inventory.collector.v1.AgentEventTypeR\x04type\x12<\n" +
+	"\x05agent\x18\x02 \x01(\v2&.inventory.collector.v1.ConnectedAgentR\x05agent\x12.\n" +
+	"\x04time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x1a\n" +
+	"\bsnapshot\x18\x04 \x01(\bR\bsnapshot\"\x89\x02\n" +
+	"\bApiToken\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\x12\x14\n" +
+	"\x05sites\x18\x04 \x03(\tR\x05sites\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x129\n" +
+	"\n" +
+	"revoked_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\trevokedAt\"\x8d\x01\n" +
+	"\x12CreateTokenRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\x12\x14\n" +
+	"\x05sites\x18\x03 \x03(\tR\x05sites\x129\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"e\n" +
+	"\x13CreateTokenResponse\x126\n" +
+	"\x05token\x18\x01 \x01(\v2 .inventory.collector.v1.ApiTokenR\x05token\x12\x16\n" +
+	"\x06secret\x18\x02 \x01(\tR\x06secret\"\x13\n" +
+	"\x11ListTokensRequest\"N\n" +
+	"\x12ListTokensResponse\x128\n" +
+	"\x06tokens\x18\x01 \x03(\v2 .inventory.collector.v1.ApiTokenR\x06tokens\"$\n" +
+	"\x12RevokeTokenRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\x15\n" +
+	"\x13RevokeTokenResponse\"\x9d\x02\n" +
+	"\x0fWebhookDelivery\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x1a\n" +
+	"\battempts\x18\x04 \x01(\x05R\battempts\x12\x1f\n" +
+	"\vstatus_code\x18\x05 \x01(\x05R\n" +
+	"statusCode\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12B\n" +
+	"\x0flast_attempt_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\rlastAttemptAt\"g\n" +
+	"\x1cListWebhookDeliveriesRequest\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\"\x89\x01\n" +
+	"\x1dListWebhookDeliveriesResponse\x12G\n" +
+	"\n" +
+	"deliveries\x18\x01 \x03(\v2'.inventory.collector.v1.WebhookDeliveryR\n" +
+	"deliveries\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x05R\n" +
+	"totalCount\".\n" +
+	"\x1cReplayWebhookDeliveryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"d\n" +
+	"\x1dReplayWebhookDeliveryResponse\x12C\n" +
+	"\bdelivery\x18\x01 \x01(\v2'.inventory.collector.v1.WebhookDeliveryR\bdelivery\"\xa9\x01\n" +
+	"\x0eHostEnrichment\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12J\n" +
+	"\x06fields\x18\x02 \x03(\v22.inventory.collector.v1.HostEnrichment.FieldsEntryR\x06fields\x1a9\n" +
+	"\vFieldsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x91\x01\n" +
+	"\x12EnrichHostsRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12<\n" +
+	"\x05hosts\x18\x02 \x03(\v2&.inventory.collector.v1.HostEnrichmentR\x05hosts\x12\x12\n" +
+	"\x04site\x18\x03 \x01(\tR\x04site\x12\x17\n" +
+	"\adry_run\x18\x04 \x01(\bR\x06dryRun\"f\n" +
+	"\x13EnrichHostsResponse\x12\x18\n" +
+	"\amatched\x18\x01 \x01(\x05R\amatched\x12\x1c\n" +
+	"\tunmatched\x18\x02 \x03(\tR\tunmatched\x12\x17\n" +
+	"\adry_run\x18\x03 \x01(\bR\x06dryRun*\x91\x01\n" +
+	"\x0eAgentEventType\x12\x1e\n" +
+	"\x1aAGENT_EVENT_TYPE_CONNECTED\x10\x00\x12!\n" +
+	"\x1dAGENT_EVENT_TYPE_DISCONNECTED\x10\x01\x12\x1e\n" +
+	"\x1aAGENT_EVENT_TYPE_SUBMITTED\x10\x02\x12\x1c\n" +
+	"\x18AGENT_EVENT_TYPE_UPDATED\x10\x032\xca\x0e\n" +
+	"\x15InventoryAdminService\x12t\n" +
+	"\x0fDeleteInventory\x12..inventory.collector.v1.DeleteInventoryRequest\x1a/.inventory.collector.v1.DeleteInventoryResponse\"\x00\x12w\n" +
+	"\x10PurgeInventories\x12/.inventory.collector.v1.PurgeInventoriesRequest\x1a0.inventory.collector.v1.PurgeInventoriesResponse\"\x00\x12w\n" +
+	"\x10RefreshInventory\x12/.inventory.collector.v1.RefreshInventoryRequest\x1a0.inventory.collector.v1.RefreshInventoryResponse\"\x00\x12\x80\x01\n" +
+	"\x13ListConnectedAgents\x122.inventory.collector.v1.ListConnectedAgentsRequest\x1a3.inventory.collector.v1.ListConnectedAgentsResponse\"\x00\x12a\n" +
+	"\vWatchAgents\x12*.inventory.collector.v1.WatchAgentsRequest\x1a\".inventory.collector.v1.AgentEvent\"\x000\x01\x12e\n" +
+	"\n" +
+	"PauseAgent\x12).inventory.collector.v1.PauseAgentRequest\x1a*.inventory.collector.v1.PauseAgentResponse\"\x00\x12h\n" +
+	"\vResumeAgent\x12*.inventory.collector.v1.ResumeAgentRequest\x1a+.inventory.collector.v1.ResumeAgentResponse\"\x00\x12h\n" +
+	"\vSendCommand\x12*.inventory.collector.v1.SendCommandRequest\x1a+.inventory.collector.v1.SendCommandResponse\"\x00\x12k\n" +
+	"\fGetAgentLogs\x12+.inventory.collector.v1.GetAgentLogsRequest\x1a,.inventory.collector.v1.GetAgentLogsResponse\"\x00\x12\x83\x01\n" +
+	"\x14AdvertiseAgentUpdate\x123.inventory.collector.v1.AdvertiseAgentUpdateRequest\x1a4.inventory.collector.v1.AdvertiseAgentUpdateResponse\"\x00\x12h\n" +
+	"\vCreateToken\x12*.inventory.collector.v1.CreateTokenRequest\x1a+.inventory.collector.v1.CreateTokenResponse\"\x00\x12e\n" +
+	"\n" +
+	"ListTokens\x12).inventory.collector.v1.ListTokensRequest\x1a*.inventory.collector.v1.ListTokensResponse\"\x00\x12h\n" +
+	"\vRevokeToken\x12*.inventory.collector.v1.RevokeTokenRequest\x1a+.inventory.collector.v1.RevokeTokenResponse\"\x00\x12\x86\x01\n" +
+	"\x15ListWebhookDeliveries\x124.inventory.collector.v1.ListWebhookDeliveriesRequest\x1a5.inventory.collector.v1.ListWebhookDeliveriesResponse\"\x00\x12\x86\x01\n" +
+	"\x15ReplayWebhookDelivery\x124.inventory.collector.v1.ReplayWebhookDeliveryRequest\x1a5.inventory.collector.v1.ReplayWebhookDeliveryResponse\"\x00\x12h\n" +
+	"\vEnrichHosts\x12*.inventory.collector.v1.EnrichHostsRequest\x1a+.inventory.collector.v1.EnrichHostsResponse\"\x00B$Z\"inventory/collector/v1;collectorv1b\x06proto3"
+
+var (
+	file_inventory_collector_v1_admin_proto_rawDescOnce sync.Once
+	file_inventory_collector_v1_admin_proto_rawDescData []byte
+)
+
+func file_inventory_collector_v1_admin_proto_rawDescGZIP() []byte {
+	file_inventory_collector_v1_admin_proto_rawDescOnce.Do(func() {
+		file_inventory_collector_v1_admin_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_inventory_collector_v1_admin_proto_rawDesc), len(file_inventory_collector_v1_admin_proto_rawDesc)))
+	})
+	return file_inventory_collector_v1_admin_proto_rawDescData
+}
+
+var file_inventory_collector_v1_admin_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_inventory_collector_v1_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
+var file_inventory_collector_v1_admin_proto_goTypes = []any{
+	(AgentEventType)(0),                   // 0: inventory.collector.v1.AgentEventType
+	(*PurgeInventoriesRequest)(nil),       // 1: inventory.collector.v1.PurgeInventoriesRequest
+	(*PurgeInventoriesResponse)(nil),      // 2: inventory.collector.v1.PurgeInventoriesResponse
+	(*AdvertiseAgentUpdateRequest)(nil),   // 3: inventory.collector.v1.AdvertiseAgentUpdateRequest
+	(*AdvertiseAgentUpdateResponse)(nil),  // 4: inventory.collector.v1.AdvertiseAgentUpdateResponse
+	(*SendCommandRequest)(nil),            // 5: inventory.collector.v1.SendCommandRequest
+	(*SendCommandResponse)(nil),           // 6: inventory.collector.v1.SendCommandResponse
+	(*GetAgentLogsRequest)(nil),           // 7: inventory.collector.v1.GetAgentLogsRequest
+	(*GetAgentLogsResponse)(nil),          // 8: inventory.collector.v1.GetAgentLogsResponse
+	(*WatchAgentsRequest)(nil),            // 9: inventory.collector.v1.WatchAgentsRequest
+	(*AgentEvent)(nil),                    // 10: inventory.collector.v1.AgentEvent
+	(*ApiToken)(nil),                      // 11: inventory.collector.v1.ApiToken
+	(*CreateTokenRequest)(nil),            // 12: inventory.collector.v1.CreateTokenRequest
+	(*CreateTokenResponse)(nil),           // 13: inventory.collector.v1.CreateTokenResponse
+	(*ListTokensRequest)(nil),             // 14: inventory.collector.v1.ListTokensRequest
+	(*ListTokensResponse)(nil),            // 15: inventory.collector.v1.ListTokensResponse
+	(*RevokeTokenRequest)(nil),            // 16: inventory.collector.v1.RevokeTokenRequest
+	(*RevokeTokenResponse)(nil),           // 17: inventory.collector.v1.RevokeTokenResponse
+	(*WebhookDelivery)(nil),               // 18: inventory.collector.v1.WebhookDelivery
+	(*ListWebhookDeliveriesRequest)(nil),  // 19: inventory.collector.v1.ListWebhookDeliveriesRequest
+	(*ListWebhookDeliveriesResponse)(nil), // 20: inventory.collector.v1.ListWebhookDeliveriesResponse
+	(*ReplayWebhookDeliveryRequest)(nil),  // 21: inventory.collector.v1.ReplayWebhookDeliveryRequest
+	(*ReplayWebhookDeliveryResponse)(nil), // 22: inventory.collector.v1.ReplayWebhookDeliveryResponse
+	(*HostEnrichment)(nil),                // 23: inventory.collector.v1.HostEnrichment
+	(*EnrichHostsRequest)(nil),            // 24: inventory.collector.v1.EnrichHostsRequest
+	(*EnrichHostsResponse)(nil),           // 25: inventory.collector.v1.EnrichHostsResponse
+	nil,                                   // 26: inventory.collector.v1.HostEnrichment.FieldsEntry
+	(*AgentUpdate)(nil),                   // 27: inventory.collector.v1.AgentUpdate
+	(*InventoryCommand)(nil),              // 28: inventory.collector.v1.InventoryCommand
+	(*ConnectedAgent)(nil),                // 29: inventory.collector.v1.ConnectedAgent
+	(*timestamp.Timestamp)(nil),           // 30: google.protobuf.Timestamp
+	(*DeleteInventoryRequest)(nil),        // 31: inventory.collector.v1.DeleteInventoryRequest
+	(*RefreshInventoryRequest)(nil),       // 32: inventory.collector.v1.RefreshInventoryRequest
+	(*ListConnectedAgentsRequest)(nil),    // 33: inventory.collector.v1.ListConnectedAgentsRequest
+	(*PauseAgentRequest)(nil),             // 34: inventory.collector.v1.PauseAgentRequest
+	(*ResumeAgentRequest)(nil),            // 35: inventory.collector.v1.ResumeAgentRequest
+	(*DeleteInventoryResponse)(nil),       // 36: inventory.collector.v1.DeleteInventoryResponse
+	(*RefreshInventoryResponse)(nil),      // 37: inventory.collector.v1.RefreshInventoryResponse
+	(*ListConnectedAgentsResponse)(nil),   // 38: inventory.collector.v1.ListConnectedAgentsResponse
+	(*PauseAgentResponse)(nil),            // 39: inventory.collector.v1.PauseAgentResponse
+	(*ResumeAgentResponse)(nil),           // 40: inventory.collector.v1.ResumeAgentResponse
+}
+var file_inventory_collector_v1_admin_proto_depIdxs = []int32{
+	27, // 0: inventory.collector.v1.AdvertiseAgentUpdateRequest.update:type_name -> inventory.collector.v1.AgentUpdate
+	28, // 1: inventory.collector.v1.SendCommandRequest.command:type_name -> inventory.collector.v1.InventoryCommand
+	0,  // 2: inventory.collector.v1.AgentEvent.type:type_name -> inventory.collector.v1.AgentEventType
+	29, // 3: inventory.collector.v1.AgentEvent.agent:type_name -> inventory.collector.v1.ConnectedAgent
+	30, // 4: inventory.collector.v1.AgentEvent.time:type_name -> google.protobuf.Timestamp
+	30, // 5: inventory.collector.v1.ApiToken.created_at:type_name -> google.protobuf.Timestamp
+	30, // 6: inventory.collector.v1.ApiToken.expires_at:type_name -> google.protobuf.Timestamp
+	30, // 7: inventory.collector.v1.ApiToken.revoked_at:type_name -> google.protobuf.Timestamp
+	30, // 8: inventory.collector.v1.CreateTokenRequest.expires_at:type_name -> google.protobuf.Timestamp
+	11, // 9: inventory.collector.v1.CreateTokenResponse.token:type_name -> inventory.collector.v1.ApiToken
+	11, // 10: inventory.collector.v1.ListTokensResponse.tokens:type_name -> inventory.collector.v1.ApiToken
+	30, // 11: inventory.collector.v1.WebhookDelivery.created_at:type_name -> google.protobuf.Timestamp
+	30, // 12: inventory.collector.v1.WebhookDelivery.last_attempt_at:type_name -> google.protobuf.Timestamp
+	18, // 13: inventory.collector.v1.ListWebhookDeliveriesResponse.deliveries:type_name -> inventory.collector.v1.WebhookDelivery
+	18, // 14: inventory.collector.v1.ReplayWebhookDeliveryResponse.delivery:type_name -> inventory.collector.v1.WebhookDelivery
+	26, // 15: inventory.collector.v1.HostEnrichment.fields:type_name -> inventory.collector.v1.HostEnrichment.FieldsEntry
+	23, // 16: inventory.collector.v1.EnrichHostsRequest.hosts:type_name -> inventory.collector.v1.HostEnrichment
+	31, // 17: inventory.collector.v1.InventoryAdminService.DeleteInventory:input_type -> inventory.collector.v1.DeleteInventoryRequest
+	1,  // 18: inventory.collector.v1.InventoryAdminService.PurgeInventories:input_type -> inventory.collector.v1.PurgeInventoriesRequest
+	32, // 19: inventory.collector.v1.InventoryAdminService.RefreshInventory:input_type -> inventory.collector.v1.RefreshInventoryRequest
+	33, // 20: inventory.collector.v1.InventoryAdminService.ListConnectedAgents:input_type -> inventory.collector.v1.ListConnectedAgentsRequest
+	9,  // 21: inventory.collector.v1.InventoryAdminService.WatchAgents:input_type -> inventory.collector.v1.WatchAgentsRequest
+	34, // 22: inventory.collector.v1.InventoryAdminService.PauseAgent:input_type -> inventory.collector.v1.PauseAgentRequest
+	35, // 23: inventory.collector.v1.InventoryAdminService.ResumeAgent:input_type -> inventory.collector.v1.ResumeAgentRequest
+	5,  // 24: inventory.collector.v1.InventoryAdminService.SendCommand:input_type -> inventory.collector.v1.SendCommandRequest
+	7,  // 25: inventory.collector.v1.InventoryAdminService.GetAgentLogs:input_type -> inventory.collector.v1.GetAgentLogsRequest
+	3,  // 26: inventory.collector.v1.InventoryAdminService.AdvertiseAgentUpdate:input_type -> inventory.collector.v1.AdvertiseAgentUpdateRequest
+	12, // 27: inventory.collector.v1.InventoryAdminService.CreateToken:input_type -> inventory.collector.v1.CreateTokenRequest
+	14, // 28: inventory.collector.v1.InventoryAdminService.ListTokens:input_type -> inventory.collector.v1.ListTokensRequest
+	16, // 29: inventory.collector.v1.InventoryAdminService.RevokeToken:input_type -> inventory.collector.v1.RevokeTokenRequest
+	19, // 30: inventory.collector.v1.InventoryAdminService.ListWebhookDeliveries:input_type -> inventory.collector.v1.ListWebhookDeliveriesRequest
+	21, // 31: inventory.collector.v1.InventoryAdminService.ReplayWebhookDelivery:input_type -> inventory.collector.v1.ReplayWebhookDeliveryRequest
+	24, // 32: inventory.collector.v1.InventoryAdminService.EnrichHosts:input_type -> inventory.collector.v1.EnrichHostsRequest
+	36, // 33: inventory.collector.v1.InventoryAdminService.DeleteInventory:output_type -> inventory.collector.v1.DeleteInventoryResponse
+	2,  // 34: inventory.collector.v1.InventoryAdminService.PurgeInventories:output_type -> inventory.collector.v1.PurgeInventoriesResponse
+	37, // 35: inventory.collector.v1.InventoryAdminService.RefreshInventory:output_type -> inventory.collector.v1.RefreshInventoryResponse
+	38, // 36: inventory.collector.v1.InventoryAdminService.ListConnectedAgents:output_type -> inventory.collector.v1.ListConnectedAgentsResponse
+	10, // 37: inventory.collector.v1.InventoryAdminService.WatchAgents:output_type -> inventory.collector.v1.AgentEvent
+	39, // 38: inventory.collector.v1.InventoryAdminService.PauseAgent:output_type -> inventory.collector.v1.PauseAgentResponse
+	40, // 39: inventory.collector.v1.InventoryAdminService.ResumeAgent:output_type -> inventory.collector.v1.ResumeAgentResponse
+	6,  // 40: inventory.collector.v1.InventoryAdminService.SendCommand:output_type -> inventory.collector.v1.SendCommandResponse
+	8,  // 41: inventory.collector.v1.InventoryAdminService.GetAgentLogs:output_type -> inventory.collector.v1.GetAgentLogsResponse
+	4,  // 42: inventory.collector.v1.InventoryAdminService.AdvertiseAgentUpdate:output_type -> inventory.collector.v1.AdvertiseAgentUpdateResponse
+	13, // 43: inventory.collector.v1.InventoryAdminService.CreateToken:output_type -> inventory.collector.v1.CreateTokenResponse
+	15, // 44: inventory.collector.v1.InventoryAdminService.ListTokens:output_type -> inventory.collector.v1.ListTokensResponse
+	17, // 45: inventory.collector.v1.InventoryAdminService.RevokeToken:output_type -> inventory.collector.v1.RevokeTokenResponse
+	20, // 46: inventory.collector.v1.InventoryAdminService.ListWebhookDeliveries:output_type -> inventory.collector.v1.ListWebhookDeliveriesResponse
+	22, // 47: inventory.collector.v1.InventoryAdminService.ReplayWebhookDelivery:output_type -> inventory.collector.v1.ReplayWebhookDeliveryResponse
+	25, // 48: inventory.collector.v1.InventoryAdminService.EnrichHosts:output_type -> inventory.collector.v1.EnrichHostsResponse
+	33, // [33:49] is the sub-list for method output_type
+	17, // [17:33] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
+}
+
+func init() { file_inventory_collector_v1_admin_proto_init() }
+func file_inventory_collector_v1_admin_proto_init() {
+	if File_inventory_collector_v1_admin_proto != nil {
+		return
+	}
+	file_inventory_collector_v1_collector_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_inventory_collector_v1_admin_proto_rawDesc), len(file_inventory_collector_v1_admin_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   26,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_inventory_collector_v1_admin_proto_goTypes,
+		DependencyIndexes: file_inventory_collector_v1_admin_proto_depIdxs,
+		EnumInfos:         file_inventory_collector_v1_admin_proto_enumTypes,
+		MessageInfos:      file_inventory_collector_v1_admin_proto_msgTypes,
+	}.Build()
+	File_inventory_collector_v1_admin_proto = out.File
+	file_inventory_collector_v1_admin_proto_goTypes = nil
+	file_inventory_collector_v1_admin_proto_depIdxs = nil
+}
@@ -0,0 +1,761 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v5.28.3
+// source: inventory/collector/v1/admin.proto
+
+package collectorv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	InventoryAdminService_DeleteInventory_FullMethodName       = "/inventory.collector.v1.InventoryAdminService/DeleteInventory"
+	InventoryAdminService_PurgeInventories_FullMethodName      = "/inventory.collector.v1.InventoryAdminService/PurgeInventories"
+	InventoryAdminService_RefreshInventory_FullMethodName      = "/inventory.collector.v1.InventoryAdminService/RefreshInventory"
+	InventoryAdminService_ListConnectedAgents_FullMethodName   = "/inventory.collector.v1.InventoryAdminService/ListConnectedAgents"
+	InventoryAdminService_WatchAgents_FullMethodName           = "/inventory.collector.v1.InventoryAdminService/WatchAgents"
+	InventoryAdminService_PauseAgent_FullMethodName            = "/inventory.collector.v1.InventoryAdminService/PauseAgent"
+	InventoryAdminService_ResumeAgent_FullMethodName           = "/inventory.collector.v1.InventoryAdminService/ResumeAgent"
+	InventoryAdminService_SendCommand_FullMethodName           = "/inventory.collector.v1.InventoryAdminService/SendCommand"
+	InventoryAdminService_GetAgentLogs_FullMethodName          = "/inventory.collector.v1.InventoryAdminService/GetAgentLogs"
+	InventoryAdminService_AdvertiseAgentUpdate_FullMethodName  = "/inventory.collector.v1.InventoryAdminService/AdvertiseAgentUpdate"
+	InventoryAdminService_CreateToken_FullMethodName           = "/inventory.collector.v1.InventoryAdminService/CreateToken"
+	InventoryAdminService_ListTokens_FullMethodName            = "/inventory.collector.v1.InventoryAdminService/ListTokens"
+	InventoryAdminService_RevokeToken_FullMethodName           = "/inventory.collector.v1.InventoryAdminService/RevokeToken"
+	InventoryAdminService_ListWebhookDeliveries_FullMethodName = "/inventory.collector.v1.InventoryAdminService/ListWebhookDeliveries"
+	InventoryAdminService_ReplayWebhookDelivery_FullMethodName = "/inventory.collector.v1.InventoryAdminService/ReplayWebhookDelivery"
+	InventoryAdminService_EnrichHosts_FullMethodName           = "/inventory.collector.v1.InventoryAdminService/EnrichHosts"
+)
+
+// InventoryAdminServiceClient is the client API for InventoryAdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// InventoryAdminService exposes management RPCs on a listener separate from
+// the agent-facing InventoryCollectorService.
+type InventoryAdminServiceClient interface {
+	// DeleteInventory removes a stored inventory by ID.
+	DeleteInventory(ctx context.Context, in *DeleteInventoryRequest, opts ...grpc.CallOption) (*DeleteInventoryResponse, error)
+	// PurgeInventories deletes inventory records older than the given age,
+	// optionally only those of one host or of the hosts matching a pattern,
+	// together with their alerts. With dry_run it only counts them.
+	PurgeInventories(ctx context.Context, in *PurgeInventoriesRequest, opts ...grpc.CallOption) (*PurgeInventoriesResponse, error)
+	// RefreshInventory sends a refresh command to a connected agent.
+	RefreshInventory(ctx context.Context, in *RefreshInventoryRequest, opts ...grpc.CallOption) (*RefreshInventoryResponse, error)
+	// ListConnectedAgents returns the currently connected agents.
+	ListConnectedAgents(ctx context.Context, in *ListConnectedAgentsRequest, opts ...grpc.CallOption) (*ListConnectedAgentsResponse, error)
+	// WatchAgents streams the agents connected when it is called, then every
+	// connect, disconnect, submission and pause or resume as it happens.
+	WatchAgents(ctx context.Context, in *WatchAgentsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AgentEvent], error)
+	// PauseAgent tells a connected agent to stop collection until resumed.
+	PauseAgent(ctx context.Context, in *PauseAgentRequest, opts ...grpc.CallOption) (*PauseAgentResponse, error)
+	// ResumeAgent tells a paused agent to resume collection.
+	ResumeAgent(ctx context.Context, in *ResumeAgentRequest, opts ...grpc.CallOption) (*ResumeAgentResponse, error)
+	// SendCommand sends any command to a connected agent and, with wait_ms,
+	// waits for the agent to acknowledge it.
+	SendCommand(ctx context.Context, in *SendCommandRequest, opts ...grpc.CallOption) (*SendCommandResponse, error)
+	// GetAgentLogs asks a connected agent for its recent log output and waits
+	// for the answer.
+	GetAgentLogs(ctx context.Context, in *GetAgentLogsRequest, opts ...grpc.CallOption) (*GetAgentLogsResponse, error)
+	// AdvertiseAgentUpdate publishes an agent release. Connected agents running
+	// another version are sent an update command right away, and agents that
+	// connect later receive it on connect. The advertisement is kept in memory
+	// until replaced, cleared with an empty version, or the collector restarts.
+	AdvertiseAgentUpdate(ctx context.Context, in *AdvertiseAgentUpdateRequest, opts ...grpc.CallOption) (*AdvertiseAgentUpdateResponse, error)
+	// CreateToken issues a managed API token. The token is only returned in
+	// the response; the collector keeps a hash of it. Issuing the first token
+	// turns on authentication if no secrets are configured.
+	CreateToken(ctx context.Context, in *CreateTokenRequest, opts ...grpc.CallOption) (*CreateTokenResponse, error)
+	// ListTokens returns the issued tokens, without the tokens themselves.
+	ListTokens(ctx context.Context, in *ListTokensRequest, opts ...grpc.CallOption) (*ListTokensResponse, error)
+	// RevokeToken revokes a token with immediate effect.
+	RevokeToken(ctx context.Context, in *RevokeTokenRequest, opts ...grpc.CallOption) (*RevokeTokenResponse, error)
+	// ListWebhookDeliveries returns the recorded alert webhook deliveries,
+	// newest first.
+	ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error)
+	// ReplayWebhookDelivery posts a failed webhook delivery again, signed with
+	// the current secret of its endpoint, and returns the outcome.
+	ReplayWebhookDelivery(ctx context.Context, in *ReplayWebhookDeliveryRequest, opts ...grpc.CallOption) (*ReplayWebhookDeliveryResponse, error)
+	// EnrichHosts sets custom fields, such as owner, location or cost center,
+	// on the hosts whose latest inventory has the given serial number or
+	// system UUID. ListHosts returns them with each host.
+	EnrichHosts(ctx context.Context, in *EnrichHostsRequest, opts ...grpc.CallOption) (*EnrichHostsResponse, error)
+}
+
+type inventoryAdminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewInventoryAdminServiceClient(cc grpc.ClientConnInterface) InventoryAdminServiceClient {
+	return &inventoryAdminServiceClient{cc}
+}
+
+func (c *inventoryAdminServiceClient) DeleteInventory(ctx context.Context, in *DeleteInventoryRequest, opts ...grpc.CallOption) (*DeleteInventoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteInventoryResponse)
+	err := c.cc.Invoke(ctx, InventoryAdminService_DeleteInventory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryAdminServiceClient) PurgeInventories(ctx context.Context, in *PurgeInventoriesRequest, opts ...grpc.CallOption) (*PurgeInventoriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PurgeInventoriesResponse)
+	err := c.cc.Invoke(ctx, InventoryAdminService_PurgeInventories_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryAdminServiceClient) RefreshInventory(ctx context.Context, in *RefreshInventoryRequest, opts ...grpc.CallOption) (*RefreshInventoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RefreshInventoryResponse)
+	err := c.cc.Invoke(ctx, InventoryAdminService_RefreshInventory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryAdminServiceClient) ListConnectedAgents(ctx context.Context, in *ListConnectedAgentsRequest, opts ...grpc.CallOption) (*ListConnectedAgentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListConnectedAgentsResponse)
+	err := c.cc.Invoke(ctx, InventoryAdminService_ListConnectedAgents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryAdminServiceClient) WatchAgents(ctx context.Context, in *WatchAgentsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AgentEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &InventoryAdminService_ServiceDesc.Streams[0], InventoryAdminService_WatchAgents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchAgentsRequest, AgentEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type InventoryAdminService_WatchAgentsClient = grpc.ServerStreamingClient[AgentEvent]
+
+func (c *inventoryAdminServiceClient) PauseAgent(ctx context.Context, in *PauseAgentRequest, opts ...grpc.CallOption) (*PauseAgentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PauseAgentResponse)
+	err := c.cc.Invoke(ctx, InventoryAdminService_PauseAgent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryAdminServiceClient) ResumeAgent(ctx context.Context, in *ResumeAgentRequest, opts ...grpc.CallOption) (*ResumeAgentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResumeAgentResponse)
+	err := c.cc.Invoke(ctx, InventoryAdminService_ResumeAgent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryAdminServiceClient) SendCommand(ctx context.Context, in *SendCommandRequest, opts ...grpc.CallOption) (*SendCommandResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SendCommandResponse)
+	err := c.cc.Invoke(ctx, InventoryAdminService_SendCommand_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryAdminServiceClient) GetAgentLogs(ctx context.Context, in *GetAgentLogsRequest, opts ...grpc.CallOption) (*GetAgentLogsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAgentLogsResponse)
+	err := c.cc.Invoke(ctx, InventoryAdminService_GetAgentLogs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryAdminServiceClient) AdvertiseAgentUpdate(ctx context.Context, in *AdvertiseAgentUpdateRequest, opts ...grpc.CallOption) (*AdvertiseAgentUpdateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdvertiseAgentUpdateResponse)
+	err := c.cc.Invoke(ctx, InventoryAdminService_AdvertiseAgentUpdate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryAdminServiceClient) CreateToken(ctx context.Context, in *CreateTokenRequest, opts ...grpc.CallOption) (*CreateTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateTokenResponse)
+	err := c.cc.Invoke(ctx, InventoryAdminService_CreateToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryAdminServiceClient) ListTokens(ctx context.Context, in *ListTokensRequest, opts ...grpc.CallOption) (*ListTokensResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTokensResponse)
+	err := c.cc.Invoke(ctx, InventoryAdminService_ListTokens_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryAdminServiceClient) RevokeToken(ctx context.Context, in *RevokeTokenRequest, opts ...grpc.CallOption) (*RevokeTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeTokenResponse)
+	err := c.cc.Invoke(ctx, InventoryAdminService_RevokeToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryAdminServiceClient) ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWebhookDeliveriesResponse)
+	err := c.cc.Invoke(ctx, InventoryAdminService_ListWebhookDeliveries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryAdminServiceClient) ReplayWebhookDelivery(ctx context.Context, in *ReplayWebhookDeliveryRequest, opts ...grpc.CallOption) (*ReplayWebhookDeliveryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReplayWebhookDeliveryResponse)
+	err := c.cc.Invoke(ctx, InventoryAdminService_ReplayWebhookDelivery_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryAdminServiceClient) EnrichHosts(ctx context.Context, in *EnrichHostsRequest, opts ...grpc.CallOption) (*EnrichHostsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EnrichHostsResponse)
+	err := c.cc.Invoke(ctx, InventoryAdminService_EnrichHosts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// InventoryAdminServiceServer is the server API for InventoryAdminService service.
+// All implementations must embed UnimplementedInventoryAdminServiceServer
+// for forward compatibility.
+//
+// InventoryAdminService exposes management RPCs on a listener separate from
+// the agent-facing InventoryCollectorService.
+type InventoryAdminServiceServer interface {
+	// DeleteInventory removes a stored inventory by ID.
+	DeleteInventory(context.Context, *DeleteInventoryRequest) (*DeleteInventoryResponse, error)
+	// PurgeInventories deletes inventory records older than the given age,
+	// optionally only those of one host or of the hosts matching a pattern,
+	// together with their alerts. With dry_run it only counts them.
+	PurgeInventories(context.Context, *PurgeInventoriesRequest) (*PurgeInventoriesResponse, error)
+	// RefreshInventory sends a refresh command to a connected agent.
+	RefreshInventory(context.Context, *RefreshInventoryRequest) (*RefreshInventoryResponse, error)
+	// ListConnectedAgents returns the currently connected agents.
+	ListConnectedAgents(context.Context, *ListConnectedAgentsRequest) (*ListConnectedAgentsResponse, error)
+	// WatchAgents streams the agents connected when it is called, then every
+	// connect, disconnect, submission and pause or resume as it happens.
+	WatchAgents(*WatchAgentsRequest, grpc.ServerStreamingServer[AgentEvent]) error
+	// PauseAgent tells a connected agent to stop collection until resumed.
+	PauseAgent(context.Context, *PauseAgentRequest) (*PauseAgentResponse, error)
+	// ResumeAgent tells a paused agent to resume collection.
+	ResumeAgent(context.Context, *ResumeAgentRequest) (*ResumeAgentResponse, error)
+	// SendCommand sends any command to a connected agent and, with wait_ms,
+	// waits for the agent to acknowledge it.
+	SendCommand(context.Context, *SendCommandRequest) (*SendCommandResponse, error)
+	// GetAgentLogs asks a connected agent for its recent log output and waits
+	// for the answer.
+	GetAgentLogs(context.Context, *GetAgentLogsRequest) (*GetAgentLogsResponse, error)
+	// AdvertiseAgentUpdate publishes an agent release. Connected agents running
+	// another version are sent an update command right away, and agents that
+	// connect later receive it on connect. The advertisement is kept in memory
+	// until replaced, cleared with an empty version, or the collector restarts.
+	AdvertiseAgentUpdate(context.Context, *AdvertiseAgentUpdateRequest) (*AdvertiseAgentUpdateResponse, error)
+	// CreateToken issues a managed API token. The token is only returned in
+	// the response; the collector keeps a hash of it. Issuing the first token
+	// turns on authentication if no secrets are configured.
+	CreateToken(context.Context, *CreateTokenRequest) (*CreateTokenResponse, error)
+	// ListTokens returns the issued tokens, without the tokens themselves.
+	ListTokens(context.Context, *ListTokensRequest) (*ListTokensResponse, error)
+	// RevokeToken revokes a token with immediate effect.
+	RevokeToken(context.Context, *RevokeTokenRequest) (*RevokeTokenResponse, error)
+	// ListWebhookDeliveries returns the recorded alert webhook deliveries,
+	// newest first.
+	ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error)
+	// ReplayWebhookDelivery posts a failed webhook delivery again, signed with
+	// the current secret of its endpoint, and returns the outcome.
+	ReplayWebhookDelivery(context.Context, *ReplayWebhookDeliveryRequest) (*ReplayWebhookDeliveryResponse, error)
+	// EnrichHosts sets custom fields, such as owner, location or cost center,
+	// on the hosts whose latest inventory has the given serial number or
+	// system UUID. ListHosts returns them with each host.
+	EnrichHosts(context.Context, *EnrichHostsRequest) (*EnrichHostsResponse, error)
+	mustEmbedUnimplementedInventoryAdminServiceServer()
+}
+
+// UnimplementedInventoryAdminServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedInventoryAdminServiceServer struct{}
+
+func (UnimplementedInventoryAdminServiceServer) DeleteInventory(context.Context, *DeleteInventoryRequest) (*DeleteInventoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteInventory not implemented")
+}
+func (UnimplementedInventoryAdminServiceServer) PurgeInventories(context.Context, *PurgeInventoriesRequest) (*PurgeInventoriesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PurgeInventories not implemented")
+}
+func (UnimplementedInventoryAdminServiceServer) RefreshInventory(context.Context, *RefreshInventoryRequest) (*RefreshInventoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RefreshInventory not implemented")
+}
+func (UnimplementedInventoryAdminServiceServer) ListConnectedAgents(context.Context, *ListConnectedAgentsRequest) (*ListConnectedAgentsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListConnectedAgents not implemented")
+}
+func (UnimplementedInventoryAdminServiceServer) WatchAgents(*WatchAgentsRequest, grpc.ServerStreamingServer[AgentEvent]) error {
+	return status.Error(codes.Unimplemented, "method WatchAgents not implemented")
+}
+func (UnimplementedInventoryAdminServiceServer) PauseAgent(context.Context, *PauseAgentRequest) (*PauseAgentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PauseAgent not implemented")
+}
+func (UnimplementedInventoryAdminServiceServer) ResumeAgent(context.Context, *ResumeAgentRequest) (*ResumeAgentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ResumeAgent not implemented")
+}
+func (UnimplementedInventoryAdminServiceServer) SendCommand(context.Context, *SendCommandRequest) (*SendCommandResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SendCommand not implemented")
+}
+func (UnimplementedInventoryAdminServiceServer) GetAgentLogs(context.Context, *GetAgentLogsRequest) (*GetAgentLogsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetAgentLogs not implemented")
+}
+func (UnimplementedInventoryAdminServiceServer) AdvertiseAgentUpdate(context.Context, *AdvertiseAgentUpdateRequest) (*AdvertiseAgentUpdateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AdvertiseAgentUpdate not implemented")
+}
+func (UnimplementedInventoryAdminServiceServer) CreateToken(context.Context, *CreateTokenRequest) (*CreateTokenResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateToken not implemented")
+}
+func (UnimplementedInventoryAdminServiceServer) ListTokens(context.Context, *ListTokensRequest) (*ListTokensResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListTokens not implemented")
+}
+func (UnimplementedInventoryAdminServiceServer) RevokeToken(context.Context, *RevokeTokenRequest) (*RevokeTokenResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RevokeToken not implemented")
+}
+func (UnimplementedInventoryAdminServiceServer) ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListWebhookDeliveries not implemented")
+}
+func (UnimplementedInventoryAdminServiceServer) ReplayWebhookDelivery(context.Context, *ReplayWebhookDeliveryRequest) (*ReplayWebhookDeliveryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReplayWebhookDelivery not implemented")
+}
+func (UnimplementedInventoryAdminServiceServer) EnrichHosts(context.Context, *EnrichHostsRequest) (*EnrichHostsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method EnrichHosts not implemented")
+}
+func (UnimplementedInventoryAdminServiceServer) mustEmbedUnimplementedInventoryAdminServiceServer() {}
+func (UnimplementedInventoryAdminServiceServer) testEmbeddedByValue()                               {}
+
+// UnsafeInventoryAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to InventoryAdminServiceServer will
+// result in compilation errors.
+type UnsafeInventoryAdminServiceServer interface {
+	mustEmbedUnimplementedInventoryAdminServiceServer()
+}
+
+func RegisterInventoryAdminServiceServer(s grpc.ServiceRegistrar, srv InventoryAdminServiceServer) {
+	// If the following call panics, it indicates UnimplementedInventoryAdminServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&InventoryAdminService_ServiceDesc, srv)
+}
+
+func _InventoryAdminService_DeleteInventory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteInventoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryAdminServiceServer).DeleteInventory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryAdminService_DeleteInventory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryAdminServiceServer).DeleteInventory(ctx, req.(*DeleteInventoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryAdminService_PurgeInventories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeInventoriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryAdminServiceServer).PurgeInventories(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryAdminService_PurgeInventories_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryAdminServiceServer).PurgeInventories(ctx, req.(*PurgeInventoriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryAdminService_RefreshInventory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshInventoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryAdminServiceServer).RefreshInventory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryAdminService_RefreshInventory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryAdminServiceServer).RefreshInventory(ctx, req.(*RefreshInventoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryAdminService_ListConnectedAgents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListConnectedAgentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryAdminServiceServer).ListConnectedAgents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryAdminService_ListConnectedAgents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryAdminServiceServer).ListConnectedAgents(ctx, req.(*ListConnectedAgentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryAdminService_WatchAgents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchAgentsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(InventoryAdminServiceServer).WatchAgents(m, &grpc.GenericServerStream[WatchAgentsRequest, AgentEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type InventoryAdminService_WatchAgentsServer = grpc.ServerStreamingServer[AgentEvent]
+
+func _InventoryAdminService_PauseAgent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseAgentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryAdminServiceServer).PauseAgent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryAdminService_PauseAgent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryAdminServiceServer).PauseAgent(ctx, req.(*PauseAgentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryAdminService_ResumeAgent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeAgentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryAdminServiceServer).ResumeAgent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryAdminService_ResumeAgent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryAdminServiceServer).ResumeAgent(ctx, req.(*ResumeAgentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryAdminService_SendCommand_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendCommandRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryAdminServiceServer).SendCommand(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryAdminService_SendCommand_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryAdminServiceServer).SendCommand(ctx, req.(*SendCommandRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryAdminService_GetAgentLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAgentLogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryAdminServiceServer).GetAgentLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryAdminService_GetAgentLogs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryAdminServiceServer).GetAgentLogs(ctx, req.(*GetAgentLogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryAdminService_AdvertiseAgentUpdate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdvertiseAgentUpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryAdminServiceServer).AdvertiseAgentUpdate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryAdminService_AdvertiseAgentUpdate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryAdminServiceServer).AdvertiseAgentUpdate(ctx, req.(*AdvertiseAgentUpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryAdminService_CreateToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryAdminServiceServer).CreateToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryAdminService_CreateToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryAdminServiceServer).CreateToken(ctx, req.(*CreateTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryAdminService_ListTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTokensRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryAdminServiceServer).ListTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryAdminService_ListTokens_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryAdminServiceServer).ListTokens(ctx, req.(*ListTokensRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryAdminService_RevokeToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryAdminServiceServer).RevokeToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryAdminService_RevokeToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryAdminServiceServer).RevokeToken(ctx, req.(*RevokeTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryAdminService_ListWebhookDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhookDeliveriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryAdminServiceServer).ListWebhookDeliveries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryAdminService_ListWebhookDeliveries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryAdminServiceServer).ListWebhookDeliveries(ctx, req.(*ListWebhookDeliveriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryAdminService_ReplayWebhookDelivery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplayWebhookDeliveryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryAdminServiceServer).ReplayWebhookDelivery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryAdminService_ReplayWebhookDelivery_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryAdminServiceServer).ReplayWebhookDelivery(ctx, req.(*ReplayWebhookDeliveryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryAdminService_EnrichHosts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnrichHostsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryAdminServiceServer).EnrichHosts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryAdminService_EnrichHosts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryAdminServiceServer).EnrichHosts(ctx, req.(*EnrichHostsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// InventoryAdminService_ServiceDesc is the grpc.ServiceDesc for InventoryAdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var InventoryAdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "inventory.collector.v1.InventoryAdminService",
+	HandlerType: (*InventoryAdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "DeleteInventory",
+			Handler:    _InventoryAdminService_DeleteInventory_Handler,
+		},
+		{
+			MethodName: "PurgeInventories",
+			Handler:    _InventoryAdminService_PurgeInventories_Handler,
+		},
+		{
+			MethodName: "RefreshInventory",
+			Handler:    _InventoryAdminService_RefreshInventory_Handler,
+		},
+		{
+			MethodName: "ListConnectedAgents",
+			Handler:    _InventoryAdminService_ListConnectedAgents_Handler,
+		},
+		{
+			MethodName: "PauseAgent",
+			Handler:    _InventoryAdminService_PauseAgent_Handler,
+		},
+		{
+			MethodName: "ResumeAgent",
+			Handler:    _InventoryAdminService_ResumeAgent_Handler,
+		},
+		{
+			MethodName: "SendCommand",
+			Handler:    _InventoryAdminService_SendCommand_Handler,
+		},
+		{
+			MethodName: "GetAgentLogs",
+			Handler:    _InventoryAdminService_GetAgentLogs_Handler,
+		},
+		{
+			MethodName: "AdvertiseAgentUpdate",
+			Handler:    _InventoryAdminService_AdvertiseAgentUpdate_Handler,
+		},
+		{
+			MethodName: "CreateToken",
+			Handler:    _InventoryAdminService_CreateToken_Handler,
+		},
+		{
+			MethodName: "ListTokens",
+			Handler:    _InventoryAdminService_ListTokens_Handler,
+		},
+		{
+			MethodName: "RevokeToken",
+			Handler:    _InventoryAdminService_RevokeToken_Handler,
+		},
+		{
+			MethodName: "ListWebhookDeliveries",
+			Handler:    _InventoryAdminService_ListWebhookDeliveries_Handler,
+		},
+		{
+			MethodName: "ReplayWebhookDelivery",
+			Handler:    _InventoryAdminService_ReplayWebhookDelivery_Handler,
+		},
+		{
+			MethodName: "EnrichHosts",
+			Handler:    _InventoryAdminService_EnrichHosts_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchAgents",
+			Handler:       _InventoryAdminService_WatchAgents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "inventory/collector/v1/admin.proto",
+}
@@ -11,6 +11,7 @@ import (
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -27,15 +28,34 @@ type InventoryCommandType int32
 
 const (
 	InventoryCommandType_INVENTORY_COMMAND_TYPE_REFRESH InventoryCommandType = 0
+	// UPDATE asks the agent to install the release described in update.
+	InventoryCommandType_INVENTORY_COMMAND_TYPE_UPDATE InventoryCommandType = 1
+	// PAUSE and RESUME stop and restart inventory collection on the agent.
+	InventoryCommandType_INVENTORY_COMMAND_TYPE_PAUSE  InventoryCommandType = 2
+	InventoryCommandType_INVENTORY_COMMAND_TYPE_RESUME InventoryCommandType = 3
+	// LOGS asks the agent to send its recent log output with SubmitAgentLogs.
+	InventoryCommandType_INVENTORY_COMMAND_TYPE_LOGS InventoryCommandType = 4
+	// PING only asks the agent to acknowledge it, to check that it responds.
+	InventoryCommandType_INVENTORY_COMMAND_TYPE_PING InventoryCommandType = 5
 )
 
 // Enum value maps for InventoryCommandType.
 var (
 	InventoryCommandType_name = map[int32]string{
 		0: "INVENTORY_COMMAND_TYPE_REFRESH",
+		1: "INVENTORY_COMMAND_TYPE_UPDATE",
+		2: "INVENTORY_COMMAND_TYPE_PAUSE",
+		3: "INVENTORY_COMMAND_TYPE_RESUME",
+		4: "INVENTORY_COMMAND_TYPE_LOGS",
+		5: "INVENTORY_COMMAND_TYPE_PING",
 	}
 	InventoryCommandType_value = map[string]int32{
 		"INVENTORY_COMMAND_TYPE_REFRESH": 0,
+		"INVENTORY_COMMAND_TYPE_UPDATE":  1,
+		"INVENTORY_COMMAND_TYPE_PAUSE":   2,
+		"INVENTORY_COMMAND_TYPE_RESUME":  3,
+		"INVENTORY_COMMAND_TYPE_LOGS":    4,
+		"INVENTORY_COMMAND_TYPE_PING":    5,
 	}
 )
 
@@ -85,6 +105,28 @@ type Inventory struct {
 	OemStrings    []string               `protobuf:"bytes,14,rep,name=oem_strings,json=oemStrings,proto3" json:"oem_strings,omitempty"`
 	BiosLanguage  *BIOSLanguageInfo      `protobuf:"bytes,15,opt,name=bios_language,json=biosLanguage,proto3" json:"bios_language,omitempty"`
 	Monitor       []*MonitorInfo         `protobuf:"bytes,16,rep,name=monitor,proto3" json:"monitor,omitempty"`
+	// site is the tenant/site the agent belongs to. Agents authenticating with
+	// a site-bound token are assigned that token's site.
+	Site string `protobuf:"bytes,17,opt,name=site,proto3" json:"site,omitempty"`
+	// telemetry describes how the agent collected this inventory.
+	Telemetry *CollectionTelemetry `protobuf:"bytes,18,opt,name=telemetry,proto3" json:"telemetry,omitempty"`
+	// privileges describes the agent's rights and what it could not collect
+	// without administrator or root rights.
+	Privileges *AgentPrivileges `protobuf:"bytes,19,opt,name=privileges,proto3" json:"privileges,omitempty"`
+	// device_class is empty or "computer" for inventories submitted by
+	// agents, and switch, printer, ups or network for devices the collector
+	// polls over SNMP.
+	DeviceClass string `protobuf:"bytes,20,opt,name=device_class,json=deviceClass,proto3" json:"device_class,omitempty"`
+	// snmp describes a device polled over SNMP.
+	Snmp *SNMPDevice `protobuf:"bytes,21,opt,name=snmp,proto3" json:"snmp,omitempty"`
+	// source is where the inventory came from: agent (or empty), snmp, or
+	// sccm or intune for inventories imported from Configuration Manager or
+	// Intune.
+	Source string `protobuf:"bytes,22,opt,name=source,proto3" json:"source,omitempty"`
+	// schema_version is the version of this message's schema an inventory was
+	// stored with, set by the collector. Inventories stored with an older one
+	// are upgraded when they are read; 0 is before versioning.
+	SchemaVersion uint32 `protobuf:"varint,23,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -231,6 +273,322 @@ func (x *Inventory) GetMonitor() []*MonitorInfo {
 	return nil
 }
 
+func (x *Inventory) GetSite() string {
+	if x != nil {
+		return x.Site
+	}
+	return ""
+}
+
+func (x *Inventory) GetTelemetry() *CollectionTelemetry {
+	if x != nil {
+		return x.Telemetry
+	}
+	return nil
+}
+
+func (x *Inventory) GetPrivileges() *AgentPrivileges {
+	if x != nil {
+		return x.Privileges
+	}
+	return nil
+}
+
+func (x *Inventory) GetDeviceClass() string {
+	if x != nil {
+		return x.DeviceClass
+	}
+	return ""
+}
+
+func (x *Inventory) GetSnmp() *SNMPDevice {
+	if x != nil {
+		return x.Snmp
+	}
+	return nil
+}
+
+func (x *Inventory) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *Inventory) GetSchemaVersion() uint32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+// SNMPDevice holds the SNMPv2-MIB system group of a polled device. Its
+// manufacturer, model and serial number, from ENTITY-MIB, Printer-MIB or
+// UPS-MIB, are in the inventory's system section.
+type SNMPDevice struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// address is the polled host or IP address.
+	Address       string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	SysDescr      string `protobuf:"bytes,2,opt,name=sys_descr,json=sysDescr,proto3" json:"sys_descr,omitempty"`
+	SysObjectId   string `protobuf:"bytes,3,opt,name=sys_object_id,json=sysObjectId,proto3" json:"sys_object_id,omitempty"`
+	SysLocation   string `protobuf:"bytes,4,opt,name=sys_location,json=sysLocation,proto3" json:"sys_location,omitempty"`
+	SysContact    string `protobuf:"bytes,5,opt,name=sys_contact,json=sysContact,proto3" json:"sys_contact,omitempty"`
+	UptimeSeconds int64  `protobuf:"varint,6,opt,name=uptime_seconds,json=uptimeSeconds,proto3" json:"uptime_seconds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SNMPDevice) Reset() {
+	*x = SNMPDevice{}
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SNMPDevice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SNMPDevice) ProtoMessage() {}
+
+func (x *SNMPDevice) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SNMPDevice.ProtoReflect.Descriptor instead.
+func (*SNMPDevice) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SNMPDevice) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *SNMPDevice) GetSysDescr() string {
+	if x != nil {
+		return x.SysDescr
+	}
+	return ""
+}
+
+func (x *SNMPDevice) GetSysObjectId() string {
+	if x != nil {
+		return x.SysObjectId
+	}
+	return ""
+}
+
+func (x *SNMPDevice) GetSysLocation() string {
+	if x != nil {
+		return x.SysLocation
+	}
+	return ""
+}
+
+func (x *SNMPDevice) GetSysContact() string {
+	if x != nil {
+		return x.SysContact
+	}
+	return ""
+}
+
+func (x *SNMPDevice) GetUptimeSeconds() int64 {
+	if x != nil {
+		return x.UptimeSeconds
+	}
+	return 0
+}
+
+// AgentPrivileges reports whether the agent ran elevated, and the modules
+// that were unavailable because it did not.
+type AgentPrivileges struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Elevated           bool                   `protobuf:"varint,1,opt,name=elevated,proto3" json:"elevated,omitempty"`
+	UnavailableModules []string               `protobuf:"bytes,2,rep,name=unavailable_modules,json=unavailableModules,proto3" json:"unavailable_modules,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *AgentPrivileges) Reset() {
+	*x = AgentPrivileges{}
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentPrivileges) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentPrivileges) ProtoMessage() {}
+
+func (x *AgentPrivileges) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentPrivileges.ProtoReflect.Descriptor instead.
+func (*AgentPrivileges) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AgentPrivileges) GetElevated() bool {
+	if x != nil {
+		return x.Elevated
+	}
+	return false
+}
+
+func (x *AgentPrivileges) GetUnavailableModules() []string {
+	if x != nil {
+		return x.UnavailableModules
+	}
+	return nil
+}
+
+// CollectionTelemetry holds agent-side collection metrics.
+type CollectionTelemetry struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// duration_ms is the total collection time.
+	DurationMs int64 `protobuf:"varint,1,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	// peak_rss_bytes is the agent's peak resident set size so far.
+	PeakRssBytes  uint64             `protobuf:"varint,2,opt,name=peak_rss_bytes,json=peakRssBytes,proto3" json:"peak_rss_bytes,omitempty"`
+	Modules       []*ModuleTelemetry `protobuf:"bytes,3,rep,name=modules,proto3" json:"modules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CollectionTelemetry) Reset() {
+	*x = CollectionTelemetry{}
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CollectionTelemetry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CollectionTelemetry) ProtoMessage() {}
+
+func (x *CollectionTelemetry) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CollectionTelemetry.ProtoReflect.Descriptor instead.
+func (*CollectionTelemetry) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CollectionTelemetry) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *CollectionTelemetry) GetPeakRssBytes() uint64 {
+	if x != nil {
+		return x.PeakRssBytes
+	}
+	return 0
+}
+
+func (x *CollectionTelemetry) GetModules() []*ModuleTelemetry {
+	if x != nil {
+		return x.Modules
+	}
+	return nil
+}
+
+// ModuleTelemetry holds the duration and error, if any, of one collection
+// module.
+type ModuleTelemetry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	DurationMs    int64                  `protobuf:"varint,2,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModuleTelemetry) Reset() {
+	*x = ModuleTelemetry{}
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModuleTelemetry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModuleTelemetry) ProtoMessage() {}
+
+func (x *ModuleTelemetry) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModuleTelemetry.ProtoReflect.Descriptor instead.
+func (*ModuleTelemetry) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ModuleTelemetry) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ModuleTelemetry) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *ModuleTelemetry) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
 // VersionInfo holds the SMBIOS specification version.
 type VersionInfo struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -243,7 +601,7 @@ type VersionInfo struct {
 
 func (x *VersionInfo) Reset() {
 	*x = VersionInfo{}
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[1]
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -255,7 +613,7 @@ func (x *VersionInfo) String() string {
 func (*VersionInfo) ProtoMessage() {}
 
 func (x *VersionInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[1]
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -268,7 +626,7 @@ func (x *VersionInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VersionInfo.ProtoReflect.Descriptor instead.
 func (*VersionInfo) Descriptor() ([]byte, []int) {
-	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{1}
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *VersionInfo) GetMajor() int32 {
@@ -304,7 +662,7 @@ type BIOSInfo struct {
 
 func (x *BIOSInfo) Reset() {
 	*x = BIOSInfo{}
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[2]
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -316,7 +674,7 @@ func (x *BIOSInfo) String() string {
 func (*BIOSInfo) ProtoMessage() {}
 
 func (x *BIOSInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[2]
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -329,7 +687,7 @@ func (x *BIOSInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BIOSInfo.ProtoReflect.Descriptor instead.
 func (*BIOSInfo) Descriptor() ([]byte, []int) {
-	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{2}
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *BIOSInfo) GetVendor() string {
@@ -370,7 +728,7 @@ type SystemInfo struct {
 
 func (x *SystemInfo) Reset() {
 	*x = SystemInfo{}
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[3]
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -382,7 +740,7 @@ func (x *SystemInfo) String() string {
 func (*SystemInfo) ProtoMessage() {}
 
 func (x *SystemInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[3]
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -395,7 +753,7 @@ func (x *SystemInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SystemInfo.ProtoReflect.Descriptor instead.
 func (*SystemInfo) Descriptor() ([]byte, []int) {
-	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{3}
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *SystemInfo) GetManufacturer() string {
@@ -470,7 +828,7 @@ type BaseboardInfo struct {
 
 func (x *BaseboardInfo) Reset() {
 	*x = BaseboardInfo{}
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[4]
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -482,7 +840,7 @@ func (x *BaseboardInfo) String() string {
 func (*BaseboardInfo) ProtoMessage() {}
 
 func (x *BaseboardInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[4]
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -495,7 +853,7 @@ func (x *BaseboardInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BaseboardInfo.ProtoReflect.Descriptor instead.
 func (*BaseboardInfo) Descriptor() ([]byte, []int) {
-	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{4}
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *BaseboardInfo) GetManufacturer() string {
@@ -561,7 +919,7 @@ type ChassisInfo struct {
 
 func (x *ChassisInfo) Reset() {
 	*x = ChassisInfo{}
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[5]
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -573,7 +931,7 @@ func (x *ChassisInfo) String() string {
 func (*ChassisInfo) ProtoMessage() {}
 
 func (x *ChassisInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[5]
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -586,7 +944,7 @@ func (x *ChassisInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ChassisInfo.ProtoReflect.Descriptor instead.
 func (*ChassisInfo) Descriptor() ([]byte, []int) {
-	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{5}
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ChassisInfo) GetManufacturer() string {
@@ -645,7 +1003,7 @@ type ProcessorInfo struct {
 
 func (x *ProcessorInfo) Reset() {
 	*x = ProcessorInfo{}
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[6]
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -657,7 +1015,7 @@ func (x *ProcessorInfo) String() string {
 func (*ProcessorInfo) ProtoMessage() {}
 
 func (x *ProcessorInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[6]
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -670,7 +1028,7 @@ func (x *ProcessorInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProcessorInfo.ProtoReflect.Descriptor instead.
 func (*ProcessorInfo) Descriptor() ([]byte, []int) {
-	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{6}
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *ProcessorInfo) GetSocketDesignation() string {
@@ -767,7 +1125,7 @@ type CacheInfo struct {
 
 func (x *CacheInfo) Reset() {
 	*x = CacheInfo{}
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[7]
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -779,7 +1137,7 @@ func (x *CacheInfo) String() string {
 func (*CacheInfo) ProtoMessage() {}
 
 func (x *CacheInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[7]
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -792,7 +1150,7 @@ func (x *CacheInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CacheInfo.ProtoReflect.Descriptor instead.
 func (*CacheInfo) Descriptor() ([]byte, []int) {
-	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{7}
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *CacheInfo) GetSocketDesignation() string {
@@ -815,7 +1173,7 @@ type MemoryInfo struct {
 
 func (x *MemoryInfo) Reset() {
 	*x = MemoryInfo{}
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[8]
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -827,7 +1185,7 @@ func (x *MemoryInfo) String() string {
 func (*MemoryInfo) ProtoMessage() {}
 
 func (x *MemoryInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[8]
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -840,7 +1198,7 @@ func (x *MemoryInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MemoryInfo.ProtoReflect.Descriptor instead.
 func (*MemoryInfo) Descriptor() ([]byte, []int) {
-	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{8}
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *MemoryInfo) GetTotalPhysicalBytes() uint64 {
@@ -885,7 +1243,7 @@ type PhysicalMemoryArray struct {
 
 func (x *PhysicalMemoryArray) Reset() {
 	*x = PhysicalMemoryArray{}
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[9]
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -897,7 +1255,7 @@ func (x *PhysicalMemoryArray) String() string {
 func (*PhysicalMemoryArray) ProtoMessage() {}
 
 func (x *PhysicalMemoryArray) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[9]
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -910,7 +1268,7 @@ func (x *PhysicalMemoryArray) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PhysicalMemoryArray.ProtoReflect.Descriptor instead.
 func (*PhysicalMemoryArray) Descriptor() ([]byte, []int) {
-	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{9}
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *PhysicalMemoryArray) GetLocation() string {
@@ -974,7 +1332,7 @@ type MemoryModule struct {
 
 func (x *MemoryModule) Reset() {
 	*x = MemoryModule{}
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[10]
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -986,7 +1344,7 @@ func (x *MemoryModule) String() string {
 func (*MemoryModule) ProtoMessage() {}
 
 func (x *MemoryModule) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[10]
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -999,7 +1357,7 @@ func (x *MemoryModule) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MemoryModule.ProtoReflect.Descriptor instead.
 func (*MemoryModule) Descriptor() ([]byte, []int) {
-	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{10}
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *MemoryModule) GetDeviceLocator() string {
@@ -1132,7 +1490,7 @@ type PortInfo struct {
 
 func (x *PortInfo) Reset() {
 	*x = PortInfo{}
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[11]
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1144,7 +1502,7 @@ func (x *PortInfo) String() string {
 func (*PortInfo) ProtoMessage() {}
 
 func (x *PortInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[11]
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1157,7 +1515,7 @@ func (x *PortInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PortInfo.ProtoReflect.Descriptor instead.
 func (*PortInfo) Descriptor() ([]byte, []int) {
-	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{11}
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *PortInfo) GetInternalDesignator() string {
@@ -1184,7 +1542,7 @@ type SlotInfo struct {
 
 func (x *SlotInfo) Reset() {
 	*x = SlotInfo{}
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[12]
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1196,7 +1554,7 @@ func (x *SlotInfo) String() string {
 func (*SlotInfo) ProtoMessage() {}
 
 func (x *SlotInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[12]
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1209,7 +1567,7 @@ func (x *SlotInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SlotInfo.ProtoReflect.Descriptor instead.
 func (*SlotInfo) Descriptor() ([]byte, []int) {
-	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{12}
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *SlotInfo) GetDesignation() string {
@@ -1230,7 +1588,7 @@ type BIOSLanguageInfo struct {
 
 func (x *BIOSLanguageInfo) Reset() {
 	*x = BIOSLanguageInfo{}
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[13]
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1242,7 +1600,7 @@ func (x *BIOSLanguageInfo) String() string {
 func (*BIOSLanguageInfo) ProtoMessage() {}
 
 func (x *BIOSLanguageInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[13]
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1255,7 +1613,7 @@ func (x *BIOSLanguageInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BIOSLanguageInfo.ProtoReflect.Descriptor instead.
 func (*BIOSLanguageInfo) Descriptor() ([]byte, []int) {
-	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{13}
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *BIOSLanguageInfo) GetCurrentLanguage() string {
@@ -1284,7 +1642,7 @@ type MonitorInfo struct {
 
 func (x *MonitorInfo) Reset() {
 	*x = MonitorInfo{}
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[14]
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1296,7 +1654,7 @@ func (x *MonitorInfo) String() string {
 func (*MonitorInfo) ProtoMessage() {}
 
 func (x *MonitorInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[14]
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1309,7 +1667,7 @@ func (x *MonitorInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MonitorInfo.ProtoReflect.Descriptor instead.
 func (*MonitorInfo) Descriptor() ([]byte, []int) {
-	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{14}
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *MonitorInfo) GetManufacturer() string {
@@ -1334,15 +1692,20 @@ func (x *MonitorInfo) GetSerialNumber() string {
 }
 
 type SubmitInventoryRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Inventory     *Inventory             `protobuf:"bytes,1,opt,name=inventory,proto3" json:"inventory,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Inventory *Inventory             `protobuf:"bytes,1,opt,name=inventory,proto3" json:"inventory,omitempty"`
+	// submission_uuid identifies this submission, generated by the client and
+	// sent again when it retries it. A submission whose UUID the site already
+	// stored an inventory under returns that inventory's id and stored_at
+	// instead of storing it twice. Optional.
+	SubmissionUuid string `protobuf:"bytes,2,opt,name=submission_uuid,json=submissionUuid,proto3" json:"submission_uuid,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *SubmitInventoryRequest) Reset() {
 	*x = SubmitInventoryRequest{}
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[15]
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1354,7 +1717,7 @@ func (x *SubmitInventoryRequest) String() string {
 func (*SubmitInventoryRequest) ProtoMessage() {}
 
 func (x *SubmitInventoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[15]
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1367,7 +1730,7 @@ func (x *SubmitInventoryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SubmitInventoryRequest.ProtoReflect.Descriptor instead.
 func (*SubmitInventoryRequest) Descriptor() ([]byte, []int) {
-	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{15}
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *SubmitInventoryRequest) GetInventory() *Inventory {
@@ -1377,6 +1740,13 @@ func (x *SubmitInventoryRequest) GetInventory() *Inventory {
 	return nil
 }
 
+func (x *SubmitInventoryRequest) GetSubmissionUuid() string {
+	if x != nil {
+		return x.SubmissionUuid
+	}
+	return ""
+}
+
 type SubmitInventoryResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -1387,7 +1757,7 @@ type SubmitInventoryResponse struct {
 
 func (x *SubmitInventoryResponse) Reset() {
 	*x = SubmitInventoryResponse{}
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[16]
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1399,7 +1769,7 @@ func (x *SubmitInventoryResponse) String() string {
 func (*SubmitInventoryResponse) ProtoMessage() {}
 
 func (x *SubmitInventoryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[16]
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1412,7 +1782,7 @@ func (x *SubmitInventoryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SubmitInventoryResponse.ProtoReflect.Descriptor instead.
 func (*SubmitInventoryResponse) Descriptor() ([]byte, []int) {
-	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{16}
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *SubmitInventoryResponse) GetId() int64 {
@@ -1430,15 +1800,19 @@ func (x *SubmitInventoryResponse) GetStoredAt() *timestamp.Timestamp {
 }
 
 type GetInventoryRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// read_mask limits the returned inventory to the given fields, e.g.
+	// "memory,processors" or "system.serial_number". Paths are relative to
+	// Inventory; an empty mask returns the whole inventory.
+	ReadMask      *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=read_mask,json=readMask,proto3" json:"read_mask,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetInventoryRequest) Reset() {
 	*x = GetInventoryRequest{}
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[17]
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1450,7 +1824,7 @@ func (x *GetInventoryRequest) String() string {
 func (*GetInventoryRequest) ProtoMessage() {}
 
 func (x *GetInventoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[17]
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1463,7 +1837,7 @@ func (x *GetInventoryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetInventoryRequest.ProtoReflect.Descriptor instead.
 func (*GetInventoryRequest) Descriptor() ([]byte, []int) {
-	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{17}
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *GetInventoryRequest) GetId() int64 {
@@ -1473,6 +1847,13 @@ func (x *GetInventoryRequest) GetId() int64 {
 	return 0
 }
 
+func (x *GetInventoryRequest) GetReadMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.ReadMask
+	}
+	return nil
+}
+
 type GetInventoryResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -1484,7 +1865,7 @@ type GetInventoryResponse struct {
 
 func (x *GetInventoryResponse) Reset() {
 	*x = GetInventoryResponse{}
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[18]
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1496,7 +1877,7 @@ func (x *GetInventoryResponse) String() string {
 func (*GetInventoryResponse) ProtoMessage() {}
 
 func (x *GetInventoryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[18]
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1509,7 +1890,7 @@ func (x *GetInventoryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetInventoryResponse.ProtoReflect.Descriptor instead.
 func (*GetInventoryResponse) Descriptor() ([]byte, []int) {
-	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{18}
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *GetInventoryResponse) GetId() int64 {
@@ -1542,13 +1923,30 @@ type ListInventoriesRequest struct {
 	CollectedBefore *timestamp.Timestamp   `protobuf:"bytes,5,opt,name=collected_before,json=collectedBefore,proto3" json:"collected_before,omitempty"`
 	PageSize        int32                  `protobuf:"varint,6,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
 	Page            int32                  `protobuf:"varint,7,opt,name=page,proto3" json:"page,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	Site            string                 `protobuf:"bytes,8,opt,name=site,proto3" json:"site,omitempty"`
+	// read_mask selects the InventorySummary fields to return. Paths under
+	// "inventory" (e.g. "inventory.memory") additionally attach those sections
+	// of each stored inventory; an empty mask returns the summary fields only.
+	ReadMask *fieldmaskpb.FieldMask `protobuf:"bytes,9,opt,name=read_mask,json=readMask,proto3" json:"read_mask,omitempty"`
+	// device_class restricts the list to a device class, e.g. computer or
+	// switch.
+	DeviceClass string `protobuf:"bytes,10,opt,name=device_class,json=deviceClass,proto3" json:"device_class,omitempty"`
+	// page_token is the next_page_token of the previous page. The page then
+	// starts after the last inventory of that page rather than at page, which
+	// is ignored, so that deep pages cost no more than the first. The other
+	// fields must be the same as for the previous page.
+	PageToken string `protobuf:"bytes,11,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// exact_count counts the matching inventories for total_count. Otherwise
+	// the collector may answer with a count cached for list_count_cache_ttl,
+	// which leaves out the inventories submitted since.
+	ExactCount    bool `protobuf:"varint,12,opt,name=exact_count,json=exactCount,proto3" json:"exact_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListInventoriesRequest) Reset() {
 	*x = ListInventoriesRequest{}
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[19]
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1560,7 +1958,7 @@ func (x *ListInventoriesRequest) String() string {
 func (*ListInventoriesRequest) ProtoMessage() {}
 
 func (x *ListInventoriesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[19]
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1573,7 +1971,7 @@ func (x *ListInventoriesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListInventoriesRequest.ProtoReflect.Descriptor instead.
 func (*ListInventoriesRequest) Descriptor() ([]byte, []int) {
-	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{19}
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *ListInventoriesRequest) GetHostname() string {
@@ -1625,17 +2023,55 @@ func (x *ListInventoriesRequest) GetPage() int32 {
 	return 0
 }
 
+func (x *ListInventoriesRequest) GetSite() string {
+	if x != nil {
+		return x.Site
+	}
+	return ""
+}
+
+func (x *ListInventoriesRequest) GetReadMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.ReadMask
+	}
+	return nil
+}
+
+func (x *ListInventoriesRequest) GetDeviceClass() string {
+	if x != nil {
+		return x.DeviceClass
+	}
+	return ""
+}
+
+func (x *ListInventoriesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListInventoriesRequest) GetExactCount() bool {
+	if x != nil {
+		return x.ExactCount
+	}
+	return false
+}
+
 type ListInventoriesResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Inventories   []*InventorySummary    `protobuf:"bytes,1,rep,name=inventories,proto3" json:"inventories,omitempty"`
-	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Inventories []*InventorySummary    `protobuf:"bytes,1,rep,name=inventories,proto3" json:"inventories,omitempty"`
+	TotalCount  int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	// next_page_token continues the list after this page (see page_token); it
+	// is empty on the last page.
+	NextPageToken string `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListInventoriesResponse) Reset() {
 	*x = ListInventoriesResponse{}
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[20]
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1647,7 +2083,7 @@ func (x *ListInventoriesResponse) String() string {
 func (*ListInventoriesResponse) ProtoMessage() {}
 
 func (x *ListInventoriesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[20]
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1660,7 +2096,7 @@ func (x *ListInventoriesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListInventoriesResponse.ProtoReflect.Descriptor instead.
 func (*ListInventoriesResponse) Descriptor() ([]byte, []int) {
-	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{20}
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *ListInventoriesResponse) GetInventories() []*InventorySummary {
@@ -1677,22 +2113,34 @@ func (x *ListInventoriesResponse) GetTotalCount() int32 {
 	return 0
 }
 
+func (x *ListInventoriesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 type InventorySummary struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Hostname      string                 `protobuf:"bytes,2,opt,name=hostname,proto3" json:"hostname,omitempty"`
-	Username      string                 `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
-	SystemUuid    string                 `protobuf:"bytes,4,opt,name=system_uuid,json=systemUuid,proto3" json:"system_uuid,omitempty"`
-	SystemSerial  string                 `protobuf:"bytes,5,opt,name=system_serial,json=systemSerial,proto3" json:"system_serial,omitempty"`
-	CollectedAt   *timestamp.Timestamp   `protobuf:"bytes,6,opt,name=collected_at,json=collectedAt,proto3" json:"collected_at,omitempty"`
-	StoredAt      *timestamp.Timestamp   `protobuf:"bytes,7,opt,name=stored_at,json=storedAt,proto3" json:"stored_at,omitempty"`
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Id           int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Hostname     string                 `protobuf:"bytes,2,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Username     string                 `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
+	SystemUuid   string                 `protobuf:"bytes,4,opt,name=system_uuid,json=systemUuid,proto3" json:"system_uuid,omitempty"`
+	SystemSerial string                 `protobuf:"bytes,5,opt,name=system_serial,json=systemSerial,proto3" json:"system_serial,omitempty"`
+	CollectedAt  *timestamp.Timestamp   `protobuf:"bytes,6,opt,name=collected_at,json=collectedAt,proto3" json:"collected_at,omitempty"`
+	StoredAt     *timestamp.Timestamp   `protobuf:"bytes,7,opt,name=stored_at,json=storedAt,proto3" json:"stored_at,omitempty"`
+	Site         string                 `protobuf:"bytes,8,opt,name=site,proto3" json:"site,omitempty"`
+	// inventory is only populated when requested through read_mask.
+	Inventory     *Inventory `protobuf:"bytes,9,opt,name=inventory,proto3" json:"inventory,omitempty"`
+	DeviceClass   string     `protobuf:"bytes,10,opt,name=device_class,json=deviceClass,proto3" json:"device_class,omitempty"`
+	Source        string     `protobuf:"bytes,11,opt,name=source,proto3" json:"source,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *InventorySummary) Reset() {
 	*x = InventorySummary{}
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[21]
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1704,7 +2152,7 @@ func (x *InventorySummary) String() string {
 func (*InventorySummary) ProtoMessage() {}
 
 func (x *InventorySummary) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[21]
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1717,7 +2165,7 @@ func (x *InventorySummary) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InventorySummary.ProtoReflect.Descriptor instead.
 func (*InventorySummary) Descriptor() ([]byte, []int) {
-	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{21}
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *InventorySummary) GetId() int64 {
@@ -1769,28 +2217,57 @@ func (x *InventorySummary) GetStoredAt() *timestamp.Timestamp {
 	return nil
 }
 
-type DeleteInventoryRequest struct {
+func (x *InventorySummary) GetSite() string {
+	if x != nil {
+		return x.Site
+	}
+	return ""
+}
+
+func (x *InventorySummary) GetInventory() *Inventory {
+	if x != nil {
+		return x.Inventory
+	}
+	return nil
+}
+
+func (x *InventorySummary) GetDeviceClass() string {
+	if x != nil {
+		return x.DeviceClass
+	}
+	return ""
+}
+
+func (x *InventorySummary) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+type DiffInventoriesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	FromId        int64                  `protobuf:"varint,1,opt,name=from_id,json=fromId,proto3" json:"from_id,omitempty"`
+	ToId          int64                  `protobuf:"varint,2,opt,name=to_id,json=toId,proto3" json:"to_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteInventoryRequest) Reset() {
-	*x = DeleteInventoryRequest{}
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[22]
+func (x *DiffInventoriesRequest) Reset() {
+	*x = DiffInventoriesRequest{}
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteInventoryRequest) String() string {
+func (x *DiffInventoriesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteInventoryRequest) ProtoMessage() {}
+func (*DiffInventoriesRequest) ProtoMessage() {}
 
-func (x *DeleteInventoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[22]
+func (x *DiffInventoriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1801,39 +2278,57 @@ func (x *DeleteInventoryRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteInventoryRequest.ProtoReflect.Descriptor instead.
-func (*DeleteInventoryRequest) Descriptor() ([]byte, []int) {
-	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use DiffInventoriesRequest.ProtoReflect.Descriptor instead.
+func (*DiffInventoriesRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{26}
 }
 
-func (x *DeleteInventoryRequest) GetId() int64 {
+func (x *DiffInventoriesRequest) GetFromId() int64 {
 	if x != nil {
-		return x.Id
+		return x.FromId
 	}
 	return 0
 }
 
-type DeleteInventoryResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+func (x *DiffInventoriesRequest) GetToId() int64 {
+	if x != nil {
+		return x.ToId
+	}
+	return 0
+}
+
+// InventoryChange is one hardware difference between two inventories. key
+// identifies the instance of repeated components (DIMM slot, CPU socket,
+// monitor serial); field is set only for modified changes.
+type InventoryChange struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// type is added, removed or modified.
+	Type          string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Component     string `protobuf:"bytes,2,opt,name=component,proto3" json:"component,omitempty"`
+	Key           string `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	Field         string `protobuf:"bytes,4,opt,name=field,proto3" json:"field,omitempty"`
+	OldValue      string `protobuf:"bytes,5,opt,name=old_value,json=oldValue,proto3" json:"old_value,omitempty"`
+	NewValue      string `protobuf:"bytes,6,opt,name=new_value,json=newValue,proto3" json:"new_value,omitempty"`
+	Description   string `protobuf:"bytes,7,opt,name=description,proto3" json:"description,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteInventoryResponse) Reset() {
-	*x = DeleteInventoryResponse{}
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[23]
+func (x *InventoryChange) Reset() {
+	*x = InventoryChange{}
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteInventoryResponse) String() string {
+func (x *InventoryChange) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteInventoryResponse) ProtoMessage() {}
+func (*InventoryChange) ProtoMessage() {}
 
-func (x *DeleteInventoryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[23]
+func (x *InventoryChange) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1844,79 +2339,285 @@ func (x *DeleteInventoryResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteInventoryResponse.ProtoReflect.Descriptor instead.
-func (*DeleteInventoryResponse) Descriptor() ([]byte, []int) {
-	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use InventoryChange.ProtoReflect.Descriptor instead.
+func (*InventoryChange) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{27}
 }
 
-type GetLatestByHostnameRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Hostname      string                 `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *InventoryChange) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
 }
 
-func (x *GetLatestByHostnameRequest) Reset() {
-	*x = GetLatestByHostnameRequest{}
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[24]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *InventoryChange) GetComponent() string {
+	if x != nil {
+		return x.Component
+	}
+	return ""
 }
 
-func (x *GetLatestByHostnameRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *InventoryChange) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
 }
 
-func (*GetLatestByHostnameRequest) ProtoMessage() {}
+func (x *InventoryChange) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
 
-func (x *GetLatestByHostnameRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[24]
+func (x *InventoryChange) GetOldValue() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.OldValue
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use GetLatestByHostnameRequest.ProtoReflect.Descriptor instead.
-func (*GetLatestByHostnameRequest) Descriptor() ([]byte, []int) {
-	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{24}
+func (x *InventoryChange) GetNewValue() string {
+	if x != nil {
+		return x.NewValue
+	}
+	return ""
 }
 
-func (x *GetLatestByHostnameRequest) GetHostname() string {
+func (x *InventoryChange) GetDescription() string {
 	if x != nil {
-		return x.Hostname
+		return x.Description
 	}
 	return ""
 }
 
-type GetLatestByHostnameResponse struct {
+type DiffInventoriesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Inventory     *Inventory             `protobuf:"bytes,2,opt,name=inventory,proto3" json:"inventory,omitempty"`
-	StoredAt      *timestamp.Timestamp   `protobuf:"bytes,3,opt,name=stored_at,json=storedAt,proto3" json:"stored_at,omitempty"`
+	FromId        int64                  `protobuf:"varint,1,opt,name=from_id,json=fromId,proto3" json:"from_id,omitempty"`
+	ToId          int64                  `protobuf:"varint,2,opt,name=to_id,json=toId,proto3" json:"to_id,omitempty"`
+	Changes       []*InventoryChange     `protobuf:"bytes,3,rep,name=changes,proto3" json:"changes,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetLatestByHostnameResponse) Reset() {
-	*x = GetLatestByHostnameResponse{}
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[25]
+func (x *DiffInventoriesResponse) Reset() {
+	*x = DiffInventoriesResponse{}
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetLatestByHostnameResponse) String() string {
+func (x *DiffInventoriesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetLatestByHostnameResponse) ProtoMessage() {}
+func (*DiffInventoriesResponse) ProtoMessage() {}
 
-func (x *GetLatestByHostnameResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[25]
+func (x *DiffInventoriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffInventoriesResponse.ProtoReflect.Descriptor instead.
+func (*DiffInventoriesResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *DiffInventoriesResponse) GetFromId() int64 {
+	if x != nil {
+		return x.FromId
+	}
+	return 0
+}
+
+func (x *DiffInventoriesResponse) GetToId() int64 {
+	if x != nil {
+		return x.ToId
+	}
+	return 0
+}
+
+func (x *DiffInventoriesResponse) GetChanges() []*InventoryChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+type DeleteInventoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteInventoryRequest) Reset() {
+	*x = DeleteInventoryRequest{}
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteInventoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteInventoryRequest) ProtoMessage() {}
+
+func (x *DeleteInventoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteInventoryRequest.ProtoReflect.Descriptor instead.
+func (*DeleteInventoryRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *DeleteInventoryRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DeleteInventoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteInventoryResponse) Reset() {
+	*x = DeleteInventoryResponse{}
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteInventoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteInventoryResponse) ProtoMessage() {}
+
+func (x *DeleteInventoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteInventoryResponse.ProtoReflect.Descriptor instead.
+func (*DeleteInventoryResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{30}
+}
+
+type GetLatestByHostnameRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Hostname string                 `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Site     string                 `protobuf:"bytes,2,opt,name=site,proto3" json:"site,omitempty"`
+	// read_mask limits the returned inventory, as in GetInventoryRequest.
+	ReadMask      *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=read_mask,json=readMask,proto3" json:"read_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLatestByHostnameRequest) Reset() {
+	*x = GetLatestByHostnameRequest{}
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLatestByHostnameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLatestByHostnameRequest) ProtoMessage() {}
+
+func (x *GetLatestByHostnameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLatestByHostnameRequest.ProtoReflect.Descriptor instead.
+func (*GetLatestByHostnameRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetLatestByHostnameRequest) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+func (x *GetLatestByHostnameRequest) GetSite() string {
+	if x != nil {
+		return x.Site
+	}
+	return ""
+}
+
+func (x *GetLatestByHostnameRequest) GetReadMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.ReadMask
+	}
+	return nil
+}
+
+type GetLatestByHostnameResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Inventory     *Inventory             `protobuf:"bytes,2,opt,name=inventory,proto3" json:"inventory,omitempty"`
+	StoredAt      *timestamp.Timestamp   `protobuf:"bytes,3,opt,name=stored_at,json=storedAt,proto3" json:"stored_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLatestByHostnameResponse) Reset() {
+	*x = GetLatestByHostnameResponse{}
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLatestByHostnameResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLatestByHostnameResponse) ProtoMessage() {}
+
+func (x *GetLatestByHostnameResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1929,7 +2630,7 @@ func (x *GetLatestByHostnameResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetLatestByHostnameResponse.ProtoReflect.Descriptor instead.
 func (*GetLatestByHostnameResponse) Descriptor() ([]byte, []int) {
-	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{25}
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *GetLatestByHostnameResponse) GetId() int64 {
@@ -1953,29 +2654,31 @@ func (x *GetLatestByHostnameResponse) GetStoredAt() *timestamp.Timestamp {
 	return nil
 }
 
-type InventoryCommand struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	CommandId     string                 `protobuf:"bytes,1,opt,name=command_id,json=commandId,proto3" json:"command_id,omitempty"`
-	CommandType   InventoryCommandType   `protobuf:"varint,2,opt,name=command_type,json=commandType,proto3,enum=inventory.collector.v1.InventoryCommandType" json:"command_type,omitempty"`
+type GetLatestBySystemUUIDRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	SystemUuid string                 `protobuf:"bytes,1,opt,name=system_uuid,json=systemUuid,proto3" json:"system_uuid,omitempty"`
+	// read_mask limits the returned inventory, as in GetInventoryRequest.
+	ReadMask      *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=read_mask,json=readMask,proto3" json:"read_mask,omitempty"`
+	Site          string                 `protobuf:"bytes,3,opt,name=site,proto3" json:"site,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *InventoryCommand) Reset() {
-	*x = InventoryCommand{}
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[26]
+func (x *GetLatestBySystemUUIDRequest) Reset() {
+	*x = GetLatestBySystemUUIDRequest{}
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *InventoryCommand) String() string {
+func (x *GetLatestBySystemUUIDRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*InventoryCommand) ProtoMessage() {}
+func (*GetLatestBySystemUUIDRequest) ProtoMessage() {}
 
-func (x *InventoryCommand) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[26]
+func (x *GetLatestBySystemUUIDRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1986,48 +2689,56 @@ func (x *InventoryCommand) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use InventoryCommand.ProtoReflect.Descriptor instead.
-func (*InventoryCommand) Descriptor() ([]byte, []int) {
-	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{26}
+// Deprecated: Use GetLatestBySystemUUIDRequest.ProtoReflect.Descriptor instead.
+func (*GetLatestBySystemUUIDRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{33}
 }
 
-func (x *InventoryCommand) GetCommandId() string {
+func (x *GetLatestBySystemUUIDRequest) GetSystemUuid() string {
 	if x != nil {
-		return x.CommandId
+		return x.SystemUuid
 	}
 	return ""
 }
 
-func (x *InventoryCommand) GetCommandType() InventoryCommandType {
+func (x *GetLatestBySystemUUIDRequest) GetReadMask() *fieldmaskpb.FieldMask {
 	if x != nil {
-		return x.CommandType
+		return x.ReadMask
 	}
-	return InventoryCommandType_INVENTORY_COMMAND_TYPE_REFRESH
+	return nil
 }
 
-type StreamCommandsRequest struct {
+func (x *GetLatestBySystemUUIDRequest) GetSite() string {
+	if x != nil {
+		return x.Site
+	}
+	return ""
+}
+
+type GetLatestBySystemUUIDResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ClientId      string                 `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
-	ClientVersion string                 `protobuf:"bytes,2,opt,name=client_version,json=clientVersion,proto3" json:"client_version,omitempty"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Inventory     *Inventory             `protobuf:"bytes,2,opt,name=inventory,proto3" json:"inventory,omitempty"`
+	StoredAt      *timestamp.Timestamp   `protobuf:"bytes,3,opt,name=stored_at,json=storedAt,proto3" json:"stored_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StreamCommandsRequest) Reset() {
-	*x = StreamCommandsRequest{}
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[27]
+func (x *GetLatestBySystemUUIDResponse) Reset() {
+	*x = GetLatestBySystemUUIDResponse{}
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StreamCommandsRequest) String() string {
+func (x *GetLatestBySystemUUIDResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StreamCommandsRequest) ProtoMessage() {}
+func (*GetLatestBySystemUUIDResponse) ProtoMessage() {}
 
-func (x *StreamCommandsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[27]
+func (x *GetLatestBySystemUUIDResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2038,35 +2749,1991 @@ func (x *StreamCommandsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StreamCommandsRequest.ProtoReflect.Descriptor instead.
-func (*StreamCommandsRequest) Descriptor() ([]byte, []int) {
-	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{27}
+// Deprecated: Use GetLatestBySystemUUIDResponse.ProtoReflect.Descriptor instead.
+func (*GetLatestBySystemUUIDResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GetLatestBySystemUUIDResponse) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *GetLatestBySystemUUIDResponse) GetInventory() *Inventory {
+	if x != nil {
+		return x.Inventory
+	}
+	return nil
+}
+
+func (x *GetLatestBySystemUUIDResponse) GetStoredAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.StoredAt
+	}
+	return nil
+}
+
+type GetLatestBySerialRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	SerialNumber string                 `protobuf:"bytes,1,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	// read_mask limits the returned inventory, as in GetInventoryRequest.
+	ReadMask      *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=read_mask,json=readMask,proto3" json:"read_mask,omitempty"`
+	Site          string                 `protobuf:"bytes,3,opt,name=site,proto3" json:"site,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLatestBySerialRequest) Reset() {
+	*x = GetLatestBySerialRequest{}
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLatestBySerialRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLatestBySerialRequest) ProtoMessage() {}
+
+func (x *GetLatestBySerialRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLatestBySerialRequest.ProtoReflect.Descriptor instead.
+func (*GetLatestBySerialRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetLatestBySerialRequest) GetSerialNumber() string {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return ""
+}
+
+func (x *GetLatestBySerialRequest) GetReadMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.ReadMask
+	}
+	return nil
+}
+
+func (x *GetLatestBySerialRequest) GetSite() string {
+	if x != nil {
+		return x.Site
+	}
+	return ""
+}
+
+type GetLatestBySerialResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Inventory     *Inventory             `protobuf:"bytes,2,opt,name=inventory,proto3" json:"inventory,omitempty"`
+	StoredAt      *timestamp.Timestamp   `protobuf:"bytes,3,opt,name=stored_at,json=storedAt,proto3" json:"stored_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLatestBySerialResponse) Reset() {
+	*x = GetLatestBySerialResponse{}
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLatestBySerialResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLatestBySerialResponse) ProtoMessage() {}
+
+func (x *GetLatestBySerialResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLatestBySerialResponse.ProtoReflect.Descriptor instead.
+func (*GetLatestBySerialResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *GetLatestBySerialResponse) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *GetLatestBySerialResponse) GetInventory() *Inventory {
+	if x != nil {
+		return x.Inventory
+	}
+	return nil
+}
+
+func (x *GetLatestBySerialResponse) GetStoredAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.StoredAt
+	}
+	return nil
+}
+
+type ListHostsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	Site          string                 `protobuf:"bytes,3,opt,name=site,proto3" json:"site,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListHostsRequest) Reset() {
+	*x = ListHostsRequest{}
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListHostsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListHostsRequest) ProtoMessage() {}
+
+func (x *ListHostsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListHostsRequest.ProtoReflect.Descriptor instead.
+func (*ListHostsRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ListHostsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListHostsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListHostsRequest) GetSite() string {
+	if x != nil {
+		return x.Site
+	}
+	return ""
+}
+
+type ListHostsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hosts         []*HostSummary         `protobuf:"bytes,1,rep,name=hosts,proto3" json:"hosts,omitempty"`
+	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListHostsResponse) Reset() {
+	*x = ListHostsResponse{}
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListHostsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListHostsResponse) ProtoMessage() {}
+
+func (x *ListHostsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListHostsResponse.ProtoReflect.Descriptor instead.
+func (*ListHostsResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ListHostsResponse) GetHosts() []*HostSummary {
+	if x != nil {
+		return x.Hosts
+	}
+	return nil
+}
+
+func (x *ListHostsResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+type HostSummary struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Hostname   string                 `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	SystemUuid string                 `protobuf:"bytes,2,opt,name=system_uuid,json=systemUuid,proto3" json:"system_uuid,omitempty"`
+	LastSeen   *timestamp.Timestamp   `protobuf:"bytes,3,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
+	LatestId   int64                  `protobuf:"varint,4,opt,name=latest_id,json=latestId,proto3" json:"latest_id,omitempty"`
+	Site       string                 `protobuf:"bytes,5,opt,name=site,proto3" json:"site,omitempty"`
+	// Active Directory / LDAP information on the host and its last user;
+	// unset unless directory enrichment (ldap_url) found either.
+	Directory *HostDirectory `protobuf:"bytes,6,opt,name=directory,proto3" json:"directory,omitempty"`
+	// Custom fields set with EnrichHosts, e.g. owner, location or cost center.
+	Fields        map[string]string `protobuf:"bytes,7,rep,name=fields,proto3" json:"fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HostSummary) Reset() {
+	*x = HostSummary{}
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HostSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HostSummary) ProtoMessage() {}
+
+func (x *HostSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HostSummary.ProtoReflect.Descriptor instead.
+func (*HostSummary) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *HostSummary) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+func (x *HostSummary) GetSystemUuid() string {
+	if x != nil {
+		return x.SystemUuid
+	}
+	return ""
+}
+
+func (x *HostSummary) GetLastSeen() *timestamp.Timestamp {
+	if x != nil {
+		return x.LastSeen
+	}
+	return nil
+}
+
+func (x *HostSummary) GetLatestId() int64 {
+	if x != nil {
+		return x.LatestId
+	}
+	return 0
+}
+
+func (x *HostSummary) GetSite() string {
+	if x != nil {
+		return x.Site
+	}
+	return ""
+}
+
+func (x *HostSummary) GetDirectory() *HostDirectory {
+	if x != nil {
+		return x.Directory
+	}
+	return nil
+}
+
+func (x *HostSummary) GetFields() map[string]string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+// HostDirectory is what the directory holds about a host's computer object
+// and the user last logged on to it.
+type HostDirectory struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// DN of the container (OU) holding the computer object.
+	Ou          string `protobuf:"bytes,1,opt,name=ou,proto3" json:"ou,omitempty"`
+	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// Username of the user object found, from the latest inventory.
+	Username        string               `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
+	UserDisplayName string               `protobuf:"bytes,4,opt,name=user_display_name,json=userDisplayName,proto3" json:"user_display_name,omitempty"`
+	Department      string               `protobuf:"bytes,5,opt,name=department,proto3" json:"department,omitempty"`
+	UpdatedAt       *timestamp.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *HostDirectory) Reset() {
+	*x = HostDirectory{}
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HostDirectory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HostDirectory) ProtoMessage() {}
+
+func (x *HostDirectory) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HostDirectory.ProtoReflect.Descriptor instead.
+func (*HostDirectory) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *HostDirectory) GetOu() string {
+	if x != nil {
+		return x.Ou
+	}
+	return ""
+}
+
+func (x *HostDirectory) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *HostDirectory) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *HostDirectory) GetUserDisplayName() string {
+	if x != nil {
+		return x.UserDisplayName
+	}
+	return ""
+}
+
+func (x *HostDirectory) GetDepartment() string {
+	if x != nil {
+		return x.Department
+	}
+	return ""
+}
+
+func (x *HostDirectory) GetUpdatedAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// Alert is a significant hardware change detected between two consecutive
+// inventories of the same host.
+type Alert struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Hostname      string                 `protobuf:"bytes,2,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	InventoryId   int64                  `protobuf:"varint,3,opt,name=inventory_id,json=inventoryId,proto3" json:"inventory_id,omitempty"`
+	Rule          string                 `protobuf:"bytes,4,opt,name=rule,proto3" json:"rule,omitempty"`
+	Severity      string                 `protobuf:"bytes,5,opt,name=severity,proto3" json:"severity,omitempty"`
+	Message       string                 `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`
+	CreatedAt     *timestamp.Timestamp   `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Acknowledged  bool                   `protobuf:"varint,8,opt,name=acknowledged,proto3" json:"acknowledged,omitempty"`
+	Site          string                 `protobuf:"bytes,9,opt,name=site,proto3" json:"site,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Alert) Reset() {
+	*x = Alert{}
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Alert) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Alert) ProtoMessage() {}
+
+func (x *Alert) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Alert.ProtoReflect.Descriptor instead.
+func (*Alert) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *Alert) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Alert) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+func (x *Alert) GetInventoryId() int64 {
+	if x != nil {
+		return x.InventoryId
+	}
+	return 0
+}
+
+func (x *Alert) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+func (x *Alert) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
+func (x *Alert) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Alert) GetCreatedAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Alert) GetAcknowledged() bool {
+	if x != nil {
+		return x.Acknowledged
+	}
+	return false
+}
+
+func (x *Alert) GetSite() string {
+	if x != nil {
+		return x.Site
+	}
+	return ""
+}
+
+type ListAlertsRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Hostname           string                 `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	UnacknowledgedOnly bool                   `protobuf:"varint,2,opt,name=unacknowledged_only,json=unacknowledgedOnly,proto3" json:"unacknowledged_only,omitempty"`
+	PageSize           int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Page               int32                  `protobuf:"varint,4,opt,name=page,proto3" json:"page,omitempty"`
+	Site               string                 `protobuf:"bytes,5,opt,name=site,proto3" json:"site,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ListAlertsRequest) Reset() {
+	*x = ListAlertsRequest{}
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAlertsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAlertsRequest) ProtoMessage() {}
+
+func (x *ListAlertsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAlertsRequest.ProtoReflect.Descriptor instead.
+func (*ListAlertsRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ListAlertsRequest) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+func (x *ListAlertsRequest) GetUnacknowledgedOnly() bool {
+	if x != nil {
+		return x.UnacknowledgedOnly
+	}
+	return false
+}
+
+func (x *ListAlertsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListAlertsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListAlertsRequest) GetSite() string {
+	if x != nil {
+		return x.Site
+	}
+	return ""
+}
+
+type ListAlertsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Alerts        []*Alert               `protobuf:"bytes,1,rep,name=alerts,proto3" json:"alerts,omitempty"`
+	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAlertsResponse) Reset() {
+	*x = ListAlertsResponse{}
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAlertsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAlertsResponse) ProtoMessage() {}
+
+func (x *ListAlertsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAlertsResponse.ProtoReflect.Descriptor instead.
+func (*ListAlertsResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ListAlertsResponse) GetAlerts() []*Alert {
+	if x != nil {
+		return x.Alerts
+	}
+	return nil
+}
+
+func (x *ListAlertsResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+type AcknowledgeAlertRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcknowledgeAlertRequest) Reset() {
+	*x = AcknowledgeAlertRequest{}
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcknowledgeAlertRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcknowledgeAlertRequest) ProtoMessage() {}
+
+func (x *AcknowledgeAlertRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcknowledgeAlertRequest.ProtoReflect.Descriptor instead.
+func (*AcknowledgeAlertRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *AcknowledgeAlertRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type AcknowledgeAlertResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcknowledgeAlertResponse) Reset() {
+	*x = AcknowledgeAlertResponse{}
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcknowledgeAlertResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcknowledgeAlertResponse) ProtoMessage() {}
+
+func (x *AcknowledgeAlertResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcknowledgeAlertResponse.ProtoReflect.Descriptor instead.
+func (*AcknowledgeAlertResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{45}
+}
+
+type GetDuplicateReportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Site          string                 `protobuf:"bytes,1,opt,name=site,proto3" json:"site,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDuplicateReportRequest) Reset() {
+	*x = GetDuplicateReportRequest{}
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDuplicateReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDuplicateReportRequest) ProtoMessage() {}
+
+func (x *GetDuplicateReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDuplicateReportRequest.ProtoReflect.Descriptor instead.
+func (*GetDuplicateReportRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *GetDuplicateReportRequest) GetSite() string {
+	if x != nil {
+		return x.Site
+	}
+	return ""
+}
+
+// DuplicateGroup lists the hosts sharing one identity value. field is either
+// "system_serial" or "system_uuid".
+type DuplicateGroup struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Hostnames     []string               `protobuf:"bytes,3,rep,name=hostnames,proto3" json:"hostnames,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DuplicateGroup) Reset() {
+	*x = DuplicateGroup{}
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DuplicateGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DuplicateGroup) ProtoMessage() {}
+
+func (x *DuplicateGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DuplicateGroup.ProtoReflect.Descriptor instead.
+func (*DuplicateGroup) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *DuplicateGroup) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *DuplicateGroup) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *DuplicateGroup) GetHostnames() []string {
+	if x != nil {
+		return x.Hostnames
+	}
+	return nil
+}
+
+type GetDuplicateReportResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Duplicates    []*DuplicateGroup      `protobuf:"bytes,1,rep,name=duplicates,proto3" json:"duplicates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDuplicateReportResponse) Reset() {
+	*x = GetDuplicateReportResponse{}
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDuplicateReportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDuplicateReportResponse) ProtoMessage() {}
+
+func (x *GetDuplicateReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDuplicateReportResponse.ProtoReflect.Descriptor instead.
+func (*GetDuplicateReportResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *GetDuplicateReportResponse) GetDuplicates() []*DuplicateGroup {
+	if x != nil {
+		return x.Duplicates
+	}
+	return nil
+}
+
+type GetCollectionReportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Site          string                 `protobuf:"bytes,1,opt,name=site,proto3" json:"site,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCollectionReportRequest) Reset() {
+	*x = GetCollectionReportRequest{}
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCollectionReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCollectionReportRequest) ProtoMessage() {}
+
+func (x *GetCollectionReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCollectionReportRequest.ProtoReflect.Descriptor instead.
+func (*GetCollectionReportRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *GetCollectionReportRequest) GetSite() string {
+	if x != nil {
+		return x.Site
+	}
+	return ""
+}
+
+// ModuleCollectionStats summarizes one collection module over the hosts whose
+// latest inventory carries telemetry.
+type ModuleCollectionStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Module        string                 `protobuf:"bytes,1,opt,name=module,proto3" json:"module,omitempty"`
+	Hosts         int32                  `protobuf:"varint,2,opt,name=hosts,proto3" json:"hosts,omitempty"`
+	Failures      int32                  `protobuf:"varint,3,opt,name=failures,proto3" json:"failures,omitempty"`
+	AvgDurationMs int64                  `protobuf:"varint,4,opt,name=avg_duration_ms,json=avgDurationMs,proto3" json:"avg_duration_ms,omitempty"`
+	MaxDurationMs int64                  `protobuf:"varint,5,opt,name=max_duration_ms,json=maxDurationMs,proto3" json:"max_duration_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModuleCollectionStats) Reset() {
+	*x = ModuleCollectionStats{}
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModuleCollectionStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModuleCollectionStats) ProtoMessage() {}
+
+func (x *ModuleCollectionStats) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModuleCollectionStats.ProtoReflect.Descriptor instead.
+func (*ModuleCollectionStats) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *ModuleCollectionStats) GetModule() string {
+	if x != nil {
+		return x.Module
+	}
+	return ""
+}
+
+func (x *ModuleCollectionStats) GetHosts() int32 {
+	if x != nil {
+		return x.Hosts
+	}
+	return 0
+}
+
+func (x *ModuleCollectionStats) GetFailures() int32 {
+	if x != nil {
+		return x.Failures
+	}
+	return 0
+}
+
+func (x *ModuleCollectionStats) GetAvgDurationMs() int64 {
+	if x != nil {
+		return x.AvgDurationMs
+	}
+	return 0
+}
+
+func (x *ModuleCollectionStats) GetMaxDurationMs() int64 {
+	if x != nil {
+		return x.MaxDurationMs
+	}
+	return 0
+}
+
+type GetCollectionReportResponse struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Modules       []*ModuleCollectionStats `protobuf:"bytes,1,rep,name=modules,proto3" json:"modules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCollectionReportResponse) Reset() {
+	*x = GetCollectionReportResponse{}
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCollectionReportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCollectionReportResponse) ProtoMessage() {}
+
+func (x *GetCollectionReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCollectionReportResponse.ProtoReflect.Descriptor instead.
+func (*GetCollectionReportResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *GetCollectionReportResponse) GetModules() []*ModuleCollectionStats {
+	if x != nil {
+		return x.Modules
+	}
+	return nil
+}
+
+type GetFleetReportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Site          string                 `protobuf:"bytes,1,opt,name=site,proto3" json:"site,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFleetReportRequest) Reset() {
+	*x = GetFleetReportRequest{}
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFleetReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFleetReportRequest) ProtoMessage() {}
+
+func (x *GetFleetReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFleetReportRequest.ProtoReflect.Descriptor instead.
+func (*GetFleetReportRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *GetFleetReportRequest) GetSite() string {
+	if x != nil {
+		return x.Site
+	}
+	return ""
+}
+
+// FleetCount is the number of hosts sharing one value.
+type FleetCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Hosts         int32                  `protobuf:"varint,2,opt,name=hosts,proto3" json:"hosts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FleetCount) Reset() {
+	*x = FleetCount{}
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FleetCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FleetCount) ProtoMessage() {}
+
+func (x *FleetCount) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FleetCount.ProtoReflect.Descriptor instead.
+func (*FleetCount) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *FleetCount) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *FleetCount) GetHosts() int32 {
+	if x != nil {
+		return x.Hosts
+	}
+	return 0
+}
+
+// GetFleetReportResponse describes the latest inventory of each host, most
+// common values first. Memory sizes are rounded up to a power of two GiB.
+type GetFleetReportResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Hosts int32                  `protobuf:"varint,1,opt,name=hosts,proto3" json:"hosts,omitempty"`
+	// online counts the hosts with a connected agent.
+	Online int32         `protobuf:"varint,2,opt,name=online,proto3" json:"online,omitempty"`
+	Models []*FleetCount `protobuf:"bytes,3,rep,name=models,proto3" json:"models,omitempty"`
+	Memory []*FleetCount `protobuf:"bytes,4,rep,name=memory,proto3" json:"memory,omitempty"`
+	// agent_versions covers connected agents only.
+	AgentVersions []*FleetCount `protobuf:"bytes,5,rep,name=agent_versions,json=agentVersions,proto3" json:"agent_versions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFleetReportResponse) Reset() {
+	*x = GetFleetReportResponse{}
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFleetReportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFleetReportResponse) ProtoMessage() {}
+
+func (x *GetFleetReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFleetReportResponse.ProtoReflect.Descriptor instead.
+func (*GetFleetReportResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *GetFleetReportResponse) GetHosts() int32 {
+	if x != nil {
+		return x.Hosts
+	}
+	return 0
+}
+
+func (x *GetFleetReportResponse) GetOnline() int32 {
+	if x != nil {
+		return x.Online
+	}
+	return 0
+}
+
+func (x *GetFleetReportResponse) GetModels() []*FleetCount {
+	if x != nil {
+		return x.Models
+	}
+	return nil
+}
+
+func (x *GetFleetReportResponse) GetMemory() []*FleetCount {
+	if x != nil {
+		return x.Memory
+	}
+	return nil
+}
+
+func (x *GetFleetReportResponse) GetAgentVersions() []*FleetCount {
+	if x != nil {
+		return x.AgentVersions
+	}
+	return nil
+}
+
+type InventoryCommand struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	CommandId   string                 `protobuf:"bytes,1,opt,name=command_id,json=commandId,proto3" json:"command_id,omitempty"`
+	CommandType InventoryCommandType   `protobuf:"varint,2,opt,name=command_type,json=commandType,proto3,enum=inventory.collector.v1.InventoryCommandType" json:"command_type,omitempty"`
+	// update is set for UPDATE commands.
+	Update *AgentUpdate `protobuf:"bytes,3,opt,name=update,proto3" json:"update,omitempty"`
+	// max_log_bytes limits the log output sent for LOGS commands.
+	MaxLogBytes   int64 `protobuf:"varint,4,opt,name=max_log_bytes,json=maxLogBytes,proto3" json:"max_log_bytes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InventoryCommand) Reset() {
+	*x = InventoryCommand{}
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InventoryCommand) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InventoryCommand) ProtoMessage() {}
+
+func (x *InventoryCommand) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InventoryCommand.ProtoReflect.Descriptor instead.
+func (*InventoryCommand) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *InventoryCommand) GetCommandId() string {
+	if x != nil {
+		return x.CommandId
+	}
+	return ""
+}
+
+func (x *InventoryCommand) GetCommandType() InventoryCommandType {
+	if x != nil {
+		return x.CommandType
+	}
+	return InventoryCommandType_INVENTORY_COMMAND_TYPE_REFRESH
+}
+
+func (x *InventoryCommand) GetUpdate() *AgentUpdate {
+	if x != nil {
+		return x.Update
+	}
+	return nil
+}
+
+func (x *InventoryCommand) GetMaxLogBytes() int64 {
+	if x != nil {
+		return x.MaxLogBytes
+	}
+	return 0
+}
+
+// AgentUpdate describes an agent release. Agents download the executable,
+// check its digest and Ed25519 signature against their configured update key,
+// replace themselves and restart.
+type AgentUpdate struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Version     string                 `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	DownloadUrl string                 `protobuf:"bytes,2,opt,name=download_url,json=downloadUrl,proto3" json:"download_url,omitempty"`
+	// sha256 is the hex-encoded SHA-256 digest of the executable.
+	Sha256 string `protobuf:"bytes,3,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	// signature is the Ed25519 signature over the release manifest of
+	// version, os, arch and sha256 ("inventoryctl sign-update" creates it).
+	Signature []byte `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
+	// os and arch are the platform the executable was built for (GOOS/GOARCH
+	// names); agents of other platforms ignore the release.
+	Os            string `protobuf:"bytes,5,opt,name=os,proto3" json:"os,omitempty"`
+	Arch          string `protobuf:"bytes,6,opt,name=arch,proto3" json:"arch,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AgentUpdate) Reset() {
+	*x = AgentUpdate{}
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentUpdate) ProtoMessage() {}
+
+func (x *AgentUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentUpdate.ProtoReflect.Descriptor instead.
+func (*AgentUpdate) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *AgentUpdate) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *AgentUpdate) GetDownloadUrl() string {
+	if x != nil {
+		return x.DownloadUrl
+	}
+	return ""
+}
+
+func (x *AgentUpdate) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *AgentUpdate) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+func (x *AgentUpdate) GetOs() string {
+	if x != nil {
+		return x.Os
+	}
+	return ""
+}
+
+func (x *AgentUpdate) GetArch() string {
+	if x != nil {
+		return x.Arch
+	}
+	return ""
+}
+
+type WatchInventoriesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// site limits the stream to one site (empty = all sites).
+	Site string `protobuf:"bytes,1,opt,name=site,proto3" json:"site,omitempty"`
+	// hostname limits the stream to one host (empty = all hosts).
+	Hostname      string `protobuf:"bytes,2,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchInventoriesRequest) Reset() {
+	*x = WatchInventoriesRequest{}
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchInventoriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchInventoriesRequest) ProtoMessage() {}
+
+func (x *WatchInventoriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchInventoriesRequest.ProtoReflect.Descriptor instead.
+func (*WatchInventoriesRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *WatchInventoriesRequest) GetSite() string {
+	if x != nil {
+		return x.Site
+	}
+	return ""
+}
+
+func (x *WatchInventoriesRequest) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+// InventorySubmission summarizes a stored inventory.
+type InventorySubmission struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Site        string                 `protobuf:"bytes,2,opt,name=site,proto3" json:"site,omitempty"`
+	Hostname    string                 `protobuf:"bytes,3,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Username    string                 `protobuf:"bytes,4,opt,name=username,proto3" json:"username,omitempty"`
+	SystemUuid  string                 `protobuf:"bytes,5,opt,name=system_uuid,json=systemUuid,proto3" json:"system_uuid,omitempty"`
+	CollectedAt *timestamp.Timestamp   `protobuf:"bytes,6,opt,name=collected_at,json=collectedAt,proto3" json:"collected_at,omitempty"`
+	StoredAt    *timestamp.Timestamp   `protobuf:"bytes,7,opt,name=stored_at,json=storedAt,proto3" json:"stored_at,omitempty"`
+	// first is set when the host had no previous inventory.
+	First bool `protobuf:"varint,8,opt,name=first,proto3" json:"first,omitempty"`
+	// changes are the hardware changes from the previous inventory.
+	Changes       []*InventoryChange `protobuf:"bytes,9,rep,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InventorySubmission) Reset() {
+	*x = InventorySubmission{}
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InventorySubmission) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InventorySubmission) ProtoMessage() {}
+
+func (x *InventorySubmission) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InventorySubmission.ProtoReflect.Descriptor instead.
+func (*InventorySubmission) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *InventorySubmission) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *InventorySubmission) GetSite() string {
+	if x != nil {
+		return x.Site
+	}
+	return ""
+}
+
+func (x *InventorySubmission) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+func (x *InventorySubmission) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *InventorySubmission) GetSystemUuid() string {
+	if x != nil {
+		return x.SystemUuid
+	}
+	return ""
+}
+
+func (x *InventorySubmission) GetCollectedAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.CollectedAt
+	}
+	return nil
+}
+
+func (x *InventorySubmission) GetStoredAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.StoredAt
+	}
+	return nil
+}
+
+func (x *InventorySubmission) GetFirst() bool {
+	if x != nil {
+		return x.First
+	}
+	return false
+}
+
+func (x *InventorySubmission) GetChanges() []*InventoryChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+type StreamCommandsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// client_id identifies the agent: its SMBIOS system UUID, or the hostname
+	// when the UUID is unavailable (and for older agents).
+	ClientId      string `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	ClientVersion string `protobuf:"bytes,2,opt,name=client_version,json=clientVersion,proto3" json:"client_version,omitempty"`
+	Site          string `protobuf:"bytes,3,opt,name=site,proto3" json:"site,omitempty"`
+	// hostname is the display name of the agent (empty = client_id).
+	Hostname string `protobuf:"bytes,4,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	// paused reports that collection is paused on the agent.
+	Paused        bool `protobuf:"varint,5,opt,name=paused,proto3" json:"paused,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamCommandsRequest) Reset() {
+	*x = StreamCommandsRequest{}
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamCommandsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamCommandsRequest) ProtoMessage() {}
+
+func (x *StreamCommandsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamCommandsRequest.ProtoReflect.Descriptor instead.
+func (*StreamCommandsRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *StreamCommandsRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *StreamCommandsRequest) GetClientVersion() string {
+	if x != nil {
+		return x.ClientVersion
+	}
+	return ""
+}
+
+func (x *StreamCommandsRequest) GetSite() string {
+	if x != nil {
+		return x.Site
+	}
+	return ""
+}
+
+func (x *StreamCommandsRequest) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+func (x *StreamCommandsRequest) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
+
+type SubmitAgentLogsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// command_id is the ID of the LOGS command answered.
+	CommandId string `protobuf:"bytes,1,opt,name=command_id,json=commandId,proto3" json:"command_id,omitempty"`
+	// logs is the most recent log output, at most max_log_bytes of it,
+	// starting at a line boundary.
+	Logs []byte `protobuf:"bytes,2,opt,name=logs,proto3" json:"logs,omitempty"`
+	// truncated is set when older output was left out or has been discarded.
+	Truncated bool `protobuf:"varint,3,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	// error explains why the agent cannot send its logs, e.g. because it
+	// keeps none.
+	Error string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	// client_id is the client_id the agent streams commands with. Only the
+	// agent the LOGS command was sent to can answer it.
+	ClientId      string `protobuf:"bytes,5,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitAgentLogsRequest) Reset() {
+	*x = SubmitAgentLogsRequest{}
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitAgentLogsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitAgentLogsRequest) ProtoMessage() {}
+
+func (x *SubmitAgentLogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitAgentLogsRequest.ProtoReflect.Descriptor instead.
+func (*SubmitAgentLogsRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *SubmitAgentLogsRequest) GetCommandId() string {
+	if x != nil {
+		return x.CommandId
+	}
+	return ""
+}
+
+func (x *SubmitAgentLogsRequest) GetLogs() []byte {
+	if x != nil {
+		return x.Logs
+	}
+	return nil
+}
+
+func (x *SubmitAgentLogsRequest) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+func (x *SubmitAgentLogsRequest) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *SubmitAgentLogsRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+type SubmitAgentLogsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitAgentLogsResponse) Reset() {
+	*x = SubmitAgentLogsResponse{}
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitAgentLogsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitAgentLogsResponse) ProtoMessage() {}
+
+func (x *SubmitAgentLogsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitAgentLogsResponse.ProtoReflect.Descriptor instead.
+func (*SubmitAgentLogsResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{61}
+}
+
+type AckCommandRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	CommandId string                 `protobuf:"bytes,1,opt,name=command_id,json=commandId,proto3" json:"command_id,omitempty"`
+	// error explains why the command failed or was refused (empty = carried
+	// out).
+	Error string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	// client_id is the client_id the agent streams commands with. Only the
+	// agent the command was sent to can acknowledge it.
+	ClientId      string `protobuf:"bytes,3,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AckCommandRequest) Reset() {
+	*x = AckCommandRequest{}
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AckCommandRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AckCommandRequest) ProtoMessage() {}
+
+func (x *AckCommandRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AckCommandRequest.ProtoReflect.Descriptor instead.
+func (*AckCommandRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *AckCommandRequest) GetCommandId() string {
+	if x != nil {
+		return x.CommandId
+	}
+	return ""
+}
+
+func (x *AckCommandRequest) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *AckCommandRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+type AckCommandResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AckCommandResponse) Reset() {
+	*x = AckCommandResponse{}
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AckCommandResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AckCommandResponse) ProtoMessage() {}
+
+func (x *AckCommandResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AckCommandResponse.ProtoReflect.Descriptor instead.
+func (*AckCommandResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{63}
+}
+
+type CheckAgentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Site          string                 `protobuf:"bytes,1,opt,name=site,proto3" json:"site,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckAgentRequest) Reset() {
+	*x = CheckAgentRequest{}
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckAgentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckAgentRequest) ProtoMessage() {}
+
+func (x *CheckAgentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckAgentRequest.ProtoReflect.Descriptor instead.
+func (*CheckAgentRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *CheckAgentRequest) GetSite() string {
+	if x != nil {
+		return x.Site
+	}
+	return ""
+}
+
+type CheckAgentResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// site is the site the agent's submissions would be stored under.
+	Site          string `protobuf:"bytes,1,opt,name=site,proto3" json:"site,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckAgentResponse) Reset() {
+	*x = CheckAgentResponse{}
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckAgentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckAgentResponse) ProtoMessage() {}
+
+func (x *CheckAgentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (x *StreamCommandsRequest) GetClientId() string {
-	if x != nil {
-		return x.ClientId
-	}
-	return ""
+// Deprecated: Use CheckAgentResponse.ProtoReflect.Descriptor instead.
+func (*CheckAgentResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{65}
 }
 
-func (x *StreamCommandsRequest) GetClientVersion() string {
+func (x *CheckAgentResponse) GetSite() string {
 	if x != nil {
-		return x.ClientVersion
+		return x.Site
 	}
 	return ""
 }
 
 type RefreshInventoryRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Hostname      string                 `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// hostname targets the connected agent with this hostname; it must match
+	// exactly one agent.
+	Hostname string `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Site     string `protobuf:"bytes,2,opt,name=site,proto3" json:"site,omitempty"`
+	// client_id targets a connected agent by client ID and takes precedence
+	// over hostname.
+	ClientId      string `protobuf:"bytes,3,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *RefreshInventoryRequest) Reset() {
 	*x = RefreshInventoryRequest{}
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[28]
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[66]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2078,7 +4745,7 @@ func (x *RefreshInventoryRequest) String() string {
 func (*RefreshInventoryRequest) ProtoMessage() {}
 
 func (x *RefreshInventoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[28]
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[66]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2091,7 +4758,7 @@ func (x *RefreshInventoryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RefreshInventoryRequest.ProtoReflect.Descriptor instead.
 func (*RefreshInventoryRequest) Descriptor() ([]byte, []int) {
-	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{28}
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{66}
 }
 
 func (x *RefreshInventoryRequest) GetHostname() string {
@@ -2101,6 +4768,20 @@ func (x *RefreshInventoryRequest) GetHostname() string {
 	return ""
 }
 
+func (x *RefreshInventoryRequest) GetSite() string {
+	if x != nil {
+		return x.Site
+	}
+	return ""
+}
+
+func (x *RefreshInventoryRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
 type RefreshInventoryResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Sent          bool                   `protobuf:"varint,1,opt,name=sent,proto3" json:"sent,omitempty"`
@@ -2111,7 +4792,7 @@ type RefreshInventoryResponse struct {
 
 func (x *RefreshInventoryResponse) Reset() {
 	*x = RefreshInventoryResponse{}
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[29]
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[67]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2123,7 +4804,7 @@ func (x *RefreshInventoryResponse) String() string {
 func (*RefreshInventoryResponse) ProtoMessage() {}
 
 func (x *RefreshInventoryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[29]
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[67]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2136,7 +4817,7 @@ func (x *RefreshInventoryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RefreshInventoryResponse.ProtoReflect.Descriptor instead.
 func (*RefreshInventoryResponse) Descriptor() ([]byte, []int) {
-	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{29}
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{67}
 }
 
 func (x *RefreshInventoryResponse) GetSent() bool {
@@ -2161,7 +4842,7 @@ type ListConnectedAgentsRequest struct {
 
 func (x *ListConnectedAgentsRequest) Reset() {
 	*x = ListConnectedAgentsRequest{}
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[30]
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[68]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2173,7 +4854,7 @@ func (x *ListConnectedAgentsRequest) String() string {
 func (*ListConnectedAgentsRequest) ProtoMessage() {}
 
 func (x *ListConnectedAgentsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[30]
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[68]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2186,21 +4867,27 @@ func (x *ListConnectedAgentsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListConnectedAgentsRequest.ProtoReflect.Descriptor instead.
 func (*ListConnectedAgentsRequest) Descriptor() ([]byte, []int) {
-	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{30}
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{68}
 }
 
 type ConnectedAgent struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ClientId      string                 `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
-	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
-	ConnectedAt   *timestamp.Timestamp   `protobuf:"bytes,3,opt,name=connected_at,json=connectedAt,proto3" json:"connected_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	ClientId    string                 `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Version     string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	ConnectedAt *timestamp.Timestamp   `protobuf:"bytes,3,opt,name=connected_at,json=connectedAt,proto3" json:"connected_at,omitempty"`
+	Site        string                 `protobuf:"bytes,4,opt,name=site,proto3" json:"site,omitempty"`
+	Hostname    string                 `protobuf:"bytes,5,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Paused      bool                   `protobuf:"varint,6,opt,name=paused,proto3" json:"paused,omitempty"`
+	// last_submitted_at is when the agent last submitted an inventory since it
+	// connected (unset = not yet).
+	LastSubmittedAt *timestamp.Timestamp `protobuf:"bytes,7,opt,name=last_submitted_at,json=lastSubmittedAt,proto3" json:"last_submitted_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *ConnectedAgent) Reset() {
 	*x = ConnectedAgent{}
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[31]
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[69]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2212,7 +4899,7 @@ func (x *ConnectedAgent) String() string {
 func (*ConnectedAgent) ProtoMessage() {}
 
 func (x *ConnectedAgent) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[31]
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[69]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2225,7 +4912,7 @@ func (x *ConnectedAgent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConnectedAgent.ProtoReflect.Descriptor instead.
 func (*ConnectedAgent) Descriptor() ([]byte, []int) {
-	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{31}
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{69}
 }
 
 func (x *ConnectedAgent) GetClientId() string {
@@ -2249,6 +4936,34 @@ func (x *ConnectedAgent) GetConnectedAt() *timestamp.Timestamp {
 	return nil
 }
 
+func (x *ConnectedAgent) GetSite() string {
+	if x != nil {
+		return x.Site
+	}
+	return ""
+}
+
+func (x *ConnectedAgent) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+func (x *ConnectedAgent) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
+
+func (x *ConnectedAgent) GetLastSubmittedAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.LastSubmittedAt
+	}
+	return nil
+}
+
 type ListConnectedAgentsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Agents        []*ConnectedAgent      `protobuf:"bytes,1,rep,name=agents,proto3" json:"agents,omitempty"`
@@ -2258,7 +4973,7 @@ type ListConnectedAgentsResponse struct {
 
 func (x *ListConnectedAgentsResponse) Reset() {
 	*x = ListConnectedAgentsResponse{}
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[32]
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[70]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2270,7 +4985,7 @@ func (x *ListConnectedAgentsResponse) String() string {
 func (*ListConnectedAgentsResponse) ProtoMessage() {}
 
 func (x *ListConnectedAgentsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_collector_v1_collector_proto_msgTypes[32]
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[70]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2283,7 +4998,7 @@ func (x *ListConnectedAgentsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListConnectedAgentsResponse.ProtoReflect.Descriptor instead.
 func (*ListConnectedAgentsResponse) Descriptor() ([]byte, []int) {
-	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{32}
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{70}
 }
 
 func (x *ListConnectedAgentsResponse) GetAgents() []*ConnectedAgent {
@@ -2293,11 +5008,237 @@ func (x *ListConnectedAgentsResponse) GetAgents() []*ConnectedAgent {
 	return nil
 }
 
+// PauseAgentRequest and ResumeAgentRequest target an agent like
+// RefreshInventoryRequest does.
+type PauseAgentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hostname      string                 `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Site          string                 `protobuf:"bytes,2,opt,name=site,proto3" json:"site,omitempty"`
+	ClientId      string                 `protobuf:"bytes,3,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PauseAgentRequest) Reset() {
+	*x = PauseAgentRequest{}
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseAgentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseAgentRequest) ProtoMessage() {}
+
+func (x *PauseAgentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseAgentRequest.ProtoReflect.Descriptor instead.
+func (*PauseAgentRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *PauseAgentRequest) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+func (x *PauseAgentRequest) GetSite() string {
+	if x != nil {
+		return x.Site
+	}
+	return ""
+}
+
+func (x *PauseAgentRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+type PauseAgentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sent          bool                   `protobuf:"varint,1,opt,name=sent,proto3" json:"sent,omitempty"`
+	CommandId     string                 `protobuf:"bytes,2,opt,name=command_id,json=commandId,proto3" json:"command_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PauseAgentResponse) Reset() {
+	*x = PauseAgentResponse{}
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseAgentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseAgentResponse) ProtoMessage() {}
+
+func (x *PauseAgentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseAgentResponse.ProtoReflect.Descriptor instead.
+func (*PauseAgentResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *PauseAgentResponse) GetSent() bool {
+	if x != nil {
+		return x.Sent
+	}
+	return false
+}
+
+func (x *PauseAgentResponse) GetCommandId() string {
+	if x != nil {
+		return x.CommandId
+	}
+	return ""
+}
+
+type ResumeAgentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hostname      string                 `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Site          string                 `protobuf:"bytes,2,opt,name=site,proto3" json:"site,omitempty"`
+	ClientId      string                 `protobuf:"bytes,3,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeAgentRequest) Reset() {
+	*x = ResumeAgentRequest{}
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeAgentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeAgentRequest) ProtoMessage() {}
+
+func (x *ResumeAgentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeAgentRequest.ProtoReflect.Descriptor instead.
+func (*ResumeAgentRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *ResumeAgentRequest) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+func (x *ResumeAgentRequest) GetSite() string {
+	if x != nil {
+		return x.Site
+	}
+	return ""
+}
+
+func (x *ResumeAgentRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+type ResumeAgentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sent          bool                   `protobuf:"varint,1,opt,name=sent,proto3" json:"sent,omitempty"`
+	CommandId     string                 `protobuf:"bytes,2,opt,name=command_id,json=commandId,proto3" json:"command_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeAgentResponse) Reset() {
+	*x = ResumeAgentResponse{}
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeAgentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeAgentResponse) ProtoMessage() {}
+
+func (x *ResumeAgentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v1_collector_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeAgentResponse.ProtoReflect.Descriptor instead.
+func (*ResumeAgentResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v1_collector_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *ResumeAgentResponse) GetSent() bool {
+	if x != nil {
+		return x.Sent
+	}
+	return false
+}
+
+func (x *ResumeAgentResponse) GetCommandId() string {
+	if x != nil {
+		return x.CommandId
+	}
+	return ""
+}
+
 var File_inventory_collector_v1_collector_proto protoreflect.FileDescriptor
 
 const file_inventory_collector_v1_collector_proto_rawDesc = "" +
 	"\n" +
-	"&inventory/collector/v1/collector.proto\x12\x16inventory.collector.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x9f\a\n" +
+	"&inventory/collector/v1/collector.proto\x12\x16inventory.collector.v1\x1a\x1cgoogle/api/annotations.proto\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xe1\t\n" +
 	"\tInventory\x12=\n" +
 	"\fcollected_at\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\vcollectedAt\x12\x1a\n" +
 	"\bhostname\x18\x02 \x01(\tR\bhostname\x12\x1a\n" +
@@ -2318,7 +5259,38 @@ const file_inventory_collector_v1_collector_proto_rawDesc = "" +
 	"\voem_strings\x18\x0e \x03(\tR\n" +
 	"oemStrings\x12M\n" +
 	"\rbios_language\x18\x0f \x01(\v2(.inventory.collector.v1.BIOSLanguageInfoR\fbiosLanguage\x12=\n" +
-	"\amonitor\x18\x10 \x03(\v2#.inventory.collector.v1.MonitorInfoR\amonitor\"U\n" +
+	"\amonitor\x18\x10 \x03(\v2#.inventory.collector.v1.MonitorInfoR\amonitor\x12\x12\n" +
+	"\x04site\x18\x11 \x01(\tR\x04site\x12I\n" +
+	"\ttelemetry\x18\x12 \x01(\v2+.inventory.collector.v1.CollectionTelemetryR\ttelemetry\x12G\n" +
+	"\n" +
+	"privileges\x18\x13 \x01(\v2'.inventory.collector.v1.AgentPrivilegesR\n" +
+	"privileges\x12!\n" +
+	"\fdevice_class\x18\x14 \x01(\tR\vdeviceClass\x126\n" +
+	"\x04snmp\x18\x15 \x01(\v2\".inventory.collector.v1.SNMPDeviceR\x04snmp\x12\x16\n" +
+	"\x06source\x18\x16 \x01(\tR\x06source\x12%\n" +
+	"\x0eschema_version\x18\x17 \x01(\rR\rschemaVersion\"\xd2\x01\n" +
+	"\n" +
+	"SNMPDevice\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x12\x1b\n" +
+	"\tsys_descr\x18\x02 \x01(\tR\bsysDescr\x12\"\n" +
+	"\rsys_object_id\x18\x03 \x01(\tR\vsysObjectId\x12!\n" +
+	"\fsys_location\x18\x04 \x01(\tR\vsysLocation\x12\x1f\n" +
+	"\vsys_contact\x18\x05 \x01(\tR\n" +
+	"sysContact\x12%\n" +
+	"\x0euptime_seconds\x18\x06 \x01(\x03R\ruptimeSeconds\"^\n" +
+	"\x0fAgentPrivileges\x12\x1a\n" +
+	"\belevated\x18\x01 \x01(\bR\belevated\x12/\n" +
+	"\x13unavailable_modules\x18\x02 \x03(\tR\x12unavailableModules\"\x9f\x01\n" +
+	"\x13CollectionTelemetry\x12\x1f\n" +
+	"\vduration_ms\x18\x01 \x01(\x03R\n" +
+	"durationMs\x12$\n" +
+	"\x0epeak_rss_bytes\x18\x02 \x01(\x04R\fpeakRssBytes\x12A\n" +
+	"\amodules\x18\x03 \x03(\v2'.inventory.collector.v1.ModuleTelemetryR\amodules\"\\\n" +
+	"\x0fModuleTelemetry\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1f\n" +
+	"\vduration_ms\x18\x02 \x01(\x03R\n" +
+	"durationMs\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"U\n" +
 	"\vVersionInfo\x12\x14\n" +
 	"\x05major\x18\x01 \x01(\x05R\x05major\x12\x14\n" +
 	"\x05minor\x18\x02 \x01(\x05R\x05minor\x12\x1a\n" +
@@ -2422,18 +5394,20 @@ const file_inventory_collector_v1_collector_proto_rawDesc = "" +
 	"\vMonitorInfo\x12\"\n" +
 	"\fmanufacturer\x18\x01 \x01(\tR\fmanufacturer\x12\x14\n" +
 	"\x05model\x18\x02 \x01(\tR\x05model\x12#\n" +
-	"\rserial_number\x18\x03 \x01(\tR\fserialNumber\"Y\n" +
+	"\rserial_number\x18\x03 \x01(\tR\fserialNumber\"\x82\x01\n" +
 	"\x16SubmitInventoryRequest\x12?\n" +
-	"\tinventory\x18\x01 \x01(\v2!.inventory.collector.v1.InventoryR\tinventory\"b\n" +
+	"\tinventory\x18\x01 \x01(\v2!.inventory.collector.v1.InventoryR\tinventory\x12'\n" +
+	"\x0fsubmission_uuid\x18\x02 \x01(\tR\x0esubmissionUuid\"b\n" +
 	"\x17SubmitInventoryResponse\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x127\n" +
-	"\tstored_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\bstoredAt\"%\n" +
+	"\tstored_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\bstoredAt\"^\n" +
 	"\x13GetInventoryRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\"\xa0\x01\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x127\n" +
+	"\tread_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\breadMask\"\xa0\x01\n" +
 	"\x14GetInventoryResponse\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12?\n" +
 	"\tinventory\x18\x02 \x01(\v2!.inventory.collector.v1.InventoryR\tinventory\x127\n" +
-	"\tstored_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\bstoredAt\"\xae\x02\n" +
+	"\tstored_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\bstoredAt\"\xde\x03\n" +
 	"\x16ListInventoriesRequest\x12\x1a\n" +
 	"\bhostname\x18\x01 \x01(\tR\bhostname\x12\x1a\n" +
 	"\busername\x18\x02 \x01(\tR\busername\x12\x1f\n" +
@@ -2442,11 +5416,20 @@ const file_inventory_collector_v1_collector_proto_rawDesc = "" +
 	"\x0fcollected_after\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x0ecollectedAfter\x12E\n" +
 	"\x10collected_before\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x0fcollectedBefore\x12\x1b\n" +
 	"\tpage_size\x18\x06 \x01(\x05R\bpageSize\x12\x12\n" +
-	"\x04page\x18\a \x01(\x05R\x04page\"\x86\x01\n" +
+	"\x04page\x18\a \x01(\x05R\x04page\x12\x12\n" +
+	"\x04site\x18\b \x01(\tR\x04site\x127\n" +
+	"\tread_mask\x18\t \x01(\v2\x1a.google.protobuf.FieldMaskR\breadMask\x12!\n" +
+	"\fdevice_class\x18\n" +
+	" \x01(\tR\vdeviceClass\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\v \x01(\tR\tpageToken\x12\x1f\n" +
+	"\vexact_count\x18\f \x01(\bR\n" +
+	"exactCount\"\xae\x01\n" +
 	"\x17ListInventoriesResponse\x12J\n" +
 	"\vinventories\x18\x01 \x03(\v2(.inventory.collector.v1.InventorySummaryR\vinventories\x12\x1f\n" +
 	"\vtotal_count\x18\x02 \x01(\x05R\n" +
-	"totalCount\"\x98\x02\n" +
+	"totalCount\x12&\n" +
+	"\x0fnext_page_token\x18\x03 \x01(\tR\rnextPageToken\"\xa8\x03\n" +
 	"\x10InventorySummary\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1a\n" +
 	"\bhostname\x18\x02 \x01(\tR\bhostname\x12\x1a\n" +
@@ -2455,48 +5438,264 @@ const file_inventory_collector_v1_collector_proto_rawDesc = "" +
 	"systemUuid\x12#\n" +
 	"\rsystem_serial\x18\x05 \x01(\tR\fsystemSerial\x12=\n" +
 	"\fcollected_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vcollectedAt\x127\n" +
-	"\tstored_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\bstoredAt\"(\n" +
+	"\tstored_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\bstoredAt\x12\x12\n" +
+	"\x04site\x18\b \x01(\tR\x04site\x12?\n" +
+	"\tinventory\x18\t \x01(\v2!.inventory.collector.v1.InventoryR\tinventory\x12!\n" +
+	"\fdevice_class\x18\n" +
+	" \x01(\tR\vdeviceClass\x12\x16\n" +
+	"\x06source\x18\v \x01(\tR\x06source\"F\n" +
+	"\x16DiffInventoriesRequest\x12\x17\n" +
+	"\afrom_id\x18\x01 \x01(\x03R\x06fromId\x12\x13\n" +
+	"\x05to_id\x18\x02 \x01(\x03R\x04toId\"\xc7\x01\n" +
+	"\x0fInventoryChange\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x1c\n" +
+	"\tcomponent\x18\x02 \x01(\tR\tcomponent\x12\x10\n" +
+	"\x03key\x18\x03 \x01(\tR\x03key\x12\x14\n" +
+	"\x05field\x18\x04 \x01(\tR\x05field\x12\x1b\n" +
+	"\told_value\x18\x05 \x01(\tR\boldValue\x12\x1b\n" +
+	"\tnew_value\x18\x06 \x01(\tR\bnewValue\x12 \n" +
+	"\vdescription\x18\a \x01(\tR\vdescription\"\x8a\x01\n" +
+	"\x17DiffInventoriesResponse\x12\x17\n" +
+	"\afrom_id\x18\x01 \x01(\x03R\x06fromId\x12\x13\n" +
+	"\x05to_id\x18\x02 \x01(\x03R\x04toId\x12A\n" +
+	"\achanges\x18\x03 \x03(\v2'.inventory.collector.v1.InventoryChangeR\achanges\"(\n" +
 	"\x16DeleteInventoryRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\"\x19\n" +
-	"\x17DeleteInventoryResponse\"8\n" +
+	"\x17DeleteInventoryResponse\"\x85\x01\n" +
 	"\x1aGetLatestByHostnameRequest\x12\x1a\n" +
-	"\bhostname\x18\x01 \x01(\tR\bhostname\"\xa7\x01\n" +
+	"\bhostname\x18\x01 \x01(\tR\bhostname\x12\x12\n" +
+	"\x04site\x18\x02 \x01(\tR\x04site\x127\n" +
+	"\tread_mask\x18\x03 \x01(\v2\x1a.google.protobuf.FieldMaskR\breadMask\"\xa7\x01\n" +
 	"\x1bGetLatestByHostnameResponse\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12?\n" +
 	"\tinventory\x18\x02 \x01(\v2!.inventory.collector.v1.InventoryR\tinventory\x127\n" +
-	"\tstored_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\bstoredAt\"\x82\x01\n" +
+	"\tstored_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\bstoredAt\"\x8c\x01\n" +
+	"\x1cGetLatestBySystemUUIDRequest\x12\x1f\n" +
+	"\vsystem_uuid\x18\x01 \x01(\tR\n" +
+	"systemUuid\x127\n" +
+	"\tread_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\breadMask\x12\x12\n" +
+	"\x04site\x18\x03 \x01(\tR\x04site\"\xa9\x01\n" +
+	"\x1dGetLatestBySystemUUIDResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12?\n" +
+	"\tinventory\x18\x02 \x01(\v2!.inventory.collector.v1.InventoryR\tinventory\x127\n" +
+	"\tstored_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\bstoredAt\"\x8c\x01\n" +
+	"\x18GetLatestBySerialRequest\x12#\n" +
+	"\rserial_number\x18\x01 \x01(\tR\fserialNumber\x127\n" +
+	"\tread_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\breadMask\x12\x12\n" +
+	"\x04site\x18\x03 \x01(\tR\x04site\"\xa5\x01\n" +
+	"\x19GetLatestBySerialResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12?\n" +
+	"\tinventory\x18\x02 \x01(\v2!.inventory.collector.v1.InventoryR\tinventory\x127\n" +
+	"\tstored_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\bstoredAt\"W\n" +
+	"\x10ListHostsRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x12\n" +
+	"\x04site\x18\x03 \x01(\tR\x04site\"o\n" +
+	"\x11ListHostsResponse\x129\n" +
+	"\x05hosts\x18\x01 \x03(\v2#.inventory.collector.v1.HostSummaryR\x05hosts\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x05R\n" +
+	"totalCount\"\xfd\x02\n" +
+	"\vHostSummary\x12\x1a\n" +
+	"\bhostname\x18\x01 \x01(\tR\bhostname\x12\x1f\n" +
+	"\vsystem_uuid\x18\x02 \x01(\tR\n" +
+	"systemUuid\x127\n" +
+	"\tlast_seen\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\blastSeen\x12\x1b\n" +
+	"\tlatest_id\x18\x04 \x01(\x03R\blatestId\x12\x12\n" +
+	"\x04site\x18\x05 \x01(\tR\x04site\x12C\n" +
+	"\tdirectory\x18\x06 \x01(\v2%.inventory.collector.v1.HostDirectoryR\tdirectory\x12G\n" +
+	"\x06fields\x18\a \x03(\v2/.inventory.collector.v1.HostSummary.FieldsEntryR\x06fields\x1a9\n" +
+	"\vFieldsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xe4\x01\n" +
+	"\rHostDirectory\x12\x0e\n" +
+	"\x02ou\x18\x01 \x01(\tR\x02ou\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x1a\n" +
+	"\busername\x18\x03 \x01(\tR\busername\x12*\n" +
+	"\x11user_display_name\x18\x04 \x01(\tR\x0fuserDisplayName\x12\x1e\n" +
+	"\n" +
+	"department\x18\x05 \x01(\tR\n" +
+	"department\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x93\x02\n" +
+	"\x05Alert\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1a\n" +
+	"\bhostname\x18\x02 \x01(\tR\bhostname\x12!\n" +
+	"\finventory_id\x18\x03 \x01(\x03R\vinventoryId\x12\x12\n" +
+	"\x04rule\x18\x04 \x01(\tR\x04rule\x12\x1a\n" +
+	"\bseverity\x18\x05 \x01(\tR\bseverity\x12\x18\n" +
+	"\amessage\x18\x06 \x01(\tR\amessage\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\"\n" +
+	"\facknowledged\x18\b \x01(\bR\facknowledged\x12\x12\n" +
+	"\x04site\x18\t \x01(\tR\x04site\"\xa5\x01\n" +
+	"\x11ListAlertsRequest\x12\x1a\n" +
+	"\bhostname\x18\x01 \x01(\tR\bhostname\x12/\n" +
+	"\x13unacknowledged_only\x18\x02 \x01(\bR\x12unacknowledgedOnly\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x12\n" +
+	"\x04page\x18\x04 \x01(\x05R\x04page\x12\x12\n" +
+	"\x04site\x18\x05 \x01(\tR\x04site\"l\n" +
+	"\x12ListAlertsResponse\x125\n" +
+	"\x06alerts\x18\x01 \x03(\v2\x1d.inventory.collector.v1.AlertR\x06alerts\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x05R\n" +
+	"totalCount\")\n" +
+	"\x17AcknowledgeAlertRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\x1a\n" +
+	"\x18AcknowledgeAlertResponse\"/\n" +
+	"\x19GetDuplicateReportRequest\x12\x12\n" +
+	"\x04site\x18\x01 \x01(\tR\x04site\"Z\n" +
+	"\x0eDuplicateGroup\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\x12\x1c\n" +
+	"\thostnames\x18\x03 \x03(\tR\thostnames\"d\n" +
+	"\x1aGetDuplicateReportResponse\x12F\n" +
+	"\n" +
+	"duplicates\x18\x01 \x03(\v2&.inventory.collector.v1.DuplicateGroupR\n" +
+	"duplicates\"0\n" +
+	"\x1aGetCollectionReportRequest\x12\x12\n" +
+	"\x04site\x18\x01 \x01(\tR\x04site\"\xb1\x01\n" +
+	"\x15ModuleCollectionStats\x12\x16\n" +
+	"\x06module\x18\x01 \x01(\tR\x06module\x12\x14\n" +
+	"\x05hosts\x18\x02 \x01(\x05R\x05hosts\x12\x1a\n" +
+	"\bfailures\x18\x03 \x01(\x05R\bfailures\x12&\n" +
+	"\x0favg_duration_ms\x18\x04 \x01(\x03R\ravgDurationMs\x12&\n" +
+	"\x0fmax_duration_ms\x18\x05 \x01(\x03R\rmaxDurationMs\"f\n" +
+	"\x1bGetCollectionReportResponse\x12G\n" +
+	"\amodules\x18\x01 \x03(\v2-.inventory.collector.v1.ModuleCollectionStatsR\amodules\"+\n" +
+	"\x15GetFleetReportRequest\x12\x12\n" +
+	"\x04site\x18\x01 \x01(\tR\x04site\"8\n" +
+	"\n" +
+	"FleetCount\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\x12\x14\n" +
+	"\x05hosts\x18\x02 \x01(\x05R\x05hosts\"\x89\x02\n" +
+	"\x16GetFleetReportResponse\x12\x14\n" +
+	"\x05hosts\x18\x01 \x01(\x05R\x05hosts\x12\x16\n" +
+	"\x06online\x18\x02 \x01(\x05R\x06online\x12:\n" +
+	"\x06models\x18\x03 \x03(\v2\".inventory.collector.v1.FleetCountR\x06models\x12:\n" +
+	"\x06memory\x18\x04 \x03(\v2\".inventory.collector.v1.FleetCountR\x06memory\x12I\n" +
+	"\x0eagent_versions\x18\x05 \x03(\v2\".inventory.collector.v1.FleetCountR\ragentVersions\"\xe3\x01\n" +
 	"\x10InventoryCommand\x12\x1d\n" +
 	"\n" +
 	"command_id\x18\x01 \x01(\tR\tcommandId\x12O\n" +
-	"\fcommand_type\x18\x02 \x01(\x0e2,.inventory.collector.v1.InventoryCommandTypeR\vcommandType\"[\n" +
+	"\fcommand_type\x18\x02 \x01(\x0e2,.inventory.collector.v1.InventoryCommandTypeR\vcommandType\x12;\n" +
+	"\x06update\x18\x03 \x01(\v2#.inventory.collector.v1.AgentUpdateR\x06update\x12\"\n" +
+	"\rmax_log_bytes\x18\x04 \x01(\x03R\vmaxLogBytes\"\xa4\x01\n" +
+	"\vAgentUpdate\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\tR\aversion\x12!\n" +
+	"\fdownload_url\x18\x02 \x01(\tR\vdownloadUrl\x12\x16\n" +
+	"\x06sha256\x18\x03 \x01(\tR\x06sha256\x12\x1c\n" +
+	"\tsignature\x18\x04 \x01(\fR\tsignature\x12\x0e\n" +
+	"\x02os\x18\x05 \x01(\tR\x02os\x12\x12\n" +
+	"\x04arch\x18\x06 \x01(\tR\x04arch\"I\n" +
+	"\x17WatchInventoriesRequest\x12\x12\n" +
+	"\x04site\x18\x01 \x01(\tR\x04site\x12\x1a\n" +
+	"\bhostname\x18\x02 \x01(\tR\bhostname\"\xe3\x02\n" +
+	"\x13InventorySubmission\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04site\x18\x02 \x01(\tR\x04site\x12\x1a\n" +
+	"\bhostname\x18\x03 \x01(\tR\bhostname\x12\x1a\n" +
+	"\busername\x18\x04 \x01(\tR\busername\x12\x1f\n" +
+	"\vsystem_uuid\x18\x05 \x01(\tR\n" +
+	"systemUuid\x12=\n" +
+	"\fcollected_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vcollectedAt\x127\n" +
+	"\tstored_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\bstoredAt\x12\x14\n" +
+	"\x05first\x18\b \x01(\bR\x05first\x12A\n" +
+	"\achanges\x18\t \x03(\v2'.inventory.collector.v1.InventoryChangeR\achanges\"\xa3\x01\n" +
 	"\x15StreamCommandsRequest\x12\x1b\n" +
 	"\tclient_id\x18\x01 \x01(\tR\bclientId\x12%\n" +
-	"\x0eclient_version\x18\x02 \x01(\tR\rclientVersion\"5\n" +
+	"\x0eclient_version\x18\x02 \x01(\tR\rclientVersion\x12\x12\n" +
+	"\x04site\x18\x03 \x01(\tR\x04site\x12\x1a\n" +
+	"\bhostname\x18\x04 \x01(\tR\bhostname\x12\x16\n" +
+	"\x06paused\x18\x05 \x01(\bR\x06paused\"\x9c\x01\n" +
+	"\x16SubmitAgentLogsRequest\x12\x1d\n" +
+	"\n" +
+	"command_id\x18\x01 \x01(\tR\tcommandId\x12\x12\n" +
+	"\x04logs\x18\x02 \x01(\fR\x04logs\x12\x1c\n" +
+	"\ttruncated\x18\x03 \x01(\bR\ttruncated\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x12\x1b\n" +
+	"\tclient_id\x18\x05 \x01(\tR\bclientId\"\x19\n" +
+	"\x17SubmitAgentLogsResponse\"e\n" +
+	"\x11AckCommandRequest\x12\x1d\n" +
+	"\n" +
+	"command_id\x18\x01 \x01(\tR\tcommandId\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1b\n" +
+	"\tclient_id\x18\x03 \x01(\tR\bclientId\"\x14\n" +
+	"\x12AckCommandResponse\"'\n" +
+	"\x11CheckAgentRequest\x12\x12\n" +
+	"\x04site\x18\x01 \x01(\tR\x04site\"(\n" +
+	"\x12CheckAgentResponse\x12\x12\n" +
+	"\x04site\x18\x01 \x01(\tR\x04site\"f\n" +
 	"\x17RefreshInventoryRequest\x12\x1a\n" +
-	"\bhostname\x18\x01 \x01(\tR\bhostname\"M\n" +
+	"\bhostname\x18\x01 \x01(\tR\bhostname\x12\x12\n" +
+	"\x04site\x18\x02 \x01(\tR\x04site\x12\x1b\n" +
+	"\tclient_id\x18\x03 \x01(\tR\bclientId\"M\n" +
 	"\x18RefreshInventoryResponse\x12\x12\n" +
 	"\x04sent\x18\x01 \x01(\bR\x04sent\x12\x1d\n" +
 	"\n" +
 	"command_id\x18\x02 \x01(\tR\tcommandId\"\x1c\n" +
-	"\x1aListConnectedAgentsRequest\"\x86\x01\n" +
+	"\x1aListConnectedAgentsRequest\"\x96\x02\n" +
 	"\x0eConnectedAgent\x12\x1b\n" +
 	"\tclient_id\x18\x01 \x01(\tR\bclientId\x12\x18\n" +
 	"\aversion\x18\x02 \x01(\tR\aversion\x12=\n" +
-	"\fconnected_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\vconnectedAt\"]\n" +
+	"\fconnected_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\vconnectedAt\x12\x12\n" +
+	"\x04site\x18\x04 \x01(\tR\x04site\x12\x1a\n" +
+	"\bhostname\x18\x05 \x01(\tR\bhostname\x12\x16\n" +
+	"\x06paused\x18\x06 \x01(\bR\x06paused\x12F\n" +
+	"\x11last_submitted_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x0flastSubmittedAt\"]\n" +
 	"\x1bListConnectedAgentsResponse\x12>\n" +
-	"\x06agents\x18\x01 \x03(\v2&.inventory.collector.v1.ConnectedAgentR\x06agents*:\n" +
+	"\x06agents\x18\x01 \x03(\v2&.inventory.collector.v1.ConnectedAgentR\x06agents\"`\n" +
+	"\x11PauseAgentRequest\x12\x1a\n" +
+	"\bhostname\x18\x01 \x01(\tR\bhostname\x12\x12\n" +
+	"\x04site\x18\x02 \x01(\tR\x04site\x12\x1b\n" +
+	"\tclient_id\x18\x03 \x01(\tR\bclientId\"G\n" +
+	"\x12PauseAgentResponse\x12\x12\n" +
+	"\x04sent\x18\x01 \x01(\bR\x04sent\x12\x1d\n" +
+	"\n" +
+	"command_id\x18\x02 \x01(\tR\tcommandId\"a\n" +
+	"\x12ResumeAgentRequest\x12\x1a\n" +
+	"\bhostname\x18\x01 \x01(\tR\bhostname\x12\x12\n" +
+	"\x04site\x18\x02 \x01(\tR\x04site\x12\x1b\n" +
+	"\tclient_id\x18\x03 \x01(\tR\bclientId\"H\n" +
+	"\x13ResumeAgentResponse\x12\x12\n" +
+	"\x04sent\x18\x01 \x01(\bR\x04sent\x12\x1d\n" +
+	"\n" +
+	"command_id\x18\x02 \x01(\tR\tcommandId*\xe4\x01\n" +
 	"\x14InventoryCommandType\x12\"\n" +
-	"\x1eINVENTORY_COMMAND_TYPE_REFRESH\x10\x002\xa3\t\n" +
+	"\x1eINVENTORY_COMMAND_TYPE_REFRESH\x10\x00\x12!\n" +
+	"\x1dINVENTORY_COMMAND_TYPE_UPDATE\x10\x01\x12 \n" +
+	"\x1cINVENTORY_COMMAND_TYPE_PAUSE\x10\x02\x12!\n" +
+	"\x1dINVENTORY_COMMAND_TYPE_RESUME\x10\x03\x12\x1f\n" +
+	"\x1bINVENTORY_COMMAND_TYPE_LOGS\x10\x04\x12\x1f\n" +
+	"\x1bINVENTORY_COMMAND_TYPE_PING\x10\x052\xc6\x19\n" +
 	"\x19InventoryCollectorService\x12\x8e\x01\n" +
 	"\x0fSubmitInventory\x12..inventory.collector.v1.SubmitInventoryRequest\x1a/.inventory.collector.v1.SubmitInventoryResponse\"\x1a\x82\xd3\xe4\x93\x02\x14:\x01*\"\x0f/v1/inventories\x12\x87\x01\n" +
 	"\fGetInventory\x12+.inventory.collector.v1.GetInventoryRequest\x1a,.inventory.collector.v1.GetInventoryResponse\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/v1/inventories/{id}\x12\x8b\x01\n" +
 	"\x0fListInventories\x12..inventory.collector.v1.ListInventoriesRequest\x1a/.inventory.collector.v1.ListInventoriesResponse\"\x17\x82\xd3\xe4\x93\x02\x11\x12\x0f/v1/inventories\x12\x90\x01\n" +
 	"\x0fDeleteInventory\x12..inventory.collector.v1.DeleteInventoryRequest\x1a/.inventory.collector.v1.DeleteInventoryResponse\"\x1c\x82\xd3\xe4\x93\x02\x16*\x14/v1/inventories/{id}\x12\xa9\x01\n" +
-	"\x13GetLatestByHostname\x122.inventory.collector.v1.GetLatestByHostnameRequest\x1a3.inventory.collector.v1.GetLatestByHostnameResponse\")\x82\xd3\xe4\x93\x02#\x12!/v1/inventories/latest/{hostname}\x12m\n" +
-	"\x0eStreamCommands\x12-.inventory.collector.v1.StreamCommandsRequest\x1a(.inventory.collector.v1.InventoryCommand\"\x000\x01\x12\x99\x01\n" +
+	"\x13GetLatestByHostname\x122.inventory.collector.v1.GetLatestByHostnameRequest\x1a3.inventory.collector.v1.GetLatestByHostnameResponse\")\x82\xd3\xe4\x93\x02#\x12!/v1/inventories/latest/{hostname}\x12\xba\x01\n" +
+	"\x15GetLatestBySystemUUID\x124.inventory.collector.v1.GetLatestBySystemUUIDRequest\x1a5.inventory.collector.v1.GetLatestBySystemUUIDResponse\"4\x82\xd3\xe4\x93\x02.\x12,/v1/inventories/latest/by-uuid/{system_uuid}\x12\xb2\x01\n" +
+	"\x11GetLatestBySerial\x120.inventory.collector.v1.GetLatestBySerialRequest\x1a1.inventory.collector.v1.GetLatestBySerialResponse\"8\x82\xd3\xe4\x93\x022\x120/v1/inventories/latest/by-serial/{serial_number}\x12\x98\x01\n" +
+	"\x0fDiffInventories\x12..inventory.collector.v1.DiffInventoriesRequest\x1a/.inventory.collector.v1.DiffInventoriesResponse\"$\x82\xd3\xe4\x93\x02\x1e\x12\x1c/v1/inventories/{to_id}/diff\x12s\n" +
+	"\tListHosts\x12(.inventory.collector.v1.ListHostsRequest\x1a).inventory.collector.v1.ListHostsResponse\"\x11\x82\xd3\xe4\x93\x02\v\x12\t/v1/hosts\x12w\n" +
+	"\n" +
+	"ListAlerts\x12).inventory.collector.v1.ListAlertsRequest\x1a*.inventory.collector.v1.ListAlertsResponse\"\x12\x82\xd3\xe4\x93\x02\f\x12\n" +
+	"/v1/alerts\x12\x95\x01\n" +
+	"\x10AcknowledgeAlert\x12/.inventory.collector.v1.AcknowledgeAlertRequest\x1a0.inventory.collector.v1.AcknowledgeAlertResponse\"\x1e\x82\xd3\xe4\x93\x02\x18:\x01*\"\x13/v1/alerts/{id}/ack\x12\x9b\x01\n" +
+	"\x12GetDuplicateReport\x121.inventory.collector.v1.GetDuplicateReportRequest\x1a2.inventory.collector.v1.GetDuplicateReportResponse\"\x1e\x82\xd3\xe4\x93\x02\x18\x12\x16/v1/reports/duplicates\x12\x9e\x01\n" +
+	"\x13GetCollectionReport\x122.inventory.collector.v1.GetCollectionReportRequest\x1a3.inventory.collector.v1.GetCollectionReportResponse\"\x1e\x82\xd3\xe4\x93\x02\x18\x12\x16/v1/reports/collection\x12\x8a\x01\n" +
+	"\x0eGetFleetReport\x12-.inventory.collector.v1.GetFleetReportRequest\x1a..inventory.collector.v1.GetFleetReportResponse\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/v1/reports/fleet\x12m\n" +
+	"\x0eStreamCommands\x12-.inventory.collector.v1.StreamCommandsRequest\x1a(.inventory.collector.v1.InventoryCommand\"\x000\x01\x12t\n" +
+	"\x10WatchInventories\x12/.inventory.collector.v1.WatchInventoriesRequest\x1a+.inventory.collector.v1.InventorySubmission\"\x000\x01\x12t\n" +
+	"\x0fSubmitAgentLogs\x12..inventory.collector.v1.SubmitAgentLogsRequest\x1a/.inventory.collector.v1.SubmitAgentLogsResponse\"\x00\x12e\n" +
+	"\n" +
+	"AckCommand\x12).inventory.collector.v1.AckCommandRequest\x1a*.inventory.collector.v1.AckCommandResponse\"\x00\x12e\n" +
+	"\n" +
+	"CheckAgent\x12).inventory.collector.v1.CheckAgentRequest\x1a*.inventory.collector.v1.CheckAgentResponse\"\x00\x12\x99\x01\n" +
 	"\x10RefreshInventory\x12/.inventory.collector.v1.RefreshInventoryRequest\x1a0.inventory.collector.v1.RefreshInventoryResponse\"\"\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/v1/inventories/refresh\x12\x92\x01\n" +
 	"\x13ListConnectedAgents\x122.inventory.collector.v1.ListConnectedAgentsRequest\x1a3.inventory.collector.v1.ListConnectedAgentsResponse\"\x12\x82\xd3\xe4\x93\x02\f\x12\n" +
-	"/v1/agentsB$Z\"inventory/collector/v1;collectorv1b\x06proto3"
+	"/v1/agents\x12\x80\x01\n" +
+	"\n" +
+	"PauseAgent\x12).inventory.collector.v1.PauseAgentRequest\x1a*.inventory.collector.v1.PauseAgentResponse\"\x1b\x82\xd3\xe4\x93\x02\x15:\x01*\"\x10/v1/agents/pause\x12\x84\x01\n" +
+	"\vResumeAgent\x12*.inventory.collector.v1.ResumeAgentRequest\x1a+.inventory.collector.v1.ResumeAgentResponse\"\x1c\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/v1/agents/resumeB$Z\"inventory/collector/v1;collectorv1b\x06proto3"
 
 var (
 	file_inventory_collector_v1_collector_proto_rawDescOnce sync.Once
@@ -2511,95 +5710,201 @@ func file_inventory_collector_v1_collector_proto_rawDescGZIP() []byte {
 }
 
 var file_inventory_collector_v1_collector_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_inventory_collector_v1_collector_proto_msgTypes = make([]protoimpl.MessageInfo, 33)
+var file_inventory_collector_v1_collector_proto_msgTypes = make([]protoimpl.MessageInfo, 76)
 var file_inventory_collector_v1_collector_proto_goTypes = []any{
-	(InventoryCommandType)(0),           // 0: inventory.collector.v1.InventoryCommandType
-	(*Inventory)(nil),                   // 1: inventory.collector.v1.Inventory
-	(*VersionInfo)(nil),                 // 2: inventory.collector.v1.VersionInfo
-	(*BIOSInfo)(nil),                    // 3: inventory.collector.v1.BIOSInfo
-	(*SystemInfo)(nil),                  // 4: inventory.collector.v1.SystemInfo
-	(*BaseboardInfo)(nil),               // 5: inventory.collector.v1.BaseboardInfo
-	(*ChassisInfo)(nil),                 // 6: inventory.collector.v1.ChassisInfo
-	(*ProcessorInfo)(nil),               // 7: inventory.collector.v1.ProcessorInfo
-	(*CacheInfo)(nil),                   // 8: inventory.collector.v1.CacheInfo
-	(*MemoryInfo)(nil),                  // 9: inventory.collector.v1.MemoryInfo
-	(*PhysicalMemoryArray)(nil),         // 10: inventory.collector.v1.PhysicalMemoryArray
-	(*MemoryModule)(nil),                // 11: inventory.collector.v1.MemoryModule
-	(*PortInfo)(nil),                    // 12: inventory.collector.v1.PortInfo
-	(*SlotInfo)(nil),                    // 13: inventory.collector.v1.SlotInfo
-	(*BIOSLanguageInfo)(nil),            // 14: inventory.collector.v1.BIOSLanguageInfo
-	(*MonitorInfo)(nil),                 // 15: inventory.collector.v1.MonitorInfo
-	(*SubmitInventoryRequest)(nil),      // 16: inventory.collector.v1.SubmitInventoryRequest
-	(*SubmitInventoryResponse)(nil),     // 17: inventory.collector.v1.SubmitInventoryResponse
-	(*GetInventoryRequest)(nil),         // 18: inventory.collector.v1.GetInventoryRequest
-	(*GetInventoryResponse)(nil),        // 19: inventory.collector.v1.GetInventoryResponse
-	(*ListInventoriesRequest)(nil),      // 20: inventory.collector.v1.ListInventoriesRequest
-	(*ListInventoriesResponse)(nil),     // 21: inventory.collector.v1.ListInventoriesResponse
-	(*InventorySummary)(nil),            // 22: inventory.collector.v1.InventorySummary
-	(*DeleteInventoryRequest)(nil),      // 23: inventory.collector.v1.DeleteInventoryRequest
-	(*DeleteInventoryResponse)(nil),     // 24: inventory.collector.v1.DeleteInventoryResponse
-	(*GetLatestByHostnameRequest)(nil),  // 25: inventory.collector.v1.GetLatestByHostnameRequest
-	(*GetLatestByHostnameResponse)(nil), // 26: inventory.collector.v1.GetLatestByHostnameResponse
-	(*InventoryCommand)(nil),            // 27: inventory.collector.v1.InventoryCommand
-	(*StreamCommandsRequest)(nil),       // 28: inventory.collector.v1.StreamCommandsRequest
-	(*RefreshInventoryRequest)(nil),     // 29: inventory.collector.v1.RefreshInventoryRequest
-	(*RefreshInventoryResponse)(nil),    // 30: inventory.collector.v1.RefreshInventoryResponse
-	(*ListConnectedAgentsRequest)(nil),  // 31: inventory.collector.v1.ListConnectedAgentsRequest
-	(*ConnectedAgent)(nil),              // 32: inventory.collector.v1.ConnectedAgent
-	(*ListConnectedAgentsResponse)(nil), // 33: inventory.collector.v1.ListConnectedAgentsResponse
-	(*timestamp.Timestamp)(nil),         // 34: google.protobuf.Timestamp
+	(InventoryCommandType)(0),             // 0: inventory.collector.v1.InventoryCommandType
+	(*Inventory)(nil),                     // 1: inventory.collector.v1.Inventory
+	(*SNMPDevice)(nil),                    // 2: inventory.collector.v1.SNMPDevice
+	(*AgentPrivileges)(nil),               // 3: inventory.collector.v1.AgentPrivileges
+	(*CollectionTelemetry)(nil),           // 4: inventory.collector.v1.CollectionTelemetry
+	(*ModuleTelemetry)(nil),               // 5: inventory.collector.v1.ModuleTelemetry
+	(*VersionInfo)(nil),                   // 6: inventory.collector.v1.VersionInfo
+	(*BIOSInfo)(nil),                      // 7: inventory.collector.v1.BIOSInfo
+	(*SystemInfo)(nil),                    // 8: inventory.collector.v1.SystemInfo
+	(*BaseboardInfo)(nil),                 // 9: inventory.collector.v1.BaseboardInfo
+	(*ChassisInfo)(nil),                   // 10: inventory.collector.v1.ChassisInfo
+	(*ProcessorInfo)(nil),                 // 11: inventory.collector.v1.ProcessorInfo
+	(*CacheInfo)(nil),                     // 12: inventory.collector.v1.CacheInfo
+	(*MemoryInfo)(nil),                    // 13: inventory.collector.v1.MemoryInfo
+	(*PhysicalMemoryArray)(nil),           // 14: inventory.collector.v1.PhysicalMemoryArray
+	(*MemoryModule)(nil),                  // 15: inventory.collector.v1.MemoryModule
+	(*PortInfo)(nil),                      // 16: inventory.collector.v1.PortInfo
+	(*SlotInfo)(nil),                      // 17: inventory.collector.v1.SlotInfo
+	(*BIOSLanguageInfo)(nil),              // 18: inventory.collector.v1.BIOSLanguageInfo
+	(*MonitorInfo)(nil),                   // 19: inventory.collector.v1.MonitorInfo
+	(*SubmitInventoryRequest)(nil),        // 20: inventory.collector.v1.SubmitInventoryRequest
+	(*SubmitInventoryResponse)(nil),       // 21: inventory.collector.v1.SubmitInventoryResponse
+	(*GetInventoryRequest)(nil),           // 22: inventory.collector.v1.GetInventoryRequest
+	(*GetInventoryResponse)(nil),          // 23: inventory.collector.v1.GetInventoryResponse
+	(*ListInventoriesRequest)(nil),        // 24: inventory.collector.v1.ListInventoriesRequest
+	(*ListInventoriesResponse)(nil),       // 25: inventory.collector.v1.ListInventoriesResponse
+	(*InventorySummary)(nil),              // 26: inventory.collector.v1.InventorySummary
+	(*DiffInventoriesRequest)(nil),        // 27: inventory.collector.v1.DiffInventoriesRequest
+	(*InventoryChange)(nil),               // 28: inventory.collector.v1.InventoryChange
+	(*DiffInventoriesResponse)(nil),       // 29: inventory.collector.v1.DiffInventoriesResponse
+	(*DeleteInventoryRequest)(nil),        // 30: inventory.collector.v1.DeleteInventoryRequest
+	(*DeleteInventoryResponse)(nil),       // 31: inventory.collector.v1.DeleteInventoryResponse
+	(*GetLatestByHostnameRequest)(nil),    // 32: inventory.collector.v1.GetLatestByHostnameRequest
+	(*GetLatestByHostnameResponse)(nil),   // 33: inventory.collector.v1.GetLatestByHostnameResponse
+	(*GetLatestBySystemUUIDRequest)(nil),  // 34: inventory.collector.v1.GetLatestBySystemUUIDRequest
+	(*GetLatestBySystemUUIDResponse)(nil), // 35: inventory.collector.v1.GetLatestBySystemUUIDResponse
+	(*GetLatestBySerialRequest)(nil),      // 36: inventory.collector.v1.GetLatestBySerialRequest
+	(*GetLatestBySerialResponse)(nil),     // 37: inventory.collector.v1.GetLatestBySerialResponse
+	(*ListHostsRequest)(nil),              // 38: inventory.collector.v1.ListHostsRequest
+	(*ListHostsResponse)(nil),             // 39: inventory.collector.v1.ListHostsResponse
+	(*HostSummary)(nil),                   // 40: inventory.collector.v1.HostSummary
+	(*HostDirectory)(nil),                 // 41: inventory.collector.v1.HostDirectory
+	(*Alert)(nil),                         // 42: inventory.collector.v1.Alert
+	(*ListAlertsRequest)(nil),             // 43: inventory.collector.v1.ListAlertsRequest
+	(*ListAlertsResponse)(nil),            // 44: inventory.collector.v1.ListAlertsResponse
+	(*AcknowledgeAlertRequest)(nil),       // 45: inventory.collector.v1.AcknowledgeAlertRequest
+	(*AcknowledgeAlertResponse)(nil),      // 46: inventory.collector.v1.AcknowledgeAlertResponse
+	(*GetDuplicateReportRequest)(nil),     // 47: inventory.collector.v1.GetDuplicateReportRequest
+	(*DuplicateGroup)(nil),                // 48: inventory.collector.v1.DuplicateGroup
+	(*GetDuplicateReportResponse)(nil),    // 49: inventory.collector.v1.GetDuplicateReportResponse
+	(*GetCollectionReportRequest)(nil),    // 50: inventory.collector.v1.GetCollectionReportRequest
+	(*ModuleCollectionStats)(nil),         // 51: inventory.collector.v1.ModuleCollectionStats
+	(*GetCollectionReportResponse)(nil),   // 52: inventory.collector.v1.GetCollectionReportResponse
+	(*GetFleetReportRequest)(nil),         // 53: inventory.collector.v1.GetFleetReportRequest
+	(*FleetCount)(nil),                    // 54: inventory.collector.v1.FleetCount
+	(*GetFleetReportResponse)(nil),        // 55: inventory.collector.v1.GetFleetReportResponse
+	(*InventoryCommand)(nil),              // 56: inventory.collector.v1.InventoryCommand
+	(*AgentUpdate)(nil),                   // 57: inventory.collector.v1.AgentUpdate
+	(*WatchInventoriesRequest)(nil),       // 58: inventory.collector.v1.WatchInventoriesRequest
+	(*InventorySubmission)(nil),           // 59: inventory.collector.v1.InventorySubmission
+	(*StreamCommandsRequest)(nil),         // 60: inventory.collector.v1.StreamCommandsRequest
+	(*SubmitAgentLogsRequest)(nil),        // 61: inventory.collector.v1.SubmitAgentLogsRequest
+	(*SubmitAgentLogsResponse)(nil),       // 62: inventory.collector.v1.SubmitAgentLogsResponse
+	(*AckCommandRequest)(nil),             // 63: inventory.collector.v1.AckCommandRequest
+	(*AckCommandResponse)(nil),            // 64: inventory.collector.v1.AckCommandResponse
+	(*CheckAgentRequest)(nil),             // 65: inventory.collector.v1.CheckAgentRequest
+	(*CheckAgentResponse)(nil),            // 66: inventory.collector.v1.CheckAgentResponse
+	(*RefreshInventoryRequest)(nil),       // 67: inventory.collector.v1.RefreshInventoryRequest
+	(*RefreshInventoryResponse)(nil),      // 68: inventory.collector.v1.RefreshInventoryResponse
+	(*ListConnectedAgentsRequest)(nil),    // 69: inventory.collector.v1.ListConnectedAgentsRequest
+	(*ConnectedAgent)(nil),                // 70: inventory.collector.v1.ConnectedAgent
+	(*ListConnectedAgentsResponse)(nil),   // 71: inventory.collector.v1.ListConnectedAgentsResponse
+	(*PauseAgentRequest)(nil),             // 72: inventory.collector.v1.PauseAgentRequest
+	(*PauseAgentResponse)(nil),            // 73: inventory.collector.v1.PauseAgentResponse
+	(*ResumeAgentRequest)(nil),            // 74: inventory.collector.v1.ResumeAgentRequest
+	(*ResumeAgentResponse)(nil),           // 75: inventory.collector.v1.ResumeAgentResponse
+	nil,                                   // 76: inventory.collector.v1.HostSummary.FieldsEntry
+	(*timestamp.Timestamp)(nil),           // 77: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil),         // 78: google.protobuf.FieldMask
 }
 var file_inventory_collector_v1_collector_proto_depIdxs = []int32{
-	34, // 0: inventory.collector.v1.Inventory.collected_at:type_name -> google.protobuf.Timestamp
-	2,  // 1: inventory.collector.v1.Inventory.smbios_version:type_name -> inventory.collector.v1.VersionInfo
-	3,  // 2: inventory.collector.v1.Inventory.bios:type_name -> inventory.collector.v1.BIOSInfo
-	4,  // 3: inventory.collector.v1.Inventory.system:type_name -> inventory.collector.v1.SystemInfo
-	5,  // 4: inventory.collector.v1.Inventory.baseboard:type_name -> inventory.collector.v1.BaseboardInfo
-	6,  // 5: inventory.collector.v1.Inventory.chassis:type_name -> inventory.collector.v1.ChassisInfo
-	7,  // 6: inventory.collector.v1.Inventory.processors:type_name -> inventory.collector.v1.ProcessorInfo
-	8,  // 7: inventory.collector.v1.Inventory.cache:type_name -> inventory.collector.v1.CacheInfo
-	9,  // 8: inventory.collector.v1.Inventory.memory:type_name -> inventory.collector.v1.MemoryInfo
-	12, // 9: inventory.collector.v1.Inventory.ports:type_name -> inventory.collector.v1.PortInfo
-	13, // 10: inventory.collector.v1.Inventory.slots:type_name -> inventory.collector.v1.SlotInfo
-	14, // 11: inventory.collector.v1.Inventory.bios_language:type_name -> inventory.collector.v1.BIOSLanguageInfo
-	15, // 12: inventory.collector.v1.Inventory.monitor:type_name -> inventory.collector.v1.MonitorInfo
-	10, // 13: inventory.collector.v1.MemoryInfo.array:type_name -> inventory.collector.v1.PhysicalMemoryArray
-	11, // 14: inventory.collector.v1.MemoryInfo.modules:type_name -> inventory.collector.v1.MemoryModule
-	1,  // 15: inventory.collector.v1.SubmitInventoryRequest.inventory:type_name -> inventory.collector.v1.Inventory
-	34, // 16: inventory.collector.v1.SubmitInventoryResponse.stored_at:type_name -> google.protobuf.Timestamp
-	1,  // 17: inventory.collector.v1.GetInventoryResponse.inventory:type_name -> inventory.collector.v1.Inventory
-	34, // 18: inventory.collector.v1.GetInventoryResponse.stored_at:type_name -> google.protobuf.Timestamp
-	34, // 19: inventory.collector.v1.ListInventoriesRequest.collected_after:type_name -> google.protobuf.Timestamp
-	34, // 20: inventory.collector.v1.ListInventoriesRequest.collected_before:type_name -> google.protobuf.Timestamp
-	22, // 21: inventory.collector.v1.ListInventoriesResponse.inventories:type_name -> inventory.collector.v1.InventorySummary
-	34, // 22: inventory.collector.v1.InventorySummary.collected_at:type_name -> google.protobuf.Timestamp
-	34, // 23: inventory.collector.v1.InventorySummary.stored_at:type_name -> google.protobuf.Timestamp
-	1,  // 24: inventory.collector.v1.GetLatestByHostnameResponse.inventory:type_name -> inventory.collector.v1.Inventory
-	34, // 25: inventory.collector.v1.GetLatestByHostnameResponse.stored_at:type_name -> google.protobuf.Timestamp
-	0,  // 26: inventory.collector.v1.InventoryCommand.command_type:type_name -> inventory.collector.v1.InventoryCommandType
-	34, // 27: inventory.collector.v1.ConnectedAgent.connected_at:type_name -> google.protobuf.Timestamp
-	32, // 28: inventory.collector.v1.ListConnectedAgentsResponse.agents:type_name -> inventory.collector.v1.ConnectedAgent
-	16, // 29: inventory.collector.v1.InventoryCollectorService.SubmitInventory:input_type -> inventory.collector.v1.SubmitInventoryRequest
-	18, // 30: inventory.collector.v1.InventoryCollectorService.GetInventory:input_type -> inventory.collector.v1.GetInventoryRequest
-	20, // 31: inventory.collector.v1.InventoryCollectorService.ListInventories:input_type -> inventory.collector.v1.ListInventoriesRequest
-	23, // 32: inventory.collector.v1.InventoryCollectorService.DeleteInventory:input_type -> inventory.collector.v1.DeleteInventoryRequest
-	25, // 33: inventory.collector.v1.InventoryCollectorService.GetLatestByHostname:input_type -> inventory.collector.v1.GetLatestByHostnameRequest
-	28, // 34: inventory.collector.v1.InventoryCollectorService.StreamCommands:input_type -> inventory.collector.v1.StreamCommandsRequest
-	29, // 35: inventory.collector.v1.InventoryCollectorService.RefreshInventory:input_type -> inventory.collector.v1.RefreshInventoryRequest
-	31, // 36: inventory.collector.v1.InventoryCollectorService.ListConnectedAgents:input_type -> inventory.collector.v1.ListConnectedAgentsRequest
-	17, // 37: inventory.collector.v1.InventoryCollectorService.SubmitInventory:output_type -> inventory.collector.v1.SubmitInventoryResponse
-	19, // 38: inventory.collector.v1.InventoryCollectorService.GetInventory:output_type -> inventory.collector.v1.GetInventoryResponse
-	21, // 39: inventory.collector.v1.InventoryCollectorService.ListInventories:output_type -> inventory.collector.v1.ListInventoriesResponse
-	24, // 40: inventory.collector.v1.InventoryCollectorService.DeleteInventory:output_type -> inventory.collector.v1.DeleteInventoryResponse
-	26, // 41: inventory.collector.v1.InventoryCollectorService.GetLatestByHostname:output_type -> inventory.collector.v1.GetLatestByHostnameResponse
-	27, // 42: inventory.collector.v1.InventoryCollectorService.StreamCommands:output_type -> inventory.collector.v1.InventoryCommand
-	30, // 43: inventory.collector.v1.InventoryCollectorService.RefreshInventory:output_type -> inventory.collector.v1.RefreshInventoryResponse
-	33, // 44: inventory.collector.v1.InventoryCollectorService.ListConnectedAgents:output_type -> inventory.collector.v1.ListConnectedAgentsResponse
-	37, // [37:45] is the sub-list for method output_type
-	29, // [29:37] is the sub-list for method input_type
-	29, // [29:29] is the sub-list for extension type_name
-	29, // [29:29] is the sub-list for extension extendee
-	0,  // [0:29] is the sub-list for field type_name
+	77, // 0: inventory.collector.v1.Inventory.collected_at:type_name -> google.protobuf.Timestamp
+	6,  // 1: inventory.collector.v1.Inventory.smbios_version:type_name -> inventory.collector.v1.VersionInfo
+	7,  // 2: inventory.collector.v1.Inventory.bios:type_name -> inventory.collector.v1.BIOSInfo
+	8,  // 3: inventory.collector.v1.Inventory.system:type_name -> inventory.collector.v1.SystemInfo
+	9,  // 4: inventory.collector.v1.Inventory.baseboard:type_name -> inventory.collector.v1.BaseboardInfo
+	10, // 5: inventory.collector.v1.Inventory.chassis:type_name -> inventory.collector.v1.ChassisInfo
+	11, // 6: inventory.collector.v1.Inventory.processors:type_name -> inventory.collector.v1.ProcessorInfo
+	12, // 7: inventory.collector.v1.Inventory.cache:type_name -> inventory.collector.v1.CacheInfo
+	13, // 8: inventory.collector.v1.Inventory.memory:type_name -> inventory.collector.v1.MemoryInfo
+	16, // 9: inventory.collector.v1.Inventory.ports:type_name -> inventory.collector.v1.PortInfo
+	17, // 10: inventory.collector.v1.Inventory.slots:type_name -> inventory.collector.v1.SlotInfo
+	18, // 11: inventory.collector.v1.Inventory.bios_language:type_name -> inventory.collector.v1.BIOSLanguageInfo
+	19, // 12: inventory.collector.v1.Inventory.monitor:type_name -> inventory.collector.v1.MonitorInfo
+	4,  // 13: inventory.collector.v1.Inventory.telemetry:type_name -> inventory.collector.v1.CollectionTelemetry
+	3,  // 14: inventory.collector.v1.Inventory.privileges:type_name -> inventory.collector.v1.AgentPrivileges
+	2,  // 15: inventory.collector.v1.Inventory.snmp:type_name -> inventory.collector.v1.SNMPDevice
+	5,  // 16: inventory.collector.v1.CollectionTelemetry.modules:type_name -> inventory.collector.v1.ModuleTelemetry
+	14, // 17: inventory.collector.v1.MemoryInfo.array:type_name -> inventory.collector.v1.PhysicalMemoryArray
+	15, // 18: inventory.collector.v1.MemoryInfo.modules:type_name -> inventory.collector.v1.MemoryModule
+	1,  // 19: inventory.collector.v1.SubmitInventoryRequest.inventory:type_name -> inventory.collector.v1.Inventory
+	77, // 20: inventory.collector.v1.SubmitInventoryResponse.stored_at:type_name -> google.protobuf.Timestamp
+	78, // 21: inventory.collector.v1.GetInventoryRequest.read_mask:type_name -> google.protobuf.FieldMask
+	1,  // 22: inventory.collector.v1.GetInventoryResponse.inventory:type_name -> inventory.collector.v1.Inventory
+	77, // 23: inventory.collector.v1.GetInventoryResponse.stored_at:type_name -> google.protobuf.Timestamp
+	77, // 24: inventory.collector.v1.ListInventoriesRequest.collected_after:type_name -> google.protobuf.Timestamp
+	77, // 25: inventory.collector.v1.ListInventoriesRequest.collected_before:type_name -> google.protobuf.Timestamp
+	78, // 26: inventory.collector.v1.ListInventoriesRequest.read_mask:type_name -> google.protobuf.FieldMask
+	26, // 27: inventory.collector.v1.ListInventoriesResponse.inventories:type_name -> inventory.collector.v1.InventorySummary
+	77, // 28: inventory.collector.v1.InventorySummary.collected_at:type_name -> google.protobuf.Timestamp
+	77, // 29: inventory.collector.v1.InventorySummary.stored_at:type_name -> google.protobuf.Timestamp
+	1,  // 30: inventory.collector.v1.InventorySummary.inventory:type_name -> inventory.collector.v1.Inventory
+	28, // 31: inventory.collector.v1.DiffInventoriesResponse.changes:type_name -> inventory.collector.v1.InventoryChange
+	78, // 32: inventory.collector.v1.GetLatestByHostnameRequest.read_mask:type_name -> google.protobuf.FieldMask
+	1,  // 33: inventory.collector.v1.GetLatestByHostnameResponse.inventory:type_name -> inventory.collector.v1.Inventory
+	77, // 34: inventory.collector.v1.GetLatestByHostnameResponse.stored_at:type_name -> google.protobuf.Timestamp
+	78, // 35: inventory.collector.v1.GetLatestBySystemUUIDRequest.read_mask:type_name -> google.protobuf.FieldMask
+	1,  // 36: inventory.collector.v1.GetLatestBySystemUUIDResponse.inventory:type_name -> inventory.collector.v1.Inventory
+	77, // 37: inventory.collector.v1.GetLatestBySystemUUIDResponse.stored_at:type_name -> google.protobuf.Timestamp
+	78, // 38: inventory.collector.v1.GetLatestBySerialRequest.read_mask:type_name -> google.protobuf.FieldMask
+	1,  // 39: inventory.collector.v1.GetLatestBySerialResponse.inventory:type_name -> inventory.collector.v1.Inventory
+	77, // 40: inventory.collector.v1.GetLatestBySerialResponse.stored_at:type_name -> google.protobuf.Timestamp
+	40, // 41: inventory.collector.v1.ListHostsResponse.hosts:type_name -> inventory.collector.v1.HostSummary
+	77, // 42: inventory.collector.v1.HostSummary.last_seen:type_name -> google.protobuf.Timestamp
+	41, // 43: inventory.collector.v1.HostSummary.directory:type_name -> inventory.collector.v1.HostDirectory
+	76, // 44: inventory.collector.v1.HostSummary.fields:type_name -> inventory.collector.v1.HostSummary.FieldsEntry
+	77, // 45: inventory.collector.v1.HostDirectory.updated_at:type_name -> google.protobuf.Timestamp
+	77, // 46: inventory.collector.v1.Alert.created_at:type_name -> google.protobuf.Timestamp
+	42, // 47: inventory.collector.v1.ListAlertsResponse.alerts:type_name -> inventory.collector.v1.Alert
+	48, // 48: inventory.collector.v1.GetDuplicateReportResponse.duplicates:type_name -> inventory.collector.v1.DuplicateGroup
+	51, // 49: inventory.collector.v1.GetCollectionReportResponse.modules:type_name -> inventory.collector.v1.ModuleCollectionStats
+	54, // 50: inventory.collector.v1.GetFleetReportResponse.models:type_name -> inventory.collector.v1.FleetCount
+	54, // 51: inventory.collector.v1.GetFleetReportResponse.memory:type_name -> inventory.collector.v1.FleetCount
+	54, // 52: inventory.collector.v1.GetFleetReportResponse.agent_versions:type_name -> inventory.collector.v1.FleetCount
+	0,  // 53: inventory.collector.v1.InventoryCommand.command_type:type_name -> inventory.collector.v1.InventoryCommandType
+	57, // 54: inventory.collector.v1.InventoryCommand.update:type_name -> inventory.collector.v1.AgentUpdate
+	77, // 55: inventory.collector.v1.InventorySubmission.collected_at:type_name -> google.protobuf.Timestamp
+	77, // 56: inventory.collector.v1.InventorySubmission.stored_at:type_name -> google.protobuf.Timestamp
+	28, // 57: inventory.collector.v1.InventorySubmission.changes:type_name -> inventory.collector.v1.InventoryChange
+	77, // 58: inventory.collector.v1.ConnectedAgent.connected_at:type_name -> google.protobuf.Timestamp
+	77, // 59: inventory.collector.v1.ConnectedAgent.last_submitted_at:type_name -> google.protobuf.Timestamp
+	70, // 60: inventory.collector.v1.ListConnectedAgentsResponse.agents:type_name -> inventory.collector.v1.ConnectedAgent
+	20, // 61: inventory.collector.v1.InventoryCollectorService.SubmitInventory:input_type -> inventory.collector.v1.SubmitInventoryRequest
+	22, // 62: inventory.collector.v1.InventoryCollectorService.GetInventory:input_type -> inventory.collector.v1.GetInventoryRequest
+	24, // 63: inventory.collector.v1.InventoryCollectorService.ListInventories:input_type -> inventory.collector.v1.ListInventoriesRequest
+	30, // 64: inventory.collector.v1.InventoryCollectorService.DeleteInventory:input_type -> inventory.collector.v1.DeleteInventoryRequest
+	32, // 65: inventory.collector.v1.InventoryCollectorService.GetLatestByHostname:input_type -> inventory.collector.v1.GetLatestByHostnameRequest
+	34, // 66: inventory.collector.v1.InventoryCollectorService.GetLatestBySystemUUID:input_type -> inventory.collector.v1.GetLatestBySystemUUIDRequest
+	36, // 67: inventory.collector.v1.InventoryCollectorService.GetLatestBySerial:input_type -> inventory.collector.v1.GetLatestBySerialRequest
+	27, // 68: inventory.collector.v1.InventoryCollectorService.DiffInventories:input_type -> inventory.collector.v1.DiffInventoriesRequest
+	38, // 69: inventory.collector.v1.InventoryCollectorService.ListHosts:input_type -> inventory.collector.v1.ListHostsRequest
+	43, // 70: inventory.collector.v1.InventoryCollectorService.ListAlerts:input_type -> inventory.collector.v1.ListAlertsRequest
+	45, // 71: inventory.collector.v1.InventoryCollectorService.AcknowledgeAlert:input_type -> inventory.collector.v1.AcknowledgeAlertRequest
+	47, // 72: inventory.collector.v1.InventoryCollectorService.GetDuplicateReport:input_type -> inventory.collector.v1.GetDuplicateReportRequest
+	50, // 73: inventory.collector.v1.InventoryCollectorService.GetCollectionReport:input_type -> inventory.collector.v1.GetCollectionReportRequest
+	53, // 74: inventory.collector.v1.InventoryCollectorService.GetFleetReport:input_type -> inventory.collector.v1.GetFleetReportRequest
+	60, // 75: inventory.collector.v1.InventoryCollectorService.StreamCommands:input_type -> inventory.collector.v1.StreamCommandsRequest
+	58, // 76: inventory.collector.v1.InventoryCollectorService.WatchInventories:input_type -> inventory.collector.v1.WatchInventoriesRequest
+	61, // 77: inventory.collector.v1.InventoryCollectorService.SubmitAgentLogs:input_type -> inventory.collector.v1.SubmitAgentLogsRequest
+	63, // 78: inventory.collector.v1.InventoryCollectorService.AckCommand:input_type -> inventory.collector.v1.AckCommandRequest
+	65, // 79: inventory.collector.v1.InventoryCollectorService.CheckAgent:input_type -> inventory.collector.v1.CheckAgentRequest
+	67, // 80: inventory.collector.v1.InventoryCollectorService.RefreshInventory:input_type -> inventory.collector.v1.RefreshInventoryRequest
+	69, // 81: inventory.collector.v1.InventoryCollectorService.ListConnectedAgents:input_type -> inventory.collector.v1.ListConnectedAgentsRequest
+	72, // 82: inventory.collector.v1.InventoryCollectorService.PauseAgent:input_type -> inventory.collector.v1.PauseAgentRequest
+	74, // 83: inventory.collector.v1.InventoryCollectorService.ResumeAgent:input_type -> inventory.collector.v1.ResumeAgentRequest
+	21, // 84: inventory.collector.v1.InventoryCollectorService.SubmitInventory:output_type -> inventory.collector.v1.SubmitInventoryResponse
+	23, // 85: inventory.collector.v1.InventoryCollectorService.GetInventory:output_type -> inventory.collector.v1.GetInventoryResponse
+	25, // 86: inventory.collector.v1.InventoryCollectorService.ListInventories:output_type -> inventory.collector.v1.ListInventoriesResponse
+	31, // 87: inventory.collector.v1.InventoryCollectorService.DeleteInventory:output_type -> inventory.collector.v1.DeleteInventoryResponse
+	33, // 88: inventory.collector.v1.InventoryCollectorService.GetLatestByHostname:output_type -> inventory.collector.v1.GetLatestByHostnameResponse
+	35, // 89: inventory.collector.v1.InventoryCollectorService.GetLatestBySystemUUID:output_type -> inventory.collector.v1.GetLatestBySystemUUIDResponse
+	37, // 90: inventory.collector.v1.InventoryCollectorService.GetLatestBySerial:output_type -> inventory.collector.v1.GetLatestBySerialResponse
+	29, // 91: inventory.collector.v1.InventoryCollectorService.DiffInventories:output_type -> inventory.collector.v1.DiffInventoriesResponse
+	39, // 92: inventory.collector.v1.InventoryCollectorService.ListHosts:output_type -> inventory.collector.v1.ListHostsResponse
+	44, // 93: inventory.collector.v1.InventoryCollectorService.ListAlerts:output_type -> inventory.collector.v1.ListAlertsResponse
+	46, // 94: inventory.collector.v1.InventoryCollectorService.AcknowledgeAlert:output_type -> inventory.collector.v1.AcknowledgeAlertResponse
+	49, // 95: inventory.collector.v1.InventoryCollectorService.GetDuplicateReport:output_type -> inventory.collector.v1.GetDuplicateReportResponse
+	52, // 96: inventory.collector.v1.InventoryCollectorService.GetCollectionReport:output_type -> inventory.collector.v1.GetCollectionReportResponse
+	55, // 97: inventory.collector.v1.InventoryCollectorService.GetFleetReport:output_type -> inventory.collector.v1.GetFleetReportResponse
+	56, // 98: inventory.collector.v1.InventoryCollectorService.StreamCommands:output_type -> inventory.collector.v1.InventoryCommand
+	59, // 99: inventory.collector.v1.InventoryCollectorService.WatchInventories:output_type -> inventory.collector.v1.InventorySubmission
+	62, // 100: inventory.collector.v1.InventoryCollectorService.SubmitAgentLogs:output_type -> inventory.collector.v1.SubmitAgentLogsResponse
+	64, // 101: inventory.collector.v1.InventoryCollectorService.AckCommand:output_type -> inventory.collector.v1.AckCommandResponse
+	66, // 102: inventory.collector.v1.InventoryCollectorService.CheckAgent:output_type -> inventory.collector.v1.CheckAgentResponse
+	68, // 103: inventory.collector.v1.InventoryCollectorService.RefreshInventory:output_type -> inventory.collector.v1.RefreshInventoryResponse
+	71, // 104: inventory.collector.v1.InventoryCollectorService.ListConnectedAgents:output_type -> inventory.collector.v1.ListConnectedAgentsResponse
+	73, // 105: inventory.collector.v1.InventoryCollectorService.PauseAgent:output_type -> inventory.collector.v1.PauseAgentResponse
+	75, // 106: inventory.collector.v1.InventoryCollectorService.ResumeAgent:output_type -> inventory.collector.v1.ResumeAgentResponse
+	84, // [84:107] is the sub-list for method output_type
+	61, // [61:84] is the sub-list for method input_type
+	61, // [61:61] is the sub-list for extension type_name
+	61, // [61:61] is the sub-list for extension extendee
+	0,  // [0:61] is the sub-list for field type_name
 }
 
 func init() { file_inventory_collector_v1_collector_proto_init() }
@@ -2613,7 +5918,7 @@ func file_inventory_collector_v1_collector_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_inventory_collector_v1_collector_proto_rawDesc), len(file_inventory_collector_v1_collector_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   33,
+			NumMessages:   76,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
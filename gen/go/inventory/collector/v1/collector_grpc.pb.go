@@ -19,14 +19,29 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	InventoryCollectorService_SubmitInventory_FullMethodName     = "/inventory.collector.v1.InventoryCollectorService/SubmitInventory"
-	InventoryCollectorService_GetInventory_FullMethodName        = "/inventory.collector.v1.InventoryCollectorService/GetInventory"
-	InventoryCollectorService_ListInventories_FullMethodName     = "/inventory.collector.v1.InventoryCollectorService/ListInventories"
-	InventoryCollectorService_DeleteInventory_FullMethodName     = "/inventory.collector.v1.InventoryCollectorService/DeleteInventory"
-	InventoryCollectorService_GetLatestByHostname_FullMethodName = "/inventory.collector.v1.InventoryCollectorService/GetLatestByHostname"
-	InventoryCollectorService_StreamCommands_FullMethodName      = "/inventory.collector.v1.InventoryCollectorService/StreamCommands"
-	InventoryCollectorService_RefreshInventory_FullMethodName    = "/inventory.collector.v1.InventoryCollectorService/RefreshInventory"
-	InventoryCollectorService_ListConnectedAgents_FullMethodName = "/inventory.collector.v1.InventoryCollectorService/ListConnectedAgents"
+	InventoryCollectorService_SubmitInventory_FullMethodName       = "/inventory.collector.v1.InventoryCollectorService/SubmitInventory"
+	InventoryCollectorService_GetInventory_FullMethodName          = "/inventory.collector.v1.InventoryCollectorService/GetInventory"
+	InventoryCollectorService_ListInventories_FullMethodName       = "/inventory.collector.v1.InventoryCollectorService/ListInventories"
+	InventoryCollectorService_DeleteInventory_FullMethodName       = "/inventory.collector.v1.InventoryCollectorService/DeleteInventory"
+	InventoryCollectorService_GetLatestByHostname_FullMethodName   = "/inventory.collector.v1.InventoryCollectorService/GetLatestByHostname"
+	InventoryCollectorService_GetLatestBySystemUUID_FullMethodName = "/inventory.collector.v1.InventoryCollectorService/GetLatestBySystemUUID"
+	InventoryCollectorService_GetLatestBySerial_FullMethodName     = "/inventory.collector.v1.InventoryCollectorService/GetLatestBySerial"
+	InventoryCollectorService_DiffInventories_FullMethodName       = "/inventory.collector.v1.InventoryCollectorService/DiffInventories"
+	InventoryCollectorService_ListHosts_FullMethodName             = "/inventory.collector.v1.InventoryCollectorService/ListHosts"
+	InventoryCollectorService_ListAlerts_FullMethodName            = "/inventory.collector.v1.InventoryCollectorService/ListAlerts"
+	InventoryCollectorService_AcknowledgeAlert_FullMethodName      = "/inventory.collector.v1.InventoryCollectorService/AcknowledgeAlert"
+	InventoryCollectorService_GetDuplicateReport_FullMethodName    = "/inventory.collector.v1.InventoryCollectorService/GetDuplicateReport"
+	InventoryCollectorService_GetCollectionReport_FullMethodName   = "/inventory.collector.v1.InventoryCollectorService/GetCollectionReport"
+	InventoryCollectorService_GetFleetReport_FullMethodName        = "/inventory.collector.v1.InventoryCollectorService/GetFleetReport"
+	InventoryCollectorService_StreamCommands_FullMethodName        = "/inventory.collector.v1.InventoryCollectorService/StreamCommands"
+	InventoryCollectorService_WatchInventories_FullMethodName      = "/inventory.collector.v1.InventoryCollectorService/WatchInventories"
+	InventoryCollectorService_SubmitAgentLogs_FullMethodName       = "/inventory.collector.v1.InventoryCollectorService/SubmitAgentLogs"
+	InventoryCollectorService_AckCommand_FullMethodName            = "/inventory.collector.v1.InventoryCollectorService/AckCommand"
+	InventoryCollectorService_CheckAgent_FullMethodName            = "/inventory.collector.v1.InventoryCollectorService/CheckAgent"
+	InventoryCollectorService_RefreshInventory_FullMethodName      = "/inventory.collector.v1.InventoryCollectorService/RefreshInventory"
+	InventoryCollectorService_ListConnectedAgents_FullMethodName   = "/inventory.collector.v1.InventoryCollectorService/ListConnectedAgents"
+	InventoryCollectorService_PauseAgent_FullMethodName            = "/inventory.collector.v1.InventoryCollectorService/PauseAgent"
+	InventoryCollectorService_ResumeAgent_FullMethodName           = "/inventory.collector.v1.InventoryCollectorService/ResumeAgent"
 )
 
 // InventoryCollectorServiceClient is the client API for InventoryCollectorService service.
@@ -45,12 +60,53 @@ type InventoryCollectorServiceClient interface {
 	DeleteInventory(ctx context.Context, in *DeleteInventoryRequest, opts ...grpc.CallOption) (*DeleteInventoryResponse, error)
 	// GetLatestByHostname returns the most recent inventory for a hostname.
 	GetLatestByHostname(ctx context.Context, in *GetLatestByHostnameRequest, opts ...grpc.CallOption) (*GetLatestByHostnameResponse, error)
+	// GetLatestBySystemUUID returns the most recent inventory for an SMBIOS system UUID.
+	GetLatestBySystemUUID(ctx context.Context, in *GetLatestBySystemUUIDRequest, opts ...grpc.CallOption) (*GetLatestBySystemUUIDResponse, error)
+	// GetLatestBySerial returns the most recent inventory for a system serial number.
+	GetLatestBySerial(ctx context.Context, in *GetLatestBySerialRequest, opts ...grpc.CallOption) (*GetLatestBySerialResponse, error)
+	// DiffInventories lists the hardware changes between two stored
+	// inventories. Without from_id, to_id is compared with the previous
+	// inventory of the same host.
+	DiffInventories(ctx context.Context, in *DiffInventoriesRequest, opts ...grpc.CallOption) (*DiffInventoriesResponse, error)
+	// ListHosts returns one entry per device with a pointer to its latest inventory.
+	ListHosts(ctx context.Context, in *ListHostsRequest, opts ...grpc.CallOption) (*ListHostsResponse, error)
+	// ListAlerts lists hardware change alerts raised on inventory submission.
+	ListAlerts(ctx context.Context, in *ListAlertsRequest, opts ...grpc.CallOption) (*ListAlertsResponse, error)
+	// AcknowledgeAlert marks a hardware change alert as acknowledged.
+	AcknowledgeAlert(ctx context.Context, in *AcknowledgeAlertRequest, opts ...grpc.CallOption) (*AcknowledgeAlertResponse, error)
+	// GetDuplicateReport lists system serials and UUIDs reported by more than one host.
+	GetDuplicateReport(ctx context.Context, in *GetDuplicateReportRequest, opts ...grpc.CallOption) (*GetDuplicateReportResponse, error)
+	// GetCollectionReport summarizes collection module durations and failures
+	// across the latest inventory of each host.
+	GetCollectionReport(ctx context.Context, in *GetCollectionReportRequest, opts ...grpc.CallOption) (*GetCollectionReportResponse, error)
+	// GetFleetReport breaks the fleet down by model, memory size and agent
+	// version, and counts the hosts whose agent is connected.
+	GetFleetReport(ctx context.Context, in *GetFleetReportRequest, opts ...grpc.CallOption) (*GetFleetReportResponse, error)
 	// StreamCommands opens a server-side stream that pushes commands to connected agents.
 	StreamCommands(ctx context.Context, in *StreamCommandsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[InventoryCommand], error)
+	// WatchInventories streams a summary of each inventory as it is stored,
+	// with its hardware changes from the host's previous inventory.
+	WatchInventories(ctx context.Context, in *WatchInventoriesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[InventorySubmission], error)
+	// SubmitAgentLogs answers a LOGS command with the agent's recent log
+	// output.
+	SubmitAgentLogs(ctx context.Context, in *SubmitAgentLogsRequest, opts ...grpc.CallOption) (*SubmitAgentLogsResponse, error)
+	// AckCommand reports that the agent carried out a command, or why it did
+	// not. Agents acknowledge every command except LOGS, which SubmitAgentLogs
+	// answers.
+	AckCommand(ctx context.Context, in *AckCommandRequest, opts ...grpc.CallOption) (*AckCommandResponse, error)
+	// CheckAgent verifies that an agent can reach the collector with its
+	// credentials, without submitting anything.
+	CheckAgent(ctx context.Context, in *CheckAgentRequest, opts ...grpc.CallOption) (*CheckAgentResponse, error)
 	// RefreshInventory sends a refresh command to a connected agent.
 	RefreshInventory(ctx context.Context, in *RefreshInventoryRequest, opts ...grpc.CallOption) (*RefreshInventoryResponse, error)
 	// ListConnectedAgents returns the currently connected agents.
 	ListConnectedAgents(ctx context.Context, in *ListConnectedAgentsRequest, opts ...grpc.CallOption) (*ListConnectedAgentsResponse, error)
+	// PauseAgent tells a connected agent to stop collecting and submitting
+	// inventories until it is resumed, e.g. during maintenance or imaging.
+	// The agent keeps the paused state across restarts.
+	PauseAgent(ctx context.Context, in *PauseAgentRequest, opts ...grpc.CallOption) (*PauseAgentResponse, error)
+	// ResumeAgent tells a paused agent to resume collection.
+	ResumeAgent(ctx context.Context, in *ResumeAgentRequest, opts ...grpc.CallOption) (*ResumeAgentResponse, error)
 }
 
 type inventoryCollectorServiceClient struct {
@@ -111,6 +167,96 @@ func (c *inventoryCollectorServiceClient) GetLatestByHostname(ctx context.Contex
 	return out, nil
 }
 
+func (c *inventoryCollectorServiceClient) GetLatestBySystemUUID(ctx context.Context, in *GetLatestBySystemUUIDRequest, opts ...grpc.CallOption) (*GetLatestBySystemUUIDResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLatestBySystemUUIDResponse)
+	err := c.cc.Invoke(ctx, InventoryCollectorService_GetLatestBySystemUUID_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryCollectorServiceClient) GetLatestBySerial(ctx context.Context, in *GetLatestBySerialRequest, opts ...grpc.CallOption) (*GetLatestBySerialResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLatestBySerialResponse)
+	err := c.cc.Invoke(ctx, InventoryCollectorService_GetLatestBySerial_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryCollectorServiceClient) DiffInventories(ctx context.Context, in *DiffInventoriesRequest, opts ...grpc.CallOption) (*DiffInventoriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DiffInventoriesResponse)
+	err := c.cc.Invoke(ctx, InventoryCollectorService_DiffInventories_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryCollectorServiceClient) ListHosts(ctx context.Context, in *ListHostsRequest, opts ...grpc.CallOption) (*ListHostsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListHostsResponse)
+	err := c.cc.Invoke(ctx, InventoryCollectorService_ListHosts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryCollectorServiceClient) ListAlerts(ctx context.Context, in *ListAlertsRequest, opts ...grpc.CallOption) (*ListAlertsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAlertsResponse)
+	err := c.cc.Invoke(ctx, InventoryCollectorService_ListAlerts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryCollectorServiceClient) AcknowledgeAlert(ctx context.Context, in *AcknowledgeAlertRequest, opts ...grpc.CallOption) (*AcknowledgeAlertResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AcknowledgeAlertResponse)
+	err := c.cc.Invoke(ctx, InventoryCollectorService_AcknowledgeAlert_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryCollectorServiceClient) GetDuplicateReport(ctx context.Context, in *GetDuplicateReportRequest, opts ...grpc.CallOption) (*GetDuplicateReportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDuplicateReportResponse)
+	err := c.cc.Invoke(ctx, InventoryCollectorService_GetDuplicateReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryCollectorServiceClient) GetCollectionReport(ctx context.Context, in *GetCollectionReportRequest, opts ...grpc.CallOption) (*GetCollectionReportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCollectionReportResponse)
+	err := c.cc.Invoke(ctx, InventoryCollectorService_GetCollectionReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryCollectorServiceClient) GetFleetReport(ctx context.Context, in *GetFleetReportRequest, opts ...grpc.CallOption) (*GetFleetReportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetFleetReportResponse)
+	err := c.cc.Invoke(ctx, InventoryCollectorService_GetFleetReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *inventoryCollectorServiceClient) StreamCommands(ctx context.Context, in *StreamCommandsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[InventoryCommand], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	stream, err := c.cc.NewStream(ctx, &InventoryCollectorService_ServiceDesc.Streams[0], InventoryCollectorService_StreamCommands_FullMethodName, cOpts...)
@@ -130,6 +276,55 @@ func (c *inventoryCollectorServiceClient) StreamCommands(ctx context.Context, in
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type InventoryCollectorService_StreamCommandsClient = grpc.ServerStreamingClient[InventoryCommand]
 
+func (c *inventoryCollectorServiceClient) WatchInventories(ctx context.Context, in *WatchInventoriesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[InventorySubmission], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &InventoryCollectorService_ServiceDesc.Streams[1], InventoryCollectorService_WatchInventories_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchInventoriesRequest, InventorySubmission]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type InventoryCollectorService_WatchInventoriesClient = grpc.ServerStreamingClient[InventorySubmission]
+
+func (c *inventoryCollectorServiceClient) SubmitAgentLogs(ctx context.Context, in *SubmitAgentLogsRequest, opts ...grpc.CallOption) (*SubmitAgentLogsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubmitAgentLogsResponse)
+	err := c.cc.Invoke(ctx, InventoryCollectorService_SubmitAgentLogs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryCollectorServiceClient) AckCommand(ctx context.Context, in *AckCommandRequest, opts ...grpc.CallOption) (*AckCommandResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AckCommandResponse)
+	err := c.cc.Invoke(ctx, InventoryCollectorService_AckCommand_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryCollectorServiceClient) CheckAgent(ctx context.Context, in *CheckAgentRequest, opts ...grpc.CallOption) (*CheckAgentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckAgentResponse)
+	err := c.cc.Invoke(ctx, InventoryCollectorService_CheckAgent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *inventoryCollectorServiceClient) RefreshInventory(ctx context.Context, in *RefreshInventoryRequest, opts ...grpc.CallOption) (*RefreshInventoryResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(RefreshInventoryResponse)
@@ -150,6 +345,26 @@ func (c *inventoryCollectorServiceClient) ListConnectedAgents(ctx context.Contex
 	return out, nil
 }
 
+func (c *inventoryCollectorServiceClient) PauseAgent(ctx context.Context, in *PauseAgentRequest, opts ...grpc.CallOption) (*PauseAgentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PauseAgentResponse)
+	err := c.cc.Invoke(ctx, InventoryCollectorService_PauseAgent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryCollectorServiceClient) ResumeAgent(ctx context.Context, in *ResumeAgentRequest, opts ...grpc.CallOption) (*ResumeAgentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResumeAgentResponse)
+	err := c.cc.Invoke(ctx, InventoryCollectorService_ResumeAgent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // InventoryCollectorServiceServer is the server API for InventoryCollectorService service.
 // All implementations must embed UnimplementedInventoryCollectorServiceServer
 // for forward compatibility.
@@ -166,12 +381,53 @@ type InventoryCollectorServiceServer interface {
 	DeleteInventory(context.Context, *DeleteInventoryRequest) (*DeleteInventoryResponse, error)
 	// GetLatestByHostname returns the most recent inventory for a hostname.
 	GetLatestByHostname(context.Context, *GetLatestByHostnameRequest) (*GetLatestByHostnameResponse, error)
+	// GetLatestBySystemUUID returns the most recent inventory for an SMBIOS system UUID.
+	GetLatestBySystemUUID(context.Context, *GetLatestBySystemUUIDRequest) (*GetLatestBySystemUUIDResponse, error)
+	// GetLatestBySerial returns the most recent inventory for a system serial number.
+	GetLatestBySerial(context.Context, *GetLatestBySerialRequest) (*GetLatestBySerialResponse, error)
+	// DiffInventories lists the hardware changes between two stored
+	// inventories. Without from_id, to_id is compared with the previous
+	// inventory of the same host.
+	DiffInventories(context.Context, *DiffInventoriesRequest) (*DiffInventoriesResponse, error)
+	// ListHosts returns one entry per device with a pointer to its latest inventory.
+	ListHosts(context.Context, *ListHostsRequest) (*ListHostsResponse, error)
+	// ListAlerts lists hardware change alerts raised on inventory submission.
+	ListAlerts(context.Context, *ListAlertsRequest) (*ListAlertsResponse, error)
+	// AcknowledgeAlert marks a hardware change alert as acknowledged.
+	AcknowledgeAlert(context.Context, *AcknowledgeAlertRequest) (*AcknowledgeAlertResponse, error)
+	// GetDuplicateReport lists system serials and UUIDs reported by more than one host.
+	GetDuplicateReport(context.Context, *GetDuplicateReportRequest) (*GetDuplicateReportResponse, error)
+	// GetCollectionReport summarizes collection module durations and failures
+	// across the latest inventory of each host.
+	GetCollectionReport(context.Context, *GetCollectionReportRequest) (*GetCollectionReportResponse, error)
+	// GetFleetReport breaks the fleet down by model, memory size and agent
+	// version, and counts the hosts whose agent is connected.
+	GetFleetReport(context.Context, *GetFleetReportRequest) (*GetFleetReportResponse, error)
 	// StreamCommands opens a server-side stream that pushes commands to connected agents.
 	StreamCommands(*StreamCommandsRequest, grpc.ServerStreamingServer[InventoryCommand]) error
+	// WatchInventories streams a summary of each inventory as it is stored,
+	// with its hardware changes from the host's previous inventory.
+	WatchInventories(*WatchInventoriesRequest, grpc.ServerStreamingServer[InventorySubmission]) error
+	// SubmitAgentLogs answers a LOGS command with the agent's recent log
+	// output.
+	SubmitAgentLogs(context.Context, *SubmitAgentLogsRequest) (*SubmitAgentLogsResponse, error)
+	// AckCommand reports that the agent carried out a command, or why it did
+	// not. Agents acknowledge every command except LOGS, which SubmitAgentLogs
+	// answers.
+	AckCommand(context.Context, *AckCommandRequest) (*AckCommandResponse, error)
+	// CheckAgent verifies that an agent can reach the collector with its
+	// credentials, without submitting anything.
+	CheckAgent(context.Context, *CheckAgentRequest) (*CheckAgentResponse, error)
 	// RefreshInventory sends a refresh command to a connected agent.
 	RefreshInventory(context.Context, *RefreshInventoryRequest) (*RefreshInventoryResponse, error)
 	// ListConnectedAgents returns the currently connected agents.
 	ListConnectedAgents(context.Context, *ListConnectedAgentsRequest) (*ListConnectedAgentsResponse, error)
+	// PauseAgent tells a connected agent to stop collecting and submitting
+	// inventories until it is resumed, e.g. during maintenance or imaging.
+	// The agent keeps the paused state across restarts.
+	PauseAgent(context.Context, *PauseAgentRequest) (*PauseAgentResponse, error)
+	// ResumeAgent tells a paused agent to resume collection.
+	ResumeAgent(context.Context, *ResumeAgentRequest) (*ResumeAgentResponse, error)
 	mustEmbedUnimplementedInventoryCollectorServiceServer()
 }
 
@@ -197,15 +453,60 @@ func (UnimplementedInventoryCollectorServiceServer) DeleteInventory(context.Cont
 func (UnimplementedInventoryCollectorServiceServer) GetLatestByHostname(context.Context, *GetLatestByHostnameRequest) (*GetLatestByHostnameResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetLatestByHostname not implemented")
 }
+func (UnimplementedInventoryCollectorServiceServer) GetLatestBySystemUUID(context.Context, *GetLatestBySystemUUIDRequest) (*GetLatestBySystemUUIDResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetLatestBySystemUUID not implemented")
+}
+func (UnimplementedInventoryCollectorServiceServer) GetLatestBySerial(context.Context, *GetLatestBySerialRequest) (*GetLatestBySerialResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetLatestBySerial not implemented")
+}
+func (UnimplementedInventoryCollectorServiceServer) DiffInventories(context.Context, *DiffInventoriesRequest) (*DiffInventoriesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DiffInventories not implemented")
+}
+func (UnimplementedInventoryCollectorServiceServer) ListHosts(context.Context, *ListHostsRequest) (*ListHostsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListHosts not implemented")
+}
+func (UnimplementedInventoryCollectorServiceServer) ListAlerts(context.Context, *ListAlertsRequest) (*ListAlertsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListAlerts not implemented")
+}
+func (UnimplementedInventoryCollectorServiceServer) AcknowledgeAlert(context.Context, *AcknowledgeAlertRequest) (*AcknowledgeAlertResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AcknowledgeAlert not implemented")
+}
+func (UnimplementedInventoryCollectorServiceServer) GetDuplicateReport(context.Context, *GetDuplicateReportRequest) (*GetDuplicateReportResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetDuplicateReport not implemented")
+}
+func (UnimplementedInventoryCollectorServiceServer) GetCollectionReport(context.Context, *GetCollectionReportRequest) (*GetCollectionReportResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetCollectionReport not implemented")
+}
+func (UnimplementedInventoryCollectorServiceServer) GetFleetReport(context.Context, *GetFleetReportRequest) (*GetFleetReportResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetFleetReport not implemented")
+}
 func (UnimplementedInventoryCollectorServiceServer) StreamCommands(*StreamCommandsRequest, grpc.ServerStreamingServer[InventoryCommand]) error {
 	return status.Error(codes.Unimplemented, "method StreamCommands not implemented")
 }
+func (UnimplementedInventoryCollectorServiceServer) WatchInventories(*WatchInventoriesRequest, grpc.ServerStreamingServer[InventorySubmission]) error {
+	return status.Error(codes.Unimplemented, "method WatchInventories not implemented")
+}
+func (UnimplementedInventoryCollectorServiceServer) SubmitAgentLogs(context.Context, *SubmitAgentLogsRequest) (*SubmitAgentLogsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SubmitAgentLogs not implemented")
+}
+func (UnimplementedInventoryCollectorServiceServer) AckCommand(context.Context, *AckCommandRequest) (*AckCommandResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AckCommand not implemented")
+}
+func (UnimplementedInventoryCollectorServiceServer) CheckAgent(context.Context, *CheckAgentRequest) (*CheckAgentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CheckAgent not implemented")
+}
 func (UnimplementedInventoryCollectorServiceServer) RefreshInventory(context.Context, *RefreshInventoryRequest) (*RefreshInventoryResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method RefreshInventory not implemented")
 }
 func (UnimplementedInventoryCollectorServiceServer) ListConnectedAgents(context.Context, *ListConnectedAgentsRequest) (*ListConnectedAgentsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListConnectedAgents not implemented")
 }
+func (UnimplementedInventoryCollectorServiceServer) PauseAgent(context.Context, *PauseAgentRequest) (*PauseAgentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PauseAgent not implemented")
+}
+func (UnimplementedInventoryCollectorServiceServer) ResumeAgent(context.Context, *ResumeAgentRequest) (*ResumeAgentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ResumeAgent not implemented")
+}
 func (UnimplementedInventoryCollectorServiceServer) mustEmbedUnimplementedInventoryCollectorServiceServer() {
 }
 func (UnimplementedInventoryCollectorServiceServer) testEmbeddedByValue() {}
@@ -318,6 +619,168 @@ func _InventoryCollectorService_GetLatestByHostname_Handler(srv interface{}, ctx
 	return interceptor(ctx, in, info, handler)
 }
 
+func _InventoryCollectorService_GetLatestBySystemUUID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLatestBySystemUUIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryCollectorServiceServer).GetLatestBySystemUUID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryCollectorService_GetLatestBySystemUUID_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryCollectorServiceServer).GetLatestBySystemUUID(ctx, req.(*GetLatestBySystemUUIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryCollectorService_GetLatestBySerial_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLatestBySerialRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryCollectorServiceServer).GetLatestBySerial(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryCollectorService_GetLatestBySerial_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryCollectorServiceServer).GetLatestBySerial(ctx, req.(*GetLatestBySerialRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryCollectorService_DiffInventories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffInventoriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryCollectorServiceServer).DiffInventories(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryCollectorService_DiffInventories_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryCollectorServiceServer).DiffInventories(ctx, req.(*DiffInventoriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryCollectorService_ListHosts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListHostsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryCollectorServiceServer).ListHosts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryCollectorService_ListHosts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryCollectorServiceServer).ListHosts(ctx, req.(*ListHostsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryCollectorService_ListAlerts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAlertsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryCollectorServiceServer).ListAlerts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryCollectorService_ListAlerts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryCollectorServiceServer).ListAlerts(ctx, req.(*ListAlertsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryCollectorService_AcknowledgeAlert_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcknowledgeAlertRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryCollectorServiceServer).AcknowledgeAlert(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryCollectorService_AcknowledgeAlert_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryCollectorServiceServer).AcknowledgeAlert(ctx, req.(*AcknowledgeAlertRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryCollectorService_GetDuplicateReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDuplicateReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryCollectorServiceServer).GetDuplicateReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryCollectorService_GetDuplicateReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryCollectorServiceServer).GetDuplicateReport(ctx, req.(*GetDuplicateReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryCollectorService_GetCollectionReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCollectionReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryCollectorServiceServer).GetCollectionReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryCollectorService_GetCollectionReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryCollectorServiceServer).GetCollectionReport(ctx, req.(*GetCollectionReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryCollectorService_GetFleetReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFleetReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryCollectorServiceServer).GetFleetReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryCollectorService_GetFleetReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryCollectorServiceServer).GetFleetReport(ctx, req.(*GetFleetReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _InventoryCollectorService_StreamCommands_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(StreamCommandsRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -329,6 +792,71 @@ func _InventoryCollectorService_StreamCommands_Handler(srv interface{}, stream g
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type InventoryCollectorService_StreamCommandsServer = grpc.ServerStreamingServer[InventoryCommand]
 
+func _InventoryCollectorService_WatchInventories_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchInventoriesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(InventoryCollectorServiceServer).WatchInventories(m, &grpc.GenericServerStream[WatchInventoriesRequest, InventorySubmission]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type InventoryCollectorService_WatchInventoriesServer = grpc.ServerStreamingServer[InventorySubmission]
+
+func _InventoryCollectorService_SubmitAgentLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitAgentLogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryCollectorServiceServer).SubmitAgentLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryCollectorService_SubmitAgentLogs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryCollectorServiceServer).SubmitAgentLogs(ctx, req.(*SubmitAgentLogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryCollectorService_AckCommand_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AckCommandRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryCollectorServiceServer).AckCommand(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryCollectorService_AckCommand_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryCollectorServiceServer).AckCommand(ctx, req.(*AckCommandRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryCollectorService_CheckAgent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckAgentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryCollectorServiceServer).CheckAgent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryCollectorService_CheckAgent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryCollectorServiceServer).CheckAgent(ctx, req.(*CheckAgentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _InventoryCollectorService_RefreshInventory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(RefreshInventoryRequest)
 	if err := dec(in); err != nil {
@@ -365,6 +893,42 @@ func _InventoryCollectorService_ListConnectedAgents_Handler(srv interface{}, ctx
 	return interceptor(ctx, in, info, handler)
 }
 
+func _InventoryCollectorService_PauseAgent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseAgentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryCollectorServiceServer).PauseAgent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryCollectorService_PauseAgent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryCollectorServiceServer).PauseAgent(ctx, req.(*PauseAgentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryCollectorService_ResumeAgent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeAgentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryCollectorServiceServer).ResumeAgent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryCollectorService_ResumeAgent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryCollectorServiceServer).ResumeAgent(ctx, req.(*ResumeAgentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // InventoryCollectorService_ServiceDesc is the grpc.ServiceDesc for InventoryCollectorService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -392,6 +956,54 @@ var InventoryCollectorService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetLatestByHostname",
 			Handler:    _InventoryCollectorService_GetLatestByHostname_Handler,
 		},
+		{
+			MethodName: "GetLatestBySystemUUID",
+			Handler:    _InventoryCollectorService_GetLatestBySystemUUID_Handler,
+		},
+		{
+			MethodName: "GetLatestBySerial",
+			Handler:    _InventoryCollectorService_GetLatestBySerial_Handler,
+		},
+		{
+			MethodName: "DiffInventories",
+			Handler:    _InventoryCollectorService_DiffInventories_Handler,
+		},
+		{
+			MethodName: "ListHosts",
+			Handler:    _InventoryCollectorService_ListHosts_Handler,
+		},
+		{
+			MethodName: "ListAlerts",
+			Handler:    _InventoryCollectorService_ListAlerts_Handler,
+		},
+		{
+			MethodName: "AcknowledgeAlert",
+			Handler:    _InventoryCollectorService_AcknowledgeAlert_Handler,
+		},
+		{
+			MethodName: "GetDuplicateReport",
+			Handler:    _InventoryCollectorService_GetDuplicateReport_Handler,
+		},
+		{
+			MethodName: "GetCollectionReport",
+			Handler:    _InventoryCollectorService_GetCollectionReport_Handler,
+		},
+		{
+			MethodName: "GetFleetReport",
+			Handler:    _InventoryCollectorService_GetFleetReport_Handler,
+		},
+		{
+			MethodName: "SubmitAgentLogs",
+			Handler:    _InventoryCollectorService_SubmitAgentLogs_Handler,
+		},
+		{
+			MethodName: "AckCommand",
+			Handler:    _InventoryCollectorService_AckCommand_Handler,
+		},
+		{
+			MethodName: "CheckAgent",
+			Handler:    _InventoryCollectorService_CheckAgent_Handler,
+		},
 		{
 			MethodName: "RefreshInventory",
 			Handler:    _InventoryCollectorService_RefreshInventory_Handler,
@@ -400,6 +1012,14 @@ var InventoryCollectorService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListConnectedAgents",
 			Handler:    _InventoryCollectorService_ListConnectedAgents_Handler,
 		},
+		{
+			MethodName: "PauseAgent",
+			Handler:    _InventoryCollectorService_PauseAgent_Handler,
+		},
+		{
+			MethodName: "ResumeAgent",
+			Handler:    _InventoryCollectorService_ResumeAgent_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -407,6 +1027,11 @@ var InventoryCollectorService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _InventoryCollectorService_StreamCommands_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "WatchInventories",
+			Handler:       _InventoryCollectorService_WatchInventories_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "inventory/collector/v1/collector.proto",
 }
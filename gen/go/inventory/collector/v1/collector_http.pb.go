@@ -19,27 +19,66 @@ var _ = binding.EncodeURL
 
 const _ = http.SupportPackageIsVersion1
 
+const OperationInventoryCollectorServiceAcknowledgeAlert = "/inventory.collector.v1.InventoryCollectorService/AcknowledgeAlert"
 const OperationInventoryCollectorServiceDeleteInventory = "/inventory.collector.v1.InventoryCollectorService/DeleteInventory"
+const OperationInventoryCollectorServiceDiffInventories = "/inventory.collector.v1.InventoryCollectorService/DiffInventories"
+const OperationInventoryCollectorServiceGetCollectionReport = "/inventory.collector.v1.InventoryCollectorService/GetCollectionReport"
+const OperationInventoryCollectorServiceGetDuplicateReport = "/inventory.collector.v1.InventoryCollectorService/GetDuplicateReport"
+const OperationInventoryCollectorServiceGetFleetReport = "/inventory.collector.v1.InventoryCollectorService/GetFleetReport"
 const OperationInventoryCollectorServiceGetInventory = "/inventory.collector.v1.InventoryCollectorService/GetInventory"
 const OperationInventoryCollectorServiceGetLatestByHostname = "/inventory.collector.v1.InventoryCollectorService/GetLatestByHostname"
+const OperationInventoryCollectorServiceGetLatestBySerial = "/inventory.collector.v1.InventoryCollectorService/GetLatestBySerial"
+const OperationInventoryCollectorServiceGetLatestBySystemUUID = "/inventory.collector.v1.InventoryCollectorService/GetLatestBySystemUUID"
+const OperationInventoryCollectorServiceListAlerts = "/inventory.collector.v1.InventoryCollectorService/ListAlerts"
 const OperationInventoryCollectorServiceListConnectedAgents = "/inventory.collector.v1.InventoryCollectorService/ListConnectedAgents"
+const OperationInventoryCollectorServiceListHosts = "/inventory.collector.v1.InventoryCollectorService/ListHosts"
 const OperationInventoryCollectorServiceListInventories = "/inventory.collector.v1.InventoryCollectorService/ListInventories"
+const OperationInventoryCollectorServicePauseAgent = "/inventory.collector.v1.InventoryCollectorService/PauseAgent"
 const OperationInventoryCollectorServiceRefreshInventory = "/inventory.collector.v1.InventoryCollectorService/RefreshInventory"
+const OperationInventoryCollectorServiceResumeAgent = "/inventory.collector.v1.InventoryCollectorService/ResumeAgent"
 const OperationInventoryCollectorServiceSubmitInventory = "/inventory.collector.v1.InventoryCollectorService/SubmitInventory"
 
 type InventoryCollectorServiceHTTPServer interface {
+	// AcknowledgeAlert AcknowledgeAlert marks a hardware change alert as acknowledged.
+	AcknowledgeAlert(context.Context, *AcknowledgeAlertRequest) (*AcknowledgeAlertResponse, error)
 	// DeleteInventory DeleteInventory removes a stored inventory by ID.
 	DeleteInventory(context.Context, *DeleteInventoryRequest) (*DeleteInventoryResponse, error)
+	// DiffInventories DiffInventories lists the hardware changes between two stored
+	// inventories. Without from_id, to_id is compared with the previous
+	// inventory of the same host.
+	DiffInventories(context.Context, *DiffInventoriesRequest) (*DiffInventoriesResponse, error)
+	// GetCollectionReport GetCollectionReport summarizes collection module durations and failures
+	// across the latest inventory of each host.
+	GetCollectionReport(context.Context, *GetCollectionReportRequest) (*GetCollectionReportResponse, error)
+	// GetDuplicateReport GetDuplicateReport lists system serials and UUIDs reported by more than one host.
+	GetDuplicateReport(context.Context, *GetDuplicateReportRequest) (*GetDuplicateReportResponse, error)
+	// GetFleetReport GetFleetReport breaks the fleet down by model, memory size and agent
+	// version, and counts the hosts whose agent is connected.
+	GetFleetReport(context.Context, *GetFleetReportRequest) (*GetFleetReportResponse, error)
 	// GetInventory GetInventory retrieves a stored inventory by ID.
 	GetInventory(context.Context, *GetInventoryRequest) (*GetInventoryResponse, error)
 	// GetLatestByHostname GetLatestByHostname returns the most recent inventory for a hostname.
 	GetLatestByHostname(context.Context, *GetLatestByHostnameRequest) (*GetLatestByHostnameResponse, error)
+	// GetLatestBySerial GetLatestBySerial returns the most recent inventory for a system serial number.
+	GetLatestBySerial(context.Context, *GetLatestBySerialRequest) (*GetLatestBySerialResponse, error)
+	// GetLatestBySystemUUID GetLatestBySystemUUID returns the most recent inventory for an SMBIOS system UUID.
+	GetLatestBySystemUUID(context.Context, *GetLatestBySystemUUIDRequest) (*GetLatestBySystemUUIDResponse, error)
+	// ListAlerts ListAlerts lists hardware change alerts raised on inventory submission.
+	ListAlerts(context.Context, *ListAlertsRequest) (*ListAlertsResponse, error)
 	// ListConnectedAgents ListConnectedAgents returns the currently connected agents.
 	ListConnectedAgents(context.Context, *ListConnectedAgentsRequest) (*ListConnectedAgentsResponse, error)
+	// ListHosts ListHosts returns one entry per device with a pointer to its latest inventory.
+	ListHosts(context.Context, *ListHostsRequest) (*ListHostsResponse, error)
 	// ListInventories ListInventories lists stored inventories with optional filters.
 	ListInventories(context.Context, *ListInventoriesRequest) (*ListInventoriesResponse, error)
+	// PauseAgent PauseAgent tells a connected agent to stop collecting and submitting
+	// inventories until it is resumed, e.g. during maintenance or imaging.
+	// The agent keeps the paused state across restarts.
+	PauseAgent(context.Context, *PauseAgentRequest) (*PauseAgentResponse, error)
 	// RefreshInventory RefreshInventory sends a refresh command to a connected agent.
 	RefreshInventory(context.Context, *RefreshInventoryRequest) (*RefreshInventoryResponse, error)
+	// ResumeAgent ResumeAgent tells a paused agent to resume collection.
+	ResumeAgent(context.Context, *ResumeAgentRequest) (*ResumeAgentResponse, error)
 	// SubmitInventory SubmitInventory receives inventory from a client and stores it.
 	SubmitInventory(context.Context, *SubmitInventoryRequest) (*SubmitInventoryResponse, error)
 }
@@ -51,8 +90,19 @@ func RegisterInventoryCollectorServiceHTTPServer(s *http.Server, srv InventoryCo
 	r.GET("/v1/inventories", _InventoryCollectorService_ListInventories0_HTTP_Handler(srv))
 	r.DELETE("/v1/inventories/{id}", _InventoryCollectorService_DeleteInventory0_HTTP_Handler(srv))
 	r.GET("/v1/inventories/latest/{hostname}", _InventoryCollectorService_GetLatestByHostname0_HTTP_Handler(srv))
+	r.GET("/v1/inventories/latest/by-uuid/{system_uuid}", _InventoryCollectorService_GetLatestBySystemUUID0_HTTP_Handler(srv))
+	r.GET("/v1/inventories/latest/by-serial/{serial_number}", _InventoryCollectorService_GetLatestBySerial0_HTTP_Handler(srv))
+	r.GET("/v1/inventories/{to_id}/diff", _InventoryCollectorService_DiffInventories0_HTTP_Handler(srv))
+	r.GET("/v1/hosts", _InventoryCollectorService_ListHosts0_HTTP_Handler(srv))
+	r.GET("/v1/alerts", _InventoryCollectorService_ListAlerts0_HTTP_Handler(srv))
+	r.POST("/v1/alerts/{id}/ack", _InventoryCollectorService_AcknowledgeAlert0_HTTP_Handler(srv))
+	r.GET("/v1/reports/duplicates", _InventoryCollectorService_GetDuplicateReport0_HTTP_Handler(srv))
+	r.GET("/v1/reports/collection", _InventoryCollectorService_GetCollectionReport0_HTTP_Handler(srv))
+	r.GET("/v1/reports/fleet", _InventoryCollectorService_GetFleetReport0_HTTP_Handler(srv))
 	r.POST("/v1/inventories/refresh", _InventoryCollectorService_RefreshInventory0_HTTP_Handler(srv))
 	r.GET("/v1/agents", _InventoryCollectorService_ListConnectedAgents0_HTTP_Handler(srv))
+	r.POST("/v1/agents/pause", _InventoryCollectorService_PauseAgent0_HTTP_Handler(srv))
+	r.POST("/v1/agents/resume", _InventoryCollectorService_ResumeAgent0_HTTP_Handler(srv))
 }
 
 func _InventoryCollectorService_SubmitInventory0_HTTP_Handler(srv InventoryCollectorServiceHTTPServer) func(ctx http.Context) error {
@@ -162,6 +212,192 @@ func _InventoryCollectorService_GetLatestByHostname0_HTTP_Handler(srv InventoryC
 	}
 }
 
+func _InventoryCollectorService_GetLatestBySystemUUID0_HTTP_Handler(srv InventoryCollectorServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in GetLatestBySystemUUIDRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationInventoryCollectorServiceGetLatestBySystemUUID)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.GetLatestBySystemUUID(ctx, req.(*GetLatestBySystemUUIDRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*GetLatestBySystemUUIDResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _InventoryCollectorService_GetLatestBySerial0_HTTP_Handler(srv InventoryCollectorServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in GetLatestBySerialRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationInventoryCollectorServiceGetLatestBySerial)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.GetLatestBySerial(ctx, req.(*GetLatestBySerialRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*GetLatestBySerialResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _InventoryCollectorService_DiffInventories0_HTTP_Handler(srv InventoryCollectorServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in DiffInventoriesRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationInventoryCollectorServiceDiffInventories)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.DiffInventories(ctx, req.(*DiffInventoriesRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*DiffInventoriesResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _InventoryCollectorService_ListHosts0_HTTP_Handler(srv InventoryCollectorServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ListHostsRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationInventoryCollectorServiceListHosts)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ListHosts(ctx, req.(*ListHostsRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ListHostsResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _InventoryCollectorService_ListAlerts0_HTTP_Handler(srv InventoryCollectorServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ListAlertsRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationInventoryCollectorServiceListAlerts)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ListAlerts(ctx, req.(*ListAlertsRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ListAlertsResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _InventoryCollectorService_AcknowledgeAlert0_HTTP_Handler(srv InventoryCollectorServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in AcknowledgeAlertRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationInventoryCollectorServiceAcknowledgeAlert)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.AcknowledgeAlert(ctx, req.(*AcknowledgeAlertRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*AcknowledgeAlertResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _InventoryCollectorService_GetDuplicateReport0_HTTP_Handler(srv InventoryCollectorServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in GetDuplicateReportRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationInventoryCollectorServiceGetDuplicateReport)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.GetDuplicateReport(ctx, req.(*GetDuplicateReportRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*GetDuplicateReportResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _InventoryCollectorService_GetCollectionReport0_HTTP_Handler(srv InventoryCollectorServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in GetCollectionReportRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationInventoryCollectorServiceGetCollectionReport)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.GetCollectionReport(ctx, req.(*GetCollectionReportRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*GetCollectionReportResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _InventoryCollectorService_GetFleetReport0_HTTP_Handler(srv InventoryCollectorServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in GetFleetReportRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationInventoryCollectorServiceGetFleetReport)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.GetFleetReport(ctx, req.(*GetFleetReportRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*GetFleetReportResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
 func _InventoryCollectorService_RefreshInventory0_HTTP_Handler(srv InventoryCollectorServiceHTTPServer) func(ctx http.Context) error {
 	return func(ctx http.Context) error {
 		var in RefreshInventoryRequest
@@ -203,19 +439,91 @@ func _InventoryCollectorService_ListConnectedAgents0_HTTP_Handler(srv InventoryC
 	}
 }
 
+func _InventoryCollectorService_PauseAgent0_HTTP_Handler(srv InventoryCollectorServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in PauseAgentRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationInventoryCollectorServicePauseAgent)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.PauseAgent(ctx, req.(*PauseAgentRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*PauseAgentResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _InventoryCollectorService_ResumeAgent0_HTTP_Handler(srv InventoryCollectorServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in ResumeAgentRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationInventoryCollectorServiceResumeAgent)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ResumeAgent(ctx, req.(*ResumeAgentRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*ResumeAgentResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
 type InventoryCollectorServiceHTTPClient interface {
+	// AcknowledgeAlert AcknowledgeAlert marks a hardware change alert as acknowledged.
+	AcknowledgeAlert(ctx context.Context, req *AcknowledgeAlertRequest, opts ...http.CallOption) (rsp *AcknowledgeAlertResponse, err error)
 	// DeleteInventory DeleteInventory removes a stored inventory by ID.
 	DeleteInventory(ctx context.Context, req *DeleteInventoryRequest, opts ...http.CallOption) (rsp *DeleteInventoryResponse, err error)
+	// DiffInventories DiffInventories lists the hardware changes between two stored
+	// inventories. Without from_id, to_id is compared with the previous
+	// inventory of the same host.
+	DiffInventories(ctx context.Context, req *DiffInventoriesRequest, opts ...http.CallOption) (rsp *DiffInventoriesResponse, err error)
+	// GetCollectionReport GetCollectionReport summarizes collection module durations and failures
+	// across the latest inventory of each host.
+	GetCollectionReport(ctx context.Context, req *GetCollectionReportRequest, opts ...http.CallOption) (rsp *GetCollectionReportResponse, err error)
+	// GetDuplicateReport GetDuplicateReport lists system serials and UUIDs reported by more than one host.
+	GetDuplicateReport(ctx context.Context, req *GetDuplicateReportRequest, opts ...http.CallOption) (rsp *GetDuplicateReportResponse, err error)
+	// GetFleetReport GetFleetReport breaks the fleet down by model, memory size and agent
+	// version, and counts the hosts whose agent is connected.
+	GetFleetReport(ctx context.Context, req *GetFleetReportRequest, opts ...http.CallOption) (rsp *GetFleetReportResponse, err error)
 	// GetInventory GetInventory retrieves a stored inventory by ID.
 	GetInventory(ctx context.Context, req *GetInventoryRequest, opts ...http.CallOption) (rsp *GetInventoryResponse, err error)
 	// GetLatestByHostname GetLatestByHostname returns the most recent inventory for a hostname.
 	GetLatestByHostname(ctx context.Context, req *GetLatestByHostnameRequest, opts ...http.CallOption) (rsp *GetLatestByHostnameResponse, err error)
+	// GetLatestBySerial GetLatestBySerial returns the most recent inventory for a system serial number.
+	GetLatestBySerial(ctx context.Context, req *GetLatestBySerialRequest, opts ...http.CallOption) (rsp *GetLatestBySerialResponse, err error)
+	// GetLatestBySystemUUID GetLatestBySystemUUID returns the most recent inventory for an SMBIOS system UUID.
+	GetLatestBySystemUUID(ctx context.Context, req *GetLatestBySystemUUIDRequest, opts ...http.CallOption) (rsp *GetLatestBySystemUUIDResponse, err error)
+	// ListAlerts ListAlerts lists hardware change alerts raised on inventory submission.
+	ListAlerts(ctx context.Context, req *ListAlertsRequest, opts ...http.CallOption) (rsp *ListAlertsResponse, err error)
 	// ListConnectedAgents ListConnectedAgents returns the currently connected agents.
 	ListConnectedAgents(ctx context.Context, req *ListConnectedAgentsRequest, opts ...http.CallOption) (rsp *ListConnectedAgentsResponse, err error)
+	// ListHosts ListHosts returns one entry per device with a pointer to its latest inventory.
+	ListHosts(ctx context.Context, req *ListHostsRequest, opts ...http.CallOption) (rsp *ListHostsResponse, err error)
 	// ListInventories ListInventories lists stored inventories with optional filters.
 	ListInventories(ctx context.Context, req *ListInventoriesRequest, opts ...http.CallOption) (rsp *ListInventoriesResponse, err error)
+	// PauseAgent PauseAgent tells a connected agent to stop collecting and submitting
+	// inventories until it is resumed, e.g. during maintenance or imaging.
+	// The agent keeps the paused state across restarts.
+	PauseAgent(ctx context.Context, req *PauseAgentRequest, opts ...http.CallOption) (rsp *PauseAgentResponse, err error)
 	// RefreshInventory RefreshInventory sends a refresh command to a connected agent.
 	RefreshInventory(ctx context.Context, req *RefreshInventoryRequest, opts ...http.CallOption) (rsp *RefreshInventoryResponse, err error)
+	// ResumeAgent ResumeAgent tells a paused agent to resume collection.
+	ResumeAgent(ctx context.Context, req *ResumeAgentRequest, opts ...http.CallOption) (rsp *ResumeAgentResponse, err error)
 	// SubmitInventory SubmitInventory receives inventory from a client and stores it.
 	SubmitInventory(ctx context.Context, req *SubmitInventoryRequest, opts ...http.CallOption) (rsp *SubmitInventoryResponse, err error)
 }
@@ -228,6 +536,20 @@ func NewInventoryCollectorServiceHTTPClient(client *http.Client) InventoryCollec
 	return &InventoryCollectorServiceHTTPClientImpl{client}
 }
 
+// AcknowledgeAlert AcknowledgeAlert marks a hardware change alert as acknowledged.
+func (c *InventoryCollectorServiceHTTPClientImpl) AcknowledgeAlert(ctx context.Context, in *AcknowledgeAlertRequest, opts ...http.CallOption) (*AcknowledgeAlertResponse, error) {
+	var out AcknowledgeAlertResponse
+	pattern := "/v1/alerts/{id}/ack"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationInventoryCollectorServiceAcknowledgeAlert))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 // DeleteInventory DeleteInventory removes a stored inventory by ID.
 func (c *InventoryCollectorServiceHTTPClientImpl) DeleteInventory(ctx context.Context, in *DeleteInventoryRequest, opts ...http.CallOption) (*DeleteInventoryResponse, error) {
 	var out DeleteInventoryResponse
@@ -242,6 +564,66 @@ func (c *InventoryCollectorServiceHTTPClientImpl) DeleteInventory(ctx context.Co
 	return &out, nil
 }
 
+// DiffInventories DiffInventories lists the hardware changes between two stored
+// inventories. Without from_id, to_id is compared with the previous
+// inventory of the same host.
+func (c *InventoryCollectorServiceHTTPClientImpl) DiffInventories(ctx context.Context, in *DiffInventoriesRequest, opts ...http.CallOption) (*DiffInventoriesResponse, error) {
+	var out DiffInventoriesResponse
+	pattern := "/v1/inventories/{to_id}/diff"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationInventoryCollectorServiceDiffInventories))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetCollectionReport GetCollectionReport summarizes collection module durations and failures
+// across the latest inventory of each host.
+func (c *InventoryCollectorServiceHTTPClientImpl) GetCollectionReport(ctx context.Context, in *GetCollectionReportRequest, opts ...http.CallOption) (*GetCollectionReportResponse, error) {
+	var out GetCollectionReportResponse
+	pattern := "/v1/reports/collection"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationInventoryCollectorServiceGetCollectionReport))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetDuplicateReport GetDuplicateReport lists system serials and UUIDs reported by more than one host.
+func (c *InventoryCollectorServiceHTTPClientImpl) GetDuplicateReport(ctx context.Context, in *GetDuplicateReportRequest, opts ...http.CallOption) (*GetDuplicateReportResponse, error) {
+	var out GetDuplicateReportResponse
+	pattern := "/v1/reports/duplicates"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationInventoryCollectorServiceGetDuplicateReport))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetFleetReport GetFleetReport breaks the fleet down by model, memory size and agent
+// version, and counts the hosts whose agent is connected.
+func (c *InventoryCollectorServiceHTTPClientImpl) GetFleetReport(ctx context.Context, in *GetFleetReportRequest, opts ...http.CallOption) (*GetFleetReportResponse, error) {
+	var out GetFleetReportResponse
+	pattern := "/v1/reports/fleet"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationInventoryCollectorServiceGetFleetReport))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 // GetInventory GetInventory retrieves a stored inventory by ID.
 func (c *InventoryCollectorServiceHTTPClientImpl) GetInventory(ctx context.Context, in *GetInventoryRequest, opts ...http.CallOption) (*GetInventoryResponse, error) {
 	var out GetInventoryResponse
@@ -270,6 +652,48 @@ func (c *InventoryCollectorServiceHTTPClientImpl) GetLatestByHostname(ctx contex
 	return &out, nil
 }
 
+// GetLatestBySerial GetLatestBySerial returns the most recent inventory for a system serial number.
+func (c *InventoryCollectorServiceHTTPClientImpl) GetLatestBySerial(ctx context.Context, in *GetLatestBySerialRequest, opts ...http.CallOption) (*GetLatestBySerialResponse, error) {
+	var out GetLatestBySerialResponse
+	pattern := "/v1/inventories/latest/by-serial/{serial_number}"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationInventoryCollectorServiceGetLatestBySerial))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetLatestBySystemUUID GetLatestBySystemUUID returns the most recent inventory for an SMBIOS system UUID.
+func (c *InventoryCollectorServiceHTTPClientImpl) GetLatestBySystemUUID(ctx context.Context, in *GetLatestBySystemUUIDRequest, opts ...http.CallOption) (*GetLatestBySystemUUIDResponse, error) {
+	var out GetLatestBySystemUUIDResponse
+	pattern := "/v1/inventories/latest/by-uuid/{system_uuid}"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationInventoryCollectorServiceGetLatestBySystemUUID))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListAlerts ListAlerts lists hardware change alerts raised on inventory submission.
+func (c *InventoryCollectorServiceHTTPClientImpl) ListAlerts(ctx context.Context, in *ListAlertsRequest, opts ...http.CallOption) (*ListAlertsResponse, error) {
+	var out ListAlertsResponse
+	pattern := "/v1/alerts"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationInventoryCollectorServiceListAlerts))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 // ListConnectedAgents ListConnectedAgents returns the currently connected agents.
 func (c *InventoryCollectorServiceHTTPClientImpl) ListConnectedAgents(ctx context.Context, in *ListConnectedAgentsRequest, opts ...http.CallOption) (*ListConnectedAgentsResponse, error) {
 	var out ListConnectedAgentsResponse
@@ -284,6 +708,20 @@ func (c *InventoryCollectorServiceHTTPClientImpl) ListConnectedAgents(ctx contex
 	return &out, nil
 }
 
+// ListHosts ListHosts returns one entry per device with a pointer to its latest inventory.
+func (c *InventoryCollectorServiceHTTPClientImpl) ListHosts(ctx context.Context, in *ListHostsRequest, opts ...http.CallOption) (*ListHostsResponse, error) {
+	var out ListHostsResponse
+	pattern := "/v1/hosts"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationInventoryCollectorServiceListHosts))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 // ListInventories ListInventories lists stored inventories with optional filters.
 func (c *InventoryCollectorServiceHTTPClientImpl) ListInventories(ctx context.Context, in *ListInventoriesRequest, opts ...http.CallOption) (*ListInventoriesResponse, error) {
 	var out ListInventoriesResponse
@@ -298,6 +736,22 @@ func (c *InventoryCollectorServiceHTTPClientImpl) ListInventories(ctx context.Co
 	return &out, nil
 }
 
+// PauseAgent PauseAgent tells a connected agent to stop collecting and submitting
+// inventories until it is resumed, e.g. during maintenance or imaging.
+// The agent keeps the paused state across restarts.
+func (c *InventoryCollectorServiceHTTPClientImpl) PauseAgent(ctx context.Context, in *PauseAgentRequest, opts ...http.CallOption) (*PauseAgentResponse, error) {
+	var out PauseAgentResponse
+	pattern := "/v1/agents/pause"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationInventoryCollectorServicePauseAgent))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 // RefreshInventory RefreshInventory sends a refresh command to a connected agent.
 func (c *InventoryCollectorServiceHTTPClientImpl) RefreshInventory(ctx context.Context, in *RefreshInventoryRequest, opts ...http.CallOption) (*RefreshInventoryResponse, error) {
 	var out RefreshInventoryResponse
@@ -312,6 +766,20 @@ func (c *InventoryCollectorServiceHTTPClientImpl) RefreshInventory(ctx context.C
 	return &out, nil
 }
 
+// ResumeAgent ResumeAgent tells a paused agent to resume collection.
+func (c *InventoryCollectorServiceHTTPClientImpl) ResumeAgent(ctx context.Context, in *ResumeAgentRequest, opts ...http.CallOption) (*ResumeAgentResponse, error) {
+	var out ResumeAgentResponse
+	pattern := "/v1/agents/resume"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationInventoryCollectorServiceResumeAgent))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 // SubmitInventory SubmitInventory receives inventory from a client and stores it.
 func (c *InventoryCollectorServiceHTTPClientImpl) SubmitInventory(ctx context.Context, in *SubmitInventoryRequest, opts ...http.CallOption) (*SubmitInventoryResponse, error) {
 	var out SubmitInventoryResponse
@@ -0,0 +1,666 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v5.28.3
+// source: inventory/collector/v2/collector.proto
+
+package collectorv2
+
+import (
+	v1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ListHostsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// page_size is the most hosts returned: 50 if unset, at most 1000.
+	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// page_token is the next_page_token of the previous page, whose filters
+	// must be the same.
+	PageToken string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	Site      string `protobuf:"bytes,3,opt,name=site,proto3" json:"site,omitempty"`
+	Hostname  string `protobuf:"bytes,4,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	// device_class restricts the list to a device class, e.g. computer or
+	// switch.
+	DeviceClass string `protobuf:"bytes,5,opt,name=device_class,json=deviceClass,proto3" json:"device_class,omitempty"`
+	// seen_after restricts the list to the hosts with an inventory collected
+	// at or after it.
+	SeenAfter     *timestamp.Timestamp `protobuf:"bytes,6,opt,name=seen_after,json=seenAfter,proto3" json:"seen_after,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListHostsRequest) Reset() {
+	*x = ListHostsRequest{}
+	mi := &file_inventory_collector_v2_collector_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListHostsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListHostsRequest) ProtoMessage() {}
+
+func (x *ListHostsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v2_collector_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListHostsRequest.ProtoReflect.Descriptor instead.
+func (*ListHostsRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v2_collector_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ListHostsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListHostsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListHostsRequest) GetSite() string {
+	if x != nil {
+		return x.Site
+	}
+	return ""
+}
+
+func (x *ListHostsRequest) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+func (x *ListHostsRequest) GetDeviceClass() string {
+	if x != nil {
+		return x.DeviceClass
+	}
+	return ""
+}
+
+func (x *ListHostsRequest) GetSeenAfter() *timestamp.Timestamp {
+	if x != nil {
+		return x.SeenAfter
+	}
+	return nil
+}
+
+type ListHostsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hosts         []*v1.HostSummary      `protobuf:"bytes,1,rep,name=hosts,proto3" json:"hosts,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// total_size is the number of hosts matching the filters.
+	TotalSize     int32 `protobuf:"varint,3,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListHostsResponse) Reset() {
+	*x = ListHostsResponse{}
+	mi := &file_inventory_collector_v2_collector_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListHostsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListHostsResponse) ProtoMessage() {}
+
+func (x *ListHostsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v2_collector_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListHostsResponse.ProtoReflect.Descriptor instead.
+func (*ListHostsResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v2_collector_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListHostsResponse) GetHosts() []*v1.HostSummary {
+	if x != nil {
+		return x.Hosts
+	}
+	return nil
+}
+
+func (x *ListHostsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListHostsResponse) GetTotalSize() int32 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+type ListHostInventoriesRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	SystemUuid string                 `protobuf:"bytes,1,opt,name=system_uuid,json=systemUuid,proto3" json:"system_uuid,omitempty"`
+	// page_size is the most inventories returned: 50 if unset, at most 1000.
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// page_token is the next_page_token of the previous page, whose filters
+	// must be the same.
+	PageToken       string               `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	Site            string               `protobuf:"bytes,4,opt,name=site,proto3" json:"site,omitempty"`
+	CollectedAfter  *timestamp.Timestamp `protobuf:"bytes,5,opt,name=collected_after,json=collectedAfter,proto3" json:"collected_after,omitempty"`
+	CollectedBefore *timestamp.Timestamp `protobuf:"bytes,6,opt,name=collected_before,json=collectedBefore,proto3" json:"collected_before,omitempty"`
+	// read_mask selects the InventorySummary fields to return. Paths under
+	// "inventory" (e.g. "inventory.memory") additionally attach those sections
+	// of each inventory; an empty mask returns the summary fields only.
+	ReadMask      *fieldmaskpb.FieldMask `protobuf:"bytes,7,opt,name=read_mask,json=readMask,proto3" json:"read_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListHostInventoriesRequest) Reset() {
+	*x = ListHostInventoriesRequest{}
+	mi := &file_inventory_collector_v2_collector_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListHostInventoriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListHostInventoriesRequest) ProtoMessage() {}
+
+func (x *ListHostInventoriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v2_collector_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListHostInventoriesRequest.ProtoReflect.Descriptor instead.
+func (*ListHostInventoriesRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v2_collector_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListHostInventoriesRequest) GetSystemUuid() string {
+	if x != nil {
+		return x.SystemUuid
+	}
+	return ""
+}
+
+func (x *ListHostInventoriesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListHostInventoriesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListHostInventoriesRequest) GetSite() string {
+	if x != nil {
+		return x.Site
+	}
+	return ""
+}
+
+func (x *ListHostInventoriesRequest) GetCollectedAfter() *timestamp.Timestamp {
+	if x != nil {
+		return x.CollectedAfter
+	}
+	return nil
+}
+
+func (x *ListHostInventoriesRequest) GetCollectedBefore() *timestamp.Timestamp {
+	if x != nil {
+		return x.CollectedBefore
+	}
+	return nil
+}
+
+func (x *ListHostInventoriesRequest) GetReadMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.ReadMask
+	}
+	return nil
+}
+
+type ListHostInventoriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Inventories   []*v1.InventorySummary `protobuf:"bytes,1,rep,name=inventories,proto3" json:"inventories,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// total_size is the number of inventories matching the filters; it may
+	// be cached for list_count_cache_ttl.
+	TotalSize     int32 `protobuf:"varint,3,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListHostInventoriesResponse) Reset() {
+	*x = ListHostInventoriesResponse{}
+	mi := &file_inventory_collector_v2_collector_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListHostInventoriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListHostInventoriesResponse) ProtoMessage() {}
+
+func (x *ListHostInventoriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v2_collector_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListHostInventoriesResponse.ProtoReflect.Descriptor instead.
+func (*ListHostInventoriesResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v2_collector_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListHostInventoriesResponse) GetInventories() []*v1.InventorySummary {
+	if x != nil {
+		return x.Inventories
+	}
+	return nil
+}
+
+func (x *ListHostInventoriesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListHostInventoriesResponse) GetTotalSize() int32 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+type GetLatestHostInventoryRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	SystemUuid string                 `protobuf:"bytes,1,opt,name=system_uuid,json=systemUuid,proto3" json:"system_uuid,omitempty"`
+	Site       string                 `protobuf:"bytes,2,opt,name=site,proto3" json:"site,omitempty"`
+	// read_mask selects the InventorySummary fields to return, e.g.
+	// "id,collected_at,inventory.memory"; an empty mask returns all of them,
+	// with the whole inventory.
+	ReadMask      *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=read_mask,json=readMask,proto3" json:"read_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLatestHostInventoryRequest) Reset() {
+	*x = GetLatestHostInventoryRequest{}
+	mi := &file_inventory_collector_v2_collector_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLatestHostInventoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLatestHostInventoryRequest) ProtoMessage() {}
+
+func (x *GetLatestHostInventoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v2_collector_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLatestHostInventoryRequest.ProtoReflect.Descriptor instead.
+func (*GetLatestHostInventoryRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v2_collector_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetLatestHostInventoryRequest) GetSystemUuid() string {
+	if x != nil {
+		return x.SystemUuid
+	}
+	return ""
+}
+
+func (x *GetLatestHostInventoryRequest) GetSite() string {
+	if x != nil {
+		return x.Site
+	}
+	return ""
+}
+
+func (x *GetLatestHostInventoryRequest) GetReadMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.ReadMask
+	}
+	return nil
+}
+
+type ListAgentsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// page_size is the most agents returned: 50 if unset, at most 1000.
+	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// page_token is the next_page_token of the previous page, whose filters
+	// must be the same.
+	PageToken     string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	Site          string `protobuf:"bytes,3,opt,name=site,proto3" json:"site,omitempty"`
+	Hostname      string `protobuf:"bytes,4,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAgentsRequest) Reset() {
+	*x = ListAgentsRequest{}
+	mi := &file_inventory_collector_v2_collector_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAgentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAgentsRequest) ProtoMessage() {}
+
+func (x *ListAgentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v2_collector_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAgentsRequest.ProtoReflect.Descriptor instead.
+func (*ListAgentsRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v2_collector_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListAgentsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListAgentsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListAgentsRequest) GetSite() string {
+	if x != nil {
+		return x.Site
+	}
+	return ""
+}
+
+func (x *ListAgentsRequest) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+type ListAgentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Agents        []*v1.ConnectedAgent   `protobuf:"bytes,1,rep,name=agents,proto3" json:"agents,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// total_size is the number of connected agents matching the filters.
+	TotalSize     int32 `protobuf:"varint,3,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAgentsResponse) Reset() {
+	*x = ListAgentsResponse{}
+	mi := &file_inventory_collector_v2_collector_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAgentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAgentsResponse) ProtoMessage() {}
+
+func (x *ListAgentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_collector_v2_collector_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAgentsResponse.ProtoReflect.Descriptor instead.
+func (*ListAgentsResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_collector_v2_collector_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListAgentsResponse) GetAgents() []*v1.ConnectedAgent {
+	if x != nil {
+		return x.Agents
+	}
+	return nil
+}
+
+func (x *ListAgentsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListAgentsResponse) GetTotalSize() int32 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+var File_inventory_collector_v2_collector_proto protoreflect.FileDescriptor
+
+const file_inventory_collector_v2_collector_proto_rawDesc = "" +
+	"\n" +
+	"&inventory/collector/v2/collector.proto\x12\x16inventory.collector.v2\x1a\x1cgoogle/api/annotations.proto\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a&inventory/collector/v1/collector.proto\"\xdc\x01\n" +
+	"\x10ListHostsRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12\x12\n" +
+	"\x04site\x18\x03 \x01(\tR\x04site\x12\x1a\n" +
+	"\bhostname\x18\x04 \x01(\tR\bhostname\x12!\n" +
+	"\fdevice_class\x18\x05 \x01(\tR\vdeviceClass\x129\n" +
+	"\n" +
+	"seen_after\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tseenAfter\"\x95\x01\n" +
+	"\x11ListHostsResponse\x129\n" +
+	"\x05hosts\x18\x01 \x03(\v2#.inventory.collector.v1.HostSummaryR\x05hosts\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\x03 \x01(\x05R\ttotalSize\"\xd2\x02\n" +
+	"\x1aListHostInventoriesRequest\x12\x1f\n" +
+	"\vsystem_uuid\x18\x01 \x01(\tR\n" +
+	"systemUuid\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\x12\x12\n" +
+	"\x04site\x18\x04 \x01(\tR\x04site\x12C\n" +
+	"\x0fcollected_after\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x0ecollectedAfter\x12E\n" +
+	"\x10collected_before\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x0fcollectedBefore\x127\n" +
+	"\tread_mask\x18\a \x01(\v2\x1a.google.protobuf.FieldMaskR\breadMask\"\xb0\x01\n" +
+	"\x1bListHostInventoriesResponse\x12J\n" +
+	"\vinventories\x18\x01 \x03(\v2(.inventory.collector.v1.InventorySummaryR\vinventories\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\x03 \x01(\x05R\ttotalSize\"\x8d\x01\n" +
+	"\x1dGetLatestHostInventoryRequest\x12\x1f\n" +
+	"\vsystem_uuid\x18\x01 \x01(\tR\n" +
+	"systemUuid\x12\x12\n" +
+	"\x04site\x18\x02 \x01(\tR\x04site\x127\n" +
+	"\tread_mask\x18\x03 \x01(\v2\x1a.google.protobuf.FieldMaskR\breadMask\"\x7f\n" +
+	"\x11ListAgentsRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12\x12\n" +
+	"\x04site\x18\x03 \x01(\tR\x04site\x12\x1a\n" +
+	"\bhostname\x18\x04 \x01(\tR\bhostname\"\x9b\x01\n" +
+	"\x12ListAgentsResponse\x12>\n" +
+	"\x06agents\x18\x01 \x03(\v2&.inventory.collector.v1.ConnectedAgentR\x06agents\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\x03 \x01(\x05R\ttotalSize2\xde\x04\n" +
+	"\x10InventoryService\x12s\n" +
+	"\tListHosts\x12(.inventory.collector.v2.ListHostsRequest\x1a).inventory.collector.v2.ListHostsResponse\"\x11\x82\xd3\xe4\x93\x02\v\x12\t/v2/hosts\x12\xab\x01\n" +
+	"\x13ListHostInventories\x122.inventory.collector.v2.ListHostInventoriesRequest\x1a3.inventory.collector.v2.ListHostInventoriesResponse\"+\x82\xd3\xe4\x93\x02%\x12#/v2/hosts/{system_uuid}/inventories\x12\xad\x01\n" +
+	"\x16GetLatestHostInventory\x125.inventory.collector.v2.GetLatestHostInventoryRequest\x1a(.inventory.collector.v1.InventorySummary\"2\x82\xd3\xe4\x93\x02,\x12*/v2/hosts/{system_uuid}/inventories:latest\x12w\n" +
+	"\n" +
+	"ListAgents\x12).inventory.collector.v2.ListAgentsRequest\x1a*.inventory.collector.v2.ListAgentsResponse\"\x12\x82\xd3\xe4\x93\x02\f\x12\n" +
+	"/v2/agentsB$Z\"inventory/collector/v2;collectorv2b\x06proto3"
+
+var (
+	file_inventory_collector_v2_collector_proto_rawDescOnce sync.Once
+	file_inventory_collector_v2_collector_proto_rawDescData []byte
+)
+
+func file_inventory_collector_v2_collector_proto_rawDescGZIP() []byte {
+	file_inventory_collector_v2_collector_proto_rawDescOnce.Do(func() {
+		file_inventory_collector_v2_collector_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_inventory_collector_v2_collector_proto_rawDesc), len(file_inventory_collector_v2_collector_proto_rawDesc)))
+	})
+	return file_inventory_collector_v2_collector_proto_rawDescData
+}
+
+var file_inventory_collector_v2_collector_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_inventory_collector_v2_collector_proto_goTypes = []any{
+	(*ListHostsRequest)(nil),              // 0: inventory.collector.v2.ListHostsRequest
+	(*ListHostsResponse)(nil),             // 1: inventory.collector.v2.ListHostsResponse
+	(*ListHostInventoriesRequest)(nil),    // 2: inventory.collector.v2.ListHostInventoriesRequest
+	(*ListHostInventoriesResponse)(nil),   // 3: inventory.collector.v2.ListHostInventoriesResponse
+	(*GetLatestHostInventoryRequest)(nil), // 4: inventory.collector.v2.GetLatestHostInventoryRequest
+	(*ListAgentsRequest)(nil),             // 5: inventory.collector.v2.ListAgentsRequest
+	(*ListAgentsResponse)(nil),            // 6: inventory.collector.v2.ListAgentsResponse
+	(*timestamp.Timestamp)(nil),           // 7: google.protobuf.Timestamp
+	(*v1.HostSummary)(nil),                // 8: inventory.collector.v1.HostSummary
+	(*fieldmaskpb.FieldMask)(nil),         // 9: google.protobuf.FieldMask
+	(*v1.InventorySummary)(nil),           // 10: inventory.collector.v1.InventorySummary
+	(*v1.ConnectedAgent)(nil),             // 11: inventory.collector.v1.ConnectedAgent
+}
+var file_inventory_collector_v2_collector_proto_depIdxs = []int32{
+	7,  // 0: inventory.collector.v2.ListHostsRequest.seen_after:type_name -> google.protobuf.Timestamp
+	8,  // 1: inventory.collector.v2.ListHostsResponse.hosts:type_name -> inventory.collector.v1.HostSummary
+	7,  // 2: inventory.collector.v2.ListHostInventoriesRequest.collected_after:type_name -> google.protobuf.Timestamp
+	7,  // 3: inventory.collector.v2.ListHostInventoriesRequest.collected_before:type_name -> google.protobuf.Timestamp
+	9,  // 4: inventory.collector.v2.ListHostInventoriesRequest.read_mask:type_name -> google.protobuf.FieldMask
+	10, // 5: inventory.collector.v2.ListHostInventoriesResponse.inventories:type_name -> inventory.collector.v1.InventorySummary
+	9,  // 6: inventory.collector.v2.GetLatestHostInventoryRequest.read_mask:type_name -> google.protobuf.FieldMask
+	11, // 7: inventory.collector.v2.ListAgentsResponse.agents:type_name -> inventory.collector.v1.ConnectedAgent
+	0,  // 8: inventory.collector.v2.InventoryService.ListHosts:input_type -> inventory.collector.v2.ListHostsRequest
+	2,  // 9: inventory.collector.v2.InventoryService.ListHostInventories:input_type -> inventory.collector.v2.ListHostInventoriesRequest
+	4,  // 10: inventory.collector.v2.InventoryService.GetLatestHostInventory:input_type -> inventory.collector.v2.GetLatestHostInventoryRequest
+	5,  // 11: inventory.collector.v2.InventoryService.ListAgents:input_type -> inventory.collector.v2.ListAgentsRequest
+	1,  // 12: inventory.collector.v2.InventoryService.ListHosts:output_type -> inventory.collector.v2.ListHostsResponse
+	3,  // 13: inventory.collector.v2.InventoryService.ListHostInventories:output_type -> inventory.collector.v2.ListHostInventoriesResponse
+	10, // 14: inventory.collector.v2.InventoryService.GetLatestHostInventory:output_type -> inventory.collector.v1.InventorySummary
+	6,  // 15: inventory.collector.v2.InventoryService.ListAgents:output_type -> inventory.collector.v2.ListAgentsResponse
+	12, // [12:16] is the sub-list for method output_type
+	8,  // [8:12] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_inventory_collector_v2_collector_proto_init() }
+func file_inventory_collector_v2_collector_proto_init() {
+	if File_inventory_collector_v2_collector_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_inventory_collector_v2_collector_proto_rawDesc), len(file_inventory_collector_v2_collector_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_inventory_collector_v2_collector_proto_goTypes,
+		DependencyIndexes: file_inventory_collector_v2_collector_proto_depIdxs,
+		MessageInfos:      file_inventory_collector_v2_collector_proto_msgTypes,
+	}.Build()
+	File_inventory_collector_v2_collector_proto = out.File
+	file_inventory_collector_v2_collector_proto_goTypes = nil
+	file_inventory_collector_v2_collector_proto_depIdxs = nil
+}
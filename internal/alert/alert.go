@@ -0,0 +1,108 @@
+// Package alert raises hardware change alerts by diffing each submitted
+// inventory against the previous one for the same host.
+package alert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+	"github.com/go-tangra/go-tangra-inventory/internal/diff"
+	"github.com/go-tangra/go-tangra-inventory/internal/logging"
+	"github.com/go-tangra/go-tangra-inventory/internal/store"
+)
+
+const notifyTimeout = 10 * time.Second
+
+// Engine evaluates inventories against the alert rules, persists raised
+// alerts, and fans them out to the configured notifiers.
+type Engine struct {
+	store     *store.Store
+	notifiers []Notifier
+}
+
+// NewEngine creates an alert engine backed by the given store.
+func NewEngine(s *store.Store, notifiers ...Notifier) *Engine {
+	return &Engine{store: s, notifiers: notifiers}
+}
+
+// Evaluate diffs prev against cur, stores an alert for every change that
+// matches a rule, and notifies asynchronously. prev may be nil for a host's
+// first submission, in which case nothing is raised.
+func (e *Engine) Evaluate(ctx context.Context, inventoryID int64, prev, cur *collectorv1.Inventory) ([]store.AlertRecord, error) {
+	var alerts []store.AlertRecord
+	for _, c := range diff.Compare(prev, cur) {
+		for _, r := range rules {
+			if !r.match(c) {
+				continue
+			}
+			alerts = append(alerts, store.AlertRecord{
+				Site:        cur.Site,
+				Hostname:    cur.Hostname,
+				InventoryID: inventoryID,
+				Rule:        r.name,
+				Severity:    r.severity,
+				Message:     c.String(),
+			})
+			break
+		}
+	}
+	if len(alerts) == 0 {
+		return nil, nil
+	}
+
+	if err := e.store.InsertAlerts(ctx, alerts); err != nil {
+		return nil, fmt.Errorf("store alerts: %w", err)
+	}
+
+	slog.Info("Raised hardware change alerts", "count", len(alerts), "hostname", cur.Hostname, "site", cur.Site, "record_id", inventoryID)
+
+	if len(e.notifiers) > 0 {
+		go e.notify(alerts)
+	}
+
+	return alerts, nil
+}
+
+func (e *Engine) notify(alerts []store.AlertRecord) {
+	ctx, cancel := context.WithTimeout(context.Background(), notifyTimeout)
+	defer cancel()
+
+	for _, n := range e.notifiers {
+		if err := n.Notify(ctx, alerts); err != nil {
+			slog.Error("Alert notification failed", logging.Err(err))
+		}
+	}
+}
+
+// Errors of ReplayWebhook.
+var (
+	ErrNotFailed       = errors.New("only failed deliveries can be replayed")
+	ErrUnknownEndpoint = errors.New("the webhook endpoint is no longer configured")
+)
+
+// ReplayWebhook posts the failed webhook delivery with ID id again, signed
+// with the current secret of its endpoint, and returns it with the outcome
+// of the attempt. The error wraps sql.ErrNoRows if there is no such
+// delivery.
+func (e *Engine) ReplayWebhook(ctx context.Context, id int64) (*store.WebhookDelivery, error) {
+	d, err := e.store.GetWebhookDelivery(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if d.Status != store.DeliveryFailed {
+		return nil, ErrNotFailed
+	}
+	for _, n := range e.notifiers {
+		if w, ok := n.(*WebhookNotifier); ok && w.URL == d.URL {
+			if err := w.Deliver(ctx, d); err != nil {
+				slog.Warn("Webhook replay failed", "delivery_id", d.ID, logging.Err(err))
+			}
+			return d, nil
+		}
+	}
+	return nil, ErrUnknownEndpoint
+}
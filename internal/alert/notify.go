@@ -0,0 +1,185 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-tangra/go-tangra-inventory/internal/logging"
+	"github.com/go-tangra/go-tangra-inventory/internal/store"
+)
+
+// Notifier delivers raised alerts to an external system.
+type Notifier interface {
+	Notify(ctx context.Context, alerts []store.AlertRecord) error
+}
+
+// webhookAlert is the JSON shape posted to generic webhooks.
+type webhookAlert struct {
+	ID          int64     `json:"id"`
+	Site        string    `json:"site,omitempty"`
+	Hostname    string    `json:"hostname"`
+	InventoryID int64     `json:"inventory_id"`
+	Rule        string    `json:"rule"`
+	Severity    string    `json:"severity"`
+	Message     string    `json:"message"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// signatureHeader carries the signature of webhook payloads, as
+// t=<unix time>,v1=<hex HMAC-SHA256 of "<unix time>.<body>">. Receivers
+// recompute the HMAC with the endpoint secret and reject stale times.
+const signatureHeader = "X-Tangra-Signature"
+
+// deliveryHeader carries the delivery ID, which stays the same when a
+// delivery is replayed, so that receivers can drop duplicates.
+const deliveryHeader = "X-Tangra-Delivery"
+
+// WebhookNotifier POSTs alerts as a JSON document to an arbitrary URL,
+// signed with Secret unless it is empty. With a Store, every delivery and
+// the outcome of its attempts is recorded, so failed ones can be replayed.
+type WebhookNotifier struct {
+	URL    string
+	Secret string
+	Client *http.Client
+	Store  *store.Store
+}
+
+// NewWebhookNotifier creates a notifier that posts to url, signing with
+// secret and recording deliveries in s (nil = not recorded).
+func NewWebhookNotifier(url, secret string, s *store.Store) *WebhookNotifier {
+	return &WebhookNotifier{URL: url, Secret: secret, Client: http.DefaultClient, Store: s}
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, alerts []store.AlertRecord) error {
+	payload := struct {
+		Alerts []webhookAlert `json:"alerts"`
+	}{Alerts: make([]webhookAlert, len(alerts))}
+	for i, a := range alerts {
+		payload.Alerts[i] = webhookAlert{
+			ID:          a.ID,
+			Site:        a.Site,
+			Hostname:    a.Hostname,
+			InventoryID: a.InventoryID,
+			Rule:        a.Rule,
+			Severity:    a.Severity,
+			Message:     a.Message,
+			CreatedAt:   a.CreatedAt,
+		}
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal payload: %w", err)
+	}
+
+	d := &store.WebhookDelivery{URL: n.URL, Payload: body}
+	if n.Store != nil {
+		if err := n.Store.InsertWebhookDelivery(ctx, d); err != nil {
+			return err
+		}
+	}
+	return n.Deliver(ctx, d)
+}
+
+// Deliver posts the payload of d and records the attempt in d and, if it
+// is stored, in the store.
+func (n *WebhookNotifier) Deliver(ctx context.Context, d *store.WebhookDelivery) error {
+	header := http.Header{}
+	if d.ID != 0 {
+		header.Set(deliveryHeader, strconv.FormatInt(d.ID, 10))
+	}
+	if n.Secret != "" {
+		header.Set(signatureHeader, Sign(n.Secret, time.Now(), d.Payload))
+	}
+	code, err := post(ctx, n.Client, n.URL, d.Payload, header)
+
+	d.Attempts++
+	d.StatusCode = code
+	d.LastAttemptAt = time.Now()
+	d.Status, d.LastError = store.DeliveryDelivered, ""
+	if err != nil {
+		d.Status, d.LastError = store.DeliveryFailed, err.Error()
+	}
+	if n.Store != nil && d.ID != 0 {
+		// Record the outcome even if the attempt used up ctx.
+		if err := n.Store.UpdateWebhookDelivery(context.WithoutCancel(ctx), d); err != nil {
+			slog.Error("Recording webhook delivery failed", "delivery_id", d.ID, logging.Err(err))
+		}
+	}
+	return err
+}
+
+// Sign returns the signature header value of body sent at t with secret.
+func Sign(secret string, t time.Time, body []byte) string {
+	ts := strconv.FormatInt(t.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts + "."))
+	mac.Write(body)
+	return "t=" + ts + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// SlackNotifier posts a plain-text summary to a Slack incoming webhook.
+type SlackNotifier struct {
+	URL    string
+	Client *http.Client
+}
+
+// NewSlackNotifier creates a notifier for the given Slack incoming webhook URL.
+func NewSlackNotifier(url string) *SlackNotifier {
+	return &SlackNotifier{URL: url, Client: http.DefaultClient}
+}
+
+func (n *SlackNotifier) Notify(ctx context.Context, alerts []store.AlertRecord) error {
+	var b strings.Builder
+	host := alerts[0].Hostname
+	if alerts[0].Site != "" {
+		host = alerts[0].Site + "/" + host
+	}
+	fmt.Fprintf(&b, "*Hardware change detected on %s*\n", host)
+	for _, a := range alerts {
+		fmt.Fprintf(&b, "• [%s] %s\n", strings.ToUpper(a.Severity), a.Message)
+	}
+	return postJSON(ctx, n.Client, n.URL, map[string]string{"text": b.String()})
+}
+
+func postJSON(ctx context.Context, client *http.Client, url string, v any) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("marshal payload: %w", err)
+	}
+	_, err = post(ctx, client, url, body, nil)
+	return err
+}
+
+// post sends body as JSON to url with the extra header and returns the
+// response status code, 0 if there was no response.
+func post(ctx context.Context, client *http.Client, url string, body []byte, header http.Header) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("build request: %w", err)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("post %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("post %s: unexpected status %s", url, resp.Status)
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,101 @@
+package alert
+
+import (
+	"strconv"
+
+	"github.com/go-tangra/go-tangra-inventory/internal/diff"
+)
+
+// Severity levels assigned to raised alerts.
+const (
+	SeverityInfo     = "info"
+	SeverityWarning  = "warning"
+	SeverityCritical = "critical"
+)
+
+// rule maps a class of hardware change to an alert.
+type rule struct {
+	name     string
+	severity string
+	match    func(c diff.Change) bool
+}
+
+// rules lists the changes considered significant enough to raise an alert.
+// Changes that match no rule (BIOS updates, new monitors, ...) are ignored.
+var rules = []rule{
+	{
+		name:     "system_serial_changed",
+		severity: SeverityCritical,
+		match: func(c diff.Change) bool {
+			return c.Component == diff.ComponentSystem && c.Field == "serial_number"
+		},
+	},
+	{
+		name:     "system_uuid_changed",
+		severity: SeverityCritical,
+		match: func(c diff.Change) bool {
+			return c.Component == diff.ComponentSystem && c.Field == "uuid"
+		},
+	},
+	{
+		name:     "baseboard_replaced",
+		severity: SeverityCritical,
+		match: func(c diff.Change) bool {
+			return c.Component == diff.ComponentBaseboard && c.Field == "serial_number"
+		},
+	},
+	{
+		name:     "chassis_serial_changed",
+		severity: SeverityWarning,
+		match: func(c diff.Change) bool {
+			return c.Component == diff.ComponentChassis && c.Field == "serial_number"
+		},
+	},
+	{
+		name:     "memory_module_removed",
+		severity: SeverityWarning,
+		match: func(c diff.Change) bool {
+			return c.Component == diff.ComponentMemoryModule && c.Type == diff.Removed
+		},
+	},
+	{
+		name:     "memory_module_swapped",
+		severity: SeverityWarning,
+		match: func(c diff.Change) bool {
+			return c.Component == diff.ComponentMemoryModule && c.Field == "serial_number"
+		},
+	},
+	{
+		name:     "memory_decreased",
+		severity: SeverityWarning,
+		match: func(c diff.Change) bool {
+			if c.Component != diff.ComponentMemory || c.Field != "total_physical_bytes" {
+				return false
+			}
+			old, err1 := strconv.ParseUint(c.Old, 10, 64)
+			cur, err2 := strconv.ParseUint(c.New, 10, 64)
+			return err1 == nil && err2 == nil && cur < old
+		},
+	},
+	{
+		name:     "processor_removed",
+		severity: SeverityWarning,
+		match: func(c diff.Change) bool {
+			return c.Component == diff.ComponentProcessor && c.Type == diff.Removed
+		},
+	},
+	{
+		name:     "processor_swapped",
+		severity: SeverityWarning,
+		match: func(c diff.Change) bool {
+			return c.Component == diff.ComponentProcessor && c.Type == diff.Modified
+		},
+	},
+	{
+		name:     "monitor_removed",
+		severity: SeverityInfo,
+		match: func(c diff.Change) bool {
+			return c.Component == diff.ComponentMonitor && c.Type == diff.Removed
+		},
+	},
+}
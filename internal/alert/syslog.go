@@ -0,0 +1,85 @@
+package alert
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/go-tangra/go-tangra-inventory/internal/convert"
+	"github.com/go-tangra/go-tangra-inventory/internal/siem"
+	"github.com/go-tangra/go-tangra-inventory/internal/store"
+)
+
+// syslogFacility is the facility of the sent messages, local0.
+const syslogFacility = 16
+
+// syslogTag is the tag (application name) of the sent messages.
+const syslogTag = "inventory-collector"
+
+// SyslogNotifier sends alerts as CEF or LEEF events to a syslog receiver,
+// such as a Splunk, QRadar or ArcSight connector, one RFC 3164 message per
+// alert. Over TCP, messages are terminated by a newline.
+type SyslogNotifier struct {
+	network  string
+	addr     string
+	format   string
+	hostname string
+}
+
+// NewSyslogNotifier creates a notifier that sends to address, given as
+// udp://host:port or tcp://host:port, in format (siem.CEF or siem.LEEF).
+func NewSyslogNotifier(address, format string) (*SyslogNotifier, error) {
+	u, err := url.Parse(address)
+	if err != nil || (u.Scheme != "udp" && u.Scheme != "tcp") || u.Port() == "" {
+		return nil, fmt.Errorf("%q is not an address such as udp://host:514 or tcp://host:514", address)
+	}
+	if err := siem.Check(format); err != nil {
+		return nil, err
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "-"
+	}
+	return &SyslogNotifier{network: u.Scheme, addr: u.Host, format: format, hostname: hostname}, nil
+}
+
+func (n *SyslogNotifier) Notify(ctx context.Context, alerts []store.AlertRecord) error {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, n.network, n.addr)
+	if err != nil {
+		return fmt.Errorf("connect to syslog receiver %s: %w", n.addr, err)
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	for i := range alerts {
+		event, err := siem.Format(n.format, convert.AlertToProto(&alerts[i]))
+		if err != nil {
+			return err
+		}
+		msg := fmt.Sprintf("<%d>%s %s %s: %s\n", syslogFacility*8+syslogSeverity(alerts[i].Severity),
+			alerts[i].CreatedAt.Local().Format(time.Stamp), n.hostname, syslogTag, event)
+		if _, err := conn.Write([]byte(msg)); err != nil {
+			return fmt.Errorf("send to syslog receiver %s: %w", n.addr, err)
+		}
+	}
+	return nil
+}
+
+// syslogSeverity maps an alert severity to a syslog severity.
+func syslogSeverity(severity string) int {
+	switch severity {
+	case SeverityCritical:
+		return 2
+	case SeverityWarning:
+		return 4
+	case SeverityInfo:
+		return 6
+	}
+	return 5
+}
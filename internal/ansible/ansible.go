@@ -0,0 +1,146 @@
+// Package ansible renders the latest inventory of each host as Ansible
+// dynamic inventory JSON, so playbooks can target groups of hosts defined by
+// their hardware facts.
+package ansible
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-tangra/go-tangra-inventory/internal/store"
+)
+
+// Group prefixes. A host joins one group of each prefix it has a value for,
+// e.g. site_hq, class_computer, manufacturer_dell_inc and model_optiplex_7090.
+const (
+	SitePrefix         = "site_"
+	ClassPrefix        = "class_"
+	ManufacturerPrefix = "manufacturer_"
+	ModelPrefix        = "model_"
+	// OUPrefix and DepartmentPrefix group hosts enriched from the
+	// directory by their container and the department of their user.
+	OUPrefix         = "ou_"
+	DepartmentPrefix = "department_"
+)
+
+// varPrefix prefixes the host variables, so they do not clash with the
+// variables of playbooks.
+const varPrefix = "tangra_"
+
+// Group is a group of hosts.
+type Group struct {
+	Hosts    []string `json:"hosts,omitempty"`
+	Children []string `json:"children,omitempty"`
+}
+
+// Inventory is the dynamic inventory document: the groups by name, and the
+// variables of every host under _meta so Ansible does not call the script
+// once per host.
+type Inventory map[string]any
+
+// Build returns the dynamic inventory of hosts. A hostname found in several
+// sites is named <hostname>@<site> after its first occurrence, with
+// ansible_host set to the hostname.
+func Build(hosts []store.HostFacts) Inventory {
+	groups := make(map[string]*Group)
+	var order []string
+	add := func(prefix, value, host string) {
+		name := GroupName(prefix, value)
+		if name == "" {
+			return
+		}
+		g, ok := groups[name]
+		if !ok {
+			g = &Group{}
+			groups[name] = g
+			order = append(order, name)
+		}
+		g.Hosts = append(g.Hosts, host)
+	}
+
+	hostvars := make(map[string]map[string]any, len(hosts))
+	for _, h := range hosts {
+		name := h.Hostname
+		vars := map[string]any{}
+		if _, taken := hostvars[name]; taken {
+			name = h.Hostname + "@" + h.Site
+			vars["ansible_host"] = h.Hostname
+		}
+		for key, value := range map[string]any{
+			"site":          h.Site,
+			"inventory_id":  h.LatestID,
+			"username":      h.Username,
+			"system_uuid":   h.SystemUUID,
+			"device_class":  h.DeviceClass,
+			"manufacturer":  h.Manufacturer,
+			"model":         h.Model,
+			"serial_number": h.SerialNumber,
+			"processor":     h.Processor,
+			"memory_bytes":  h.MemoryBytes,
+			"last_seen":     h.LastSeen.UTC().Format(time.RFC3339),
+			"ou":            h.OU,
+			"department":    h.Department,
+		} {
+			if value == "" || value == int64(0) {
+				continue
+			}
+			vars[varPrefix+key] = value
+		}
+		hostvars[name] = vars
+
+		add(SitePrefix, h.Site, name)
+		add(ClassPrefix, h.DeviceClass, name)
+		add(ManufacturerPrefix, h.Manufacturer, name)
+		add(ModelPrefix, h.Model, name)
+		add(OUPrefix, container(h.OU), name)
+		add(DepartmentPrefix, h.Department, name)
+	}
+
+	inv := Inventory{"_meta": map[string]any{"hostvars": hostvars}}
+	slices.Sort(order)
+	all := &Group{Children: append([]string{"ungrouped"}, order...)}
+	for name, g := range groups {
+		inv[name] = g
+	}
+	// Every host has a class group, so ungrouped stays empty.
+	inv["ungrouped"] = &Group{}
+	inv["all"] = all
+	return inv
+}
+
+// GroupName returns the group of hosts with value, e.g. model_optiplex_7090
+// for prefix model_ and value "OptiPlex 7090", or "" if value has no
+// letters or digits. Group names are lower case with runs of other
+// characters replaced by an underscore, as Ansible requires.
+func GroupName(prefix, value string) string {
+	var b strings.Builder
+	sep := false
+	for _, r := range strings.ToLower(value) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if sep && b.Len() > 0 {
+				b.WriteByte('_')
+			}
+			b.WriteRune(r)
+			sep = false
+		} else {
+			sep = true
+		}
+	}
+	if b.Len() == 0 {
+		return ""
+	}
+	return prefix + b.String()
+}
+
+// container returns the name of the innermost container of an OU
+// distinguished name, e.g. Workstations for
+// OU=Workstations,OU=HQ,DC=example,DC=com.
+func container(dn string) string {
+	first, _, _ := strings.Cut(dn, ",")
+	_, value, ok := strings.Cut(first, "=")
+	if !ok {
+		return first
+	}
+	return value
+}
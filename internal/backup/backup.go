@@ -0,0 +1,342 @@
+// Package backup uploads database snapshots and archived inventories to an
+// object storage bucket and prunes them by retention rules.
+package backup
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-tangra/go-tangra-inventory/internal/convert"
+	"github.com/go-tangra/go-tangra-inventory/internal/export"
+	"github.com/go-tangra/go-tangra-inventory/internal/objstore"
+	"github.com/go-tangra/go-tangra-inventory/internal/store"
+)
+
+// Key prefixes of snapshots and inventory segments in the bucket.
+const (
+	SnapshotPrefix = "snapshots/"
+	SegmentPrefix  = "segments/"
+)
+
+// snapshotTime is the time format in snapshot keys; it sorts
+// chronologically.
+const snapshotTime = "20060102T150405Z"
+
+// segmentSize is the maximum number of inventories in a segment.
+const segmentSize = 10000
+
+// pageSize is the number of inventories read from the store at a time.
+const pageSize = 500
+
+// Retention limits the backups kept in the bucket. The newest snapshot and
+// the newest segment are always kept.
+type Retention struct {
+	// KeepSnapshots is the number of newest snapshots kept (0 = all).
+	KeepSnapshots int
+	// MaxAge deletes snapshots and segments uploaded longer ago (0 =
+	// never).
+	MaxAge time.Duration
+}
+
+// Result summarizes a backup run.
+type Result struct {
+	// Snapshot is the key of the uploaded snapshot.
+	Snapshot     string
+	SnapshotSize int64
+	// Segments are the keys of the uploaded segments, holding Inventories
+	// inventories in total.
+	Segments    []string
+	Inventories int
+	// Deleted is the number of snapshots and segments pruned.
+	Deleted int
+}
+
+// Backuper uploads a consistent snapshot of the database, as written by
+// store.Backup, and the inventories stored since the previous run as gzipped
+// NDJSON segments. Segments are named after the first and last inventory ID
+// they hold, so the next run continues after the newest one; they keep
+// inventories that retention_days purges from the database.
+type Backuper struct {
+	bucket    objstore.Bucket
+	db        *store.Store
+	retention Retention
+}
+
+// New returns a Backuper of db to bucket.
+func New(bucket objstore.Bucket, db *store.Store, retention Retention) *Backuper {
+	return &Backuper{bucket: bucket, db: db, retention: retention}
+}
+
+// Run uploads a snapshot and the new segments, then prunes the bucket.
+func (b *Backuper) Run(ctx context.Context) (Result, error) {
+	var res Result
+	dir, err := os.MkdirTemp("", "inventory-backup-")
+	if err != nil {
+		return res, fmt.Errorf("create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	if res.Snapshot, res.SnapshotSize, err = b.uploadSnapshot(ctx, dir); err != nil {
+		return res, err
+	}
+	if res.Segments, res.Inventories, err = b.uploadSegments(ctx, dir); err != nil {
+		return res, err
+	}
+	res.Deleted, err = b.prune(ctx)
+	return res, err
+}
+
+// Snapshots returns the snapshots in the bucket, oldest first.
+func (b *Backuper) Snapshots(ctx context.Context) ([]objstore.Object, error) {
+	objects, err := b.bucket.List(ctx, SnapshotPrefix)
+	if err != nil {
+		return nil, err
+	}
+	snapshots := slices.DeleteFunc(objects, func(o objstore.Object) bool {
+		_, ok := snapshotTaken(o.Key)
+		return !ok
+	})
+	slices.SortFunc(snapshots, func(x, y objstore.Object) int { return strings.Compare(x.Key, y.Key) })
+	return snapshots, nil
+}
+
+// Segments returns the segments in the bucket, oldest first.
+func (b *Backuper) Segments(ctx context.Context) ([]objstore.Object, error) {
+	objects, err := b.bucket.List(ctx, SegmentPrefix)
+	if err != nil {
+		return nil, err
+	}
+	segments := slices.DeleteFunc(objects, func(o objstore.Object) bool {
+		_, _, ok := segmentRange(o.Key)
+		return !ok
+	})
+	slices.SortFunc(segments, func(x, y objstore.Object) int { return strings.Compare(x.Key, y.Key) })
+	return segments, nil
+}
+
+// LastSnapshot returns when the newest snapshot in the bucket was taken, or
+// the zero time if there is none.
+func (b *Backuper) LastSnapshot(ctx context.Context) (time.Time, error) {
+	snapshots, err := b.Snapshots(ctx)
+	if err != nil || len(snapshots) == 0 {
+		return time.Time{}, err
+	}
+	taken, _ := snapshotTaken(snapshots[len(snapshots)-1].Key)
+	return taken, nil
+}
+
+func (b *Backuper) uploadSnapshot(ctx context.Context, dir string) (string, int64, error) {
+	dbPath := filepath.Join(dir, "inventory.db")
+	if err := b.db.Backup(ctx, dbPath); err != nil {
+		return "", 0, err
+	}
+
+	gzPath := dbPath + ".gz"
+	err := writeGzip(gzPath, func(w io.Writer) error {
+		f, err := os.Open(dbPath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(w, f)
+		return err
+	})
+	if err != nil {
+		return "", 0, fmt.Errorf("compress snapshot: %w", err)
+	}
+	os.Remove(dbPath)
+
+	key := SnapshotPrefix + "inventory-" + time.Now().UTC().Format(snapshotTime) + ".db.gz"
+	size, err := upload(ctx, b.bucket, key, gzPath)
+	if err != nil {
+		return "", 0, fmt.Errorf("upload snapshot: %w", err)
+	}
+	return key, size, nil
+}
+
+// uploadSegments uploads the inventories stored after the newest segment,
+// segmentSize at a time.
+func (b *Backuper) uploadSegments(ctx context.Context, dir string) ([]string, int, error) {
+	segments, err := b.Segments(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	var after int64
+	if len(segments) > 0 {
+		_, after, _ = segmentRange(segments[len(segments)-1].Key)
+	}
+
+	var keys []string
+	var total int
+	path := filepath.Join(dir, "segment.ndjson.gz")
+	for {
+		var first, last int64
+		var n int
+		err := writeGzip(path, func(w io.Writer) error {
+			for n < segmentSize {
+				records, err := b.db.ListAfter(ctx, after, min(pageSize, segmentSize-n))
+				if err != nil {
+					return err
+				}
+				for i := range records {
+					if err := writeInventory(w, &records[i]); err != nil {
+						return err
+					}
+					if first == 0 {
+						first = records[i].ID
+					}
+					after, last = records[i].ID, records[i].ID
+					n++
+				}
+				if len(records) < pageSize {
+					return nil
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return keys, total, fmt.Errorf("write segment: %w", err)
+		}
+		if n == 0 {
+			return keys, total, nil
+		}
+
+		key := fmt.Sprintf("%sinventories-%012d-%012d.ndjson.gz", SegmentPrefix, first, last)
+		if _, err := upload(ctx, b.bucket, key, path); err != nil {
+			return keys, total, fmt.Errorf("upload segment: %w", err)
+		}
+		keys = append(keys, key)
+		total += n
+		if n < segmentSize {
+			return keys, total, nil
+		}
+	}
+}
+
+// prune deletes the snapshots and segments beyond the retention, and
+// returns how many it deleted.
+func (b *Backuper) prune(ctx context.Context) (int, error) {
+	snapshots, err := b.Snapshots(ctx)
+	if err != nil {
+		return 0, err
+	}
+	segments, err := b.Segments(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var expired []string
+	cutoff := time.Now().Add(-b.retention.MaxAge)
+	old := func(o objstore.Object) bool {
+		return b.retention.MaxAge > 0 && o.Modified.Before(cutoff)
+	}
+	for i, o := range snapshots {
+		if i == len(snapshots)-1 {
+			break
+		}
+		surplus := b.retention.KeepSnapshots > 0 && i < len(snapshots)-b.retention.KeepSnapshots
+		if surplus || old(o) {
+			expired = append(expired, o.Key)
+		}
+	}
+	for i, o := range segments {
+		if i < len(segments)-1 && old(o) {
+			expired = append(expired, o.Key)
+		}
+	}
+
+	for i, key := range expired {
+		if err := b.bucket.Delete(ctx, key); err != nil {
+			return i, err
+		}
+	}
+	return len(expired), nil
+}
+
+// writeInventory writes rec to w as an NDJSON line, in the format of
+// "inventoryctl export".
+func writeInventory(w io.Writer, rec *store.InventoryRecord) error {
+	inv, err := convert.RecordToInventory(rec)
+	if err != nil {
+		return fmt.Errorf("inventory %d: %w", rec.ID, err)
+	}
+	summary := convert.RecordToSummary(rec)
+	summary.Inventory = inv
+	if err := export.WriteNDJSON(w, summary); err != nil {
+		return fmt.Errorf("write inventory %d: %w", rec.ID, err)
+	}
+	return nil
+}
+
+// writeGzip creates the file path with the gzipped output of write.
+func writeGzip(path string, write func(io.Writer) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	zw := gzip.NewWriter(f)
+	if err := write(zw); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// upload stores the file at path as key and returns its size.
+func upload(ctx context.Context, bucket objstore.Bucket, key, path string) (int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return 0, err
+	}
+	return fi.Size(), bucket.Put(ctx, key, f, fi.Size())
+}
+
+// snapshotTaken returns the time in the snapshot key.
+func snapshotTaken(key string) (time.Time, bool) {
+	name, ok := strings.CutPrefix(key, SnapshotPrefix+"inventory-")
+	if !ok {
+		return time.Time{}, false
+	}
+	name, ok = strings.CutSuffix(name, ".db.gz")
+	if !ok {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(snapshotTime, name)
+	return t, err == nil
+}
+
+// segmentRange returns the first and last inventory ID in the segment key.
+func segmentRange(key string) (first, last int64, ok bool) {
+	name, ok := strings.CutPrefix(key, SegmentPrefix+"inventories-")
+	if !ok {
+		return 0, 0, false
+	}
+	name, ok = strings.CutSuffix(name, ".ndjson.gz")
+	if !ok {
+		return 0, 0, false
+	}
+	from, to, ok := strings.Cut(name, "-")
+	if !ok {
+		return 0, 0, false
+	}
+	first, err1 := strconv.ParseInt(from, 10, 64)
+	last, err2 := strconv.ParseInt(to, 10, 64)
+	return first, last, err1 == nil && err2 == nil
+}
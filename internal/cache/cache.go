@@ -0,0 +1,49 @@
+// Package cache keeps a copy of the last inventory submitted to the
+// collector, so that the agent can show what changed locally.
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/go-tangra/go-tangra-inventory/internal/collector"
+)
+
+// Save replaces the cached inventory at path with inv.
+func Save(path string, inv *collector.Inventory) error {
+	data, err := json.Marshal(inv)
+	if err != nil {
+		return fmt.Errorf("marshal inventory: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("create cache directory: %w", err)
+	}
+
+	// Write a temporary file first so that a crash never leaves a
+	// truncated cache behind.
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("write inventory cache: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("write inventory cache: %w", err)
+	}
+	return nil
+}
+
+// Load reads the cached inventory at path. The error wraps fs.ErrNotExist
+// when nothing has been cached yet.
+func Load(path string) (*collector.Inventory, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read inventory cache: %w", err)
+	}
+	var inv collector.Inventory
+	if err := json.Unmarshal(data, &inv); err != nil {
+		return nil, fmt.Errorf("parse inventory cache %s: %w", path, err)
+	}
+	return &inv, nil
+}
@@ -0,0 +1,154 @@
+// Package certs issues a private CA with a collector server certificate and
+// an agent client certificate, for securing agent connections with TLS or
+// mutual TLS without an external PKI.
+package certs
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Names of the files written by Init.
+const (
+	CAFile         = "ca.pem"
+	CAKeyFile      = "ca-key.pem"
+	ServerCertFile = "server.pem"
+	ServerKeyFile  = "server-key.pem"
+	ClientCertFile = "client.pem"
+	ClientKeyFile  = "client-key.pem"
+)
+
+// Options configures the issued certificates.
+type Options struct {
+	// Hosts are the DNS names and IP addresses agents reach the collector
+	// by; they become the subject alternative names of the server
+	// certificate.
+	Hosts []string
+	// ClientName is the common name of the client certificate.
+	ClientName string
+	// Validity is how long the server and client certificates are valid;
+	// the CA is valid twice as long, so that they can be reissued.
+	Validity time.Duration
+	// Overwrite replaces existing files instead of refusing to run.
+	Overwrite bool
+}
+
+// Init writes a new CA and a server and client certificate issued by it to
+// dir. Private keys are written readable by the owner only.
+func Init(dir string, opts Options) error {
+	if len(opts.Hosts) == 0 {
+		return errors.New("at least one collector host is required")
+	}
+	if !opts.Overwrite {
+		for _, name := range []string{CAFile, CAKeyFile, ServerCertFile, ServerKeyFile, ClientCertFile, ClientKeyFile} {
+			if _, err := os.Stat(filepath.Join(dir, name)); !errors.Is(err, fs.ErrNotExist) {
+				return fmt.Errorf("%s already exists", filepath.Join(dir, name))
+			}
+		}
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create certificate directory: %w", err)
+	}
+
+	now := time.Now()
+	caTmpl := &x509.Certificate{
+		Subject:               pkix.Name{CommonName: "Tangra Inventory CA"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(2 * opts.Validity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+	}
+	caCert, caKey, err := issue(dir, CAFile, CAKeyFile, caTmpl, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	serverTmpl := &x509.Certificate{
+		Subject:     pkix.Name{CommonName: opts.Hosts[0]},
+		NotBefore:   now.Add(-time.Hour),
+		NotAfter:    now.Add(opts.Validity),
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	for _, h := range opts.Hosts {
+		if ip := net.ParseIP(h); ip != nil {
+			serverTmpl.IPAddresses = append(serverTmpl.IPAddresses, ip)
+		} else {
+			serverTmpl.DNSNames = append(serverTmpl.DNSNames, h)
+		}
+	}
+	if _, _, err := issue(dir, ServerCertFile, ServerKeyFile, serverTmpl, caCert, caKey); err != nil {
+		return err
+	}
+
+	clientTmpl := &x509.Certificate{
+		Subject:     pkix.Name{CommonName: opts.ClientName},
+		NotBefore:   now.Add(-time.Hour),
+		NotAfter:    now.Add(opts.Validity),
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	_, _, err = issue(dir, ClientCertFile, ClientKeyFile, clientTmpl, caCert, caKey)
+	return err
+}
+
+// issue generates a key for tmpl, signs the certificate with parent and
+// parentKey (self-signed when parent is nil) and writes both as PEM.
+func issue(dir, certName, keyName string, tmpl, parent *x509.Certificate, parentKey crypto.Signer) (*x509.Certificate, crypto.Signer, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("generate key: %w", err)
+	}
+	if tmpl.SerialNumber, err = rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128)); err != nil {
+		return nil, nil, fmt.Errorf("generate serial number: %w", err)
+	}
+	if parent == nil {
+		parent, parentKey = tmpl, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, key.Public(), parentKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create %s: %w", certName, err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse %s: %w", certName, err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("encode %s: %w", keyName, err)
+	}
+
+	if err := writePEM(filepath.Join(dir, keyName), "PRIVATE KEY", keyDER, 0o600); err != nil {
+		return nil, nil, err
+	}
+	if err := writePEM(filepath.Join(dir, certName), "CERTIFICATE", der, 0o644); err != nil {
+		return nil, nil, err
+	}
+	return cert, key, nil
+}
+
+func writePEM(path, blockType string, der []byte, perm os.FileMode) error {
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	// Remove an overwritten file first so that perm applies to the new one.
+	os.Remove(path)
+	if err := os.WriteFile(path, data, perm); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,309 @@
+package codec
+
+import (
+	"errors"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var errSyntax = errors.New("invalid JSON")
+
+// unquoteInt64 returns a copy of data, the protojson encoding of a message
+// of type desc, with the string-encoded 64-bit integer fields converted to
+// JSON numbers. It copies data in a single pass, using desc to tell which
+// fields to convert, and leaves everything else byte for byte as protojson
+// wrote it.
+func unquoteInt64(desc protoreflect.MessageDescriptor, data []byte) ([]byte, error) {
+	r := rewriter{in: data, out: make([]byte, 0, len(data))}
+	r.space()
+	if err := r.object(desc); err != nil {
+		return nil, err
+	}
+	r.space()
+	if r.pos != len(r.in) {
+		return nil, errSyntax
+	}
+	return r.out, nil
+}
+
+// rewriter copies JSON from in to out.
+type rewriter struct {
+	in  []byte
+	pos int
+	out []byte
+}
+
+// object copies the JSON object at the current position, the encoding of a
+// message of type desc; a nil desc copies it unchanged.
+func (r *rewriter) object(desc protoreflect.MessageDescriptor) error {
+	if !r.consume('{') {
+		return errSyntax
+	}
+	r.space()
+	if r.consume('}') {
+		return nil
+	}
+	for {
+		start := r.pos
+		if err := r.copyString(); err != nil {
+			return err
+		}
+		var fd protoreflect.FieldDescriptor
+		if desc != nil {
+			// Field names never need escaping, so the quoted key is the
+			// JSON name, or the proto name with UseProtoNames, as is.
+			name := string(r.in[start+1 : r.pos-1])
+			if fd = desc.Fields().ByJSONName(name); fd == nil {
+				fd = desc.Fields().ByName(protoreflect.Name(name))
+			}
+		}
+		r.space()
+		if !r.consume(':') {
+			return errSyntax
+		}
+		r.space()
+		if err := r.field(fd); err != nil {
+			return err
+		}
+		r.space()
+		if r.consume('}') {
+			return nil
+		}
+		if !r.consume(',') {
+			return errSyntax
+		}
+		r.space()
+	}
+}
+
+// field copies the value of the field fd, converting it if it is a singular
+// 64-bit integer and descending into messages, including those in lists and
+// map values. Repeated and map scalars are copied unchanged.
+func (r *rewriter) field(fd protoreflect.FieldDescriptor) error {
+	switch {
+	case fd == nil:
+		return r.copyValue()
+	case fd.IsMap():
+		if fd.MapValue().Kind() != protoreflect.MessageKind || r.peek() != '{' {
+			return r.copyValue()
+		}
+		return r.mapObject(fd.MapValue().Message())
+	case fd.IsList():
+		if (fd.Kind() != protoreflect.MessageKind && fd.Kind() != protoreflect.GroupKind) || r.peek() != '[' {
+			return r.copyValue()
+		}
+		return r.array(fd.Message())
+	}
+
+	switch fd.Kind() {
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		if r.peek() == '"' {
+			return r.number()
+		}
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		// Well-known types such as Timestamp are encoded as strings.
+		if r.peek() == '{' {
+			return r.object(fd.Message())
+		}
+	}
+	return r.copyValue()
+}
+
+// mapObject copies a JSON object of map entries whose values are messages
+// of type desc.
+func (r *rewriter) mapObject(desc protoreflect.MessageDescriptor) error {
+	return r.elements('{', '}', func() error {
+		if err := r.copyString(); err != nil {
+			return err
+		}
+		r.space()
+		if !r.consume(':') {
+			return errSyntax
+		}
+		r.space()
+		if r.peek() != '{' {
+			return r.copyValue()
+		}
+		return r.object(desc)
+	})
+}
+
+// array copies a JSON array of messages of type desc.
+func (r *rewriter) array(desc protoreflect.MessageDescriptor) error {
+	return r.elements('[', ']', func() error {
+		if r.peek() != '{' {
+			return r.copyValue()
+		}
+		return r.object(desc)
+	})
+}
+
+// elements copies a JSON object or array delimited by open and close,
+// calling elem to copy each member.
+func (r *rewriter) elements(open, close byte, elem func() error) error {
+	if !r.consume(open) {
+		return errSyntax
+	}
+	r.space()
+	if r.consume(close) {
+		return nil
+	}
+	for {
+		if err := elem(); err != nil {
+			return err
+		}
+		r.space()
+		if r.consume(close) {
+			return nil
+		}
+		if !r.consume(',') {
+			return errSyntax
+		}
+		r.space()
+	}
+}
+
+// number copies the string at the current position without its quotes if
+// it holds an integer, as protojson encodes 64-bit integers, or quoted
+// otherwise.
+func (r *rewriter) number() error {
+	start := r.pos
+	if err := r.skipString(); err != nil {
+		return err
+	}
+	digits := r.in[start+1 : r.pos-1]
+	if !isInteger(digits) {
+		r.out = append(r.out, r.in[start:r.pos]...)
+		return nil
+	}
+	r.out = append(r.out, digits...)
+	return nil
+}
+
+func isInteger(b []byte) bool {
+	if len(b) > 0 && b[0] == '-' {
+		b = b[1:]
+	}
+	if len(b) == 0 {
+		return false
+	}
+	for _, c := range b {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// copyValue copies the JSON value at the current position unchanged.
+func (r *rewriter) copyValue() error {
+	start := r.pos
+	if err := r.skipValue(); err != nil {
+		return err
+	}
+	r.out = append(r.out, r.in[start:r.pos]...)
+	return nil
+}
+
+func (r *rewriter) copyString() error {
+	start := r.pos
+	if err := r.skipString(); err != nil {
+		return err
+	}
+	r.out = append(r.out, r.in[start:r.pos]...)
+	return nil
+}
+
+// skipValue moves past the JSON value at the current position.
+func (r *rewriter) skipValue() error {
+	switch r.peek() {
+	case '"':
+		return r.skipString()
+	case '{', '[':
+		// Strings are skipped whole, so only brackets outside of them are
+		// counted.
+		depth := 0
+		for r.pos < len(r.in) {
+			switch r.in[r.pos] {
+			case '"':
+				if err := r.skipString(); err != nil {
+					return err
+				}
+				continue
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+			}
+			r.pos++
+			if depth == 0 {
+				return nil
+			}
+		}
+		return errSyntax
+	default:
+		// A number, true, false or null.
+		start := r.pos
+		for r.pos < len(r.in) {
+			switch r.in[r.pos] {
+			case ',', '}', ']', ' ', '\t', '\n', '\r':
+				if r.pos == start {
+					return errSyntax
+				}
+				return nil
+			}
+			r.pos++
+		}
+		if r.pos == start {
+			return errSyntax
+		}
+		return nil
+	}
+}
+
+// skipString moves past the JSON string at the current position.
+func (r *rewriter) skipString() error {
+	if r.peek() != '"' {
+		return errSyntax
+	}
+	for i := r.pos + 1; i < len(r.in); i++ {
+		switch r.in[i] {
+		case '\\':
+			i++
+		case '"':
+			r.pos = i + 1
+			return nil
+		}
+	}
+	return errSyntax
+}
+
+// space moves past whitespace.
+func (r *rewriter) space() {
+	for r.pos < len(r.in) {
+		switch r.in[r.pos] {
+		case ' ', '\t', '\n', '\r':
+			r.pos++
+		default:
+			return
+		}
+	}
+}
+
+// consume moves past c and copies it if it is at the current position.
+func (r *rewriter) consume(c byte) bool {
+	if r.peek() != c {
+		return false
+	}
+	r.out = append(r.out, c)
+	r.pos++
+	return true
+}
+
+func (r *rewriter) peek() byte {
+	if r.pos < len(r.in) {
+		return r.in[r.pos]
+	}
+	return 0
+}
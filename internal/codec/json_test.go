@@ -0,0 +1,82 @@
+package codec
+
+import (
+	"testing"
+	"time"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// benchSummary returns the summary of a typical server inventory, with the
+// 64-bit memory sizes the codec rewrites as numbers.
+func benchSummary() *collectorv1.InventorySummary {
+	collected := timestamppb.New(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	inv := &collectorv1.Inventory{
+		CollectedAt: collected,
+		Hostname:    "srv-db-01",
+		System: &collectorv1.SystemInfo{
+			Manufacturer: "Dell Inc.",
+			ProductName:  "PowerEdge R750",
+			SerialNumber: "7XK2QH3",
+			Uuid:         "4c4c4544-0058-4b10-8032-b7c04f514833",
+		},
+		Memory: &collectorv1.MemoryInfo{},
+	}
+	for s := range 2 {
+		inv.Processors = append(inv.Processors, &collectorv1.ProcessorInfo{
+			SocketDesignation: "CPU" + string(rune('1'+s)),
+			Manufacturer:      "Intel",
+			Version:           "Intel(R) Xeon(R) Gold 6338 CPU @ 2.00GHz",
+			CoreCount:         32,
+			ThreadCount:       64,
+		})
+	}
+	for s := range 16 {
+		inv.Memory.Modules = append(inv.Memory.Modules, &collectorv1.MemoryModule{
+			DeviceLocator: "DIMM " + string(rune('A'+s/2)) + string(rune('1'+s%2)),
+			CapacityBytes: 64 << 30,
+			MemoryType:    "DDR4",
+			SpeedMtS:      3200,
+			Manufacturer:  "Samsung",
+			PartNumber:    "M393A8G40AB2-CWE",
+		})
+		inv.Memory.TotalPhysicalBytes += 64 << 30
+	}
+	return &collectorv1.InventorySummary{
+		Id:          123456,
+		Hostname:    inv.Hostname,
+		SystemUuid:  inv.System.Uuid,
+		CollectedAt: collected,
+		StoredAt:    collected,
+		Inventory:   inv,
+	}
+}
+
+func BenchmarkMarshal(b *testing.B) {
+	c := jsonCodec{}
+	summary := benchSummary()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := c.Marshal(summary); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkUnmarshal(b *testing.B) {
+	c := jsonCodec{}
+	data, err := c.Marshal(&collectorv1.SubmitInventoryRequest{Inventory: benchSummary().Inventory})
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var req collectorv1.SubmitInventoryRequest
+		if err := c.Unmarshal(data, &req); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
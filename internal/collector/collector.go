@@ -1,37 +1,157 @@
 package collector
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/siderolabs/go-smbios/smbios"
 )
 
-// Collect gathers a full hardware inventory from the local host
-// using SMBIOS data.
+// ModuleError reports an inventory module that could not be collected. The
+// rest of the inventory is still usable.
+type ModuleError struct {
+	Module string
+	Err    error
+}
+
+func (e *ModuleError) Error() string {
+	return fmt.Sprintf("cannot collect %s info: %v", e.Module, e.Err)
+}
+
+func (e *ModuleError) Unwrap() error {
+	return e.Err
+}
+
+// FailedModules returns the modules named by the ModuleErrors in err, as
+// returned by Collect.
+func FailedModules(err error) []string {
+	var modules []string
+	var walk func(error)
+	walk = func(err error) {
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			for _, err := range joined.Unwrap() {
+				walk(err)
+			}
+			return
+		}
+		var me *ModuleError
+		if errors.As(err, &me) {
+			modules = append(modules, me.Module)
+		}
+	}
+	if err != nil {
+		walk(err)
+	}
+	return modules
+}
+
+// maxParallel bounds how many modules collect at the same time.
+const maxParallel = 4
+
+// ErrUnprivileged is wrapped into the ModuleError of a module that failed
+// because the agent lacks administrator or root rights.
+var ErrUnprivileged = errors.New("requires administrator or root privileges")
+
+// module collects one independent part of the inventory. Modules run
+// concurrently, so each must only set the Inventory fields it owns.
+type module struct {
+	name    string
+	collect func(inv *Inventory) error
+	// privileged modules may fail without administrator or root rights.
+	privileged bool
+}
+
+var modules = []module{
+	{name: "monitor", collect: collectMonitor},
+	{name: "user", collect: collectUser},
+	{name: "smbios", collect: collectSMBIOS, privileged: smbiosNeedsPrivilege},
+}
+
+// Collect gathers a full hardware inventory from the local host, running
+// the independent modules concurrently. Modules that fail are reported as
+// ModuleErrors joined into the returned error, alongside the partial
+// inventory. Module durations and errors are recorded in its Telemetry.
+//
+// Without administrator or root rights, privileged modules that fail are
+// reported with ErrUnprivileged and listed in the inventory's Privileges.
 func Collect() (*Inventory, error) {
+	start := time.Now()
 	hostname, _ := os.Hostname()
 
 	inv := &Inventory{
-		CollectedAt: time.Now().UTC(),
+		CollectedAt: start.UTC(),
 		Hostname:    hostname,
+		Privileges:  Privileges{Elevated: elevated()},
+	}
+
+	errs := make([]error, len(modules))
+	tel := &Telemetry{Modules: make([]ModuleTelemetry, len(modules))}
+	sem := make(chan struct{}, maxParallel)
+	var wg sync.WaitGroup
+	for i, m := range modules {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			mt := ModuleTelemetry{Name: m.name}
+			began := time.Now()
+			if err := m.collect(inv); err != nil {
+				if m.privileged && !inv.Privileges.Elevated {
+					err = fmt.Errorf("%w: %v", ErrUnprivileged, err)
+				}
+				errs[i] = &ModuleError{Module: m.name, Err: err}
+				mt.Error = err.Error()
+			}
+			mt.DurationMs = time.Since(began).Milliseconds()
+			tel.Modules[i] = mt
+		}()
 	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if errors.Is(err, ErrUnprivileged) {
+			inv.Privileges.Unavailable = append(inv.Privileges.Unavailable, modules[i].name)
+		}
+	}
+
+	// Fill sections the modules could not provide from secondary sources.
+	applyFallbacks(inv)
+
+	tel.DurationMs = time.Since(start).Milliseconds()
+	tel.PeakRSSBytes = peakRSS()
+	inv.Telemetry = tel
+
+	return inv, errors.Join(errs...)
+}
+
+func collectMonitor(inv *Inventory) error {
 	monitorInfo, err := CollectMonitorInfo()
 	if err != nil {
-		fmt.Printf("warning: cannot collect monitor info: %v\n", err)
-	} else {
-		inv.Monitor = monitorInfo
+		return err
 	}
+	inv.Monitor = monitorInfo
+	return nil
+}
+
+func collectUser(inv *Inventory) error {
 	userName, err := GetUserInfo()
 	if err != nil {
-		fmt.Printf("warning: cannot collect user info: %v\n", err)
-	} else {
-		inv.Username = userName
+		return err
 	}
+	inv.Username = userName
+	return nil
+}
+
+// collectSMBIOS fills in everything read from the SMBIOS tables.
+func collectSMBIOS(inv *Inventory) error {
 	s, err := smbios.New()
 	if err != nil {
-		return inv, fmt.Errorf("opening SMBIOS: %w", err)
+		return err
 	}
 
 	inv.SMBIOSVersion = VersionInfo{
@@ -77,5 +197,5 @@ func Collect() (*Inventory, error) {
 		InstallableLanguages: s.BIOSLanguageInformation.InstallableLanguages,
 	}
 
-	return inv, nil
+	return nil
 }
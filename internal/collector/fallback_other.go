@@ -0,0 +1,6 @@
+//go:build !windows
+
+package collector
+
+// applyFallbacks is a no-op outside Windows: SMBIOS is the only source.
+func applyFallbacks(_ *Inventory) {}
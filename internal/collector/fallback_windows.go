@@ -0,0 +1,111 @@
+package collector
+
+import (
+	"strings"
+
+	"golang.org/x/sys/windows/registry"
+)
+
+// Registry sources used when SMBIOS cannot be read.
+const (
+	cpuRegKey     = `HARDWARE\DESCRIPTION\System\CentralProcessor`
+	biosRegKey    = `HARDWARE\DESCRIPTION\System\BIOS`
+	sysInfoRegKey = `SYSTEM\CurrentControlSet\Control\SystemInformation`
+)
+
+// applyFallbacks fills the processor, system, BIOS and baseboard sections
+// from the registry when SMBIOS left them empty, so hosts with unreadable
+// firmware tables still report a usable inventory.
+func applyFallbacks(inv *Inventory) {
+	if len(inv.Processors) == 0 {
+		inv.Processors = registryProcessors()
+	}
+
+	v := registryStrings(biosRegKey)
+	for name, val := range registryStrings(sysInfoRegKey) {
+		if v[name] == "" {
+			v[name] = val
+		}
+	}
+
+	if inv.System == (SystemInfo{}) {
+		inv.System = SystemInfo{
+			Manufacturer: v["SystemManufacturer"],
+			ProductName:  v["SystemProductName"],
+			Version:      v["SystemVersion"],
+			SKUNumber:    v["SystemSKU"],
+			Family:       v["SystemFamily"],
+		}
+	}
+	if inv.BIOS == (BIOSInfo{}) {
+		inv.BIOS = BIOSInfo{
+			Vendor:      v["BIOSVendor"],
+			Version:     v["BIOSVersion"],
+			ReleaseDate: v["BIOSReleaseDate"],
+		}
+	}
+	if inv.Baseboard == (BaseboardInfo{}) {
+		inv.Baseboard = BaseboardInfo{
+			Manufacturer: v["BaseBoardManufacturer"],
+			Product:      v["BaseBoardProduct"],
+			Version:      v["BaseBoardVersion"],
+		}
+	}
+}
+
+// registryProcessors describes the processors from the per-logical-CPU
+// subkeys of HARDWARE\DESCRIPTION\System\CentralProcessor. Sockets cannot be
+// told apart there, so a single entry carries the logical processor count.
+func registryProcessors() []ProcessorInfo {
+	k, err := registry.OpenKey(registry.LOCAL_MACHINE, cpuRegKey, registry.ENUMERATE_SUB_KEYS|registry.QUERY_VALUE)
+	if err != nil {
+		return nil
+	}
+	defer k.Close()
+
+	cpus, err := k.ReadSubKeyNames(-1)
+	if err != nil || len(cpus) == 0 {
+		return nil
+	}
+
+	cpu, err := registry.OpenKey(k, cpus[0], registry.QUERY_VALUE)
+	if err != nil {
+		return nil
+	}
+	defer cpu.Close()
+
+	name, _, _ := cpu.GetStringValue("ProcessorNameString")
+	vendor, _, _ := cpu.GetStringValue("VendorIdentifier")
+	mhz, _, _ := cpu.GetIntegerValue("~MHz")
+
+	return []ProcessorInfo{{
+		Manufacturer:    vendor,
+		Version:         strings.TrimSpace(name),
+		CurrentSpeedMHz: uint16(mhz),
+		SocketPopulated: true,
+		ThreadCount:     uint8(min(len(cpus), 255)),
+	}}
+}
+
+// registryStrings returns the string values of the HKLM key at path, or an
+// empty map when it cannot be opened.
+func registryStrings(path string) map[string]string {
+	values := make(map[string]string)
+
+	k, err := registry.OpenKey(registry.LOCAL_MACHINE, path, registry.QUERY_VALUE)
+	if err != nil {
+		return values
+	}
+	defer k.Close()
+
+	names, err := k.ReadValueNames(-1)
+	if err != nil {
+		return values
+	}
+	for _, name := range names {
+		if s, _, err := k.GetStringValue(name); err == nil {
+			values[name] = strings.TrimSpace(s)
+		}
+	}
+	return values
+}
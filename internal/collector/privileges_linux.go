@@ -0,0 +1,12 @@
+package collector
+
+import "os"
+
+// smbiosNeedsPrivilege reports that the raw SMBIOS tables are only readable
+// by root.
+const smbiosNeedsPrivilege = true
+
+// elevated reports whether the agent runs as root.
+func elevated() bool {
+	return os.Geteuid() == 0
+}
@@ -0,0 +1,13 @@
+package collector
+
+import "golang.org/x/sys/windows"
+
+// smbiosNeedsPrivilege is false: GetSystemFirmwareTable works for standard
+// users.
+const smbiosNeedsPrivilege = false
+
+// elevated reports whether the agent runs with an elevated (administrator)
+// token.
+func elevated() bool {
+	return windows.GetCurrentProcessToken().IsElevated()
+}
@@ -0,0 +1,14 @@
+package collector
+
+import "syscall"
+
+// peakRSS returns the peak resident set size of the agent process in bytes,
+// or 0 if it is unavailable.
+func peakRSS() uint64 {
+	var ru syscall.Rusage
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &ru); err != nil {
+		return 0
+	}
+	// Linux reports ru_maxrss in kilobytes.
+	return uint64(ru.Maxrss) * 1024
+}
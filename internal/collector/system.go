@@ -1,6 +1,18 @@
 package collector
 
-import "github.com/siderolabs/go-smbios/smbios"
+import (
+	"strings"
+
+	"github.com/siderolabs/go-smbios/smbios"
+)
+
+// placeholderUUIDs are system UUIDs that firmware reports when none was
+// assigned; they do not identify a machine.
+var placeholderUUIDs = map[string]bool{
+	"00000000000000000000000000000000": true,
+	"ffffffffffffffffffffffffffffffff": true,
+	"03000200040005000006000700080009": true,
+}
 
 // collectSystemInfo extracts system identification from SMBIOS Type 1.
 func collectSystemInfo(s *smbios.SMBIOS) SystemInfo {
@@ -16,3 +28,17 @@ func collectSystemInfo(s *smbios.SMBIOS) SystemInfo {
 		Family:       si.Family,
 	}
 }
+
+// SystemUUID returns the SMBIOS system UUID in lower case, or "" when SMBIOS
+// cannot be read or the UUID is a firmware placeholder.
+func SystemUUID() string {
+	s, err := smbios.New()
+	if err != nil {
+		return ""
+	}
+	uuid := strings.ToLower(strings.TrimSpace(s.SystemInformation.UUID))
+	if uuid == "" || placeholderUUIDs[strings.ReplaceAll(uuid, "-", "")] {
+		return ""
+	}
+	return uuid
+}
@@ -20,6 +20,29 @@ type Inventory struct {
 	OEMStrings    []string         `json:"oem_strings,omitempty"`
 	BIOSLanguage  BIOSLanguageInfo `json:"bios_language,omitempty"`
 	Monitor       []MonitorInfo    `json:"monitor,omitempty"`
+	Telemetry     *Telemetry       `json:"telemetry,omitempty"`
+	Privileges    Privileges       `json:"privileges"`
+}
+
+// Privileges describes whether the agent ran with administrator or root
+// rights, and which modules could not be collected without them.
+type Privileges struct {
+	Elevated    bool     `json:"elevated"`
+	Unavailable []string `json:"unavailable,omitempty"`
+}
+
+// Telemetry describes how an inventory was collected.
+type Telemetry struct {
+	DurationMs   int64             `json:"duration_ms"`
+	PeakRSSBytes uint64            `json:"peak_rss_bytes,omitempty"`
+	Modules      []ModuleTelemetry `json:"modules"`
+}
+
+// ModuleTelemetry holds the duration and error of one collection module.
+type ModuleTelemetry struct {
+	Name       string `json:"name"`
+	DurationMs int64  `json:"duration_ms"`
+	Error      string `json:"error,omitempty"`
 }
 
 // VersionInfo holds the SMBIOS specification version.
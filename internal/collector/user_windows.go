@@ -1,10 +1,6 @@
 package collector
 
-import (
-	"fmt"
-
-	"golang.org/x/sys/windows"
-)
+import "golang.org/x/sys/windows"
 
 func GetUserInfo() (string, error) {
 	var token windows.Token
@@ -23,13 +19,11 @@ func GetUserInfo() (string, error) {
 		return "unknown", err
 	}
 
-	account, domain, _, err := user.User.Sid.LookupAccount("")
+	account, _, _, err := user.User.Sid.LookupAccount("")
 	if err != nil {
 		return "unknown", err
 	}
 
-	fmt.Printf("User: %s\\%s\n", domain, account)
-
 	return account, nil
 
 }
@@ -0,0 +1,107 @@
+// Package compression registers the gRPC compressors shared by the agent and
+// the collector: gzip from grpc-go and zstd backed by klauspost/compress.
+// Importing it is enough for a gRPC server to accept and advertise both.
+package compression
+
+import (
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/encoding/gzip"
+)
+
+// Supported compression names, as accepted by the agent's -compression flag.
+const (
+	None = "none"
+	Gzip = gzip.Name
+	Zstd = "zstd"
+)
+
+func init() {
+	encoding.RegisterCompressor(newZstdCompressor())
+}
+
+// Encoding maps a configured compression name to the gRPC content-coding
+// passed to grpc.UseCompressor. An empty name selects no compression.
+func Encoding(name string) (string, error) {
+	switch name {
+	case "", None:
+		return encoding.Identity, nil
+	case Gzip, Zstd:
+		return name, nil
+	default:
+		return "", fmt.Errorf("unknown compression %q (use gzip, zstd or none)", name)
+	}
+}
+
+// zstdCompressor implements encoding.Compressor. Encoders and decoders are
+// pooled because each allocates sizeable window buffers.
+type zstdCompressor struct {
+	encoders sync.Pool
+	decoders sync.Pool
+}
+
+func newZstdCompressor() *zstdCompressor {
+	c := &zstdCompressor{}
+	c.encoders.New = func() any {
+		enc, _ := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+		return enc
+	}
+	c.decoders.New = func() any {
+		dec, _ := zstd.NewReader(nil, zstd.WithDecoderConcurrency(1))
+		return dec
+	}
+	return c
+}
+
+func (c *zstdCompressor) Name() string {
+	return Zstd
+}
+
+func (c *zstdCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	enc := c.encoders.Get().(*zstd.Encoder)
+	enc.Reset(w)
+	return &zstdWriter{Encoder: enc, pool: &c.encoders}, nil
+}
+
+func (c *zstdCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	dec := c.decoders.Get().(*zstd.Decoder)
+	if err := dec.Reset(r); err != nil {
+		c.decoders.Put(dec)
+		return nil, err
+	}
+	return &zstdReader{Decoder: dec, pool: &c.decoders}, nil
+}
+
+// zstdWriter returns its encoder to the pool once the message is flushed.
+type zstdWriter struct {
+	*zstd.Encoder
+	pool *sync.Pool
+}
+
+func (w *zstdWriter) Close() error {
+	err := w.Encoder.Close()
+	w.pool.Put(w.Encoder)
+	return err
+}
+
+// zstdReader returns its decoder to the pool once the message is drained.
+type zstdReader struct {
+	*zstd.Decoder
+	pool *sync.Pool
+}
+
+func (r *zstdReader) Read(p []byte) (int, error) {
+	if r.Decoder == nil {
+		return 0, io.EOF
+	}
+	n, err := r.Decoder.Read(p)
+	if err == io.EOF {
+		r.pool.Put(r.Decoder)
+		r.Decoder = nil
+	}
+	return n, err
+}
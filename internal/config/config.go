@@ -1,7 +1,10 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"net/url"
+	"path/filepath"
 	"time"
 
 	"github.com/spf13/viper"
@@ -9,14 +12,271 @@ import (
 
 // Config holds the collector daemon configuration.
 type Config struct {
-	Listen         string        `mapstructure:"listen"`
-	HTTPListen     string        `mapstructure:"http_listen"`
-	EnableSwagger  bool          `mapstructure:"enable_swagger"`
-	DatabasePath   string        `mapstructure:"database"`
-	RetentionDays  int           `mapstructure:"retention_days"`
-	PurgeInterval  time.Duration `mapstructure:"purge_interval"`
-	ClientSecret   string        `mapstructure:"client_secret"`
-	ApiSecret      string        `mapstructure:"api_secret"`
+	Listen        string        `mapstructure:"listen"`
+	HTTPListen    string        `mapstructure:"http_listen"`
+	HTTPBasePath  string        `mapstructure:"http_base_path"`
+	EnableSwagger bool          `mapstructure:"enable_swagger"`
+	EnableGraphQL bool          `mapstructure:"enable_graphql"`
+	DatabasePath  string        `mapstructure:"database"`
+	AutoMigrate   bool          `mapstructure:"auto_migrate"`
+	RetentionDays int           `mapstructure:"retention_days"`
+	PurgeInterval time.Duration `mapstructure:"purge_interval"`
+	ClientSecret  string        `mapstructure:"client_secret"`
+	ApiSecret     string        `mapstructure:"api_secret"`
+
+	// JSONProtoNames makes the REST API write the field names of the proto
+	// files (snake_case) instead of lowerCamelCase.
+	JSONProtoNames bool `mapstructure:"json_proto_names"`
+
+	// WriteQueueSize is the most submitted inventories waiting to be
+	// stored before submissions are refused (0 = no queue); WriteBatchSize
+	// is the most stored in one transaction (<= 1 = one transaction each).
+	WriteQueueSize int `mapstructure:"write_queue_size"`
+	WriteBatchSize int `mapstructure:"write_batch_size"`
+	// LatestCacheSize is the number of hosts whose latest inventory is
+	// cached for LatestCacheTTL (0 = no cache).
+	LatestCacheSize int           `mapstructure:"latest_cache_size"`
+	LatestCacheTTL  time.Duration `mapstructure:"latest_cache_ttl"`
+	// ListCountCacheTTL is how long the total counts of inventory lists
+	// are cached (0 = counted for every page).
+	ListCountCacheTTL time.Duration `mapstructure:"list_count_cache_ttl"`
+
+	// Limits of the gRPC listener (0 = unlimited): connections in total and
+	// per source IP, concurrent streams per connection, and unary handlers
+	// running at once.
+	GRPCMaxConnections       int    `mapstructure:"grpc_max_connections"`
+	GRPCMaxConnectionsPerIP  int    `mapstructure:"grpc_max_connections_per_ip"`
+	GRPCMaxConcurrentStreams uint32 `mapstructure:"grpc_max_concurrent_streams"`
+	GRPCWorkers              int    `mapstructure:"grpc_workers"`
+
+	// Purges delete at most PurgeBatchSize records per transaction and wait
+	// PurgeBatchPause between transactions.
+	PurgeBatchSize  int           `mapstructure:"purge_batch_size"`
+	PurgeBatchPause time.Duration `mapstructure:"purge_batch_pause"`
+
+	// Bounds of submitted inventories (0 = unlimited): the encoded size, and
+	// how far collected_at may be ahead of the collector's clock or behind
+	// it.
+	SubmitMaxBytes     int           `mapstructure:"submit_max_bytes"`
+	SubmitMaxClockSkew time.Duration `mapstructure:"submit_max_clock_skew"`
+	SubmitMaxAge       time.Duration `mapstructure:"submit_max_age"`
+
+	// TLS for the gRPC listener (empty = plaintext).
+	TLSCertFile string `mapstructure:"tls_cert_file"`
+	TLSKeyFile  string `mapstructure:"tls_key_file"`
+	// PEM CA bundle to require and verify client certificates with (mutual
+	// TLS; empty = no client certificates).
+	TLSClientCAFile string `mapstructure:"tls_client_ca_file"`
+
+	// Source address filtering for agent RPCs (CIDRs or bare IPs).
+	AgentAllowCIDRs []string `mapstructure:"agent_allow_cidrs"`
+	AgentDenyCIDRs  []string `mapstructure:"agent_deny_cidrs"`
+
+	// Site-bound tokens for multi-tenant deployments.
+	SiteTokens []SiteToken `mapstructure:"site_tokens"`
+
+	// Dedicated admin gRPC listener (empty = admin RPCs share the main port).
+	AdminListen string `mapstructure:"admin_listen"`
+	AdminSecret string `mapstructure:"admin_secret"`
+
+	// Swagger UI authentication: none, basic or api_key.
+	SwaggerAuth     string `mapstructure:"swagger_auth"`
+	SwaggerUsername string `mapstructure:"swagger_username"`
+	SwaggerPassword string `mapstructure:"swagger_password"`
+
+	// Hardware change alerting.
+	EnableAlerts         bool   `mapstructure:"enable_alerts"`
+	AlertWebhookURL      string `mapstructure:"alert_webhook_url"`
+	AlertSlackWebhookURL string `mapstructure:"alert_slack_webhook_url"`
+	// Webhook endpoints with per-endpoint signing secrets.
+	AlertWebhooks []AlertWebhook `mapstructure:"alert_webhooks"`
+	// SIEM forwarding: udp:// or tcp:// syslog address (empty = disabled),
+	// events in cef or leef.
+	AlertSyslogAddress string `mapstructure:"alert_syslog_address"`
+	AlertSyslogFormat  string `mapstructure:"alert_syslog_format"`
+
+	// Snipe-IT asset synchronization (empty URL = disabled).
+	SnipeITURL      string        `mapstructure:"snipeit_url"`
+	SnipeITToken    string        `mapstructure:"snipeit_token"`
+	SnipeITInterval time.Duration `mapstructure:"snipeit_interval"`
+	SnipeITSites    []SnipeITSite `mapstructure:"snipeit_sites"`
+
+	// NetBox device synchronization (empty URL = disabled).
+	NetBoxURL      string        `mapstructure:"netbox_url"`
+	NetBoxToken    string        `mapstructure:"netbox_token"`
+	NetBoxInterval time.Duration `mapstructure:"netbox_interval"`
+	NetBoxSites    []NetBoxSite  `mapstructure:"netbox_sites"`
+
+	// Active Directory / LDAP enrichment of host records (empty URL =
+	// disabled).
+	LDAPURL            string         `mapstructure:"ldap_url"`
+	LDAPStartTLS       bool           `mapstructure:"ldap_start_tls"`
+	LDAPCAFile         string         `mapstructure:"ldap_ca_file"`
+	LDAPBindDN         string         `mapstructure:"ldap_bind_dn"`
+	LDAPBindPassword   string         `mapstructure:"ldap_bind_password"`
+	LDAPBaseDN         string         `mapstructure:"ldap_base_dn"`
+	LDAPComputerFilter string         `mapstructure:"ldap_computer_filter"`
+	LDAPUserFilter     string         `mapstructure:"ldap_user_filter"`
+	LDAPAttributes     LDAPAttributes `mapstructure:"ldap_attributes"`
+	LDAPInterval       time.Duration  `mapstructure:"ldap_interval"`
+
+	// SMTP server for email reports.
+	SMTPHost     string `mapstructure:"smtp_host"`
+	SMTPPort     int    `mapstructure:"smtp_port"`
+	SMTPSecurity string `mapstructure:"smtp_security"`
+	SMTPUsername string `mapstructure:"smtp_username"`
+	SMTPPassword string `mapstructure:"smtp_password"`
+	SMTPFrom     string `mapstructure:"smtp_from"`
+	// Scheduled email reports (none = disabled).
+	EmailReports []EmailReport `mapstructure:"email_reports"`
+
+	// Sources of "inventory-collector import": a Configuration Manager site
+	// database (go-mssqldb connection string) and an Intune tenant's app
+	// registration.
+	SCCMDSN            string `mapstructure:"sccm_dsn"`
+	IntuneTenantID     string `mapstructure:"intune_tenant_id"`
+	IntuneClientID     string `mapstructure:"intune_client_id"`
+	IntuneClientSecret string `mapstructure:"intune_client_secret"`
+
+	// Prometheus fleet metrics listener (empty = disabled), optionally
+	// requiring a bearer token.
+	MetricsListen string `mapstructure:"metrics_listen"`
+	MetricsToken  string `mapstructure:"metrics_token"`
+
+	// SNMP polling of network devices (no targets = disabled).
+	SNMPCredentials []SNMPCredentials `mapstructure:"snmp_credentials"`
+	SNMPTargets     []SNMPTarget      `mapstructure:"snmp_targets"`
+	SNMPInterval    time.Duration     `mapstructure:"snmp_interval"`
+	SNMPTimeout     time.Duration     `mapstructure:"snmp_timeout"`
+	SNMPRetries     int               `mapstructure:"snmp_retries"`
+
+	// Kafka publishing of inventory events (no brokers = disabled);
+	// encoding json or protobuf.
+	KafkaBrokers  []string `mapstructure:"kafka_brokers"`
+	KafkaTopic    string   `mapstructure:"kafka_topic"`
+	KafkaEncoding string   `mapstructure:"kafka_encoding"`
+
+	// MQTT publishing of inventory events (empty broker = disabled).
+	MQTTBroker   string `mapstructure:"mqtt_broker"`
+	MQTTClientID string `mapstructure:"mqtt_client_id"`
+	MQTTUsername string `mapstructure:"mqtt_username"`
+	MQTTPassword string `mapstructure:"mqtt_password"`
+	MQTTTopic    string `mapstructure:"mqtt_topic"`
+	MQTTQoS      int    `mapstructure:"mqtt_qos"`
+	MQTTRetain   bool   `mapstructure:"mqtt_retain"`
+
+	// NATS JetStream publishing of inventory and agent events (empty URL =
+	// disabled).
+	NATSURL           string `mapstructure:"nats_url"`
+	NATSCredsFile     string `mapstructure:"nats_creds_file"`
+	NATSStream        string `mapstructure:"nats_stream"`
+	NATSSubjectPrefix string `mapstructure:"nats_subject_prefix"`
+
+	// OpenSearch or Elasticsearch indexing of submitted inventories (empty
+	// URL = disabled).
+	OpenSearchURL      string `mapstructure:"opensearch_url"`
+	OpenSearchIndex    string `mapstructure:"opensearch_index"`
+	OpenSearchUsername string `mapstructure:"opensearch_username"`
+	OpenSearchPassword string `mapstructure:"opensearch_password"`
+	OpenSearchAPIKey   string `mapstructure:"opensearch_api_key"`
+
+	// Object storage backups (empty URL = disabled): s3://, gs:// or
+	// azblob:// bucket URL, credentials and retention.
+	BackupURL             string        `mapstructure:"backup_url"`
+	BackupInterval        time.Duration `mapstructure:"backup_interval"`
+	BackupEndpoint        string        `mapstructure:"backup_endpoint"`
+	BackupRegion          string        `mapstructure:"backup_region"`
+	BackupAccessKeyID     string        `mapstructure:"backup_access_key_id"`
+	BackupSecretAccessKey string        `mapstructure:"backup_secret_access_key"`
+	BackupSASToken        string        `mapstructure:"backup_sas_token"`
+	BackupKeepSnapshots   int           `mapstructure:"backup_keep_snapshots"`
+	BackupRetentionDays   int           `mapstructure:"backup_retention_days"`
+
+	// Logging: level debug, info, warn or error; format text or json.
+	LogLevel  string `mapstructure:"log_level"`
+	LogFormat string `mapstructure:"log_format"`
+}
+
+// SiteToken is a secret that grants access to the listed sites only. It is
+// accepted wherever client_secret or api_secret is.
+type SiteToken struct {
+	Token string   `mapstructure:"token"`
+	Sites []string `mapstructure:"sites"`
+}
+
+// AlertWebhook is a webhook endpoint that receives alerts, signed with
+// Secret unless it is empty.
+type AlertWebhook struct {
+	URL    string `mapstructure:"url" yaml:"url"`
+	Secret string `mapstructure:"secret" yaml:"secret"`
+}
+
+// SnipeITSite configures the Snipe-IT assets of the hosts of a site. Site
+// "*" applies to every site without its own entry.
+type SnipeITSite struct {
+	Site           string            `mapstructure:"site" yaml:"site"`
+	StatusID       int               `mapstructure:"status_id" yaml:"status_id"`
+	CategoryID     int               `mapstructure:"category_id" yaml:"category_id"`
+	FieldsetID     int               `mapstructure:"fieldset_id" yaml:"fieldset_id"`
+	CompanyID      int               `mapstructure:"company_id" yaml:"company_id"`
+	LocationID     int               `mapstructure:"location_id" yaml:"location_id"`
+	AssetTagPrefix string            `mapstructure:"asset_tag_prefix" yaml:"asset_tag_prefix"`
+	Fields         map[string]string `mapstructure:"fields" yaml:"fields"`
+}
+
+// NetBoxSite configures the NetBox devices of the hosts of a site. Site "*"
+// applies to every site without its own entry.
+type NetBoxSite struct {
+	Site     string         `mapstructure:"site" yaml:"site"`
+	SiteID   int            `mapstructure:"site_id" yaml:"site_id"`
+	RoleID   int            `mapstructure:"role_id" yaml:"role_id"`
+	Roles    map[string]int `mapstructure:"roles" yaml:"roles"`
+	TenantID int            `mapstructure:"tenant_id" yaml:"tenant_id"`
+	Status   string         `mapstructure:"status" yaml:"status"`
+}
+
+// LDAPAttributes names the LDAP attributes holding the enriched values; an
+// empty name leaves the value out.
+type LDAPAttributes struct {
+	Description string `mapstructure:"description" yaml:"description"`
+	DisplayName string `mapstructure:"display_name" yaml:"display_name"`
+	Department  string `mapstructure:"department" yaml:"department"`
+}
+
+// EmailReport is a report mailed to To on Schedule ("mon 08:00" or "daily
+// 07:30"). Reports lists the report kinds; StaleDays is the stale period
+// (0 = 14 days) and Sites restricts the report (empty = all sites).
+type EmailReport struct {
+	Name      string   `mapstructure:"name" yaml:"name"`
+	To        []string `mapstructure:"to" yaml:"to"`
+	Schedule  string   `mapstructure:"schedule" yaml:"schedule"`
+	Reports   []string `mapstructure:"reports" yaml:"reports"`
+	Sites     []string `mapstructure:"sites" yaml:"sites"`
+	StaleDays int      `mapstructure:"stale_days" yaml:"stale_days"`
+}
+
+// SNMPCredentials are named SNMP credentials for targets to refer to.
+// Version is 1, 2c or 3; SNMPv3 uses the USM fields and empty protocols
+// disable authentication or privacy.
+type SNMPCredentials struct {
+	Name         string `mapstructure:"name" yaml:"name"`
+	Version      string `mapstructure:"version" yaml:"version"`
+	Community    string `mapstructure:"community" yaml:"community"`
+	Username     string `mapstructure:"username" yaml:"username"`
+	AuthProtocol string `mapstructure:"auth_protocol" yaml:"auth_protocol"`
+	AuthPassword string `mapstructure:"auth_password" yaml:"auth_password"`
+	PrivProtocol string `mapstructure:"priv_protocol" yaml:"priv_protocol"`
+	PrivPassword string `mapstructure:"priv_password" yaml:"priv_password"`
+}
+
+// SNMPTarget is a device to poll with the named Credentials. Name replaces
+// its sysName as the hostname and Class its detected device class.
+type SNMPTarget struct {
+	Address     string `mapstructure:"address" yaml:"address"`
+	Site        string `mapstructure:"site" yaml:"site"`
+	Name        string `mapstructure:"name" yaml:"name"`
+	Class       string `mapstructure:"class" yaml:"class"`
+	Credentials string `mapstructure:"credentials" yaml:"credentials"`
 }
 
 // Load reads configuration from file and environment.
@@ -34,14 +294,72 @@ func Load(cfgFile string) (*Config, error) {
 	viper.SetDefault("listen", ":9550")
 	viper.SetDefault("http_listen", ":9551")
 	viper.SetDefault("enable_swagger", true)
+	viper.SetDefault("json_proto_names", false)
+	viper.SetDefault("swagger_auth", "none")
 	viper.SetDefault("database", "inventory.db")
+	viper.SetDefault("auto_migrate", true)
+	viper.SetDefault("write_queue_size", 1024)
+	viper.SetDefault("write_batch_size", 64)
+	viper.SetDefault("latest_cache_size", 1024)
+	viper.SetDefault("latest_cache_ttl", "30s")
+	viper.SetDefault("list_count_cache_ttl", "30s")
+	viper.SetDefault("grpc_max_connections", 0)
+	viper.SetDefault("grpc_max_connections_per_ip", 0)
+	viper.SetDefault("grpc_max_concurrent_streams", 100)
+	viper.SetDefault("grpc_workers", 256)
 	viper.SetDefault("retention_days", 0)
 	viper.SetDefault("purge_interval", "24h")
+	viper.SetDefault("purge_batch_size", 1000)
+	viper.SetDefault("purge_batch_pause", "100ms")
+	viper.SetDefault("submit_max_bytes", 4<<20)
+	viper.SetDefault("submit_max_clock_skew", "24h")
+	viper.SetDefault("submit_max_age", "2160h")
+	viper.SetDefault("enable_alerts", true)
+	viper.SetDefault("alert_syslog_format", "cef")
+	viper.SetDefault("snipeit_interval", "1h")
+	viper.SetDefault("netbox_interval", "1h")
+	viper.SetDefault("ldap_computer_filter", "(&(objectClass=computer)(cn={name}))")
+	viper.SetDefault("ldap_user_filter", "(&(objectClass=user)(sAMAccountName={name}))")
+	viper.SetDefault("ldap_attributes.description", "description")
+	viper.SetDefault("ldap_attributes.display_name", "displayName")
+	viper.SetDefault("ldap_attributes.department", "department")
+	viper.SetDefault("ldap_interval", "6h")
+	viper.SetDefault("smtp_port", 587)
+	viper.SetDefault("smtp_security", "starttls")
+	viper.SetDefault("snmp_interval", "6h")
+	viper.SetDefault("snmp_timeout", "5s")
+	viper.SetDefault("snmp_retries", 1)
+	viper.SetDefault("kafka_brokers", []string{})
+	viper.SetDefault("kafka_topic", "inventory-events")
+	viper.SetDefault("kafka_encoding", "json")
+	viper.SetDefault("mqtt_client_id", "inventory-collector")
+	viper.SetDefault("mqtt_topic", "tangra/inventory/{site}/{hostname}")
+	viper.SetDefault("mqtt_qos", 1)
+	viper.SetDefault("nats_stream", "INVENTORY")
+	viper.SetDefault("nats_subject_prefix", "tangra.inventory")
+	viper.SetDefault("opensearch_index", "tangra-inventory")
+	viper.SetDefault("backup_interval", "24h")
+	viper.SetDefault("backup_region", "us-east-1")
+	viper.SetDefault("backup_keep_snapshots", 7)
+	viper.SetDefault("log_level", "info")
+	viper.SetDefault("log_format", "text")
+	viper.SetDefault("agent_allow_cidrs", []string{})
+	viper.SetDefault("agent_deny_cidrs", []string{})
 
 	viper.SetEnvPrefix("COLLECTOR")
 	viper.AutomaticEnv()
 
-	_ = viper.ReadInConfig()
+	// Without a config file the defaults apply, but a config file that
+	// cannot be read or parsed is an error. The search also matches an
+	// extensionless file named "collector", such as a binary in the working
+	// directory; that one is skipped.
+	if err := viper.ReadInConfig(); err != nil {
+		var notFound viper.ConfigFileNotFoundError
+		found := !errors.As(err, &notFound) && filepath.Ext(viper.ConfigFileUsed()) != ""
+		if cfgFile != "" || found {
+			return nil, fmt.Errorf("read config file: %w", err)
+		}
+	}
 
 	var cfg Config
 	if err := viper.Unmarshal(&cfg); err != nil {
@@ -50,3 +368,55 @@ func Load(cfgFile string) (*Config, error) {
 
 	return &cfg, nil
 }
+
+// File returns the path of the config file read by Load, or "" when the
+// defaults and environment were used alone.
+func File() string {
+	if f := viper.ConfigFileUsed(); filepath.Ext(f) != "" {
+		return f
+	}
+	return ""
+}
+
+// redacted replaces set secrets in printed configurations.
+const redacted = "<redacted>"
+
+// Redacted returns a copy of c with its secrets masked, for display.
+func (c *Config) Redacted() *Config {
+	r := *c
+	for _, s := range []*string{&r.ClientSecret, &r.ApiSecret, &r.AdminSecret, &r.SwaggerPassword, &r.AlertSlackWebhookURL, &r.SnipeITToken, &r.NetBoxToken, &r.LDAPBindPassword, &r.SMTPPassword, &r.MetricsToken, &r.IntuneClientSecret, &r.MQTTPassword, &r.OpenSearchPassword, &r.OpenSearchAPIKey, &r.BackupSecretAccessKey, &r.BackupSASToken} {
+		if *s != "" {
+			*s = redacted
+		}
+	}
+	if u, err := url.Parse(r.AlertWebhookURL); err == nil && r.AlertWebhookURL != "" {
+		r.AlertWebhookURL = u.Redacted()
+	}
+	if u, err := url.Parse(r.SCCMDSN); err == nil && r.SCCMDSN != "" {
+		r.SCCMDSN = u.Redacted()
+	}
+	if u, err := url.Parse(r.OpenSearchURL); err == nil && r.OpenSearchURL != "" {
+		r.OpenSearchURL = u.Redacted()
+	}
+	r.AlertWebhooks = make([]AlertWebhook, len(c.AlertWebhooks))
+	for i, w := range c.AlertWebhooks {
+		r.AlertWebhooks[i] = w
+		if w.Secret != "" {
+			r.AlertWebhooks[i].Secret = redacted
+		}
+	}
+	r.SNMPCredentials = make([]SNMPCredentials, len(c.SNMPCredentials))
+	for i, cred := range c.SNMPCredentials {
+		r.SNMPCredentials[i] = cred
+		for _, s := range []*string{&r.SNMPCredentials[i].Community, &r.SNMPCredentials[i].AuthPassword, &r.SNMPCredentials[i].PrivPassword} {
+			if *s != "" {
+				*s = redacted
+			}
+		}
+	}
+	r.SiteTokens = make([]SiteToken, len(c.SiteTokens))
+	for i, t := range c.SiteTokens {
+		r.SiteTokens[i] = SiteToken{Token: redacted, Sites: t.Sites}
+	}
+	return &r
+}
@@ -5,18 +5,26 @@ import (
 	"time"
 
 	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+	"github.com/go-tangra/go-tangra-inventory/internal/diff"
 	"github.com/go-tangra/go-tangra-inventory/internal/store"
 
 	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-// InventoryToRecord converts a proto Inventory to a store record.
+// InventoryToRecord converts a proto Inventory to a store record, setting
+// its schema version to SchemaVersion, which it is encoded in.
 func InventoryToRecord(inv *collectorv1.Inventory) (*store.InventoryRecord, error) {
+	inv.SchemaVersion = SchemaVersion
 	jsonBytes, err := protojson.Marshal(inv)
 	if err != nil {
 		return nil, fmt.Errorf("marshal inventory to JSON: %w", err)
 	}
+	protoBytes, err := proto.Marshal(inv)
+	if err != nil {
+		return nil, fmt.Errorf("marshal inventory: %w", err)
+	}
 
 	var collectedAt time.Time
 	if inv.CollectedAt != nil {
@@ -32,19 +40,32 @@ func InventoryToRecord(inv *collectorv1.Inventory) (*store.InventoryRecord, erro
 	}
 
 	return &store.InventoryRecord{
-		Hostname:      inv.Hostname,
-		Username:      inv.Username,
-		SystemUUID:    systemUUID,
-		SystemSerial:  systemSerial,
-		CollectedAt:   collectedAt,
-		InventoryJSON: string(jsonBytes),
+		Site:           inv.Site,
+		Hostname:       inv.Hostname,
+		Username:       inv.Username,
+		SystemUUID:     systemUUID,
+		SystemSerial:   systemSerial,
+		CollectedAt:    collectedAt,
+		DeviceClass:    inv.DeviceClass,
+		Source:         inv.Source,
+		InventoryJSON:  string(jsonBytes),
+		InventoryProto: protoBytes,
 	}, nil
 }
 
-// RecordToInventory converts a store record back to a proto Inventory.
+// RecordToInventory converts a store record back to a proto Inventory, from
+// its binary encoding when it has one and from its JSON otherwise, upgraded
+// to SchemaVersion if it was stored with an older one.
 func RecordToInventory(rec *store.InventoryRecord) (*collectorv1.Inventory, error) {
 	var inv collectorv1.Inventory
-	if err := protojson.Unmarshal([]byte(rec.InventoryJSON), &inv); err != nil {
+	if len(rec.InventoryProto) > 0 {
+		if err := proto.Unmarshal(rec.InventoryProto, &inv); err != nil {
+			return nil, fmt.Errorf("unmarshal inventory: %w", err)
+		}
+		upgradeInventory(&inv, inv.SchemaVersion)
+		return &inv, nil
+	}
+	if err := decodeJSON([]byte(rec.InventoryJSON), &inv); err != nil {
 		return nil, fmt.Errorf("unmarshal inventory JSON: %w", err)
 	}
 	return &inv, nil
@@ -60,5 +81,102 @@ func RecordToSummary(rec *store.InventoryRecord) *collectorv1.InventorySummary {
 		SystemSerial: rec.SystemSerial,
 		CollectedAt:  timestamppb.New(rec.CollectedAt),
 		StoredAt:     timestamppb.New(rec.StoredAt),
+		Site:         rec.Site,
+		DeviceClass:  rec.DeviceClass,
+		Source:       rec.Source,
+	}
+}
+
+// HostToSummary converts a store host rollup to a HostSummary proto.
+func HostToSummary(h *store.HostRecord) *collectorv1.HostSummary {
+	return &collectorv1.HostSummary{
+		Hostname:   h.Hostname,
+		SystemUuid: h.SystemUUID,
+		LastSeen:   timestamppb.New(h.LastSeen),
+		LatestId:   h.LatestID,
+		Site:       h.Site,
+		Directory:  DirectoryToProto(h.Directory),
+		Fields:     h.Fields,
+	}
+}
+
+// DirectoryToProto converts a host's directory information to a
+// HostDirectory proto; nil stays nil.
+func DirectoryToProto(d *store.HostDirectory) *collectorv1.HostDirectory {
+	if d == nil {
+		return nil
+	}
+	return &collectorv1.HostDirectory{
+		Ou:              d.OU,
+		Description:     d.Description,
+		Username:        d.Username,
+		UserDisplayName: d.UserDisplayName,
+		Department:      d.Department,
+		UpdatedAt:       timestamppb.New(d.UpdatedAt),
+	}
+}
+
+// AlertToProto converts a stored alert to an Alert proto.
+func AlertToProto(a *store.AlertRecord) *collectorv1.Alert {
+	return &collectorv1.Alert{
+		Id:           a.ID,
+		Hostname:     a.Hostname,
+		InventoryId:  a.InventoryID,
+		Rule:         a.Rule,
+		Severity:     a.Severity,
+		Message:      a.Message,
+		CreatedAt:    timestamppb.New(a.CreatedAt),
+		Acknowledged: a.Acknowledged,
+		Site:         a.Site,
+	}
+}
+
+// ChangeToProto converts a hardware change to an InventoryChange proto.
+func ChangeToProto(c diff.Change) *collectorv1.InventoryChange {
+	return &collectorv1.InventoryChange{
+		Type:        string(c.Type),
+		Component:   c.Component,
+		Key:         c.Key,
+		Field:       c.Field,
+		OldValue:    c.Old,
+		NewValue:    c.New,
+		Description: c.String(),
+	}
+}
+
+// TokenToProto converts a stored API token to an ApiToken proto, without
+// its hash.
+func TokenToProto(t *store.TokenRecord) *collectorv1.ApiToken {
+	pb := &collectorv1.ApiToken{
+		Id:        t.ID,
+		Name:      t.Name,
+		Role:      t.Role,
+		Sites:     t.Sites,
+		CreatedAt: timestamppb.New(t.CreatedAt),
+	}
+	if !t.ExpiresAt.IsZero() {
+		pb.ExpiresAt = timestamppb.New(t.ExpiresAt)
+	}
+	if !t.RevokedAt.IsZero() {
+		pb.RevokedAt = timestamppb.New(t.RevokedAt)
+	}
+	return pb
+}
+
+// DeliveryToProto converts a recorded webhook delivery to a WebhookDelivery
+// proto, without its payload.
+func DeliveryToProto(d *store.WebhookDelivery) *collectorv1.WebhookDelivery {
+	pb := &collectorv1.WebhookDelivery{
+		Id:         d.ID,
+		Url:        d.URL,
+		Status:     d.Status,
+		Attempts:   int32(d.Attempts),
+		StatusCode: int32(d.StatusCode),
+		Error:      d.LastError,
+		CreatedAt:  timestamppb.New(d.CreatedAt),
+	}
+	if !d.LastAttemptAt.IsZero() {
+		pb.LastAttemptAt = timestamppb.New(d.LastAttemptAt)
 	}
+	return pb
 }
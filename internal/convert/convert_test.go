@@ -0,0 +1,114 @@
+package convert
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+	"github.com/go-tangra/go-tangra-inventory/internal/store"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// benchInventory returns a typical desktop inventory.
+func benchInventory() *collectorv1.Inventory {
+	inv := &collectorv1.Inventory{
+		CollectedAt: timestamppb.New(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)),
+		Hostname:    "ws-00042",
+		Username:    "jdoe",
+		System: &collectorv1.SystemInfo{
+			Manufacturer: "Dell Inc.",
+			ProductName:  "OptiPlex 7090",
+			SerialNumber: "SN00000042",
+			Uuid:         "4c4c4544-0000-1000-8000-000000000042",
+		},
+		Processors: []*collectorv1.ProcessorInfo{{
+			SocketDesignation: "CPU1",
+			Manufacturer:      "Intel(R) Corporation",
+			Version:           "Intel(R) Core(TM) i7-11700 CPU @ 2.50GHz",
+			CoreCount:         8,
+			ThreadCount:       16,
+		}},
+		Memory:  &collectorv1.MemoryInfo{},
+		Monitor: []*collectorv1.MonitorInfo{{Manufacturer: "DEL", Model: "DELL P2422H", SerialNumber: "7XK2QH3"}},
+	}
+	for _, slot := range []string{"DIMM A1", "DIMM B1"} {
+		inv.Memory.Modules = append(inv.Memory.Modules, &collectorv1.MemoryModule{
+			DeviceLocator: slot,
+			CapacityBytes: 16 << 30,
+			MemoryType:    "DDR4",
+			SpeedMtS:      3200,
+		})
+		inv.Memory.TotalPhysicalBytes += 16 << 30
+	}
+	return inv
+}
+
+func BenchmarkInventoryToRecord(b *testing.B) {
+	inv := benchInventory()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := InventoryToRecord(inv); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRecordToInventory(b *testing.B) {
+	rec, err := InventoryToRecord(benchInventory())
+	if err != nil {
+		b.Fatal(err)
+	}
+	jsonOnly := *rec
+	jsonOnly.InventoryProto = nil
+	// Inventories stored before schema versioning lack schemaVersion.
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(rec.InventoryJSON), &doc); err != nil {
+		b.Fatal(err)
+	}
+	delete(doc, "schemaVersion")
+	data, err := json.Marshal(doc)
+	if err != nil {
+		b.Fatal(err)
+	}
+	legacy := jsonOnly
+	legacy.InventoryJSON = string(data)
+
+	for _, bm := range []struct {
+		name string
+		rec  *store.InventoryRecord
+	}{
+		{"Proto", rec},
+		{"JSON", &jsonOnly},
+		{"LegacyJSON", &legacy},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := RecordToInventory(bm.rec); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// TestRecordToInventoryLegacyJSON checks that an inventory stored as JSON
+// before schema versioning decodes upgraded to SchemaVersion.
+func TestRecordToInventoryLegacyJSON(t *testing.T) {
+	rec := &store.InventoryRecord{
+		InventoryJSON: `{"hostname":"ws-00042","system":{"uuid":"4c4c4544-0000-1000-8000-000000000042"},"memory":{"totalPhysicalBytes":"17179869184"}}`,
+	}
+	inv, err := RecordToInventory(rec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inv.SchemaVersion != SchemaVersion {
+		t.Errorf("schema version %d, want %d", inv.SchemaVersion, SchemaVersion)
+	}
+	if inv.Hostname != "ws-00042" || inv.GetSystem().GetUuid() != "4c4c4544-0000-1000-8000-000000000042" ||
+		inv.GetMemory().GetTotalPhysicalBytes() != 16<<30 {
+		t.Errorf("decoded %v", inv)
+	}
+}
@@ -0,0 +1,169 @@
+package convert
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+	"github.com/go-tangra/go-tangra-inventory/internal/store"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// summaryInventoryField is the InventorySummary field that carries masked
+// inventory sections in list responses.
+const summaryInventoryField = "inventory"
+
+// MaskPaths validates a read mask against the fields of m and returns its
+// normalized paths. A nil or empty mask yields nil, meaning "everything".
+func MaskPaths(m proto.Message, mask *fieldmaskpb.FieldMask) ([]string, error) {
+	if len(mask.GetPaths()) == 0 {
+		return nil, nil
+	}
+	if !mask.IsValid(m) {
+		return nil, fmt.Errorf("invalid read_mask %q for %s", strings.Join(mask.GetPaths(), ","), m.ProtoReflect().Descriptor().Name())
+	}
+	mask.Normalize()
+	return mask.GetPaths(), nil
+}
+
+// RecordToInventoryMasked converts a store record to a proto Inventory holding
+// only the fields named by paths. Only the top-level sections selected by the
+// mask are decoded from the stored JSON; the binary encoding, when stored,
+// is decoded whole as it is cheaper still. nil paths select everything.
+func RecordToInventoryMasked(rec *store.InventoryRecord, paths []string) (*collectorv1.Inventory, error) {
+	if paths == nil {
+		return RecordToInventory(rec)
+	}
+	if len(rec.InventoryProto) > 0 {
+		inv, err := RecordToInventory(rec)
+		if err != nil {
+			return nil, err
+		}
+		ApplyMask(inv, paths)
+		return inv, nil
+	}
+
+	var sections map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(rec.InventoryJSON), &sections); err != nil {
+		return nil, fmt.Errorf("unmarshal inventory JSON: %w", err)
+	}
+	from, err := upgradeJSON(sections)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal inventory JSON: %w", err)
+	}
+
+	fields := (&collectorv1.Inventory{}).ProtoReflect().Descriptor().Fields()
+	keep := make(map[string]bool, len(paths))
+	for _, p := range paths {
+		top, _, _ := strings.Cut(p, ".")
+		if fd := fields.ByName(protoreflect.Name(top)); fd != nil {
+			keep[fd.JSONName()] = true
+			keep[string(fd.Name())] = true
+		}
+	}
+	for key := range sections {
+		if !keep[key] {
+			delete(sections, key)
+		}
+	}
+
+	var inv collectorv1.Inventory
+	if err := decodeUpgraded(sections, from, &inv); err != nil {
+		return nil, fmt.Errorf("unmarshal inventory JSON: %w", err)
+	}
+	ApplyMask(&inv, paths)
+	return &inv, nil
+}
+
+// RecordToSummaryMasked converts a store record to an InventorySummary holding
+// only the fields named by paths. Paths under "inventory" attach the matching
+// sections of the stored inventory. nil paths return the plain summary.
+func RecordToSummaryMasked(rec *store.InventoryRecord, paths []string) (*collectorv1.InventorySummary, error) {
+	summary := RecordToSummary(rec)
+	if paths == nil {
+		return summary, nil
+	}
+
+	if MasksInventory(paths) {
+		var invPaths []string
+		for _, p := range paths {
+			if sub, ok := strings.CutPrefix(p, summaryInventoryField+"."); ok {
+				invPaths = append(invPaths, sub)
+			}
+		}
+		inv, err := RecordToInventoryMasked(rec, invPaths)
+		if err != nil {
+			return nil, err
+		}
+		summary.Inventory = inv
+	}
+
+	ApplyMask(summary, paths)
+	return summary, nil
+}
+
+// MasksInventory reports whether summary paths select any part of the
+// stored inventory, which then has to be loaded alongside the summary.
+func MasksInventory(paths []string) bool {
+	for _, p := range paths {
+		if p == summaryInventoryField || strings.HasPrefix(p, summaryInventoryField+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplyMask clears every field of m that is not named by paths. Paths are
+// expected to be valid for m; nil paths leave m untouched.
+func ApplyMask(m proto.Message, paths []string) {
+	if paths == nil {
+		return
+	}
+	root := maskNode{}
+	for _, p := range paths {
+		root.add(strings.Split(p, "."))
+	}
+	root.prune(m.ProtoReflect())
+}
+
+// maskNode is a path tree built from a field mask. A node without children
+// selects its whole field.
+type maskNode map[protoreflect.Name]maskNode
+
+func (n maskNode) add(parts []string) {
+	name := protoreflect.Name(parts[0])
+	child, ok := n[name]
+	if ok && child == nil {
+		return // already selected in full
+	}
+	if len(parts) == 1 {
+		n[name] = nil
+		return
+	}
+	if child == nil {
+		child = maskNode{}
+		n[name] = child
+	}
+	child.add(parts[1:])
+}
+
+func (n maskNode) prune(m protoreflect.Message) {
+	var drop []protoreflect.FieldDescriptor
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		child, ok := n[fd.Name()]
+		switch {
+		case !ok:
+			drop = append(drop, fd)
+		case child != nil && fd.Message() != nil && !fd.IsList() && !fd.IsMap():
+			child.prune(v.Message())
+		}
+		return true
+	})
+	for _, fd := range drop {
+		m.Clear(fd)
+	}
+}
@@ -0,0 +1,130 @@
+package convert
+
+import (
+	"encoding/json"
+	"fmt"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// SchemaVersion is the version of the Inventory schema this build stores
+// inventories with, recorded in their schema_version. Changes that older
+// stored inventories need upgrading for, such as renaming a field, which
+// breaks decoding their JSON, or a field whose value older inventories
+// imply, increment it and add a step to schemaUpgrades.
+const SchemaVersion = 1
+
+// schemaUpgrade upgrades a stored inventory from the schema version before
+// it to its own. Either function may be nil.
+type schemaUpgrade struct {
+	// json rewrites the top-level sections of an inventory's protojson
+	// encoding so that it decodes, e.g. renaming a field.
+	json func(doc map[string]json.RawMessage) error
+	// inventory completes the decoded inventory, however it was stored, e.g.
+	// deriving a field older inventories lack.
+	inventory func(inv *collectorv1.Inventory)
+}
+
+// schemaUpgrades[v-1] upgrades an inventory of schema version v-1 to v.
+// Steps are never changed or removed once released, as stored inventories
+// of every version before them may still be read.
+var schemaUpgrades = []schemaUpgrade{
+	// 1: the schema when versioning was introduced. Inventories without a
+	// version are already in it.
+	{},
+}
+
+// schemaVersionKeys are the keys the schema version may be stored under in
+// protojson, which accepts both the JSON and the proto field name.
+var schemaVersionKeys = []string{"schemaVersion", "schema_version"}
+
+// upgradeJSON brings the top-level sections of a stored inventory's JSON up
+// to SchemaVersion, and returns the version they were stored with.
+func upgradeJSON(doc map[string]json.RawMessage) (uint32, error) {
+	var from uint32
+	for _, key := range schemaVersionKeys {
+		if raw, ok := doc[key]; ok {
+			if err := json.Unmarshal(raw, &from); err != nil {
+				return 0, fmt.Errorf("schema version: %w", err)
+			}
+			delete(doc, key)
+		}
+	}
+	for v := from; v < SchemaVersion; v++ {
+		if up := schemaUpgrades[v].json; up != nil {
+			if err := up(doc); err != nil {
+				return 0, fmt.Errorf("upgrade schema version %d to %d: %w", v, v+1, err)
+			}
+		}
+	}
+	return from, nil
+}
+
+// rewritesJSON reports whether upgrading an inventory of schema version
+// from to SchemaVersion rewrites its JSON.
+func rewritesJSON(from uint32) bool {
+	for v := from; v < SchemaVersion; v++ {
+		if schemaUpgrades[v].json != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// upgradeInventory completes a decoded inventory of schema version from up
+// to SchemaVersion. Inventories of a later version, written by a newer
+// collector, are left as they are.
+func upgradeInventory(inv *collectorv1.Inventory, from uint32) {
+	if from >= SchemaVersion {
+		return
+	}
+	for v := from; v < SchemaVersion; v++ {
+		if up := schemaUpgrades[v].inventory; up != nil {
+			up(inv)
+		}
+	}
+	inv.SchemaVersion = SchemaVersion
+}
+
+// decodeJSON decodes a stored inventory's JSON, upgrading it to
+// SchemaVersion first if it is older. Fields of later versions, written by
+// a newer collector, are dropped.
+func decodeJSON(data []byte, inv *collectorv1.Inventory) error {
+	// Inventories of the current version, and older ones whose JSON no
+	// upgrade step rewrites, decode as they are.
+	if err := protojson.Unmarshal(data, inv); err == nil && !rewritesJSON(inv.SchemaVersion) {
+		upgradeInventory(inv, inv.SchemaVersion)
+		return nil
+	}
+
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	from, err := upgradeJSON(doc)
+	if err != nil {
+		return err
+	}
+	return decodeUpgraded(doc, from, inv)
+}
+
+// decodeUpgraded decodes the sections of a stored inventory of schema
+// version from, which upgradeJSON brought up to SchemaVersion.
+func decodeUpgraded(doc map[string]json.RawMessage, from uint32, inv *collectorv1.Inventory) error {
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	inv.Reset()
+	opts := protojson.UnmarshalOptions{DiscardUnknown: from > SchemaVersion}
+	if err := opts.Unmarshal(data, inv); err != nil {
+		return err
+	}
+	if from > SchemaVersion {
+		inv.SchemaVersion = from
+	}
+	upgradeInventory(inv, from)
+	return nil
+}
@@ -0,0 +1,55 @@
+package daemon
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// Default reconnect delays.
+const (
+	DefaultBackoffBase = 1 * time.Second
+	DefaultBackoffMax  = 2 * time.Minute
+)
+
+// stableStream is how long a command stream must have stayed up for the
+// next disconnect to start the backoff over.
+const stableStream = time.Minute
+
+// Backoff is the reconnect policy applied once every collector has failed.
+// Delays grow exponentially from Base up to Max.
+type Backoff struct {
+	// Base is the delay after the first failed round (0 = DefaultBackoffBase).
+	Base time.Duration
+	// Max caps the delay (0 = DefaultBackoffMax).
+	Max time.Duration
+	// Jitter waits a random time between zero and the exponential delay
+	// ("full jitter"), so that agents dropped by a collector restart do not
+	// reconnect in lockstep.
+	Jitter bool
+	// MaxRetries gives up after this many consecutive failed rounds, and
+	// Run returns an error so that the service manager can step in
+	// (0 = retry forever).
+	MaxRetries int
+}
+
+// Delay returns the wait before reconnect attempt n, counted from 1.
+func (b Backoff) Delay(n int) time.Duration {
+	base, limit := b.Base, b.Max
+	if base <= 0 {
+		base = DefaultBackoffBase
+	}
+	if limit <= 0 {
+		limit = DefaultBackoffMax
+	}
+
+	d := base
+	for i := 1; i < n && d < limit; i++ {
+		d *= 2
+	}
+	d = min(d, limit)
+
+	if b.Jitter {
+		d = rand.N(d + 1)
+	}
+	return d
+}
@@ -0,0 +1,48 @@
+package daemon
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+)
+
+// commandNames names the command types for the allowlist and the status
+// counters.
+var commandNames = map[collectorv1.InventoryCommandType]string{
+	collectorv1.InventoryCommandType_INVENTORY_COMMAND_TYPE_REFRESH: "refresh",
+	collectorv1.InventoryCommandType_INVENTORY_COMMAND_TYPE_UPDATE:  "update",
+	collectorv1.InventoryCommandType_INVENTORY_COMMAND_TYPE_PAUSE:   "pause",
+	collectorv1.InventoryCommandType_INVENTORY_COMMAND_TYPE_RESUME:  "resume",
+	collectorv1.InventoryCommandType_INVENTORY_COMMAND_TYPE_LOGS:    "logs",
+	collectorv1.InventoryCommandType_INVENTORY_COMMAND_TYPE_PING:    "ping",
+}
+
+// ParseCommands parses a comma-separated list of command names, e.g.
+// "refresh,pause,resume", into a set for Config.AllowedCommands. An empty
+// string yields nil, which allows every command.
+func ParseCommands(s string) (map[string]bool, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+
+	var known []string
+	for _, name := range commandNames {
+		known = append(known, name)
+	}
+	slices.Sort(known)
+
+	allowed := make(map[string]bool)
+	for _, name := range strings.Split(s, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if !slices.Contains(known, name) {
+			return nil, fmt.Errorf("unknown command %q (use %s)", name, strings.Join(known, ", "))
+		}
+		allowed[name] = true
+	}
+	return allowed, nil
+}
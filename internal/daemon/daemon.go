@@ -2,75 +2,245 @@ package daemon
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
-	"math"
+	"log/slog"
+	"math/rand/v2"
+	"sync"
 	"time"
 
 	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+	"github.com/go-tangra/go-tangra-inventory/internal/cache"
 	"github.com/go-tangra/go-tangra-inventory/internal/collector"
+	"github.com/go-tangra/go-tangra-inventory/internal/logging"
+	"github.com/go-tangra/go-tangra-inventory/internal/schedule"
 	"github.com/go-tangra/go-tangra-inventory/internal/sender"
+	"github.com/go-tangra/go-tangra-inventory/internal/spool"
+	"github.com/go-tangra/go-tangra-inventory/internal/update"
 
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 // Config holds daemon-mode configuration.
 type Config struct {
-	CollectorAddr string
-	ClientSecret  string
-	ClientID      string
-	Version       string
+	// Collectors are the collector addresses in failover order.
+	Collectors   []string
+	ClientSecret string
+	// ClientID identifies the agent to the collector: the system UUID, or
+	// the hostname when there is none.
+	ClientID    string
+	Hostname    string
+	Site        string
+	Compression string
+	TLS         sender.TLS
+	Proxy       string
+	// MaxRate caps the upload rate in bytes per second (0 = unlimited).
+	MaxRate int64
+	Version string
+	// Interval re-collects and submits the inventory periodically, in
+	// addition to refresh commands (0 = only on command).
+	Interval time.Duration
+	// Schedule delays the initial and periodic submissions by random jitter
+	// and confines them to submit windows. Refresh commands are answered
+	// immediately.
+	Schedule schedule.Policy
+	// Spool keeps inventories while the collector is unreachable; they are
+	// replayed on reconnect (nil = no spooling).
+	Spool *spool.Spool
+	// SkipUnchanged skips scheduled submissions whose inventory is identical
+	// to the last one sent. Refresh commands always submit.
+	SkipUnchanged bool
+	// MaxUnchanged submits an unchanged inventory anyway once the last
+	// submission is older than this, so the host keeps showing as alive
+	// (0 = never).
+	MaxUnchanged time.Duration
+	// Updater installs agent releases advertised by the collector (nil =
+	// update commands are ignored).
+	Updater *update.Updater
+	// StatusAddr is a loopback host:port serving the agent status as JSON
+	// on /status for endpoint management tools (empty = disabled).
+	StatusAddr string
+	// StateFile persists agent state, such as a pause, across restarts
+	// (empty = kept in memory only).
+	StateFile string
+	// Backoff is the reconnect policy.
+	Backoff Backoff
+	// AllowedCommands restricts the command types the agent executes, by
+	// name as parsed by ParseCommands; others are logged and ignored (nil =
+	// all).
+	AllowedCommands map[string]bool
+	// Cache keeps a copy of the last submitted inventory for local diffs
+	// (empty = disabled).
+	Cache string
+	// Logs holds the recent log output sent in answer to logs commands (nil
+	// = logs commands are answered with an error).
+	Logs *logging.Ring
 }
 
 const (
-	baseBackoff = 1 * time.Second
-	maxBackoff  = 2 * time.Minute
+	// streamKeepalive pings the collector on an idle command stream so that
+	// a half-open connection, e.g. after a NAT or VPN drops its state, is
+	// detected within a minute rather than after the OS TCP timeout.
+	streamKeepalive = 30 * time.Second
+
+	// replyTimeout bounds answering a command: acknowledging it, or sending
+	// the log output for a logs command.
+	replyTimeout = 30 * time.Second
+
+	// intervalJitter spreads periodic submissions by up to ±10% of the
+	// interval so that agents started together drift apart.
+	intervalJitter = 0.1
+)
+
+// sendMu serializes submissions from refresh commands, the periodic loop
+// and spool replays.
+var sendMu sync.Mutex
+
+var (
+	// errSpooled reports a submission that was spooled for later replay.
+	errSpooled = errors.New("collector unreachable, inventory spooled for replay")
+	// errUnchanged reports a submission skipped by send-on-change.
+	errUnchanged = errors.New("inventory unchanged since last submission")
+	// errPaused reports a collection skipped while the agent is paused.
+	errPaused = errors.New("collection is paused")
+	// errNotAllowed and errNoUpdater answer commands the agent refuses.
+	errNotAllowed = errors.New("command type not allowed on this agent")
+	errNoUpdater  = errors.New("self-update is not enabled on this agent")
 )
 
+// lastSent identifies the last submitted inventory for send-on-change;
+// guarded by sendMu.
+var lastSent struct {
+	fingerprint [sha256.Size]byte
+	at          time.Time
+}
+
 // Run performs an initial collect-and-send, then enters a reconnect loop
-// that streams commands from the collector.
+// that streams commands from the collector. With a submission schedule the
+// initial submission is deferred to the schedule instead. Run returns
+// update.ErrRestart once a self-update has been installed.
 func Run(ctx context.Context, cfg Config) error {
-	// Initial collect + send.
-	if err := collectAndSend(ctx, cfg); err != nil {
-		return fmt.Errorf("initial inventory submit: %w", err)
+	update.Cleanup()
+	if err := loadState(cfg.StateFile); err != nil {
+		slog.Warn("Could not load agent state; starting unpaused", logging.Err(err))
 	}
-	log.Println("Initial inventory submitted; entering daemon mode")
+	recordStart(cfg)
 
-	reconnectLoop(ctx, cfg)
-	return nil
+	if cfg.StatusAddr != "" {
+		if err := serveStatus(ctx, cfg.StatusAddr); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Schedule.IsZero() {
+		// Initial collect + send.
+		switch err := collectAndSend(ctx, cfg, false); {
+		case errors.Is(err, errSpooled):
+			slog.Info("Initial inventory spooled; entering daemon mode")
+		case errors.Is(err, errPaused):
+			slog.Info("Collection is paused; entering daemon mode")
+		case err != nil:
+			return fmt.Errorf("initial inventory submit: %w", err)
+		default:
+			slog.Info("Initial inventory submitted; entering daemon mode")
+		}
+
+		if cfg.Interval > 0 {
+			go periodicLoop(ctx, cfg, withJitter(cfg.Interval))
+		}
+	} else {
+		slog.Info("Entering daemon mode; initial inventory submission is scheduled")
+		go periodicLoop(ctx, cfg, 0)
+	}
+
+	return reconnectLoop(ctx, cfg)
 }
 
-func reconnectLoop(ctx context.Context, cfg Config) {
-	attempt := 0
+// periodicLoop submits the inventory after the first delay, adjusted to the
+// submission schedule, and then every cfg.Interval if one is set.
+func periodicLoop(ctx context.Context, cfg Config, after time.Duration) {
 	for {
+		wait := cfg.Schedule.Delay(time.Now(), after)
+		slog.Info("Next scheduled inventory collection", "in", wait.Round(time.Second))
+
 		select {
 		case <-ctx.Done():
-			log.Println("Daemon shutting down")
 			return
+		case <-time.After(wait):
+		}
+
+		switch err := collectAndSend(ctx, cfg, false); {
+		case errors.Is(err, errUnchanged):
+			slog.Info("Scheduled collection complete; inventory unchanged, submission skipped")
+		case errors.Is(err, errPaused):
+			slog.Info("Scheduled collection skipped; collection is paused")
+		case err != nil:
+			slog.Error("Scheduled collection failed", logging.Err(err))
 		default:
+			slog.Info("Scheduled collection complete; inventory submitted")
 		}
 
-		err := streamLoop(ctx, cfg)
-		if ctx.Err() != nil {
+		if cfg.Interval <= 0 {
 			return
 		}
+		after = withJitter(cfg.Interval)
+	}
+}
+
+func reconnectLoop(ctx context.Context, cfg Config) error {
+	attempt := 0
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("Daemon shutting down")
+			return nil
+		default:
+		}
+
+		// Try the collectors in order; back off only once all of them
+		// have failed. SRV records are looked up again on every round.
+		addrs, err := sender.Resolve(ctx, cfg.Collectors)
+		for _, addr := range addrs {
+			start := time.Now()
+			err = streamLoop(ctx, cfg, addr)
+			recordDisconnected(err)
+			if time.Since(start) >= stableStream {
+				attempt = 0
+			}
+			if errors.Is(err, update.ErrRestart) {
+				return err
+			}
+			if ctx.Err() != nil {
+				return nil
+			}
+			if len(addrs) > 1 {
+				slog.Warn("Collector stream ended", "collector", addr, logging.Err(err))
+			}
+		}
 
 		attempt++
-		backoff := calcBackoff(attempt)
-		log.Printf("Stream disconnected (attempt %d): %v; reconnecting in %s", attempt, err, backoff)
+		if cfg.Backoff.MaxRetries > 0 && attempt > cfg.Backoff.MaxRetries {
+			return fmt.Errorf("giving up after %d failed reconnect attempts: %w", cfg.Backoff.MaxRetries, err)
+		}
+		backoff := cfg.Backoff.Delay(attempt)
+		slog.Warn("Stream disconnected; reconnecting", "attempt", attempt, "backoff", backoff, logging.Err(err))
 
 		select {
 		case <-ctx.Done():
-			return
+			return nil
 		case <-time.After(backoff):
 		}
 	}
 }
 
-func streamLoop(ctx context.Context, cfg Config) error {
-	conn, err := grpc.NewClient(cfg.CollectorAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+func streamLoop(ctx context.Context, cfg Config, addr string) error {
+	opts := cfg.senderOptions()
+	opts.Keepalive = streamKeepalive
+	conn, err := sender.Dial(addr, opts)
 	if err != nil {
 		return fmt.Errorf("dial collector: %w", err)
 	}
@@ -86,12 +256,20 @@ func streamLoop(ctx context.Context, cfg Config) error {
 	stream, err := client.StreamCommands(streamCtx, &collectorv1.StreamCommandsRequest{
 		ClientId:      cfg.ClientID,
 		ClientVersion: cfg.Version,
+		Site:          cfg.Site,
+		Hostname:      cfg.Hostname,
+		Paused:        isPaused(),
 	})
 	if err != nil {
 		return fmt.Errorf("open stream: %w", err)
 	}
 
-	log.Printf("Connected to collector at %s; waiting for commands", cfg.CollectorAddr)
+	slog.Info("Connected to collector; waiting for commands", "collector", addr, "client_id", cfg.ClientID, "hostname", cfg.Hostname, "site", cfg.Site)
+	recordConnected(addr)
+
+	if cfg.Spool != nil {
+		go replaySpool(ctx, cfg)
+	}
 
 	for {
 		cmd, err := stream.Recv()
@@ -99,38 +277,222 @@ func streamLoop(ctx context.Context, cfg Config) error {
 			return fmt.Errorf("recv: %w", err)
 		}
 
+		if name, ok := commandNames[cmd.CommandType]; ok && cfg.AllowedCommands != nil && !cfg.AllowedCommands[name] {
+			recordCommand("refused")
+			slog.Warn("Command type not allowed on this agent, ignoring", "command_id", cmd.CommandId, "command", name)
+			if cmd.CommandType == collectorv1.InventoryCommandType_INVENTORY_COMMAND_TYPE_LOGS {
+				sendLogs(streamCtx, client, &collectorv1.SubmitAgentLogsRequest{CommandId: cmd.CommandId, ClientId: cfg.ClientID, Error: errNotAllowed.Error()})
+			} else {
+				ackCommand(streamCtx, client, cfg.ClientID, cmd, errNotAllowed)
+			}
+			continue
+		}
+
+		var result error
 		switch cmd.CommandType {
 		case collectorv1.InventoryCommandType_INVENTORY_COMMAND_TYPE_REFRESH:
-			log.Printf("Received refresh command %s", cmd.CommandId)
-			handleRefresh(ctx, cfg)
+			recordCommand("refresh")
+			slog.Info("Received refresh command", "command_id", cmd.CommandId)
+			result = handleRefresh(ctx, cfg)
+		case collectorv1.InventoryCommandType_INVENTORY_COMMAND_TYPE_UPDATE:
+			recordCommand("update")
+			slog.Info("Received update command", "command_id", cmd.CommandId, "version", cmd.Update.GetVersion())
+			result = handleUpdate(ctx, cfg, cmd.Update)
+			if errors.Is(result, update.ErrRestart) {
+				ackCommand(streamCtx, client, cfg.ClientID, cmd, nil)
+				return result
+			}
+		case collectorv1.InventoryCommandType_INVENTORY_COMMAND_TYPE_PAUSE:
+			recordCommand("pause")
+			slog.Info("Received pause command", "command_id", cmd.CommandId)
+			handlePause(cfg, true)
+		case collectorv1.InventoryCommandType_INVENTORY_COMMAND_TYPE_RESUME:
+			recordCommand("resume")
+			slog.Info("Received resume command", "command_id", cmd.CommandId)
+			handlePause(cfg, false)
+		case collectorv1.InventoryCommandType_INVENTORY_COMMAND_TYPE_LOGS:
+			recordCommand("logs")
+			slog.Info("Received logs command", "command_id", cmd.CommandId, "max_bytes", cmd.MaxLogBytes)
+			handleLogs(streamCtx, cfg, client, cmd)
+			continue
+		case collectorv1.InventoryCommandType_INVENTORY_COMMAND_TYPE_PING:
+			recordCommand("ping")
+			slog.Debug("Received ping command", "command_id", cmd.CommandId)
 		default:
-			log.Printf("Unknown command type %d (id: %s), ignoring", cmd.CommandType, cmd.CommandId)
+			recordCommand("unknown")
+			slog.Warn("Unknown command type, ignoring", "command_id", cmd.CommandId, "command_type", int32(cmd.CommandType))
+			result = fmt.Errorf("unknown command type %d", cmd.CommandType)
 		}
+		ackCommand(streamCtx, client, cfg.ClientID, cmd, result)
+	}
+}
+
+// ackCommand reports the outcome of cmd to the collector as agent clientID.
+// Collectors that predate acknowledgements answer codes.Unimplemented, which
+// is ignored.
+func ackCommand(ctx context.Context, client collectorv1.InventoryCollectorServiceClient, clientID string, cmd *collectorv1.InventoryCommand, result error) {
+	req := &collectorv1.AckCommandRequest{CommandId: cmd.CommandId, ClientId: clientID}
+	if result != nil {
+		req.Error = result.Error()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, replyTimeout)
+	defer cancel()
+	if _, err := client.AckCommand(ctx, req); err != nil && status.Code(err) != codes.Unimplemented {
+		slog.Warn("Acknowledging command failed", "command_id", cmd.CommandId, logging.Err(err))
+	}
+}
+
+// handleRefresh collects and submits the inventory and returns why it was
+// not, if so.
+func handleRefresh(ctx context.Context, cfg Config) error {
+	err := collectAndSend(ctx, cfg, true)
+	switch {
+	case errors.Is(err, errPaused):
+		slog.Info("Refresh ignored; collection is paused")
+	case err != nil:
+		slog.Error("Refresh failed", logging.Err(err))
+	default:
+		slog.Info("Refresh complete; inventory re-submitted")
+	}
+	return err
+}
+
+// handlePause pauses or resumes collection. A state that cannot be persisted
+// still applies until the agent restarts.
+func handlePause(cfg Config, paused bool) {
+	if err := setPaused(cfg.StateFile, paused); err != nil {
+		slog.Error("Could not persist agent state", logging.Err(err))
+	}
+	if paused {
+		slog.Info("Collection paused")
+	} else {
+		slog.Info("Collection resumed")
 	}
 }
 
-func handleRefresh(ctx context.Context, cfg Config) {
-	if err := collectAndSend(ctx, cfg); err != nil {
-		log.Printf("Refresh failed: %v", err)
+// handleLogs sends the recent log output to the collector in answer to cmd.
+func handleLogs(ctx context.Context, cfg Config, client collectorv1.InventoryCollectorServiceClient, cmd *collectorv1.InventoryCommand) {
+	req := &collectorv1.SubmitAgentLogsRequest{CommandId: cmd.CommandId, ClientId: cfg.ClientID}
+	if cfg.Logs == nil {
+		req.Error = "the agent keeps no log buffer (-log-buffer 0)"
 	} else {
-		log.Println("Refresh complete; inventory re-submitted")
+		req.Logs, req.Truncated = cfg.Logs.Tail(int(cmd.MaxLogBytes))
 	}
+	sendLogs(ctx, client, req)
 }
 
-func collectAndSend(ctx context.Context, cfg Config) error {
+func sendLogs(ctx context.Context, client collectorv1.InventoryCollectorServiceClient, req *collectorv1.SubmitAgentLogsRequest) {
+	ctx, cancel := context.WithTimeout(ctx, replyTimeout)
+	defer cancel()
+	if _, err := client.SubmitAgentLogs(ctx, req); err != nil {
+		slog.Error("Sending logs failed", "command_id", req.CommandId, logging.Err(err))
+		return
+	}
+	slog.Info("Logs sent", "command_id", req.CommandId, "bytes", len(req.Logs))
+}
+
+// handleUpdate installs u and returns update.ErrRestart once the new
+// executable is in place. Other failures are logged and returned, and the
+// agent keeps running its current version.
+func handleUpdate(ctx context.Context, cfg Config, u *collectorv1.AgentUpdate) error {
+	if cfg.Updater == nil {
+		slog.Warn("Self-update is not enabled; ignoring update command")
+		return errNoUpdater
+	}
+
+	err := cfg.Updater.Apply(ctx, u)
+	switch {
+	case errors.Is(err, update.ErrRestart):
+		slog.Info("Installed agent update; exiting to restart", "version", u.GetVersion())
+		return err
+	case err != nil:
+		slog.Error("Agent update failed", "version", u.GetVersion(), logging.Err(err))
+	}
+	return err
+}
+
+// collectAndSend collects and submits the inventory. Unless force is set,
+// an inventory unchanged since the last submission is skipped when
+// cfg.SkipUnchanged is enabled. Nothing is collected while paused.
+func collectAndSend(ctx context.Context, cfg Config, force bool) (err error) {
+	sendMu.Lock()
+	defer sendMu.Unlock()
+	defer func() { recordSubmission(err) }()
+
+	if isPaused() {
+		return errPaused
+	}
+
+	start := time.Now()
 	inv, err := collector.Collect()
+	recordCollection(time.Since(start), collector.FailedModules(err))
 	if err != nil {
-		log.Printf("warning: collect: %v", err)
+		slog.Warn("Some inventory modules failed", "modules", collector.FailedModules(err), logging.Err(err))
+	}
+
+	sum := fingerprint(inv)
+	if cfg.SkipUnchanged && !force && sum == lastSent.fingerprint &&
+		(cfg.MaxUnchanged <= 0 || time.Since(lastSent.at) < cfg.MaxUnchanged) {
+		return errUnchanged
 	}
 
-	_, err = sender.Send(ctx, cfg.CollectorAddr, cfg.ClientSecret, inv)
+	spooled, err := cfg.Spool.Submit(ctx, inv, cfg.send)
+	if err != nil {
+		return err
+	}
+	// A spooled inventory will be delivered on replay, so it counts as sent.
+	lastSent.fingerprint, lastSent.at = sum, time.Now()
+	if spooled {
+		return errSpooled
+	}
+	if cfg.Cache != "" {
+		if err := cache.Save(cfg.Cache, inv); err != nil {
+			slog.Warn("Could not cache submitted inventory", logging.Err(err))
+		}
+	}
+	return nil
+}
+
+// fingerprint hashes the inventory content, ignoring the collection time
+// and telemetry.
+func fingerprint(inv *collector.Inventory) [sha256.Size]byte {
+	c := *inv
+	c.CollectedAt = time.Time{}
+	c.Telemetry = nil
+	data, _ := json.Marshal(&c)
+	return sha256.Sum256(data)
+}
+
+func replaySpool(ctx context.Context, cfg Config) {
+	sendMu.Lock()
+	defer sendMu.Unlock()
+
+	if _, err := cfg.Spool.Replay(ctx, cfg.send); err != nil {
+		slog.Error("Spool replay failed", logging.Err(err))
+	}
+}
+
+func (cfg Config) send(ctx context.Context, inv *collector.Inventory) error {
+	_, _, err := sender.SendFailover(ctx, cfg.Collectors, cfg.senderOptions(), inv)
 	return err
 }
 
-func calcBackoff(attempt int) time.Duration {
-	d := baseBackoff * time.Duration(math.Pow(2, float64(attempt-1)))
-	if d > maxBackoff {
-		d = maxBackoff
+func (cfg Config) senderOptions() sender.Options {
+	return sender.Options{
+		Secret:      cfg.ClientSecret,
+		Site:        cfg.Site,
+		Compression: cfg.Compression,
+		TLS:         cfg.TLS,
+		Proxy:       cfg.Proxy,
+		MaxRate:     cfg.MaxRate,
+	}
+}
+
+func withJitter(d time.Duration) time.Duration {
+	spread := time.Duration(float64(d) * intervalJitter)
+	if spread <= 0 {
+		return d
 	}
-	return d
+	return d - spread + rand.N(2*spread+1)
 }
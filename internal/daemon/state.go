@@ -0,0 +1,88 @@
+package daemon
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// agentState is the part of the daemon state that survives restarts.
+type agentState struct {
+	// Paused stops inventory collection until a resume command arrives.
+	Paused bool `json:"paused"`
+}
+
+// state is the current agent state; guarded by stateMu.
+var (
+	stateMu sync.Mutex
+	state   agentState
+)
+
+// loadState reads the state file at path. A missing file or an empty path
+// leaves the default state.
+func loadState(path string) error {
+	if path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read state file: %w", err)
+	}
+
+	var s agentState
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("parse state file %s: %w", path, err)
+	}
+
+	stateMu.Lock()
+	defer stateMu.Unlock()
+	state = s
+	return nil
+}
+
+// setPaused updates the paused state and writes it to the state file at path,
+// if any. The in-memory state changes even when the file cannot be written.
+func setPaused(path string, paused bool) error {
+	stateMu.Lock()
+	defer stateMu.Unlock()
+	state.Paused = paused
+	return saveState(path, state)
+}
+
+func isPaused() bool {
+	stateMu.Lock()
+	defer stateMu.Unlock()
+	return state.Paused
+}
+
+// saveState replaces the state file atomically so that a crash never leaves
+// a truncated file behind.
+func saveState(path string, s agentState) error {
+	if path == "" {
+		return nil
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("marshal state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("create state directory: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("write state file: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("write state file: %w", err)
+	}
+	return nil
+}
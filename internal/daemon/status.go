@@ -0,0 +1,189 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-tangra/go-tangra-inventory/internal/logging"
+)
+
+// Status is the agent health snapshot served on the status listener.
+type Status struct {
+	Version   string    `json:"version"`
+	ClientID  string    `json:"client_id"`
+	Hostname  string    `json:"hostname"`
+	StartedAt time.Time `json:"started_at"`
+	// Collectors lists the configured collectors in failover order;
+	// Collector is the one connected last.
+	Collectors []string `json:"collectors"`
+	Collector  string   `json:"collector,omitempty"`
+	// Paused reports that collection is paused by a pause command.
+	Paused bool `json:"paused"`
+
+	Connected      bool       `json:"connected"`
+	ConnectedSince *time.Time `json:"connected_since,omitempty"`
+	Reconnects     int        `json:"reconnects"`
+	LastStreamErr  string     `json:"last_stream_error,omitempty"`
+
+	LastSubmission *Submission `json:"last_submission,omitempty"`
+	LastSuccessAt  *time.Time  `json:"last_success_at,omitempty"`
+
+	// Commands counts received commands by type.
+	Commands map[string]int `json:"commands"`
+
+	Collections Collections `json:"collections"`
+}
+
+// Submission describes the most recent collect-and-send attempt.
+type Submission struct {
+	At time.Time `json:"at"`
+	// Result is submitted, spooled, unchanged, paused or failed.
+	Result string `json:"result"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Collections summarizes inventory collection durations.
+type Collections struct {
+	Count             int      `json:"count"`
+	LastDurationMs    int64    `json:"last_duration_ms"`
+	AverageDurationMs int64    `json:"average_duration_ms"`
+	MaxDurationMs     int64    `json:"max_duration_ms"`
+	LastFailedModules []string `json:"last_failed_modules,omitempty"`
+
+	total time.Duration
+}
+
+// stats holds the daemon's self-metrics; guarded by statsMu.
+var (
+	statsMu sync.Mutex
+	stats   = Status{Commands: map[string]int{}}
+)
+
+func recordStart(cfg Config) {
+	statsMu.Lock()
+	defer statsMu.Unlock()
+	stats.Version = cfg.Version
+	stats.ClientID = cfg.ClientID
+	stats.Hostname = cfg.Hostname
+	stats.Collectors = cfg.Collectors
+	stats.StartedAt = time.Now().UTC()
+}
+
+func recordConnected(addr string) {
+	statsMu.Lock()
+	defer statsMu.Unlock()
+	now := time.Now().UTC()
+	stats.Collector = addr
+	stats.Connected = true
+	stats.ConnectedSince = &now
+}
+
+func recordDisconnected(err error) {
+	statsMu.Lock()
+	defer statsMu.Unlock()
+	if stats.Connected {
+		stats.Reconnects++
+	}
+	stats.Connected = false
+	stats.ConnectedSince = nil
+	if err != nil {
+		stats.LastStreamErr = err.Error()
+	}
+}
+
+func recordCommand(kind string) {
+	statsMu.Lock()
+	defer statsMu.Unlock()
+	stats.Commands[kind]++
+}
+
+func recordCollection(d time.Duration, failedModules []string) {
+	statsMu.Lock()
+	defer statsMu.Unlock()
+	c := &stats.Collections
+	c.Count++
+	c.total += d
+	c.LastDurationMs = d.Milliseconds()
+	c.AverageDurationMs = (c.total / time.Duration(c.Count)).Milliseconds()
+	c.MaxDurationMs = max(c.MaxDurationMs, c.LastDurationMs)
+	c.LastFailedModules = failedModules
+}
+
+func recordSubmission(err error) {
+	statsMu.Lock()
+	defer statsMu.Unlock()
+	s := &Submission{At: time.Now().UTC(), Result: "submitted"}
+	switch {
+	case errors.Is(err, errSpooled):
+		s.Result = "spooled"
+	case errors.Is(err, errUnchanged):
+		s.Result = "unchanged"
+	case errors.Is(err, errPaused):
+		s.Result = "paused"
+	case err != nil:
+		s.Result = "failed"
+		s.Error = err.Error()
+	default:
+		at := s.At
+		stats.LastSuccessAt = &at
+	}
+	stats.LastSubmission = s
+}
+
+func snapshot() Status {
+	statsMu.Lock()
+	defer statsMu.Unlock()
+	s := stats
+	s.Paused = isPaused()
+	s.Commands = make(map[string]int, len(stats.Commands))
+	for k, v := range stats.Commands {
+		s.Commands[k] = v
+	}
+	return s
+}
+
+// serveStatus serves the status snapshot as JSON on addr until ctx is
+// cancelled. addr must be a loopback address.
+func serveStatus(ctx context.Context, addr string) error {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return fmt.Errorf("status address %q: %w", addr, err)
+	}
+	if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+		return fmt.Errorf("status address %q must be a loopback address", addr)
+	}
+
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("listen on status address: %w", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /status", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		enc.Encode(snapshot())
+	})
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+
+	go func() {
+		<-ctx.Done()
+		srv.Close()
+	}()
+	go func() {
+		if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("Status listener stopped", logging.Err(err))
+		}
+	}()
+
+	slog.Info("Serving agent status", "url", fmt.Sprintf("http://%s/status", ln.Addr()))
+	return nil
+}
@@ -0,0 +1,234 @@
+// Package diff compares two inventories of the same host and reports
+// component-level hardware changes.
+package diff
+
+import (
+	"fmt"
+	"strings"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+)
+
+// ChangeType classifies a single difference.
+type ChangeType string
+
+const (
+	Added    ChangeType = "added"
+	Removed  ChangeType = "removed"
+	Modified ChangeType = "modified"
+)
+
+// Component names used in Change.Component.
+const (
+	ComponentSystem       = "system"
+	ComponentBIOS         = "bios"
+	ComponentBaseboard    = "baseboard"
+	ComponentChassis      = "chassis"
+	ComponentProcessor    = "processor"
+	ComponentMemory       = "memory"
+	ComponentMemoryModule = "memory_module"
+	ComponentMonitor      = "monitor"
+)
+
+// Change describes one difference between a previous and a current inventory.
+// Key identifies the component instance for repeated components (DIMM slot,
+// CPU socket, monitor serial); Field is set only for Modified changes.
+type Change struct {
+	Type      ChangeType `json:"type"`
+	Component string     `json:"component"`
+	Key       string     `json:"key,omitempty"`
+	Field     string     `json:"field,omitempty"`
+	Old       string     `json:"old,omitempty"`
+	New       string     `json:"new,omitempty"`
+}
+
+// String renders the change as a short human-readable sentence.
+func (c Change) String() string {
+	name := c.Component
+	if c.Key != "" {
+		name += " " + c.Key
+	}
+	switch c.Type {
+	case Added:
+		return fmt.Sprintf("%s added (%s)", name, c.New)
+	case Removed:
+		return fmt.Sprintf("%s removed (%s)", name, c.Old)
+	default:
+		return fmt.Sprintf("%s %s changed from %q to %q", name, c.Field, c.Old, c.New)
+	}
+}
+
+// Compare returns the hardware changes between prev and cur. Either argument
+// may be nil, in which case no changes are reported.
+func Compare(prev, cur *collectorv1.Inventory) []Change {
+	if prev == nil || cur == nil {
+		return nil
+	}
+
+	var d differ
+
+	ps, cs := prev.GetSystem(), cur.GetSystem()
+	d.field(ComponentSystem, "", "manufacturer", ps.GetManufacturer(), cs.GetManufacturer())
+	d.field(ComponentSystem, "", "product_name", ps.GetProductName(), cs.GetProductName())
+	d.field(ComponentSystem, "", "serial_number", ps.GetSerialNumber(), cs.GetSerialNumber())
+	d.field(ComponentSystem, "", "uuid", ps.GetUuid(), cs.GetUuid())
+
+	pb, cb := prev.GetBios(), cur.GetBios()
+	d.field(ComponentBIOS, "", "vendor", pb.GetVendor(), cb.GetVendor())
+	d.field(ComponentBIOS, "", "version", pb.GetVersion(), cb.GetVersion())
+
+	pbb, cbb := prev.GetBaseboard(), cur.GetBaseboard()
+	d.field(ComponentBaseboard, "", "product", pbb.GetProduct(), cbb.GetProduct())
+	d.field(ComponentBaseboard, "", "serial_number", pbb.GetSerialNumber(), cbb.GetSerialNumber())
+
+	pc, cc := prev.GetChassis(), cur.GetChassis()
+	d.field(ComponentChassis, "", "serial_number", pc.GetSerialNumber(), cc.GetSerialNumber())
+	d.field(ComponentChassis, "", "asset_tag_number", pc.GetAssetTagNumber(), cc.GetAssetTagNumber())
+
+	d.field(ComponentMemory, "", "total_physical_bytes",
+		fmt.Sprint(prev.GetMemory().GetTotalPhysicalBytes()),
+		fmt.Sprint(cur.GetMemory().GetTotalPhysicalBytes()))
+
+	d.processors(prev.GetProcessors(), cur.GetProcessors())
+	d.memoryModules(prev.GetMemory().GetModules(), cur.GetMemory().GetModules())
+	d.monitors(prev.GetMonitor(), cur.GetMonitor())
+
+	return d.changes
+}
+
+type differ struct {
+	changes []Change
+}
+
+func (d *differ) field(component, key, name, old, cur string) {
+	if old == cur {
+		return
+	}
+	d.changes = append(d.changes, Change{
+		Type:      Modified,
+		Component: component,
+		Key:       key,
+		Field:     name,
+		Old:       old,
+		New:       cur,
+	})
+}
+
+func (d *differ) added(component, key, desc string) {
+	d.changes = append(d.changes, Change{Type: Added, Component: component, Key: key, New: desc})
+}
+
+func (d *differ) removed(component, key, desc string) {
+	d.changes = append(d.changes, Change{Type: Removed, Component: component, Key: key, Old: desc})
+}
+
+func (d *differ) processors(prev, cur []*collectorv1.ProcessorInfo) {
+	key := func(p *collectorv1.ProcessorInfo) string { return p.GetSocketDesignation() }
+	desc := func(p *collectorv1.ProcessorInfo) string { return p.GetVersion() }
+
+	old := indexBy(prev, key)
+	for _, c := range cur {
+		k := key(c)
+		p, ok := old[k]
+		if !ok {
+			if c.GetSocketPopulated() {
+				d.added(ComponentProcessor, k, desc(c))
+			}
+			continue
+		}
+		delete(old, k)
+		if p.GetSocketPopulated() && !c.GetSocketPopulated() {
+			d.removed(ComponentProcessor, k, desc(p))
+			continue
+		}
+		if !p.GetSocketPopulated() && c.GetSocketPopulated() {
+			d.added(ComponentProcessor, k, desc(c))
+			continue
+		}
+		d.field(ComponentProcessor, k, "version", p.GetVersion(), c.GetVersion())
+		d.field(ComponentProcessor, k, "serial_number", p.GetSerialNumber(), c.GetSerialNumber())
+	}
+	for _, p := range prev {
+		if _, ok := old[key(p)]; ok && p.GetSocketPopulated() {
+			d.removed(ComponentProcessor, key(p), desc(p))
+			delete(old, key(p))
+		}
+	}
+}
+
+func (d *differ) memoryModules(prev, cur []*collectorv1.MemoryModule) {
+	key := func(m *collectorv1.MemoryModule) string {
+		if m.GetBankLocator() == "" {
+			return m.GetDeviceLocator()
+		}
+		return m.GetBankLocator() + "/" + m.GetDeviceLocator()
+	}
+	desc := func(m *collectorv1.MemoryModule) string {
+		return join(fmt.Sprintf("%d MiB", m.GetCapacityBytes()/(1024*1024)), m.GetManufacturer(), m.GetPartNumber())
+	}
+
+	old := indexBy(prev, key)
+	for _, c := range cur {
+		k := key(c)
+		p, ok := old[k]
+		if !ok {
+			d.added(ComponentMemoryModule, k, desc(c))
+			continue
+		}
+		delete(old, k)
+		d.field(ComponentMemoryModule, k, "capacity_bytes", fmt.Sprint(p.GetCapacityBytes()), fmt.Sprint(c.GetCapacityBytes()))
+		d.field(ComponentMemoryModule, k, "serial_number", p.GetSerialNumber(), c.GetSerialNumber())
+		d.field(ComponentMemoryModule, k, "part_number", p.GetPartNumber(), c.GetPartNumber())
+	}
+	for _, p := range prev {
+		if _, ok := old[key(p)]; ok {
+			d.removed(ComponentMemoryModule, key(p), desc(p))
+			delete(old, key(p))
+		}
+	}
+}
+
+func (d *differ) monitors(prev, cur []*collectorv1.MonitorInfo) {
+	key := func(m *collectorv1.MonitorInfo) string {
+		if m.GetSerialNumber() != "" {
+			return m.GetSerialNumber()
+		}
+		return join(m.GetManufacturer(), m.GetModel())
+	}
+	desc := func(m *collectorv1.MonitorInfo) string { return join(m.GetManufacturer(), m.GetModel()) }
+
+	old := indexBy(prev, key)
+	for _, c := range cur {
+		k := key(c)
+		if _, ok := old[k]; ok {
+			delete(old, k)
+			continue
+		}
+		d.added(ComponentMonitor, k, desc(c))
+	}
+	for _, p := range prev {
+		if _, ok := old[key(p)]; ok {
+			d.removed(ComponentMonitor, key(p), desc(p))
+			delete(old, key(p))
+		}
+	}
+}
+
+// join concatenates the non-empty parts with single spaces.
+func join(parts ...string) string {
+	var out []string
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return strings.Join(out, " ")
+}
+
+func indexBy[T any](items []T, key func(T) string) map[string]T {
+	m := make(map[string]T, len(items))
+	for _, it := range items {
+		m[key(it)] = it
+	}
+	return m
+}
@@ -0,0 +1,276 @@
+// Package directory enriches host records with what Active Directory, or
+// another LDAP directory, holds about the computer and the user last logged
+// on to it.
+package directory
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+
+	"github.com/go-tangra/go-tangra-inventory/internal/store"
+)
+
+// requestTimeout bounds connecting to the server and every LDAP operation.
+const requestTimeout = 30 * time.Second
+
+// pageSize is the number of hosts read from the store at a time.
+const pageSize = 500
+
+// namePlaceholder is replaced in the search filters by the escaped computer
+// or account name.
+const namePlaceholder = "{name}"
+
+// Attributes maps the enriched values to the LDAP attributes holding them;
+// an empty name leaves the value out.
+type Attributes struct {
+	// Description is an attribute of the computer object.
+	Description string
+	// DisplayName and Department are attributes of the user object.
+	DisplayName string
+	Department  string
+}
+
+// Config configures the directory lookups.
+type Config struct {
+	// URL is an ldap:// or ldaps:// URL of the directory server.
+	URL string
+	// StartTLS upgrades ldap:// connections to TLS.
+	StartTLS bool
+	// TLS configures ldaps:// and StartTLS connections (nil = system
+	// roots).
+	TLS *tls.Config
+	// BindDN and BindPassword authenticate the lookups; an empty BindDN
+	// binds anonymously.
+	BindDN       string
+	BindPassword string
+	// BaseDN is the subtree searched for computers and users.
+	BaseDN string
+	// ComputerFilter and UserFilter select the computer object of a host
+	// and the user object of an account; {name} is replaced by the short
+	// hostname or the account name without its domain.
+	ComputerFilter string
+	UserFilter     string
+	Attributes     Attributes
+}
+
+// Result summarizes an enrichment run.
+type Result struct {
+	// Hosts is the number of hosts looked up.
+	Hosts int
+	// Computers and Users are the numbers of hosts whose computer object,
+	// and whose last user's object, were found.
+	Computers int
+	Users     int
+}
+
+// Enricher looks up the hosts in the store in the directory and records
+// what it finds, in the host_directory table, for ListHosts to return.
+type Enricher struct {
+	cfg Config
+	db  *store.Store
+}
+
+// New returns an Enricher of the hosts in db after validating cfg.
+func New(cfg Config, db *store.Store) (*Enricher, error) {
+	u, err := url.Parse(cfg.URL)
+	if err != nil || (u.Scheme != "ldap" && u.Scheme != "ldaps") || u.Host == "" {
+		return nil, errors.New("not an ldap or ldaps URL")
+	}
+	if cfg.StartTLS && u.Scheme == "ldaps" {
+		return nil, errors.New("StartTLS needs an ldap URL")
+	}
+	if cfg.BaseDN == "" {
+		return nil, errors.New("a base DN is required")
+	}
+	if _, err := ldap.ParseDN(cfg.BaseDN); err != nil {
+		return nil, fmt.Errorf("base DN: %w", err)
+	}
+	for _, f := range []struct{ name, filter string }{
+		{"computer filter", cfg.ComputerFilter},
+		{"user filter", cfg.UserFilter},
+	} {
+		if !strings.Contains(f.filter, namePlaceholder) {
+			return nil, fmt.Errorf("%s: must contain %s", f.name, namePlaceholder)
+		}
+		if _, err := ldap.CompileFilter(strings.ReplaceAll(f.filter, namePlaceholder, "x")); err != nil {
+			return nil, fmt.Errorf("%s: %w", f.name, err)
+		}
+	}
+	return &Enricher{cfg: cfg, db: db}, nil
+}
+
+// Enrich looks up every host and its last user, as reported by its latest
+// inventory, and stores the result. Hosts found neither as a computer nor
+// by their user lose their directory information.
+func (e *Enricher) Enrich(ctx context.Context) (Result, error) {
+	var res Result
+	conn, err := e.connect()
+	if err != nil {
+		return res, err
+	}
+	defer conn.Close()
+
+	users := make(map[string]*ldap.Entry)
+	for page := 1; ; page++ {
+		hosts, _, err := e.db.ListHosts(ctx, store.HostFilter{PageSize: pageSize, Page: page})
+		if err != nil {
+			return res, err
+		}
+		for _, h := range hosts {
+			if err := ctx.Err(); err != nil {
+				return res, err
+			}
+			d := &store.HostDirectory{Site: h.Site, Hostname: h.Hostname}
+
+			computer, err := e.search(conn, e.cfg.ComputerFilter, shortName(h.Hostname), e.cfg.Attributes.Description)
+			if err != nil {
+				return res, fmt.Errorf("look up computer %s: %w", h.Hostname, err)
+			}
+			if computer != nil {
+				d.OU = parentDN(computer.DN)
+				d.Description = attribute(computer, e.cfg.Attributes.Description)
+				res.Computers++
+			}
+
+			var user *ldap.Entry
+			if account := accountName(h.Username); account != "" {
+				var cached bool
+				if user, cached = users[account]; !cached {
+					user, err = e.search(conn, e.cfg.UserFilter, account, e.cfg.Attributes.DisplayName, e.cfg.Attributes.Department)
+					if err != nil {
+						return res, fmt.Errorf("look up user %s: %w", h.Username, err)
+					}
+					users[account] = user
+				}
+			}
+			if user != nil {
+				d.Username = h.Username
+				d.UserDisplayName = attribute(user, e.cfg.Attributes.DisplayName)
+				d.Department = attribute(user, e.cfg.Attributes.Department)
+				res.Users++
+			}
+
+			if computer != nil || user != nil {
+				err = e.db.UpsertHostDirectory(ctx, d)
+			} else {
+				err = e.db.DeleteHostDirectory(ctx, h.Site, h.Hostname)
+			}
+			if err != nil {
+				return res, err
+			}
+			res.Hosts++
+		}
+		if len(hosts) < pageSize {
+			return res, nil
+		}
+	}
+}
+
+// connect dials and binds to the directory server.
+func (e *Enricher) connect() (*ldap.Conn, error) {
+	tlsCfg := e.cfg.TLS
+	if tlsCfg == nil {
+		tlsCfg = &tls.Config{}
+	}
+	if tlsCfg.ServerName == "" {
+		// The URL was validated by New.
+		u, _ := url.Parse(e.cfg.URL)
+		tlsCfg = tlsCfg.Clone()
+		tlsCfg.ServerName = u.Hostname()
+	}
+
+	conn, err := ldap.DialURL(e.cfg.URL, ldap.DialWithTLSDialer(tlsCfg, &net.Dialer{Timeout: requestTimeout}))
+	if err != nil {
+		return nil, fmt.Errorf("connect to %s: %w", e.cfg.URL, err)
+	}
+	conn.SetTimeout(requestTimeout)
+	if e.cfg.StartTLS {
+		if err := conn.StartTLS(tlsCfg); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("start TLS: %w", err)
+		}
+	}
+	if e.cfg.BindDN != "" {
+		if err := conn.Bind(e.cfg.BindDN, e.cfg.BindPassword); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("bind as %s: %w", e.cfg.BindDN, err)
+		}
+	}
+	return conn, nil
+}
+
+// search returns the single object that filter, with name in place of
+// {name}, matches, or nil if none or several do.
+func (e *Enricher) search(conn *ldap.Conn, filter, name string, attributes ...string) (*ldap.Entry, error) {
+	var attrs []string
+	for _, a := range attributes {
+		if a != "" {
+			attrs = append(attrs, a)
+		}
+	}
+	if len(attrs) == 0 {
+		// "1.1" requests the DN alone.
+		attrs = []string{"1.1"}
+	}
+	res, err := conn.Search(ldap.NewSearchRequest(
+		e.cfg.BaseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 2, int(requestTimeout/time.Second), false,
+		strings.ReplaceAll(filter, namePlaceholder, ldap.EscapeFilter(name)), attrs, nil))
+	if ldap.IsErrorWithCode(err, ldap.LDAPResultSizeLimitExceeded) || (err == nil && len(res.Entries) > 1) {
+		slog.Warn("Several directory objects match, skipping", "name", name)
+		return nil, nil
+	}
+	if ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Entries) == 0 {
+		return nil, nil
+	}
+	return res.Entries[0], nil
+}
+
+// attribute returns the first value of the attribute name of entry, or ""
+// if name is empty.
+func attribute(entry *ldap.Entry, name string) string {
+	if name == "" {
+		return ""
+	}
+	return entry.GetEqualFoldAttributeValue(name)
+}
+
+// parentDN returns the DN of the container of the object dn.
+func parentDN(dn string) string {
+	parsed, err := ldap.ParseDN(dn)
+	if err != nil || len(parsed.RDNs) < 2 {
+		return ""
+	}
+	return (&ldap.DN{RDNs: parsed.RDNs[1:]}).String()
+}
+
+// shortName returns hostname up to its first dot: the computer's name in
+// Active Directory.
+func shortName(hostname string) string {
+	name, _, _ := strings.Cut(hostname, ".")
+	return name
+}
+
+// accountName strips the domain from username, as DOMAIN\user or
+// user@domain.
+func accountName(username string) string {
+	if _, account, ok := strings.Cut(username, `\`); ok {
+		username = account
+	}
+	account, _, _ := strings.Cut(username, "@")
+	return account
+}
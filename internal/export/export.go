@@ -0,0 +1,60 @@
+// Package export writes inventories in the CSV and NDJSON formats of
+// "inventoryctl export", which the collector's export endpoint and backups
+// share.
+package export
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// CSVHeader lists the columns of CSV exports; CSVRow must match it.
+var CSVHeader = []string{
+	"id", "site", "hostname", "username", "system_uuid", "system_serial", "collected_at", "stored_at",
+	"manufacturer", "product_name", "bios_version", "processor", "cores", "threads", "memory_gb",
+}
+
+// CSVRow returns the CSV columns of s: the summary fields and the main
+// hardware details of s.Inventory, which are empty if it is nil.
+func CSVRow(s *collectorv1.InventorySummary) []string {
+	inv := s.Inventory
+	row := []string{
+		strconv.FormatInt(s.Id, 10), s.Site, s.Hostname, s.Username, s.SystemUuid, s.SystemSerial,
+		s.CollectedAt.AsTime().Format(time.RFC3339), s.StoredAt.AsTime().Format(time.RFC3339),
+		inv.GetSystem().GetManufacturer(), inv.GetSystem().GetProductName(), inv.GetBios().GetVersion(),
+	}
+
+	var procs []string
+	var cores, threads uint32
+	for _, p := range inv.GetProcessors() {
+		if !p.SocketPopulated {
+			continue
+		}
+		procs = append(procs, strings.TrimSpace(p.Version))
+		cores += p.CoreCount
+		threads += p.ThreadCount
+	}
+	row = append(row,
+		strings.Join(procs, "; "),
+		strconv.FormatUint(uint64(cores), 10),
+		strconv.FormatUint(uint64(threads), 10),
+		strconv.FormatFloat(inv.GetMemory().GetTotalPhysicalGb(), 'f', 1, 64),
+	)
+	return row
+}
+
+// WriteNDJSON writes s to w as a line of JSON with the proto field names.
+func WriteNDJSON(w io.Writer, s *collectorv1.InventorySummary) error {
+	line, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(s)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(line, '\n'))
+	return err
+}
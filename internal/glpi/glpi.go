@@ -0,0 +1,217 @@
+// Package glpi renders inventories in the FusionInventory XML format, which
+// GLPI (natively or with the FusionInventory plugin) and OCS Inventory NG
+// import.
+package glpi
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+)
+
+// client is reported as the VERSIONCLIENT of the documents.
+const client = "go-tangra-inventory"
+
+// request is the root element of a FusionInventory inventory document.
+type request struct {
+	XMLName  xml.Name `xml:"REQUEST"`
+	Content  content  `xml:"CONTENT"`
+	DeviceID string   `xml:"DEVICEID"`
+	Query    string   `xml:"QUERY"`
+}
+
+type content struct {
+	Hardware      hardware  `xml:"HARDWARE"`
+	BIOS          bios      `xml:"BIOS"`
+	CPUs          []cpu     `xml:"CPUS"`
+	Memories      []memory  `xml:"MEMORIES"`
+	Monitors      []monitor `xml:"MONITORS"`
+	Ports         []port    `xml:"PORTS"`
+	Slots         []slot    `xml:"SLOTS"`
+	Users         []user    `xml:"USERS"`
+	VersionClient string    `xml:"VERSIONCLIENT"`
+}
+
+type hardware struct {
+	Name   string `xml:"NAME"`
+	UUID   string `xml:"UUID,omitempty"`
+	Memory uint64 `xml:"MEMORY,omitempty"`
+	UserID string `xml:"USERID,omitempty"`
+	// ProcessorN, ProcessorS and ProcessorT are the number of processors,
+	// the speed of the first in MHz and its name.
+	ProcessorN int    `xml:"PROCESSORN,omitempty"`
+	ProcessorS uint32 `xml:"PROCESSORS,omitempty"`
+	ProcessorT string `xml:"PROCESSORT,omitempty"`
+}
+
+type bios struct {
+	AssetTag      string `xml:"ASSETTAG,omitempty"`
+	BDate         string `xml:"BDATE,omitempty"`
+	BManufacturer string `xml:"BMANUFACTURER,omitempty"`
+	BVersion      string `xml:"BVERSION,omitempty"`
+	MManufacturer string `xml:"MMANUFACTURER,omitempty"`
+	MModel        string `xml:"MMODEL,omitempty"`
+	MSN           string `xml:"MSN,omitempty"`
+	SKUNumber     string `xml:"SKUNUMBER,omitempty"`
+	SManufacturer string `xml:"SMANUFACTURER,omitempty"`
+	SModel        string `xml:"SMODEL,omitempty"`
+	SSN           string `xml:"SSN,omitempty"`
+}
+
+type cpu struct {
+	Core         uint32 `xml:"CORE,omitempty"`
+	Manufacturer string `xml:"MANUFACTURER,omitempty"`
+	Name         string `xml:"NAME,omitempty"`
+	Serial       string `xml:"SERIAL,omitempty"`
+	Speed        uint32 `xml:"SPEED,omitempty"`
+	Thread       uint32 `xml:"THREAD,omitempty"`
+}
+
+type memory struct {
+	Capacity     uint64 `xml:"CAPACITY"`
+	Caption      string `xml:"CAPTION,omitempty"`
+	Description  string `xml:"DESCRIPTION,omitempty"`
+	Manufacturer string `xml:"MANUFACTURER,omitempty"`
+	Model        string `xml:"MODEL,omitempty"`
+	NumSlots     int    `xml:"NUMSLOTS"`
+	SerialNumber string `xml:"SERIALNUMBER,omitempty"`
+	Speed        uint32 `xml:"SPEED,omitempty"`
+	Type         string `xml:"TYPE,omitempty"`
+}
+
+type monitor struct {
+	Caption      string `xml:"CAPTION,omitempty"`
+	Manufacturer string `xml:"MANUFACTURER,omitempty"`
+	Serial       string `xml:"SERIAL,omitempty"`
+}
+
+type port struct {
+	Caption string `xml:"CAPTION,omitempty"`
+	Name    string `xml:"NAME,omitempty"`
+}
+
+type slot struct {
+	Designation string `xml:"DESIGNATION"`
+	Name        string `xml:"NAME"`
+}
+
+type user struct {
+	Domain string `xml:"DOMAIN,omitempty"`
+	Login  string `xml:"LOGIN"`
+}
+
+// deviceIDUnsafe matches the characters not kept in device IDs.
+var deviceIDUnsafe = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// DeviceID returns the FusionInventory device ID of inv: its hostname and
+// collection time, as the FusionInventory agent forms them. It is also safe
+// as a file name.
+func DeviceID(inv *collectorv1.Inventory) string {
+	name := deviceIDUnsafe.ReplaceAllString(inv.Hostname, "_")
+	if name == "" {
+		name = "unknown"
+	}
+	return name + "-" + inv.CollectedAt.AsTime().UTC().Format("2006-01-02-15-04-05")
+}
+
+// Write writes inv to w as a FusionInventory XML inventory document.
+func Write(w io.Writer, inv *collectorv1.Inventory) error {
+	doc := request{
+		Content:  convert(inv),
+		DeviceID: DeviceID(inv),
+		Query:    "INVENTORY",
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("encode %s: %w", doc.DeviceID, err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func convert(inv *collectorv1.Inventory) content {
+	sys, board := inv.GetSystem(), inv.GetBaseboard()
+	c := content{
+		Hardware: hardware{
+			Name:   inv.Hostname,
+			UUID:   sys.GetUuid(),
+			Memory: inv.GetMemory().GetTotalPhysicalBytes() >> 20,
+			UserID: inv.Username,
+		},
+		BIOS: bios{
+			AssetTag:      inv.GetChassis().GetAssetTagNumber(),
+			BDate:         inv.GetBios().GetReleaseDate(),
+			BManufacturer: inv.GetBios().GetVendor(),
+			BVersion:      inv.GetBios().GetVersion(),
+			MManufacturer: board.GetManufacturer(),
+			MModel:        board.GetProduct(),
+			MSN:           board.GetSerialNumber(),
+			SKUNumber:     sys.GetSkuNumber(),
+			SManufacturer: sys.GetManufacturer(),
+			SModel:        sys.GetProductName(),
+			SSN:           sys.GetSerialNumber(),
+		},
+		VersionClient: client,
+	}
+
+	for _, p := range inv.GetProcessors() {
+		if !p.SocketPopulated {
+			continue
+		}
+		c.CPUs = append(c.CPUs, cpu{
+			Core:         p.CoreCount,
+			Manufacturer: p.Manufacturer,
+			Name:         strings.TrimSpace(p.Version),
+			Serial:       p.SerialNumber,
+			Speed:        p.MaxSpeedMhz,
+			Thread:       p.ThreadCount,
+		})
+	}
+	if len(c.CPUs) > 0 {
+		c.Hardware.ProcessorN = len(c.CPUs)
+		c.Hardware.ProcessorS = c.CPUs[0].Speed
+		c.Hardware.ProcessorT = c.CPUs[0].Name
+	}
+
+	for i, m := range inv.GetMemory().GetModules() {
+		if m.CapacityBytes == 0 {
+			continue
+		}
+		c.Memories = append(c.Memories, memory{
+			Capacity:     m.CapacityBytes >> 20,
+			Caption:      m.DeviceLocator,
+			Description:  m.FormFactor,
+			Manufacturer: m.Manufacturer,
+			Model:        m.PartNumber,
+			NumSlots:     i + 1,
+			SerialNumber: m.SerialNumber,
+			Speed:        m.SpeedMtS,
+			Type:         m.MemoryType,
+		})
+	}
+	for _, m := range inv.GetMonitor() {
+		c.Monitors = append(c.Monitors, monitor{Caption: m.Model, Manufacturer: m.Manufacturer, Serial: m.SerialNumber})
+	}
+	for _, p := range inv.GetPorts() {
+		c.Ports = append(c.Ports, port{Caption: p.ExternalDesignator, Name: p.InternalDesignator})
+	}
+	for _, s := range inv.GetSlots() {
+		c.Slots = append(c.Slots, slot{Designation: s.Designation, Name: s.Designation})
+	}
+	if inv.Username != "" {
+		domain, login, ok := strings.Cut(inv.Username, `\`)
+		if !ok {
+			domain, login = "", inv.Username
+		}
+		c.Users = []user{{Domain: domain, Login: login}}
+	}
+	return c
+}
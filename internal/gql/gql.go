@@ -0,0 +1,290 @@
+// Package gql exposes the inventory store through a read-only GraphQL API so
+// that dashboards can fetch hosts, inventories, components and diffs with
+// nested selection in a single request.
+package gql
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/go-tangra/go-tangra-inventory/internal/diff"
+	"github.com/go-tangra/go-tangra-inventory/internal/store"
+	"github.com/go-tangra/go-tangra-inventory/internal/tenant"
+
+	"github.com/graph-gophers/graphql-go"
+	"google.golang.org/grpc/status"
+)
+
+//go:embed schema.graphql
+var schemaSDL string
+
+// maxDepth bounds query nesting so that a single request cannot fan out
+// into an unbounded number of store lookups.
+const maxDepth = 8
+
+// NewSchema parses the GraphQL schema and binds it to resolvers backed by s.
+func NewSchema(s *store.Store) (*graphql.Schema, error) {
+	schema, err := graphql.ParseSchema(schemaSDL, &queryResolver{store: s},
+		graphql.UseFieldResolvers(),
+		graphql.MaxDepth(maxDepth),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("parse graphql schema: %w", err)
+	}
+	return schema, nil
+}
+
+// queryResolver resolves the root Query type.
+type queryResolver struct {
+	store *store.Store
+}
+
+func (q *queryResolver) Hosts(ctx context.Context, args struct {
+	Site     *string
+	PageSize *int32
+	Page     *int32
+}) (*hostListResolver, error) {
+	sites, err := siteFilter(ctx, args.Site)
+	if err != nil {
+		return nil, err
+	}
+
+	hosts, total, err := q.store.ListHosts(ctx, store.HostFilter{
+		Sites:    sites,
+		PageSize: intArg(args.PageSize),
+		Page:     intArg(args.Page),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list hosts: %w", err)
+	}
+
+	list := &hostListResolver{total: total, hosts: make([]*hostResolver, len(hosts))}
+	for i := range hosts {
+		list.hosts[i] = &hostResolver{store: q.store, host: hosts[i]}
+	}
+	return list, nil
+}
+
+func (q *queryResolver) Host(ctx context.Context, args struct {
+	Hostname string
+	Site     *string
+}) (*hostResolver, error) {
+	sites, err := siteFilter(ctx, args.Site)
+	if err != nil {
+		return nil, err
+	}
+
+	rec, err := q.store.GetLatestByHostname(ctx, args.Hostname, sites)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get latest inventory: %w", err)
+	}
+
+	return &hostResolver{store: q.store, host: store.HostRecord{
+		Site:       rec.Site,
+		Hostname:   rec.Hostname,
+		SystemUUID: rec.SystemUUID,
+		LastSeen:   rec.CollectedAt,
+		LatestID:   rec.ID,
+	}}, nil
+}
+
+func (q *queryResolver) Inventories(ctx context.Context, args struct {
+	Site            *string
+	Hostname        *string
+	Username        *string
+	SystemUuid      *string
+	CollectedAfter  *graphql.Time
+	CollectedBefore *graphql.Time
+	PageSize        *int32
+	Page            *int32
+	After           *string
+	ExactCount      *bool
+}) (*inventoryListResolver, error) {
+	sites, err := siteFilter(ctx, args.Site)
+	if err != nil {
+		return nil, err
+	}
+	after, err := afterArg(args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := store.ListFilter{
+		Sites:      sites,
+		Hostname:   deref(args.Hostname),
+		Username:   deref(args.Username),
+		SystemUUID: deref(args.SystemUuid),
+		PageSize:   intArg(args.PageSize),
+		Page:       intArg(args.Page),
+		After:      after,
+		ExactCount: deref(args.ExactCount),
+	}
+	if args.CollectedAfter != nil {
+		filter.CollectedAfter = &args.CollectedAfter.Time
+	}
+	if args.CollectedBefore != nil {
+		filter.CollectedBefore = &args.CollectedBefore.Time
+	}
+
+	return listInventories(ctx, q.store, filter)
+}
+
+func (q *queryResolver) Inventory(ctx context.Context, args struct{ ID graphql.ID }) (*inventoryResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	sites, _ := tenant.FromContext(ctx)
+	rec, err := q.store.Get(ctx, id, sites)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get inventory: %w", err)
+	}
+	return newInventoryResolver(q.store, rec), nil
+}
+
+func (q *queryResolver) Diff(ctx context.Context, args struct {
+	FromId graphql.ID
+	ToId   graphql.ID
+}) ([]*changeResolver, error) {
+	from, err := q.loadInventory(ctx, args.FromId)
+	if err != nil {
+		return nil, err
+	}
+	to, err := q.loadInventory(ctx, args.ToId)
+	if err != nil {
+		return nil, err
+	}
+
+	prev, err := from.decode(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cur, err := to.decode(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return changeResolvers(diff.Compare(prev, cur)), nil
+}
+
+func (q *queryResolver) Alerts(ctx context.Context, args struct {
+	Site               *string
+	Hostname           *string
+	UnacknowledgedOnly *bool
+	PageSize           *int32
+	Page               *int32
+}) (*alertListResolver, error) {
+	sites, err := siteFilter(ctx, args.Site)
+	if err != nil {
+		return nil, err
+	}
+
+	return listAlerts(ctx, q.store, store.AlertFilter{
+		Sites:              sites,
+		Hostname:           deref(args.Hostname),
+		UnacknowledgedOnly: args.UnacknowledgedOnly != nil && *args.UnacknowledgedOnly,
+		PageSize:           intArg(args.PageSize),
+		Page:               intArg(args.Page),
+	})
+}
+
+// loadInventory fetches an inventory within the caller's scope, reporting a
+// missing one as an error because diff cannot return a partial result.
+func (q *queryResolver) loadInventory(ctx context.Context, gid graphql.ID) (*inventoryResolver, error) {
+	id, err := parseID(gid)
+	if err != nil {
+		return nil, err
+	}
+
+	sites, _ := tenant.FromContext(ctx)
+	rec, err := q.store.Get(ctx, id, sites)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("inventory %d not found", id)
+		}
+		return nil, fmt.Errorf("get inventory: %w", err)
+	}
+	return newInventoryResolver(q.store, rec), nil
+}
+
+func listInventories(ctx context.Context, s *store.Store, f store.ListFilter) (*inventoryListResolver, error) {
+	records, total, err := s.List(ctx, f)
+	if err != nil {
+		return nil, fmt.Errorf("list inventories: %w", err)
+	}
+
+	list := &inventoryListResolver{total: total, inventories: make([]*inventoryResolver, len(records))}
+	for i := range records {
+		list.inventories[i] = newInventoryResolver(s, &records[i])
+	}
+	if next := f.Next(records); next != nil {
+		token := next.Token()
+		list.next = &token
+	}
+	return list, nil
+}
+
+// afterArg decodes the after argument of inventory lists.
+func afterArg(after *string) (*store.ListCursor, error) {
+	if after == nil || *after == "" {
+		return nil, nil
+	}
+	c, err := store.ParseListToken(*after)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func listAlerts(ctx context.Context, s *store.Store, f store.AlertFilter) (*alertListResolver, error) {
+	alerts, total, err := s.ListAlerts(ctx, f)
+	if err != nil {
+		return nil, fmt.Errorf("list alerts: %w", err)
+	}
+
+	list := &alertListResolver{total: total, alerts: make([]*alertResolver, len(alerts))}
+	for i := range alerts {
+		list.alerts[i] = &alertResolver{alert: alerts[i]}
+	}
+	return list, nil
+}
+
+// siteFilter applies tenant.Filter, stripping the gRPC status wrapping from
+// its error so that GraphQL clients see a plain message.
+func siteFilter(ctx context.Context, site *string) ([]string, error) {
+	sites, err := tenant.Filter(ctx, deref(site))
+	if err != nil {
+		return nil, errors.New(status.Convert(err).Message())
+	}
+	return sites, nil
+}
+
+func parseID(id graphql.ID) (int64, error) {
+	n, err := strconv.ParseInt(string(id), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid id %q", id)
+	}
+	return n, nil
+}
+
+func deref[T any](p *T) T {
+	var zero T
+	if p == nil {
+		return zero
+	}
+	return *p
+}
+
+func intArg(p *int32) int {
+	return int(deref(p))
+}
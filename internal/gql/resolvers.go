@@ -0,0 +1,326 @@
+package gql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+	"github.com/go-tangra/go-tangra-inventory/internal/convert"
+	"github.com/go-tangra/go-tangra-inventory/internal/diff"
+	"github.com/go-tangra/go-tangra-inventory/internal/store"
+
+	"github.com/graph-gophers/graphql-go"
+)
+
+type hostListResolver struct {
+	total int
+	hosts []*hostResolver
+}
+
+func (r *hostListResolver) TotalCount() int32      { return int32(r.total) }
+func (r *hostListResolver) Hosts() []*hostResolver { return r.hosts }
+
+type hostResolver struct {
+	store *store.Store
+	host  store.HostRecord
+}
+
+func (r *hostResolver) Site() string           { return r.host.Site }
+func (r *hostResolver) Hostname() string       { return r.host.Hostname }
+func (r *hostResolver) SystemUuid() string     { return r.host.SystemUUID }
+func (r *hostResolver) LastSeen() graphql.Time { return graphql.Time{Time: r.host.LastSeen} }
+
+func (r *hostResolver) Latest(ctx context.Context) (*inventoryResolver, error) {
+	rec, err := r.store.Get(ctx, r.host.LatestID, []string{r.host.Site})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get inventory: %w", err)
+	}
+	return newInventoryResolver(r.store, rec), nil
+}
+
+func (r *hostResolver) History(ctx context.Context, args struct {
+	PageSize *int32
+	Page     *int32
+	After    *string
+}) (*inventoryListResolver, error) {
+	after, err := afterArg(args.After)
+	if err != nil {
+		return nil, err
+	}
+	return listInventories(ctx, r.store, store.ListFilter{
+		Sites:    []string{r.host.Site},
+		Hostname: r.host.Hostname,
+		PageSize: intArg(args.PageSize),
+		Page:     intArg(args.Page),
+		After:    after,
+	})
+}
+
+func (r *hostResolver) Alerts(ctx context.Context, args struct {
+	UnacknowledgedOnly *bool
+	PageSize           *int32
+	Page               *int32
+}) (*alertListResolver, error) {
+	return listAlerts(ctx, r.store, store.AlertFilter{
+		Sites:              []string{r.host.Site},
+		Hostname:           r.host.Hostname,
+		UnacknowledgedOnly: args.UnacknowledgedOnly != nil && *args.UnacknowledgedOnly,
+		PageSize:           intArg(args.PageSize),
+		Page:               intArg(args.Page),
+	})
+}
+
+type inventoryListResolver struct {
+	total       int
+	inventories []*inventoryResolver
+	next        *string
+}
+
+func (r *inventoryListResolver) TotalCount() int32                 { return int32(r.total) }
+func (r *inventoryListResolver) Inventories() []*inventoryResolver { return r.inventories }
+func (r *inventoryListResolver) NextPageToken() *string            { return r.next }
+
+// inventoryResolver serves summary fields from the store record and decodes
+// the full inventory only when a component field is selected. Records from
+// list queries carry no JSON, which is then loaded on first use.
+type inventoryResolver struct {
+	store *store.Store
+	rec   *store.InventoryRecord
+
+	once sync.Once
+	inv  *collectorv1.Inventory
+	err  error
+}
+
+func newInventoryResolver(s *store.Store, rec *store.InventoryRecord) *inventoryResolver {
+	return &inventoryResolver{store: s, rec: rec}
+}
+
+func (r *inventoryResolver) decode(ctx context.Context) (*collectorv1.Inventory, error) {
+	r.once.Do(func() {
+		rec := r.rec
+		if rec.InventoryJSON == "" {
+			// The record was already scoped when it was listed.
+			if rec, r.err = r.store.Get(ctx, rec.ID, nil); r.err != nil {
+				r.err = fmt.Errorf("get inventory: %w", r.err)
+				return
+			}
+		}
+		if r.inv, r.err = convert.RecordToInventory(rec); r.err != nil {
+			r.err = fmt.Errorf("decode inventory: %w", r.err)
+		}
+	})
+	return r.inv, r.err
+}
+
+func (r *inventoryResolver) ID() graphql.ID            { return graphql.ID(strconv.FormatInt(r.rec.ID, 10)) }
+func (r *inventoryResolver) Site() string              { return r.rec.Site }
+func (r *inventoryResolver) Hostname() string          { return r.rec.Hostname }
+func (r *inventoryResolver) Username() string          { return r.rec.Username }
+func (r *inventoryResolver) SystemUuid() string        { return r.rec.SystemUUID }
+func (r *inventoryResolver) SystemSerial() string      { return r.rec.SystemSerial }
+func (r *inventoryResolver) CollectedAt() graphql.Time { return graphql.Time{Time: r.rec.CollectedAt} }
+func (r *inventoryResolver) StoredAt() graphql.Time    { return graphql.Time{Time: r.rec.StoredAt} }
+
+func (r *inventoryResolver) Bios(ctx context.Context) (*collectorv1.BIOSInfo, error) {
+	inv, err := r.decode(ctx)
+	return inv.GetBios(), err
+}
+
+func (r *inventoryResolver) System(ctx context.Context) (*collectorv1.SystemInfo, error) {
+	inv, err := r.decode(ctx)
+	return inv.GetSystem(), err
+}
+
+func (r *inventoryResolver) Baseboard(ctx context.Context) (*collectorv1.BaseboardInfo, error) {
+	inv, err := r.decode(ctx)
+	return inv.GetBaseboard(), err
+}
+
+func (r *inventoryResolver) Chassis(ctx context.Context) (*collectorv1.ChassisInfo, error) {
+	inv, err := r.decode(ctx)
+	return inv.GetChassis(), err
+}
+
+func (r *inventoryResolver) Processors(ctx context.Context) ([]*processorResolver, error) {
+	inv, err := r.decode(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*processorResolver, len(inv.GetProcessors()))
+	for i, p := range inv.GetProcessors() {
+		out[i] = &processorResolver{p}
+	}
+	return out, nil
+}
+
+func (r *inventoryResolver) Memory(ctx context.Context) (*memoryResolver, error) {
+	inv, err := r.decode(ctx)
+	if err != nil || inv.GetMemory() == nil {
+		return nil, err
+	}
+	return &memoryResolver{inv.GetMemory()}, nil
+}
+
+func (r *inventoryResolver) Ports(ctx context.Context) ([]*collectorv1.PortInfo, error) {
+	inv, err := r.decode(ctx)
+	return inv.GetPorts(), err
+}
+
+func (r *inventoryResolver) Slots(ctx context.Context) ([]*collectorv1.SlotInfo, error) {
+	inv, err := r.decode(ctx)
+	return inv.GetSlots(), err
+}
+
+func (r *inventoryResolver) Monitors(ctx context.Context) ([]*collectorv1.MonitorInfo, error) {
+	inv, err := r.decode(ctx)
+	return inv.GetMonitor(), err
+}
+
+func (r *inventoryResolver) OemStrings(ctx context.Context) ([]string, error) {
+	inv, err := r.decode(ctx)
+	return inv.GetOemStrings(), err
+}
+
+func (r *inventoryResolver) Changes(ctx context.Context) ([]*changeResolver, error) {
+	prevRec, err := r.store.GetPrevious(ctx, r.rec)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return []*changeResolver{}, nil
+		}
+		return nil, fmt.Errorf("get previous inventory: %w", err)
+	}
+
+	prev, err := newInventoryResolver(r.store, prevRec).decode(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cur, err := r.decode(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return changeResolvers(diff.Compare(prev, cur)), nil
+}
+
+// processorResolver converts the unsigned proto counters to GraphQL Int.
+type processorResolver struct {
+	p *collectorv1.ProcessorInfo
+}
+
+func (r *processorResolver) SocketDesignation() string { return r.p.SocketDesignation }
+func (r *processorResolver) Manufacturer() string      { return r.p.Manufacturer }
+func (r *processorResolver) Version() string           { return r.p.Version }
+func (r *processorResolver) MaxSpeedMhz() int32        { return int32(r.p.MaxSpeedMhz) }
+func (r *processorResolver) CurrentSpeedMhz() int32    { return int32(r.p.CurrentSpeedMhz) }
+func (r *processorResolver) SocketPopulated() bool     { return r.p.SocketPopulated }
+func (r *processorResolver) SerialNumber() string      { return r.p.SerialNumber }
+func (r *processorResolver) AssetTag() string          { return r.p.AssetTag }
+func (r *processorResolver) PartNumber() string        { return r.p.PartNumber }
+func (r *processorResolver) CoreCount() int32          { return int32(r.p.CoreCount) }
+func (r *processorResolver) CoreEnabled() int32        { return int32(r.p.CoreEnabled) }
+func (r *processorResolver) ThreadCount() int32        { return int32(r.p.ThreadCount) }
+
+type memoryResolver struct {
+	m *collectorv1.MemoryInfo
+}
+
+func (r *memoryResolver) TotalPhysicalBytes() float64 { return float64(r.m.TotalPhysicalBytes) }
+func (r *memoryResolver) TotalPhysicalGb() float64    { return r.m.TotalPhysicalGb }
+
+func (r *memoryResolver) Array() *memoryArrayResolver {
+	if r.m.Array == nil {
+		return nil
+	}
+	return &memoryArrayResolver{r.m.Array}
+}
+
+func (r *memoryResolver) Modules() []*memoryModuleResolver {
+	out := make([]*memoryModuleResolver, len(r.m.Modules))
+	for i, m := range r.m.Modules {
+		out[i] = &memoryModuleResolver{m}
+	}
+	return out
+}
+
+type memoryArrayResolver struct {
+	a *collectorv1.PhysicalMemoryArray
+}
+
+func (r *memoryArrayResolver) Location() string             { return r.a.Location }
+func (r *memoryArrayResolver) Use() string                  { return r.a.Use }
+func (r *memoryArrayResolver) ErrorCorrection() string      { return r.a.ErrorCorrection }
+func (r *memoryArrayResolver) MaximumCapacity() string      { return r.a.MaximumCapacity }
+func (r *memoryArrayResolver) NumberOfMemoryDevices() int32 { return int32(r.a.NumberOfMemoryDevices) }
+
+type memoryModuleResolver struct {
+	m *collectorv1.MemoryModule
+}
+
+func (r *memoryModuleResolver) DeviceLocator() string     { return r.m.DeviceLocator }
+func (r *memoryModuleResolver) BankLocator() string       { return r.m.BankLocator }
+func (r *memoryModuleResolver) CapacityBytes() float64    { return float64(r.m.CapacityBytes) }
+func (r *memoryModuleResolver) FormFactor() string        { return r.m.FormFactor }
+func (r *memoryModuleResolver) MemoryType() string        { return r.m.MemoryType }
+func (r *memoryModuleResolver) TypeDetail() string        { return r.m.TypeDetail }
+func (r *memoryModuleResolver) SpeedMts() int32           { return int32(r.m.SpeedMtS) }
+func (r *memoryModuleResolver) ConfiguredSpeedMts() int32 { return int32(r.m.ConfiguredSpeedMtS) }
+func (r *memoryModuleResolver) Manufacturer() string      { return r.m.Manufacturer }
+func (r *memoryModuleResolver) SerialNumber() string      { return r.m.SerialNumber }
+func (r *memoryModuleResolver) AssetTag() string          { return r.m.AssetTag }
+func (r *memoryModuleResolver) PartNumber() string        { return r.m.PartNumber }
+func (r *memoryModuleResolver) MinimumVoltage() string    { return r.m.MinimumVoltage }
+func (r *memoryModuleResolver) MaximumVoltage() string    { return r.m.MaximumVoltage }
+func (r *memoryModuleResolver) ConfiguredVoltage() string { return r.m.ConfiguredVoltage }
+func (r *memoryModuleResolver) TotalWidth() string        { return r.m.TotalWidth }
+func (r *memoryModuleResolver) DataWidth() string         { return r.m.DataWidth }
+
+type changeResolver struct {
+	c diff.Change
+}
+
+func changeResolvers(changes []diff.Change) []*changeResolver {
+	out := make([]*changeResolver, len(changes))
+	for i, c := range changes {
+		out[i] = &changeResolver{c}
+	}
+	return out
+}
+
+func (r *changeResolver) Type() string        { return string(r.c.Type) }
+func (r *changeResolver) Component() string   { return r.c.Component }
+func (r *changeResolver) Key() string         { return r.c.Key }
+func (r *changeResolver) Field() string       { return r.c.Field }
+func (r *changeResolver) Old() string         { return r.c.Old }
+func (r *changeResolver) New() string         { return r.c.New }
+func (r *changeResolver) Description() string { return r.c.String() }
+
+type alertListResolver struct {
+	total  int
+	alerts []*alertResolver
+}
+
+func (r *alertListResolver) TotalCount() int32        { return int32(r.total) }
+func (r *alertListResolver) Alerts() []*alertResolver { return r.alerts }
+
+type alertResolver struct {
+	alert store.AlertRecord
+}
+
+func (r *alertResolver) ID() graphql.ID   { return graphql.ID(strconv.FormatInt(r.alert.ID, 10)) }
+func (r *alertResolver) Site() string     { return r.alert.Site }
+func (r *alertResolver) Hostname() string { return r.alert.Hostname }
+func (r *alertResolver) InventoryId() graphql.ID {
+	return graphql.ID(strconv.FormatInt(r.alert.InventoryID, 10))
+}
+func (r *alertResolver) Rule() string            { return r.alert.Rule }
+func (r *alertResolver) Severity() string        { return r.alert.Severity }
+func (r *alertResolver) Message() string         { return r.alert.Message }
+func (r *alertResolver) CreatedAt() graphql.Time { return graphql.Time{Time: r.alert.CreatedAt} }
+func (r *alertResolver) Acknowledged() bool      { return r.alert.Acknowledged }
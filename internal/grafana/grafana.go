@@ -0,0 +1,169 @@
+// Package grafana serves the fleet statistics and host timelines to Grafana
+// dashboards through the API of the Grafana JSON datasource plugin
+// (simpod-json-datasource): search, query and annotations.
+package grafana
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-tangra/go-tangra-inventory/internal/logging"
+	"github.com/go-tangra/go-tangra-inventory/internal/store"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxRequestBytes bounds the body of datasource requests.
+const maxRequestBytes = 1 << 20
+
+// Handler serves the datasource API below a path prefix: GET <prefix> for
+// the connection test, and POST <prefix>search, metrics, query and
+// annotations. Queries are limited to the sites of the caller (see
+// tenant.Filter).
+type Handler struct {
+	db     *store.Store
+	prefix string
+}
+
+// NewHandler returns a Handler serving the datasource API of db below
+// prefix, which ends with a slash.
+func NewHandler(db *store.Store, prefix string) *Handler {
+	return &Handler{db: db, prefix: prefix}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	endpoint := strings.TrimPrefix(r.URL.Path, h.prefix)
+	if endpoint == "" {
+		// The datasource connection test.
+		if r.Method != http.MethodGet {
+			methodNotAllowed(w, http.MethodGet)
+			return
+		}
+		w.Write([]byte("OK\n"))
+		return
+	}
+
+	var serve func(*http.Request) (any, error)
+	switch endpoint {
+	case "search":
+		serve = h.search
+	case "metrics":
+		serve = h.metrics
+	case "query":
+		serve = h.query
+	case "annotations":
+		serve = h.annotations
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w, http.MethodPost)
+		return
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestBytes)
+
+	res, err := serve(r)
+	if err != nil {
+		var reqErr requestError
+		switch {
+		case errors.As(err, &reqErr):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case status.Code(err) == codes.PermissionDenied:
+			http.Error(w, status.Convert(err).Message(), http.StatusForbidden)
+		default:
+			slog.Error("Grafana datasource request failed", "endpoint", endpoint, logging.Err(err))
+			http.Error(w, "internal error", http.StatusInternalServerError)
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(res); err != nil {
+		slog.Debug("Writing Grafana datasource response failed", logging.Err(err))
+	}
+}
+
+func methodNotAllowed(w http.ResponseWriter, allow string) {
+	w.Header().Set("Allow", allow)
+	http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+}
+
+// requestError is a malformed or unsupported request.
+type requestError string
+
+func (e requestError) Error() string { return string(e) }
+
+// timeRange is the dashboard time range of a request.
+type timeRange struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+// payload is the target options of the datasource query editor.
+type payload struct {
+	Site     string `json:"site"`
+	Hostname string `json:"hostname"`
+}
+
+// queryRequest is the body of POST query.
+type queryRequest struct {
+	Range         timeRange `json:"range"`
+	IntervalMs    int64     `json:"intervalMs"`
+	MaxDataPoints int       `json:"maxDataPoints"`
+	Targets       []struct {
+		Target  string          `json:"target"`
+		RefID   string          `json:"refId"`
+		Hide    bool            `json:"hide"`
+		Payload json.RawMessage `json:"payload"`
+	} `json:"targets"`
+}
+
+// annotationRequest is the body of POST annotations.
+type annotationRequest struct {
+	Range      timeRange `json:"range"`
+	Annotation struct {
+		Name  string `json:"name"`
+		Query string `json:"query"`
+	} `json:"annotation"`
+}
+
+// series is a time series result: datapoints are [value, Unix ms] pairs.
+type series struct {
+	Target     string       `json:"target"`
+	Datapoints [][2]float64 `json:"datapoints"`
+}
+
+// table is a table result.
+type table struct {
+	Type    string   `json:"type"`
+	Columns []column `json:"columns"`
+	Rows    [][]any  `json:"rows"`
+}
+
+type column struct {
+	Text string `json:"text"`
+	// Type is string, number or time.
+	Type string `json:"type"`
+}
+
+// annotation is an event shown on time series panels.
+type annotation struct {
+	// Time is in Unix ms.
+	Time  int64    `json:"time"`
+	Title string   `json:"title"`
+	Text  string   `json:"text"`
+	Tags  []string `json:"tags"`
+}
+
+// decode reads the JSON body of r into v.
+func decode(r *http.Request, v any) error {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return requestError("invalid request body: " + err.Error())
+	}
+	return nil
+}
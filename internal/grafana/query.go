@@ -0,0 +1,408 @@
+package grafana
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+	"github.com/go-tangra/go-tangra-inventory/internal/convert"
+	"github.com/go-tangra/go-tangra-inventory/internal/diff"
+	"github.com/go-tangra/go-tangra-inventory/internal/store"
+	"github.com/go-tangra/go-tangra-inventory/internal/tenant"
+)
+
+const (
+	// maxBuckets bounds the intervals of fleet time series, whatever the
+	// interval Grafana asks for.
+	maxBuckets = 10000
+	// minStep is the shortest interval of fleet time series.
+	minStep = time.Minute
+	// maxRecords bounds the inventories and alerts read for one host
+	// timeline or annotation query.
+	maxRecords = 1000
+)
+
+// metric is a query target.
+type metric struct {
+	Name        string
+	Description string
+	// Host targets need the hostname payload option.
+	Host bool
+}
+
+// targets lists the query targets; hosts, models, memory and host_timeline
+// return tables, the others time series.
+var targets = []metric{
+	{Name: "submissions", Description: "Inventories collected per interval"},
+	{Name: "active_hosts", Description: "Hosts that submitted per interval"},
+	{Name: "new_hosts", Description: "Hosts first seen per interval"},
+	{Name: "host_memory_bytes", Description: "Installed memory of a host, per inventory", Host: true},
+	{Name: "host_cores", Description: "Processor cores of a host, per inventory", Host: true},
+	{Name: "hosts", Description: "Latest facts of each host"},
+	{Name: "models", Description: "Hosts per model"},
+	{Name: "memory", Description: "Hosts per installed memory size"},
+	{Name: "host_timeline", Description: "Inventories of a host", Host: true},
+}
+
+func lookupMetric(name string) (metric, bool) {
+	i := slices.IndexFunc(targets, func(m metric) bool { return m.Name == name })
+	if i < 0 {
+		return metric{}, false
+	}
+	return targets[i], true
+}
+
+// search returns the target names, for the legacy query editor.
+func (h *Handler) search(r *http.Request) (any, error) {
+	type option struct {
+		Text  string `json:"text"`
+		Value string `json:"value"`
+	}
+	opts := make([]option, len(targets))
+	for i, m := range targets {
+		opts[i] = option{Text: m.Name, Value: m.Name}
+	}
+	return opts, nil
+}
+
+// metrics describes the targets and their payload options, for the query
+// editor of datasource plugin versions 0.4 and later.
+func (h *Handler) metrics(r *http.Request) (any, error) {
+	type payloadOption struct {
+		Name        string `json:"name"`
+		Label       string `json:"label"`
+		Type        string `json:"type"`
+		Placeholder string `json:"placeholder,omitempty"`
+	}
+	type metricOption struct {
+		Label    string          `json:"label"`
+		Value    string          `json:"value"`
+		Payloads []payloadOption `json:"payloads"`
+	}
+	opts := make([]metricOption, len(targets))
+	for i, m := range targets {
+		opts[i] = metricOption{Label: m.Description, Value: m.Name, Payloads: []payloadOption{
+			{Name: "site", Label: "Site", Type: "input", Placeholder: "all sites"},
+		}}
+		if m.Host {
+			opts[i].Payloads = append(opts[i].Payloads, payloadOption{Name: "hostname", Label: "Hostname", Type: "input"})
+		}
+	}
+	return opts, nil
+}
+
+// query returns a result per visible target.
+func (h *Handler) query(r *http.Request) (any, error) {
+	var req queryRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	if req.Range.From.IsZero() || !req.Range.To.After(req.Range.From) {
+		return nil, requestError("range.from must be before range.to")
+	}
+
+	results := []any{}
+	for _, t := range req.Targets {
+		if t.Hide || t.Target == "" {
+			continue
+		}
+		m, ok := lookupMetric(t.Target)
+		if !ok {
+			return nil, requestError(fmt.Sprintf("unknown target %q", t.Target))
+		}
+		p, err := decodePayload(t.Payload)
+		if err != nil {
+			return nil, err
+		}
+		if m.Host && p.Hostname == "" {
+			return nil, requestError(fmt.Sprintf("target %s needs the hostname payload option", t.Target))
+		}
+		sites, err := tenant.Filter(r.Context(), p.Site)
+		if err != nil {
+			return nil, err
+		}
+
+		var res any
+		switch m.Name {
+		case "submissions", "active_hosts", "new_hosts":
+			res, err = h.fleetSeries(r, m.Name, sites, req)
+		case "host_memory_bytes", "host_cores":
+			res, err = h.hostSeries(r, m.Name, sites, p.Hostname, req.Range)
+		case "hosts":
+			res, err = h.hostsTable(r, sites)
+		case "models", "memory":
+			res, err = h.countsTable(r, m.Name, sites)
+		case "host_timeline":
+			res, err = h.timelineTable(r, sites, p.Hostname, req.Range)
+		}
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+// decodePayload reads the payload options of a target, which plugin
+// versions send as an object or as a JSON string.
+func decodePayload(raw json.RawMessage) (payload, error) {
+	var p payload
+	if len(raw) == 0 || string(raw) == "null" {
+		return p, nil
+	}
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		if strings.TrimSpace(s) == "" {
+			return p, nil
+		}
+		raw = json.RawMessage(s)
+	}
+	if err := json.Unmarshal(raw, &p); err != nil {
+		return p, requestError("invalid payload: " + err.Error())
+	}
+	return p, nil
+}
+
+// step returns the interval of fleet time series for req.
+func step(req queryRequest) time.Duration {
+	d := time.Duration(req.IntervalMs) * time.Millisecond
+	span := req.Range.To.Sub(req.Range.From)
+	if req.MaxDataPoints > 0 {
+		d = max(d, span/time.Duration(req.MaxDataPoints))
+	}
+	d = max(d, span/maxBuckets, minStep)
+	return d.Truncate(time.Second)
+}
+
+func (h *Handler) fleetSeries(r *http.Request, name string, sites []string, req queryRequest) (*series, error) {
+	buckets, err := h.db.SubmissionSeries(r.Context(), sites, req.Range.From, req.Range.To, step(req))
+	if err != nil {
+		return nil, err
+	}
+	s := &series{Target: name, Datapoints: make([][2]float64, len(buckets))}
+	for i, b := range buckets {
+		v := b.Inventories
+		switch name {
+		case "active_hosts":
+			v = b.Hosts
+		case "new_hosts":
+			v = b.NewHosts
+		}
+		s.Datapoints[i] = [2]float64{float64(v), float64(b.Start.UnixMilli())}
+	}
+	return s, nil
+}
+
+// hostInventories returns the inventories of hostname collected in rng,
+// oldest first.
+func (h *Handler) hostInventories(r *http.Request, sites []string, hostname string, rng timeRange) ([]store.InventoryRecord, error) {
+	records, _, err := h.db.List(r.Context(), store.ListFilter{
+		Sites:           sites,
+		Hostname:        hostname,
+		CollectedAfter:  &rng.From,
+		CollectedBefore: &rng.To,
+		PageSize:        maxRecords,
+		WithJSON:        true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices.Reverse(records)
+	return records, nil
+}
+
+func (h *Handler) hostSeries(r *http.Request, name string, sites []string, hostname string, rng timeRange) (*series, error) {
+	records, err := h.hostInventories(r, sites, hostname, rng)
+	if err != nil {
+		return nil, err
+	}
+	s := &series{Target: name, Datapoints: make([][2]float64, 0, len(records))}
+	for i := range records {
+		inv, err := convert.RecordToInventory(&records[i])
+		if err != nil {
+			return nil, err
+		}
+		v := float64(inv.GetMemory().GetTotalPhysicalBytes())
+		if name == "host_cores" {
+			v = float64(cores(inv))
+		}
+		s.Datapoints = append(s.Datapoints, [2]float64{v, float64(records[i].CollectedAt.UnixMilli())})
+	}
+	return s, nil
+}
+
+func (h *Handler) hostsTable(r *http.Request, sites []string) (*table, error) {
+	facts, err := h.db.ListHostFacts(r.Context(), sites)
+	if err != nil {
+		return nil, err
+	}
+	t := &table{Type: "table", Columns: []column{
+		{Text: "Last seen", Type: "time"},
+		{Text: "Site", Type: "string"},
+		{Text: "Hostname", Type: "string"},
+		{Text: "Class", Type: "string"},
+		{Text: "Manufacturer", Type: "string"},
+		{Text: "Model", Type: "string"},
+		{Text: "Serial number", Type: "string"},
+		{Text: "Processor", Type: "string"},
+		{Text: "Memory", Type: "number"},
+		{Text: "Username", Type: "string"},
+	}, Rows: make([][]any, len(facts))}
+	for i, f := range facts {
+		t.Rows[i] = []any{f.LastSeen.UnixMilli(), f.Site, f.Hostname, f.DeviceClass, f.Manufacturer, f.Model,
+			f.SerialNumber, f.Processor, f.MemoryBytes, f.Username}
+	}
+	return t, nil
+}
+
+func (h *Handler) countsTable(r *http.Request, name string, sites []string) (*table, error) {
+	stats, err := h.db.FleetStats(r.Context(), sites)
+	if err != nil {
+		return nil, err
+	}
+	counts, header := stats.Models, "Model"
+	if name == "memory" {
+		counts, header = stats.Memory, "Memory"
+	}
+	t := &table{Type: "table", Columns: []column{{Text: header, Type: "string"}, {Text: "Hosts", Type: "number"}},
+		Rows: make([][]any, len(counts))}
+	for i, c := range counts {
+		t.Rows[i] = []any{c.Value, c.Hosts}
+	}
+	return t, nil
+}
+
+func (h *Handler) timelineTable(r *http.Request, sites []string, hostname string, rng timeRange) (*table, error) {
+	records, err := h.hostInventories(r, sites, hostname, rng)
+	if err != nil {
+		return nil, err
+	}
+	t := &table{Type: "table", Columns: []column{
+		{Text: "Collected", Type: "time"},
+		{Text: "Inventory", Type: "number"},
+		{Text: "Site", Type: "string"},
+		{Text: "Username", Type: "string"},
+		{Text: "Memory", Type: "number"},
+		{Text: "Cores", Type: "number"},
+		{Text: "Changes", Type: "string"},
+	}, Rows: make([][]any, 0, len(records))}
+	prev := map[string]*collectorv1.Inventory{}
+	for i := range records {
+		rec := &records[i]
+		inv, err := convert.RecordToInventory(rec)
+		if err != nil {
+			return nil, err
+		}
+		var changes []string
+		for _, c := range diff.Compare(prev[rec.Site], inv) {
+			changes = append(changes, c.String())
+		}
+		prev[rec.Site] = inv
+		t.Rows = append(t.Rows, []any{rec.CollectedAt.UnixMilli(), rec.ID, rec.Site, rec.Username,
+			inv.GetMemory().GetTotalPhysicalBytes(), cores(inv), strings.Join(changes, "; ")})
+	}
+	// Newest first, as tables are read.
+	slices.Reverse(t.Rows)
+	return t, nil
+}
+
+// annotations returns the events of the annotation query: "alerts" or
+// "alerts <hostname>" for the alerts raised, and "changes <hostname>" for
+// the hardware changes of a host.
+func (h *Handler) annotations(r *http.Request) (any, error) {
+	var req annotationRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	args := strings.Fields(req.Annotation.Query)
+	if len(args) == 0 || len(args) > 2 {
+		return nil, requestError(`query must be "alerts [hostname]" or "changes <hostname>"`)
+	}
+	hostname := ""
+	if len(args) == 2 {
+		hostname = args[1]
+	}
+	sites, err := tenant.Filter(r.Context(), "")
+	if err != nil {
+		return nil, err
+	}
+
+	events := []annotation{}
+	switch args[0] {
+	case "alerts":
+		alerts, _, err := h.db.ListAlerts(r.Context(), store.AlertFilter{
+			Sites:         sites,
+			Hostname:      hostname,
+			CreatedAfter:  &req.Range.From,
+			CreatedBefore: &req.Range.To,
+			PageSize:      maxRecords,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range alerts {
+			events = append(events, annotation{
+				Time:  a.CreatedAt.UnixMilli(),
+				Title: a.Hostname + ": " + a.Rule,
+				Text:  a.Message,
+				Tags:  []string{"alert", a.Severity, a.Hostname},
+			})
+		}
+	case "changes":
+		if hostname == "" {
+			return nil, requestError(`query must be "changes <hostname>"`)
+		}
+		records, err := h.hostInventories(r, sites, hostname, req.Range)
+		if err != nil {
+			return nil, err
+		}
+		if len(records) == 0 {
+			return events, nil
+		}
+		// The inventory before the range is the base of the first change.
+		prev := map[string]*collectorv1.Inventory{}
+		if base, err := h.db.GetPrevious(r.Context(), &records[0]); err == nil {
+			if prev[base.Site], err = convert.RecordToInventory(base); err != nil {
+				return nil, err
+			}
+		}
+		for i := range records {
+			rec := &records[i]
+			inv, err := convert.RecordToInventory(rec)
+			if err != nil {
+				return nil, err
+			}
+			changes := diff.Compare(prev[rec.Site], inv)
+			prev[rec.Site] = inv
+			if len(changes) == 0 {
+				continue
+			}
+			lines := make([]string, len(changes))
+			for j, c := range changes {
+				lines[j] = c.String()
+			}
+			events = append(events, annotation{
+				Time:  rec.CollectedAt.UnixMilli(),
+				Title: fmt.Sprintf("%s: %d hardware changes", rec.Hostname, len(changes)),
+				Text:  strings.Join(lines, "\n"),
+				Tags:  []string{"change", rec.Hostname},
+			})
+		}
+	default:
+		return nil, requestError(fmt.Sprintf("unknown annotation query %q (use alerts or changes)", args[0]))
+	}
+	return events, nil
+}
+
+// cores returns the processor cores of inv.
+func cores(inv *collectorv1.Inventory) uint32 {
+	var n uint32
+	for _, p := range inv.GetProcessors() {
+		n += p.CoreCount
+	}
+	return n
+}
@@ -0,0 +1,190 @@
+// Package importer imports the device inventory of other inventory systems,
+// Configuration Manager (SCCM) and Intune, so a migration to the collector
+// starts with the existing fleet. Imported devices are reconciled with the
+// stored hosts by system UUID, serial number or hostname, and their
+// inventories are marked with the source they came from.
+package importer
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+	"github.com/go-tangra/go-tangra-inventory/internal/convert"
+	"github.com/go-tangra/go-tangra-inventory/internal/store"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Device is a device read from another inventory system.
+type Device struct {
+	Hostname     string
+	Username     string
+	Manufacturer string
+	Model        string
+	SerialNumber string
+	// UUID is the SMBIOS system UUID, if the source records it.
+	UUID        string
+	BIOSVersion string
+	Processor   string
+	Cores       uint32
+	Threads     uint32
+	MaxSpeedMHz uint32
+	MemoryBytes uint64
+	// LastSeen is when the source last inventoried the device; it becomes
+	// the collection time of the imported inventory.
+	LastSeen time.Time
+}
+
+// Source reads devices from an inventory system.
+type Source interface {
+	// Name is the store source of the imported inventories, e.g.
+	// store.SourceSCCM.
+	Name() string
+	Devices(ctx context.Context) ([]Device, error)
+}
+
+// Result summarizes an import.
+type Result struct {
+	// Devices is the number of devices read from the source.
+	Devices int
+	// Imported is the number of inventories stored, of which Matched
+	// belong to hosts that were already stored.
+	Imported int
+	Matched  int
+	// Skipped is the number of devices without a hostname, or whose host
+	// already has an inventory at least as recent.
+	Skipped int
+}
+
+// placeholderSerials are serial numbers firmware reports when none was set;
+// they cannot identify a device.
+var placeholderSerials = map[string]bool{
+	"":                       true,
+	"0":                      true,
+	"none":                   true,
+	"default string":         true,
+	"to be filled by o.e.m.": true,
+	"system serial number":   true,
+	"not specified":          true,
+	"not applicable":         true,
+}
+
+// Import reads the devices of src and stores an inventory for each device
+// the store has no inventory as recent of. A device matching a host of site
+// by system UUID, serial number or hostname is stored as that host; other
+// devices are stored as new hosts of site. Imported inventories
+// do not raise alerts or events. dryRun counts without storing.
+func Import(ctx context.Context, db *store.Store, src Source, site string, dryRun bool) (Result, error) {
+	devices, err := src.Devices(ctx)
+	if err != nil {
+		return Result{}, fmt.Errorf("read %s devices: %w", src.Name(), err)
+	}
+
+	res := Result{Devices: len(devices)}
+	for _, d := range devices {
+		d.SerialNumber = strings.TrimSpace(d.SerialNumber)
+		if placeholderSerials[strings.ToLower(d.SerialNumber)] {
+			d.SerialNumber = ""
+		}
+		if d.Hostname == "" {
+			res.Skipped++
+			continue
+		}
+
+		existing, err := match(ctx, db, d, site)
+		if err != nil {
+			return res, fmt.Errorf("match %s: %w", d.Hostname, err)
+		}
+		inv := inventory(d, src.Name(), site)
+		if existing != nil {
+			if !existing.CollectedAt.Before(d.LastSeen) {
+				res.Skipped++
+				continue
+			}
+			inv.Hostname = existing.Hostname
+			res.Matched++
+		}
+		res.Imported++
+		if dryRun {
+			continue
+		}
+
+		rec, err := convert.InventoryToRecord(inv)
+		if err != nil {
+			return res, fmt.Errorf("convert %s: %w", d.Hostname, err)
+		}
+		if _, _, err := db.Insert(ctx, rec); err != nil {
+			return res, err
+		}
+		slog.Debug("Inventory imported", "source", src.Name(), "hostname", inv.Hostname, "site", inv.Site)
+	}
+	return res, nil
+}
+
+// match returns the latest stored inventory of site's host d is, or nil if
+// it is a new host.
+func match(ctx context.Context, db *store.Store, d Device, site string) (*store.InventoryRecord, error) {
+	lookups := []struct {
+		value string
+		get   func(context.Context, string, []string) (*store.InventoryRecord, error)
+	}{
+		{d.UUID, db.GetLatestBySystemUUID},
+		{d.SerialNumber, db.GetLatestBySerial},
+		{d.Hostname, db.GetLatestByHostname},
+	}
+	for _, l := range lookups {
+		if l.value == "" {
+			continue
+		}
+		// Hosts of other sites are never matched: cloned VMs share system
+		// UUIDs and cheap hardware serial numbers.
+		rec, err := l.get(ctx, l.value, []string{site})
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		return rec, err
+	}
+	return nil, nil
+}
+
+// inventory returns the inventory of d.
+func inventory(d Device, source, site string) *collectorv1.Inventory {
+	inv := &collectorv1.Inventory{
+		CollectedAt: timestamppb.New(d.LastSeen),
+		Hostname:    d.Hostname,
+		Username:    d.Username,
+		Site:        site,
+		Source:      source,
+		System: &collectorv1.SystemInfo{
+			Manufacturer: d.Manufacturer,
+			ProductName:  d.Model,
+			SerialNumber: d.SerialNumber,
+			Uuid:         d.UUID,
+		},
+	}
+	if d.BIOSVersion != "" {
+		inv.Bios = &collectorv1.BIOSInfo{Version: d.BIOSVersion}
+	}
+	if d.Processor != "" {
+		inv.Processors = []*collectorv1.ProcessorInfo{{
+			Version:         d.Processor,
+			MaxSpeedMhz:     d.MaxSpeedMHz,
+			SocketPopulated: true,
+			CoreCount:       d.Cores,
+			ThreadCount:     d.Threads,
+		}}
+	}
+	if d.MemoryBytes > 0 {
+		inv.Memory = &collectorv1.MemoryInfo{
+			TotalPhysicalBytes: d.MemoryBytes,
+			TotalPhysicalGb:    float64(d.MemoryBytes) / (1024 * 1024 * 1024),
+		}
+	}
+	return inv
+}
@@ -0,0 +1,164 @@
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-tangra/go-tangra-inventory/internal/store"
+)
+
+// requestTimeout bounds a single Microsoft Graph or token request.
+const requestTimeout = 60 * time.Second
+
+// Microsoft identity platform and Graph endpoints.
+const (
+	loginURL = "https://login.microsoftonline.com"
+	graphURL = "https://graph.microsoft.com"
+)
+
+// managedDevicesPath lists the Intune managed devices with the properties
+// imported.
+const managedDevicesPath = "/v1.0/deviceManagement/managedDevices?$select=" +
+	"deviceName,userPrincipalName,manufacturer,model,serialNumber,physicalMemoryInBytes,lastSyncDateTime,operatingSystem"
+
+// intuneSystems are the operating systems of the managed devices imported;
+// phones and tablets are not inventoried by the collector.
+var intuneSystems = []string{"windows", "macos"}
+
+// Intune reads the managed devices of an Intune tenant through Microsoft
+// Graph, authenticating as an app registration with the
+// DeviceManagementManagedDevices.Read.All application permission.
+type Intune struct {
+	TenantID     string
+	ClientID     string
+	ClientSecret string
+	Client       *http.Client
+}
+
+// managedDevice is the part of an Intune managedDevice imported.
+type managedDevice struct {
+	DeviceName            string    `json:"deviceName"`
+	UserPrincipalName     string    `json:"userPrincipalName"`
+	Manufacturer          string    `json:"manufacturer"`
+	Model                 string    `json:"model"`
+	SerialNumber          string    `json:"serialNumber"`
+	PhysicalMemoryInBytes int64     `json:"physicalMemoryInBytes"`
+	LastSyncDateTime      time.Time `json:"lastSyncDateTime"`
+	OperatingSystem       string    `json:"operatingSystem"`
+}
+
+// Name implements Source.
+func (c *Intune) Name() string { return store.SourceIntune }
+
+// Devices implements Source. Only Windows and macOS devices are returned.
+func (c *Intune) Devices(ctx context.Context) ([]Device, error) {
+	token, err := c.token(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var devices []Device
+	next := graphURL + managedDevicesPath
+	for next != "" {
+		var page struct {
+			Value    []managedDevice `json:"value"`
+			NextLink string          `json:"@odata.nextLink"`
+		}
+		if err := c.get(ctx, next, token, &page); err != nil {
+			return nil, fmt.Errorf("list managed devices: %w", err)
+		}
+		for _, d := range page.Value {
+			if !slices.Contains(intuneSystems, strings.ToLower(d.OperatingSystem)) {
+				continue
+			}
+			devices = append(devices, Device{
+				Hostname:     d.DeviceName,
+				Username:     d.UserPrincipalName,
+				Manufacturer: d.Manufacturer,
+				Model:        d.Model,
+				SerialNumber: d.SerialNumber,
+				MemoryBytes:  uint64(max(d.PhysicalMemoryInBytes, 0)),
+				LastSeen:     d.LastSyncDateTime.UTC(),
+			})
+		}
+		next = page.NextLink
+	}
+	return devices, nil
+}
+
+// token obtains a Graph access token with the client credentials grant.
+func (c *Intune) token(ctx context.Context) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {c.ClientID},
+		"client_secret": {c.ClientSecret},
+		"scope":         {graphURL + "/.default"},
+	}
+	endpoint := loginURL + "/" + url.PathEscape(c.TenantID) + "/oauth2/v2.0/token"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("build token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.client().Do(req)
+	if err != nil {
+		return "", fmt.Errorf("get access token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var res struct {
+		AccessToken      string `json:"access_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return "", fmt.Errorf("get access token: unexpected status %s", resp.Status)
+	}
+	if res.AccessToken == "" {
+		return "", fmt.Errorf("get access token: %s: %s", res.Error, res.ErrorDescription)
+	}
+	return res.AccessToken, nil
+}
+
+func (c *Intune) get(ctx context.Context, endpoint, token string, v any) error {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("build request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.client().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	return nil
+}
+
+func (c *Intune) client() *http.Client {
+	if c.Client != nil {
+		return c.Client
+	}
+	return http.DefaultClient
+}
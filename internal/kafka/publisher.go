@@ -0,0 +1,93 @@
+// Package kafka publishes inventory events to a Kafka topic.
+package kafka
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/segmentio/kafka-go"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+	"github.com/go-tangra/go-tangra-inventory/internal/store"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Encodings of the published messages.
+const (
+	EncodingJSON     = "json"
+	EncodingProtobuf = "protobuf"
+)
+
+// Publisher writes InventorySubmission events from the outbox to a Kafka
+// topic, keyed by their outbox key (the system UUID).
+type Publisher struct {
+	writer   *kafka.Writer
+	encoding string
+}
+
+// NewPublisher returns a Publisher writing to topic on brokers in encoding.
+func NewPublisher(brokers []string, topic, encoding string) (*Publisher, error) {
+	if encoding != EncodingJSON && encoding != EncodingProtobuf {
+		return nil, fmt.Errorf("unknown encoding %q (use %s or %s)", encoding, EncodingJSON, EncodingProtobuf)
+	}
+	return &Publisher{
+		writer: &kafka.Writer{
+			Addr:         kafka.TCP(brokers...),
+			Topic:        topic,
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: kafka.RequireAll,
+		},
+		encoding: encoding,
+	}, nil
+}
+
+// Publish writes events to the topic and returns once the brokers have
+// acknowledged them.
+func (p *Publisher) Publish(ctx context.Context, events []store.OutboxEvent) error {
+	msgs := make([]kafka.Message, len(events))
+	for i, e := range events {
+		value, err := p.encode(e.Payload)
+		if err != nil {
+			return fmt.Errorf("encode outbox event %d: %w", e.ID, err)
+		}
+		msgs[i] = kafka.Message{
+			Key:   []byte(e.Key),
+			Value: value,
+			Time:  e.CreatedAt,
+			Headers: []kafka.Header{
+				{Key: "content-type", Value: []byte(p.contentType())},
+			},
+		}
+	}
+	if err := p.writer.WriteMessages(ctx, msgs...); err != nil {
+		return fmt.Errorf("write %d messages to Kafka: %w", len(msgs), err)
+	}
+	return nil
+}
+
+// Close flushes and closes the connections to the brokers.
+func (p *Publisher) Close() error {
+	return p.writer.Close()
+}
+
+// encode converts an outbox payload, a binary InventorySubmission, to the
+// configured encoding.
+func (p *Publisher) encode(payload []byte) ([]byte, error) {
+	if p.encoding == EncodingProtobuf {
+		return payload, nil
+	}
+	var sub collectorv1.InventorySubmission
+	if err := proto.Unmarshal(payload, &sub); err != nil {
+		return nil, err
+	}
+	return protojson.MarshalOptions{UseProtoNames: true}.Marshal(&sub)
+}
+
+func (p *Publisher) contentType() string {
+	if p.encoding == EncodingProtobuf {
+		return "application/x-protobuf; messageType=inventory.collector.v1.InventorySubmission"
+	}
+	return "application/json"
+}
@@ -0,0 +1,76 @@
+// Package logging configures the structured slog logger shared by the agent
+// and the collector.
+//
+// Records use consistent attribute keys so that log pipelines can correlate
+// agents, commands and stored inventories: client_id, site, hostname,
+// command_id, record_id, version and error.
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// Supported output formats.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// Options selects the log level and output format.
+type Options struct {
+	// Level is debug, info, warn or error (empty = info).
+	Level string
+	// Format is text or json (empty = text).
+	Format string
+}
+
+// NewHandler returns a handler that writes records at or above the
+// configured level to w. With noTime the time attribute is left out, for
+// sinks such as the Windows Event Log that timestamp entries themselves.
+func (o Options) NewHandler(w io.Writer, noTime bool) (slog.Handler, error) {
+	var level slog.Level
+	if o.Level != "" {
+		if err := level.UnmarshalText([]byte(o.Level)); err != nil {
+			return nil, fmt.Errorf("unknown log level %q (use debug, info, warn or error)", o.Level)
+		}
+	}
+
+	opts := &slog.HandlerOptions{Level: level}
+	if noTime {
+		opts.ReplaceAttr = func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		}
+	}
+
+	switch strings.ToLower(o.Format) {
+	case "", FormatText:
+		return slog.NewTextHandler(w, opts), nil
+	case FormatJSON:
+		return slog.NewJSONHandler(w, opts), nil
+	default:
+		return nil, fmt.Errorf("unknown log format %q (use text or json)", o.Format)
+	}
+}
+
+// Setup installs a stderr logger built from o as the slog default, which
+// also routes the standard log package through it.
+func (o Options) Setup() error {
+	h, err := o.NewHandler(os.Stderr, false)
+	if err != nil {
+		return err
+	}
+	slog.SetDefault(slog.New(h))
+	return nil
+}
+
+// Err returns err as the error attribute.
+func Err(err error) slog.Attr {
+	return slog.Any("error", err)
+}
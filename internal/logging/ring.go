@@ -0,0 +1,119 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+)
+
+// Ring keeps the most recent output written to it, up to a fixed size, so
+// that a running process can hand out its recent log on request.
+type Ring struct {
+	mu   sync.Mutex
+	buf  []byte
+	next int
+	full bool
+}
+
+// NewRing returns a Ring that keeps the last size bytes written to it.
+func NewRing(size int) *Ring {
+	return &Ring{buf: make([]byte, size)}
+}
+
+// Write implements io.Writer; it never fails.
+func (r *Ring) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	n := len(p)
+	if n >= len(r.buf) {
+		p = p[n-len(r.buf):]
+	}
+	for len(p) > 0 {
+		c := copy(r.buf[r.next:], p)
+		p = p[c:]
+		r.next += c
+		if r.next == len(r.buf) {
+			r.next, r.full = 0, true
+		}
+	}
+	return n, nil
+}
+
+// Tail returns up to limit bytes of the most recent output, starting at a line
+// boundary, and whether older output was left out or already overwritten.
+func (r *Ring) Tail(limit int) (tail []byte, truncated bool) {
+	r.mu.Lock()
+	var b []byte
+	if r.full {
+		b = append(append(b, r.buf[r.next:]...), r.buf[:r.next]...)
+	} else {
+		b = append(b, r.buf[:r.next]...)
+	}
+	truncated = r.full
+	r.mu.Unlock()
+
+	if limit > 0 && len(b) > limit {
+		b, truncated = b[len(b)-limit:], true
+	}
+	if truncated {
+		// Drop the partial line at the start.
+		if i := bytes.IndexByte(b, '\n'); i >= 0 {
+			b = b[i+1:]
+		}
+	}
+	return b, truncated
+}
+
+// Capture makes the default slog logger also write its records to w,
+// formatted according to o with timestamps, e.g. into a Ring. It is applied
+// on top of the logger installed by Setup or an event log setup.
+func (o Options) Capture(w io.Writer) error {
+	h, err := o.NewHandler(w, false)
+	if err != nil {
+		return err
+	}
+	slog.SetDefault(slog.New(teeHandler{slog.Default().Handler(), h}))
+	return nil
+}
+
+// teeHandler passes each record to every handler that is enabled for it.
+type teeHandler []slog.Handler
+
+func (t teeHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, h := range t {
+		if h.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t teeHandler) Handle(ctx context.Context, r slog.Record) error {
+	var errs []error
+	for _, h := range t {
+		if h.Enabled(ctx, r.Level) {
+			errs = append(errs, h.Handle(ctx, r.Clone()))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (t teeHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	hs := make(teeHandler, len(t))
+	for i, h := range t {
+		hs[i] = h.WithAttrs(attrs)
+	}
+	return hs
+}
+
+func (t teeHandler) WithGroup(name string) slog.Handler {
+	hs := make(teeHandler, len(t))
+	for i, h := range t {
+		hs[i] = h.WithGroup(name)
+	}
+	return hs
+}
@@ -0,0 +1,110 @@
+// Package metrics exports fleet facts from the latest inventory of each host
+// as Prometheus metrics, for dashboards and alerts on inventory freshness
+// and capacity.
+package metrics
+
+import (
+	"context"
+	"crypto/subtle"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/go-tangra/go-tangra-inventory/internal/logging"
+	"github.com/go-tangra/go-tangra-inventory/internal/store"
+)
+
+// Path is where the metrics are served.
+const Path = "/metrics"
+
+// scrapeTimeout bounds reading the host facts for one scrape.
+const scrapeTimeout = 30 * time.Second
+
+var (
+	hostLabels = []string{"site", "hostname"}
+
+	infoDesc = prometheus.NewDesc("tangra_host_info",
+		"Hardware identity of the host's latest inventory; always 1.",
+		append(hostLabels, "device_class", "manufacturer", "model", "serial_number"), nil)
+	memoryDesc = prometheus.NewDesc("tangra_host_memory_bytes",
+		"Installed physical memory reported by the host's latest inventory.",
+		hostLabels, nil)
+	lastSeenDesc = prometheus.NewDesc("tangra_host_last_seen_timestamp",
+		"Unix time the host's latest inventory was collected.",
+		hostLabels, nil)
+	hostsDesc = prometheus.NewDesc("tangra_hosts",
+		"Number of hosts with stored inventories.",
+		[]string{"site"}, nil)
+)
+
+// Collector reads the metrics from the store on every scrape.
+type Collector struct {
+	db *store.Store
+}
+
+// NewCollector returns a Collector of the hosts in db.
+func NewCollector(db *store.Store) *Collector {
+	return &Collector{db: db}
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- infoDesc
+	ch <- memoryDesc
+	ch <- lastSeenDesc
+	ch <- hostsDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), scrapeTimeout)
+	defer cancel()
+
+	facts, err := c.db.ListHostFacts(ctx, nil)
+	if err != nil {
+		slog.Error("Reading host facts for metrics failed", logging.Err(err))
+		ch <- prometheus.NewInvalidMetric(hostsDesc, err)
+		return
+	}
+
+	hosts := make(map[string]int)
+	for _, f := range facts {
+		hosts[f.Site]++
+		ch <- prometheus.MustNewConstMetric(infoDesc, prometheus.GaugeValue, 1,
+			f.Site, f.Hostname, f.DeviceClass, f.Manufacturer, f.Model, f.SerialNumber)
+		if f.MemoryBytes > 0 {
+			ch <- prometheus.MustNewConstMetric(memoryDesc, prometheus.GaugeValue, float64(f.MemoryBytes), f.Site, f.Hostname)
+		}
+		if !f.LastSeen.IsZero() {
+			ch <- prometheus.MustNewConstMetric(lastSeenDesc, prometheus.GaugeValue, float64(f.LastSeen.Unix()), f.Site, f.Hostname)
+		}
+	}
+	for site, n := range hosts {
+		ch <- prometheus.MustNewConstMetric(hostsDesc, prometheus.GaugeValue, float64(n), site)
+	}
+}
+
+// Handler serves the metrics of db and of the extra collectors. A non-empty
+// token must be presented as a bearer token.
+func Handler(db *store.Store, token string, extra ...prometheus.Collector) http.Handler {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(NewCollector(db))
+	reg.MustRegister(extra...)
+	h := promhttp.HandlerFor(reg, promhttp.HandlerOpts{ErrorHandling: promhttp.HTTPErrorOnError})
+	if token == "" {
+		return h
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="metrics"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,168 @@
+// Package mqtt publishes inventory events to an MQTT broker.
+package mqtt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	paho "github.com/eclipse/paho.mqtt.golang"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+	"github.com/go-tangra/go-tangra-inventory/internal/store"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// connectTimeout bounds connecting to the broker.
+const connectTimeout = 30 * time.Second
+
+// emptyLevel replaces empty placeholder values in topics, e.g. the site of
+// hosts without one.
+const emptyLevel = "_"
+
+// placeholder matches the placeholders of topic templates.
+var placeholder = regexp.MustCompile(`\{[^}]*\}`)
+
+// placeholders are the values topic templates can contain.
+var placeholders = map[string]func(*collectorv1.InventorySubmission) string{
+	"{site}":     func(s *collectorv1.InventorySubmission) string { return s.Site },
+	"{hostname}": func(s *collectorv1.InventorySubmission) string { return s.Hostname },
+	"{uuid}":     func(s *collectorv1.InventorySubmission) string { return s.SystemUuid },
+}
+
+// Options configures a Publisher.
+type Options struct {
+	// Broker is the broker URL: tcp://, ssl://, ws:// or wss://.
+	Broker   string
+	ClientID string
+	Username string
+	Password string
+	// Topic is the topic template, e.g. tangra/inventory/{site}/{hostname}.
+	Topic  string
+	QoS    byte
+	Retain bool
+}
+
+// Publisher publishes InventorySubmission events from the outbox as JSON to
+// a topic per host.
+type Publisher struct {
+	client paho.Client
+	opts   Options
+}
+
+// NewPublisher checks o and returns a Publisher that connects to the broker
+// on the first Publish.
+func NewPublisher(o Options) (*Publisher, error) {
+	u, err := url.Parse(o.Broker)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("broker: %q is not a URL such as tcp://host:1883", o.Broker)
+	}
+	switch u.Scheme {
+	case "tcp", "ssl", "tls", "ws", "wss", "mqtt", "mqtts":
+	default:
+		return nil, fmt.Errorf("broker: unsupported scheme %q (use tcp, ssl, ws or wss)", u.Scheme)
+	}
+	if err := checkTopic(o.Topic); err != nil {
+		return nil, fmt.Errorf("topic: %w", err)
+	}
+	if o.QoS > 2 {
+		return nil, fmt.Errorf("qos: %d is not 0, 1 or 2", o.QoS)
+	}
+
+	opts := paho.NewClientOptions().
+		AddBroker(o.Broker).
+		SetClientID(o.ClientID).
+		SetUsername(o.Username).
+		SetPassword(o.Password).
+		SetConnectTimeout(connectTimeout).
+		SetAutoReconnect(false)
+	return &Publisher{client: paho.NewClient(opts), opts: o}, nil
+}
+
+// checkTopic checks that template is a valid topic name once its
+// placeholders are replaced.
+func checkTopic(template string) error {
+	if template == "" {
+		return errors.New("required")
+	}
+	for _, p := range placeholder.FindAllString(template, -1) {
+		if _, ok := placeholders[p]; !ok {
+			return fmt.Errorf("unknown placeholder %s (use {site}, {hostname} or {uuid})", p)
+		}
+	}
+	if strings.ContainsAny(template, "+#") {
+		return errors.New("wildcards + and # are not allowed in topic names")
+	}
+	return nil
+}
+
+// Topic returns the topic of sub.
+func (p *Publisher) Topic(sub *collectorv1.InventorySubmission) string {
+	return placeholder.ReplaceAllStringFunc(p.opts.Topic, func(name string) string {
+		return topicLevel(placeholders[name](sub))
+	})
+}
+
+// topicLevel makes v safe to use within a topic level.
+func topicLevel(v string) string {
+	if v == "" {
+		return emptyLevel
+	}
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '+', '#', 0:
+			return '_'
+		}
+		return r
+	}, v)
+}
+
+// Publish publishes events, connecting to the broker first if needed, and
+// returns once the broker has received them at the configured QoS.
+func (p *Publisher) Publish(ctx context.Context, events []store.OutboxEvent) error {
+	if !p.client.IsConnectionOpen() {
+		if err := wait(ctx, p.client.Connect()); err != nil {
+			return fmt.Errorf("connect to MQTT broker %s: %w", p.opts.Broker, err)
+		}
+	}
+
+	for _, e := range events {
+		var sub collectorv1.InventorySubmission
+		if err := proto.Unmarshal(e.Payload, &sub); err != nil {
+			return fmt.Errorf("decode outbox event %d: %w", e.ID, err)
+		}
+		payload, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(&sub)
+		if err != nil {
+			return fmt.Errorf("encode outbox event %d: %w", e.ID, err)
+		}
+		topic := p.Topic(&sub)
+		if err := wait(ctx, p.client.Publish(topic, p.opts.QoS, p.opts.Retain, payload)); err != nil {
+			return fmt.Errorf("publish to %s: %w", topic, err)
+		}
+	}
+	return nil
+}
+
+// Close disconnects from the broker.
+func (p *Publisher) Close() error {
+	if p.client.IsConnectionOpen() {
+		p.client.Disconnect(250)
+	}
+	return nil
+}
+
+// wait waits for t to complete or ctx to be done.
+func wait(ctx context.Context, t paho.Token) error {
+	select {
+	case <-t.Done():
+		return t.Error()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
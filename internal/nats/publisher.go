@@ -0,0 +1,181 @@
+// Package nats publishes inventory and agent events to NATS JetStream.
+package nats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+	"github.com/go-tangra/go-tangra-inventory/internal/outbox"
+	"github.com/go-tangra/go-tangra-inventory/internal/store"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// emptyToken replaces empty values in subjects, e.g. the site of hosts
+// without one.
+const emptyToken = "_"
+
+// Options configures a Publisher.
+type Options struct {
+	URL string
+	// CredsFile is a NATS credentials file (empty = none, or those in URL).
+	CredsFile string
+	// Stream is the JetStream stream the events are stored in. It is
+	// created for the subjects under SubjectPrefix unless it exists.
+	Stream        string
+	SubjectPrefix string
+}
+
+// Publisher publishes events from the outbox as JSON to JetStream, on the
+// subject <prefix>.<type>.<site>.<hostname>, e.g.
+// tangra.inventory.agent.connected.acme.pc-042. Each event carries a
+// Nats-Msg-Id, so that JetStream discards the duplicates of events that are
+// published again after a failure.
+type Publisher struct {
+	opts Options
+	// msgIDPrefix distinguishes the message IDs of collectors sharing a
+	// stream.
+	msgIDPrefix string
+
+	nc *nats.Conn
+	js jetstream.JetStream
+}
+
+// NewPublisher checks o and returns a Publisher that connects to NATS on
+// the first Publish.
+func NewPublisher(o Options) (*Publisher, error) {
+	if o.URL == "" {
+		return nil, errors.New("url: required")
+	}
+	if o.Stream == "" || strings.ContainsAny(o.Stream, ".*> \t") {
+		return nil, fmt.Errorf("stream: %q is not a stream name", o.Stream)
+	}
+	for _, tok := range strings.Split(o.SubjectPrefix, ".") {
+		if tok == "" || strings.ContainsAny(tok, "*> \t") {
+			return nil, fmt.Errorf("subject_prefix: %q is not a subject without wildcards", o.SubjectPrefix)
+		}
+	}
+	host, err := os.Hostname()
+	if err != nil {
+		host = "collector"
+	}
+	return &Publisher{opts: o, msgIDPrefix: subjectToken(host)}, nil
+}
+
+// connect connects to NATS and makes sure the stream exists.
+func (p *Publisher) connect(ctx context.Context) error {
+	if p.nc != nil && p.nc.IsConnected() {
+		return nil
+	}
+	p.Close()
+
+	opts := []nats.Option{nats.Name("inventory-collector")}
+	if p.opts.CredsFile != "" {
+		opts = append(opts, nats.UserCredentials(p.opts.CredsFile))
+	}
+	nc, err := nats.Connect(p.opts.URL, opts...)
+	if err != nil {
+		return fmt.Errorf("connect to NATS: %w", err)
+	}
+	js, err := jetstream.New(nc)
+	if err != nil {
+		nc.Close()
+		return fmt.Errorf("JetStream: %w", err)
+	}
+
+	_, err = js.Stream(ctx, p.opts.Stream)
+	if errors.Is(err, jetstream.ErrStreamNotFound) {
+		_, err = js.CreateStream(ctx, jetstream.StreamConfig{
+			Name:     p.opts.Stream,
+			Subjects: []string{p.opts.SubjectPrefix + ".>"},
+		})
+	}
+	if err != nil {
+		nc.Close()
+		return fmt.Errorf("stream %s: %w", p.opts.Stream, err)
+	}
+	p.nc, p.js = nc, js
+	return nil
+}
+
+// Publish publishes events and returns once JetStream has stored them.
+func (p *Publisher) Publish(ctx context.Context, events []store.OutboxEvent) error {
+	if err := p.connect(ctx); err != nil {
+		return err
+	}
+	for _, e := range events {
+		msg, err := p.message(e)
+		if err != nil {
+			return fmt.Errorf("encode outbox event %d: %w", e.ID, err)
+		}
+		if _, err := p.js.PublishMsg(ctx, msg, jetstream.WithMsgID(fmt.Sprintf("%s-%d", p.msgIDPrefix, e.ID))); err != nil {
+			return fmt.Errorf("publish to %s: %w", msg.Subject, err)
+		}
+	}
+	return nil
+}
+
+// message converts an outbox event to a NATS message.
+func (p *Publisher) message(e store.OutboxEvent) (*nats.Msg, error) {
+	var m proto.Message
+	var site, hostname string
+	switch e.Type {
+	case outbox.InventorySubmitted:
+		var sub collectorv1.InventorySubmission
+		if err := proto.Unmarshal(e.Payload, &sub); err != nil {
+			return nil, err
+		}
+		m, site, hostname = &sub, sub.Site, sub.Hostname
+	case outbox.AgentConnected, outbox.AgentDisconnected, outbox.AgentUpdated:
+		var ev collectorv1.AgentEvent
+		if err := proto.Unmarshal(e.Payload, &ev); err != nil {
+			return nil, err
+		}
+		m, site, hostname = &ev, ev.GetAgent().GetSite(), ev.GetAgent().GetHostname()
+	default:
+		return nil, fmt.Errorf("unknown event type %q", e.Type)
+	}
+
+	// Emit default values so that e.g. CONNECTED agent events, the zero
+	// enum value, carry their type.
+	data, err := protojson.MarshalOptions{UseProtoNames: true, EmitDefaultValues: true}.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	msg := nats.NewMsg(strings.Join([]string{p.opts.SubjectPrefix, e.Type, subjectToken(site), subjectToken(hostname)}, "."))
+	msg.Data = data
+	msg.Header.Set("Content-Type", "application/json")
+	msg.Header.Set("Event-Type", e.Type)
+	return msg, nil
+}
+
+// subjectToken makes v safe to use as a single subject token.
+func subjectToken(v string) string {
+	if v == "" {
+		return emptyToken
+	}
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '.', '*', '>', ' ', '\t', '\r', '\n':
+			return '_'
+		}
+		return r
+	}, v)
+}
+
+// Close closes the connection to NATS.
+func (p *Publisher) Close() error {
+	if p.nc != nil {
+		p.nc.Close()
+		p.nc, p.js = nil, nil
+	}
+	return nil
+}
@@ -0,0 +1,204 @@
+// Package netbox synchronizes the latest inventories to devices in a NetBox
+// DCIM through its REST API.
+package netbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// requestTimeout bounds a single NetBox API call.
+const requestTimeout = 30 * time.Second
+
+// Client calls the NetBox REST API.
+type Client struct {
+	// URL is the base URL of the NetBox server, e.g.
+	// https://netbox.example.com.
+	URL string
+	// Token is an API token with write permission on DCIM objects.
+	Token  string
+	Client *http.Client
+}
+
+// NewClient returns a client for the NetBox server at baseURL.
+func NewClient(baseURL, token string) *Client {
+	return &Client{URL: strings.TrimSuffix(baseURL, "/"), Token: token, Client: http.DefaultClient}
+}
+
+// Device is the part of a NetBox device the synchronization reads.
+type Device struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Serial string `json:"serial"`
+}
+
+// object is any NetBox object, of which only the ID is read.
+type object struct {
+	ID int `json:"id"`
+}
+
+// list is the envelope of NetBox list responses.
+type list[T any] struct {
+	Count   int `json:"count"`
+	Results []T `json:"results"`
+}
+
+// DevicesBySerial returns the devices with serial number serial.
+func (c *Client) DevicesBySerial(ctx context.Context, serial string) ([]Device, error) {
+	var res list[Device]
+	if err := c.do(ctx, http.MethodGet, "/api/dcim/devices/?limit=10&serial="+url.QueryEscape(serial), nil, &res); err != nil {
+		return nil, fmt.Errorf("find devices by serial: %w", err)
+	}
+	return res.Results, nil
+}
+
+// DevicesByName returns the devices named name in the NetBox site with ID
+// siteID.
+func (c *Client) DevicesByName(ctx context.Context, name string, siteID int) ([]Device, error) {
+	var res list[Device]
+	path := "/api/dcim/devices/?limit=10&name=" + url.QueryEscape(name) + "&site_id=" + strconv.Itoa(siteID)
+	if err := c.do(ctx, http.MethodGet, path, nil, &res); err != nil {
+		return nil, fmt.Errorf("find devices by name: %w", err)
+	}
+	return res.Results, nil
+}
+
+// CreateDevice creates a device with fields and returns its ID.
+func (c *Client) CreateDevice(ctx context.Context, fields map[string]any) (int, error) {
+	var res object
+	if err := c.do(ctx, http.MethodPost, "/api/dcim/devices/", fields, &res); err != nil {
+		return 0, fmt.Errorf("create device: %w", err)
+	}
+	return res.ID, nil
+}
+
+// UpdateDevice sets fields of the device with ID id.
+func (c *Client) UpdateDevice(ctx context.Context, id int, fields map[string]any) error {
+	if err := c.do(ctx, http.MethodPatch, "/api/dcim/devices/"+strconv.Itoa(id)+"/", fields, &object{}); err != nil {
+		return fmt.Errorf("update device %d: %w", id, err)
+	}
+	return nil
+}
+
+// FindDeviceType returns the ID of the device type model of the
+// manufacturer with ID manufacturerID, or 0 if there is none.
+func (c *Client) FindDeviceType(ctx context.Context, manufacturerID int, model string) (int, error) {
+	id, err := c.find(ctx, "/api/dcim/device-types/?manufacturer_id="+strconv.Itoa(manufacturerID)+"&model="+url.QueryEscape(model))
+	if err != nil {
+		return 0, fmt.Errorf("find device type: %w", err)
+	}
+	return id, nil
+}
+
+// CreateDeviceType creates a device type with fields and returns its ID.
+func (c *Client) CreateDeviceType(ctx context.Context, fields map[string]any) (int, error) {
+	var res object
+	if err := c.do(ctx, http.MethodPost, "/api/dcim/device-types/", fields, &res); err != nil {
+		return 0, fmt.Errorf("create device type: %w", err)
+	}
+	return res.ID, nil
+}
+
+// Manufacturer returns the ID of the manufacturer named name, creating it
+// if there is none.
+func (c *Client) Manufacturer(ctx context.Context, name string) (int, error) {
+	id, err := c.find(ctx, "/api/dcim/manufacturers/?name__ie="+url.QueryEscape(name))
+	if err != nil {
+		return 0, fmt.Errorf("find manufacturer: %w", err)
+	}
+	if id != 0 {
+		return id, nil
+	}
+	var res object
+	if err := c.do(ctx, http.MethodPost, "/api/dcim/manufacturers/", map[string]any{"name": name, "slug": Slug(name)}, &res); err != nil {
+		return 0, fmt.Errorf("create manufacturer: %w", err)
+	}
+	return res.ID, nil
+}
+
+// find returns the ID of the first object the list query path returns, or
+// 0.
+func (c *Client) find(ctx context.Context, path string) (int, error) {
+	var res list[object]
+	if err := c.do(ctx, http.MethodGet, path+"&limit=1", nil, &res); err != nil {
+		return 0, err
+	}
+	if len(res.Results) == 0 {
+		return 0, nil
+	}
+	return res.Results[0].ID, nil
+}
+
+// Slug returns the NetBox slug of name: lower-case letters, digits,
+// underscores and dashes, at most 100 characters.
+func Slug(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '_' {
+			dash = true
+			continue
+		}
+		if dash && b.Len() > 0 {
+			b.WriteByte('-')
+		}
+		b.WriteRune(r)
+		dash = false
+	}
+	s := b.String()
+	if len(s) > 100 {
+		s = strings.TrimRight(s[:100], "-")
+	}
+	if s == "" {
+		return "unknown"
+	}
+	return s
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body, v any) error {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	var r io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("marshal request: %w", err)
+		}
+		r = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.URL+path, r)
+	if err != nil {
+		return fmt.Errorf("build request: %w", err)
+	}
+	req.Header.Set("Authorization", "Token "+c.Token)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s %s: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		// NetBox explains validation failures with a JSON object of field
+		// errors.
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s %s: unexpected status %s: %s", method, path, resp.Status, bytes.TrimSpace(msg))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("%s %s: decode response: %w", method, path, err)
+	}
+	return nil
+}
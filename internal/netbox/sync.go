@@ -0,0 +1,291 @@
+package netbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+	"github.com/go-tangra/go-tangra-inventory/internal/convert"
+	"github.com/go-tangra/go-tangra-inventory/internal/logging"
+	"github.com/go-tangra/go-tangra-inventory/internal/store"
+)
+
+// hostPageSize is the number of hosts read from the store at a time.
+const hostPageSize = 500
+
+// AnySite is the Site.Name of the entry that applies to every site without
+// its own entry.
+const AnySite = "*"
+
+// Site configures the NetBox devices of the hosts of one site.
+type Site struct {
+	// Name is the site, "" for hosts without one, or AnySite.
+	Name string
+	// SiteID is the NetBox site of created devices.
+	SiteID int
+	// RoleID is the device role of created devices; Roles overrides it by
+	// device class, e.g. "switch": 4.
+	RoleID int
+	Roles  map[string]int
+	// TenantID is set on created devices (0 = none).
+	TenantID int
+	// Status is the status of created devices, e.g. active.
+	Status string
+}
+
+// Validate checks that s can create devices.
+func (s Site) Validate() error {
+	if s.SiteID <= 0 {
+		return errors.New("site_id is required")
+	}
+	if s.RoleID <= 0 {
+		return errors.New("role_id is required")
+	}
+	for class, id := range s.Roles {
+		if id <= 0 {
+			return fmt.Errorf("roles: %s: %d is not a role ID", class, id)
+		}
+	}
+	return nil
+}
+
+// role returns the device role of hosts of device class class.
+func (s Site) role(class string) int {
+	if id, ok := s.Roles[class]; ok {
+		return id
+	}
+	return s.RoleID
+}
+
+// Result counts the hosts of a synchronization by outcome.
+type Result struct {
+	Created, Updated int
+	// Unchanged hosts have not submitted since they were last synchronized.
+	Unchanged int
+	// Skipped hosts match several devices or failed; the failures are
+	// logged.
+	Skipped int
+}
+
+// Syncer creates and updates NetBox devices, and their device types and
+// manufacturers, from the latest inventories.
+type Syncer struct {
+	client *Client
+	store  *store.Store
+	sites  map[string]Site
+
+	mu sync.Mutex
+	// synced is the latest inventory ID synchronized per host, so that
+	// hosts that have not submitted since are skipped.
+	synced map[[2]string]int64
+	// deviceTypes caches device type IDs by manufacturer and model.
+	deviceTypes map[[2]string]int
+}
+
+// NewSyncer returns a Syncer that synchronizes the hosts of the given sites
+// in db to the NetBox server of client.
+func NewSyncer(client *Client, db *store.Store, sites []Site) (*Syncer, error) {
+	m := make(map[string]Site, len(sites))
+	for _, s := range sites {
+		if _, dup := m[s.Name]; dup {
+			return nil, fmt.Errorf("site %q is configured twice", s.Name)
+		}
+		if err := s.Validate(); err != nil {
+			return nil, fmt.Errorf("site %q: %w", s.Name, err)
+		}
+		if s.Status == "" {
+			s.Status = "active"
+		}
+		m[s.Name] = s
+	}
+	return &Syncer{
+		client:      client,
+		store:       db,
+		sites:       m,
+		synced:      make(map[[2]string]int64),
+		deviceTypes: make(map[[2]string]int),
+	}, nil
+}
+
+// site returns the configuration for hosts of site name.
+func (s *Syncer) site(name string) (Site, bool) {
+	if cfg, ok := s.sites[name]; ok {
+		return cfg, true
+	}
+	cfg, ok := s.sites[AnySite]
+	return cfg, ok
+}
+
+// Sync synchronizes the latest inventory of every host of a configured
+// site. With dryRun nothing is written to NetBox and the outcomes are
+// counted as if it were. Errors about single hosts are logged and counted
+// as skipped; an error is returned when the hosts cannot be listed.
+func (s *Syncer) Sync(ctx context.Context, dryRun bool) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var res Result
+	for page := 1; ; page++ {
+		hosts, total, err := s.store.ListHosts(ctx, store.HostFilter{PageSize: hostPageSize, Page: page})
+		if err != nil {
+			return res, fmt.Errorf("list hosts: %w", err)
+		}
+		for _, h := range hosts {
+			site, ok := s.site(h.Site)
+			if !ok {
+				continue
+			}
+			key := [2]string{h.Site, h.Hostname}
+			if s.synced[key] == h.LatestID {
+				res.Unchanged++
+				continue
+			}
+			created, err := s.syncHost(ctx, site, h.LatestID, dryRun)
+			switch {
+			case err != nil:
+				res.Skipped++
+				slog.Warn("NetBox sync skipped host", "site", h.Site, "hostname", h.Hostname, logging.Err(err))
+				continue
+			case created:
+				res.Created++
+			default:
+				res.Updated++
+			}
+			if !dryRun {
+				s.synced[key] = h.LatestID
+			}
+		}
+		if len(hosts) == 0 || page*hostPageSize >= total {
+			return res, ctx.Err()
+		}
+	}
+}
+
+// syncHost creates or updates the device of the inventory with ID id and
+// reports whether it was created. Devices are matched by serial number, or
+// by name within the NetBox site for hosts without a usable one.
+func (s *Syncer) syncHost(ctx context.Context, site Site, id int64, dryRun bool) (bool, error) {
+	rec, err := s.store.Get(ctx, id, nil)
+	if err != nil {
+		return false, fmt.Errorf("read inventory %d: %w", id, err)
+	}
+	inv, err := convert.RecordToInventory(rec)
+	if err != nil {
+		return false, fmt.Errorf("decode inventory %d: %w", id, err)
+	}
+	serial := strings.TrimSpace(inv.GetSystem().GetSerialNumber())
+	if placeholder(serial) {
+		serial = ""
+	}
+
+	var devices []Device
+	if serial != "" {
+		devices, err = s.client.DevicesBySerial(ctx, serial)
+	} else {
+		devices, err = s.client.DevicesByName(ctx, inv.Hostname, site.SiteID)
+	}
+	if err != nil {
+		return false, err
+	}
+	if len(devices) > 1 {
+		return false, fmt.Errorf("%d devices match", len(devices))
+	}
+	if dryRun {
+		return len(devices) == 0, nil
+	}
+
+	deviceType, err := s.deviceType(ctx, inv)
+	if err != nil {
+		return false, err
+	}
+	fields := map[string]any{"name": inv.Hostname, "device_type": deviceType}
+	if serial != "" {
+		fields["serial"] = serial
+	}
+	if len(devices) == 1 {
+		return false, s.client.UpdateDevice(ctx, devices[0].ID, fields)
+	}
+
+	fields["site"] = site.SiteID
+	fields["role"] = site.role(rec.DeviceClass)
+	fields["status"] = site.Status
+	if site.TenantID > 0 {
+		fields["tenant"] = site.TenantID
+	}
+	_, err = s.client.CreateDevice(ctx, fields)
+	return true, err
+}
+
+// deviceType returns the ID of the NetBox device type of inv, creating the
+// device type and its manufacturer if needed.
+func (s *Syncer) deviceType(ctx context.Context, inv *collectorv1.Inventory) (int, error) {
+	manufacturer := strings.TrimSpace(inv.GetSystem().GetManufacturer())
+	model := strings.TrimSpace(inv.GetSystem().GetProductName())
+	if placeholder(manufacturer) {
+		manufacturer = "Unknown"
+	}
+	if placeholder(model) {
+		model = "Unknown"
+	}
+	key := [2]string{manufacturer, model}
+	if id, ok := s.deviceTypes[key]; ok {
+		return id, nil
+	}
+
+	manufacturerID, err := s.client.Manufacturer(ctx, manufacturer)
+	if err != nil {
+		return 0, err
+	}
+	id, err := s.client.FindDeviceType(ctx, manufacturerID, model)
+	if err != nil {
+		return 0, err
+	}
+	if id == 0 {
+		fields := map[string]any{
+			"manufacturer": manufacturerID,
+			"model":        model,
+			"slug":         Slug(manufacturer + " " + model),
+		}
+		if sku := strings.TrimSpace(inv.GetSystem().GetSkuNumber()); !placeholder(sku) {
+			fields["part_number"] = sku
+		}
+		if id, err = s.client.CreateDeviceType(ctx, fields); err != nil {
+			return 0, err
+		}
+		slog.Info("Created NetBox device type", "model", model, "manufacturer", manufacturer, "id", id)
+	}
+	s.deviceTypes[key] = id
+	return id, nil
+}
+
+// placeholders are values firmware reports for unset serial numbers and
+// product names, compared case-insensitively.
+var placeholders = map[string]bool{
+	"":                       true,
+	"0":                      true,
+	"none":                   true,
+	"n/a":                    true,
+	"default string":         true,
+	"to be filled by o.e.m.": true,
+	"system serial number":   true,
+	"system manufacturer":    true,
+	"system product name":    true,
+	"not specified":          true,
+	"not applicable":         true,
+	"0123456789":             true,
+	"123456789":              true,
+	"xxxxxxxxxx":             true,
+	"invalid":                true,
+	"o.e.m.":                 true,
+	"oem":                    true,
+	"unknown":                true,
+	"serial number":          true,
+}
+
+func placeholder(s string) bool {
+	return placeholders[strings.ToLower(strings.TrimSpace(s))]
+}
@@ -0,0 +1,135 @@
+package objstore
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// azureVersion is the Blob service REST API version requested; it allows
+// single-request uploads of up to 5000 MiB.
+const azureVersion = "2021-08-06"
+
+// azureContainer is an Azure Blob Storage container, accessed with a
+// shared access signature.
+type azureContainer struct {
+	// base is the URL of the container.
+	base   string
+	prefix string
+	// sas is the shared access signature query string.
+	sas    string
+	client *http.Client
+}
+
+func newAzure(account, container, prefix, sas string) *azureContainer {
+	return &azureContainer{
+		base:   "https://" + account + ".blob.core.windows.net/" + url.PathEscape(container),
+		prefix: prefix,
+		sas:    strings.TrimPrefix(sas, "?"),
+		client: http.DefaultClient,
+	}
+}
+
+func (c *azureContainer) Put(ctx context.Context, key string, r io.ReadSeeker, size int64) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, c.blobURL(join(c.prefix, key)), io.NopCloser(r))
+	if err != nil {
+		return fmt.Errorf("put %s: %w", key, err)
+	}
+	req.ContentLength = size
+	req.Header.Set("x-ms-version", azureVersion)
+	req.Header.Set("x-ms-blob-type", "BlockBlob")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("put %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return statusError("put "+key, resp.Status, resp.Body)
+	}
+	return nil
+}
+
+// enumerationResults is the response of List Blobs.
+type enumerationResults struct {
+	Blobs []struct {
+		Name       string `xml:"Name"`
+		Properties struct {
+			LastModified  string `xml:"Last-Modified"`
+			ContentLength int64  `xml:"Content-Length"`
+		} `xml:"Properties"`
+	} `xml:"Blobs>Blob"`
+	NextMarker string `xml:"NextMarker"`
+}
+
+func (c *azureContainer) List(ctx context.Context, prefix string) ([]Object, error) {
+	var objects []Object
+	query := url.Values{"restype": {"container"}, "comp": {"list"}, "prefix": {join(c.prefix, prefix)}}
+	for {
+		var res enumerationResults
+		if err := c.get(ctx, query, &res); err != nil {
+			return nil, fmt.Errorf("list %s: %w", prefix, err)
+		}
+		for _, b := range res.Blobs {
+			modified, _ := http.ParseTime(b.Properties.LastModified)
+			objects = append(objects, Object{Key: trim(c.prefix, b.Name), Size: b.Properties.ContentLength, Modified: modified})
+		}
+		if res.NextMarker == "" {
+			return objects, nil
+		}
+		query.Set("marker", res.NextMarker)
+	}
+}
+
+func (c *azureContainer) Delete(ctx context.Context, key string) error {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, c.blobURL(join(c.prefix, key)), nil)
+	if err != nil {
+		return fmt.Errorf("delete %s: %w", key, err)
+	}
+	req.Header.Set("x-ms-version", azureVersion)
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("delete %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return statusError("delete "+key, resp.Status, resp.Body)
+	}
+	return nil
+}
+
+// get requests the container with query and decodes the XML response into
+// v.
+func (c *azureContainer) get(ctx context.Context, query url.Values, v any) error {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.base+"?"+query.Encode()+"&"+c.sas, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("x-ms-version", azureVersion)
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return statusError("get", resp.Status, resp.Body)
+	}
+	if err := xml.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	return nil
+}
+
+// blobURL returns the signed URL of the blob name.
+func (c *azureContainer) blobURL(name string) string {
+	return c.base + "/" + uriEncode(name, false) + "?" + c.sas
+}
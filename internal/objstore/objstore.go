@@ -0,0 +1,114 @@
+// Package objstore stores files in S3, Google Cloud Storage or Azure Blob
+// Storage containers through their REST APIs.
+package objstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// requestTimeout bounds a single list or delete call. Uploads are bounded
+// by the caller's context only, as large files may take long.
+const requestTimeout = 30 * time.Second
+
+// Object is a stored object.
+type Object struct {
+	// Key is the object name relative to the bucket prefix.
+	Key      string
+	Size     int64
+	Modified time.Time
+}
+
+// Bucket is a container of objects under a key prefix.
+type Bucket interface {
+	// Put stores the content of r, of size bytes, as key.
+	Put(ctx context.Context, key string, r io.ReadSeeker, size int64) error
+	// List returns the objects whose key starts with prefix.
+	List(ctx context.Context, prefix string) ([]Object, error)
+	// Delete removes key.
+	Delete(ctx context.Context, key string) error
+}
+
+// Options configures Open.
+type Options struct {
+	// Endpoint overrides the S3 endpoint, e.g. https://minio.example.com:9000
+	// for an S3-compatible server.
+	Endpoint string
+	// Region is the S3 region of the bucket.
+	Region string
+	// AccessKeyID and SecretAccessKey are the S3 credentials, or the HMAC
+	// key of a Google Cloud service account.
+	AccessKeyID     string
+	SecretAccessKey string
+	// SASToken is the Azure shared access signature of the container.
+	SASToken string
+}
+
+// Open returns the bucket at rawURL, one of s3://bucket/prefix,
+// gs://bucket/prefix and azblob://account/container/prefix.
+func Open(rawURL string, opts Options) (Bucket, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("%q is not a bucket URL such as s3://bucket/prefix", rawURL)
+	}
+	prefix := strings.Trim(u.Path, "/")
+
+	switch u.Scheme {
+	case "s3":
+		if opts.AccessKeyID == "" || opts.SecretAccessKey == "" {
+			return nil, errors.New("s3 needs an access key ID and secret access key")
+		}
+		region := opts.Region
+		if region == "" {
+			region = "us-east-1"
+		}
+		return newS3(u.Host, prefix, opts.Endpoint, region, opts.AccessKeyID, opts.SecretAccessKey)
+	case "gs":
+		// Cloud Storage speaks the S3 protocol with HMAC keys.
+		if opts.AccessKeyID == "" || opts.SecretAccessKey == "" {
+			return nil, errors.New("gs needs the access ID and secret of an HMAC key")
+		}
+		return newS3(u.Host, prefix, "https://storage.googleapis.com", "auto", opts.AccessKeyID, opts.SecretAccessKey)
+	case "azblob":
+		container, prefix, _ := strings.Cut(prefix, "/")
+		if container == "" {
+			return nil, fmt.Errorf("%q has no container, use azblob://account/container/prefix", rawURL)
+		}
+		if opts.SASToken == "" {
+			return nil, errors.New("azblob needs a SAS token")
+		}
+		return newAzure(u.Host, container, prefix, opts.SASToken), nil
+	}
+	return nil, fmt.Errorf("unknown bucket URL scheme %q (use s3, gs or azblob)", u.Scheme)
+}
+
+// join returns key under prefix.
+func join(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "/" + key
+}
+
+// trim returns name relative to prefix.
+func trim(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return strings.TrimPrefix(name, prefix+"/")
+}
+
+// statusError returns an error describing an unexpected response, with the
+// start of its body, which carries the service's error code.
+func statusError(op string, status string, body io.Reader) error {
+	b, _ := io.ReadAll(io.LimitReader(body, 512))
+	if msg := strings.TrimSpace(string(b)); msg != "" {
+		return fmt.Errorf("%s: unexpected status %s: %s", op, status, msg)
+	}
+	return fmt.Errorf("%s: unexpected status %s", op, status)
+}
@@ -0,0 +1,241 @@
+package objstore
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// emptyHash is the SHA-256 of an empty payload.
+const emptyHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// s3Bucket is a bucket of S3 or of an S3-compatible service, accessed with
+// AWS Signature Version 4.
+type s3Bucket struct {
+	// base is the URL of the bucket: virtual-hosted on AWS, path-style on
+	// other endpoints.
+	base      *url.URL
+	prefix    string
+	region    string
+	accessKey string
+	secretKey string
+	client    *http.Client
+}
+
+func newS3(bucket, prefix, endpoint, region, accessKey, secretKey string) (*s3Bucket, error) {
+	var base *url.URL
+	if endpoint == "" {
+		base = &url.URL{Scheme: "https", Host: bucket + ".s3." + region + ".amazonaws.com", Path: "/"}
+	} else {
+		u, err := url.Parse(endpoint)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("endpoint %q is not an http or https URL", endpoint)
+		}
+		base = &url.URL{Scheme: u.Scheme, Host: u.Host, Path: "/" + bucket + "/"}
+	}
+	return &s3Bucket{
+		base:      base,
+		prefix:    prefix,
+		region:    region,
+		accessKey: accessKey,
+		secretKey: secretKey,
+		client:    http.DefaultClient,
+	}, nil
+}
+
+func (b *s3Bucket) Put(ctx context.Context, key string, r io.ReadSeeker, size int64) error {
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return fmt.Errorf("put %s: %w", key, err)
+	}
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("put %s: %w", key, err)
+	}
+
+	req, err := b.request(ctx, http.MethodPut, join(b.prefix, key), nil, io.NopCloser(r), hex.EncodeToString(h.Sum(nil)))
+	if err != nil {
+		return fmt.Errorf("put %s: %w", key, err)
+	}
+	req.ContentLength = size
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("put %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return statusError("put "+key, resp.Status, resp.Body)
+	}
+	return nil
+}
+
+// listResult is the response of ListObjectsV2.
+type listResult struct {
+	Contents []struct {
+		Key          string    `xml:"Key"`
+		LastModified time.Time `xml:"LastModified"`
+		Size         int64     `xml:"Size"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+func (b *s3Bucket) List(ctx context.Context, prefix string) ([]Object, error) {
+	var objects []Object
+	query := url.Values{"list-type": {"2"}, "prefix": {join(b.prefix, prefix)}}
+	for {
+		var res listResult
+		if err := b.get(ctx, query, &res); err != nil {
+			return nil, fmt.Errorf("list %s: %w", prefix, err)
+		}
+		for _, c := range res.Contents {
+			objects = append(objects, Object{Key: trim(b.prefix, c.Key), Size: c.Size, Modified: c.LastModified})
+		}
+		if !res.IsTruncated || res.NextContinuationToken == "" {
+			return objects, nil
+		}
+		query.Set("continuation-token", res.NextContinuationToken)
+	}
+}
+
+func (b *s3Bucket) Delete(ctx context.Context, key string) error {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	req, err := b.request(ctx, http.MethodDelete, join(b.prefix, key), nil, nil, emptyHash)
+	if err != nil {
+		return fmt.Errorf("delete %s: %w", key, err)
+	}
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("delete %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return statusError("delete "+key, resp.Status, resp.Body)
+	}
+	return nil
+}
+
+// get requests the bucket with query and decodes the XML response into v.
+func (b *s3Bucket) get(ctx context.Context, query url.Values, v any) error {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	req, err := b.request(ctx, http.MethodGet, "", query, nil, emptyHash)
+	if err != nil {
+		return err
+	}
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return statusError("get", resp.Status, resp.Body)
+	}
+	if err := xml.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	return nil
+}
+
+// request builds a request for name in the bucket, signed with the
+// payload hash.
+func (b *s3Bucket) request(ctx context.Context, method, name string, query url.Values, body io.ReadCloser, payloadHash string) (*http.Request, error) {
+	path := b.base.Path + name
+	u := &url.URL{
+		Scheme:   b.base.Scheme,
+		Host:     b.base.Host,
+		Path:     path,
+		RawPath:  uriEncode(path, false),
+		RawQuery: canonicalQuery(query),
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	b.sign(req, payloadHash, time.Now().UTC())
+	return req, nil
+}
+
+// sign adds the AWS Signature Version 4 headers to req.
+func (b *s3Bucket) sign(req *http.Request, payloadHash string, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	scope := now.Format("20060102") + "/" + b.region + "/s3/aws4_request"
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	const signedHeaders = "host;x-amz-content-sha256;x-amz-date"
+	canonical := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		"host:" + req.URL.Host,
+		"x-amz-content-sha256:" + payloadHash,
+		"x-amz-date:" + amzDate,
+		"",
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	sum := sha256.Sum256([]byte(canonical))
+	toSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(sum[:])
+
+	key := []byte("AWS4" + b.secretKey)
+	for _, part := range []string{now.Format("20060102"), b.region, "s3", "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, toSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		b.accessKey, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// canonicalQuery encodes query sorted by key, as Signature Version 4
+// requires.
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var parts []string
+	for _, k := range keys {
+		for _, v := range query[k] {
+			parts = append(parts, uriEncode(k, true)+"="+uriEncode(v, true))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// uriEncode percent-encodes every byte of s but the unreserved characters
+// and, unless encodeSlash is set, the slash.
+func uriEncode(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~', c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
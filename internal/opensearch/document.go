@@ -0,0 +1,147 @@
+package opensearch
+
+import (
+	"encoding/json"
+	"time"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+	"github.com/go-tangra/go-tangra-inventory/internal/store"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Document flattens an inventory into the document indexed for it: the
+// record's identity at the top level, the single hardware components as
+// objects, and the repeated ones summarized as counts, totals and value
+// lists, so every field can be filtered and aggregated on without nested
+// queries. sub adds the hardware changes of the submission; it may be nil.
+func Document(rec *store.InventoryRecord, inv *collectorv1.Inventory, sub *collectorv1.InventorySubmission) map[string]any {
+	doc := map[string]any{
+		"@timestamp":    rec.CollectedAt.UTC().Format(time.RFC3339),
+		"stored_at":     rec.StoredAt.UTC().Format(time.RFC3339),
+		"inventory_id":  rec.ID,
+		"site":          rec.Site,
+		"hostname":      rec.Hostname,
+		"username":      rec.Username,
+		"system_uuid":   rec.SystemUUID,
+		"system_serial": rec.SystemSerial,
+		"device_class":  deviceClass(rec.DeviceClass),
+		"source":        rec.Source,
+	}
+	for name, m := range map[string]proto.Message{
+		"system":    inv.GetSystem(),
+		"bios":      inv.GetBios(),
+		"baseboard": inv.GetBaseboard(),
+		"chassis":   inv.GetChassis(),
+		"snmp":      inv.GetSnmp(),
+	} {
+		if v := object(m); v != nil {
+			doc[name] = v
+		}
+	}
+
+	var (
+		sockets, cores, threads int
+		maxSpeed                uint32
+		cpuModels               []string
+	)
+	for _, p := range inv.GetProcessors() {
+		if !p.SocketPopulated {
+			continue
+		}
+		sockets++
+		cores += int(p.CoreCount)
+		threads += int(p.ThreadCount)
+		maxSpeed = max(maxSpeed, p.MaxSpeedMhz)
+		cpuModels = appendUnique(cpuModels, p.Version)
+	}
+	doc["processor"] = map[string]any{
+		"count":         sockets,
+		"cores":         cores,
+		"threads":       threads,
+		"max_speed_mhz": maxSpeed,
+		"models":        cpuModels,
+	}
+
+	if mem := inv.GetMemory(); mem != nil {
+		var types, parts []string
+		modules := 0
+		for _, m := range mem.Modules {
+			if m.CapacityBytes == 0 {
+				continue
+			}
+			modules++
+			types = appendUnique(types, m.MemoryType)
+			parts = appendUnique(parts, m.PartNumber)
+		}
+		doc["memory"] = map[string]any{
+			"total_bytes":  mem.TotalPhysicalBytes,
+			"total_gb":     mem.TotalPhysicalGb,
+			"modules":      modules,
+			"slots":        mem.GetArray().GetNumberOfMemoryDevices(),
+			"types":        types,
+			"part_numbers": parts,
+		}
+	}
+
+	var monModels, monSerials []string
+	for _, m := range inv.GetMonitor() {
+		monModels = appendUnique(monModels, m.Model)
+		monSerials = appendUnique(monSerials, m.SerialNumber)
+	}
+	doc["monitor"] = map[string]any{
+		"count":          len(inv.GetMonitor()),
+		"models":         monModels,
+		"serial_numbers": monSerials,
+	}
+
+	if sub != nil {
+		changes := make([]string, 0, len(sub.Changes))
+		for _, c := range sub.Changes {
+			changes = append(changes, c.Description)
+		}
+		doc["first"] = sub.First
+		doc["change_count"] = len(changes)
+		doc["changes"] = changes
+	}
+	return doc
+}
+
+// deviceClass returns the device class of an inventory, agents leaving it
+// empty for computers.
+func deviceClass(class string) string {
+	if class == "" {
+		return store.DeviceComputer
+	}
+	return class
+}
+
+// object returns m as a JSON object with the proto field names, or nil if
+// m is not set.
+func object(m proto.Message) map[string]any {
+	if m == nil || !m.ProtoReflect().IsValid() {
+		return nil
+	}
+	b, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(m)
+	if err != nil {
+		return nil
+	}
+	var v map[string]any
+	if err := json.Unmarshal(b, &v); err != nil || len(v) == 0 {
+		return nil
+	}
+	return v
+}
+
+func appendUnique(list []string, s string) []string {
+	if s == "" {
+		return list
+	}
+	for _, v := range list {
+		if v == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
@@ -0,0 +1,243 @@
+// Package opensearch indexes the submitted inventories into OpenSearch or
+// Elasticsearch through the bulk API, as flattened documents suited to
+// exploring the fleet in Kibana or OpenSearch Dashboards.
+package opensearch
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+	"github.com/go-tangra/go-tangra-inventory/internal/convert"
+	"github.com/go-tangra/go-tangra-inventory/internal/outbox"
+	"github.com/go-tangra/go-tangra-inventory/internal/store"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// requestTimeout bounds a single bulk or template request.
+const requestTimeout = time.Minute
+
+// latestSuffix names the index holding the latest inventory of each host,
+// next to the index of every inventory.
+const latestSuffix = "-latest"
+
+// Options configures an Indexer.
+type Options struct {
+	// URL is the base URL of the cluster, e.g. https://search.example.com:9200.
+	URL string
+	// Index is the index of every inventory; the latest inventory of each
+	// host is also indexed in Index-latest.
+	Index string
+	// Username and Password authenticate with basic authentication, APIKey
+	// with an Elasticsearch API key (base64 id:key).
+	Username string
+	Password string
+	APIKey   string
+}
+
+// Indexer indexes the inventories of InventorySubmitted events from the
+// outbox. Every inventory is indexed by its ID, and the latest inventory of
+// each host by site and hostname, so both the history and the current
+// state of the fleet can be explored. Indexing is idempotent, so events
+// published again after a failure do not duplicate documents.
+type Indexer struct {
+	opts   Options
+	db     *store.Store
+	client *http.Client
+	// templated is set once the index template is installed.
+	templated bool
+}
+
+// NewIndexer checks o and returns an Indexer reading the inventories of
+// the events from db.
+func NewIndexer(o Options, db *store.Store) (*Indexer, error) {
+	u, err := url.Parse(o.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("url: %q is not an http or https URL", o.URL)
+	}
+	if o.Index == "" || o.Index != strings.ToLower(o.Index) || strings.ContainsAny(o.Index, ` "*\<|,>/?#:`) {
+		return nil, fmt.Errorf("index: %q is not a lower-case index name", o.Index)
+	}
+	if o.APIKey != "" && o.Username != "" {
+		return nil, errors.New("api_key: not allowed with username")
+	}
+	o.URL = strings.TrimSuffix(o.URL, "/")
+	return &Indexer{opts: o, db: db, client: http.DefaultClient}, nil
+}
+
+// Publish indexes the inventories of events and returns once the cluster
+// has accepted them. Inventories deleted since they were submitted are
+// skipped.
+func (x *Indexer) Publish(ctx context.Context, events []store.OutboxEvent) error {
+	if !x.templated {
+		if err := x.putTemplate(ctx); err != nil {
+			return err
+		}
+		x.templated = true
+	}
+
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body)
+	for _, e := range events {
+		if e.Type != outbox.InventorySubmitted {
+			continue
+		}
+		var sub collectorv1.InventorySubmission
+		if err := proto.Unmarshal(e.Payload, &sub); err != nil {
+			return fmt.Errorf("decode outbox event %d: %w", e.ID, err)
+		}
+		rec, err := x.db.Get(ctx, sub.Id, nil)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		inv, err := convert.RecordToInventory(rec)
+		if err != nil {
+			return err
+		}
+		doc := Document(rec, inv, &sub)
+
+		// The latest index only moves forward: an older inventory, e.g.
+		// one imported later, is rejected as a version conflict.
+		actions := []any{
+			map[string]any{"index": map[string]any{"_index": x.opts.Index, "_id": fmt.Sprint(rec.ID)}}, doc,
+			map[string]any{"index": map[string]any{
+				"_index":       x.opts.Index + latestSuffix,
+				"_id":          rec.Site + "/" + rec.Hostname,
+				"version":      rec.CollectedAt.Unix(),
+				"version_type": "external_gte",
+			}}, doc,
+		}
+		for _, a := range actions {
+			if err := enc.Encode(a); err != nil {
+				return fmt.Errorf("encode inventory %d: %w", rec.ID, err)
+			}
+		}
+	}
+	if body.Len() == 0 {
+		return nil
+	}
+
+	var res struct {
+		Errors bool `json:"errors"`
+		Items  []map[string]struct {
+			ID     string `json:"_id"`
+			Index  string `json:"_index"`
+			Status int    `json:"status"`
+			Error  struct {
+				Type   string `json:"type"`
+				Reason string `json:"reason"`
+			} `json:"error"`
+		} `json:"items"`
+	}
+	if err := x.do(ctx, http.MethodPost, "/_bulk", "application/x-ndjson", &body, &res); err != nil {
+		return err
+	}
+	if !res.Errors {
+		return nil
+	}
+	// Retry the batch on throttling and server errors; documents the
+	// cluster refuses would fail again and are logged instead.
+	for _, item := range res.Items {
+		for _, r := range item {
+			switch {
+			case r.Status < 300 || r.Status == http.StatusConflict:
+			case r.Status == http.StatusTooManyRequests || r.Status >= 500:
+				return fmt.Errorf("index %s/%s: %s: %s", r.Index, r.ID, r.Error.Type, r.Error.Reason)
+			default:
+				slog.Warn("Inventory document rejected", "index", r.Index, "id", r.ID, "error", r.Error.Type+": "+r.Error.Reason)
+			}
+		}
+	}
+	return nil
+}
+
+// Close implements outbox.Sink.
+func (x *Indexer) Close() error {
+	return nil
+}
+
+// putTemplate installs the index template of the indices, mapping strings
+// as keywords and the timestamps and sizes by type.
+func (x *Indexer) putTemplate(ctx context.Context) error {
+	template := map[string]any{
+		"index_patterns": []string{x.opts.Index, x.opts.Index + latestSuffix},
+		"template": map[string]any{
+			"mappings": map[string]any{
+				"dynamic_templates": []any{
+					map[string]any{"strings": map[string]any{
+						"match_mapping_type": "string",
+						"mapping":            map[string]any{"type": "keyword", "ignore_above": 1024},
+					}},
+				},
+				"properties": map[string]any{
+					"@timestamp":   map[string]any{"type": "date"},
+					"stored_at":    map[string]any{"type": "date"},
+					"inventory_id": map[string]any{"type": "long"},
+					"memory": map[string]any{"properties": map[string]any{
+						"total_bytes": map[string]any{"type": "long"},
+						"total_gb":    map[string]any{"type": "double"},
+					}},
+					"changes": map[string]any{"type": "text"},
+				},
+			},
+		},
+	}
+	body, err := json.Marshal(template)
+	if err != nil {
+		return err
+	}
+	if err := x.do(ctx, http.MethodPut, "/_index_template/"+x.opts.Index, "application/json", bytes.NewReader(body), nil); err != nil {
+		return fmt.Errorf("install index template: %w", err)
+	}
+	return nil
+}
+
+func (x *Indexer) do(ctx context.Context, method, path, contentType string, body io.Reader, v any) error {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, method, x.opts.URL+path, body)
+	if err != nil {
+		return fmt.Errorf("build request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Accept", "application/json")
+	switch {
+	case x.opts.APIKey != "":
+		req.Header.Set("Authorization", "ApiKey "+x.opts.APIKey)
+	case x.opts.Username != "":
+		req.SetBasicAuth(x.opts.Username, x.opts.Password)
+	}
+
+	resp, err := x.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s %s: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s %s: unexpected status %s: %s", method, path, resp.Status, bytes.TrimSpace(msg))
+	}
+	if v == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("%s %s: decode response: %w", method, path, err)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+// Package outbox relays the inventory events queued in the store's outbox
+// to external sinks such as Kafka or MQTT.
+package outbox
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/go-tangra/go-tangra-inventory/internal/logging"
+	"github.com/go-tangra/go-tangra-inventory/internal/store"
+)
+
+const (
+	// batchSize is the number of events published at a time.
+	batchSize = 100
+	// pollInterval is how often the outbox is checked without a Notify.
+	pollInterval = 5 * time.Second
+	// retryInterval is the wait after a failed publish.
+	retryInterval = 10 * time.Second
+)
+
+// Event types. The payload of inventory events is a binary
+// InventorySubmission, that of agent events a binary AgentEvent.
+const (
+	InventorySubmitted = "inventory.submitted"
+	AgentConnected     = "agent.connected"
+	AgentDisconnected  = "agent.disconnected"
+	// AgentUpdated reports that an agent was paused or resumed.
+	AgentUpdated = "agent.updated"
+)
+
+// Sink publishes events to an external system.
+type Sink interface {
+	// Publish publishes a batch of events, oldest first. The events are
+	// removed from the outbox only if it returns nil, so sinks receive
+	// every event at least once.
+	Publish(ctx context.Context, events []store.OutboxEvent) error
+	Close() error
+}
+
+// Relay publishes the events queued for one sink.
+type Relay struct {
+	store  *store.Store
+	name   string
+	sink   Sink
+	types  []string
+	notify chan struct{}
+}
+
+// NewRelay returns a Relay that publishes the events of the given types
+// queued in db under name to sink.
+func NewRelay(db *store.Store, name string, sink Sink, types ...string) *Relay {
+	return &Relay{store: db, name: name, sink: sink, types: types, notify: make(chan struct{}, 1)}
+}
+
+// Wants reports whether the relay publishes events of eventType.
+func (r *Relay) Wants(eventType string) bool {
+	return slices.Contains(r.types, eventType)
+}
+
+// Name returns the name the relay's events are queued under.
+func (r *Relay) Name() string {
+	return r.name
+}
+
+// Notify wakes the relay after an event was queued.
+func (r *Relay) Notify() {
+	select {
+	case r.notify <- struct{}{}:
+	default:
+	}
+}
+
+// Run publishes queued events until ctx is done, then closes the sink.
+func (r *Relay) Run(ctx context.Context) {
+	defer r.sink.Close()
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		n, err := r.relay(ctx)
+		switch {
+		case ctx.Err() != nil:
+			return
+		case err != nil:
+			slog.Error("Publish inventory events failed", "sink", r.name, logging.Err(err))
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(retryInterval):
+			}
+			continue
+		case n == batchSize:
+			// More may be queued.
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-r.notify:
+		case <-ticker.C:
+		}
+	}
+}
+
+// relay publishes a batch of queued events and returns its size.
+func (r *Relay) relay(ctx context.Context) (int, error) {
+	events, err := r.store.PendingOutbox(ctx, r.name, batchSize)
+	if err != nil || len(events) == 0 {
+		return 0, err
+	}
+	if err := r.sink.Publish(ctx, events); err != nil {
+		return 0, err
+	}
+	if err := r.store.DeleteOutbox(ctx, r.name, events[len(events)-1].ID); err != nil {
+		return 0, err
+	}
+	slog.Debug("Published inventory events", "sink", r.name, "count", len(events))
+	return len(events), nil
+}
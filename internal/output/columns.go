@@ -0,0 +1,88 @@
+package output
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+)
+
+// InventoryColumns is the table layout of inventory listings.
+var InventoryColumns = []Column[*collectorv1.InventorySummary]{
+	{Header: "ID", Value: func(s *collectorv1.InventorySummary) string { return strconv.FormatInt(s.Id, 10) }},
+	{Header: "HOSTNAME", Value: func(s *collectorv1.InventorySummary) string { return s.Hostname }},
+	{Header: "SITE", Value: func(s *collectorv1.InventorySummary) string { return s.Site }},
+	{Header: "USERNAME", Value: func(s *collectorv1.InventorySummary) string { return s.Username }},
+	{Header: "SERIAL", Value: func(s *collectorv1.InventorySummary) string { return s.SystemSerial }},
+	{Header: "CLASS", Wide: true, Value: func(s *collectorv1.InventorySummary) string { return s.DeviceClass }},
+	{Header: "SOURCE", Wide: true, Value: func(s *collectorv1.InventorySummary) string { return s.Source }},
+	{Header: "UUID", Wide: true, Value: func(s *collectorv1.InventorySummary) string { return s.SystemUuid }},
+	{Header: "COLLECTED", Value: func(s *collectorv1.InventorySummary) string { return Time(s.CollectedAt) }},
+	{Header: "STORED", Wide: true, Value: func(s *collectorv1.InventorySummary) string { return Time(s.StoredAt) }},
+}
+
+// AgentColumns is the table layout of connected agent listings.
+var AgentColumns = []Column[*collectorv1.ConnectedAgent]{
+	{Header: "HOSTNAME", Value: func(a *collectorv1.ConnectedAgent) string { return a.Hostname }},
+	{Header: "CLIENT ID", Wide: true, Value: func(a *collectorv1.ConnectedAgent) string { return a.ClientId }},
+	{Header: "SITE", Value: func(a *collectorv1.ConnectedAgent) string { return a.Site }},
+	{Header: "VERSION", Value: func(a *collectorv1.ConnectedAgent) string { return a.Version }},
+	{Header: "CONNECTED", Value: func(a *collectorv1.ConnectedAgent) string { return Time(a.ConnectedAt) }},
+	{Header: "SUBMITTED", Value: func(a *collectorv1.ConnectedAgent) string { return OrDash(Time(a.LastSubmittedAt)) }},
+	{Header: "PAUSED", Value: func(a *collectorv1.ConnectedAgent) string { return strconv.FormatBool(a.Paused) }},
+}
+
+// AlertColumns is the table layout of alert listings.
+var AlertColumns = []Column[*collectorv1.Alert]{
+	{Header: "ID", Value: func(a *collectorv1.Alert) string { return strconv.FormatInt(a.Id, 10) }},
+	{Header: "HOSTNAME", Value: func(a *collectorv1.Alert) string { return a.Hostname }},
+	{Header: "SITE", Value: func(a *collectorv1.Alert) string { return a.Site }},
+	{Header: "SEVERITY", Value: func(a *collectorv1.Alert) string { return a.Severity }},
+	{Header: "RULE", Value: func(a *collectorv1.Alert) string { return a.Rule }},
+	{Header: "INVENTORY", Wide: true, Value: func(a *collectorv1.Alert) string { return strconv.FormatInt(a.InventoryId, 10) }},
+	{Header: "CREATED", Value: func(a *collectorv1.Alert) string { return Time(a.CreatedAt) }},
+	{Header: "ACKED", Value: func(a *collectorv1.Alert) string { return strconv.FormatBool(a.Acknowledged) }},
+	{Header: "MESSAGE", Wide: true, Value: func(a *collectorv1.Alert) string { return a.Message }},
+}
+
+// TokenColumns is the table layout of API token listings.
+var TokenColumns = []Column[*collectorv1.ApiToken]{
+	{Header: "ID", Value: func(t *collectorv1.ApiToken) string { return strconv.FormatInt(t.Id, 10) }},
+	{Header: "NAME", Value: func(t *collectorv1.ApiToken) string { return t.Name }},
+	{Header: "ROLE", Value: func(t *collectorv1.ApiToken) string { return t.Role }},
+	{Header: "SITES", Value: func(t *collectorv1.ApiToken) string { return strings.Join(t.Sites, ",") }},
+	{Header: "STATUS", Value: tokenStatus},
+	{Header: "CREATED", Wide: true, Value: func(t *collectorv1.ApiToken) string { return Time(t.CreatedAt) }},
+	{Header: "EXPIRES", Value: func(t *collectorv1.ApiToken) string { return Time(t.ExpiresAt) }},
+	{Header: "REVOKED", Wide: true, Value: func(t *collectorv1.ApiToken) string { return Time(t.RevokedAt) }},
+}
+
+// DeliveryColumns is the table layout of webhook delivery listings.
+var DeliveryColumns = []Column[*collectorv1.WebhookDelivery]{
+	{Header: "ID", Value: func(d *collectorv1.WebhookDelivery) string { return strconv.FormatInt(d.Id, 10) }},
+	{Header: "STATUS", Value: func(d *collectorv1.WebhookDelivery) string { return d.Status }},
+	{Header: "ATTEMPTS", Value: func(d *collectorv1.WebhookDelivery) string { return strconv.Itoa(int(d.Attempts)) }},
+	{Header: "HTTP", Value: func(d *collectorv1.WebhookDelivery) string { return OrDash(httpStatus(d.StatusCode)) }},
+	{Header: "CREATED", Value: func(d *collectorv1.WebhookDelivery) string { return Time(d.CreatedAt) }},
+	{Header: "LAST ATTEMPT", Wide: true, Value: func(d *collectorv1.WebhookDelivery) string { return OrDash(Time(d.LastAttemptAt)) }},
+	{Header: "URL", Value: func(d *collectorv1.WebhookDelivery) string { return d.Url }},
+	{Header: "ERROR", Wide: true, Value: func(d *collectorv1.WebhookDelivery) string { return d.Error }},
+}
+
+func httpStatus(code int32) string {
+	if code == 0 {
+		return ""
+	}
+	return strconv.Itoa(int(code))
+}
+
+func tokenStatus(t *collectorv1.ApiToken) string {
+	switch {
+	case t.RevokedAt != nil:
+		return "revoked"
+	case t.ExpiresAt != nil && t.ExpiresAt.AsTime().Before(time.Now()):
+		return "expired"
+	}
+	return "active"
+}
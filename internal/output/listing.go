@@ -0,0 +1,115 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Wide is a table with additional columns; it applies to listings only.
+const Wide = "wide"
+
+// ListUsage describes the -o flag of listing commands.
+const ListUsage = "output format: table, wide, json or yaml"
+
+// CheckList reports an error if format is not a listing format. An empty
+// format selects a table.
+func CheckList(format string) error {
+	switch format {
+	case "", Table, Wide, JSON, YAML:
+		return nil
+	}
+	return fmt.Errorf("unknown output format %q (use table, wide, json or yaml)", format)
+}
+
+// Column is a table column of a listing of T.
+type Column[T proto.Message] struct {
+	Header string
+	// Wide columns are only shown in wide tables.
+	Wide  bool
+	Value func(T) string
+}
+
+// List renders items to w in format. Tables show cols, with empty cells as
+// "-"; JSON and YAML render the complete messages with the API field names.
+func List[T proto.Message](w io.Writer, format string, items []T, cols []Column[T]) error {
+	if err := CheckList(format); err != nil {
+		return err
+	}
+	if format == JSON || format == YAML {
+		raw := make([]json.RawMessage, len(items))
+		for i, item := range items {
+			data, err := marshalProto(item)
+			if err != nil {
+				return err
+			}
+			raw[i] = data
+		}
+		return Write(w, raw, format)
+	}
+
+	var shown []Column[T]
+	for _, c := range cols {
+		if !c.Wide || format == Wide {
+			shown = append(shown, c)
+		}
+	}
+	cells := make([]string, len(shown))
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for i, c := range shown {
+		cells[i] = c.Header
+	}
+	fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	for _, item := range items {
+		for i, c := range shown {
+			cells[i] = OrDash(c.Value(item))
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	return tw.Flush()
+}
+
+// Message renders a single message to w as YAML, or as JSON for any other
+// format, since a table does not fit a full message.
+func Message(w io.Writer, format string, m proto.Message) error {
+	data, err := marshalProto(m)
+	if err != nil {
+		return err
+	}
+	if format != YAML {
+		format = JSON
+	}
+	return Write(w, json.RawMessage(data), format)
+}
+
+func marshalProto(m proto.Message) ([]byte, error) {
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(m)
+	if err != nil {
+		return nil, fmt.Errorf("encode %s: %w", m.ProtoReflect().Descriptor().Name(), err)
+	}
+	return data, nil
+}
+
+// Time renders ts in local time for table cells.
+func Time(ts *timestamppb.Timestamp) string {
+	if ts == nil {
+		return ""
+	}
+	return ts.AsTime().Local().Format(time.DateTime)
+}
+
+// OrDash renders empty table cells as "-".
+func OrDash(s string) string {
+	if strings.TrimSpace(s) == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,132 @@
+// Package output renders an inventory for people and spreadsheets: indented
+// JSON, YAML, CSV with one field per row, or an aligned text table.
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Supported formats.
+const (
+	JSON  = "json"
+	YAML  = "yaml"
+	CSV   = "csv"
+	Table = "table"
+)
+
+// Ext returns the file name extension for format, or an error if the format
+// is not supported. An empty format selects JSON.
+func Ext(format string) (string, error) {
+	switch format {
+	case "", JSON:
+		return "json", nil
+	case YAML:
+		return "yaml", nil
+	case CSV:
+		return "csv", nil
+	case Table:
+		return "txt", nil
+	default:
+		return "", fmt.Errorf("unknown output format %q (use json, yaml, csv or table)", format)
+	}
+}
+
+// Write renders v to w in format. Field names follow the JSON encoding of v
+// in all formats; CSV and table output flatten nested fields into dotted
+// paths such as processors[0].version.
+func Write(w io.Writer, v any, format string) error {
+	if _, err := Ext(format); err != nil {
+		return err
+	}
+	if format == "" || format == JSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+
+	node, err := toNode(v)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case YAML:
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(node); err != nil {
+			return err
+		}
+		return enc.Close()
+
+	case CSV:
+		cw := csv.NewWriter(w)
+		cw.Write([]string{"field", "value"})
+		flatten(node, "", func(field, value string) {
+			cw.Write([]string{field, value})
+		})
+		cw.Flush()
+		return cw.Error()
+
+	default: // Table
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "FIELD\tVALUE")
+		flatten(node, "", func(field, value string) {
+			fmt.Fprintf(tw, "%s\t%s\n", field, value)
+		})
+		return tw.Flush()
+	}
+}
+
+// toNode converts v to a YAML node tree through its JSON encoding, which
+// keeps the JSON field names and order. Styles are reset so that the tree
+// encodes as block YAML rather than JSON-like flow YAML.
+func toNode(v any) (*yaml.Node, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("encode: %w", err)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("encode: %w", err)
+	}
+	node := doc.Content[0]
+	resetStyle(node)
+	return node, nil
+}
+
+func resetStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, c := range n.Content {
+		resetStyle(c)
+	}
+}
+
+// flatten calls fn for every scalar in n with its dotted path. Empty
+// collections and nulls are skipped.
+func flatten(n *yaml.Node, path string, fn func(field, value string)) {
+	switch n.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key := n.Content[i].Value
+			if path != "" {
+				key = path + "." + key
+			}
+			flatten(n.Content[i+1], key, fn)
+		}
+	case yaml.SequenceNode:
+		for i, c := range n.Content {
+			flatten(c, path+"["+strconv.Itoa(i)+"]", fn)
+		}
+	case yaml.ScalarNode:
+		if n.Tag != "!!null" {
+			fn(path, n.Value)
+		}
+	}
+}
@@ -0,0 +1,271 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-tangra/go-tangra-inventory/internal/store"
+)
+
+// sendTimeout bounds delivering a message to the SMTP server.
+const sendTimeout = time.Minute
+
+// SMTP security modes.
+const (
+	// StartTLS upgrades the connection with STARTTLS and fails if the
+	// server does not offer it.
+	StartTLS = "starttls"
+	// TLS connects over TLS, usually to port 465.
+	TLS = "tls"
+	// Plain sends in the clear.
+	Plain = "none"
+)
+
+// SMTP is an SMTP server to submit mail to.
+type SMTP struct {
+	Host string
+	Port int
+	// Security is StartTLS, TLS or Plain.
+	Security string
+	// Username and Password authenticate with PLAIN unless Username is
+	// empty.
+	Username string
+	Password string
+	From     string
+}
+
+// Check validates the server settings.
+func (s *SMTP) Check() error {
+	if s.Host == "" {
+		return errors.New("host is required")
+	}
+	if s.Port <= 0 || s.Port > 65535 {
+		return fmt.Errorf("invalid port %d", s.Port)
+	}
+	if s.Security != StartTLS && s.Security != TLS && s.Security != Plain {
+		return fmt.Errorf("unknown security %q (use starttls, tls or none)", s.Security)
+	}
+	if _, err := mail.ParseAddress(s.From); err != nil {
+		return fmt.Errorf("from address: %w", err)
+	}
+	return nil
+}
+
+// Attachment is a file attached to a message.
+type Attachment struct {
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
+// Send mails an HTML message with attachments to the recipients.
+func (s *SMTP) Send(ctx context.Context, to []string, subject string, html []byte, attachments []Attachment) error {
+	from, err := mail.ParseAddress(s.From)
+	if err != nil {
+		return fmt.Errorf("from address: %w", err)
+	}
+	msg, err := compose(from, to, subject, html, attachments)
+	if err != nil {
+		return fmt.Errorf("compose message: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+	addr := net.JoinHostPort(s.Host, strconv.Itoa(s.Port))
+	tlsCfg := &tls.Config{ServerName: s.Host, MinVersion: tls.VersionTLS12}
+
+	var conn net.Conn
+	if s.Security == TLS {
+		conn, err = (&tls.Dialer{Config: tlsCfg}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return fmt.Errorf("connect to %s: %w", addr, err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	c, err := smtp.NewClient(conn, s.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("connect to %s: %w", addr, err)
+	}
+	defer c.Close()
+
+	if s.Security == StartTLS {
+		if ok, _ := c.Extension("STARTTLS"); !ok {
+			return fmt.Errorf("%s does not offer STARTTLS", addr)
+		}
+		if err := c.StartTLS(tlsCfg); err != nil {
+			return fmt.Errorf("start TLS: %w", err)
+		}
+	}
+	if s.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", s.Username, s.Password, s.Host)); err != nil {
+			return fmt.Errorf("authenticate: %w", err)
+		}
+	}
+	if err := c.Mail(from.Address); err != nil {
+		return fmt.Errorf("mail from %s: %w", from.Address, err)
+	}
+	for _, rcpt := range to {
+		addr, err := mail.ParseAddress(rcpt)
+		if err != nil {
+			return fmt.Errorf("recipient %q: %w", rcpt, err)
+		}
+		if err := c.Rcpt(addr.Address); err != nil {
+			return fmt.Errorf("recipient %s: %w", addr.Address, err)
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return fmt.Errorf("send message: %w", err)
+	}
+	if _, err := w.Write(msg); err != nil {
+		return fmt.Errorf("send message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("send message: %w", err)
+	}
+	return c.Quit()
+}
+
+// compose builds a multipart/mixed MIME message of the HTML body and the
+// attachments.
+func compose(from *mail.Address, to []string, subject string, html []byte, attachments []Attachment) ([]byte, error) {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+
+	id := make([]byte, 16)
+	rand.Read(id)
+	header := []struct{ key, value string }{
+		{"From", from.String()},
+		{"To", joinAddresses(to)},
+		{"Subject", mime.QEncoding.Encode("utf-8", subject)},
+		{"Date", time.Now().Format(time.RFC1123Z)},
+		{"Message-ID", "<" + hex.EncodeToString(id) + "@" + domain(from.Address) + ">"},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", "multipart/mixed; boundary=" + mw.Boundary()},
+	}
+	var msg bytes.Buffer
+	for _, h := range header {
+		fmt.Fprintf(&msg, "%s: %s\r\n", h.key, h.value)
+	}
+	msg.WriteString("\r\n")
+
+	part, err := mw.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {"text/html; charset=utf-8"},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	qp := quotedprintable.NewWriter(part)
+	if _, err := qp.Write(html); err != nil {
+		return nil, err
+	}
+	if err := qp.Close(); err != nil {
+		return nil, err
+	}
+
+	for _, a := range attachments {
+		part, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {mime.FormatMediaType(a.ContentType, map[string]string{"name": a.Name})},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": a.Name})},
+			"Content-Transfer-Encoding": {"base64"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := writeBase64(part, a.Data); err != nil {
+			return nil, err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+	msg.Write(buf.Bytes())
+	return msg.Bytes(), nil
+}
+
+// writeBase64 writes data base64-encoded in lines of 76 characters.
+func writeBase64(w io.Writer, data []byte) error {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for len(encoded) > 0 {
+		n := min(76, len(encoded))
+		if _, err := fmt.Fprintf(w, "%s\r\n", encoded[:n]); err != nil {
+			return err
+		}
+		encoded = encoded[n:]
+	}
+	return nil
+}
+
+// joinAddresses formats the recipients for the To header.
+func joinAddresses(to []string) string {
+	list := make([]string, len(to))
+	for i, rcpt := range to {
+		list[i] = rcpt
+		if addr, err := mail.ParseAddress(rcpt); err == nil {
+			list[i] = addr.String()
+		}
+	}
+	return strings.Join(list, ", ")
+}
+
+// domain returns the domain of an email address.
+func domain(address string) string {
+	if i := strings.LastIndexByte(address, '@'); i >= 0 {
+		return address[i+1:]
+	}
+	return "localhost"
+}
+
+// Job is a report mailed to recipients on a schedule.
+type Job struct {
+	Spec     Spec
+	To       []string
+	Schedule Schedule
+}
+
+// Mail sends the report of job to its recipients through s: the tables
+// rendered in an HTML message and attached as CSV files.
+func Mail(ctx context.Context, s *SMTP, db *store.Store, job Job) error {
+	r, err := Build(ctx, db, job.Spec)
+	if err != nil {
+		return err
+	}
+	html, err := r.HTML()
+	if err != nil {
+		return fmt.Errorf("render report: %w", err)
+	}
+	attachments := make([]Attachment, len(r.Tables))
+	for i := range r.Tables {
+		data, err := r.Tables[i].CSV()
+		if err != nil {
+			return fmt.Errorf("render %s: %w", r.Tables[i].File, err)
+		}
+		attachments[i] = Attachment{Name: r.Tables[i].File, ContentType: "text/csv", Data: data}
+	}
+	subject := fmt.Sprintf("%s, %s", r.Title, r.GeneratedAt.Format("2 Jan 2006"))
+	return s.Send(ctx, job.To, subject, html, attachments)
+}
@@ -0,0 +1,56 @@
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"html/template"
+)
+
+var htmlTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+</head>
+<body style="font-family: sans-serif; font-size: 14px; color: #222;">
+<h2>{{.Title}}</h2>
+{{range .Summary}}<p>{{.}}</p>
+{{end}}
+{{- range .Tables}}
+<h3>{{.Title}}</h3>
+{{- if .Rows}}
+<table style="border-collapse: collapse;">
+<tr>{{range .Columns}}<th style="border: 1px solid #ccc; padding: 4px 8px; text-align: left; background: #f4f4f4;">{{.}}</th>{{end}}</tr>
+{{range .Rows}}<tr>{{range .}}<td style="border: 1px solid #ccc; padding: 4px 8px;">{{.}}</td>{{end}}</tr>
+{{end -}}
+</table>
+{{- else}}
+<p>None.</p>
+{{- end}}
+{{end}}
+<p style="color: #888; font-size: 12px;">Generated by the inventory collector at {{.GeneratedAt.Format "2006-01-02 15:04 MST"}}. The tables are attached as CSV files.</p>
+</body>
+</html>
+`))
+
+// HTML renders r as an HTML document.
+func (r *Report) HTML() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := htmlTemplate.Execute(&buf, r); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// CSV renders t as CSV with a header row.
+func (t *Table) CSV() ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(t.Columns); err != nil {
+		return nil, err
+	}
+	if err := w.WriteAll(t.Rows); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,182 @@
+// Package report builds the scheduled fleet reports and mails them, as an
+// HTML message with each table attached as CSV.
+package report
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/go-tangra/go-tangra-inventory/internal/store"
+)
+
+// Report kinds.
+const (
+	// Fleet summarizes the latest inventories: host counts and the models
+	// and memory sizes in use.
+	Fleet = "fleet"
+	// Stale lists the hosts not seen for the stale period.
+	Stale = "stale"
+)
+
+// Kinds lists the report kinds.
+var Kinds = []string{Fleet, Stale}
+
+// DefaultStaleAfter is the stale period of a Spec without one.
+const DefaultStaleAfter = 14 * 24 * time.Hour
+
+// pageSize is the number of hosts read from the store at a time.
+const pageSize = 500
+
+// Spec describes a report mail.
+type Spec struct {
+	// Name identifies the report in logs and the subject.
+	Name string
+	// Kinds are the reports included, in order.
+	Kinds []string
+	// Sites restricts the reports to hosts of these sites (nil = all).
+	Sites []string
+	// StaleAfter is how long a host must not have been seen to be stale.
+	StaleAfter time.Duration
+}
+
+// Table is a titled table of a report, rendered as HTML in the message and
+// attached as a CSV file.
+type Table struct {
+	Title string
+	// File is the name of the CSV attachment.
+	File    string
+	Columns []string
+	Rows    [][]string
+}
+
+// Report is a built report: summary lines followed by tables.
+type Report struct {
+	Title       string
+	GeneratedAt time.Time
+	Summary     []string
+	Tables      []Table
+}
+
+// Build runs the reports of spec against db.
+func Build(ctx context.Context, db *store.Store, spec Spec) (*Report, error) {
+	now := time.Now()
+	staleAfter := spec.StaleAfter
+	if staleAfter <= 0 {
+		staleAfter = DefaultStaleAfter
+	}
+
+	r := &Report{Title: spec.Name, GeneratedAt: now}
+	for _, kind := range spec.Kinds {
+		var err error
+		switch kind {
+		case Fleet:
+			err = r.addFleet(ctx, db, spec.Sites, now, staleAfter)
+		case Stale:
+			err = r.addStale(ctx, db, spec.Sites, now, staleAfter)
+		default:
+			err = fmt.Errorf("unknown report %q", kind)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+func (r *Report) addFleet(ctx context.Context, db *store.Store, sites []string, now time.Time, staleAfter time.Duration) error {
+	stats, err := db.FleetStats(ctx, sites)
+	if err != nil {
+		return err
+	}
+	hosts, err := listHosts(ctx, db, sites)
+	if err != nil {
+		return err
+	}
+	var week, stale int
+	for _, h := range hosts {
+		age := now.Sub(h.LastSeen)
+		if age <= 7*24*time.Hour {
+			week++
+		}
+		if age > staleAfter {
+			stale++
+		}
+	}
+
+	r.Summary = append(r.Summary,
+		fmt.Sprintf("%d hosts, %d seen in the last 7 days, %d not seen for %s.", stats.Hosts, week, stale, days(staleAfter)))
+	r.Tables = append(r.Tables,
+		countTable("Models", "fleet-models.csv", "Model", stats.Models, stats.Hosts),
+		countTable("Memory", "fleet-memory.csv", "Memory", stats.Memory, stats.Hosts))
+	return nil
+}
+
+func (r *Report) addStale(ctx context.Context, db *store.Store, sites []string, now time.Time, staleAfter time.Duration) error {
+	hosts, err := listHosts(ctx, db, sites)
+	if err != nil {
+		return err
+	}
+
+	t := Table{
+		Title:   fmt.Sprintf("Hosts not seen for %s", days(staleAfter)),
+		File:    "stale-hosts.csv",
+		Columns: []string{"Site", "Hostname", "Username", "Last seen", "Days", "Latest inventory"},
+	}
+	// ListHosts returns the most recently seen first; list the longest
+	// unseen first.
+	for _, h := range slices.Backward(hosts) {
+		age := now.Sub(h.LastSeen)
+		if age <= staleAfter {
+			continue
+		}
+		t.Rows = append(t.Rows, []string{
+			h.Site, h.Hostname, h.Username,
+			h.LastSeen.Local().Format("2006-01-02 15:04"),
+			strconv.Itoa(int(age / (24 * time.Hour))),
+			strconv.FormatInt(h.LatestID, 10),
+		})
+	}
+	r.Summary = append(r.Summary, fmt.Sprintf("%d hosts not seen for %s.", len(t.Rows), days(staleAfter)))
+	r.Tables = append(r.Tables, t)
+	return nil
+}
+
+// listHosts returns every host of sites.
+func listHosts(ctx context.Context, db *store.Store, sites []string) ([]store.HostRecord, error) {
+	var all []store.HostRecord
+	for page := 1; ; page++ {
+		hosts, _, err := db.ListHosts(ctx, store.HostFilter{Sites: sites, PageSize: pageSize, Page: page})
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, hosts...)
+		if len(hosts) < pageSize {
+			return all, nil
+		}
+	}
+}
+
+// countTable renders a breakdown with each value's share of total.
+func countTable(title, file, column string, counts []store.Count, total int) Table {
+	t := Table{Title: title, File: file, Columns: []string{column, "Hosts", "Share"}}
+	for _, c := range counts {
+		share := "0%"
+		if total > 0 {
+			share = fmt.Sprintf("%.0f%%", 100*float64(c.Hosts)/float64(total))
+		}
+		t.Rows = append(t.Rows, []string{c.Value, strconv.Itoa(c.Hosts), share})
+	}
+	return t
+}
+
+// days formats d in whole days, e.g. "14 days".
+func days(d time.Duration) string {
+	n := int(d / (24 * time.Hour))
+	if n == 1 {
+		return "1 day"
+	}
+	return fmt.Sprintf("%d days", n)
+}
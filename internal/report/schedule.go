@@ -0,0 +1,55 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Schedule is a weekly or daily time of day, in the collector's local time.
+type Schedule struct {
+	// daily ignores weekday.
+	daily   bool
+	weekday time.Weekday
+	hour    int
+	minute  int
+}
+
+// ParseSchedule parses "<day> HH:MM", where day is a weekday such as mon or
+// monday, or "daily".
+func ParseSchedule(s string) (Schedule, error) {
+	day, clock, ok := strings.Cut(strings.TrimSpace(s), " ")
+	if !ok {
+		return Schedule{}, fmt.Errorf("invalid schedule %q (use e.g. \"mon 08:00\" or \"daily 07:30\")", s)
+	}
+	t, err := time.Parse("15:04", strings.TrimSpace(clock))
+	if err != nil {
+		return Schedule{}, fmt.Errorf("invalid schedule %q: invalid time %q", s, clock)
+	}
+	sched := Schedule{hour: t.Hour(), minute: t.Minute()}
+
+	day = strings.ToLower(day)
+	if day == "daily" {
+		sched.daily = true
+		return sched, nil
+	}
+	for wd := time.Sunday; wd <= time.Saturday; wd++ {
+		name := strings.ToLower(wd.String())
+		if day == name || day == name[:3] {
+			sched.weekday = wd
+			return sched, nil
+		}
+	}
+	return Schedule{}, fmt.Errorf("invalid schedule %q: unknown day %q", s, day)
+}
+
+// Next returns the first scheduled time after t.
+func (s Schedule) Next(t time.Time) time.Time {
+	y, m, d := t.Date()
+	next := time.Date(y, m, d, s.hour, s.minute, 0, 0, t.Location())
+	for !next.After(t) || (!s.daily && next.Weekday() != s.weekday) {
+		next = time.Date(y, m, d+1, s.hour, s.minute, 0, 0, t.Location())
+		y, m, d = next.Date()
+	}
+	return next
+}
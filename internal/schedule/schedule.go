@@ -0,0 +1,125 @@
+// Package schedule decides when an agent may submit its inventory, so that
+// large fleets started at the same moment spread their load over time and,
+// optionally, only submit inside configured daily windows.
+package schedule
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"time"
+)
+
+const minutesPerDay = 24 * 60
+
+// Window is a daily submission window in local time. A window whose end is
+// before its start wraps past midnight, e.g. 22:00-02:00.
+type Window struct {
+	start, end int // minutes since midnight
+}
+
+// ParseWindows parses a comma-separated list of HH:MM-HH:MM windows. An
+// empty string yields no windows, meaning submissions are always allowed.
+func ParseWindows(s string) ([]Window, error) {
+	var windows []Window
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		from, to, ok := strings.Cut(part, "-")
+		if !ok {
+			return nil, fmt.Errorf("invalid window %q (use HH:MM-HH:MM)", part)
+		}
+		start, err := parseClock(from)
+		if err != nil {
+			return nil, fmt.Errorf("invalid window %q: %w", part, err)
+		}
+		end, err := parseClock(to)
+		if err != nil {
+			return nil, fmt.Errorf("invalid window %q: %w", part, err)
+		}
+		if start == end {
+			return nil, fmt.Errorf("invalid window %q: start equals end", part)
+		}
+		windows = append(windows, Window{start: start, end: end})
+	}
+	return windows, nil
+}
+
+func parseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// length returns the duration of the window.
+func (w Window) length() time.Duration {
+	return time.Duration((w.end-w.start+minutesPerDay)%minutesPerDay) * time.Minute
+}
+
+// span returns the occurrence of w that contains t, or else the next one
+// that starts after t.
+func (w Window) span(t time.Time) (start, end time.Time) {
+	y, m, d := t.Date()
+	for _, day := range []int{d - 1, d, d + 1} {
+		start = time.Date(y, m, day, 0, w.start, 0, 0, t.Location())
+		end = start.Add(w.length())
+		if t.Before(end) {
+			return start, end
+		}
+	}
+	return start, end
+}
+
+// Policy spreads submissions with random jitter and restricts them to daily
+// windows. The zero Policy allows immediate submission.
+type Policy struct {
+	// Jitter is the maximum random delay added before a submission.
+	Jitter time.Duration
+	// Windows restricts submissions to these times of day (empty = any time).
+	Windows []Window
+}
+
+// IsZero reports whether p never delays a submission.
+func (p Policy) IsZero() bool {
+	return p.Jitter <= 0 && len(p.Windows) == 0
+}
+
+// Delay returns how long to wait from now before submitting, given that the
+// submission should happen no earlier than after from now. The jitter is
+// spread inside the window so agents waiting for it do not all fire at its
+// opening.
+func (p Policy) Delay(now time.Time, after time.Duration) time.Duration {
+	t := now.Add(after)
+
+	start, end := t, time.Time{}
+	if len(p.Windows) > 0 {
+		start, end = p.nextWindow(t)
+		if start.Before(t) {
+			start = t
+		}
+	}
+
+	spread := p.Jitter
+	if !end.IsZero() && spread > end.Sub(start) {
+		spread = end.Sub(start)
+	}
+	if spread > 0 {
+		start = start.Add(rand.N(spread))
+	}
+	return start.Sub(now)
+}
+
+// nextWindow returns the earliest window occurrence containing or following t.
+func (p Policy) nextWindow(t time.Time) (start, end time.Time) {
+	for i, w := range p.Windows {
+		s, e := w.span(t)
+		if i == 0 || s.Before(start) {
+			start, end = s, e
+		}
+	}
+	return start, end
+}
@@ -0,0 +1,141 @@
+package sender
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/http/httpproxy"
+	"golang.org/x/net/proxy"
+)
+
+// ProxyNone disables proxying, including proxies from the environment.
+const ProxyNone = "none"
+
+// proxyURL returns the proxy to reach addr through: the explicit setting if
+// given, otherwise HTTPS_PROXY (honoring NO_PROXY). nil means a direct
+// connection.
+func proxyURL(setting, addr string) (*url.URL, error) {
+	switch setting {
+	case ProxyNone:
+		return nil, nil
+	case "":
+		return httpproxy.FromEnvironment().ProxyFunc()(&url.URL{Scheme: "https", Host: addr})
+	}
+
+	u, err := url.Parse(setting)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid proxy URL %q", setting)
+	}
+	return u, nil
+}
+
+// proxyDialer returns a gRPC context dialer that tunnels through the proxy
+// at u. HTTP(S) proxies are used with CONNECT; socks5 and socks5h with SOCKS5.
+func proxyDialer(u *url.URL) (func(context.Context, string) (net.Conn, error), error) {
+	switch u.Scheme {
+	case "http", "https":
+		return func(ctx context.Context, addr string) (net.Conn, error) {
+			return dialConnect(ctx, u, addr)
+		}, nil
+
+	case "socks5", "socks5h":
+		d, err := proxy.FromURL(u, &net.Dialer{})
+		if err != nil {
+			return nil, fmt.Errorf("socks proxy: %w", err)
+		}
+		cd, ok := d.(proxy.ContextDialer)
+		if !ok {
+			return nil, fmt.Errorf("socks proxy dialer does not support contexts")
+		}
+		return func(ctx context.Context, addr string) (net.Conn, error) {
+			return cd.DialContext(ctx, "tcp", addr)
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported proxy scheme %q (use http, https or socks5)", u.Scheme)
+	}
+}
+
+// dialConnect opens a tunnel to addr through the HTTP proxy at u.
+func dialConnect(ctx context.Context, u *url.URL, addr string) (net.Conn, error) {
+	proxyAddr := u.Host
+	if u.Port() == "" {
+		if u.Scheme == "https" {
+			proxyAddr = net.JoinHostPort(u.Hostname(), "443")
+		} else {
+			proxyAddr = net.JoinHostPort(u.Hostname(), "80")
+		}
+	}
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", proxyAddr)
+	if err != nil {
+		return nil, fmt.Errorf("dial proxy %s: %w", proxyAddr, err)
+	}
+	if u.Scheme == "https" {
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: u.Hostname(), MinVersion: tls.VersionTLS12})
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("proxy TLS handshake: %w", err)
+		}
+		conn = tlsConn
+	}
+
+	// Abort the handshake when the caller gives up.
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: addr},
+		Host:   addr,
+		Header: make(http.Header),
+	}
+	if u.User != nil {
+		password, _ := u.User.Password()
+		creds := base64.StdEncoding.EncodeToString([]byte(u.User.Username() + ":" + password))
+		req.Header.Set("Proxy-Authorization", "Basic "+creds)
+	}
+	if err := req.Write(conn); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("proxy CONNECT: %w", err)
+	}
+
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("proxy CONNECT: %w", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		conn.Close()
+		return nil, fmt.Errorf("proxy CONNECT to %s: %s", addr, strings.TrimSpace(resp.Status))
+	}
+	if !stop() {
+		return nil, fmt.Errorf("proxy CONNECT: %w", ctx.Err())
+	}
+
+	if br.Buffered() > 0 {
+		return &bufferedConn{Conn: conn, r: br}, nil
+	}
+	return conn, nil
+}
+
+// bufferedConn returns bytes read ahead while parsing the CONNECT response
+// before reading from the connection itself.
+type bufferedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (c *bufferedConn) Read(p []byte) (int, error) {
+	return c.r.Read(p)
+}
@@ -0,0 +1,106 @@
+package sender
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ParseRate parses an upload rate in bytes per second with an optional k, m
+// or g suffix (powers of 1024), e.g. 256k. An empty string or 0 means
+// unlimited.
+func ParseRate(rate string) (int64, error) {
+	s := strings.ToLower(strings.TrimSpace(rate))
+	s = strings.TrimSuffix(s, "/s")
+	s = strings.TrimSuffix(s, "b")
+	if s == "" {
+		return 0, nil
+	}
+
+	mult := int64(1)
+	switch s[len(s)-1] {
+	case 'k':
+		mult = 1 << 10
+	case 'm':
+		mult = 1 << 20
+	case 'g':
+		mult = 1 << 30
+	}
+	if mult > 1 {
+		s = s[:len(s)-1]
+	}
+
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid rate %q (use bytes per second, e.g. 256k or 2m)", rate)
+	}
+	return int64(n * float64(mult)), nil
+}
+
+// rateLimiter is a token bucket refilled at rate bytes per second that
+// holds up to one second's worth of tokens.
+type rateLimiter struct {
+	rate int64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(rate int64) *rateLimiter {
+	return &rateLimiter{rate: rate, tokens: float64(rate), last: time.Now()}
+}
+
+// wait blocks until n bytes, at most one second's worth, may be sent.
+func (l *rateLimiter) wait(n int) {
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens = min(l.tokens+now.Sub(l.last).Seconds()*float64(l.rate), float64(l.rate))
+	l.last = now
+	l.tokens -= float64(n)
+	deficit := -l.tokens
+	l.mu.Unlock()
+
+	if deficit > 0 {
+		time.Sleep(time.Duration(deficit / float64(l.rate) * float64(time.Second)))
+	}
+}
+
+// throttle wraps dial so that each connection it opens shares one limit of
+// rate bytes per second.
+func throttle(dial func(context.Context, string) (net.Conn, error), rate int64) func(context.Context, string) (net.Conn, error) {
+	limiter := newRateLimiter(rate)
+	return func(ctx context.Context, addr string) (net.Conn, error) {
+		conn, err := dial(ctx, addr)
+		if err != nil {
+			return nil, err
+		}
+		return &throttledConn{Conn: conn, limiter: limiter}, nil
+	}
+}
+
+// throttledConn caps the write rate of a connection, so that submissions do
+// not saturate thin links.
+type throttledConn struct {
+	net.Conn
+	limiter *rateLimiter
+}
+
+func (c *throttledConn) Write(p []byte) (int, error) {
+	var written int
+	for len(p) > 0 {
+		chunk := min(len(p), int(max(c.limiter.rate/10, 1)))
+		c.limiter.wait(chunk)
+		n, err := c.Conn.Write(p[:chunk])
+		written += n
+		if err != nil {
+			return written, err
+		}
+		p = p[chunk:]
+	}
+	return written, nil
+}
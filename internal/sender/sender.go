@@ -1,44 +1,173 @@
 package sender
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"net"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
+
 	"github.com/go-tangra/go-tangra-inventory/internal/collector"
+	"github.com/go-tangra/go-tangra-inventory/internal/compression"
+	"github.com/go-tangra/go-tangra-inventory/internal/logging"
 
 	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
 
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// Options controls how an inventory is submitted to the collector.
+type Options struct {
+	// Secret is sent as the x-client-secret gRPC metadata header when non-empty.
+	Secret string
+	// Site is the tenant/site the host belongs to (may be empty).
+	Site string
+	// Compression selects the request compressor: gzip, zstd or none.
+	Compression string
+	// TLS configures transport security (default plaintext).
+	TLS TLS
+	// Proxy is an http://, https:// or socks5:// proxy URL for the
+	// connection. Empty uses HTTPS_PROXY/NO_PROXY from the environment;
+	// ProxyNone connects directly.
+	Proxy string
+	// Keepalive pings the collector after this long without activity, and
+	// closes the connection when a ping goes unanswered for keepaliveTimeout,
+	// so that half-open connections are noticed (0 = no pings).
+	Keepalive time.Duration
+	// MaxRate caps the upload rate to the collector in bytes per second
+	// (0 = unlimited). Unix socket connections are not limited.
+	MaxRate int64
+}
+
+const (
+	// submitTimeout bounds a submission, plus the transfer time when the
+	// upload rate is capped.
+	submitTimeout = 30 * time.Second
+
+	// keepaliveTimeout is how long a keepalive ping may go unanswered.
+	keepaliveTimeout = 20 * time.Second
+)
+
+// Dial creates a client connection to the collector at addr using the
+// transport settings in opts.
+func Dial(addr string, opts Options) (*grpc.ClientConn, error) {
+	creds, err := opts.TLS.credentials()
+	if err != nil {
+		return nil, err
+	}
+
+	// Proxies are resolved here rather than by gRPC, which only supports
+	// HTTP CONNECT from the environment.
+	dialOpts := []grpc.DialOption{grpc.WithTransportCredentials(creds), grpc.WithNoProxy()}
+	if opts.Keepalive > 0 {
+		dialOpts = append(dialOpts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                opts.Keepalive,
+			Timeout:             keepaliveTimeout,
+			PermitWithoutStream: true,
+		}))
+	}
+	if !strings.HasPrefix(addr, "unix:") {
+		var dialer func(context.Context, string) (net.Conn, error)
+		u, err := proxyURL(opts.Proxy, addr)
+		if err != nil {
+			return nil, err
+		}
+		if u != nil {
+			if dialer, err = proxyDialer(u); err != nil {
+				return nil, err
+			}
+			// Let the proxy resolve the collector hostname.
+			addr = "passthrough:///" + addr
+		}
+		if opts.MaxRate > 0 {
+			if dialer == nil {
+				var d net.Dialer
+				dialer = func(ctx context.Context, addr string) (net.Conn, error) {
+					return d.DialContext(ctx, "tcp", addr)
+				}
+			}
+			dialer = throttle(dialer, opts.MaxRate)
+		}
+		if dialer != nil {
+			dialOpts = append(dialOpts, grpc.WithContextDialer(dialer))
+		}
+	}
+
+	conn, err := grpc.NewClient(addr, dialOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("connect to collector: %w", err)
+	}
+	return conn, nil
+}
+
+// submissionNamespace is the namespace of the name-based UUIDs that
+// SubmissionUUID returns.
+var submissionNamespace = uuid.MustParse("ee703db3-7455-40a1-ab4e-05c9fb909e42")
+
+// SubmissionUUID returns the submission UUID inv is sent with. It is derived
+// from the host and collection time, so that every retry of inv, whether
+// after a timeout, to another collector or from the spool, sends the same
+// one and the collector stores inv once.
+func SubmissionUUID(inv *collector.Inventory) string {
+	name := inv.Hostname + "\x00" + inv.System.UUID + "\x00" + strconv.FormatInt(inv.CollectedAt.UnixNano(), 10)
+	return uuid.NewSHA1(submissionNamespace, []byte(name)).String()
+}
+
 // Send connects to the collector at addr and submits the inventory.
-// When secret is non-empty, it is sent as the x-client-secret gRPC metadata header.
 // Returns the assigned record ID.
-func Send(ctx context.Context, addr string, secret string, inv *collector.Inventory) (int64, error) {
-	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+//
+// Collectors that predate compression support reject compressed requests;
+// the submission is then retried once uncompressed.
+func Send(ctx context.Context, addr string, opts Options, inv *collector.Inventory) (int64, error) {
+	enc, err := compression.Encoding(opts.Compression)
+	if err != nil {
+		return 0, err
+	}
+
+	pbInv := ToProto(inv)
+	pbInv.Site = opts.Site
+	req := &collectorv1.SubmitInventoryRequest{
+		Inventory:      pbInv,
+		SubmissionUuid: SubmissionUUID(inv),
+	}
+
+	// Allow a rate-limited upload the time it needs on top of the timeout.
+	timeout := submitTimeout
+	if opts.MaxRate > 0 {
+		timeout += time.Duration(int64(proto.Size(req))/opts.MaxRate) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	if secret != "" {
-		ctx = metadata.AppendToOutgoingContext(ctx, "x-client-secret", secret)
+	if opts.Secret != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "x-client-secret", opts.Secret)
 	}
 
-	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := Dial(addr, opts)
 	if err != nil {
-		return 0, fmt.Errorf("connect to collector: %w", err)
+		return 0, err
 	}
 	defer conn.Close()
 
 	client := collectorv1.NewInventoryCollectorServiceClient(conn)
 
-	pbInv := toProto(inv)
-
-	resp, err := client.SubmitInventory(ctx, &collectorv1.SubmitInventoryRequest{
-		Inventory: pbInv,
-	})
+	resp, err := client.SubmitInventory(ctx, req, grpc.UseCompressor(enc))
+	if status.Code(err) == codes.Unimplemented && enc != encoding.Identity {
+		resp, err = client.SubmitInventory(ctx, req, grpc.UseCompressor(encoding.Identity))
+	}
 	if err != nil {
 		return 0, fmt.Errorf("submit inventory: %w", err)
 	}
@@ -46,7 +175,157 @@ func Send(ctx context.Context, addr string, secret string, inv *collector.Invent
 	return resp.Id, nil
 }
 
-func toProto(inv *collector.Inventory) *collectorv1.Inventory {
+// ParseAddrs splits a comma-separated list of collector addresses.
+func ParseAddrs(s string) []string {
+	var addrs []string
+	for _, a := range strings.Split(s, ",") {
+		if a = strings.TrimSpace(a); a != "" {
+			addrs = append(addrs, a)
+		}
+	}
+	return addrs
+}
+
+// srvPrefix marks a collector address that is discovered through DNS SRV
+// records, e.g. srv:_tangra-inventory._tcp.corp.example.com.
+const srvPrefix = "srv:"
+
+// Resolve expands the srv: entries in addrs into the host:port targets of
+// their SRV records, ordered by priority and weight as returned by the
+// resolver. Entries that fail to resolve are logged and skipped; an error is
+// returned only when no address remains.
+func Resolve(ctx context.Context, addrs []string) ([]string, error) {
+	var out []string
+	var lastErr error
+	for _, addr := range addrs {
+		name, ok := strings.CutPrefix(addr, srvPrefix)
+		if !ok {
+			out = append(out, addr)
+			continue
+		}
+
+		_, records, err := net.DefaultResolver.LookupSRV(ctx, "", "", name)
+		if err != nil {
+			lastErr = fmt.Errorf("resolve SRV %s: %w", name, err)
+			slog.Warn("Collector discovery failed", "srv", name, logging.Err(err))
+			continue
+		}
+		for _, r := range records {
+			out = append(out, net.JoinHostPort(strings.TrimSuffix(r.Target, "."), strconv.Itoa(int(r.Port))))
+		}
+	}
+
+	if len(out) == 0 {
+		if lastErr != nil {
+			return nil, lastErr
+		}
+		return nil, errors.New("no collector address")
+	}
+	return out, nil
+}
+
+// SendFailover submits inv to the collectors in addrs in order, moving on to
+// the next one only while the error is Retryable. srv: entries are resolved
+// first. It returns the assigned record ID and the address that accepted the
+// inventory.
+func SendFailover(ctx context.Context, addrs []string, opts Options, inv *collector.Inventory) (int64, string, error) {
+	addrs, err := Resolve(ctx, addrs)
+	if err != nil {
+		// Treated like an unreachable collector, so that it is retried.
+		return 0, "", status.Error(codes.Unavailable, err.Error())
+	}
+	for _, addr := range addrs {
+		var id int64
+		id, err = Send(ctx, addr, opts, inv)
+		if err == nil {
+			return id, addr, nil
+		}
+		if !Retryable(err) {
+			return 0, addr, err
+		}
+		slog.Warn("Collector unavailable", "collector", addr, logging.Err(err))
+	}
+	return 0, "", err
+}
+
+// Check verifies that the collector at addr accepts the credentials in opts,
+// without submitting anything, and returns the site that submissions would be
+// stored under. Collectors that predate the check report codes.Unimplemented.
+func Check(ctx context.Context, addr string, opts Options) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, submitTimeout)
+	defer cancel()
+
+	if opts.Secret != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "x-client-secret", opts.Secret)
+	}
+
+	conn, err := Dial(addr, opts)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	resp, err := collectorv1.NewInventoryCollectorServiceClient(conn).CheckAgent(ctx, &collectorv1.CheckAgentRequest{
+		Site: opts.Site,
+	})
+	if err != nil {
+		return "", fmt.Errorf("check collector: %w", err)
+	}
+	return resp.Site, nil
+}
+
+// Preview builds the request Send would submit for inv without contacting
+// the collector. It checks opts and rejects inventories the collector would
+// refuse, and returns the encoded request size before and after compression.
+func Preview(opts Options, inv *collector.Inventory) (size, compressed int, err error) {
+	enc, err := compression.Encoding(opts.Compression)
+	if err != nil {
+		return 0, 0, err
+	}
+	if _, err := opts.TLS.credentials(); err != nil {
+		return 0, 0, err
+	}
+	if inv.Hostname == "" {
+		return 0, 0, fmt.Errorf("hostname is required")
+	}
+
+	pbInv := ToProto(inv)
+	pbInv.Site = opts.Site
+	data, err := proto.Marshal(&collectorv1.SubmitInventoryRequest{Inventory: pbInv, SubmissionUuid: SubmissionUUID(inv)})
+	if err != nil {
+		return 0, 0, fmt.Errorf("encode inventory: %w", err)
+	}
+	if enc == encoding.Identity {
+		return len(data), len(data), nil
+	}
+
+	var buf bytes.Buffer
+	w, err := encoding.GetCompressor(enc).Compress(&buf)
+	if err != nil {
+		return 0, 0, fmt.Errorf("compress inventory: %w", err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return 0, 0, fmt.Errorf("compress inventory: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return 0, 0, fmt.Errorf("compress inventory: %w", err)
+	}
+	return len(data), buf.Len(), nil
+}
+
+// Retryable reports whether err from Send means the collector could not be
+// reached or was temporarily unable to accept the inventory, so that the same
+// submission may succeed later.
+func Retryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+		return true
+	}
+	return false
+}
+
+// ToProto converts inv to the message submitted to the collector.
+func ToProto(inv *collector.Inventory) *collectorv1.Inventory {
 	pb := &collectorv1.Inventory{
 		CollectedAt: timestamppb.New(inv.CollectedAt),
 		Hostname:    inv.Hostname,
@@ -179,5 +458,25 @@ func toProto(inv *collector.Inventory) *collectorv1.Inventory {
 		})
 	}
 
+	pb.Privileges = &collectorv1.AgentPrivileges{
+		Elevated:           inv.Privileges.Elevated,
+		UnavailableModules: inv.Privileges.Unavailable,
+	}
+
+	// Telemetry
+	if t := inv.Telemetry; t != nil {
+		pb.Telemetry = &collectorv1.CollectionTelemetry{
+			DurationMs:   t.DurationMs,
+			PeakRssBytes: t.PeakRSSBytes,
+		}
+		for _, m := range t.Modules {
+			pb.Telemetry.Modules = append(pb.Telemetry.Modules, &collectorv1.ModuleTelemetry{
+				Name:       m.Name,
+				DurationMs: m.DurationMs,
+				Error:      m.Error,
+			})
+		}
+	}
+
 	return pb
 }
@@ -0,0 +1,122 @@
+package sender
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// TLS configures transport security for the collector connection.
+type TLS struct {
+	// Enabled turns on TLS. Without CAFile or Pins the collector certificate
+	// is verified against the system roots.
+	Enabled bool
+	// CAFile is a PEM bundle of CAs to verify the collector certificate with
+	// instead of the system roots.
+	CAFile string
+	// Pins are SHA-256 digests (hex or base64) of acceptable collector public
+	// keys (SubjectPublicKeyInfo). With pins and no CAFile, a self-signed
+	// collector certificate is accepted if its key matches a pin.
+	Pins []string
+	// CertFile and KeyFile are a PEM client certificate and key presented
+	// to collectors that require one (mutual TLS).
+	CertFile string
+	KeyFile  string
+}
+
+// credentials returns the gRPC transport credentials for t.
+func (t TLS) credentials() (credentials.TransportCredentials, error) {
+	if !t.Enabled {
+		return insecure.NewCredentials(), nil
+	}
+
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if t.CAFile != "" {
+		pem, err := os.ReadFile(t.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("read CA certificate: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", t.CAFile)
+		}
+		cfg.RootCAs = pool
+	}
+
+	if t.CertFile != "" || t.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(t.CertFile, t.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	if len(t.Pins) > 0 {
+		pins := make(map[[sha256.Size]byte]bool, len(t.Pins))
+		for _, p := range t.Pins {
+			sum, err := parsePin(p)
+			if err != nil {
+				return nil, err
+			}
+			pins[sum] = true
+		}
+
+		// Without a CA only the leaf key is pinned, since the rest of the
+		// presented chain is unverified.
+		if t.CAFile == "" {
+			cfg.InsecureSkipVerify = true
+		}
+		cfg.VerifyConnection = func(cs tls.ConnectionState) error {
+			return verifyPins(cs, pins)
+		}
+	}
+
+	return credentials.NewTLS(cfg), nil
+}
+
+// verifyPins accepts the connection if the leaf certificate's key, or with a
+// verified chain any key in it, matches a pin.
+func verifyPins(cs tls.ConnectionState, pins map[[sha256.Size]byte]bool) error {
+	if len(cs.PeerCertificates) == 0 {
+		return errors.New("collector presented no certificate")
+	}
+
+	candidates := cs.PeerCertificates[:1]
+	for _, chain := range cs.VerifiedChains {
+		candidates = append(candidates, chain...)
+	}
+	for _, cert := range candidates {
+		if pins[sha256.Sum256(cert.RawSubjectPublicKeyInfo)] {
+			return nil
+		}
+	}
+	return errors.New("collector certificate does not match any pinned key")
+}
+
+// parsePin decodes a SHA-256 pin given as hex (optionally colon-separated)
+// or standard base64, with an optional "sha256/" prefix.
+func parsePin(s string) ([sha256.Size]byte, error) {
+	var sum [sha256.Size]byte
+
+	p := strings.TrimPrefix(strings.TrimSpace(s), "sha256/")
+	b, err := hex.DecodeString(strings.ReplaceAll(p, ":", ""))
+	if err != nil || len(b) != sha256.Size {
+		b, err = base64.StdEncoding.DecodeString(p)
+	}
+	if err != nil || len(b) != sha256.Size {
+		return sum, fmt.Errorf("invalid SHA-256 pin %q", s)
+	}
+
+	copy(sum[:], b)
+	return sum, nil
+}
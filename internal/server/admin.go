@@ -0,0 +1,339 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+	"github.com/go-tangra/go-tangra-inventory/internal/alert"
+	"github.com/go-tangra/go-tangra-inventory/internal/convert"
+	"github.com/go-tangra/go-tangra-inventory/internal/logging"
+	"github.com/go-tangra/go-tangra-inventory/internal/store"
+	"github.com/go-tangra/go-tangra-inventory/internal/tenant"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// AdminHandler implements the InventoryAdminService gRPC service. It shares
+// the store and command registry of the collector Handler.
+type AdminHandler struct {
+	collectorv1.UnimplementedInventoryAdminServiceServer
+	h      *Handler
+	tokens *APITokens
+}
+
+// NewAdminHandler creates an admin handler that delegates to h and manages
+// tokens.
+func NewAdminHandler(h *Handler, tokens *APITokens) *AdminHandler {
+	return &AdminHandler{h: h, tokens: tokens}
+}
+
+func (a *AdminHandler) DeleteInventory(ctx context.Context, req *collectorv1.DeleteInventoryRequest) (*collectorv1.DeleteInventoryResponse, error) {
+	return a.h.DeleteInventory(ctx, req)
+}
+
+func (a *AdminHandler) PurgeInventories(ctx context.Context, req *collectorv1.PurgeInventoriesRequest) (*collectorv1.PurgeInventoriesResponse, error) {
+	if req.OlderThanDays < 0 {
+		return nil, status.Error(codes.InvalidArgument, "older_than_days must not be negative")
+	}
+	if req.OlderThanDays == 0 && req.Hostname == "" && req.HostnameRegex == "" && req.SystemUuid == "" {
+		return nil, status.Error(codes.InvalidArgument, "older_than_days must be positive unless hostname, hostname_regex or system_uuid is set")
+	}
+	var re *regexp.Regexp
+	if req.HostnameRegex != "" {
+		var err error
+		if re, err = regexp.Compile(req.HostnameRegex); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid hostname_regex: %v", err)
+		}
+	}
+	sites, err := tenant.Filter(ctx, req.Site)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := a.h.store.Purge(ctx, store.PurgeFilter{
+		OlderThan:      time.Duration(req.OlderThanDays) * 24 * time.Hour,
+		Hostname:       req.Hostname,
+		HostnameRegexp: re,
+		SystemUUID:     req.SystemUuid,
+		Sites:          sites,
+		DryRun:         req.DryRun,
+		Progress: purgeProgress("Purging inventories (admin request)", "older_than_days", req.OlderThanDays,
+			"hostname", req.Hostname, "hostname_regex", req.HostnameRegex, "system_uuid", req.SystemUuid, "site", req.Site),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "purge inventories: %v", err)
+	}
+
+	if !req.DryRun {
+		slog.Info("Purged inventories (admin request)", "purged", res.Inventories, "alerts", res.Alerts, "hosts", res.Hosts,
+			"older_than_days", req.OlderThanDays, "hostname", req.Hostname, "hostname_regex", req.HostnameRegex,
+			"system_uuid", req.SystemUuid, "site", req.Site)
+	}
+
+	return &collectorv1.PurgeInventoriesResponse{
+		Purged:       res.Inventories,
+		AlertsPurged: res.Alerts,
+		DryRun:       req.DryRun,
+		HostsPurged:  res.Hosts,
+	}, nil
+}
+
+func (a *AdminHandler) RefreshInventory(ctx context.Context, req *collectorv1.RefreshInventoryRequest) (*collectorv1.RefreshInventoryResponse, error) {
+	return a.h.RefreshInventory(ctx, req)
+}
+
+func (a *AdminHandler) ListConnectedAgents(ctx context.Context, req *collectorv1.ListConnectedAgentsRequest) (*collectorv1.ListConnectedAgentsResponse, error) {
+	return a.h.ListConnectedAgents(ctx, req)
+}
+
+func (a *AdminHandler) WatchAgents(req *collectorv1.WatchAgentsRequest, stream grpc.ServerStreamingServer[collectorv1.AgentEvent]) error {
+	ctx := stream.Context()
+	sites, err := tenant.Filter(ctx, req.Site)
+	if err != nil {
+		return err
+	}
+	watched := func(site string) bool {
+		return sites == nil || slices.Contains(sites, site)
+	}
+
+	snapshot, events, stop := a.h.cmdReg.Watch()
+	defer stop()
+
+	for _, agent := range snapshot {
+		if !watched(agent.Site) {
+			continue
+		}
+		err := stream.Send(&collectorv1.AgentEvent{
+			Type:     collectorv1.AgentEventType_AGENT_EVENT_TYPE_CONNECTED,
+			Agent:    connectedAgentToProto(agent),
+			Time:     timestamppb.New(agent.ConnectedAt),
+			Snapshot: true,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case ev, ok := <-events:
+			if !ok {
+				return status.Error(codes.ResourceExhausted, "watcher fell behind the agent events; watch again")
+			}
+			if !watched(ev.Agent.Site) {
+				continue
+			}
+			err := stream.Send(&collectorv1.AgentEvent{
+				Type:  ev.Type,
+				Agent: connectedAgentToProto(ev.Agent),
+				Time:  timestamppb.New(ev.Time),
+			})
+			if err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (a *AdminHandler) PauseAgent(ctx context.Context, req *collectorv1.PauseAgentRequest) (*collectorv1.PauseAgentResponse, error) {
+	return a.h.PauseAgent(ctx, req)
+}
+
+func (a *AdminHandler) ResumeAgent(ctx context.Context, req *collectorv1.ResumeAgentRequest) (*collectorv1.ResumeAgentResponse, error) {
+	return a.h.ResumeAgent(ctx, req)
+}
+
+func (a *AdminHandler) AdvertiseAgentUpdate(ctx context.Context, req *collectorv1.AdvertiseAgentUpdateRequest) (*collectorv1.AdvertiseAgentUpdateResponse, error) {
+	if _, restricted := tenant.FromContext(ctx); restricted {
+		return nil, status.Error(codes.PermissionDenied, "agent updates require an unrestricted credential")
+	}
+
+	u := req.Update
+	if u.GetVersion() == "" {
+		a.h.cmdReg.AdvertiseUpdate(nil, "")
+		slog.Info("Agent update advertisement cleared")
+		return &collectorv1.AdvertiseAgentUpdateResponse{}, nil
+	}
+
+	if err := validateUpdate(u); err != nil {
+		return nil, err
+	}
+
+	var notified int32
+	for _, agent := range a.h.cmdReg.AdvertiseUpdate(u, req.Site) {
+		if err := a.h.cmdReg.Send(agent.Site, agent.ClientID, newUpdateCommand(u)); err != nil {
+			slog.Warn("Update command failed", "client_id", agent.ClientID, "site", agent.Site, logging.Err(err))
+			continue
+		}
+		notified++
+	}
+
+	slog.Info("Advertised agent update", "version", u.Version, "site", req.Site, "notified", notified)
+
+	return &collectorv1.AdvertiseAgentUpdateResponse{Notified: notified}, nil
+}
+
+func (a *AdminHandler) CreateToken(ctx context.Context, req *collectorv1.CreateTokenRequest) (*collectorv1.CreateTokenResponse, error) {
+	if _, restricted := tenant.FromContext(ctx); restricted {
+		return nil, status.Error(codes.PermissionDenied, "token management requires an unrestricted credential")
+	}
+
+	switch req.Role {
+	case RoleAgent, RoleAPI, RoleAdmin:
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown role %q (use agent, api or admin)", req.Role)
+	}
+	if req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	if req.Role == RoleAdmin && len(req.Sites) > 0 {
+		return nil, status.Error(codes.InvalidArgument, "admin tokens cannot be restricted to sites")
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.AsTime().After(time.Now()) {
+		return nil, status.Error(codes.InvalidArgument, "expires_at must be in the future")
+	}
+
+	secret, hash, err := newToken()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "create token: %v", err)
+	}
+	rec := &store.TokenRecord{Name: req.Name, Role: req.Role, Sites: req.Sites, Hash: hash}
+	if req.ExpiresAt != nil {
+		rec.ExpiresAt = req.ExpiresAt.AsTime()
+	}
+	if err := a.h.store.InsertToken(ctx, rec); err != nil {
+		return nil, status.Errorf(codes.Internal, "create token: %v", err)
+	}
+	if err := a.tokens.reload(ctx); err != nil {
+		return nil, status.Errorf(codes.Internal, "create token: %v", err)
+	}
+
+	slog.Info("API token created", "token_id", rec.ID, "name", rec.Name, "role", rec.Role)
+
+	return &collectorv1.CreateTokenResponse{Token: convert.TokenToProto(rec), Secret: secret}, nil
+}
+
+func (a *AdminHandler) ListTokens(ctx context.Context, _ *collectorv1.ListTokensRequest) (*collectorv1.ListTokensResponse, error) {
+	if _, restricted := tenant.FromContext(ctx); restricted {
+		return nil, status.Error(codes.PermissionDenied, "token management requires an unrestricted credential")
+	}
+
+	tokens, err := a.h.store.ListTokens(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list tokens: %v", err)
+	}
+	resp := &collectorv1.ListTokensResponse{Tokens: make([]*collectorv1.ApiToken, len(tokens))}
+	for i := range tokens {
+		resp.Tokens[i] = convert.TokenToProto(&tokens[i])
+	}
+	return resp, nil
+}
+
+func (a *AdminHandler) RevokeToken(ctx context.Context, req *collectorv1.RevokeTokenRequest) (*collectorv1.RevokeTokenResponse, error) {
+	if _, restricted := tenant.FromContext(ctx); restricted {
+		return nil, status.Error(codes.PermissionDenied, "token management requires an unrestricted credential")
+	}
+
+	if err := a.h.store.RevokeToken(ctx, req.Id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "token %d not found or already revoked", req.Id)
+		}
+		return nil, status.Errorf(codes.Internal, "revoke token: %v", err)
+	}
+	if err := a.tokens.reload(ctx); err != nil {
+		return nil, status.Errorf(codes.Internal, "revoke token: %v", err)
+	}
+
+	slog.Info("API token revoked", "token_id", req.Id)
+
+	return &collectorv1.RevokeTokenResponse{}, nil
+}
+
+func (a *AdminHandler) ListWebhookDeliveries(ctx context.Context, req *collectorv1.ListWebhookDeliveriesRequest) (*collectorv1.ListWebhookDeliveriesResponse, error) {
+	if _, restricted := tenant.FromContext(ctx); restricted {
+		return nil, status.Error(codes.PermissionDenied, "webhook deliveries require an unrestricted credential")
+	}
+	switch req.Status {
+	case "", store.DeliveryPending, store.DeliveryDelivered, store.DeliveryFailed:
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown status %q (use pending, delivered or failed)", req.Status)
+	}
+
+	deliveries, total, err := a.h.store.ListWebhookDeliveries(ctx, store.WebhookDeliveryFilter{
+		Status:   req.Status,
+		PageSize: int(req.PageSize),
+		Page:     int(req.Page),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list webhook deliveries: %v", err)
+	}
+	resp := &collectorv1.ListWebhookDeliveriesResponse{
+		Deliveries: make([]*collectorv1.WebhookDelivery, len(deliveries)),
+		TotalCount: int32(total),
+	}
+	for i := range deliveries {
+		resp.Deliveries[i] = convert.DeliveryToProto(&deliveries[i])
+	}
+	return resp, nil
+}
+
+func (a *AdminHandler) ReplayWebhookDelivery(ctx context.Context, req *collectorv1.ReplayWebhookDeliveryRequest) (*collectorv1.ReplayWebhookDeliveryResponse, error) {
+	if _, restricted := tenant.FromContext(ctx); restricted {
+		return nil, status.Error(codes.PermissionDenied, "webhook deliveries require an unrestricted credential")
+	}
+	if a.h.alerts == nil {
+		return nil, status.Error(codes.FailedPrecondition, "alerting is disabled")
+	}
+
+	d, err := a.h.alerts.ReplayWebhook(ctx, req.Id)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, status.Errorf(codes.NotFound, "webhook delivery %d not found", req.Id)
+	case errors.Is(err, alert.ErrNotFailed), errors.Is(err, alert.ErrUnknownEndpoint):
+		return nil, status.Errorf(codes.FailedPrecondition, "webhook delivery %d: %v", req.Id, err)
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "replay webhook delivery: %v", err)
+	}
+
+	slog.Info("Webhook delivery replayed", "delivery_id", d.ID, "status", d.Status)
+
+	return &collectorv1.ReplayWebhookDeliveryResponse{Delivery: convert.DeliveryToProto(d)}, nil
+}
+
+// validateUpdate checks that u describes a release agents can install.
+func validateUpdate(u *collectorv1.AgentUpdate) error {
+	switch {
+	case u.GetVersion() == "":
+		return status.Error(codes.InvalidArgument, "update.version is required")
+	case u.DownloadUrl == "":
+		return status.Error(codes.InvalidArgument, "update.download_url is required")
+	case len(u.Sha256) != 64:
+		return status.Error(codes.InvalidArgument, "update.sha256 must be a hex SHA-256 digest")
+	case u.Os == "" || u.Arch == "":
+		return status.Error(codes.InvalidArgument, "update.os and update.arch are required")
+	case len(u.Signature) == 0:
+		return status.Error(codes.InvalidArgument, "update.signature is required")
+	}
+	return nil
+}
+
+func newUpdateCommand(u *collectorv1.AgentUpdate) *collectorv1.InventoryCommand {
+	return &collectorv1.InventoryCommand{
+		CommandId:   uuid.NewString(),
+		CommandType: collectorv1.InventoryCommandType_INVENTORY_COMMAND_TYPE_UPDATE,
+		Update:      u,
+	}
+}
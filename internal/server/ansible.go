@@ -0,0 +1,52 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-tangra/go-tangra-inventory/internal/ansible"
+	"github.com/go-tangra/go-tangra-inventory/internal/logging"
+	"github.com/go-tangra/go-tangra-inventory/internal/store"
+	"github.com/go-tangra/go-tangra-inventory/internal/tenant"
+
+	"google.golang.org/grpc/status"
+)
+
+// ansiblePath is where the Ansible dynamic inventory is served, below the
+// HTTP base path.
+const ansiblePath = "/v1/ansible/inventory"
+
+// newAnsibleHandler serves the Ansible dynamic inventory of the latest
+// inventory of each host, optionally restricted to the site query parameter.
+// It is registered outside the Kratos middleware chain, so it is guarded by
+// apiKeyGuard.
+func newAnsibleHandler(db *store.Store, apiSecret string, siteTokens tenant.Tokens, apiTokens *APITokens) http.Handler {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sites, err := tenant.Filter(r.Context(), r.URL.Query().Get("site"))
+		if err != nil {
+			http.Error(w, status.Convert(err).Message(), http.StatusForbidden)
+			return
+		}
+		facts, err := db.ListHostFacts(r.Context(), sites)
+		if err != nil {
+			slog.Error("Building Ansible inventory failed", logging.Err(err))
+			http.Error(w, "list hosts failed", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(ansible.Build(facts)); err != nil {
+			slog.Debug("Writing Ansible inventory failed", logging.Err(err))
+		}
+	})
+	guarded := apiKeyGuard(h, ansiblePath, apiSecret, siteTokens, apiTokens)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		guarded.ServeHTTP(w, r)
+	})
+}
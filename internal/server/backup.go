@@ -0,0 +1,77 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/go-tangra/go-tangra-inventory/internal/backup"
+	"github.com/go-tangra/go-tangra-inventory/internal/config"
+	"github.com/go-tangra/go-tangra-inventory/internal/logging"
+	"github.com/go-tangra/go-tangra-inventory/internal/objstore"
+	"github.com/go-tangra/go-tangra-inventory/internal/store"
+)
+
+// NewBackuper builds the scheduled object storage backup from config, or
+// returns nil when it is disabled.
+func NewBackuper(cfg *config.Config, db *store.Store) (*backup.Backuper, error) {
+	if cfg.BackupURL == "" {
+		return nil, nil
+	}
+	if cfg.BackupInterval <= 0 {
+		return nil, errors.New("backup_interval: must be positive")
+	}
+	if cfg.BackupKeepSnapshots < 0 {
+		return nil, errors.New("backup_keep_snapshots: must not be negative")
+	}
+	if cfg.BackupRetentionDays < 0 {
+		return nil, errors.New("backup_retention_days: must not be negative")
+	}
+
+	bucket, err := objstore.Open(cfg.BackupURL, objstore.Options{
+		Endpoint:        cfg.BackupEndpoint,
+		Region:          cfg.BackupRegion,
+		AccessKeyID:     cfg.BackupAccessKeyID,
+		SecretAccessKey: cfg.BackupSecretAccessKey,
+		SASToken:        cfg.BackupSASToken,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("backup_url: %w", err)
+	}
+	return backup.New(bucket, db, backup.Retention{
+		KeepSnapshots: cfg.BackupKeepSnapshots,
+		MaxAge:        time.Duration(cfg.BackupRetentionDays) * 24 * time.Hour,
+	}), nil
+}
+
+// runBackupLoop backs up every interval, counted from the newest snapshot
+// in the bucket, so that restarts neither skip nor repeat backups.
+func runBackupLoop(ctx context.Context, b *backup.Backuper, interval time.Duration) {
+	next := time.Now()
+	if last, err := b.LastSnapshot(ctx); err != nil {
+		slog.Error("Listing backups failed", logging.Err(err))
+	} else if !last.IsZero() {
+		next = last.Add(interval)
+	}
+
+	for {
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		res, err := b.Run(ctx)
+		if err != nil && ctx.Err() == nil {
+			slog.Error("Backup failed", logging.Err(err))
+		} else if err == nil {
+			slog.Info("Uploaded backup", "snapshot", res.Snapshot, "size", res.SnapshotSize,
+				"segments", len(res.Segments), "inventories", res.Inventories, "deleted", res.Deleted)
+		}
+		next = time.Now().Add(interval)
+	}
+}
@@ -0,0 +1,151 @@
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-tangra/go-tangra-inventory/internal/config"
+	"github.com/go-tangra/go-tangra-inventory/internal/logging"
+)
+
+// CheckConfig reports the problems in cfg that would keep the collector
+// from starting or from serving as configured. It opens neither listeners
+// nor the database, so it can run next to a serving collector.
+func CheckConfig(cfg *config.Config) []error {
+	var errs []error
+	check := func(err error) {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	addrs := map[string]string{}
+	for _, l := range []struct{ key, addr string }{
+		{"listen", cfg.Listen},
+		{"http_listen", cfg.HTTPListen},
+		{"admin_listen", cfg.AdminListen},
+	} {
+		if l.addr == "" && l.key == "admin_listen" {
+			continue
+		}
+		if err := checkListenAddr(l.addr); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", l.key, err))
+			continue
+		}
+		if other, dup := addrs[l.addr]; dup {
+			errs = append(errs, fmt.Errorf("%s: %s is also used by %s", l.key, l.addr, other))
+		}
+		addrs[l.addr] = l.key
+	}
+
+	if tlsCfg, err := serverTLS(cfg); err != nil {
+		errs = append(errs, err)
+	} else {
+		if tlsCfg != nil {
+			check(checkValidity(cfg.TLSCertFile, tlsCfg.Certificates[0]))
+		}
+		if cfg.AdminListen != "" && checkListenAddr(cfg.AdminListen) == nil {
+			check(checkAdminTLS(cfg.AdminListen, tlsCfg))
+		}
+	}
+
+	_, err := newSiteTokens(cfg.SiteTokens)
+	check(err)
+	_, err = NewIPFilter(cfg.AgentAllowCIDRs, cfg.AgentDenyCIDRs)
+	check(err)
+	_, err = SwaggerAuth(cfg.SwaggerAuth, cfg.SwaggerUsername, cfg.SwaggerPassword, cfg.ApiSecret)
+	check(err)
+	_, err = NewSnipeITSyncer(cfg, nil)
+	check(err)
+	_, err = NewNetBoxSyncer(cfg, nil)
+	check(err)
+	_, err = newEventRelays(cfg, nil)
+	check(err)
+	_, err = newAlertEngine(cfg, nil)
+	check(err)
+	_, err = NewBackuper(cfg, nil)
+	check(err)
+	_, err = NewDirectoryEnricher(cfg, nil)
+	check(err)
+	_, _, err = NewReportJobs(cfg)
+	check(err)
+	_, err = NewSNMPPoller(cfg, nil)
+	check(err)
+	_, err = logging.Options{Level: cfg.LogLevel, Format: cfg.LogFormat}.NewHandler(io.Discard, false)
+	check(err)
+
+	if cfg.RetentionDays < 0 {
+		errs = append(errs, errors.New("retention_days: must not be negative"))
+	}
+	if cfg.RetentionDays > 0 && cfg.PurgeInterval <= 0 {
+		errs = append(errs, errors.New("purge_interval: must be positive when retention_days is set"))
+	}
+	if cfg.DatabasePath == "" {
+		errs = append(errs, errors.New("database: path is required"))
+	}
+
+	webhooks := []struct{ key, url string }{
+		{"alert_webhook_url", cfg.AlertWebhookURL},
+		{"alert_slack_webhook_url", cfg.AlertSlackWebhookURL},
+	}
+	for i, w := range cfg.AlertWebhooks {
+		webhooks = append(webhooks, struct{ key, url string }{fmt.Sprintf("alert_webhooks[%d].url", i), w.URL})
+	}
+	for _, w := range webhooks {
+		if w.url == "" {
+			continue
+		}
+		if u, err := url.Parse(w.url); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, fmt.Errorf("%s: not an http or https URL", w.key))
+		}
+	}
+	return errs
+}
+
+// checkListenAddr checks that addr is a valid TCP host:port or a usable
+// unix:// socket path, as accepted by listen.
+func checkListenAddr(addr string) error {
+	if !isUnixAddr(addr) {
+		_, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return err
+		}
+		if _, err := net.LookupPort("tcp", port); err != nil {
+			return err
+		}
+		return nil
+	}
+
+	path := strings.TrimPrefix(addr, unixScheme)
+	if path == "" {
+		return fmt.Errorf("empty unix socket path in %q", addr)
+	}
+	if fi, err := os.Stat(path); err == nil && fi.Mode()&fs.ModeSocket == 0 {
+		return fmt.Errorf("%s exists and is not a socket", path)
+	}
+	return nil
+}
+
+// checkValidity checks that the certificate loaded from certFile is valid
+// now.
+func checkValidity(certFile string, cert tls.Certificate) error {
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return fmt.Errorf("parse TLS certificate: %w", err)
+	}
+	if now := time.Now(); now.After(leaf.NotAfter) {
+		return fmt.Errorf("TLS certificate %s expired on %s", certFile, leaf.NotAfter.Format(time.DateOnly))
+	} else if now.Before(leaf.NotBefore) {
+		return fmt.Errorf("TLS certificate %s is not valid before %s", certFile, leaf.NotBefore.Format(time.DateOnly))
+	}
+	return nil
+}
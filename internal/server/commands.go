@@ -0,0 +1,151 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+	"github.com/go-tangra/go-tangra-inventory/internal/tenant"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// maxCommandWait bounds how long SendCommand waits for an acknowledgement.
+const maxCommandWait = 5 * time.Minute
+
+// awaited matches the replies agents send for commands to the callers
+// waiting for them, by command ID and the agent the command was sent to.
+type awaited[T any] struct {
+	mu      sync.Mutex
+	pending map[string]awaitedReply[T]
+}
+
+type awaitedReply[T any] struct {
+	site     string
+	clientID string
+	ch       chan T
+}
+
+func newAwaited[T any]() *awaited[T] {
+	return &awaited[T]{pending: make(map[string]awaitedReply[T])}
+}
+
+// expect registers a command sent to agent clientID of site and returns the
+// channel its reply is delivered on, until done is called.
+func (a *awaited[T]) expect(cmdID, site, clientID string) (reply <-chan T, done func()) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	ch := make(chan T, 1)
+	a.pending[cmdID] = awaitedReply[T]{site: site, clientID: clientID, ch: ch}
+	return ch, func() {
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		delete(a.pending, cmdID)
+	}
+}
+
+// deliver hands v, sent by agent clientID, to the caller waiting for the
+// reply to cmdID and reports whether there was one that this agent, in the
+// sites of ctx, may answer.
+func (a *awaited[T]) deliver(ctx context.Context, cmdID, clientID string, v T) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	p, ok := a.pending[cmdID]
+	if !ok || p.clientID != clientID || !tenant.Allowed(ctx, p.site) {
+		return false
+	}
+	delete(a.pending, cmdID)
+	p.ch <- v
+	return true
+}
+
+func (h *Handler) AckCommand(ctx context.Context, req *collectorv1.AckCommandRequest) (*collectorv1.AckCommandResponse, error) {
+	if req.CommandId == "" {
+		return nil, status.Error(codes.InvalidArgument, "command_id is required")
+	}
+	// Most commands are not waited for; their acknowledgements are dropped.
+	h.acks.deliver(ctx, req.CommandId, req.ClientId, req)
+	return &collectorv1.AckCommandResponse{}, nil
+}
+
+func (a *AdminHandler) SendCommand(ctx context.Context, req *collectorv1.SendCommandRequest) (*collectorv1.SendCommandResponse, error) {
+	if req.Command == nil {
+		return nil, status.Error(codes.InvalidArgument, "command is required")
+	}
+	if req.WaitMs < 0 {
+		return nil, status.Error(codes.InvalidArgument, "wait_ms must not be negative")
+	}
+	wait := min(time.Duration(req.WaitMs)*time.Millisecond, maxCommandWait)
+
+	cmdType := req.Command.CommandType
+	switch cmdType {
+	case collectorv1.InventoryCommandType_INVENTORY_COMMAND_TYPE_LOGS:
+		return nil, status.Error(codes.InvalidArgument, "logs commands are sent with GetAgentLogs")
+	case collectorv1.InventoryCommandType_INVENTORY_COMMAND_TYPE_UPDATE:
+		if _, restricted := tenant.FromContext(ctx); restricted {
+			return nil, status.Error(codes.PermissionDenied, "agent updates require an unrestricted credential")
+		}
+		if err := validateUpdate(req.Command.Update); err != nil {
+			return nil, err
+		}
+	default:
+		if _, ok := collectorv1.InventoryCommandType_name[int32(cmdType)]; !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown command type %d", cmdType)
+		}
+	}
+
+	site, clientID, err := a.h.resolveAgent(ctx, req.Site, req.ClientId, req.Hostname)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := proto.Clone(req.Command).(*collectorv1.InventoryCommand)
+	cmd.CommandId = uuid.NewString()
+	kind := strings.ToLower(strings.TrimPrefix(cmdType.String(), "INVENTORY_COMMAND_TYPE_"))
+
+	var ack <-chan *collectorv1.AckCommandRequest
+	if wait > 0 {
+		var done func()
+		ack, done = a.h.acks.expect(cmd.CommandId, site, clientID)
+		defer done()
+	}
+
+	sent := time.Now()
+	if err := a.h.cmdReg.Send(site, clientID, cmd); err != nil {
+		return nil, status.Errorf(codes.Internal, "send %s command: %v", kind, err)
+	}
+	switch cmdType {
+	case collectorv1.InventoryCommandType_INVENTORY_COMMAND_TYPE_PAUSE:
+		a.h.cmdReg.SetPaused(site, clientID, true)
+	case collectorv1.InventoryCommandType_INVENTORY_COMMAND_TYPE_RESUME:
+		a.h.cmdReg.SetPaused(site, clientID, false)
+	}
+
+	slog.Info("Sent "+kind+" command", "client_id", clientID, "site", site, "command_id", cmd.CommandId)
+
+	resp := &collectorv1.SendCommandResponse{CommandId: cmd.CommandId, ClientId: clientID}
+	if wait == 0 {
+		return resp, nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case r := <-ack:
+		resp.Acknowledged = true
+		resp.Error = r.Error
+		resp.DurationMs = time.Since(sent).Milliseconds()
+	case <-timer.C:
+	case <-ctx.Done():
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+	return resp, nil
+}
@@ -0,0 +1,45 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-tangra/go-tangra-inventory/internal/tenant"
+)
+
+// TestAwaitedDeliver checks that a reply is only handed over when it comes
+// from the agent the command was sent to.
+func TestAwaitedDeliver(t *testing.T) {
+	a := newAwaited[string]()
+	reply, done := a.expect("cmd-1", "customer-a", "pc-042")
+	defer done()
+
+	agentCtx := tenant.NewContext(context.Background(), []string{"customer-a"})
+	otherSite := tenant.NewContext(context.Background(), []string{"customer-b"})
+
+	for _, tt := range []struct {
+		name     string
+		ctx      context.Context
+		cmdID    string
+		clientID string
+	}{
+		{"other agent of the site", agentCtx, "cmd-1", "pc-043"},
+		{"no client ID", agentCtx, "cmd-1", ""},
+		{"other site", otherSite, "cmd-1", "pc-042"},
+		{"unknown command", agentCtx, "cmd-2", "pc-042"},
+	} {
+		if a.deliver(tt.ctx, tt.cmdID, tt.clientID, tt.name) {
+			t.Errorf("%s: reply delivered", tt.name)
+		}
+	}
+
+	if !a.deliver(agentCtx, "cmd-1", "pc-042", "ok") {
+		t.Fatal("reply of the target agent not delivered")
+	}
+	if got := <-reply; got != "ok" {
+		t.Errorf("received %q, want %q", got, "ok")
+	}
+	if a.deliver(agentCtx, "cmd-1", "pc-042", "again") {
+		t.Error("second reply delivered")
+	}
+}
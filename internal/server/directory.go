@@ -0,0 +1,86 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/go-tangra/go-tangra-inventory/internal/config"
+	"github.com/go-tangra/go-tangra-inventory/internal/directory"
+	"github.com/go-tangra/go-tangra-inventory/internal/logging"
+	"github.com/go-tangra/go-tangra-inventory/internal/store"
+)
+
+// NewDirectoryEnricher builds the Active Directory / LDAP enrichment of host
+// records from config, or returns nil when it is disabled.
+func NewDirectoryEnricher(cfg *config.Config, db *store.Store) (*directory.Enricher, error) {
+	if cfg.LDAPURL == "" {
+		return nil, nil
+	}
+	if cfg.LDAPInterval <= 0 {
+		return nil, errors.New("ldap_interval: must be positive")
+	}
+	if cfg.LDAPBindDN != "" && cfg.LDAPBindPassword == "" {
+		return nil, errors.New("ldap_bind_password: required with ldap_bind_dn")
+	}
+
+	tlsCfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if cfg.LDAPCAFile != "" {
+		pem, err := os.ReadFile(cfg.LDAPCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("ldap_ca_file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("ldap_ca_file: no certificates found in %s", cfg.LDAPCAFile)
+		}
+		tlsCfg.RootCAs = pool
+	}
+
+	enricher, err := directory.New(directory.Config{
+		URL:            cfg.LDAPURL,
+		StartTLS:       cfg.LDAPStartTLS,
+		TLS:            tlsCfg,
+		BindDN:         cfg.LDAPBindDN,
+		BindPassword:   cfg.LDAPBindPassword,
+		BaseDN:         cfg.LDAPBaseDN,
+		ComputerFilter: cfg.LDAPComputerFilter,
+		UserFilter:     cfg.LDAPUserFilter,
+		Attributes: directory.Attributes{
+			Description: cfg.LDAPAttributes.Description,
+			DisplayName: cfg.LDAPAttributes.DisplayName,
+			Department:  cfg.LDAPAttributes.Department,
+		},
+	}, db)
+	if err != nil {
+		return nil, fmt.Errorf("ldap: %w", err)
+	}
+	return enricher, nil
+}
+
+// runDirectoryLoop enriches the host records at startup and then every
+// interval.
+func runDirectoryLoop(ctx context.Context, enricher *directory.Enricher, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		res, err := enricher.Enrich(ctx)
+		if err != nil && ctx.Err() == nil {
+			slog.Error("Directory enrichment failed", logging.Err(err))
+		} else if err == nil {
+			slog.Info("Enriched hosts from the directory", "hosts", res.Hosts, "computers", res.Computers, "users", res.Users)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"regexp"
+	"strings"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+	"github.com/go-tangra/go-tangra-inventory/internal/store"
+	"github.com/go-tangra/go-tangra-inventory/internal/tenant"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Limits of EnrichHosts requests.
+const (
+	maxEnrichHosts      = 1000
+	maxFieldsPerHost    = 50
+	maxFieldValueLength = 1024
+)
+
+// fieldName is the form of custom field names.
+var fieldName = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+func (a *AdminHandler) EnrichHosts(ctx context.Context, req *collectorv1.EnrichHostsRequest) (*collectorv1.EnrichHostsResponse, error) {
+	var lookup func(context.Context, string, []string) (*store.InventoryRecord, error)
+	switch req.Key {
+	case "serial":
+		lookup = a.h.store.GetLatestBySerial
+	case "uuid":
+		lookup = a.h.store.GetLatestBySystemUUID
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown key %q (use serial or uuid)", req.Key)
+	}
+	if len(req.Hosts) > maxEnrichHosts {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d hosts per request", maxEnrichHosts)
+	}
+	for i, e := range req.Hosts {
+		if len(e.Fields) > maxFieldsPerHost {
+			return nil, status.Errorf(codes.InvalidArgument, "hosts[%d]: at most %d fields", i, maxFieldsPerHost)
+		}
+		for name, value := range e.Fields {
+			if !fieldName.MatchString(name) {
+				return nil, status.Errorf(codes.InvalidArgument, "hosts[%d]: invalid field name %q (use lower-case letters, digits and underscores)", i, name)
+			}
+			if len(value) > maxFieldValueLength {
+				return nil, status.Errorf(codes.InvalidArgument, "hosts[%d]: field %s is longer than %d bytes", i, name, maxFieldValueLength)
+			}
+		}
+	}
+	sites, err := tenant.Filter(ctx, req.Site)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &collectorv1.EnrichHostsResponse{DryRun: req.DryRun}
+	for _, e := range req.Hosts {
+		key := strings.TrimSpace(e.Key)
+		if req.Key == "uuid" {
+			// Agents report system UUIDs in lower case.
+			key = strings.ToLower(key)
+		}
+		if key == "" {
+			resp.Unmatched = append(resp.Unmatched, e.Key)
+			continue
+		}
+		rec, err := lookup(ctx, key, sites)
+		if errors.Is(err, sql.ErrNoRows) {
+			resp.Unmatched = append(resp.Unmatched, e.Key)
+			continue
+		}
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "enrich hosts: %v", err)
+		}
+		resp.Matched++
+		if req.DryRun {
+			continue
+		}
+		if err := a.h.store.SetHostFields(ctx, rec.Site, rec.Hostname, e.Fields); err != nil {
+			return nil, status.Errorf(codes.Internal, "enrich hosts: %v", err)
+		}
+	}
+
+	if !req.DryRun {
+		slog.Info("Enriched hosts (admin request)", "key", req.Key, "matched", resp.Matched,
+			"unmatched", len(resp.Unmatched), "site", req.Site)
+	}
+
+	return resp, nil
+}
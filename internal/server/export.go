@@ -0,0 +1,160 @@
+package server
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"iter"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/go-tangra/go-tangra-inventory/internal/convert"
+	"github.com/go-tangra/go-tangra-inventory/internal/export"
+	"github.com/go-tangra/go-tangra-inventory/internal/logging"
+	"github.com/go-tangra/go-tangra-inventory/internal/store"
+	"github.com/go-tangra/go-tangra-inventory/internal/tenant"
+
+	"google.golang.org/grpc/status"
+)
+
+// exportPath is where inventories are exported, below the HTTP base path.
+const exportPath = "/v1/export/inventories"
+
+// exportFlushEvery is the number of inventories written between flushes
+// of the response.
+const exportFlushEvery = 100
+
+// newExportHandler streams the inventories matching the query parameters,
+// newest first, as NDJSON or, with format=csv, CSV in the formats of
+// "inventoryctl export". They are read from the store and written out a
+// page at a time, so that the size of an export is not bounded by memory.
+// It is registered outside the Kratos middleware chain, so it is guarded by
+// apiKeyGuard.
+func newExportHandler(db *store.Store, apiSecret string, siteTokens tenant.Tokens, apiTokens *APITokens) http.Handler {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		format := q.Get("format")
+		if format == "" {
+			format = "ndjson"
+		}
+		if format != "ndjson" && format != "csv" {
+			http.Error(w, fmt.Sprintf("unknown format %q (use ndjson or csv)", format), http.StatusBadRequest)
+			return
+		}
+		sites, err := tenant.Filter(r.Context(), q.Get("site"))
+		if err != nil {
+			http.Error(w, status.Convert(err).Message(), http.StatusForbidden)
+			return
+		}
+		f := store.ListFilter{
+			Sites:       sites,
+			Hostname:    q.Get("hostname"),
+			Username:    q.Get("username"),
+			SystemUUID:  q.Get("system_uuid"),
+			DeviceClass: q.Get("device_class"),
+			WithJSON:    true,
+		}
+		if f.CollectedAfter, err = timeParam(q, "collected_after"); err == nil {
+			f.CollectedBefore, err = timeParam(q, "collected_before")
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if format == "csv" {
+			w.Header().Set("Content-Type", "text/csv")
+		} else {
+			w.Header().Set("Content-Type", "application/x-ndjson")
+		}
+		w.Header().Set("Content-Disposition", `attachment; filename="inventories.`+format+`"`)
+
+		n, err := writeExport(w, db.Iterate(r.Context(), f), format)
+		if err != nil {
+			if r.Context().Err() != nil {
+				return
+			}
+			slog.Error("Exporting inventories failed", "exported", n, logging.Err(err))
+			if n == 0 {
+				http.Error(w, "export failed", http.StatusInternalServerError)
+				return
+			}
+			// Break off the response, so that the client does not take
+			// the truncated export for a complete one.
+			panic(http.ErrAbortHandler)
+		}
+	})
+	guarded := apiKeyGuard(h, exportPath, apiSecret, siteTokens, apiTokens)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		guarded.ServeHTTP(w, r)
+	})
+}
+
+// writeExport writes the records to w in format, flushing the response
+// every exportFlushEvery records, and returns the number written.
+func writeExport(w http.ResponseWriter, records iter.Seq2[*store.InventoryRecord, error], format string) (int, error) {
+	rc := http.NewResponseController(w)
+	var cw *csv.Writer
+	if format == "csv" {
+		cw = csv.NewWriter(w)
+		cw.Write(export.CSVHeader)
+	}
+	flush := func() error {
+		if cw != nil {
+			cw.Flush()
+			if err := cw.Error(); err != nil {
+				return err
+			}
+		}
+		if err := rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			return err
+		}
+		return nil
+	}
+
+	var n int
+	for rec, err := range records {
+		if err != nil {
+			return n, err
+		}
+		summary := convert.RecordToSummary(rec)
+		if summary.Inventory, err = convert.RecordToInventory(rec); err != nil {
+			return n, fmt.Errorf("inventory %d: %w", rec.ID, err)
+		}
+		if cw != nil {
+			err = cw.Write(export.CSVRow(summary))
+		} else {
+			err = export.WriteNDJSON(w, summary)
+		}
+		if err != nil {
+			return n, err
+		}
+		if n++; n%exportFlushEvery == 0 {
+			if err := flush(); err != nil {
+				return n, err
+			}
+		}
+	}
+	return n, flush()
+}
+
+// timeParam parses the RFC 3339 time of the query parameter name, if given.
+func timeParam(q url.Values, name string) (*time.Time, error) {
+	v := q.Get(name)
+	if v == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", name, err)
+	}
+	return &t, nil
+}
@@ -0,0 +1,59 @@
+package server
+
+import (
+	"sync"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+)
+
+// inventoryFeed fans stored inventories out to WatchInventories streams.
+type inventoryFeed struct {
+	mu       sync.Mutex
+	watchers map[chan *collectorv1.InventorySubmission]struct{}
+}
+
+func newInventoryFeed() *inventoryFeed {
+	return &inventoryFeed{watchers: make(map[chan *collectorv1.InventorySubmission]struct{})}
+}
+
+// watched reports whether anyone is watching, so that submissions need
+// not be diffed otherwise.
+func (f *inventoryFeed) watched() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.watchers) > 0
+}
+
+// watch returns a channel of the submissions stored from now on, until
+// stop is called. A watcher that falls behind by more than
+// watchChannelBufferSize submissions has its channel closed.
+func (f *inventoryFeed) watch() (submissions <-chan *collectorv1.InventorySubmission, stop func()) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ch := make(chan *collectorv1.InventorySubmission, watchChannelBufferSize)
+	f.watchers[ch] = struct{}{}
+	return ch, func() {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if _, ok := f.watchers[ch]; ok {
+			delete(f.watchers, ch)
+			close(ch)
+		}
+	}
+}
+
+// publish sends s to the watchers, which must not modify it.
+func (f *inventoryFeed) publish(s *collectorv1.InventorySubmission) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for ch := range f.watchers {
+		select {
+		case ch <- s:
+		default:
+			delete(f.watchers, ch)
+			close(ch)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/go-tangra/go-tangra-inventory/internal/grafana"
+	"github.com/go-tangra/go-tangra-inventory/internal/store"
+	"github.com/go-tangra/go-tangra-inventory/internal/tenant"
+)
+
+// grafanaPath is the URL of the Grafana JSON datasource, below the HTTP
+// base path; its endpoints follow it.
+const grafanaPath = "/v1/grafana/"
+
+// newGrafanaHandler serves the Grafana JSON datasource API over the fleet
+// statistics and host timelines. It is registered outside the Kratos
+// middleware chain, so it is guarded by apiKeyGuard.
+func newGrafanaHandler(db *store.Store, apiSecret string, siteTokens tenant.Tokens, apiTokens *APITokens) http.Handler {
+	return apiKeyGuard(grafana.NewHandler(db, grafanaPath), grafanaPath, apiSecret, siteTokens, apiTokens)
+}
@@ -0,0 +1,35 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/go-tangra/go-tangra-inventory/internal/gql"
+	"github.com/go-tangra/go-tangra-inventory/internal/store"
+	"github.com/go-tangra/go-tangra-inventory/internal/tenant"
+
+	"github.com/graph-gophers/graphql-go/relay"
+)
+
+// graphQLPath is where the GraphQL endpoint is mounted, below the HTTP base path.
+const graphQLPath = "/graphql"
+
+// newGraphQLHandler builds the GraphQL endpoint. It is registered outside the
+// Kratos middleware chain, so it is guarded by apiKeyGuard.
+func newGraphQLHandler(db *store.Store, apiSecret string, siteTokens tenant.Tokens, apiTokens *APITokens) (http.Handler, error) {
+	schema, err := gql.NewSchema(db)
+	if err != nil {
+		return nil, err
+	}
+	h := &relay.Handler{Schema: schema}
+
+	guarded := apiKeyGuard(h, graphQLPath, apiSecret, siteTokens, apiTokens)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		guarded.ServeHTTP(w, r)
+	}), nil
+}
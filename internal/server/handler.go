@@ -4,17 +4,26 @@ import (
 	"context"
 	"database/sql"
 	"errors"
-	"log"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 
 	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+	"github.com/go-tangra/go-tangra-inventory/internal/alert"
 	"github.com/go-tangra/go-tangra-inventory/internal/convert"
+	"github.com/go-tangra/go-tangra-inventory/internal/diff"
+	"github.com/go-tangra/go-tangra-inventory/internal/logging"
+	"github.com/go-tangra/go-tangra-inventory/internal/outbox"
 	"github.com/go-tangra/go-tangra-inventory/internal/store"
+	"github.com/go-tangra/go-tangra-inventory/internal/tenant"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -23,39 +32,285 @@ type Handler struct {
 	collectorv1.UnimplementedInventoryCollectorServiceServer
 	store  *store.Store
 	cmdReg *CommandRegistry
+	alerts *alert.Engine
+	events []*outbox.Relay
+	feed   *inventoryFeed
+	logs   *awaited[*collectorv1.SubmitAgentLogsRequest]
+	acks   *awaited[*collectorv1.AckCommandRequest]
+	// writes queues the inserts of submissions and groups them into
+	// transactions; nil inserts each on its own.
+	writes *writeQueue
+	// limits bounds the inventories SubmitInventory accepts.
+	limits submitLimits
 }
 
 // NewHandler creates a new gRPC handler backed by the given store.
-func NewHandler(s *store.Store, reg *CommandRegistry) *Handler {
-	return &Handler{store: s, cmdReg: reg}
+// alerts may be nil to disable hardware change alerting. Submissions are
+// queued for publishing by each of the events relays.
+func NewHandler(s *store.Store, reg *CommandRegistry, alerts *alert.Engine, events []*outbox.Relay) *Handler {
+	return &Handler{
+		store:  s,
+		cmdReg: reg,
+		alerts: alerts,
+		events: events,
+		feed:   newInventoryFeed(),
+		logs:   newAwaited[*collectorv1.SubmitAgentLogsRequest](),
+		acks:   newAwaited[*collectorv1.AckCommandRequest](),
+	}
 }
 
 func (h *Handler) SubmitInventory(ctx context.Context, req *collectorv1.SubmitInventoryRequest) (*collectorv1.SubmitInventoryResponse, error) {
-	if req.Inventory == nil {
-		return nil, status.Error(codes.InvalidArgument, "inventory is required")
+	if err := validateInventory(req.Inventory, h.limits, time.Now()); err != nil {
+		return nil, err
 	}
-	if req.Inventory.Hostname == "" {
-		return nil, status.Error(codes.InvalidArgument, "hostname is required")
+	if id := req.SubmissionUuid; id != "" && !uuidPattern.MatchString(id) {
+		return nil, status.Errorf(codes.InvalidArgument, "submission_uuid %q is not a UUID", id)
+	}
+	if h.writes != nil && h.writes.full() {
+		h.writes.rejected.Add(1)
+		return nil, errQueueFull
+	}
+
+	site, err := tenant.Resolve(ctx, req.Inventory.Site)
+	if err != nil {
+		return nil, err
+	}
+	req.Inventory.Site = site
+
+	submission := strings.ToLower(req.SubmissionUuid)
+	if submission != "" {
+		if resp, err := h.storedSubmission(ctx, site, submission); resp != nil || err != nil {
+			return resp, err
+		}
 	}
 
 	rec, err := convert.InventoryToRecord(req.Inventory)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "convert inventory: %v", err)
 	}
+	rec.SubmissionUUID = submission
+
+	// Capture the previous inventory before inserting so alerts, watchers
+	// and events can diff against it.
+	var prev *collectorv1.Inventory
+	watched := h.feed.watched()
+	if h.alerts != nil || watched || len(h.events) > 0 {
+		prev = h.previousInventory(ctx, site, req.Inventory.Hostname)
+	}
 
-	id, storedAt, err := h.store.Insert(ctx, rec)
+	var sub *collectorv1.InventorySubmission
+	if watched || len(h.events) > 0 {
+		sub = &collectorv1.InventorySubmission{
+			Site:        site,
+			Hostname:    req.Inventory.Hostname,
+			Username:    req.Inventory.Username,
+			SystemUuid:  rec.SystemUUID,
+			CollectedAt: timestamppb.New(rec.CollectedAt),
+			First:       prev == nil,
+		}
+		if prev != nil {
+			for _, c := range diff.Compare(prev, req.Inventory) {
+				sub.Changes = append(sub.Changes, convert.ChangeToProto(c))
+			}
+		}
+	}
+	if len(h.events) > 0 {
+		// The event is queued in the transaction that stores the inventory,
+		// so that it is published if and only if the inventory is stored.
+		key := sub.SystemUuid
+		if key == "" {
+			key = sub.Hostname
+		}
+		rec.Events = func(id int64, storedAt time.Time) []store.OutboxMessage {
+			event := proto.Clone(sub).(*collectorv1.InventorySubmission)
+			event.Id = id
+			event.StoredAt = timestamppb.New(storedAt)
+			return h.outboxMessages(outbox.InventorySubmitted, key, event)
+		}
+	}
+
+	id, storedAt, err := h.insert(ctx, rec)
+	if errors.Is(err, errQueueFull) {
+		return nil, err
+	}
+	if errors.Is(err, store.ErrDuplicateSubmission) {
+		// A retry stored the inventory since it was looked up above.
+		if resp, err := h.storedSubmission(ctx, site, submission); resp != nil || err != nil {
+			return resp, err
+		}
+	}
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "store inventory: %v", err)
 	}
 
+	slog.Debug("Inventory stored", "record_id", id, "hostname", req.Inventory.Hostname, "site", site)
+	h.cmdReg.Submitted(site, rec.SystemUUID, req.Inventory.Hostname, storedAt)
+
+	if len(h.events) > 0 {
+		h.notifyRelays(outbox.InventorySubmitted)
+	}
+	if watched {
+		sub.Id = id
+		sub.StoredAt = timestamppb.New(storedAt)
+		h.feed.publish(sub)
+	}
+
+	if prev != nil && h.alerts != nil {
+		if _, err := h.alerts.Evaluate(ctx, id, prev, req.Inventory); err != nil {
+			slog.Error("Alert evaluation failed", "hostname", req.Inventory.Hostname, "site", site, "record_id", id, logging.Err(err))
+		}
+	}
+
+	return &collectorv1.SubmitInventoryResponse{
+		Id:       id,
+		StoredAt: timestamppb.New(storedAt),
+	}, nil
+}
+
+// storedSubmission answers a retried submission with the ID and stored_at
+// time of the inventory the site stored under the submission UUID, which
+// alerts, watchers and events have already seen. It returns nil if there is
+// none.
+func (h *Handler) storedSubmission(ctx context.Context, site, submission string) (*collectorv1.SubmitInventoryResponse, error) {
+	id, storedAt, err := h.store.FindSubmission(ctx, site, submission)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "look up submission: %v", err)
+	}
+	slog.Debug("Duplicate submission", "record_id", id, "submission_uuid", submission, "site", site)
 	return &collectorv1.SubmitInventoryResponse{
 		Id:       id,
 		StoredAt: timestamppb.New(storedAt),
 	}, nil
 }
 
+// insert stores rec, through the write queue when group commit is enabled.
+func (h *Handler) insert(ctx context.Context, rec *store.InventoryRecord) (int64, time.Time, error) {
+	if h.writes != nil {
+		return h.writes.insert(ctx, rec)
+	}
+	return h.store.Insert(ctx, rec)
+}
+
+// queueWrites queues up to size inserts, committed up to max per
+// transaction, until ctx is done. It is called before the handler serves
+// requests.
+func (h *Handler) queueWrites(ctx context.Context, size, max int) {
+	h.writes = newWriteQueue(h.store, size, max)
+	go h.writes.run(ctx)
+}
+
+// queueEvent queues msg as an event of eventType with key in the outbox,
+// for each event relay that publishes events of that type.
+func (h *Handler) queueEvent(ctx context.Context, eventType, key string, msg proto.Message) {
+	for _, m := range h.outboxMessages(eventType, key, msg) {
+		if err := h.store.InsertOutbox(ctx, m.Sink, m.Type, m.Key, m.Payload); err != nil {
+			slog.Error("Queue event failed", "type", eventType, "key", key, "sink", m.Sink, logging.Err(err))
+		}
+	}
+	h.notifyRelays(eventType)
+}
+
+// outboxMessages encodes msg as an event of eventType with key for each
+// event relay that publishes events of that type.
+func (h *Handler) outboxMessages(eventType, key string, msg proto.Message) []store.OutboxMessage {
+	var msgs []store.OutboxMessage
+	var payload []byte
+	for _, r := range h.events {
+		if !r.Wants(eventType) {
+			continue
+		}
+		if payload == nil {
+			var err error
+			if payload, err = proto.Marshal(msg); err != nil {
+				slog.Error("Encode event failed", "type", eventType, "key", key, logging.Err(err))
+				return nil
+			}
+		}
+		msgs = append(msgs, store.OutboxMessage{Sink: r.Name(), Type: eventType, Key: key, Payload: payload})
+	}
+	return msgs
+}
+
+// notifyRelays wakes the event relays that publish events of eventType to
+// publish the events just queued.
+func (h *Handler) notifyRelays(eventType string) {
+	for _, r := range h.events {
+		if r.Wants(eventType) {
+			r.Notify()
+		}
+	}
+}
+
+// agentEventTypes maps agent events to the event types they are queued as.
+var agentEventTypes = map[collectorv1.AgentEventType]string{
+	collectorv1.AgentEventType_AGENT_EVENT_TYPE_CONNECTED:    outbox.AgentConnected,
+	collectorv1.AgentEventType_AGENT_EVENT_TYPE_DISCONNECTED: outbox.AgentDisconnected,
+	collectorv1.AgentEventType_AGENT_EVENT_TYPE_UPDATED:      outbox.AgentUpdated,
+}
+
+// queueAgentEvents queues the agents connecting, disconnecting and being
+// paused or resumed as events, keyed by client ID, until ctx is done.
+func (h *Handler) queueAgentEvents(ctx context.Context) {
+	for ctx.Err() == nil {
+		_, events, stop := h.cmdReg.Watch()
+		h.queueAgentEventsFrom(ctx, events)
+		stop()
+	}
+}
+
+func (h *Handler) queueAgentEventsFrom(ctx context.Context, events <-chan AgentEvent) {
+	for {
+		select {
+		case ev, ok := <-events:
+			if !ok {
+				slog.Warn("Queuing agent events fell behind; some events were dropped")
+				return
+			}
+			eventType, ok := agentEventTypes[ev.Type]
+			if !ok {
+				continue
+			}
+			h.queueEvent(ctx, eventType, ev.Agent.ClientID, &collectorv1.AgentEvent{
+				Type:  ev.Type,
+				Agent: connectedAgentToProto(ev.Agent),
+				Time:  timestamppb.New(ev.Time),
+			})
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// previousInventory returns the latest stored inventory for hostname within
+// site, or nil when there is none or it cannot be decoded.
+func (h *Handler) previousInventory(ctx context.Context, site, hostname string) *collectorv1.Inventory {
+	rec, err := h.store.GetLatestByHostname(ctx, hostname, []string{site})
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			slog.Error("Load previous inventory failed", "hostname", hostname, "site", site, logging.Err(err))
+		}
+		return nil
+	}
+
+	inv, err := convert.RecordToInventory(rec)
+	if err != nil {
+		slog.Error("Decode previous inventory failed", "hostname", hostname, "site", site, "record_id", rec.ID, logging.Err(err))
+		return nil
+	}
+	return inv
+}
+
 func (h *Handler) GetInventory(ctx context.Context, req *collectorv1.GetInventoryRequest) (*collectorv1.GetInventoryResponse, error) {
-	rec, err := h.store.Get(ctx, req.Id)
+	paths, err := convert.MaskPaths(&collectorv1.Inventory{}, req.ReadMask)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	sites, _ := tenant.FromContext(ctx)
+	rec, err := h.store.Get(ctx, req.Id, sites)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, status.Errorf(codes.NotFound, "inventory %d not found", req.Id)
@@ -63,7 +318,7 @@ func (h *Handler) GetInventory(ctx context.Context, req *collectorv1.GetInventor
 		return nil, status.Errorf(codes.Internal, "get inventory: %v", err)
 	}
 
-	inv, err := convert.RecordToInventory(rec)
+	inv, err := convert.RecordToInventoryMasked(rec, paths)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "decode inventory: %v", err)
 	}
@@ -76,12 +331,27 @@ func (h *Handler) GetInventory(ctx context.Context, req *collectorv1.GetInventor
 }
 
 func (h *Handler) ListInventories(ctx context.Context, req *collectorv1.ListInventoriesRequest) (*collectorv1.ListInventoriesResponse, error) {
+	sites, err := tenant.Filter(ctx, req.Site)
+	if err != nil {
+		return nil, err
+	}
+
 	filter := store.ListFilter{
-		Hostname:   req.Hostname,
-		Username:   req.Username,
-		SystemUUID: req.SystemUuid,
-		PageSize:   int(req.PageSize),
-		Page:       int(req.Page),
+		Sites:       sites,
+		Hostname:    req.Hostname,
+		Username:    req.Username,
+		SystemUUID:  req.SystemUuid,
+		DeviceClass: req.DeviceClass,
+		PageSize:    int(req.PageSize),
+		Page:        int(req.Page),
+		ExactCount:  req.ExactCount,
+	}
+	if req.PageToken != "" {
+		after, err := store.ParseListToken(req.PageToken)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		filter.After = &after
 	}
 	if req.CollectedAfter != nil {
 		t := req.CollectedAfter.AsTime()
@@ -92,6 +362,12 @@ func (h *Handler) ListInventories(ctx context.Context, req *collectorv1.ListInve
 		filter.CollectedBefore = &t
 	}
 
+	paths, err := convert.MaskPaths(&collectorv1.InventorySummary{}, req.ReadMask)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	filter.WithJSON = convert.MasksInventory(paths)
+
 	records, total, err := h.store.List(ctx, filter)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "list inventories: %v", err)
@@ -99,17 +375,25 @@ func (h *Handler) ListInventories(ctx context.Context, req *collectorv1.ListInve
 
 	summaries := make([]*collectorv1.InventorySummary, len(records))
 	for i := range records {
-		summaries[i] = convert.RecordToSummary(&records[i])
+		summaries[i], err = convert.RecordToSummaryMasked(&records[i], paths)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "decode inventory: %v", err)
+		}
 	}
 
-	return &collectorv1.ListInventoriesResponse{
+	resp := &collectorv1.ListInventoriesResponse{
 		Inventories: summaries,
 		TotalCount:  int32(total),
-	}, nil
+	}
+	if next := filter.Next(records); next != nil {
+		resp.NextPageToken = next.Token()
+	}
+	return resp, nil
 }
 
 func (h *Handler) DeleteInventory(ctx context.Context, req *collectorv1.DeleteInventoryRequest) (*collectorv1.DeleteInventoryResponse, error) {
-	err := h.store.Delete(ctx, req.Id)
+	sites, _ := tenant.FromContext(ctx)
+	err := h.store.Delete(ctx, req.Id, sites)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, status.Errorf(codes.NotFound, "inventory %d not found", req.Id)
@@ -124,7 +408,17 @@ func (h *Handler) GetLatestByHostname(ctx context.Context, req *collectorv1.GetL
 		return nil, status.Error(codes.InvalidArgument, "hostname is required")
 	}
 
-	rec, err := h.store.GetLatestByHostname(ctx, req.Hostname)
+	paths, err := convert.MaskPaths(&collectorv1.Inventory{}, req.ReadMask)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	sites, err := tenant.Filter(ctx, req.Site)
+	if err != nil {
+		return nil, err
+	}
+
+	rec, err := h.store.GetLatestByHostname(ctx, req.Hostname, sites)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, status.Errorf(codes.NotFound, "no inventory found for hostname %q", req.Hostname)
@@ -132,7 +426,7 @@ func (h *Handler) GetLatestByHostname(ctx context.Context, req *collectorv1.GetL
 		return nil, status.Errorf(codes.Internal, "get latest inventory: %v", err)
 	}
 
-	inv, err := convert.RecordToInventory(rec)
+	inv, err := convert.RecordToInventoryMasked(rec, paths)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "decode inventory: %v", err)
 	}
@@ -144,15 +438,320 @@ func (h *Handler) GetLatestByHostname(ctx context.Context, req *collectorv1.GetL
 	}, nil
 }
 
+func (h *Handler) GetLatestBySystemUUID(ctx context.Context, req *collectorv1.GetLatestBySystemUUIDRequest) (*collectorv1.GetLatestBySystemUUIDResponse, error) {
+	if req.SystemUuid == "" {
+		return nil, status.Error(codes.InvalidArgument, "system_uuid is required")
+	}
+
+	paths, err := convert.MaskPaths(&collectorv1.Inventory{}, req.ReadMask)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	sites, err := tenant.Filter(ctx, req.Site)
+	if err != nil {
+		return nil, err
+	}
+
+	rec, err := h.store.GetLatestBySystemUUID(ctx, req.SystemUuid, sites)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "no inventory found for system UUID %q", req.SystemUuid)
+		}
+		return nil, status.Errorf(codes.Internal, "get latest inventory: %v", err)
+	}
+
+	inv, err := convert.RecordToInventoryMasked(rec, paths)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "decode inventory: %v", err)
+	}
+
+	return &collectorv1.GetLatestBySystemUUIDResponse{
+		Id:        rec.ID,
+		Inventory: inv,
+		StoredAt:  timestamppb.New(rec.StoredAt),
+	}, nil
+}
+
+func (h *Handler) GetLatestBySerial(ctx context.Context, req *collectorv1.GetLatestBySerialRequest) (*collectorv1.GetLatestBySerialResponse, error) {
+	if req.SerialNumber == "" {
+		return nil, status.Error(codes.InvalidArgument, "serial_number is required")
+	}
+
+	paths, err := convert.MaskPaths(&collectorv1.Inventory{}, req.ReadMask)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	sites, err := tenant.Filter(ctx, req.Site)
+	if err != nil {
+		return nil, err
+	}
+
+	rec, err := h.store.GetLatestBySerial(ctx, req.SerialNumber, sites)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "no inventory found for serial number %q", req.SerialNumber)
+		}
+		return nil, status.Errorf(codes.Internal, "get latest inventory: %v", err)
+	}
+
+	inv, err := convert.RecordToInventoryMasked(rec, paths)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "decode inventory: %v", err)
+	}
+
+	return &collectorv1.GetLatestBySerialResponse{
+		Id:        rec.ID,
+		Inventory: inv,
+		StoredAt:  timestamppb.New(rec.StoredAt),
+	}, nil
+}
+
+func (h *Handler) DiffInventories(ctx context.Context, req *collectorv1.DiffInventoriesRequest) (*collectorv1.DiffInventoriesResponse, error) {
+	if req.ToId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "to_id is required")
+	}
+
+	sites, _ := tenant.FromContext(ctx)
+	to, err := h.store.Get(ctx, req.ToId, sites)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "inventory %d not found", req.ToId)
+		}
+		return nil, status.Errorf(codes.Internal, "get inventory: %v", err)
+	}
+
+	var from *store.InventoryRecord
+	if req.FromId > 0 {
+		from, err = h.store.Get(ctx, req.FromId, sites)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "inventory %d not found", req.FromId)
+		}
+	} else {
+		from, err = h.store.GetPrevious(ctx, to)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "inventory %d has no previous inventory", req.ToId)
+		}
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "get inventory: %v", err)
+	}
+
+	prev, err := convert.RecordToInventory(from)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "decode inventory: %v", err)
+	}
+	cur, err := convert.RecordToInventory(to)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "decode inventory: %v", err)
+	}
+
+	changes := diff.Compare(prev, cur)
+	resp := &collectorv1.DiffInventoriesResponse{
+		FromId:  from.ID,
+		ToId:    to.ID,
+		Changes: make([]*collectorv1.InventoryChange, len(changes)),
+	}
+	for i, c := range changes {
+		resp.Changes[i] = convert.ChangeToProto(c)
+	}
+	return resp, nil
+}
+
+func (h *Handler) ListHosts(ctx context.Context, req *collectorv1.ListHostsRequest) (*collectorv1.ListHostsResponse, error) {
+	sites, err := tenant.Filter(ctx, req.Site)
+	if err != nil {
+		return nil, err
+	}
+
+	hosts, total, err := h.store.ListHosts(ctx, store.HostFilter{
+		Sites:    sites,
+		PageSize: int(req.PageSize),
+		Page:     int(req.Page),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list hosts: %v", err)
+	}
+
+	summaries := make([]*collectorv1.HostSummary, len(hosts))
+	for i := range hosts {
+		summaries[i] = convert.HostToSummary(&hosts[i])
+	}
+
+	return &collectorv1.ListHostsResponse{
+		Hosts:      summaries,
+		TotalCount: int32(total),
+	}, nil
+}
+
+func (h *Handler) ListAlerts(ctx context.Context, req *collectorv1.ListAlertsRequest) (*collectorv1.ListAlertsResponse, error) {
+	sites, err := tenant.Filter(ctx, req.Site)
+	if err != nil {
+		return nil, err
+	}
+
+	alerts, total, err := h.store.ListAlerts(ctx, store.AlertFilter{
+		Sites:              sites,
+		Hostname:           req.Hostname,
+		UnacknowledgedOnly: req.UnacknowledgedOnly,
+		PageSize:           int(req.PageSize),
+		Page:               int(req.Page),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list alerts: %v", err)
+	}
+
+	pbAlerts := make([]*collectorv1.Alert, len(alerts))
+	for i := range alerts {
+		pbAlerts[i] = convert.AlertToProto(&alerts[i])
+	}
+
+	return &collectorv1.ListAlertsResponse{
+		Alerts:     pbAlerts,
+		TotalCount: int32(total),
+	}, nil
+}
+
+func (h *Handler) AcknowledgeAlert(ctx context.Context, req *collectorv1.AcknowledgeAlertRequest) (*collectorv1.AcknowledgeAlertResponse, error) {
+	sites, _ := tenant.FromContext(ctx)
+	if err := h.store.AcknowledgeAlert(ctx, req.Id, sites); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "alert %d not found", req.Id)
+		}
+		return nil, status.Errorf(codes.Internal, "acknowledge alert: %v", err)
+	}
+	return &collectorv1.AcknowledgeAlertResponse{}, nil
+}
+
+func (h *Handler) GetDuplicateReport(ctx context.Context, req *collectorv1.GetDuplicateReportRequest) (*collectorv1.GetDuplicateReportResponse, error) {
+	sites, err := tenant.Filter(ctx, req.Site)
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := h.store.FindDuplicates(ctx, sites)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "find duplicates: %v", err)
+	}
+
+	pbGroups := make([]*collectorv1.DuplicateGroup, len(groups))
+	for i, g := range groups {
+		pbGroups[i] = &collectorv1.DuplicateGroup{
+			Field:     g.Field,
+			Value:     g.Value,
+			Hostnames: g.Hostnames,
+		}
+	}
+
+	return &collectorv1.GetDuplicateReportResponse{
+		Duplicates: pbGroups,
+	}, nil
+}
+
+func (h *Handler) GetCollectionReport(ctx context.Context, req *collectorv1.GetCollectionReportRequest) (*collectorv1.GetCollectionReportResponse, error) {
+	sites, err := tenant.Filter(ctx, req.Site)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := h.store.CollectionStats(ctx, sites)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "collection stats: %v", err)
+	}
+
+	pbModules := make([]*collectorv1.ModuleCollectionStats, len(stats))
+	for i, m := range stats {
+		pbModules[i] = &collectorv1.ModuleCollectionStats{
+			Module:        m.Module,
+			Hosts:         int32(m.Hosts),
+			Failures:      int32(m.Failures),
+			AvgDurationMs: m.AvgDurationMs,
+			MaxDurationMs: m.MaxDurationMs,
+		}
+	}
+
+	return &collectorv1.GetCollectionReportResponse{
+		Modules: pbModules,
+	}, nil
+}
+
+func (h *Handler) GetFleetReport(ctx context.Context, req *collectorv1.GetFleetReportRequest) (*collectorv1.GetFleetReportResponse, error) {
+	sites, err := tenant.Filter(ctx, req.Site)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := h.store.FleetStats(ctx, sites)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "fleet stats: %v", err)
+	}
+
+	connected := make(map[[2]string]bool)
+	versions := make(map[string]int)
+	for _, a := range h.cmdReg.ListConnected() {
+		if !tenant.Allowed(ctx, a.Site) || (req.Site != "" && a.Site != req.Site) {
+			continue
+		}
+		connected[[2]string{a.Site, a.Hostname}] = true
+		version := a.Version
+		if version == "" {
+			version = "unknown"
+		}
+		versions[version]++
+	}
+	var online int32
+	for _, key := range stats.HostKeys {
+		if connected[key] {
+			online++
+		}
+	}
+
+	return &collectorv1.GetFleetReportResponse{
+		Hosts:         int32(stats.Hosts),
+		Online:        online,
+		Models:        fleetCounts(stats.Models),
+		Memory:        fleetCounts(stats.Memory),
+		AgentVersions: fleetCounts(store.SortCounts(versions)),
+	}, nil
+}
+
+func fleetCounts(counts []store.Count) []*collectorv1.FleetCount {
+	pb := make([]*collectorv1.FleetCount, len(counts))
+	for i, c := range counts {
+		pb[i] = &collectorv1.FleetCount{Value: c.Value, Hosts: int32(c.Hosts)}
+	}
+	return pb
+}
+
 func (h *Handler) StreamCommands(req *collectorv1.StreamCommandsRequest, stream grpc.ServerStreamingServer[collectorv1.InventoryCommand]) error {
 	if req.ClientId == "" {
 		return status.Error(codes.InvalidArgument, "client_id is required")
 	}
 
-	ch := h.cmdReg.Register(req.ClientId, req.ClientVersion)
-	defer h.cmdReg.Unregister(req.ClientId)
+	site, err := tenant.Resolve(stream.Context(), req.Site)
+	if err != nil {
+		return err
+	}
+
+	// Older agents identify by hostname only.
+	hostname := req.Hostname
+	if hostname == "" {
+		hostname = req.ClientId
+	}
+
+	ch := h.cmdReg.Register(site, req.ClientId, hostname, req.ClientVersion, req.Paused)
+	defer h.cmdReg.Unregister(site, req.ClientId, ch)
 
-	log.Printf("Agent %q connected (version: %s)", req.ClientId, req.ClientVersion)
+	slog.Info("Agent connected", "client_id", req.ClientId, "hostname", hostname, "site", site, "version", req.ClientVersion, "paused", req.Paused)
+
+	if u := h.cmdReg.PendingUpdate(site, req.ClientVersion); u != nil {
+		cmd := newUpdateCommand(u)
+		if err := stream.Send(cmd); err != nil {
+			return err
+		}
+		slog.Info("Sent update command", "client_id", req.ClientId, "site", site, "command_id", cmd.CommandId, "version", u.Version)
+	}
 
 	for {
 		select {
@@ -164,19 +763,55 @@ func (h *Handler) StreamCommands(req *collectorv1.StreamCommandsRequest, stream
 				return err
 			}
 		case <-stream.Context().Done():
-			log.Printf("Agent %q disconnected", req.ClientId)
+			slog.Info("Agent disconnected", "client_id", req.ClientId, "hostname", hostname, "site", site)
 			return stream.Context().Err()
 		}
 	}
 }
 
-func (h *Handler) RefreshInventory(ctx context.Context, req *collectorv1.RefreshInventoryRequest) (*collectorv1.RefreshInventoryResponse, error) {
-	if req.Hostname == "" {
-		return nil, status.Error(codes.InvalidArgument, "hostname is required")
+func (h *Handler) WatchInventories(req *collectorv1.WatchInventoriesRequest, stream grpc.ServerStreamingServer[collectorv1.InventorySubmission]) error {
+	ctx := stream.Context()
+	sites, err := tenant.Filter(ctx, req.Site)
+	if err != nil {
+		return err
 	}
 
-	if !h.cmdReg.IsConnected(req.Hostname) {
-		return nil, status.Errorf(codes.NotFound, "agent %q is not connected", req.Hostname)
+	submissions, stop := h.feed.watch()
+	defer stop()
+
+	for {
+		select {
+		case sub, ok := <-submissions:
+			if !ok {
+				return status.Error(codes.ResourceExhausted, "watcher fell behind the submissions; watch again")
+			}
+			if sites != nil && !slices.Contains(sites, sub.Site) {
+				continue
+			}
+			if req.Hostname != "" && sub.Hostname != req.Hostname {
+				continue
+			}
+			if err := stream.Send(sub); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (h *Handler) CheckAgent(ctx context.Context, req *collectorv1.CheckAgentRequest) (*collectorv1.CheckAgentResponse, error) {
+	site, err := tenant.Resolve(ctx, req.Site)
+	if err != nil {
+		return nil, err
+	}
+	return &collectorv1.CheckAgentResponse{Site: site}, nil
+}
+
+func (h *Handler) RefreshInventory(ctx context.Context, req *collectorv1.RefreshInventoryRequest) (*collectorv1.RefreshInventoryResponse, error) {
+	site, clientID, err := h.resolveAgent(ctx, req.Site, req.ClientId, req.Hostname)
+	if err != nil {
+		return nil, err
 	}
 
 	cmdID := uuid.NewString()
@@ -185,11 +820,11 @@ func (h *Handler) RefreshInventory(ctx context.Context, req *collectorv1.Refresh
 		CommandType: collectorv1.InventoryCommandType_INVENTORY_COMMAND_TYPE_REFRESH,
 	}
 
-	if err := h.cmdReg.Send(req.Hostname, cmd); err != nil {
+	if err := h.cmdReg.Send(site, clientID, cmd); err != nil {
 		return nil, status.Errorf(codes.Internal, "send refresh command: %v", err)
 	}
 
-	log.Printf("Sent refresh command %s to agent %q", cmdID, req.Hostname)
+	slog.Info("Sent refresh command", "client_id", clientID, "site", site, "command_id", cmdID)
 
 	return &collectorv1.RefreshInventoryResponse{
 		Sent:      true,
@@ -197,19 +832,115 @@ func (h *Handler) RefreshInventory(ctx context.Context, req *collectorv1.Refresh
 	}, nil
 }
 
-func (h *Handler) ListConnectedAgents(_ context.Context, _ *collectorv1.ListConnectedAgentsRequest) (*collectorv1.ListConnectedAgentsResponse, error) {
+func (h *Handler) PauseAgent(ctx context.Context, req *collectorv1.PauseAgentRequest) (*collectorv1.PauseAgentResponse, error) {
+	cmdID, err := h.setPaused(ctx, req.Site, req.ClientId, req.Hostname, true)
+	if err != nil {
+		return nil, err
+	}
+	return &collectorv1.PauseAgentResponse{
+		Sent:      true,
+		CommandId: cmdID,
+	}, nil
+}
+
+func (h *Handler) ResumeAgent(ctx context.Context, req *collectorv1.ResumeAgentRequest) (*collectorv1.ResumeAgentResponse, error) {
+	cmdID, err := h.setPaused(ctx, req.Site, req.ClientId, req.Hostname, false)
+	if err != nil {
+		return nil, err
+	}
+	return &collectorv1.ResumeAgentResponse{
+		Sent:      true,
+		CommandId: cmdID,
+	}, nil
+}
+
+// setPaused sends a pause or resume command to the targeted agent and
+// returns its command ID. The registry is updated right away; the agent
+// confirms its state when it next connects.
+func (h *Handler) setPaused(ctx context.Context, reqSite, clientID, hostname string, paused bool) (string, error) {
+	site, clientID, err := h.resolveAgent(ctx, reqSite, clientID, hostname)
+	if err != nil {
+		return "", err
+	}
+
+	kind, cmdType := "resume", collectorv1.InventoryCommandType_INVENTORY_COMMAND_TYPE_RESUME
+	if paused {
+		kind, cmdType = "pause", collectorv1.InventoryCommandType_INVENTORY_COMMAND_TYPE_PAUSE
+	}
+
+	cmdID := uuid.NewString()
+	cmd := &collectorv1.InventoryCommand{
+		CommandId:   cmdID,
+		CommandType: cmdType,
+	}
+
+	if err := h.cmdReg.Send(site, clientID, cmd); err != nil {
+		return "", status.Errorf(codes.Internal, "send %s command: %v", kind, err)
+	}
+	h.cmdReg.SetPaused(site, clientID, paused)
+
+	slog.Info("Sent "+kind+" command", "client_id", clientID, "site", site, "command_id", cmdID)
+
+	return cmdID, nil
+}
+
+// resolveAgent resolves the site of a command request and the connected agent
+// it targets, by client_id or else by hostname.
+func (h *Handler) resolveAgent(ctx context.Context, reqSite, clientID, hostname string) (string, string, error) {
+	if clientID == "" && hostname == "" {
+		return "", "", status.Error(codes.InvalidArgument, "client_id or hostname is required")
+	}
+
+	site, err := tenant.Resolve(ctx, reqSite)
+	if err != nil {
+		return "", "", err
+	}
+
+	if clientID != "" {
+		if !h.cmdReg.IsConnected(site, clientID) {
+			return "", "", status.Errorf(codes.NotFound, "agent %q is not connected", clientID)
+		}
+		return site, clientID, nil
+	}
+
+	ids := h.cmdReg.FindByHostname(site, hostname)
+	switch len(ids) {
+	case 0:
+		return "", "", status.Errorf(codes.NotFound, "agent %q is not connected", hostname)
+	case 1:
+		return site, ids[0], nil
+	default:
+		return "", "", status.Errorf(codes.FailedPrecondition, "hostname %q matches %d connected agents; target one by client_id", hostname, len(ids))
+	}
+}
+
+func (h *Handler) ListConnectedAgents(ctx context.Context, _ *collectorv1.ListConnectedAgentsRequest) (*collectorv1.ListConnectedAgentsResponse, error) {
 	agents := h.cmdReg.ListConnected()
 
-	pbAgents := make([]*collectorv1.ConnectedAgent, len(agents))
-	for i, a := range agents {
-		pbAgents[i] = &collectorv1.ConnectedAgent{
-			ClientId:    a.ClientID,
-			Version:     a.Version,
-			ConnectedAt: timestamppb.New(a.ConnectedAt),
+	pbAgents := make([]*collectorv1.ConnectedAgent, 0, len(agents))
+	for _, a := range agents {
+		if !tenant.Allowed(ctx, a.Site) {
+			continue
 		}
+		pbAgents = append(pbAgents, connectedAgentToProto(a))
 	}
 
 	return &collectorv1.ListConnectedAgentsResponse{
 		Agents: pbAgents,
 	}, nil
 }
+
+func connectedAgentToProto(a ConnectedAgentInfo) *collectorv1.ConnectedAgent {
+	pb := &collectorv1.ConnectedAgent{
+		ClientId:    a.ClientID,
+		Version:     a.Version,
+		ConnectedAt: timestamppb.New(a.ConnectedAt),
+		Site:        a.Site,
+		Hostname:    a.Hostname,
+		Paused:      a.Paused,
+	}
+	if !a.LastSubmittedAt.IsZero() {
+		pb.LastSubmittedAt = timestamppb.New(a.LastSubmittedAt)
+	}
+	return pb
+}
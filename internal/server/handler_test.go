@@ -0,0 +1,86 @@
+package server
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+	"github.com/go-tangra/go-tangra-inventory/internal/store"
+	"github.com/go-tangra/go-tangra-inventory/internal/tenant"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TestGetLatestSite checks that GetLatestBySystemUUID and GetLatestBySerial
+// are limited to the requested site, which must be one of the caller's.
+func TestGetLatestSite(t *testing.T) {
+	db, err := store.New(filepath.Join(t.TempDir(), "inventory.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	// The same machine, re-imaged and moved from customer-a to customer-b.
+	ids := map[string]int64{}
+	for i, site := range []string{"customer-a", "customer-b"} {
+		id, _, err := db.Insert(context.Background(), &store.InventoryRecord{
+			Site:          site,
+			Hostname:      "pc-042",
+			SystemUUID:    "4c4c4544-0000-1000-8000-000000000042",
+			SystemSerial:  "SN00000042",
+			CollectedAt:   time.Date(2026, 1, 1+i, 0, 0, 0, 0, time.UTC),
+			InventoryJSON: `{"hostname":"pc-042"}`,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids[site] = id
+	}
+
+	h := &Handler{store: db}
+	get := func(ctx context.Context, site string) (uuidID, serialID int64, err error) {
+		byUUID, err := h.GetLatestBySystemUUID(ctx, &collectorv1.GetLatestBySystemUUIDRequest{
+			SystemUuid: "4c4c4544-0000-1000-8000-000000000042",
+			Site:       site,
+		})
+		if err != nil {
+			return 0, 0, err
+		}
+		bySerial, err := h.GetLatestBySerial(ctx, &collectorv1.GetLatestBySerialRequest{
+			SerialNumber: "SN00000042",
+			Site:         site,
+		})
+		if err != nil {
+			return 0, 0, err
+		}
+		return byUUID.Id, bySerial.Id, nil
+	}
+
+	both := tenant.NewContext(context.Background(), []string{"customer-a", "customer-b"})
+	for _, tt := range []struct {
+		ctx  context.Context
+		site string
+		want int64
+	}{
+		{context.Background(), "", ids["customer-b"]},
+		{both, "", ids["customer-b"]},
+		{both, "customer-a", ids["customer-a"]},
+		{tenant.NewContext(context.Background(), []string{"customer-a"}), "", ids["customer-a"]},
+	} {
+		uuidID, serialID, err := get(tt.ctx, tt.site)
+		if err != nil {
+			t.Fatalf("site %q: %v", tt.site, err)
+		}
+		if uuidID != tt.want || serialID != tt.want {
+			t.Errorf("site %q: got IDs %d (UUID) and %d (serial), want %d", tt.site, uuidID, serialID, tt.want)
+		}
+	}
+
+	onlyA := tenant.NewContext(context.Background(), []string{"customer-a"})
+	if _, _, err := get(onlyA, "customer-b"); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("site outside the caller's scope: %v, want PermissionDenied", err)
+	}
+}
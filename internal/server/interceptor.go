@@ -5,6 +5,10 @@ import (
 	"crypto/subtle"
 	"strings"
 
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+	collectorv2 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v2"
+	"github.com/go-tangra/go-tangra-inventory/internal/tenant"
+
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
@@ -14,6 +18,9 @@ import (
 // allowedClientSecretUnaryMethods lists unary RPCs that client-secret callers may invoke.
 var allowedClientSecretUnaryMethods = map[string]bool{
 	"/SubmitInventory": true,
+	"/SubmitAgentLogs": true,
+	"/AckCommand":      true,
+	"/CheckAgent":      true,
 }
 
 // allowedClientSecretStreamMethods lists streaming RPCs that client-secret callers may invoke.
@@ -21,15 +28,45 @@ var allowedClientSecretStreamMethods = map[string]bool{
 	"/StreamCommands": true,
 }
 
+// adminCollectorMethods lists RPCs of InventoryCollectorService and the v2
+// InventoryService that are also served by InventoryAdminService. When a
+// dedicated admin listener is configured they are refused on the
+// agent-facing port.
+var adminCollectorMethods = map[string]bool{
+	collectorv1.InventoryCollectorService_DeleteInventory_FullMethodName:     true,
+	collectorv1.InventoryCollectorService_RefreshInventory_FullMethodName:    true,
+	collectorv1.InventoryCollectorService_ListConnectedAgents_FullMethodName: true,
+	collectorv1.InventoryCollectorService_PauseAgent_FullMethodName:          true,
+	collectorv1.InventoryCollectorService_ResumeAgent_FullMethodName:         true,
+	collectorv2.InventoryService_ListAgents_FullMethodName:                   true,
+}
+
+// AdminOnlyInterceptor returns a gRPC unary server interceptor that rejects
+// management RPCs on the agent-facing port, directing callers to the admin
+// listener instead.
+func AdminOnlyInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if adminCollectorMethods[info.FullMethod] {
+			return nil, status.Error(codes.PermissionDenied, "method is only available on the admin listener")
+		}
+		return handler(ctx, req)
+	}
+}
+
 // AuthInterceptor returns a gRPC unary server interceptor that validates
 // either x-client-secret or x-api-secret metadata headers.
 //
-// When both secrets are empty, authentication is disabled (pass-through).
-// x-client-secret callers may only invoke SubmitInventory (agent write path).
+// When both secrets are empty and no site tokens are configured,
+// authentication is disabled (pass-through).
+// x-client-secret callers may only invoke SubmitInventory, SubmitAgentLogs,
+// AckCommand and CheckAgent (agent write path).
 // x-api-secret callers may invoke any RPC (service-to-service read path).
-func AuthInterceptor(clientSecret, apiSecret string) grpc.UnaryServerInterceptor {
+// Either header may instead carry a site-bound token, which restricts the
+// call to the token's sites, or a managed API token, whose role decides the
+// RPCs it may invoke.
+func AuthInterceptor(clientSecret, apiSecret string, siteTokens tenant.Tokens, apiTokens *APITokens) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
-		if clientSecret == "" && apiSecret == "" {
+		if clientSecret == "" && apiSecret == "" && len(siteTokens) == 0 && !apiTokens.enabled() {
 			return handler(ctx, req)
 		}
 
@@ -39,9 +76,15 @@ func AuthInterceptor(clientSecret, apiSecret string) grpc.UnaryServerInterceptor
 		}
 
 		// Try x-api-secret first — grants access to all RPCs.
-		if apiSecret != "" {
+		if apiSecret != "" || len(siteTokens) > 0 || apiTokens.enabled() {
 			if vals := md.Get("x-api-secret"); len(vals) > 0 {
-				if subtle.ConstantTimeCompare([]byte(vals[0]), []byte(apiSecret)) == 1 {
+				if ctx, ok := authorize(ctx, vals[0], apiSecret, siteTokens); ok {
+					return handler(ctx, req)
+				}
+				if ctx, ok, err := apiTokens.authorize(ctx, vals[0], info.FullMethod); ok {
+					if err != nil {
+						return nil, err
+					}
 					return handler(ctx, req)
 				}
 				return nil, status.Error(codes.Unauthenticated, "invalid x-api-secret")
@@ -49,9 +92,16 @@ func AuthInterceptor(clientSecret, apiSecret string) grpc.UnaryServerInterceptor
 		}
 
 		// Fall back to x-client-secret — restricted to agent methods only.
-		if clientSecret != "" {
+		if clientSecret != "" || len(siteTokens) > 0 || apiTokens.enabled() {
 			if vals := md.Get("x-client-secret"); len(vals) > 0 {
-				if subtle.ConstantTimeCompare([]byte(vals[0]), []byte(clientSecret)) != 1 {
+				ctx, ok := authorize(ctx, vals[0], clientSecret, siteTokens)
+				if !ok {
+					var err error
+					if ctx, ok, err = apiTokens.authorize(ctx, vals[0], info.FullMethod); err != nil {
+						return nil, err
+					}
+				}
+				if !ok {
 					return nil, status.Error(codes.Unauthenticated, "invalid x-client-secret")
 				}
 
@@ -79,9 +129,11 @@ func AuthInterceptor(clientSecret, apiSecret string) grpc.UnaryServerInterceptor
 //
 // x-client-secret callers may only invoke StreamCommands (agent path).
 // x-api-secret callers may invoke any streaming RPC.
-func AuthStreamInterceptor(clientSecret, apiSecret string) grpc.StreamServerInterceptor {
+// Site-bound and managed API tokens are accepted in either header, as for
+// AuthInterceptor.
+func AuthStreamInterceptor(clientSecret, apiSecret string, siteTokens tenant.Tokens, apiTokens *APITokens) grpc.StreamServerInterceptor {
 	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
-		if clientSecret == "" && apiSecret == "" {
+		if clientSecret == "" && apiSecret == "" && len(siteTokens) == 0 && !apiTokens.enabled() {
 			return handler(srv, ss)
 		}
 
@@ -91,19 +143,32 @@ func AuthStreamInterceptor(clientSecret, apiSecret string) grpc.StreamServerInte
 		}
 
 		// Try x-api-secret first — grants access to all RPCs.
-		if apiSecret != "" {
+		if apiSecret != "" || len(siteTokens) > 0 || apiTokens.enabled() {
 			if vals := md.Get("x-api-secret"); len(vals) > 0 {
-				if subtle.ConstantTimeCompare([]byte(vals[0]), []byte(apiSecret)) == 1 {
-					return handler(srv, ss)
+				if ctx, ok := authorize(ss.Context(), vals[0], apiSecret, siteTokens); ok {
+					return handler(srv, &scopedStream{ServerStream: ss, ctx: ctx})
+				}
+				if ctx, ok, err := apiTokens.authorize(ss.Context(), vals[0], info.FullMethod); ok {
+					if err != nil {
+						return err
+					}
+					return handler(srv, &scopedStream{ServerStream: ss, ctx: ctx})
 				}
 				return status.Error(codes.Unauthenticated, "invalid x-api-secret")
 			}
 		}
 
 		// Fall back to x-client-secret — restricted to agent methods only.
-		if clientSecret != "" {
+		if clientSecret != "" || len(siteTokens) > 0 || apiTokens.enabled() {
 			if vals := md.Get("x-client-secret"); len(vals) > 0 {
-				if subtle.ConstantTimeCompare([]byte(vals[0]), []byte(clientSecret)) != 1 {
+				ctx, ok := authorize(ss.Context(), vals[0], clientSecret, siteTokens)
+				if !ok {
+					var err error
+					if ctx, ok, err = apiTokens.authorize(ss.Context(), vals[0], info.FullMethod); err != nil {
+						return err
+					}
+				}
+				if !ok {
 					return status.Error(codes.Unauthenticated, "invalid x-client-secret")
 				}
 
@@ -118,10 +183,33 @@ func AuthStreamInterceptor(clientSecret, apiSecret string) grpc.StreamServerInte
 					return status.Error(codes.PermissionDenied, "client-secret not permitted for this method")
 				}
 
-				return handler(srv, ss)
+				return handler(srv, &scopedStream{ServerStream: ss, ctx: ctx})
 			}
 		}
 
 		return status.Error(codes.Unauthenticated, "missing x-api-secret or x-client-secret")
 	}
 }
+
+// authorize checks value against secret and then against the site-bound
+// tokens. On a token match the returned context is restricted to its sites.
+func authorize(ctx context.Context, value, secret string, siteTokens tenant.Tokens) (context.Context, bool) {
+	if secret != "" && subtle.ConstantTimeCompare([]byte(value), []byte(secret)) == 1 {
+		return ctx, true
+	}
+	if sites, ok := siteTokens.Lookup(value); ok {
+		return tenant.NewContext(ctx, sites), true
+	}
+	return ctx, false
+}
+
+// scopedStream replaces the context of a server stream so that handlers see
+// the site scope established during authentication.
+type scopedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *scopedStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,141 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// agentMethods lists the RPCs subject to source address filtering.
+var agentMethods = map[string]bool{
+	collectorv1.InventoryCollectorService_SubmitInventory_FullMethodName: true,
+	collectorv1.InventoryCollectorService_StreamCommands_FullMethodName:  true,
+	collectorv1.InventoryCollectorService_CheckAgent_FullMethodName:      true,
+	collectorv1.InventoryCollectorService_SubmitAgentLogs_FullMethodName: true,
+	collectorv1.InventoryCollectorService_AckCommand_FullMethodName:      true,
+}
+
+// IPFilter decides whether an agent may connect from a given address.
+// Deny entries take precedence; an empty allow list allows every address
+// that is not denied.
+type IPFilter struct {
+	allow []netip.Prefix
+	deny  []netip.Prefix
+}
+
+// NewIPFilter parses CIDR allow and deny lists. Bare IP addresses are
+// accepted as single-host prefixes. It returns nil when both lists are empty.
+func NewIPFilter(allow, deny []string) (*IPFilter, error) {
+	if len(allow) == 0 && len(deny) == 0 {
+		return nil, nil
+	}
+
+	f := &IPFilter{}
+	var err error
+	if f.allow, err = parsePrefixes(allow); err != nil {
+		return nil, fmt.Errorf("agent_allow_cidrs: %w", err)
+	}
+	if f.deny, err = parsePrefixes(deny); err != nil {
+		return nil, fmt.Errorf("agent_deny_cidrs: %w", err)
+	}
+	return f, nil
+}
+
+// Allowed reports whether addr passes the filter. Non-IP peers such as unix
+// socket clients are always allowed; socket permissions govern them instead.
+func (f *IPFilter) Allowed(addr net.Addr) bool {
+	tcp, ok := addr.(*net.TCPAddr)
+	if !ok {
+		return true
+	}
+	ip, ok := netip.AddrFromSlice(tcp.IP)
+	if !ok {
+		return false
+	}
+	ip = ip.Unmap()
+
+	for _, p := range f.deny {
+		if p.Contains(ip) {
+			return false
+		}
+	}
+	if len(f.allow) == 0 {
+		return true
+	}
+	for _, p := range f.allow {
+		if p.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *IPFilter) check(ctx context.Context, method string) error {
+	if !agentMethods[method] {
+		return nil
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return status.Error(codes.PermissionDenied, "unknown source address")
+	}
+	if !f.Allowed(p.Addr) {
+		return status.Errorf(codes.PermissionDenied, "source address %s not allowed", p.Addr)
+	}
+	return nil
+}
+
+// SourceIPInterceptor returns a gRPC unary server interceptor that rejects
+// SubmitInventory calls from addresses not permitted by f.
+func SourceIPInterceptor(f *IPFilter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := f.check(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// SourceIPStreamInterceptor returns a gRPC stream server interceptor that
+// rejects StreamCommands calls from addresses not permitted by f.
+func SourceIPStreamInterceptor(f *IPFilter) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := f.check(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+func parsePrefixes(entries []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(entries))
+	for _, e := range entries {
+		e = strings.TrimSpace(e)
+		if e == "" {
+			continue
+		}
+		if !strings.Contains(e, "/") {
+			ip, err := netip.ParseAddr(e)
+			if err != nil {
+				return nil, fmt.Errorf("invalid address %q: %w", e, err)
+			}
+			ip = ip.Unmap()
+			prefixes = append(prefixes, netip.PrefixFrom(ip, ip.BitLen()))
+			continue
+		}
+		p, err := netip.ParsePrefix(e)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIDR %q: %w", e, err)
+		}
+		prefixes = append(prefixes, p.Masked())
+	}
+	return prefixes, nil
+}
@@ -0,0 +1,155 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"sync"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// limitListener caps the connections a listener has open: in total, by
+// holding back Accept until one closes so that further clients wait in the
+// kernel backlog, and per source IP, by closing the connections beyond the
+// cap at once.
+type limitListener struct {
+	net.Listener
+	// slots holds a token per open connection; nil means no total cap.
+	slots chan struct{}
+	perIP int
+	done  chan struct{}
+	once  sync.Once
+
+	mu   sync.Mutex
+	byIP map[string]int
+}
+
+// limitConnections wraps l to allow at most max connections in total and
+// perIP from each source IP; 0 means no limit. It returns l itself when
+// neither is limited.
+func limitConnections(l net.Listener, max, perIP int) net.Listener {
+	if max <= 0 && perIP <= 0 {
+		return l
+	}
+	ll := &limitListener{Listener: l, perIP: perIP, done: make(chan struct{}), byIP: make(map[string]int)}
+	if max > 0 {
+		ll.slots = make(chan struct{}, max)
+	}
+	return ll
+}
+
+func (l *limitListener) Accept() (net.Conn, error) {
+	for {
+		if l.slots != nil {
+			select {
+			case l.slots <- struct{}{}:
+			case <-l.done:
+				return nil, net.ErrClosed
+			}
+		}
+		c, err := l.Listener.Accept()
+		if err != nil {
+			l.release()
+			return nil, err
+		}
+		ip := sourceIP(c.RemoteAddr())
+		if !l.admit(ip) {
+			slog.Debug("Refused connection over the per-IP limit", "addr", c.RemoteAddr(), "limit", l.perIP)
+			c.Close()
+			l.release()
+			continue
+		}
+		return &limitConn{Conn: c, l: l, ip: ip}, nil
+	}
+}
+
+func (l *limitListener) Close() error {
+	l.once.Do(func() { close(l.done) })
+	return l.Listener.Close()
+}
+
+// admit counts a connection from ip, unless ip already has perIP open.
+// Connections without an IP, such as Unix socket clients, are not counted.
+func (l *limitListener) admit(ip string) bool {
+	if l.perIP <= 0 || ip == "" {
+		return true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.byIP[ip] >= l.perIP {
+		return false
+	}
+	l.byIP[ip]++
+	return true
+}
+
+func (l *limitListener) closed(ip string) {
+	if l.perIP > 0 && ip != "" {
+		l.mu.Lock()
+		if l.byIP[ip]--; l.byIP[ip] <= 0 {
+			delete(l.byIP, ip)
+		}
+		l.mu.Unlock()
+	}
+	l.release()
+}
+
+func (l *limitListener) release() {
+	if l.slots != nil {
+		<-l.slots
+	}
+}
+
+// limitConn releases its place in the limits of l when closed.
+type limitConn struct {
+	net.Conn
+	l    *limitListener
+	ip   string
+	once sync.Once
+}
+
+func (c *limitConn) Close() error {
+	err := c.Conn.Close()
+	c.once.Do(func() { c.l.closed(c.ip) })
+	return err
+}
+
+func sourceIP(addr net.Addr) string {
+	if tcp, ok := addr.(*net.TCPAddr); ok {
+		return tcp.IP.String()
+	}
+	return ""
+}
+
+// waitingMethods lists the unary RPCs that wait for an agent to answer, for
+// up to maxCommandWait or agentLogsTimeout. They take no worker: they would
+// hold it while idle, and the AckCommand or SubmitAgentLogs they wait for
+// needs one.
+var waitingMethods = map[string]bool{
+	collectorv1.InventoryAdminService_SendCommand_FullMethodName:  true,
+	collectorv1.InventoryAdminService_GetAgentLogs_FullMethodName: true,
+}
+
+// WorkerInterceptor returns a gRPC unary server interceptor that runs at
+// most workers handlers at a time. Further calls wait for a free worker
+// until their deadline, so that a burst of agents is worked off at a steady
+// pace instead of all at once. The RPCs in waitingMethods are not limited.
+func WorkerInterceptor(workers int) grpc.UnaryServerInterceptor {
+	sem := make(chan struct{}, workers)
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if waitingMethods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}
+		defer func() { <-sem }()
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,71 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TestWorkerInterceptorWaitingMethods checks that RPCs waiting for an agent
+// take no worker, so that they neither starve other calls nor the replies
+// they wait for.
+func TestWorkerInterceptorWaitingMethods(t *testing.T) {
+	intercept := WorkerInterceptor(1)
+	call := func(ctx context.Context, method string, handler grpc.UnaryHandler) error {
+		_, err := intercept(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		return err
+	}
+
+	// A SendCommand waiting for its acknowledgement, which needs a worker.
+	acked := make(chan struct{})
+	sendErr := make(chan error, 1)
+	go func() {
+		sendErr <- call(context.Background(), collectorv1.InventoryAdminService_SendCommand_FullMethodName,
+			func(ctx context.Context, req any) (any, error) {
+				select {
+				case <-acked:
+					return nil, nil
+				case <-time.After(5 * time.Second):
+					return nil, status.Error(codes.DeadlineExceeded, "not acknowledged")
+				}
+			})
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	err := call(ctx, collectorv1.InventoryCollectorService_AckCommand_FullMethodName,
+		func(ctx context.Context, req any) (any, error) {
+			close(acked)
+			return nil, nil
+		})
+	if err != nil {
+		t.Fatalf("AckCommand: %v", err)
+	}
+	if err := <-sendErr; err != nil {
+		t.Fatalf("SendCommand: %v", err)
+	}
+
+	// Other calls still share the single worker.
+	release := make(chan struct{})
+	go call(context.Background(), collectorv1.InventoryCollectorService_SubmitInventory_FullMethodName,
+		func(ctx context.Context, req any) (any, error) {
+			<-release
+			return nil, nil
+		})
+	defer close(release)
+	time.Sleep(50 * time.Millisecond)
+
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err = call(ctx, collectorv1.InventoryCollectorService_CheckAgent_FullMethodName,
+		func(ctx context.Context, req any) (any, error) { return nil, nil })
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("call beyond the worker limit: %v, want DeadlineExceeded", err)
+	}
+}
@@ -0,0 +1,128 @@
+package server
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// unixScheme prefixes listen addresses that name a Unix domain socket,
+// e.g. unix:///run/inventory/collector.sock.
+const unixScheme = "unix://"
+
+// isUnixAddr reports whether addr refers to a Unix domain socket.
+func isUnixAddr(addr string) bool {
+	return strings.HasPrefix(addr, unixScheme)
+}
+
+// isLoopbackAddr reports whether the TCP host:port addr only accepts
+// connections from the local host. An empty host listens on all interfaces.
+func isLoopbackAddr(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// checkAdminTLS refuses to serve the admin RPCs in plaintext on addr unless
+// it is a Unix socket or a loopback address.
+func checkAdminTLS(addr string, tlsCfg *tls.Config) error {
+	if tlsCfg != nil || isUnixAddr(addr) || isLoopbackAddr(addr) {
+		return nil
+	}
+	return fmt.Errorf("admin_listen: %s is not a loopback address; set tls_cert_file and tls_key_file to serve admin RPCs on it", addr)
+}
+
+// listen opens a listener for addr, which is either a TCP host:port or a
+// unix:// socket path. A stale socket file left behind by a previous run is
+// removed first; the listener unlinks the socket again when closed.
+func listen(addr string) (net.Listener, error) {
+	if !isUnixAddr(addr) {
+		return net.Listen("tcp", addr)
+	}
+
+	path := strings.TrimPrefix(addr, unixScheme)
+	if path == "" {
+		return nil, fmt.Errorf("empty unix socket path in %q", addr)
+	}
+
+	if fi, err := os.Stat(path); err == nil {
+		if fi.Mode()&fs.ModeSocket == 0 {
+			return nil, fmt.Errorf("%s exists and is not a socket", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("remove stale socket: %w", err)
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("create socket directory: %w", err)
+	}
+
+	return net.Listen("unix", path)
+}
+
+// unixEndpoint returns the endpoint URL advertised for a Unix socket listener.
+// Kratos cannot derive one from a socket address on its own.
+func unixEndpoint(addr string) *url.URL {
+	return &url.URL{Scheme: "unix", Path: strings.TrimPrefix(addr, unixScheme)}
+}
+
+// ErrAddrInUse is returned by CheckListen when something is already
+// listening on the address, typically a running collector.
+var ErrAddrInUse = errors.New("address already in use")
+
+// CheckListen checks that addr could be listened on without disturbing a
+// process that already does: TCP addresses are bound and released, and for
+// Unix sockets the directory must be writable and an existing socket stale.
+func CheckListen(addr string) error {
+	if !isUnixAddr(addr) {
+		l, err := net.Listen("tcp", addr)
+		if err == nil {
+			return l.Close()
+		}
+		host, port, _ := net.SplitHostPort(addr)
+		if ip := net.ParseIP(host); host == "" || ip != nil && ip.IsUnspecified() {
+			host = "localhost"
+		}
+		if c, derr := net.DialTimeout("tcp", net.JoinHostPort(host, port), time.Second); derr == nil {
+			c.Close()
+			return ErrAddrInUse
+		}
+		return err
+	}
+
+	path := strings.TrimPrefix(addr, unixScheme)
+	if err := checkListenAddr(addr); err != nil {
+		return err
+	}
+	if c, err := net.DialTimeout("unix", path, time.Second); err == nil {
+		c.Close()
+		return ErrAddrInUse
+	}
+	dir := filepath.Dir(path)
+	if _, err := os.Stat(dir); errors.Is(err, fs.ErrNotExist) {
+		// listen creates it.
+		return nil
+	}
+	probe, err := os.CreateTemp(dir, ".inventory-check-*")
+	if err != nil {
+		return fmt.Errorf("socket directory %s is not writable: %w", dir, err)
+	}
+	probe.Close()
+	return os.Remove(probe.Name())
+}
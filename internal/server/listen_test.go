@@ -0,0 +1,35 @@
+package server
+
+import (
+	"crypto/tls"
+	"testing"
+)
+
+// TestCheckAdminTLS checks that a plaintext admin listener is only accepted
+// on loopback addresses and Unix sockets.
+func TestCheckAdminTLS(t *testing.T) {
+	for _, tt := range []struct {
+		addr string
+		tls  bool
+		ok   bool
+	}{
+		{"127.0.0.1:9552", false, true},
+		{"[::1]:9552", false, true},
+		{"localhost:9552", false, true},
+		{"unix:///run/inventory/admin.sock", false, true},
+		{":9552", false, false},
+		{"0.0.0.0:9552", false, false},
+		{"10.0.0.5:9552", false, false},
+		{"admin.example.com:9552", false, false},
+		{":9552", true, true},
+		{"10.0.0.5:9552", true, true},
+	} {
+		var tlsCfg *tls.Config
+		if tt.tls {
+			tlsCfg = &tls.Config{}
+		}
+		if err := checkAdminTLS(tt.addr, tlsCfg); (err == nil) != tt.ok {
+			t.Errorf("checkAdminTLS(%q, tls=%v) = %v, want ok=%v", tt.addr, tt.tls, err, tt.ok)
+		}
+	}
+}
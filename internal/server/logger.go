@@ -0,0 +1,49 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	klog "github.com/go-kratos/kratos/v2/log"
+)
+
+// kratosLogger forwards Kratos framework logs to the default slog logger so
+// that they share its level and format.
+type kratosLogger struct{}
+
+func (kratosLogger) Log(level klog.Level, keyvals ...any) error {
+	var lvl slog.Level
+	switch level {
+	case klog.LevelDebug:
+		lvl = slog.LevelDebug
+	case klog.LevelWarn:
+		lvl = slog.LevelWarn
+	case klog.LevelError, klog.LevelFatal:
+		lvl = slog.LevelError
+	default:
+		lvl = slog.LevelInfo
+	}
+
+	logger := slog.Default()
+	if !logger.Enabled(context.Background(), lvl) {
+		return nil
+	}
+
+	var msg string
+	var attrs []any
+	for i := 0; i < len(keyvals); i += 2 {
+		key := fmt.Sprint(keyvals[i])
+		var val any
+		if i+1 < len(keyvals) {
+			val = keyvals[i+1]
+		}
+		if key == klog.DefaultMessageKey {
+			msg = fmt.Sprint(val)
+			continue
+		}
+		attrs = append(attrs, key, val)
+	}
+	logger.Log(context.Background(), lvl, msg, attrs...)
+	return nil
+}
@@ -0,0 +1,90 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// defaultAgentLogBytes and maxAgentLogBytes bound the log output
+	// requested from an agent.
+	defaultAgentLogBytes = 64 << 10
+	maxAgentLogBytes     = 1 << 20
+
+	// agentLogsTimeout is how long GetAgentLogs waits for the agent to
+	// answer.
+	agentLogsTimeout = 20 * time.Second
+)
+
+func (h *Handler) SubmitAgentLogs(ctx context.Context, req *collectorv1.SubmitAgentLogsRequest) (*collectorv1.SubmitAgentLogsResponse, error) {
+	if req.CommandId == "" {
+		return nil, status.Error(codes.InvalidArgument, "command_id is required")
+	}
+	if !h.logs.deliver(ctx, req.CommandId, req.ClientId, req) {
+		return nil, status.Errorf(codes.NotFound, "no logs command %q is awaiting an answer from agent %q", req.CommandId, req.ClientId)
+	}
+	return &collectorv1.SubmitAgentLogsResponse{}, nil
+}
+
+func (a *AdminHandler) GetAgentLogs(ctx context.Context, req *collectorv1.GetAgentLogsRequest) (*collectorv1.GetAgentLogsResponse, error) {
+	maxBytes := req.MaxBytes
+	switch {
+	case maxBytes < 0:
+		return nil, status.Error(codes.InvalidArgument, "max_bytes must not be negative")
+	case maxBytes == 0:
+		maxBytes = defaultAgentLogBytes
+	case maxBytes > maxAgentLogBytes:
+		maxBytes = maxAgentLogBytes
+	}
+
+	site, clientID, err := a.h.resolveAgent(ctx, req.Site, req.ClientId, req.Hostname)
+	if err != nil {
+		return nil, err
+	}
+
+	cmdID := uuid.NewString()
+	answer, done := a.h.logs.expect(cmdID, site, clientID)
+	defer done()
+
+	cmd := &collectorv1.InventoryCommand{
+		CommandId:   cmdID,
+		CommandType: collectorv1.InventoryCommandType_INVENTORY_COMMAND_TYPE_LOGS,
+		MaxLogBytes: maxBytes,
+	}
+	if err := a.h.cmdReg.Send(site, clientID, cmd); err != nil {
+		return nil, status.Errorf(codes.Internal, "send logs command: %v", err)
+	}
+
+	slog.Info("Sent logs command", "client_id", clientID, "site", site, "command_id", cmdID)
+
+	timer := time.NewTimer(agentLogsTimeout)
+	defer timer.Stop()
+	select {
+	case resp := <-answer:
+		if resp.Error != "" {
+			return nil, status.Errorf(codes.FailedPrecondition, "agent cannot send its logs: %s", resp.Error)
+		}
+		logs := resp.Logs
+		if int64(len(logs)) > maxBytes {
+			logs = logs[int64(len(logs))-maxBytes:]
+		}
+		return &collectorv1.GetAgentLogsResponse{
+			Logs:      logs,
+			Truncated: resp.Truncated,
+			ClientId:  clientID,
+		}, nil
+	case <-timer.C:
+		return nil, status.Errorf(codes.DeadlineExceeded,
+			"agent did not send its logs within %s; it may predate logs commands or not allow them", agentLogsTimeout)
+	case <-ctx.Done():
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+}
@@ -0,0 +1,41 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+	"github.com/go-tangra/go-tangra-inventory/internal/tenant"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TestSubmitAgentLogsOtherAgent checks that another agent of the same site
+// cannot answer a LOGS command in place of the agent it was sent to.
+func TestSubmitAgentLogsOtherAgent(t *testing.T) {
+	h := &Handler{logs: newAwaited[*collectorv1.SubmitAgentLogsRequest]()}
+	answer, done := h.logs.expect("cmd-1", "customer-a", "pc-042")
+	defer done()
+	ctx := tenant.NewContext(context.Background(), []string{"customer-a"})
+
+	_, err := h.SubmitAgentLogs(ctx, &collectorv1.SubmitAgentLogsRequest{
+		CommandId: "cmd-1",
+		ClientId:  "pc-043",
+		Logs:      []byte("forged"),
+	})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("logs of another agent: %v, want NotFound", err)
+	}
+
+	if _, err := h.SubmitAgentLogs(ctx, &collectorv1.SubmitAgentLogsRequest{
+		CommandId: "cmd-1",
+		ClientId:  "pc-042",
+		Logs:      []byte("genuine"),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if got := string((<-answer).Logs); got != "genuine" {
+		t.Errorf("received logs %q, want %q", got, "genuine")
+	}
+}
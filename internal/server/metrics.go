@@ -0,0 +1,51 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/go-tangra/go-tangra-inventory/internal/config"
+	"github.com/go-tangra/go-tangra-inventory/internal/metrics"
+	"github.com/go-tangra/go-tangra-inventory/internal/store"
+)
+
+// ServeMetrics serves the Prometheus fleet metrics of db on
+// cfg.MetricsListen until ctx is cancelled.
+func ServeMetrics(ctx context.Context, cfg *config.Config, db *store.Store) error {
+	serve, err := listenMetrics(ctx, cfg, db)
+	if err != nil {
+		return err
+	}
+	return serve()
+}
+
+// listenMetrics opens the metrics listener and returns a function serving
+// on it until ctx is cancelled, so listen errors surface before serving.
+// extra collectors are served along with the fleet metrics.
+func listenMetrics(ctx context.Context, cfg *config.Config, db *store.Store, extra ...prometheus.Collector) (func() error, error) {
+	lis, err := listen(cfg.MetricsListen)
+	if err != nil {
+		return nil, fmt.Errorf("listen metrics on %s: %w", cfg.MetricsListen, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(metrics.Path, metrics.Handler(db, cfg.MetricsToken, extra...))
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	go func() {
+		<-ctx.Done()
+		_ = srv.Shutdown(context.Background())
+	}()
+
+	return func() error {
+		if err := srv.Serve(lis); !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	}, nil
+}
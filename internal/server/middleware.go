@@ -2,7 +2,9 @@ package server
 
 import (
 	"context"
-	"crypto/subtle"
+	"net/http"
+
+	"github.com/go-tangra/go-tangra-inventory/internal/tenant"
 
 	"github.com/go-kratos/kratos/v2/middleware"
 	"github.com/go-kratos/kratos/v2/transport"
@@ -11,13 +13,15 @@ import (
 )
 
 // ApiSecretMiddleware returns a Kratos middleware that validates the X-API-Key
-// HTTP header. An empty secret disables authentication (pass-through).
+// HTTP header against the API secret, a site-bound token or a managed API
+// token. An empty secret with no tokens disables authentication
+// (pass-through).
 // Swagger UI is unaffected because it's registered via HandlePrefix which
-// bypasses the Kratos middleware chain.
-func ApiSecretMiddleware(secret string) middleware.Middleware {
+// bypasses the Kratos middleware chain; see SwaggerAuth for protecting it.
+func ApiSecretMiddleware(secret string, siteTokens tenant.Tokens, apiTokens *APITokens) middleware.Middleware {
 	return func(handler middleware.Handler) middleware.Handler {
 		return func(ctx context.Context, req any) (any, error) {
-			if secret == "" {
+			if secret == "" && len(siteTokens) == 0 && !apiTokens.enabled() {
 				return handler(ctx, req)
 			}
 
@@ -31,11 +35,64 @@ func ApiSecretMiddleware(secret string) middleware.Middleware {
 				return nil, status.Error(codes.Unauthenticated, "missing X-API-Key header")
 			}
 
-			if subtle.ConstantTimeCompare([]byte(key), []byte(secret)) != 1 {
+			scoped, ok := authorize(ctx, key, secret, siteTokens)
+			if !ok {
+				var err error
+				if scoped, ok, err = apiTokens.authorize(ctx, key, tr.Operation()); err != nil {
+					return nil, err
+				}
+			}
+			if !ok {
 				return nil, status.Error(codes.Unauthenticated, "invalid X-API-Key")
 			}
+			ctx = scoped
 
 			return handler(ctx, req)
 		}
 	}
 }
+
+// AdminOnlyMiddleware returns a Kratos middleware that refuses the HTTP
+// routes of management RPCs, by operation, for servers on the public HTTP
+// port when a dedicated admin listener is configured, like
+// AdminOnlyInterceptor does on the gRPC port.
+func AdminOnlyMiddleware() middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req any) (any, error) {
+			if tr, ok := transport.FromServerContext(ctx); ok && adminCollectorMethods[tr.Operation()] {
+				return nil, status.Error(codes.PermissionDenied, "method is only available on the admin listener")
+			}
+			return handler(ctx, req)
+		}
+	}
+}
+
+// apiKeyGuard protects an HTTP handler registered outside the Kratos
+// middleware chain with the X-API-Key rules of ApiSecretMiddleware. Managed
+// API tokens are checked against operation, so agent tokens are refused.
+func apiKeyGuard(next http.Handler, operation, secret string, siteTokens tenant.Tokens, apiTokens *APITokens) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if secret != "" || len(siteTokens) > 0 || apiTokens.enabled() {
+			key := r.Header.Get("X-API-Key")
+			if key == "" {
+				http.Error(w, "missing X-API-Key header", http.StatusUnauthorized)
+				return
+			}
+			ctx, ok := authorize(r.Context(), key, secret, siteTokens)
+			if !ok {
+				var err error
+				if ctx, ok, err = apiTokens.authorize(r.Context(), key, operation); err != nil {
+					http.Error(w, status.Convert(err).Message(), http.StatusForbidden)
+					return
+				}
+			}
+			if !ok {
+				http.Error(w, "invalid X-API-Key", http.StatusUnauthorized)
+				return
+			}
+			r = r.WithContext(ctx)
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,77 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	kratoshttp "github.com/go-kratos/kratos/v2/transport/http"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+	collectorv2 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v2"
+	"github.com/go-tangra/go-tangra-inventory/internal/config"
+)
+
+// newPublicHTTPServer serves the public HTTP routes of cfg with services
+// that answer every call with Unimplemented.
+func newPublicHTTPServer(t *testing.T, cfg *config.Config) *httptest.Server {
+	t.Helper()
+	srv := kratoshttp.NewServer(kratoshttp.Middleware(httpMiddleware(cfg, nil, nil)...))
+	collectorv1.RegisterInventoryCollectorServiceHTTPServer(srv, collectorv1.UnimplementedInventoryCollectorServiceServer{})
+	collectorv2.RegisterInventoryServiceHTTPServer(srv, collectorv2.UnimplementedInventoryServiceServer{})
+	ts := httptest.NewServer(srv)
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+// TestAdminRoutesRefusedOnPublicHTTP checks that with a dedicated admin
+// listener, the management routes are refused on the public HTTP port and
+// the others are still served.
+func TestAdminRoutesRefusedOnPublicHTTP(t *testing.T) {
+	routes := []struct {
+		method, path string
+		admin        bool
+	}{
+		{http.MethodDelete, "/v1/inventories/1", true},
+		{http.MethodPost, "/v1/inventories/refresh", true},
+		{http.MethodGet, "/v1/agents", true},
+		{http.MethodPost, "/v1/agents/pause", true},
+		{http.MethodPost, "/v1/agents/resume", true},
+		{http.MethodGet, "/v2/agents", true},
+		{http.MethodGet, "/v1/inventories", false},
+		{http.MethodGet, "/v2/hosts", false},
+	}
+
+	for _, tc := range []struct {
+		name        string
+		adminListen string
+	}{
+		{"AdminListener", "127.0.0.1:9552"},
+		{"SharedPort", ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := newPublicHTTPServer(t, &config.Config{AdminListen: tc.adminListen})
+			for _, r := range routes {
+				req, err := http.NewRequest(r.method, ts.URL+r.path, strings.NewReader("{}"))
+				if err != nil {
+					t.Fatal(err)
+				}
+				req.Header.Set("Content-Type", "application/json")
+				resp, err := http.DefaultClient.Do(req)
+				if err != nil {
+					t.Fatal(err)
+				}
+				resp.Body.Close()
+
+				want := http.StatusNotImplemented
+				if r.admin && tc.adminListen != "" {
+					want = http.StatusForbidden
+				}
+				if resp.StatusCode != want {
+					t.Errorf("%s %s: status %d, want %d", r.method, r.path, resp.StatusCode, want)
+				}
+			}
+		})
+	}
+}
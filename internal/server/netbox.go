@@ -0,0 +1,74 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"github.com/go-tangra/go-tangra-inventory/internal/config"
+	"github.com/go-tangra/go-tangra-inventory/internal/logging"
+	"github.com/go-tangra/go-tangra-inventory/internal/netbox"
+	"github.com/go-tangra/go-tangra-inventory/internal/store"
+)
+
+// NewNetBoxSyncer builds the NetBox device synchronization from config, or
+// returns nil when it is disabled.
+func NewNetBoxSyncer(cfg *config.Config, db *store.Store) (*netbox.Syncer, error) {
+	if cfg.NetBoxURL == "" {
+		return nil, nil
+	}
+	if u, err := url.Parse(cfg.NetBoxURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, errors.New("netbox_url: not an http or https URL")
+	}
+	if cfg.NetBoxToken == "" {
+		return nil, errors.New("netbox_token: required with netbox_url")
+	}
+	if cfg.NetBoxInterval <= 0 {
+		return nil, errors.New("netbox_interval: must be positive")
+	}
+	if len(cfg.NetBoxSites) == 0 {
+		return nil, errors.New(`netbox_sites: configure at least one site, or "*" for all`)
+	}
+
+	sites := make([]netbox.Site, len(cfg.NetBoxSites))
+	for i, s := range cfg.NetBoxSites {
+		sites[i] = netbox.Site{
+			Name:     s.Site,
+			SiteID:   s.SiteID,
+			RoleID:   s.RoleID,
+			Roles:    s.Roles,
+			TenantID: s.TenantID,
+			Status:   s.Status,
+		}
+	}
+	syncer, err := netbox.NewSyncer(netbox.NewClient(cfg.NetBoxURL, cfg.NetBoxToken), db, sites)
+	if err != nil {
+		return nil, fmt.Errorf("netbox_sites: %w", err)
+	}
+	return syncer, nil
+}
+
+// runNetBoxLoop synchronizes the devices at startup and then every
+// interval.
+func runNetBoxLoop(ctx context.Context, syncer *netbox.Syncer, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		res, err := syncer.Sync(ctx, false)
+		if err != nil && ctx.Err() == nil {
+			slog.Error("NetBox sync failed", logging.Err(err))
+		} else if res.Created > 0 || res.Updated > 0 || res.Skipped > 0 {
+			slog.Info("Synchronized NetBox devices", "created", res.Created, "updated", res.Updated, "skipped", res.Skipped)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
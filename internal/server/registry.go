@@ -10,67 +10,112 @@ import (
 
 const commandChannelBufferSize = 16
 
+// watchChannelBufferSize bounds the events queued for a watcher before it is
+// dropped as too slow.
+const watchChannelBufferSize = 256
+
+// agentKey identifies a connected agent. Client IDs are system UUIDs, or
+// hostnames for agents without one, and are only assumed unique within a site.
+type agentKey struct {
+	site     string
+	clientID string
+}
+
 // connectedAgent holds the command channel and metadata for a connected agent.
 type connectedAgent struct {
 	ch          chan *collectorv1.InventoryCommand
+	hostname    string
 	version     string
 	connectedAt time.Time
+	paused      bool
+	// lastSubmittedAt is zero until the agent submits while connected.
+	lastSubmittedAt time.Time
 }
 
 // ConnectedAgentInfo is a read-only snapshot of a connected agent's metadata.
 type ConnectedAgentInfo struct {
+	Site        string
 	ClientID    string
+	Hostname    string
 	Version     string
 	ConnectedAt time.Time
+	Paused      bool
+	// LastSubmittedAt is zero until the agent submits while connected.
+	LastSubmittedAt time.Time
+}
+
+// AgentEvent is a change to a connected agent, with its state after the
+// change.
+type AgentEvent struct {
+	Type  collectorv1.AgentEventType
+	Agent ConnectedAgentInfo
+	Time  time.Time
 }
 
 // CommandRegistry manages in-memory command channels for connected agents.
 type CommandRegistry struct {
 	mu     sync.RWMutex
-	agents map[string]*connectedAgent
+	agents map[agentKey]*connectedAgent
+
+	// update is the advertised agent release, limited to updateSite when
+	// that is non-empty.
+	update     *collectorv1.AgentUpdate
+	updateSite string
+
+	watchers map[chan AgentEvent]struct{}
 }
 
 // NewCommandRegistry creates a new CommandRegistry.
 func NewCommandRegistry() *CommandRegistry {
 	return &CommandRegistry{
-		agents: make(map[string]*connectedAgent),
+		agents:   make(map[agentKey]*connectedAgent),
+		watchers: make(map[chan AgentEvent]struct{}),
 	}
 }
 
-// Register creates a buffered channel for the given agent.
-// If one already exists, it is closed first.
-func (r *CommandRegistry) Register(clientID, version string) <-chan *collectorv1.InventoryCommand {
+// Register creates a buffered channel for the given agent, which reports
+// whether its collection is paused. If one already exists, it is closed first.
+func (r *CommandRegistry) Register(site, clientID, hostname, version string, paused bool) <-chan *collectorv1.InventoryCommand {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if old, ok := r.agents[clientID]; ok {
+	key := agentKey{site: site, clientID: clientID}
+	if old, ok := r.agents[key]; ok {
 		close(old.ch)
+		r.publish(collectorv1.AgentEventType_AGENT_EVENT_TYPE_DISCONNECTED, key, old)
 	}
 	ch := make(chan *collectorv1.InventoryCommand, commandChannelBufferSize)
-	r.agents[clientID] = &connectedAgent{
+	a := &connectedAgent{
 		ch:          ch,
+		hostname:    hostname,
 		version:     version,
 		connectedAt: time.Now(),
+		paused:      paused,
 	}
+	r.agents[key] = a
+	r.publish(collectorv1.AgentEventType_AGENT_EVENT_TYPE_CONNECTED, key, a)
 	return ch
 }
 
-// Unregister closes and removes the channel for the given agent.
-func (r *CommandRegistry) Unregister(clientID string) {
+// Unregister closes and removes the channel ch returned by Register. It is a
+// no-op when the agent has since re-registered with a new channel.
+func (r *CommandRegistry) Unregister(site, clientID string, ch <-chan *collectorv1.InventoryCommand) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if a, ok := r.agents[clientID]; ok {
+	key := agentKey{site: site, clientID: clientID}
+	if a, ok := r.agents[key]; ok && a.ch == ch {
 		close(a.ch)
-		delete(r.agents, clientID)
+		delete(r.agents, key)
+		r.publish(collectorv1.AgentEventType_AGENT_EVENT_TYPE_DISCONNECTED, key, a)
 	}
 }
 
 // Send sends an inventory command to a connected agent.
 // Returns an error if the agent is not connected or the channel is full.
-func (r *CommandRegistry) Send(clientID string, cmd *collectorv1.InventoryCommand) error {
+func (r *CommandRegistry) Send(site, clientID string, cmd *collectorv1.InventoryCommand) error {
 	r.mu.RLock()
-	a, ok := r.agents[clientID]
+	a, ok := r.agents[agentKey{site: site, clientID: clientID}]
 	r.mu.RUnlock()
 
 	if !ok {
@@ -86,25 +131,159 @@ func (r *CommandRegistry) Send(clientID string, cmd *collectorv1.InventoryComman
 }
 
 // IsConnected checks whether an agent has an active channel.
-func (r *CommandRegistry) IsConnected(clientID string) bool {
+func (r *CommandRegistry) IsConnected(site, clientID string) bool {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
-	_, ok := r.agents[clientID]
+	_, ok := r.agents[agentKey{site: site, clientID: clientID}]
 	return ok
 }
 
+// SetPaused records the paused state of a connected agent. It is a no-op when
+// the agent is not connected.
+func (r *CommandRegistry) SetPaused(site, clientID string, paused bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := agentKey{site: site, clientID: clientID}
+	if a, ok := r.agents[key]; ok && a.paused != paused {
+		a.paused = paused
+		r.publish(collectorv1.AgentEventType_AGENT_EVENT_TYPE_UPDATED, key, a)
+	}
+}
+
+// Submitted records that an inventory of the host with systemUUID and
+// hostname was stored for site at t, for the agents connected as that host.
+func (r *CommandRegistry) Submitted(site, systemUUID, hostname string, t time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key, a := range r.agents {
+		if key.site != site {
+			continue
+		}
+		if (systemUUID != "" && key.clientID == systemUUID) || key.clientID == hostname || a.hostname == hostname {
+			a.lastSubmittedAt = t
+			r.publish(collectorv1.AgentEventType_AGENT_EVENT_TYPE_SUBMITTED, key, a)
+		}
+	}
+}
+
+// FindByHostname returns the client IDs of the agents connected for site
+// under hostname.
+func (r *CommandRegistry) FindByHostname(site, hostname string) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var ids []string
+	for key, a := range r.agents {
+		if key.site == site && a.hostname == hostname {
+			ids = append(ids, key.clientID)
+		}
+	}
+	return ids
+}
+
 // ListConnected returns a snapshot of all currently connected agents.
 func (r *CommandRegistry) ListConnected() []ConnectedAgentInfo {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
 	result := make([]ConnectedAgentInfo, 0, len(r.agents))
-	for id, a := range r.agents {
-		result = append(result, ConnectedAgentInfo{
-			ClientID:    id,
-			Version:     a.version,
-			ConnectedAt: a.connectedAt,
-		})
+	for key, a := range r.agents {
+		result = append(result, a.info(key))
 	}
 	return result
 }
+
+// Watch returns a snapshot of the connected agents and a channel of the
+// events that follow it, until stop is called. A watcher that falls behind
+// by more than watchChannelBufferSize events has its channel closed.
+func (r *CommandRegistry) Watch() (snapshot []ConnectedAgentInfo, events <-chan AgentEvent, stop func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key, a := range r.agents {
+		snapshot = append(snapshot, a.info(key))
+	}
+	ch := make(chan AgentEvent, watchChannelBufferSize)
+	r.watchers[ch] = struct{}{}
+	return snapshot, ch, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if _, ok := r.watchers[ch]; ok {
+			delete(r.watchers, ch)
+			close(ch)
+		}
+	}
+}
+
+// publish sends an event about agent a to the watchers. r.mu must be held.
+func (r *CommandRegistry) publish(typ collectorv1.AgentEventType, key agentKey, a *connectedAgent) {
+	if len(r.watchers) == 0 {
+		return
+	}
+	ev := AgentEvent{Type: typ, Agent: a.info(key), Time: time.Now()}
+	for ch := range r.watchers {
+		select {
+		case ch <- ev:
+		default:
+			delete(r.watchers, ch)
+			close(ch)
+		}
+	}
+}
+
+func (a *connectedAgent) info(key agentKey) ConnectedAgentInfo {
+	return ConnectedAgentInfo{
+		Site:            key.site,
+		ClientID:        key.clientID,
+		Hostname:        a.hostname,
+		Version:         a.version,
+		ConnectedAt:     a.connectedAt,
+		Paused:          a.paused,
+		LastSubmittedAt: a.lastSubmittedAt,
+	}
+}
+
+// AdvertiseUpdate records u as the current agent release for site (empty =
+// all sites) and returns the connected agents that run another version. A nil
+// u clears the advertisement.
+func (r *CommandRegistry) AdvertiseUpdate(u *collectorv1.AgentUpdate, site string) []ConnectedAgentInfo {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.update, r.updateSite = u, site
+	if u == nil {
+		return nil
+	}
+
+	var outdated []ConnectedAgentInfo
+	for key, a := range r.agents {
+		if r.updateFor(key.site, a.version) != nil {
+			outdated = append(outdated, ConnectedAgentInfo{
+				Site:        key.site,
+				ClientID:    key.clientID,
+				Version:     a.version,
+				ConnectedAt: a.connectedAt,
+			})
+		}
+	}
+	return outdated
+}
+
+// PendingUpdate returns the advertised release if an agent of the given site
+// running version should install it, or nil.
+func (r *CommandRegistry) PendingUpdate(site, version string) *collectorv1.AgentUpdate {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.updateFor(site, version)
+}
+
+func (r *CommandRegistry) updateFor(site, version string) *collectorv1.AgentUpdate {
+	if r.update == nil || r.update.Version == version {
+		return nil
+	}
+	if r.updateSite != "" && r.updateSite != site {
+		return nil
+	}
+	return r.update
+}
@@ -0,0 +1,111 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/mail"
+	"slices"
+	"time"
+
+	"github.com/go-tangra/go-tangra-inventory/internal/config"
+	"github.com/go-tangra/go-tangra-inventory/internal/logging"
+	"github.com/go-tangra/go-tangra-inventory/internal/report"
+	"github.com/go-tangra/go-tangra-inventory/internal/store"
+)
+
+// NewReportJobs builds the SMTP server and the scheduled email reports from
+// config, or returns no jobs when none are configured.
+func NewReportJobs(cfg *config.Config) (*report.SMTP, []report.Job, error) {
+	if len(cfg.EmailReports) == 0 {
+		return nil, nil, nil
+	}
+	if cfg.SMTPHost == "" {
+		return nil, nil, errors.New("smtp_host: required with email_reports")
+	}
+	smtp := &report.SMTP{
+		Host:     cfg.SMTPHost,
+		Port:     cfg.SMTPPort,
+		Security: cfg.SMTPSecurity,
+		Username: cfg.SMTPUsername,
+		Password: cfg.SMTPPassword,
+		From:     cfg.SMTPFrom,
+	}
+	if err := smtp.Check(); err != nil {
+		return nil, nil, fmt.Errorf("smtp: %w", err)
+	}
+
+	jobs := make([]report.Job, len(cfg.EmailReports))
+	names := make(map[string]bool)
+	for i, r := range cfg.EmailReports {
+		key := fmt.Sprintf("email_reports[%d]", i)
+		if r.Name == "" {
+			return nil, nil, fmt.Errorf("%s.name: required", key)
+		}
+		if names[r.Name] {
+			return nil, nil, fmt.Errorf("%s.name: %q is used twice", key, r.Name)
+		}
+		names[r.Name] = true
+		if len(r.To) == 0 {
+			return nil, nil, fmt.Errorf("%s.to: at least one recipient is required", key)
+		}
+		for _, to := range r.To {
+			if _, err := mail.ParseAddress(to); err != nil {
+				return nil, nil, fmt.Errorf("%s.to: %q: %w", key, to, err)
+			}
+		}
+		if len(r.Reports) == 0 {
+			return nil, nil, fmt.Errorf("%s.reports: list at least one of %v", key, report.Kinds)
+		}
+		for _, kind := range r.Reports {
+			if !slices.Contains(report.Kinds, kind) {
+				return nil, nil, fmt.Errorf("%s.reports: unknown report %q (use %v)", key, kind, report.Kinds)
+			}
+		}
+		if r.StaleDays < 0 {
+			return nil, nil, fmt.Errorf("%s.stale_days: must not be negative", key)
+		}
+		schedule, err := report.ParseSchedule(r.Schedule)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s.schedule: %w", key, err)
+		}
+
+		var sites []string
+		if len(r.Sites) > 0 {
+			sites = r.Sites
+		}
+		jobs[i] = report.Job{
+			Spec: report.Spec{
+				Name:       r.Name,
+				Kinds:      r.Reports,
+				Sites:      sites,
+				StaleAfter: time.Duration(r.StaleDays) * 24 * time.Hour,
+			},
+			To:       r.To,
+			Schedule: schedule,
+		}
+	}
+	return smtp, jobs, nil
+}
+
+// runReportLoop mails the report of job at each scheduled time. Times
+// missed while the collector was down are skipped.
+func runReportLoop(ctx context.Context, db *store.Store, smtp *report.SMTP, job report.Job) {
+	for {
+		next := job.Schedule.Next(time.Now())
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		if err := report.Mail(ctx, smtp, db, job); err != nil && ctx.Err() == nil {
+			slog.Error("Sending email report failed", "report", job.Spec.Name, logging.Err(err))
+		} else if err == nil {
+			slog.Info("Sent email report", "report", job.Spec.Name, "to", job.To)
+		}
+	}
+}
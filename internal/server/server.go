@@ -2,79 +2,340 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"net"
+	"slices"
 	"time"
 
+	klog "github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
 	kratoshttp "github.com/go-kratos/kratos/v2/transport/http"
+	"github.com/prometheus/client_golang/prometheus"
 	swaggerUI "github.com/tx7do/kratos-swagger-ui"
 
 	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
-	_ "github.com/go-tangra/go-tangra-inventory/internal/codec" // register custom JSON codec (uint64 as numbers)
+	collectorv2 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v2"
+	"github.com/go-tangra/go-tangra-inventory/internal/alert"
+	"github.com/go-tangra/go-tangra-inventory/internal/codec"         // registers the REST JSON codec (uint64 as numbers)
+	_ "github.com/go-tangra/go-tangra-inventory/internal/compression" // register gzip and zstd gRPC compressors
 	"github.com/go-tangra/go-tangra-inventory/internal/config"
+	"github.com/go-tangra/go-tangra-inventory/internal/kafka"
+	"github.com/go-tangra/go-tangra-inventory/internal/logging"
+	"github.com/go-tangra/go-tangra-inventory/internal/metrics"
+	"github.com/go-tangra/go-tangra-inventory/internal/mqtt"
+	"github.com/go-tangra/go-tangra-inventory/internal/nats"
+	"github.com/go-tangra/go-tangra-inventory/internal/opensearch"
+	"github.com/go-tangra/go-tangra-inventory/internal/outbox"
 	"github.com/go-tangra/go-tangra-inventory/internal/store"
+	"github.com/go-tangra/go-tangra-inventory/internal/tenant"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/reflection"
 )
 
+// agentKeepaliveMinTime is the shortest keepalive ping interval accepted
+// from agents; they ping every 30 seconds.
+const agentKeepaliveMinTime = 15 * time.Second
+
 // Run starts the gRPC and HTTP servers and blocks until the context is cancelled.
 func Run(ctx context.Context, cfg *config.Config, openApiData []byte) error {
-	db, err := store.New(cfg.DatabasePath)
+	klog.SetLogger(kratosLogger{})
+
+	open := store.New
+	if !cfg.AutoMigrate {
+		open = store.OpenCurrent
+	}
+	db, err := open(cfg.DatabasePath)
 	if err != nil {
 		return fmt.Errorf("open database: %w", err)
 	}
 	defer db.Close()
+	db.CacheLatest(cfg.LatestCacheSize, cfg.LatestCacheTTL)
+	db.CacheCounts(cfg.ListCountCacheTTL)
+	db.PurgeBatches(cfg.PurgeBatchSize, cfg.PurgeBatchPause)
+
+	siteTokens, err := newSiteTokens(cfg.SiteTokens)
+	if err != nil {
+		return err
+	}
+
+	ipFilter, err := NewIPFilter(cfg.AgentAllowCIDRs, cfg.AgentDenyCIDRs)
+	if err != nil {
+		return err
+	}
+
+	apiTokens, err := NewAPITokens(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	snipeIT, err := NewSnipeITSyncer(cfg, db)
+	if err != nil {
+		return err
+	}
+
+	netBox, err := NewNetBoxSyncer(cfg, db)
+	if err != nil {
+		return err
+	}
+
+	backuper, err := NewBackuper(cfg, db)
+	if err != nil {
+		return err
+	}
+
+	enricher, err := NewDirectoryEnricher(cfg, db)
+	if err != nil {
+		return err
+	}
+
+	smtp, reportJobs, err := NewReportJobs(cfg)
+	if err != nil {
+		return err
+	}
+
+	events, err := newEventRelays(cfg, db)
+	if err != nil {
+		return err
+	}
+	sinks := make([]string, len(events))
+	for i, r := range events {
+		sinks[i] = r.Name()
+	}
+	if n, err := db.PruneOutbox(ctx, sinks); err != nil {
+		return err
+	} else if n > 0 {
+		slog.Warn("Discarded unpublished events of disabled sinks", "count", n)
+	}
+
+	alerts, err := newAlertEngine(cfg, db)
+	if err != nil {
+		return err
+	}
 
 	cmdReg := NewCommandRegistry()
-	handler := NewHandler(db, cmdReg)
+	handler := NewHandler(db, cmdReg, alerts, events)
+	if cfg.WriteQueueSize > 0 {
+		handler.queueWrites(ctx, cfg.WriteQueueSize, max(1, cfg.WriteBatchSize))
+	}
+	handler.limitSubmissions(submitLimits{
+		maxBytes:     cfg.SubmitMaxBytes,
+		maxClockSkew: cfg.SubmitMaxClockSkew,
+		maxAge:       cfg.SubmitMaxAge,
+	})
+
+	snmpPoller, err := NewSNMPPoller(cfg, handler)
+	if err != nil {
+		return err
+	}
 
-	// gRPC server with auth interceptors (unary + stream).
-	grpcSrv := grpc.NewServer(
-		grpc.ChainUnaryInterceptor(AuthInterceptor(cfg.ClientSecret, cfg.ApiSecret)),
-		grpc.ChainStreamInterceptor(AuthStreamInterceptor(cfg.ClientSecret, cfg.ApiSecret)),
-	)
+	// gRPC server with auth interceptors (unary + stream). Agent RPCs are
+	// checked against the source address filter first. With a dedicated
+	// admin listener, management RPCs are refused on this port.
+	var unary []grpc.UnaryServerInterceptor
+	var stream []grpc.StreamServerInterceptor
+	if ipFilter != nil {
+		unary = append(unary, SourceIPInterceptor(ipFilter))
+		stream = append(stream, SourceIPStreamInterceptor(ipFilter))
+	}
+	unary = append(unary, AuthInterceptor(cfg.ClientSecret, cfg.ApiSecret, siteTokens, apiTokens))
+	stream = append(stream, AuthStreamInterceptor(cfg.ClientSecret, cfg.ApiSecret, siteTokens, apiTokens))
+	if cfg.AdminListen != "" {
+		unary = append(unary, AdminOnlyInterceptor())
+	}
+	if cfg.GRPCWorkers > 0 {
+		unary = append(unary, WorkerInterceptor(cfg.GRPCWorkers))
+	}
+	grpcOpts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+		// Agents ping idle command streams; accept their keepalives
+		// instead of closing the connection for pinging too often.
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             agentKeepaliveMinTime,
+			PermitWithoutStream: true,
+		}),
+	}
+	if cfg.GRPCMaxConcurrentStreams > 0 {
+		grpcOpts = append(grpcOpts, grpc.MaxConcurrentStreams(cfg.GRPCMaxConcurrentStreams))
+	}
+	// gRPC refuses messages over 4 MiB by default; make room for larger
+	// inventories if they are allowed.
+	if cfg.SubmitMaxBytes > defaultGRPCMaxRecvMsgSize {
+		grpcOpts = append(grpcOpts, grpc.MaxRecvMsgSize(cfg.SubmitMaxBytes+submitRequestOverhead))
+	}
+	tlsCfg, err := serverTLS(cfg)
+	if err != nil {
+		return err
+	}
+	if tlsCfg != nil {
+		grpcOpts = append(grpcOpts, grpc.Creds(credentials.NewTLS(tlsCfg)))
+	}
+	grpcSrv := grpc.NewServer(grpcOpts...)
 	collectorv1.RegisterInventoryCollectorServiceServer(grpcSrv, handler)
+	v2 := NewV2Handler(handler)
+	collectorv2.RegisterInventoryServiceServer(grpcSrv, v2)
+	if cfg.AdminListen == "" {
+		collectorv1.RegisterInventoryAdminServiceServer(grpcSrv, NewAdminHandler(handler, apiTokens))
+	}
 	reflection.Register(grpcSrv)
 
-	lis, err := net.Listen("tcp", cfg.Listen)
+	lis, err := listen(cfg.Listen)
 	if err != nil {
 		return fmt.Errorf("listen gRPC on %s: %w", cfg.Listen, err)
 	}
+	lis = limitConnections(lis, cfg.GRPCMaxConnections, cfg.GRPCMaxConnectionsPerIP)
 
 	// Graceful shutdown when the caller cancels the context.
 	go func() {
 		<-ctx.Done()
-		log.Println("Shutting down...")
+		slog.Info("Shutting down")
 		grpcSrv.GracefulStop()
 	}()
 
+	// Optional admin gRPC server on its own listener, accepting only the
+	// admin secret.
+	if cfg.AdminListen != "" {
+		if err := startAdminServer(ctx, cfg, tlsCfg, handler, apiTokens); err != nil {
+			return err
+		}
+	}
+
 	// Optional retention purge goroutine.
 	if cfg.RetentionDays > 0 {
 		go runPurgeLoop(ctx, db, cfg.RetentionDays, cfg.PurgeInterval)
 	}
 
-	// HTTP server with API-secret middleware and service routes.
-	httpSrv := kratoshttp.NewServer(
+	// Optional Snipe-IT asset synchronization goroutine.
+	if snipeIT != nil {
+		go runSnipeITLoop(ctx, snipeIT, cfg.SnipeITInterval)
+	}
+
+	// Optional NetBox device synchronization goroutine.
+	if netBox != nil {
+		go runNetBoxLoop(ctx, netBox, cfg.NetBoxInterval)
+	}
+
+	// Optional object storage backup goroutine.
+	if backuper != nil {
+		go runBackupLoop(ctx, backuper, cfg.BackupInterval)
+	}
+
+	// Optional directory enrichment goroutine.
+	if enricher != nil {
+		go runDirectoryLoop(ctx, enricher, cfg.LDAPInterval)
+	}
+
+	// Optional email report goroutines, one per report.
+	for _, job := range reportJobs {
+		go runReportLoop(ctx, db, smtp, job)
+	}
+
+	// Optional Prometheus metrics server on its own listener.
+	if cfg.MetricsListen != "" {
+		var ingest []prometheus.Collector
+		if handler.writes != nil {
+			ingest = append(ingest, handler.writes)
+		}
+		serveMetrics, err := listenMetrics(ctx, cfg, db, ingest...)
+		if err != nil {
+			return err
+		}
+		go func() {
+			if err := serveMetrics(); err != nil {
+				slog.Error("Metrics server error", logging.Err(err))
+			}
+		}()
+	}
+
+	// Optional SNMP polling goroutine.
+	if snmpPoller != nil {
+		go runSNMPLoop(ctx, snmpPoller, cfg.SNMPInterval)
+	}
+
+	// Optional event publishing goroutines (Kafka, MQTT, NATS, OpenSearch).
+	for _, r := range events {
+		go r.Run(ctx)
+	}
+	if slices.ContainsFunc(events, func(r *outbox.Relay) bool { return r.Wants(outbox.AgentConnected) }) {
+		go handler.queueAgentEvents(ctx)
+	}
+
+	// HTTP server with API-secret middleware and service routes, optionally
+	// mounted under a base path for reverse proxies. With a dedicated admin
+	// listener, management routes are refused here too.
+	basePath := NormalizeBasePath(cfg.HTTPBasePath)
+	codec.UseProtoNames(cfg.JSONProtoNames)
+	httpOpts := []kratoshttp.ServerOption{
 		kratoshttp.Address(cfg.HTTPListen),
-		kratoshttp.Middleware(ApiSecretMiddleware(cfg.ApiSecret)),
-	)
+		kratoshttp.Middleware(httpMiddleware(cfg, siteTokens, apiTokens)...),
+	}
+	if basePath != "" {
+		httpOpts = append(httpOpts, kratoshttp.PathPrefix(basePath))
+	}
+	if isUnixAddr(cfg.HTTPListen) {
+		httpLis, err := listen(cfg.HTTPListen)
+		if err != nil {
+			return fmt.Errorf("listen HTTP on %s: %w", cfg.HTTPListen, err)
+		}
+		httpOpts = append(httpOpts,
+			kratoshttp.Listener(httpLis),
+			kratoshttp.Endpoint(unixEndpoint(cfg.HTTPListen)),
+		)
+	}
+	httpSrv := kratoshttp.NewServer(httpOpts...)
 	collectorv1.RegisterInventoryCollectorServiceHTTPServer(httpSrv, handler)
+	collectorv2.RegisterInventoryServiceHTTPServer(httpSrv, v2)
+
+	// Ansible dynamic inventory (registered via Handle — guarded separately).
+	httpSrv.Handle(ansiblePath, newAnsibleHandler(db, cfg.ApiSecret, siteTokens, apiTokens))
 
-	// Swagger UI (registered via HandlePrefix — bypasses middleware chain).
+	// Streaming inventory export (registered via Handle — guarded
+	// separately).
+	httpSrv.Handle(exportPath, newExportHandler(db, cfg.ApiSecret, siteTokens, apiTokens))
+
+	// Zabbix low-level discovery (registered via HandlePrefix — guarded
+	// separately).
+	httpSrv.HandlePrefix(zabbixPath, newZabbixHandler(db, cfg.ApiSecret, siteTokens, apiTokens))
+
+	// Grafana JSON datasource (registered via HandlePrefix — guarded
+	// separately).
+	httpSrv.HandlePrefix(grafanaPath, newGrafanaHandler(db, cfg.ApiSecret, siteTokens, apiTokens))
+
+	// Optional GraphQL endpoint (registered via Handle — guarded separately).
+	if cfg.EnableGraphQL {
+		gqlHandler, err := newGraphQLHandler(db, cfg.ApiSecret, siteTokens, apiTokens)
+		if err != nil {
+			return err
+		}
+		httpSrv.Handle(graphQLPath, gqlHandler)
+		slog.Info("GraphQL endpoint available", "url", "http://"+cfg.HTTPListen+basePath+graphQLPath)
+	}
+
+	// Swagger UI (registered via HandlePrefix — bypasses middleware chain,
+	// so it is guarded separately according to swagger_auth).
 	if cfg.EnableSwagger && len(openApiData) > 0 {
+		guard, err := SwaggerAuth(cfg.SwaggerAuth, cfg.SwaggerUsername, cfg.SwaggerPassword, cfg.ApiSecret)
+		if err != nil {
+			return err
+		}
 		swaggerUI.RegisterSwaggerUIServerWithOption(
-			httpSrv,
+			swaggerRouter{srv: httpSrv, basePath: basePath, guard: guard},
 			swaggerUI.WithTitle("Inventory Collector"),
-			swaggerUI.WithMemoryData(openApiData, "yaml"),
+			swaggerUI.WithBasePath(basePath+"/docs/"),
+			swaggerUI.WithMemoryData(openAPIWithBasePath(openApiData, basePath), "yaml"),
 		)
-		log.Printf("Swagger UI available at http://%s/docs/", cfg.HTTPListen)
+		slog.Info("Swagger UI available", "url", "http://"+cfg.HTTPListen+basePath+"/docs/")
 	}
 
 	go func() {
 		if err := httpSrv.Start(ctx); err != nil {
-			log.Printf("HTTP server error: %v", err)
+			slog.Error("HTTP server error", logging.Err(err))
 		}
 	}()
 
@@ -83,14 +344,225 @@ func Run(ctx context.Context, cfg *config.Config, openApiData []byte) error {
 		_ = httpSrv.Stop(context.Background())
 	}()
 
-	log.Printf("Inventory Collector gRPC listening on %s (db: %s)", cfg.Listen, cfg.DatabasePath)
+	security := "plaintext"
+	if cfg.TLSClientCAFile != "" {
+		security = "mutual TLS"
+	} else if cfg.TLSCertFile != "" {
+		security = "TLS"
+	}
+	slog.Info("Inventory Collector gRPC listening", "addr", cfg.Listen, "transport", security, "db", cfg.DatabasePath)
 	if cfg.RetentionDays > 0 {
-		log.Printf("Retention: %d days, purge interval: %s", cfg.RetentionDays, cfg.PurgeInterval)
+		slog.Info("Retention enabled", "days", cfg.RetentionDays, "purge_interval", cfg.PurgeInterval)
+	}
+	if snipeIT != nil {
+		slog.Info("Snipe-IT sync enabled", "url", cfg.SnipeITURL, "interval", cfg.SnipeITInterval)
+	}
+	if netBox != nil {
+		slog.Info("NetBox sync enabled", "url", cfg.NetBoxURL, "interval", cfg.NetBoxInterval)
+	}
+	if backuper != nil {
+		slog.Info("Backups enabled", "url", cfg.BackupURL, "interval", cfg.BackupInterval)
+	}
+	if enricher != nil {
+		slog.Info("Directory enrichment enabled", "url", cfg.LDAPURL, "interval", cfg.LDAPInterval)
+	}
+	for _, job := range reportJobs {
+		slog.Info("Email report scheduled", "report", job.Spec.Name, "next", job.Schedule.Next(time.Now()).Format(time.RFC3339))
+	}
+	if snmpPoller != nil {
+		slog.Info("SNMP polling enabled", "targets", len(cfg.SNMPTargets), "interval", cfg.SNMPInterval)
+	}
+	if cfg.MetricsListen != "" {
+		slog.Info("Prometheus metrics available", "addr", cfg.MetricsListen, "path", metrics.Path)
+	}
+	if len(cfg.KafkaBrokers) > 0 {
+		slog.Info("Kafka publishing enabled", "brokers", cfg.KafkaBrokers, "topic", cfg.KafkaTopic, "encoding", cfg.KafkaEncoding)
+	}
+	if cfg.MQTTBroker != "" {
+		slog.Info("MQTT publishing enabled", "broker", cfg.MQTTBroker, "topic", cfg.MQTTTopic)
+	}
+	if cfg.NATSURL != "" {
+		slog.Info("NATS JetStream publishing enabled", "stream", cfg.NATSStream, "subject_prefix", cfg.NATSSubjectPrefix)
+	}
+	if cfg.OpenSearchURL != "" {
+		slog.Info("OpenSearch indexing enabled", "index", cfg.OpenSearchIndex)
 	}
 
 	return grpcSrv.Serve(lis)
 }
 
+// httpMiddleware returns the middleware chain of the public HTTP server:
+// API key checks and, with a dedicated admin listener, the refusal of
+// management routes.
+func httpMiddleware(cfg *config.Config, siteTokens tenant.Tokens, apiTokens *APITokens) []middleware.Middleware {
+	chain := []middleware.Middleware{ApiSecretMiddleware(cfg.ApiSecret, siteTokens, apiTokens)}
+	if cfg.AdminListen != "" {
+		chain = append(chain, AdminOnlyMiddleware())
+	}
+	return chain
+}
+
+// startAdminServer serves InventoryAdminService on cfg.AdminListen until ctx
+// is cancelled. admin_secret takes precedence over api_secret for this port;
+// of the managed API tokens only admin tokens are accepted. The listener uses
+// the same TLS configuration as the public gRPC listener; without one it is
+// only served on a loopback address or a Unix socket.
+func startAdminServer(ctx context.Context, cfg *config.Config, tlsCfg *tls.Config, handler *Handler, apiTokens *APITokens) error {
+	if err := checkAdminTLS(cfg.AdminListen, tlsCfg); err != nil {
+		return err
+	}
+	secret := cfg.AdminSecret
+	if secret == "" {
+		secret = cfg.ApiSecret
+	}
+
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(AuthInterceptor("", secret, nil, apiTokens)),
+		grpc.ChainStreamInterceptor(AuthStreamInterceptor("", secret, nil, apiTokens)),
+	}
+	if tlsCfg != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsCfg)))
+	}
+	adminSrv := grpc.NewServer(opts...)
+	collectorv1.RegisterInventoryAdminServiceServer(adminSrv, NewAdminHandler(handler, apiTokens))
+	reflection.Register(adminSrv)
+
+	lis, err := listen(cfg.AdminListen)
+	if err != nil {
+		return fmt.Errorf("listen admin gRPC on %s: %w", cfg.AdminListen, err)
+	}
+
+	go func() {
+		<-ctx.Done()
+		adminSrv.GracefulStop()
+	}()
+
+	go func() {
+		if err := adminSrv.Serve(lis); err != nil {
+			slog.Error("Admin gRPC server error", logging.Err(err))
+		}
+	}()
+
+	slog.Info("Admin gRPC listening", "addr", cfg.AdminListen)
+	return nil
+}
+
+// newSiteTokens validates the configured site tokens and indexes them by token.
+func newSiteTokens(entries []config.SiteToken) (tenant.Tokens, error) {
+	tokens := make(tenant.Tokens, len(entries))
+	for i, e := range entries {
+		if e.Token == "" {
+			return nil, fmt.Errorf("site_tokens[%d]: token is required", i)
+		}
+		if len(e.Sites) == 0 {
+			return nil, fmt.Errorf("site_tokens[%d]: at least one site is required", i)
+		}
+		if _, dup := tokens[e.Token]; dup {
+			return nil, fmt.Errorf("site_tokens[%d]: duplicate token", i)
+		}
+		tokens[e.Token] = e.Sites
+	}
+	return tokens, nil
+}
+
+// newAlertEngine builds the hardware change alert engine from config, or
+// returns nil when alerting is disabled.
+func newAlertEngine(cfg *config.Config, db *store.Store) (*alert.Engine, error) {
+	if !cfg.EnableAlerts {
+		return nil, nil
+	}
+
+	var notifiers []alert.Notifier
+	if cfg.AlertWebhookURL != "" {
+		notifiers = append(notifiers, alert.NewWebhookNotifier(cfg.AlertWebhookURL, "", db))
+	}
+	for i, w := range cfg.AlertWebhooks {
+		if w.URL == "" {
+			return nil, fmt.Errorf("alert_webhooks[%d]: url is required", i)
+		}
+		notifiers = append(notifiers, alert.NewWebhookNotifier(w.URL, w.Secret, db))
+	}
+	if cfg.AlertSlackWebhookURL != "" {
+		notifiers = append(notifiers, alert.NewSlackNotifier(cfg.AlertSlackWebhookURL))
+	}
+	if cfg.AlertSyslogAddress != "" {
+		n, err := alert.NewSyslogNotifier(cfg.AlertSyslogAddress, cfg.AlertSyslogFormat)
+		if err != nil {
+			return nil, fmt.Errorf("alert_syslog: %w", err)
+		}
+		notifiers = append(notifiers, n)
+	}
+	return alert.NewEngine(db, notifiers...), nil
+}
+
+// newEventRelays returns the outbox relays of the configured event sinks.
+func newEventRelays(cfg *config.Config, db *store.Store) ([]*outbox.Relay, error) {
+	var relays []*outbox.Relay
+	if len(cfg.KafkaBrokers) > 0 {
+		for _, b := range cfg.KafkaBrokers {
+			if _, _, err := net.SplitHostPort(b); err != nil {
+				return nil, fmt.Errorf("kafka_brokers: %q is not a host:port address", b)
+			}
+		}
+		if cfg.KafkaTopic == "" {
+			return nil, errors.New("kafka_topic: required with kafka_brokers")
+		}
+		p, err := kafka.NewPublisher(cfg.KafkaBrokers, cfg.KafkaTopic, cfg.KafkaEncoding)
+		if err != nil {
+			return nil, fmt.Errorf("kafka_encoding: %w", err)
+		}
+		relays = append(relays, outbox.NewRelay(db, "kafka", p, outbox.InventorySubmitted))
+	}
+	if cfg.MQTTBroker != "" {
+		if cfg.MQTTQoS < 0 || cfg.MQTTQoS > 2 {
+			return nil, fmt.Errorf("mqtt_qos: %d is not 0, 1 or 2", cfg.MQTTQoS)
+		}
+		p, err := mqtt.NewPublisher(mqtt.Options{
+			Broker:   cfg.MQTTBroker,
+			ClientID: cfg.MQTTClientID,
+			Username: cfg.MQTTUsername,
+			Password: cfg.MQTTPassword,
+			Topic:    cfg.MQTTTopic,
+			QoS:      byte(cfg.MQTTQoS),
+			Retain:   cfg.MQTTRetain,
+		})
+		if err != nil {
+			// The error names the option, e.g. "topic: ...".
+			return nil, fmt.Errorf("mqtt_%w", err)
+		}
+		relays = append(relays, outbox.NewRelay(db, "mqtt", p, outbox.InventorySubmitted))
+	}
+	if cfg.NATSURL != "" {
+		p, err := nats.NewPublisher(nats.Options{
+			URL:           cfg.NATSURL,
+			CredsFile:     cfg.NATSCredsFile,
+			Stream:        cfg.NATSStream,
+			SubjectPrefix: cfg.NATSSubjectPrefix,
+		})
+		if err != nil {
+			// The error names the option, e.g. "stream: ...".
+			return nil, fmt.Errorf("nats_%w", err)
+		}
+		relays = append(relays, outbox.NewRelay(db, "nats", p,
+			outbox.InventorySubmitted, outbox.AgentConnected, outbox.AgentDisconnected, outbox.AgentUpdated))
+	}
+	if cfg.OpenSearchURL != "" {
+		x, err := opensearch.NewIndexer(opensearch.Options{
+			URL:      cfg.OpenSearchURL,
+			Index:    cfg.OpenSearchIndex,
+			Username: cfg.OpenSearchUsername,
+			Password: cfg.OpenSearchPassword,
+			APIKey:   cfg.OpenSearchAPIKey,
+		}, db)
+		if err != nil {
+			// The error names the option, e.g. "index: ...".
+			return nil, fmt.Errorf("opensearch_%w", err)
+		}
+		relays = append(relays, outbox.NewRelay(db, "opensearch", x, outbox.InventorySubmitted))
+	}
+	return relays, nil
+}
+
 func runPurgeLoop(ctx context.Context, db *store.Store, retentionDays int, interval time.Duration) {
 	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
@@ -101,12 +573,31 @@ func runPurgeLoop(ctx context.Context, db *store.Store, retentionDays int, inter
 			return
 		case <-ticker.C:
 			olderThan := time.Duration(retentionDays) * 24 * time.Hour
-			n, err := db.Purge(ctx, olderThan)
+			res, err := db.Purge(ctx, store.PurgeFilter{
+				OlderThan: olderThan,
+				Progress:  purgeProgress("Purging inventories", "older_than_days", retentionDays),
+			})
 			if err != nil {
-				log.Printf("Purge error: %v", err)
-			} else if n > 0 {
-				log.Printf("Purged %d records older than %d days", n, retentionDays)
+				slog.Error("Purge failed", logging.Err(err))
+			} else if res.Inventories > 0 {
+				slog.Info("Purged inventories", "purged", res.Inventories, "older_than_days", retentionDays)
 			}
 		}
 	}
 }
+
+// purgeProgressInterval is how often a long purge logs its progress.
+const purgeProgressInterval = 10 * time.Second
+
+// purgeProgress returns a PurgeFilter.Progress function that logs msg with
+// the records purged so far and attrs, at most every purgeProgressInterval.
+func purgeProgress(msg string, attrs ...any) func(store.PurgeResult) {
+	next := time.Now().Add(purgeProgressInterval)
+	return func(res store.PurgeResult) {
+		if time.Now().Before(next) {
+			return
+		}
+		next = time.Now().Add(purgeProgressInterval)
+		slog.Info(msg, append([]any{"purged", res.Inventories, "alerts", res.Alerts}, attrs...)...)
+	}
+}
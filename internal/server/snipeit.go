@@ -0,0 +1,76 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"github.com/go-tangra/go-tangra-inventory/internal/config"
+	"github.com/go-tangra/go-tangra-inventory/internal/logging"
+	"github.com/go-tangra/go-tangra-inventory/internal/snipeit"
+	"github.com/go-tangra/go-tangra-inventory/internal/store"
+)
+
+// NewSnipeITSyncer builds the Snipe-IT asset synchronization from config,
+// or returns nil when it is disabled.
+func NewSnipeITSyncer(cfg *config.Config, db *store.Store) (*snipeit.Syncer, error) {
+	if cfg.SnipeITURL == "" {
+		return nil, nil
+	}
+	if u, err := url.Parse(cfg.SnipeITURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, errors.New("snipeit_url: not an http or https URL")
+	}
+	if cfg.SnipeITToken == "" {
+		return nil, errors.New("snipeit_token: required with snipeit_url")
+	}
+	if cfg.SnipeITInterval <= 0 {
+		return nil, errors.New("snipeit_interval: must be positive")
+	}
+	if len(cfg.SnipeITSites) == 0 {
+		return nil, errors.New(`snipeit_sites: configure at least one site, or "*" for all`)
+	}
+
+	sites := make([]snipeit.Site, len(cfg.SnipeITSites))
+	for i, s := range cfg.SnipeITSites {
+		sites[i] = snipeit.Site{
+			Name:           s.Site,
+			StatusID:       s.StatusID,
+			CategoryID:     s.CategoryID,
+			FieldsetID:     s.FieldsetID,
+			CompanyID:      s.CompanyID,
+			LocationID:     s.LocationID,
+			AssetTagPrefix: s.AssetTagPrefix,
+			Fields:         s.Fields,
+		}
+	}
+	syncer, err := snipeit.NewSyncer(snipeit.NewClient(cfg.SnipeITURL, cfg.SnipeITToken), db, sites)
+	if err != nil {
+		return nil, fmt.Errorf("snipeit_sites: %w", err)
+	}
+	return syncer, nil
+}
+
+// runSnipeITLoop synchronizes the assets at startup and then every
+// interval.
+func runSnipeITLoop(ctx context.Context, syncer *snipeit.Syncer, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		res, err := syncer.Sync(ctx, false)
+		if err != nil && ctx.Err() == nil {
+			slog.Error("Snipe-IT sync failed", logging.Err(err))
+		} else if res.Created > 0 || res.Updated > 0 || res.Skipped > 0 {
+			slog.Info("Synchronized Snipe-IT assets", "created", res.Created, "updated", res.Updated, "skipped", res.Skipped)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+	"github.com/go-tangra/go-tangra-inventory/internal/config"
+	"github.com/go-tangra/go-tangra-inventory/internal/logging"
+	"github.com/go-tangra/go-tangra-inventory/internal/snmp"
+)
+
+// NewSNMPPoller builds the SNMP poller of network devices from config, or
+// returns nil when no targets are configured. Polled inventories are stored
+// through h so they raise alerts and events like agent submissions; h may be
+// nil to only validate the configuration.
+func NewSNMPPoller(cfg *config.Config, h *Handler) (*snmp.Poller, error) {
+	if len(cfg.SNMPTargets) == 0 {
+		return nil, nil
+	}
+	if cfg.SNMPInterval <= 0 {
+		return nil, errors.New("snmp_interval: must be positive")
+	}
+	if cfg.SNMPRetries < 0 {
+		return nil, errors.New("snmp_retries: must not be negative")
+	}
+
+	creds := make(map[string]snmp.Credentials, len(cfg.SNMPCredentials))
+	for i, c := range cfg.SNMPCredentials {
+		if c.Name == "" {
+			return nil, fmt.Errorf("snmp_credentials[%d].name: required", i)
+		}
+		if _, ok := creds[c.Name]; ok {
+			return nil, fmt.Errorf("snmp_credentials[%d].name: %q is used twice", i, c.Name)
+		}
+		creds[c.Name] = snmp.Credentials{
+			Version:      c.Version,
+			Community:    c.Community,
+			Username:     c.Username,
+			AuthProtocol: c.AuthProtocol,
+			AuthPassword: c.AuthPassword,
+			PrivProtocol: c.PrivProtocol,
+			PrivPassword: c.PrivPassword,
+		}
+	}
+
+	targets := make([]snmp.Target, len(cfg.SNMPTargets))
+	for i, t := range cfg.SNMPTargets {
+		cred, ok := creds[t.Credentials]
+		if !ok {
+			return nil, fmt.Errorf("snmp_targets[%d].credentials: no snmp_credentials named %q", i, t.Credentials)
+		}
+		targets[i] = snmp.Target{Address: t.Address, Site: t.Site, Name: t.Name, Class: t.Class, Credentials: cred}
+	}
+
+	submit := func(ctx context.Context, inv *collectorv1.Inventory) error {
+		_, err := h.SubmitInventory(ctx, &collectorv1.SubmitInventoryRequest{Inventory: inv})
+		return err
+	}
+	poller, err := snmp.NewPoller(targets, cfg.SNMPTimeout, cfg.SNMPRetries, submit)
+	if err != nil {
+		return nil, fmt.Errorf("snmp_targets: %w", err)
+	}
+	return poller, nil
+}
+
+// runSNMPLoop polls the network devices at startup and then every interval.
+func runSNMPLoop(ctx context.Context, poller *snmp.Poller, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		res, err := poller.Poll(ctx)
+		if err != nil && ctx.Err() == nil {
+			slog.Error("SNMP polling failed", logging.Err(err))
+		} else if err == nil {
+			slog.Info("Polled network devices", "polled", res.Polled, "failed", res.Failed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
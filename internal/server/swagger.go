@@ -0,0 +1,122 @@
+package server
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strings"
+
+	kratoshttp "github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// Swagger UI authentication modes (config key swagger_auth).
+const (
+	SwaggerAuthNone   = "none"
+	SwaggerAuthBasic  = "basic"
+	SwaggerAuthAPIKey = "api_key"
+)
+
+// SwaggerAuth returns an http.Handler wrapper that guards the Swagger UI.
+//
+//   - "none" (or empty) leaves the UI public.
+//   - "basic" requires HTTP basic auth with the given username and password.
+//   - "api_key" requires the REST API secret, either in the X-API-Key header or
+//     as the basic-auth password (any username) so browsers can log in.
+func SwaggerAuth(mode, username, password, apiSecret string) (func(http.Handler) http.Handler, error) {
+	switch mode {
+	case "", SwaggerAuthNone:
+		return func(h http.Handler) http.Handler { return h }, nil
+
+	case SwaggerAuthBasic:
+		if username == "" || password == "" {
+			return nil, fmt.Errorf("swagger_auth %q requires swagger_username and swagger_password", mode)
+		}
+		return basicAuth(func(r *http.Request) bool {
+			u, p, ok := r.BasicAuth()
+			return ok &&
+				subtle.ConstantTimeCompare([]byte(u), []byte(username)) == 1 &&
+				subtle.ConstantTimeCompare([]byte(p), []byte(password)) == 1
+		}), nil
+
+	case SwaggerAuthAPIKey:
+		if apiSecret == "" {
+			return nil, fmt.Errorf("swagger_auth %q requires api_secret", mode)
+		}
+		return basicAuth(func(r *http.Request) bool {
+			key := r.Header.Get("X-API-Key")
+			if key == "" {
+				_, key, _ = r.BasicAuth()
+			}
+			return subtle.ConstantTimeCompare([]byte(key), []byte(apiSecret)) == 1
+		}), nil
+
+	default:
+		return nil, fmt.Errorf("unknown swagger_auth %q (use none, basic or api_key)", mode)
+	}
+}
+
+func basicAuth(authorized func(r *http.Request) bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !authorized(r) {
+				w.Header().Set("WWW-Authenticate", `Basic realm="Inventory Collector API docs"`)
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// swaggerRouter adapts an HTTP server to the router interface expected by
+// kratos-swagger-ui. Every handler registered through it passes through
+// guard, and basePath is stripped from registered paths because the server's
+// router is already mounted under it (see kratoshttp.PathPrefix) while the
+// Swagger UI must still generate links that include it.
+type swaggerRouter struct {
+	srv      *kratoshttp.Server
+	basePath string
+	guard    func(http.Handler) http.Handler
+}
+
+func (r swaggerRouter) HandlePrefix(prefix string, h http.Handler) {
+	r.srv.HandlePrefix(strings.TrimPrefix(prefix, r.basePath), r.guard(h))
+}
+
+func (r swaggerRouter) Handle(path string, h http.Handler) {
+	r.srv.Handle(strings.TrimPrefix(path, r.basePath), r.guard(h))
+}
+
+func (r swaggerRouter) HandleFunc(path string, h http.HandlerFunc) {
+	r.srv.Handle(strings.TrimPrefix(path, r.basePath), r.guard(h))
+}
+
+// NormalizeBasePath turns a configured http_base_path into the form used for
+// routing: a leading slash and no trailing slash, or "" for the root.
+func NormalizeBasePath(p string) string {
+	p = strings.Trim(strings.TrimSpace(p), "/")
+	if p == "" {
+		return ""
+	}
+	return "/" + p
+}
+
+// openAPIWithBasePath adds a servers entry pointing at basePath to the
+// generated OpenAPI document so that Swagger UI "try it out" requests and
+// client generators target the prefixed routes.
+func openAPIWithBasePath(data []byte, basePath string) []byte {
+	if basePath == "" {
+		return data
+	}
+	i := bytes.Index(data, []byte("\npaths:"))
+	if i < 0 {
+		return data
+	}
+	servers := fmt.Sprintf("\nservers:\n    - url: %s", basePath)
+
+	out := make([]byte, 0, len(data)+len(servers))
+	out = append(out, data[:i]...)
+	out = append(out, servers...)
+	return append(out, data[i:]...)
+}
@@ -0,0 +1,151 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+	"github.com/go-tangra/go-tangra-inventory/internal/store"
+	"github.com/go-tangra/go-tangra-inventory/internal/tenant"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Roles of managed API tokens.
+const (
+	// RoleAgent tokens may call the agent RPCs only, like client_secret.
+	RoleAgent = "agent"
+	// RoleAPI tokens may call every RPC except the management ones.
+	RoleAPI = "api"
+	// RoleAdmin tokens may call every RPC, also on the admin listener.
+	RoleAdmin = "admin"
+)
+
+// tokenPrefix starts every managed API token, so that tokens are
+// recognizable in leaked configuration and can be told from secrets.
+const tokenPrefix = "itk_"
+
+// APITokens authenticates callers with the managed API tokens of the store.
+// The active tokens are kept in memory, indexed by their SHA-256 hash, and
+// reloaded whenever a token is created or revoked.
+type APITokens struct {
+	db *store.Store
+
+	mu     sync.RWMutex
+	active map[string]store.TokenRecord
+}
+
+// NewAPITokens loads the active tokens of db.
+func NewAPITokens(ctx context.Context, db *store.Store) (*APITokens, error) {
+	t := &APITokens{db: db}
+	if err := t.reload(ctx); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (t *APITokens) reload(ctx context.Context) error {
+	tokens, err := t.db.ListTokens(ctx)
+	if err != nil {
+		return fmt.Errorf("load API tokens: %w", err)
+	}
+	active := make(map[string]store.TokenRecord)
+	for _, tok := range tokens {
+		if tok.RevokedAt.IsZero() {
+			active[tok.Hash] = tok
+		}
+	}
+
+	t.mu.Lock()
+	t.active = active
+	t.mu.Unlock()
+	return nil
+}
+
+// enabled reports whether tokens have been issued and not revoked. Like
+// configured secrets, they turn on authentication.
+func (t *APITokens) enabled() bool {
+	if t == nil {
+		return false
+	}
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return len(t.active) > 0
+}
+
+// authorize checks value as a token for method. ok is false when value is
+// not an active, unexpired token; err is set when the token's role does not
+// permit method. A site-bound token restricts the returned context to its
+// sites.
+func (t *APITokens) authorize(ctx context.Context, value, method string) (_ context.Context, ok bool, err error) {
+	if t == nil || !strings.HasPrefix(value, tokenPrefix) {
+		return ctx, false, nil
+	}
+
+	t.mu.RLock()
+	tok, ok := t.active[hashToken(value)]
+	t.mu.RUnlock()
+	if !ok || (!tok.ExpiresAt.IsZero() && time.Now().After(tok.ExpiresAt)) {
+		return ctx, false, nil
+	}
+
+	if !rolePermits(tok.Role, method) {
+		return ctx, true, status.Errorf(codes.PermissionDenied, "%s token not permitted for this method", tok.Role)
+	}
+	if len(tok.Sites) > 0 {
+		ctx = tenant.NewContext(ctx, tok.Sites)
+	}
+	return ctx, true, nil
+}
+
+// rolePermits reports whether a token of role may call method, a full gRPC
+// method name.
+func rolePermits(role, method string) bool {
+	switch role {
+	case RoleAdmin:
+		return true
+	case RoleAPI:
+		return !isAdminMethod(method)
+	case RoleAgent:
+		for suffix := range allowedClientSecretUnaryMethods {
+			if strings.HasSuffix(method, suffix) {
+				return true
+			}
+		}
+		for suffix := range allowedClientSecretStreamMethods {
+			if strings.HasSuffix(method, suffix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isAdminMethod reports whether method is a management RPC.
+func isAdminMethod(method string) bool {
+	return strings.HasPrefix(method, "/"+collectorv1.InventoryAdminService_ServiceDesc.ServiceName+"/") ||
+		adminCollectorMethods[method]
+}
+
+// newToken returns a new random token and its hash.
+func newToken() (token, hash string, err error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", fmt.Errorf("generate token: %w", err)
+	}
+	token = tokenPrefix + base64.RawURLEncoding.EncodeToString(b)
+	return token, hashToken(token), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,235 @@
+package server
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"slices"
+	"strconv"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+	collectorv2 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v2"
+	"github.com/go-tangra/go-tangra-inventory/internal/convert"
+	"github.com/go-tangra/go-tangra-inventory/internal/store"
+	"github.com/go-tangra/go-tangra-inventory/internal/tenant"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Page sizes of the v2 collections: the default, and the most a request may
+// ask for; larger requests get the most.
+const (
+	v2DefaultPageSize = 50
+	v2MaxPageSize     = 1000
+)
+
+// V2Handler implements the resource-oriented v2 InventoryService on top of
+// the store and command registry of the collector Handler.
+type V2Handler struct {
+	collectorv2.UnimplementedInventoryServiceServer
+	h *Handler
+}
+
+// NewV2Handler creates a v2 handler that shares the state of h.
+func NewV2Handler(h *Handler) *V2Handler {
+	return &V2Handler{h: h}
+}
+
+func (v *V2Handler) ListHosts(ctx context.Context, req *collectorv2.ListHostsRequest) (*collectorv2.ListHostsResponse, error) {
+	sites, err := tenant.Filter(ctx, req.Site)
+	if err != nil {
+		return nil, err
+	}
+	offset, err := parseOffsetToken(req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	f := store.HostFilter{
+		Sites:       sites,
+		Hostname:    req.Hostname,
+		DeviceClass: req.DeviceClass,
+		PageSize:    v2PageSize(req.PageSize),
+		Offset:      offset,
+	}
+	if req.SeenAfter != nil {
+		t := req.SeenAfter.AsTime()
+		f.SeenAfter = &t
+	}
+	hosts, total, err := v.h.store.ListHosts(ctx, f)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list hosts: %v", err)
+	}
+
+	resp := &collectorv2.ListHostsResponse{
+		Hosts:         make([]*collectorv1.HostSummary, len(hosts)),
+		NextPageToken: nextOffsetToken(offset, len(hosts), total),
+		TotalSize:     int32(total),
+	}
+	for i := range hosts {
+		resp.Hosts[i] = convert.HostToSummary(&hosts[i])
+	}
+	return resp, nil
+}
+
+func (v *V2Handler) ListHostInventories(ctx context.Context, req *collectorv2.ListHostInventoriesRequest) (*collectorv2.ListHostInventoriesResponse, error) {
+	if req.SystemUuid == "" {
+		return nil, status.Error(codes.InvalidArgument, "system_uuid is required")
+	}
+	sites, err := tenant.Filter(ctx, req.Site)
+	if err != nil {
+		return nil, err
+	}
+
+	f := store.ListFilter{
+		Sites:      sites,
+		SystemUUID: req.SystemUuid,
+		PageSize:   v2PageSize(req.PageSize),
+	}
+	if req.PageToken != "" {
+		after, err := store.ParseListToken(req.PageToken)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		f.After = &after
+	}
+	if req.CollectedAfter != nil {
+		t := req.CollectedAfter.AsTime()
+		f.CollectedAfter = &t
+	}
+	if req.CollectedBefore != nil {
+		t := req.CollectedBefore.AsTime()
+		f.CollectedBefore = &t
+	}
+
+	paths, err := convert.MaskPaths(&collectorv1.InventorySummary{}, req.ReadMask)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	f.WithJSON = convert.MasksInventory(paths)
+
+	records, total, err := v.h.store.List(ctx, f)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list inventories: %v", err)
+	}
+
+	resp := &collectorv2.ListHostInventoriesResponse{
+		Inventories: make([]*collectorv1.InventorySummary, len(records)),
+		TotalSize:   int32(total),
+	}
+	for i := range records {
+		if resp.Inventories[i], err = convert.RecordToSummaryMasked(&records[i], paths); err != nil {
+			return nil, status.Errorf(codes.Internal, "decode inventory: %v", err)
+		}
+	}
+	if next := f.Next(records); next != nil {
+		resp.NextPageToken = next.Token()
+	}
+	return resp, nil
+}
+
+func (v *V2Handler) GetLatestHostInventory(ctx context.Context, req *collectorv2.GetLatestHostInventoryRequest) (*collectorv1.InventorySummary, error) {
+	if req.SystemUuid == "" {
+		return nil, status.Error(codes.InvalidArgument, "system_uuid is required")
+	}
+	paths, err := convert.MaskPaths(&collectorv1.InventorySummary{}, req.ReadMask)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	sites, err := tenant.Filter(ctx, req.Site)
+	if err != nil {
+		return nil, err
+	}
+
+	rec, err := v.h.store.GetLatestBySystemUUID(ctx, req.SystemUuid, sites)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "no inventory found for system UUID %q", req.SystemUuid)
+		}
+		return nil, status.Errorf(codes.Internal, "get latest inventory: %v", err)
+	}
+
+	// Unlike in lists, an empty mask includes the inventory.
+	if paths == nil {
+		summary := convert.RecordToSummary(rec)
+		if summary.Inventory, err = convert.RecordToInventory(rec); err != nil {
+			return nil, status.Errorf(codes.Internal, "decode inventory: %v", err)
+		}
+		return summary, nil
+	}
+	summary, err := convert.RecordToSummaryMasked(rec, paths)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "decode inventory: %v", err)
+	}
+	return summary, nil
+}
+
+func (v *V2Handler) ListAgents(ctx context.Context, req *collectorv2.ListAgentsRequest) (*collectorv2.ListAgentsResponse, error) {
+	sites, err := tenant.Filter(ctx, req.Site)
+	if err != nil {
+		return nil, err
+	}
+	offset, err := parseOffsetToken(req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	agents := slices.DeleteFunc(v.h.cmdReg.ListConnected(), func(a ConnectedAgentInfo) bool {
+		return (sites != nil && !slices.Contains(sites, a.Site)) || (req.Hostname != "" && a.Hostname != req.Hostname)
+	})
+	slices.SortFunc(agents, func(a, b ConnectedAgentInfo) int {
+		return cmp.Or(cmp.Compare(a.Site, b.Site), cmp.Compare(a.Hostname, b.Hostname), cmp.Compare(a.ClientID, b.ClientID))
+	})
+
+	page := agents[min(offset, len(agents)):]
+	page = page[:min(v2PageSize(req.PageSize), len(page))]
+	resp := &collectorv2.ListAgentsResponse{
+		Agents:        make([]*collectorv1.ConnectedAgent, len(page)),
+		NextPageToken: nextOffsetToken(offset, len(page), len(agents)),
+		TotalSize:     int32(len(agents)),
+	}
+	for i, a := range page {
+		resp.Agents[i] = connectedAgentToProto(a)
+	}
+	return resp, nil
+}
+
+// v2PageSize returns the page size of a request for size items.
+func v2PageSize(size int32) int {
+	if size <= 0 {
+		return v2DefaultPageSize
+	}
+	return min(int(size), v2MaxPageSize)
+}
+
+// Collections that are not listed by keyset, such as hosts and agents, use
+// the offset of the next page as their page token.
+
+// nextOffsetToken returns the page token of the page after n items listed
+// from offset, or "" if they were the last of total.
+func nextOffsetToken(offset, n, total int) string {
+	if n == 0 || offset+n >= total {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset + n)))
+}
+
+// parseOffsetToken decodes a page token of nextOffsetToken; "" is the
+// first page.
+func parseOffsetToken(token string) (int, error) {
+	if token == "" {
+		return 0, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, status.Error(codes.InvalidArgument, "invalid page token")
+	}
+	offset, err := strconv.Atoi(string(b))
+	if err != nil || offset < 0 {
+		return 0, status.Error(codes.InvalidArgument, "invalid page token")
+	}
+	return offset, nil
+}
@@ -0,0 +1,120 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// defaultGRPCMaxRecvMsgSize is the largest message a gRPC server receives
+// unless told otherwise, and submitRequestOverhead room for the fields of a
+// SubmitInventoryRequest around its inventory.
+const (
+	defaultGRPCMaxRecvMsgSize = 4 << 20
+	submitRequestOverhead     = 4 << 10
+)
+
+// maxHostnameLen bounds hostnames: a DNS name is at most 253 bytes, and
+// SNMP device names configured by hand somewhat more.
+const maxHostnameLen = 255
+
+// uuidPattern matches the textual form of a UUID, which agents report the
+// SMBIOS system UUID in.
+var uuidPattern = regexp.MustCompile(`^[0-9A-Fa-f]{8}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{12}$`)
+
+// submitLimits bounds the inventories SubmitInventory accepts; zero fields
+// are not limited.
+type submitLimits struct {
+	// maxBytes is the largest encoded inventory.
+	maxBytes int
+	// maxClockSkew is how far collected_at may be ahead of the collector's
+	// clock, and maxAge how far behind it.
+	maxClockSkew time.Duration
+	maxAge       time.Duration
+}
+
+// limitSubmissions makes SubmitInventory reject inventories beyond l. It is
+// called before the handler serves requests.
+func (h *Handler) limitSubmissions(l submitLimits) {
+	h.limits = l
+}
+
+// validateInventory checks a submitted inventory against l and the rules
+// every inventory must meet, returning an InvalidArgument error describing
+// the first it breaks.
+func validateInventory(inv *collectorv1.Inventory, l submitLimits, now time.Time) error {
+	if inv == nil {
+		return status.Error(codes.InvalidArgument, "inventory is required")
+	}
+	if err := validateHostname(inv.Hostname); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if uuid := inv.GetSystem().GetUuid(); uuid != "" && !uuidPattern.MatchString(uuid) {
+		return status.Errorf(codes.InvalidArgument, "system.uuid %q is not a UUID", uuid)
+	}
+	if inv.CollectedAt != nil {
+		if err := inv.CollectedAt.CheckValid(); err != nil {
+			return status.Errorf(codes.InvalidArgument, "collected_at: %v", err)
+		}
+		at := inv.CollectedAt.AsTime()
+		if l.maxClockSkew > 0 && at.After(now.Add(l.maxClockSkew)) {
+			return status.Errorf(codes.InvalidArgument, "collected_at %s is more than %s ahead of the collector's clock",
+				at.Format(time.RFC3339), l.maxClockSkew)
+		}
+		if l.maxAge > 0 && at.Before(now.Add(-l.maxAge)) {
+			return status.Errorf(codes.InvalidArgument, "collected_at %s is more than %s ago", at.Format(time.RFC3339), l.maxAge)
+		}
+	}
+	if l.maxBytes > 0 {
+		if n := proto.Size(inv); n > l.maxBytes {
+			return status.Errorf(codes.InvalidArgument, "inventory of %d bytes exceeds the limit of %d", n, l.maxBytes)
+		}
+	}
+	if !hasHardware(inv) {
+		return status.Error(codes.InvalidArgument, "inventory holds no hardware information")
+	}
+	return nil
+}
+
+// validateHostname accepts hostnames of printable characters without
+// surrounding spaces, which covers DNS and NetBIOS names as well as the
+// names given to SNMP devices.
+func validateHostname(hostname string) error {
+	switch {
+	case hostname == "":
+		return errors.New("hostname is required")
+	case len(hostname) > maxHostnameLen:
+		return fmt.Errorf("hostname is longer than %d bytes", maxHostnameLen)
+	case !utf8.ValidString(hostname):
+		return errors.New("hostname is not valid UTF-8")
+	case strings.TrimSpace(hostname) != hostname:
+		return fmt.Errorf("hostname %q has leading or trailing spaces", hostname)
+	}
+	for _, r := range hostname {
+		if !unicode.IsPrint(r) {
+			return fmt.Errorf("hostname %q contains the unprintable character %U", hostname, r)
+		}
+	}
+	return nil
+}
+
+// hasHardware reports whether inv describes any hardware: an inventory of
+// only a hostname and metadata is taken for a broken agent's.
+func hasHardware(inv *collectorv1.Inventory) bool {
+	for _, m := range []proto.Message{inv.System, inv.Bios, inv.Baseboard, inv.Chassis, inv.Memory, inv.Snmp} {
+		if proto.Size(m) > 0 {
+			return true
+		}
+	}
+	return len(inv.Processors) > 0 || len(inv.Monitor) > 0 || len(inv.Ports) > 0 || len(inv.Slots) > 0
+}
@@ -0,0 +1,228 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/go-tangra/go-tangra-inventory/internal/logging"
+	"github.com/go-tangra/go-tangra-inventory/internal/store"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// writeQueue groups concurrent inventory inserts into shared transactions.
+// SQLite commits one transaction at a time, each with its own fsync, so
+// during check-in storms most of the time goes to waiting for commits;
+// inserts queued while a commit is in progress are written together by the
+// next one. A lone insert is committed right away.
+//
+// The queue is bounded: when it is full, inserts are refused with
+// ResourceExhausted, which agents retry later, rather than piling up until
+// their deadlines expire.
+type writeQueue struct {
+	store *store.Store
+	// max is the most inserts committed in one transaction.
+	max  int
+	reqs chan *writeRequest
+	// stopping is closed when the queue stops taking inserts, and stopped
+	// once it has committed those it had taken.
+	stopping chan struct{}
+	stopped  chan struct{}
+
+	// rejected and stored count inserts for the metrics.
+	rejected atomic.Uint64
+	stored   atomic.Uint64
+}
+
+// errQueueFull refuses inserts while the queue is full.
+var errQueueFull = status.Error(codes.ResourceExhausted, "the collector is busy storing other inventories; retry later")
+
+// writeRequest is a queued insert; its result is sent on done.
+type writeRequest struct {
+	ctx  context.Context
+	rec  *store.InventoryRecord
+	done chan writeResult
+}
+
+type writeResult struct {
+	id       int64
+	storedAt time.Time
+	err      error
+}
+
+// newWriteQueue returns a queue of up to size inserts into db, committed up
+// to max at a time.
+func newWriteQueue(db *store.Store, size, max int) *writeQueue {
+	return &writeQueue{
+		store:    db,
+		max:      max,
+		reqs:     make(chan *writeRequest, size),
+		stopping: make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+}
+
+// full reports whether inserts are currently refused, so that submissions
+// can be turned away before any work is done for them.
+func (q *writeQueue) full() bool {
+	return len(q.reqs) == cap(q.reqs) && !q.isStopping()
+}
+
+// isStopping reports whether the queue has stopped taking inserts.
+func (q *writeQueue) isStopping() bool {
+	select {
+	case <-q.stopping:
+		return true
+	default:
+		return false
+	}
+}
+
+// insert stores rec with the next group commit and returns its ID and
+// stored_at time, or errQueueFull. Once the queue is stopping, rec is
+// stored on its own.
+func (q *writeQueue) insert(ctx context.Context, rec *store.InventoryRecord) (int64, time.Time, error) {
+	if q.isStopping() {
+		return q.store.Insert(ctx, rec)
+	}
+	req := &writeRequest{ctx: ctx, rec: rec, done: make(chan writeResult, 1)}
+	select {
+	case q.reqs <- req:
+	default:
+		if q.isStopping() {
+			return q.store.Insert(ctx, rec)
+		}
+		q.rejected.Add(1)
+		return 0, time.Time{}, errQueueFull
+	}
+
+	select {
+	case res := <-req.done:
+		return res.id, res.storedAt, res.err
+	case <-q.stopped:
+		// The queue answers every request it takes before it stops, so an
+		// unanswered one was queued after it had drained and is stored here.
+		select {
+		case res := <-req.done:
+			return res.id, res.storedAt, res.err
+		default:
+			return q.store.Insert(ctx, rec)
+		}
+	case <-ctx.Done():
+		// commit skips the request unless it is already being stored.
+		return 0, time.Time{}, ctx.Err()
+	}
+}
+
+// run commits the queued inserts until ctx is done, and then those still
+// queued.
+func (q *writeQueue) run(ctx context.Context) {
+	defer close(q.stopped)
+	// Inserts already taken are committed even while shutting down.
+	writeCtx := context.WithoutCancel(ctx)
+	for {
+		select {
+		case req := <-q.reqs:
+			q.commit(writeCtx, q.collect(req))
+		case <-ctx.Done():
+			close(q.stopping)
+			for {
+				select {
+				case req := <-q.reqs:
+					q.commit(writeCtx, q.collect(req))
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// collect returns req and the requests queued behind it, up to max.
+func (q *writeQueue) collect(req *writeRequest) []*writeRequest {
+	batch := []*writeRequest{req}
+	for len(batch) < q.max {
+		select {
+		case req := <-q.reqs:
+			batch = append(batch, req)
+		default:
+			return batch
+		}
+	}
+	return batch
+}
+
+// commit stores the records of batch in one transaction and answers each
+// request. Requests whose caller has given up are skipped. If the
+// transaction fails, the records are stored one by one so that a single bad
+// record fails only its own request.
+func (q *writeQueue) commit(ctx context.Context, batch []*writeRequest) {
+	live := batch[:0]
+	for _, req := range batch {
+		if err := req.ctx.Err(); err != nil {
+			req.done <- writeResult{err: err}
+			continue
+		}
+		live = append(live, req)
+	}
+	if len(live) == 0 {
+		return
+	}
+
+	recs := make([]*store.InventoryRecord, len(live))
+	for i, req := range live {
+		recs[i] = req.rec
+	}
+	ids, storedAt, err := q.store.InsertBatch(ctx, recs)
+	switch {
+	case err == nil:
+		q.stored.Add(uint64(len(live)))
+		for i, req := range live {
+			req.done <- writeResult{id: ids[i], storedAt: storedAt}
+		}
+		return
+	case len(live) == 1:
+		live[0].done <- writeResult{err: err}
+		return
+	}
+	slog.Warn("Group commit failed; storing inventories one by one", "count", len(live), logging.Err(err))
+	for _, req := range live {
+		id, storedAt, err := q.store.Insert(ctx, req.rec)
+		if err == nil {
+			q.stored.Add(1)
+		}
+		req.done <- writeResult{id: id, storedAt: storedAt, err: err}
+	}
+}
+
+var (
+	queueDepthDesc = prometheus.NewDesc("tangra_ingest_queue_depth",
+		"Submitted inventories waiting to be stored.", nil, nil)
+	queueCapacityDesc = prometheus.NewDesc("tangra_ingest_queue_capacity",
+		"Submitted inventories that can wait to be stored before submissions are refused.", nil, nil)
+	rejectedDesc = prometheus.NewDesc("tangra_ingest_rejected_total",
+		"Submitted inventories refused because the queue was full.", nil, nil)
+	storedDesc = prometheus.NewDesc("tangra_ingest_stored_total",
+		"Submitted inventories stored through the queue.", nil, nil)
+)
+
+// Describe implements prometheus.Collector.
+func (q *writeQueue) Describe(ch chan<- *prometheus.Desc) {
+	ch <- queueDepthDesc
+	ch <- queueCapacityDesc
+	ch <- rejectedDesc
+	ch <- storedDesc
+}
+
+// Collect implements prometheus.Collector.
+func (q *writeQueue) Collect(ch chan<- prometheus.Metric) {
+	ch <- prometheus.MustNewConstMetric(queueDepthDesc, prometheus.GaugeValue, float64(len(q.reqs)))
+	ch <- prometheus.MustNewConstMetric(queueCapacityDesc, prometheus.GaugeValue, float64(cap(q.reqs)))
+	ch <- prometheus.MustNewConstMetric(rejectedDesc, prometheus.CounterValue, float64(q.rejected.Load()))
+	ch <- prometheus.MustNewConstMetric(storedDesc, prometheus.CounterValue, float64(q.stored.Load()))
+}
@@ -0,0 +1,75 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-tangra/go-tangra-inventory/internal/store"
+)
+
+// TestWriteQueueStop checks that inserts racing the queue's shutdown all
+// return, each having stored its record exactly once.
+func TestWriteQueueStop(t *testing.T) {
+	db, err := store.New(filepath.Join(t.TempDir(), "inventory.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	const inserts = 200
+	q := newWriteQueue(db, 16, 8)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go q.run(ctx)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, inserts)
+	for i := range inserts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rec := &store.InventoryRecord{
+				Hostname:      fmt.Sprintf("host-%03d", i),
+				CollectedAt:   time.Now(),
+				InventoryJSON: "{}",
+			}
+			for {
+				_, _, err := q.insert(context.Background(), rec)
+				if err != errQueueFull {
+					errs <- err
+					return
+				}
+				time.Sleep(time.Millisecond)
+			}
+		}()
+		if i == inserts/2 {
+			cancel()
+		}
+	}
+
+	done := make(chan struct{})
+	go func() { wg.Wait(); close(done) }()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("inserts still blocked after the queue stopped")
+	}
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	_, total, err := db.List(context.Background(), store.ListFilter{ExactCount: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != inserts {
+		t.Fatalf("stored %d inventories, want %d", total, inserts)
+	}
+}
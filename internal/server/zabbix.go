@@ -0,0 +1,98 @@
+package server
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/go-tangra/go-tangra-inventory/internal/convert"
+	"github.com/go-tangra/go-tangra-inventory/internal/logging"
+	"github.com/go-tangra/go-tangra-inventory/internal/store"
+	"github.com/go-tangra/go-tangra-inventory/internal/tenant"
+	"github.com/go-tangra/go-tangra-inventory/internal/zabbix"
+
+	"google.golang.org/grpc/status"
+)
+
+// zabbixPath is the prefix of the Zabbix low-level discovery endpoints,
+// below the HTTP base path; the discovery kind follows it.
+const zabbixPath = "/v1/zabbix/discovery/"
+
+// newZabbixHandler serves Zabbix LLD JSON: GET <zabbixPath>hosts lists the
+// hosts, optionally restricted to the site query parameter, and
+// GET <zabbixPath><kind>?hostname=<host> the components of kind in the
+// latest inventory of a host. It is registered outside the Kratos
+// middleware chain, so it is guarded by apiKeyGuard.
+func newZabbixHandler(db *store.Store, apiSecret string, siteTokens tenant.Tokens, apiTokens *APITokens) http.Handler {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		kind := strings.TrimPrefix(r.URL.Path, zabbixPath)
+		if err := zabbix.CheckKind(kind); err != nil {
+			code := http.StatusNotFound
+			if errors.Is(err, zabbix.ErrNoData) {
+				code = http.StatusNotImplemented
+			}
+			http.Error(w, err.Error(), code)
+			return
+		}
+		q := r.URL.Query()
+		sites, err := tenant.Filter(r.Context(), q.Get("site"))
+		if err != nil {
+			http.Error(w, status.Convert(err).Message(), http.StatusForbidden)
+			return
+		}
+
+		var entries []zabbix.Entry
+		if kind == zabbix.KindHosts {
+			facts, err := db.ListHostFacts(r.Context(), sites)
+			if err != nil {
+				slog.Error("Zabbix host discovery failed", logging.Err(err))
+				http.Error(w, "list hosts failed", http.StatusInternalServerError)
+				return
+			}
+			entries = zabbix.Hosts(facts)
+		} else {
+			hostname := q.Get("hostname")
+			if hostname == "" {
+				http.Error(w, "hostname is required", http.StatusBadRequest)
+				return
+			}
+			rec, err := db.GetLatestByHostname(r.Context(), hostname, sites)
+			if errors.Is(err, sql.ErrNoRows) {
+				http.Error(w, "no inventory for host "+hostname, http.StatusNotFound)
+				return
+			}
+			if err != nil {
+				slog.Error("Zabbix component discovery failed", "hostname", hostname, logging.Err(err))
+				http.Error(w, "get inventory failed", http.StatusInternalServerError)
+				return
+			}
+			inv, err := convert.RecordToInventory(rec)
+			if err == nil {
+				entries, err = zabbix.Components(kind, inv)
+			}
+			if err != nil {
+				slog.Error("Zabbix component discovery failed", "hostname", hostname, logging.Err(err))
+				http.Error(w, "decode inventory failed", http.StatusInternalServerError)
+				return
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(entries); err != nil {
+			slog.Debug("Writing Zabbix discovery failed", logging.Err(err))
+		}
+	})
+	guarded := apiKeyGuard(h, zabbixPath, apiSecret, siteTokens, apiTokens)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		guarded.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,133 @@
+// Package siem renders alerts as CEF (ArcSight Common Event Format) and
+// LEEF (QRadar Log Event Extended Format) events, the formats SIEMs such as
+// Splunk, ArcSight and QRadar parse without custom extraction rules.
+package siem
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+)
+
+// Supported formats.
+const (
+	CEF  = "cef"
+	LEEF = "leef"
+)
+
+// Device identification in the event headers. deviceVersion is the version
+// of the event layout, which correlation rules may rely on.
+const (
+	deviceVendor  = "go-tangra"
+	deviceProduct = "inventory"
+	deviceVersion = "1.0"
+)
+
+// category is the event category of hardware change alerts.
+const category = "hardware-change"
+
+// leefTime is the default LEEF devTime format, MMM dd yyyy HH:mm:ss.SSS zzz.
+const leefTime = "Jan 02 2006 15:04:05.000 MST"
+
+// Check reports an error if format is not a supported format.
+func Check(format string) error {
+	if format != CEF && format != LEEF {
+		return fmt.Errorf("unknown SIEM format %q (use %s or %s)", format, CEF, LEEF)
+	}
+	return nil
+}
+
+// Format renders a in format as a single line without line terminator.
+// The alert rule is the event ID, e.g. system_serial_changed.
+func Format(format string, a *collectorv1.Alert) (string, error) {
+	switch format {
+	case CEF:
+		return formatCEF(a), nil
+	case LEEF:
+		return formatLEEF(a), nil
+	}
+	return "", Check(format)
+}
+
+// Severity maps an alert severity to the 0-10 scale of CEF and LEEF.
+func Severity(severity string) int {
+	switch severity {
+	case "critical":
+		return 9
+	case "warning":
+		return 6
+	case "info":
+		return 3
+	}
+	return 5
+}
+
+func formatCEF(a *collectorv1.Alert) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "CEF:0|%s|%s|%s|%s|%s|%d|",
+		cefHeader(deviceVendor), cefHeader(deviceProduct), cefHeader(deviceVersion),
+		cefHeader(a.Rule), cefHeader(ruleName(a.Rule)), Severity(a.Severity))
+
+	ext := [][2]string{
+		{"rt", strconv.FormatInt(a.CreatedAt.AsTime().UnixMilli(), 10)},
+		{"cat", category},
+		{"dhost", a.Hostname},
+		{"externalId", strconv.FormatInt(a.Id, 10)},
+		{"cs1Label", "site"},
+		{"cs1", a.Site},
+		{"cn1Label", "inventoryId"},
+		{"cn1", strconv.FormatInt(a.InventoryId, 10)},
+		{"msg", a.Message},
+	}
+	for i, kv := range ext {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(kv[0] + "=" + cefValue(kv[1]))
+	}
+	return b.String()
+}
+
+func formatLEEF(a *collectorv1.Alert) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "LEEF:1.0|%s|%s|%s|%s|",
+		leefHeader(deviceVendor), leefHeader(deviceProduct), leefHeader(deviceVersion), leefHeader(a.Rule))
+
+	attrs := [][2]string{
+		{"devTime", a.CreatedAt.AsTime().UTC().Format(leefTime)},
+		{"sev", strconv.Itoa(Severity(a.Severity))},
+		{"cat", category},
+		{"identHostName", a.Hostname},
+		{"site", a.Site},
+		{"alertId", strconv.FormatInt(a.Id, 10)},
+		{"inventoryId", strconv.FormatInt(a.InventoryId, 10)},
+		{"msg", a.Message},
+	}
+	for i, kv := range attrs {
+		if i > 0 {
+			b.WriteByte('\t')
+		}
+		b.WriteString(kv[0] + "=" + leefValue(kv[1]))
+	}
+	return b.String()
+}
+
+// ruleName turns a rule into the human-readable event name, e.g.
+// "system serial changed".
+func ruleName(rule string) string {
+	return strings.ReplaceAll(rule, "_", " ")
+}
+
+var (
+	cefHeaderEscaper  = strings.NewReplacer(`\`, `\\`, `|`, `\|`, "\r", " ", "\n", " ")
+	cefValueEscaper   = strings.NewReplacer(`\`, `\\`, `=`, `\=`, "\r\n", `\n`, "\r", `\r`, "\n", `\n`)
+	leefHeaderEscaper = strings.NewReplacer(`\`, `\\`, `|`, `\|`, "\r", " ", "\n", " ")
+	leefValueEscaper  = strings.NewReplacer("\t", " ", "\r", " ", "\n", " ")
+)
+
+func cefHeader(s string) string  { return cefHeaderEscaper.Replace(s) }
+func cefValue(s string) string   { return cefValueEscaper.Replace(s) }
+func leefHeader(s string) string { return leefHeaderEscaper.Replace(s) }
+func leefValue(s string) string  { return leefValueEscaper.Replace(s) }
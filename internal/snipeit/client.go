@@ -0,0 +1,188 @@
+// Package snipeit synchronizes the latest inventories to assets in a
+// Snipe-IT asset management server through its REST API.
+package snipeit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// requestTimeout bounds a single Snipe-IT API call.
+const requestTimeout = 30 * time.Second
+
+// Client calls the Snipe-IT REST API (v1).
+type Client struct {
+	// URL is the base URL of the Snipe-IT server, e.g.
+	// https://assets.example.com.
+	URL string
+	// Token is a personal API token.
+	Token  string
+	Client *http.Client
+}
+
+// NewClient returns a client for the Snipe-IT server at baseURL.
+func NewClient(baseURL, token string) *Client {
+	return &Client{URL: strings.TrimSuffix(baseURL, "/"), Token: token, Client: http.DefaultClient}
+}
+
+// Asset is the part of a Snipe-IT asset the synchronization reads.
+type Asset struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	AssetTag string `json:"asset_tag"`
+	Serial   string `json:"serial"`
+}
+
+// named is a Snipe-IT model or manufacturer.
+type named struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// rows is the envelope of Snipe-IT list responses.
+type rows[T any] struct {
+	Total int `json:"total"`
+	Rows  []T `json:"rows"`
+}
+
+// result is the envelope of Snipe-IT write responses. Snipe-IT reports
+// validation errors with status "error" and HTTP 200.
+type result struct {
+	Status   string          `json:"status"`
+	Messages json.RawMessage `json:"messages"`
+	Payload  struct {
+		ID int `json:"id"`
+	} `json:"payload"`
+}
+
+// AssetsBySerial returns the assets with serial number serial.
+func (c *Client) AssetsBySerial(ctx context.Context, serial string) ([]Asset, error) {
+	var res rows[Asset]
+	if err := c.do(ctx, http.MethodGet, "/api/v1/hardware/byserial/"+url.PathEscape(serial), nil, &res); err != nil {
+		return nil, fmt.Errorf("find assets by serial: %w", err)
+	}
+	return res.Rows, nil
+}
+
+// CreateAsset creates an asset with fields and returns its ID.
+func (c *Client) CreateAsset(ctx context.Context, fields map[string]any) (int, error) {
+	id, err := c.write(ctx, http.MethodPost, "/api/v1/hardware", fields)
+	if err != nil {
+		return 0, fmt.Errorf("create asset: %w", err)
+	}
+	return id, nil
+}
+
+// UpdateAsset sets fields of the asset with ID id.
+func (c *Client) UpdateAsset(ctx context.Context, id int, fields map[string]any) error {
+	if _, err := c.write(ctx, http.MethodPatch, "/api/v1/hardware/"+strconv.Itoa(id), fields); err != nil {
+		return fmt.Errorf("update asset %d: %w", id, err)
+	}
+	return nil
+}
+
+// FindModel returns the ID of the model named name, or 0 if there is none.
+func (c *Client) FindModel(ctx context.Context, name string) (int, error) {
+	id, err := c.find(ctx, "/api/v1/models", name)
+	if err != nil {
+		return 0, fmt.Errorf("find model: %w", err)
+	}
+	return id, nil
+}
+
+// CreateModel creates a model with fields and returns its ID.
+func (c *Client) CreateModel(ctx context.Context, fields map[string]any) (int, error) {
+	id, err := c.write(ctx, http.MethodPost, "/api/v1/models", fields)
+	if err != nil {
+		return 0, fmt.Errorf("create model: %w", err)
+	}
+	return id, nil
+}
+
+// Manufacturer returns the ID of the manufacturer named name, creating it
+// if there is none.
+func (c *Client) Manufacturer(ctx context.Context, name string) (int, error) {
+	id, err := c.find(ctx, "/api/v1/manufacturers", name)
+	if err != nil {
+		return 0, fmt.Errorf("find manufacturer: %w", err)
+	}
+	if id != 0 {
+		return id, nil
+	}
+	if id, err = c.write(ctx, http.MethodPost, "/api/v1/manufacturers", map[string]any{"name": name}); err != nil {
+		return 0, fmt.Errorf("create manufacturer: %w", err)
+	}
+	return id, nil
+}
+
+// find searches the list at path for an entry named exactly name and
+// returns its ID, or 0.
+func (c *Client) find(ctx context.Context, path, name string) (int, error) {
+	var res rows[named]
+	if err := c.do(ctx, http.MethodGet, path+"?limit=50&search="+url.QueryEscape(name), nil, &res); err != nil {
+		return 0, err
+	}
+	for _, r := range res.Rows {
+		if strings.EqualFold(r.Name, name) {
+			return r.ID, nil
+		}
+	}
+	return 0, nil
+}
+
+// write sends body to path and returns the ID of the written object.
+func (c *Client) write(ctx context.Context, method, path string, body map[string]any) (int, error) {
+	var res result
+	if err := c.do(ctx, method, path, body, &res); err != nil {
+		return 0, err
+	}
+	if res.Status != "success" {
+		return 0, fmt.Errorf("snipe-it: %s", strings.Trim(string(res.Messages), `"`))
+	}
+	return res.Payload.ID, nil
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body, v any) error {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	var r io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("marshal request: %w", err)
+		}
+		r = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.URL+path, r)
+	if err != nil {
+		return fmt.Errorf("build request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.Token)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s %s: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("%s %s: unexpected status %s", method, path, resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("%s %s: decode response: %w", method, path, err)
+	}
+	return nil
+}
@@ -0,0 +1,398 @@
+package snipeit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+	"github.com/go-tangra/go-tangra-inventory/internal/convert"
+	"github.com/go-tangra/go-tangra-inventory/internal/logging"
+	"github.com/go-tangra/go-tangra-inventory/internal/store"
+)
+
+// hostPageSize is the number of hosts read from the store at a time.
+const hostPageSize = 500
+
+// AnySite is the Site.Name of the entry that applies to every site without
+// its own entry.
+const AnySite = "*"
+
+// Site configures the assets of the hosts of one site.
+type Site struct {
+	// Name is the site, "" for hosts without one, or AnySite.
+	Name string
+	// StatusID is the status label of created assets.
+	StatusID int
+	// CategoryID and FieldsetID are the category and custom fieldset of
+	// created models (FieldsetID 0 = none).
+	CategoryID int
+	FieldsetID int
+	// CompanyID and LocationID are set on created assets (0 = none).
+	CompanyID  int
+	LocationID int
+	// AssetTagPrefix is prepended to the serial number to form the asset
+	// tag of hosts whose firmware reports none.
+	AssetTagPrefix string
+	// Fields maps inventory values, by the names in FieldNames, to the DB
+	// columns of Snipe-IT custom fields, e.g. "cpu": "_snipeit_cpu_2".
+	Fields map[string]string
+}
+
+// fieldValues derive the custom field values from an inventory.
+var fieldValues = map[string]func(*collectorv1.Inventory) string{
+	"cpu":      cpuField,
+	"cores":    coresField,
+	"ram":      ramField,
+	"bios":     biosField,
+	"hostname": func(inv *collectorv1.Inventory) string { return inv.Hostname },
+	"username": func(inv *collectorv1.Inventory) string { return inv.Username },
+	"uuid":     func(inv *collectorv1.Inventory) string { return inv.GetSystem().GetUuid() },
+	"monitors": monitorsField,
+	"collected_at": func(inv *collectorv1.Inventory) string {
+		return inv.CollectedAt.AsTime().UTC().Format(time.DateTime)
+	},
+}
+
+// FieldNames returns the inventory values that can fill custom fields.
+func FieldNames() []string {
+	names := make([]string, 0, len(fieldValues))
+	for name := range fieldValues {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Validate checks that s can create assets.
+func (s Site) Validate() error {
+	if s.StatusID <= 0 {
+		return errors.New("status_id is required")
+	}
+	if s.CategoryID <= 0 {
+		return errors.New("category_id is required")
+	}
+	for name, column := range s.Fields {
+		if name == "disks" {
+			return errors.New("fields: disks: inventories carry no disk data")
+		}
+		if _, ok := fieldValues[name]; !ok {
+			return fmt.Errorf("fields: unknown value %q (use %s)", name, strings.Join(FieldNames(), ", "))
+		}
+		if !strings.HasPrefix(column, "_snipeit_") {
+			return fmt.Errorf("fields: %s: %q is not a custom field DB column such as _snipeit_%s_1", name, column, name)
+		}
+	}
+	return nil
+}
+
+// Result counts the hosts of a synchronization by outcome.
+type Result struct {
+	Created, Updated int
+	// Unchanged hosts have not submitted since they were last synchronized.
+	Unchanged int
+	// Skipped hosts have no usable serial number, match several assets or
+	// failed; the failures are logged.
+	Skipped int
+}
+
+// Syncer creates and updates Snipe-IT assets from the latest inventories.
+type Syncer struct {
+	client *Client
+	store  *store.Store
+	sites  map[string]Site
+
+	mu sync.Mutex
+	// synced is the latest inventory ID synchronized per host, so that
+	// hosts that have not submitted since are skipped.
+	synced map[[2]string]int64
+	// models caches model IDs by manufacturer and product name.
+	models map[[2]string]int
+}
+
+// NewSyncer returns a Syncer that synchronizes the hosts of the given sites
+// in db to the Snipe-IT server of client.
+func NewSyncer(client *Client, db *store.Store, sites []Site) (*Syncer, error) {
+	m := make(map[string]Site, len(sites))
+	for _, s := range sites {
+		if _, dup := m[s.Name]; dup {
+			return nil, fmt.Errorf("site %q is configured twice", s.Name)
+		}
+		if err := s.Validate(); err != nil {
+			return nil, fmt.Errorf("site %q: %w", s.Name, err)
+		}
+		m[s.Name] = s
+	}
+	return &Syncer{
+		client: client,
+		store:  db,
+		sites:  m,
+		synced: make(map[[2]string]int64),
+		models: make(map[[2]string]int),
+	}, nil
+}
+
+// site returns the configuration for hosts of site name.
+func (s *Syncer) site(name string) (Site, bool) {
+	if cfg, ok := s.sites[name]; ok {
+		return cfg, true
+	}
+	cfg, ok := s.sites[AnySite]
+	return cfg, ok
+}
+
+// Sync synchronizes the latest inventory of every host of a configured
+// site. With dryRun nothing is written to Snipe-IT and the outcomes are
+// counted as if it were. Errors about single hosts are logged and counted
+// as skipped; an error is returned when the hosts cannot be listed.
+func (s *Syncer) Sync(ctx context.Context, dryRun bool) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var res Result
+	for page := 1; ; page++ {
+		hosts, total, err := s.store.ListHosts(ctx, store.HostFilter{PageSize: hostPageSize, Page: page})
+		if err != nil {
+			return res, fmt.Errorf("list hosts: %w", err)
+		}
+		for _, h := range hosts {
+			site, ok := s.site(h.Site)
+			if !ok {
+				continue
+			}
+			key := [2]string{h.Site, h.Hostname}
+			if s.synced[key] == h.LatestID {
+				res.Unchanged++
+				continue
+			}
+			created, err := s.syncHost(ctx, site, h.LatestID, dryRun)
+			switch {
+			case err != nil:
+				res.Skipped++
+				slog.Warn("Snipe-IT sync skipped host", "site", h.Site, "hostname", h.Hostname, logging.Err(err))
+				continue
+			case created:
+				res.Created++
+			default:
+				res.Updated++
+			}
+			if !dryRun {
+				s.synced[key] = h.LatestID
+			}
+		}
+		if len(hosts) == 0 || page*hostPageSize >= total {
+			return res, ctx.Err()
+		}
+	}
+}
+
+// syncHost creates or updates the asset of the inventory with ID id and
+// reports whether it was created.
+func (s *Syncer) syncHost(ctx context.Context, site Site, id int64, dryRun bool) (bool, error) {
+	rec, err := s.store.Get(ctx, id, nil)
+	if err != nil {
+		return false, fmt.Errorf("read inventory %d: %w", id, err)
+	}
+	inv, err := convert.RecordToInventory(rec)
+	if err != nil {
+		return false, fmt.Errorf("decode inventory %d: %w", id, err)
+	}
+	serial := strings.TrimSpace(inv.GetSystem().GetSerialNumber())
+	if placeholder(serial) {
+		return false, errors.New("no usable system serial number")
+	}
+
+	assets, err := s.client.AssetsBySerial(ctx, serial)
+	if err != nil {
+		return false, err
+	}
+	fields := map[string]any{"name": inv.Hostname}
+	for name, column := range site.Fields {
+		fields[column] = fieldValues[name](inv)
+	}
+
+	switch len(assets) {
+	case 0:
+	case 1:
+		if dryRun {
+			return false, nil
+		}
+		return false, s.client.UpdateAsset(ctx, assets[0].ID, fields)
+	default:
+		return false, fmt.Errorf("serial number %s matches %d assets", serial, len(assets))
+	}
+
+	if dryRun {
+		return true, nil
+	}
+	modelID, err := s.model(ctx, site, inv)
+	if err != nil {
+		return false, err
+	}
+	fields["serial"] = serial
+	fields["model_id"] = modelID
+	fields["status_id"] = site.StatusID
+	fields["asset_tag"] = assetTag(site, inv, serial)
+	if site.CompanyID > 0 {
+		fields["company_id"] = site.CompanyID
+	}
+	if site.LocationID > 0 {
+		fields["rtd_location_id"] = site.LocationID
+	}
+	_, err = s.client.CreateAsset(ctx, fields)
+	return true, err
+}
+
+// model returns the ID of the Snipe-IT model of inv, creating the model and
+// its manufacturer if needed.
+func (s *Syncer) model(ctx context.Context, site Site, inv *collectorv1.Inventory) (int, error) {
+	manufacturer := strings.TrimSpace(inv.GetSystem().GetManufacturer())
+	product := strings.TrimSpace(inv.GetSystem().GetProductName())
+	if manufacturer == "" {
+		manufacturer = "Unknown"
+	}
+	if product == "" {
+		product = "Unknown"
+	}
+	key := [2]string{manufacturer, product}
+	if id, ok := s.models[key]; ok {
+		return id, nil
+	}
+
+	id, err := s.client.FindModel(ctx, product)
+	if err != nil {
+		return 0, err
+	}
+	if id == 0 {
+		manufacturerID, err := s.client.Manufacturer(ctx, manufacturer)
+		if err != nil {
+			return 0, err
+		}
+		fields := map[string]any{
+			"name":            product,
+			"category_id":     site.CategoryID,
+			"manufacturer_id": manufacturerID,
+		}
+		if sku := strings.TrimSpace(inv.GetSystem().GetSkuNumber()); !placeholder(sku) {
+			fields["model_number"] = sku
+		}
+		if site.FieldsetID > 0 {
+			fields["fieldset_id"] = site.FieldsetID
+		}
+		if id, err = s.client.CreateModel(ctx, fields); err != nil {
+			return 0, err
+		}
+		slog.Info("Created Snipe-IT model", "model", product, "manufacturer", manufacturer, "id", id)
+	}
+	s.models[key] = id
+	return id, nil
+}
+
+// placeholders are values firmware reports for unset serial numbers and
+// asset tags, compared case-insensitively.
+var placeholders = map[string]bool{
+	"":                         true,
+	"0":                        true,
+	"none":                     true,
+	"n/a":                      true,
+	"default string":           true,
+	"to be filled by o.e.m.":   true,
+	"system serial number":     true,
+	"not specified":            true,
+	"not applicable":           true,
+	"chassis serial number":    true,
+	"no asset tag":             true,
+	"no asset information":     true,
+	"asset-1234567890":         true,
+	"0123456789":               true,
+	"123456789":                true,
+	"xxxxxxxxxx":               true,
+	"invalid":                  true,
+	"o.e.m.":                   true,
+	"oem":                      true,
+	"unknown":                  true,
+	"serial number":            true,
+	"system product name":      true,
+	"base board serial number": true,
+}
+
+func placeholder(s string) bool {
+	return placeholders[strings.ToLower(strings.TrimSpace(s))]
+}
+
+// assetTag returns the asset tag the firmware reports for inv, or else one
+// made of the site's prefix and serial.
+func assetTag(site Site, inv *collectorv1.Inventory, serial string) string {
+	for _, tag := range []string{inv.GetChassis().GetAssetTagNumber(), inv.GetBaseboard().GetAssetTag()} {
+		if tag = strings.TrimSpace(tag); !placeholder(tag) {
+			return tag
+		}
+	}
+	return site.AssetTagPrefix + serial
+}
+
+func cpuField(inv *collectorv1.Inventory) string {
+	counts := map[string]int{}
+	var names []string
+	for _, p := range inv.Processors {
+		name := strings.Join(strings.Fields(p.Version), " ")
+		if !p.SocketPopulated || name == "" {
+			continue
+		}
+		if counts[name] == 0 {
+			names = append(names, name)
+		}
+		counts[name]++
+	}
+	for i, name := range names {
+		if counts[name] > 1 {
+			names[i] = fmt.Sprintf("%d x %s", counts[name], name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+func coresField(inv *collectorv1.Inventory) string {
+	var cores uint32
+	for _, p := range inv.Processors {
+		cores += p.CoreCount
+	}
+	if cores == 0 {
+		return ""
+	}
+	return fmt.Sprint(cores)
+}
+
+func ramField(inv *collectorv1.Inventory) string {
+	n := inv.GetMemory().GetTotalPhysicalBytes()
+	if n == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d GiB", (n+1<<29)>>30)
+}
+
+func biosField(inv *collectorv1.Inventory) string {
+	b := inv.GetBios()
+	s := strings.TrimSpace(b.GetVendor() + " " + b.GetVersion())
+	if b.GetReleaseDate() != "" {
+		s += " (" + b.GetReleaseDate() + ")"
+	}
+	return s
+}
+
+func monitorsField(inv *collectorv1.Inventory) string {
+	var monitors []string
+	for _, m := range inv.Monitor {
+		s := strings.TrimSpace(m.Manufacturer + " " + m.Model)
+		if !placeholder(m.SerialNumber) {
+			s += " (" + m.SerialNumber + ")"
+		}
+		monitors = append(monitors, s)
+	}
+	return strings.Join(monitors, ", ")
+}
@@ -0,0 +1,492 @@
+// Package snmp inventories network devices that cannot run the agent, such
+// as switches, printers and UPSes, by polling them over SNMP and turning
+// what they report into inventories.
+package snmp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gosnmp/gosnmp"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+	"github.com/go-tangra/go-tangra-inventory/internal/logging"
+	"github.com/go-tangra/go-tangra-inventory/internal/store"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Device classes of polled devices. A target's class overrides the one
+// detected from the MIBs it implements.
+const (
+	ClassSwitch  = "switch"
+	ClassPrinter = "printer"
+	ClassUPS     = "ups"
+	// ClassNetwork is any other SNMP device.
+	ClassNetwork = "network"
+)
+
+// Classes lists the device classes of polled devices.
+var Classes = []string{ClassSwitch, ClassPrinter, ClassUPS, ClassNetwork}
+
+// SNMPv2-MIB system group.
+const (
+	oidSysDescr    = ".1.3.6.1.2.1.1.1.0"
+	oidSysObjectID = ".1.3.6.1.2.1.1.2.0"
+	oidSysUpTime   = ".1.3.6.1.2.1.1.3.0"
+	oidSysContact  = ".1.3.6.1.2.1.1.4.0"
+	oidSysName     = ".1.3.6.1.2.1.1.5.0"
+	oidSysLocation = ".1.3.6.1.2.1.1.6.0"
+)
+
+// Identification objects of other MIBs, each of which also marks the device
+// class.
+const (
+	// BRIDGE-MIB dot1dBaseNumPorts.
+	oidBridgePorts = ".1.3.6.1.2.1.17.1.2.0"
+	// Printer-MIB prtGeneralSerialNumber of the first printer.
+	oidPrinterSerial = ".1.3.6.1.2.1.43.5.1.1.17.1"
+	// UPS-MIB upsIdentManufacturer, upsIdentModel and
+	// upsIdentUPSSoftwareVersion.
+	oidUPSManufacturer = ".1.3.6.1.2.1.33.1.1.1.0"
+	oidUPSModel        = ".1.3.6.1.2.1.33.1.1.2.0"
+	oidUPSSoftware     = ".1.3.6.1.2.1.33.1.1.3.0"
+	// PowerNet-MIB upsAdvIdentSerialNumber; UPS-MIB has no serial number
+	// and APC is the most common UPS vendor.
+	oidAPCSerial = ".1.3.6.1.4.1.318.1.1.1.1.2.3.0"
+)
+
+// ENTITY-MIB entPhysicalTable and the columns read from it.
+const (
+	oidEntPhysicalEntry = ".1.3.6.1.2.1.47.1.1.1.1"
+	entClass            = 5
+	entHardwareRev      = 8
+	entFirmwareRev      = 9
+	entSerialNum        = 11
+	entMfgName          = 12
+	entModelName        = 13
+	// entPhysicalClass chassis(3).
+	entClassChassis = 3
+)
+
+// enterprises names the vendors of common sysObjectID enterprise numbers,
+// for devices that do not report their manufacturer.
+var enterprises = map[string]string{
+	"9":     "Cisco",
+	"11":    "HP",
+	"43":    "3Com",
+	"253":   "Xerox",
+	"318":   "APC",
+	"367":   "Ricoh",
+	"534":   "Eaton",
+	"641":   "Lexmark",
+	"674":   "Dell",
+	"1347":  "Kyocera",
+	"1602":  "Canon",
+	"1916":  "Extreme Networks",
+	"2011":  "Huawei",
+	"2435":  "Brother",
+	"2636":  "Juniper Networks",
+	"4526":  "Netgear",
+	"11863": "TP-Link",
+	"14988": "MikroTik",
+	"25506": "H3C",
+	"30065": "Arista Networks",
+	"41112": "Ubiquiti",
+}
+
+// Credentials authenticate SNMP requests.
+type Credentials struct {
+	// Version is "1", "2c" or "3".
+	Version string
+	// Community is the SNMPv1/v2c community.
+	Community string
+	// Username, AuthProtocol (md5, sha, sha224, sha256, sha384 or sha512),
+	// AuthPassword, PrivProtocol (des, aes, aes192 or aes256) and
+	// PrivPassword are the SNMPv3 USM credentials; empty protocols disable
+	// authentication or privacy.
+	Username     string
+	AuthProtocol string
+	AuthPassword string
+	PrivProtocol string
+	PrivPassword string
+}
+
+// Target is a device to poll.
+type Target struct {
+	// Address is a host or IP address, with an optional :port (default
+	// 161).
+	Address string
+	// Site is the site of the device's inventories.
+	Site string
+	// Name replaces the device's sysName as its hostname.
+	Name string
+	// Class replaces the detected device class.
+	Class       string
+	Credentials Credentials
+}
+
+// SubmitFunc stores the inventory of a polled device.
+type SubmitFunc func(ctx context.Context, inv *collectorv1.Inventory) error
+
+// Result summarizes a polling run.
+type Result struct {
+	Polled int
+	Failed int
+}
+
+// Poller polls targets and submits an inventory for each device that
+// answers.
+type Poller struct {
+	targets []Target
+	timeout time.Duration
+	retries int
+	submit  SubmitFunc
+}
+
+// NewPoller returns a Poller of targets after validating them. timeout and
+// retries apply to every request.
+func NewPoller(targets []Target, timeout time.Duration, retries int, submit SubmitFunc) (*Poller, error) {
+	for i, t := range targets {
+		if _, _, err := splitAddress(t.Address); err != nil {
+			return nil, fmt.Errorf("target %d: %w", i, err)
+		}
+		if t.Class != "" && !slices.Contains(Classes, t.Class) {
+			return nil, fmt.Errorf("target %s: unknown class %q", t.Address, t.Class)
+		}
+		if _, err := t.Credentials.apply(&gosnmp.GoSNMP{}); err != nil {
+			return nil, fmt.Errorf("target %s: %w", t.Address, err)
+		}
+	}
+	if timeout <= 0 {
+		return nil, errors.New("timeout must be positive")
+	}
+	return &Poller{targets: targets, timeout: timeout, retries: retries, submit: submit}, nil
+}
+
+// Poll polls every target once. Devices that do not answer are logged and
+// counted as failed.
+func (p *Poller) Poll(ctx context.Context) (Result, error) {
+	var res Result
+	for _, t := range p.targets {
+		if err := ctx.Err(); err != nil {
+			return res, err
+		}
+		inv, err := p.Inventory(ctx, t)
+		if err == nil {
+			err = p.submit(ctx, inv)
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return res, ctx.Err()
+			}
+			slog.Warn("SNMP poll failed", "address", t.Address, logging.Err(err))
+			res.Failed++
+			continue
+		}
+		res.Polled++
+	}
+	return res, nil
+}
+
+// Inventory polls the target and returns its inventory.
+func (p *Poller) Inventory(ctx context.Context, t Target) (*collectorv1.Inventory, error) {
+	host, port, _ := splitAddress(t.Address)
+	g := &gosnmp.GoSNMP{
+		Target:         host,
+		Port:           port,
+		Context:        ctx,
+		Timeout:        p.timeout,
+		Retries:        p.retries,
+		MaxOids:        gosnmp.MaxOids,
+		MaxRepetitions: 20,
+	}
+	if _, err := t.Credentials.apply(g); err != nil {
+		return nil, err
+	}
+	if err := g.Connect(); err != nil {
+		return nil, fmt.Errorf("connect: %w", err)
+	}
+	defer g.Conn.Close()
+
+	values, err := get(g, oidSysDescr, oidSysObjectID, oidSysUpTime, oidSysContact, oidSysName, oidSysLocation,
+		oidBridgePorts, oidPrinterSerial, oidUPSManufacturer, oidUPSModel, oidUPSSoftware)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := values[oidSysObjectID]; !ok {
+		return nil, errors.New("no SNMPv2-MIB system group")
+	}
+
+	dev := &collectorv1.SNMPDevice{
+		Address:       t.Address,
+		SysDescr:      values[oidSysDescr].str(),
+		SysObjectId:   strings.TrimPrefix(values[oidSysObjectID].str(), "."),
+		SysLocation:   values[oidSysLocation].str(),
+		SysContact:    values[oidSysContact].str(),
+		UptimeSeconds: gosnmp.ToBigInt(values[oidSysUpTime].value).Int64() / 100,
+	}
+	sys := &collectorv1.SystemInfo{}
+	inv := &collectorv1.Inventory{
+		CollectedAt: timestamppb.Now(),
+		Hostname:    t.Name,
+		Site:        t.Site,
+		DeviceClass: t.Class,
+		Source:      store.SourceSNMP,
+		System:      sys,
+		Snmp:        dev,
+	}
+	if inv.Hostname == "" {
+		inv.Hostname = values[oidSysName].str()
+	}
+	if inv.Hostname == "" {
+		inv.Hostname = host
+	}
+
+	// The chassis entry of ENTITY-MIB describes the device as a whole.
+	if chassis, err := entityChassis(g); err != nil {
+		slog.Debug("ENTITY-MIB walk failed", "address", t.Address, logging.Err(err))
+	} else if chassis != nil {
+		sys.Manufacturer = chassis[entMfgName]
+		sys.ProductName = chassis[entModelName]
+		sys.SerialNumber = chassis[entSerialNum]
+		sys.Version = firstNonEmpty(chassis[entHardwareRev], chassis[entFirmwareRev])
+	}
+
+	switch {
+	case values.has(oidUPSModel):
+		if inv.DeviceClass == "" {
+			inv.DeviceClass = ClassUPS
+		}
+		sys.Manufacturer = firstNonEmpty(sys.Manufacturer, values[oidUPSManufacturer].str())
+		sys.ProductName = firstNonEmpty(sys.ProductName, values[oidUPSModel].str())
+		sys.Version = firstNonEmpty(sys.Version, values[oidUPSSoftware].str())
+		if sys.SerialNumber == "" {
+			if apc, err := get(g, oidAPCSerial); err == nil {
+				sys.SerialNumber = apc[oidAPCSerial].str()
+			}
+		}
+	case values.has(oidPrinterSerial):
+		if inv.DeviceClass == "" {
+			inv.DeviceClass = ClassPrinter
+		}
+		sys.SerialNumber = firstNonEmpty(sys.SerialNumber, values[oidPrinterSerial].str())
+	case values.has(oidBridgePorts):
+		if inv.DeviceClass == "" {
+			inv.DeviceClass = ClassSwitch
+		}
+	}
+	if inv.DeviceClass == "" {
+		inv.DeviceClass = ClassNetwork
+	}
+	if sys.Manufacturer == "" {
+		sys.Manufacturer = enterprises[enterprise(dev.SysObjectId)]
+	}
+	if sys.ProductName == "" {
+		// Printers and small switches often only name their model in
+		// sysDescr.
+		sys.ProductName, _, _ = strings.Cut(dev.SysDescr, "\n")
+	}
+	return inv, nil
+}
+
+// variable is the value of an SNMP variable.
+type variable struct {
+	value any
+}
+
+// str returns the value as a string.
+func (v variable) str() string {
+	switch val := v.value.(type) {
+	case []byte:
+		return printable(val)
+	case string:
+		return strings.TrimSpace(val)
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+// variables maps the OIDs that exist on a device to their values.
+type variables map[string]variable
+
+func (v variables) has(oid string) bool {
+	_, ok := v[oid]
+	return ok
+}
+
+// get requests oids in one PDU and returns those the device has.
+func get(g *gosnmp.GoSNMP, oids ...string) (variables, error) {
+	pkt, err := g.Get(oids)
+	if err != nil {
+		return nil, err
+	}
+	if pkt.Error != gosnmp.NoError {
+		return nil, fmt.Errorf("get: %s", pkt.Error)
+	}
+	values := make(variables)
+	for _, pdu := range pkt.Variables {
+		switch pdu.Type {
+		case gosnmp.NoSuchObject, gosnmp.NoSuchInstance, gosnmp.EndOfMibView, gosnmp.Null:
+			continue
+		}
+		values[pdu.Name] = variable{value: pdu.Value}
+	}
+	return values, nil
+}
+
+// entityChassis returns the columns of the first chassis in the ENTITY-MIB
+// entPhysicalTable, or nil if the device has none.
+func entityChassis(g *gosnmp.GoSNMP) (map[int]string, error) {
+	walk := g.BulkWalkAll
+	if g.Version == gosnmp.Version1 {
+		walk = g.WalkAll
+	}
+	pdus, err := walk(oidEntPhysicalEntry)
+	if err != nil {
+		return nil, err
+	}
+
+	// Rows are indexed by entPhysicalIndex: .<column>.<index>.
+	rows := make(map[string]map[int]string)
+	var chassis []string
+	for _, pdu := range pdus {
+		col, index, ok := strings.Cut(strings.TrimPrefix(pdu.Name, oidEntPhysicalEntry+"."), ".")
+		if !ok {
+			continue
+		}
+		n, err := strconv.Atoi(col)
+		if err != nil {
+			continue
+		}
+		if rows[index] == nil {
+			rows[index] = make(map[int]string)
+		}
+		v := variable{value: pdu.Value}
+		rows[index][n] = v.str()
+		if n == entClass && gosnmp.ToBigInt(pdu.Value).Int64() == entClassChassis {
+			chassis = append(chassis, index)
+		}
+	}
+	if len(chassis) == 0 {
+		return nil, nil
+	}
+	return rows[chassis[0]], nil
+}
+
+// apply sets the credentials on g and returns it.
+func (c Credentials) apply(g *gosnmp.GoSNMP) (*gosnmp.GoSNMP, error) {
+	switch c.Version {
+	case "1", "2c", "":
+		g.Version = gosnmp.Version2c
+		if c.Version == "1" {
+			g.Version = gosnmp.Version1
+		}
+		if c.Community == "" {
+			return nil, errors.New("community is required for SNMPv1 and v2c")
+		}
+		g.Community = c.Community
+		return g, nil
+	case "3":
+	default:
+		return nil, fmt.Errorf("unknown SNMP version %q (use 1, 2c or 3)", c.Version)
+	}
+
+	if c.Username == "" {
+		return nil, errors.New("username is required for SNMPv3")
+	}
+	params := &gosnmp.UsmSecurityParameters{UserName: c.Username}
+	flags := gosnmp.NoAuthNoPriv
+	if c.AuthProtocol != "" {
+		auth, ok := map[string]gosnmp.SnmpV3AuthProtocol{
+			"md5": gosnmp.MD5, "sha": gosnmp.SHA, "sha224": gosnmp.SHA224,
+			"sha256": gosnmp.SHA256, "sha384": gosnmp.SHA384, "sha512": gosnmp.SHA512,
+		}[strings.ToLower(c.AuthProtocol)]
+		if !ok {
+			return nil, fmt.Errorf("unknown auth protocol %q", c.AuthProtocol)
+		}
+		if c.AuthPassword == "" {
+			return nil, errors.New("auth password is required with an auth protocol")
+		}
+		params.AuthenticationProtocol, params.AuthenticationPassphrase = auth, c.AuthPassword
+		flags = gosnmp.AuthNoPriv
+	}
+	if c.PrivProtocol != "" {
+		if flags == gosnmp.NoAuthNoPriv {
+			return nil, errors.New("privacy needs an auth protocol")
+		}
+		priv, ok := map[string]gosnmp.SnmpV3PrivProtocol{
+			"des": gosnmp.DES, "aes": gosnmp.AES, "aes192": gosnmp.AES192, "aes256": gosnmp.AES256,
+		}[strings.ToLower(c.PrivProtocol)]
+		if !ok {
+			return nil, fmt.Errorf("unknown privacy protocol %q", c.PrivProtocol)
+		}
+		if c.PrivPassword == "" {
+			return nil, errors.New("privacy password is required with a privacy protocol")
+		}
+		params.PrivacyProtocol, params.PrivacyPassphrase = priv, c.PrivPassword
+		flags = gosnmp.AuthPriv
+	}
+	g.Version = gosnmp.Version3
+	g.SecurityModel = gosnmp.UserSecurityModel
+	g.MsgFlags = flags
+	g.SecurityParameters = params
+	return g, nil
+}
+
+// splitAddress splits a target address into host and port.
+func splitAddress(address string) (string, uint16, error) {
+	if address == "" {
+		return "", 0, errors.New("address is required")
+	}
+	host, portStr, err := net.SplitHostPort(address)
+	if err != nil {
+		// No port, or a bare IPv6 address.
+		return strings.Trim(address, "[]"), 161, nil
+	}
+	port, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil || port == 0 {
+		return "", 0, fmt.Errorf("invalid port in %q", address)
+	}
+	return host, uint16(port), nil
+}
+
+// enterprise returns the enterprise number of a sysObjectID under
+// 1.3.6.1.4.1.
+func enterprise(oid string) string {
+	rest, ok := strings.CutPrefix(oid, "1.3.6.1.4.1.")
+	if !ok {
+		return ""
+	}
+	number, _, _ := strings.Cut(rest, ".")
+	return number
+}
+
+// printable returns b as trimmed text, or as hex if it is not valid UTF-8
+// (e.g. a MAC address).
+func printable(b []byte) string {
+	b = []byte(strings.TrimRight(string(b), "\x00"))
+	if !utf8.Valid(b) {
+		return fmt.Sprintf("%x", b)
+	}
+	return strings.TrimSpace(string(b))
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
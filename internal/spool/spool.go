@@ -0,0 +1,177 @@
+// Package spool keeps inventories that could not be submitted because the
+// collector was unreachable, and replays them in collection order once it
+// can be reached again.
+package spool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/go-tangra/go-tangra-inventory/internal/collector"
+	"github.com/go-tangra/go-tangra-inventory/internal/logging"
+	"github.com/go-tangra/go-tangra-inventory/internal/sender"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	fileSuffix = ".json"
+
+	// maxEntries bounds the spool; the oldest entries are dropped first.
+	maxEntries = 100
+)
+
+// SendFunc submits one inventory to the collector.
+type SendFunc func(ctx context.Context, inv *collector.Inventory) error
+
+// Spool is a directory of pending inventories, one JSON file each, named so
+// that lexical order is collection order. A nil *Spool disables spooling.
+type Spool struct {
+	dir string
+}
+
+// New opens the spool directory, creating it if needed.
+func New(dir string) (*Spool, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("create spool directory: %w", err)
+	}
+	return &Spool{dir: dir}, nil
+}
+
+// Submit replays any spooled inventories and then sends inv. When the
+// collector is unreachable inv is spooled instead, and spooled reports true
+// with a nil error.
+func (s *Spool) Submit(ctx context.Context, inv *collector.Inventory, send SendFunc) (spooled bool, err error) {
+	if s == nil {
+		return false, send(ctx, inv)
+	}
+
+	_, err = s.Replay(ctx, send)
+	if err == nil {
+		if err = send(ctx, inv); err == nil {
+			return false, nil
+		}
+	}
+	if !sender.Retryable(err) {
+		return false, err
+	}
+
+	slog.Warn("Collector unreachable, spooling inventory", logging.Err(err))
+	if err := s.Put(inv); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Put writes inv to the spool, dropping the oldest entries beyond maxEntries.
+func (s *Spool) Put(inv *collector.Inventory) error {
+	data, err := json.Marshal(inv)
+	if err != nil {
+		return fmt.Errorf("marshal inventory: %w", err)
+	}
+
+	pattern := fmt.Sprintf("%020d-*%s", inv.CollectedAt.UnixNano(), fileSuffix)
+	f, err := os.CreateTemp(s.dir, pattern+".tmp")
+	if err != nil {
+		return fmt.Errorf("create spool file: %w", err)
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return fmt.Errorf("write spool file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return fmt.Errorf("write spool file: %w", err)
+	}
+	if err := os.Rename(f.Name(), strings.TrimSuffix(f.Name(), ".tmp")); err != nil {
+		os.Remove(f.Name())
+		return fmt.Errorf("commit spool file: %w", err)
+	}
+
+	files, err := s.files()
+	if err != nil {
+		return err
+	}
+	for len(files) > maxEntries {
+		slog.Warn("Spool full; dropping oldest inventory", "entry", filepath.Base(files[0]))
+		os.Remove(files[0])
+		files = files[1:]
+	}
+
+	slog.Info("Inventory spooled", "pending", len(files))
+	return nil
+}
+
+// Replay sends spooled inventories oldest first, removing each one once the
+// collector has accepted it. It stops at the first failure, leaving that
+// entry and the newer ones in place; entries the collector rejects as
+// invalid are discarded so they cannot block the queue.
+func (s *Spool) Replay(ctx context.Context, send SendFunc) (int, error) {
+	if s == nil {
+		return 0, nil
+	}
+
+	files, err := s.files()
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, path := range files {
+		inv, err := readEntry(path)
+		if err != nil {
+			slog.Warn("Discarding unreadable spool entry", "entry", filepath.Base(path), logging.Err(err))
+			os.Remove(path)
+			continue
+		}
+
+		if err := send(ctx, inv); err != nil {
+			if status.Code(err) == codes.InvalidArgument {
+				slog.Warn("Discarding spool entry rejected by collector", "entry", filepath.Base(path), logging.Err(err))
+				os.Remove(path)
+				continue
+			}
+			return sent, fmt.Errorf("replay spooled inventory: %w", err)
+		}
+
+		if err := os.Remove(path); err != nil {
+			return sent, fmt.Errorf("remove spool file: %w", err)
+		}
+		sent++
+	}
+
+	if sent > 0 {
+		slog.Info("Replayed spooled inventories", "count", sent)
+	}
+	return sent, nil
+}
+
+// files returns the committed spool entries, oldest first.
+func (s *Spool) files() ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(s.dir, "*"+fileSuffix))
+	if err != nil {
+		return nil, fmt.Errorf("list spool: %w", err)
+	}
+	slices.Sort(files)
+	return files, nil
+}
+
+func readEntry(path string) (*collector.Inventory, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var inv collector.Inventory
+	if err := json.Unmarshal(data, &inv); err != nil {
+		return nil, err
+	}
+	return &inv, nil
+}
@@ -0,0 +1,146 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// AlertRecord represents a stored hardware change alert.
+type AlertRecord struct {
+	ID           int64
+	Site         string
+	Hostname     string
+	InventoryID  int64
+	Rule         string
+	Severity     string
+	Message      string
+	CreatedAt    time.Time
+	Acknowledged bool
+}
+
+// AlertFilter holds optional query parameters for listing alerts.
+type AlertFilter struct {
+	Sites              []string
+	Hostname           string
+	UnacknowledgedOnly bool
+	// CreatedAfter and CreatedBefore bound the creation time (nil = no
+	// bound).
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	PageSize      int
+	Page          int
+}
+
+// InsertAlerts stores the given alerts in a single transaction, filling in
+// their IDs and creation time.
+func (s *Store) InsertAlerts(ctx context.Context, alerts []AlertRecord) error {
+	if len(alerts) == 0 {
+		return nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	createdAt := time.Now().UTC()
+	for i := range alerts {
+		a := &alerts[i]
+		result, err := tx.ExecContext(ctx,
+			`INSERT INTO alerts (site, hostname, inventory_id, rule, severity, message, created_at)
+			 VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			a.Site, a.Hostname, a.InventoryID, a.Rule, a.Severity, a.Message, createdAt.Format(time.RFC3339))
+		if err != nil {
+			return fmt.Errorf("insert alert: %w", err)
+		}
+		if a.ID, err = result.LastInsertId(); err != nil {
+			return fmt.Errorf("get last insert id: %w", err)
+		}
+		a.CreatedAt = createdAt
+	}
+
+	return tx.Commit()
+}
+
+// ListAlerts returns alerts matching the given filter, newest first.
+func (s *Store) ListAlerts(ctx context.Context, f AlertFilter) ([]AlertRecord, int, error) {
+	var conditions []string
+	var args []any
+	if f.Sites != nil {
+		cond, siteArgs := siteCondition(f.Sites)
+		conditions = append(conditions, cond)
+		args = append(args, siteArgs...)
+	}
+	if f.Hostname != "" {
+		conditions = append(conditions, "hostname = ?")
+		args = append(args, f.Hostname)
+	}
+	if f.UnacknowledgedOnly {
+		conditions = append(conditions, "acknowledged = 0")
+	}
+	if f.CreatedAfter != nil {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, f.CreatedAfter.UTC().Format(time.RFC3339))
+	}
+	if f.CreatedBefore != nil {
+		conditions = append(conditions, "created_at <= ?")
+		args = append(args, f.CreatedBefore.UTC().Format(time.RFC3339))
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	if err := s.read.QueryRowContext(ctx, "SELECT COUNT(*) FROM alerts"+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count alerts: %w", err)
+	}
+
+	limit, offset := pageBounds(f.PageSize, f.Page)
+	rows, err := s.read.QueryContext(ctx,
+		`SELECT id, site, hostname, inventory_id, rule, severity, message, created_at, acknowledged
+		 FROM alerts`+where+` ORDER BY id DESC LIMIT ? OFFSET ?`,
+		append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list alerts: %w", err)
+	}
+	defer rows.Close()
+
+	var alerts []AlertRecord
+	for rows.Next() {
+		var a AlertRecord
+		var createdAt string
+		if err := rows.Scan(&a.ID, &a.Site, &a.Hostname, &a.InventoryID, &a.Rule, &a.Severity, &a.Message, &createdAt, &a.Acknowledged); err != nil {
+			return nil, 0, err
+		}
+		a.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+		alerts = append(alerts, a)
+	}
+
+	return alerts, total, rows.Err()
+}
+
+// AcknowledgeAlert marks an alert as acknowledged. A non-nil sites restricts
+// the update to alerts of those sites.
+func (s *Store) AcknowledgeAlert(ctx context.Context, id int64, sites []string) error {
+	scope, args := siteScope(sites)
+	result, err := s.db.ExecContext(ctx, `UPDATE alerts SET acknowledged = 1 WHERE id = ?`+scope, append([]any{id}, args...)...)
+	if err != nil {
+		return fmt.Errorf("acknowledge alert: %w", err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("rows affected: %w", err)
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package store
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// countCacheSize bounds the number of filters whose totals are cached.
+const countCacheSize = 1024
+
+// countCache caches the total counts of List by filter, so that paging
+// through a large list does not count it again for every page. Deleting or
+// purging inventories through the Store invalidates it; stored inventories
+// are only counted once an entry expires, so cached totals may fall behind
+// by the submissions of a TTL.
+type countCache struct {
+	ttl time.Duration
+
+	mu sync.Mutex
+	// gen is incremented by every invalidation, so that a count that raced
+	// with a deletion is not cached.
+	gen    uint64
+	counts map[string]cachedCount
+}
+
+type cachedCount struct {
+	total   int
+	expires time.Time
+}
+
+// CacheCounts enables caching of the total counts of List for ttl, unless
+// ListFilter.ExactCount is set. It must be called before the Store is used
+// concurrently.
+func (s *Store) CacheCounts(ttl time.Duration) {
+	if ttl <= 0 {
+		s.counts = nil
+		return
+	}
+	s.counts = &countCache{ttl: ttl, counts: make(map[string]cachedCount)}
+}
+
+// countKey identifies the rows a WHERE clause with args selects.
+func countKey(where string, args []any) string {
+	var b strings.Builder
+	b.WriteString(where)
+	for _, a := range args {
+		fmt.Fprintf(&b, "\x00%v", a)
+	}
+	return b.String()
+}
+
+// get returns the cached count for key and whether there is one. A nil
+// cache has none.
+func (c *countCache) get(key string) (int, bool) {
+	if c == nil {
+		return 0, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	n, ok := c.counts[key]
+	if !ok || time.Now().After(n.expires) {
+		return 0, false
+	}
+	return n.total, true
+}
+
+func (c *countCache) generation() uint64 {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.gen
+}
+
+// put caches total for key, unless the cache was invalidated since gen.
+func (c *countCache) put(key string, total int, gen uint64) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if gen != c.gen {
+		return
+	}
+	now := time.Now()
+	if len(c.counts) >= countCacheSize {
+		for k, n := range c.counts {
+			if now.After(n.expires) {
+				delete(c.counts, k)
+			}
+		}
+		if len(c.counts) >= countCacheSize {
+			clear(c.counts)
+		}
+	}
+	c.counts[key] = cachedCount{total: total, expires: now.Add(c.ttl)}
+}
+
+// invalidate drops every cached count.
+func (c *countCache) invalidate() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.gen++
+	clear(c.counts)
+}
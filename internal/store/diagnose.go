@@ -0,0 +1,152 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+)
+
+// Diagnosis is the outcome of Diagnose.
+type Diagnosis struct {
+	VerifyResult
+	// SchemaVersion is the newest applied migration, 0 for a database
+	// created before migrations were versioned.
+	SchemaVersion int
+	// MissingIndexes lists the CREATE INDEX statements of the indexes that
+	// SchemaVersion creates but the database lacks.
+	MissingIndexes []string
+	// Size and WALSize are the on-disk sizes of the database file and its
+	// write-ahead log.
+	Size    int64
+	WALSize int64
+	// NewestStoredAt is when the most recent inventory was stored, zero for
+	// an empty database.
+	NewestStoredAt time.Time
+	// ClockAhead lists the hosts whose latest inventory was collected more
+	// than the allowed skew after the collector stored it.
+	ClockAhead []string
+}
+
+// Diagnose checks the database file at path like Verify and inspects its
+// schema, size and timestamps, without modifying it. maxSkew is how far an
+// agent's collection time may be ahead of the collector's clock.
+func Diagnose(ctx context.Context, path string, maxSkew time.Duration) (*Diagnosis, error) {
+	res, err := Verify(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	d := &Diagnosis{VerifyResult: *res, Size: fileSize(path), WALSize: fileSize(path + "-wal")}
+
+	db, err := sql.Open("sqlite", "file:"+path+"?mode=ro")
+	if err != nil {
+		return nil, fmt.Errorf("open database: %w", err)
+	}
+	defer db.Close()
+
+	versioned, err := hasTable(ctx, db, "schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	if versioned {
+		if err := db.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&d.SchemaVersion); err != nil {
+			return nil, fmt.Errorf("read schema version: %w", err)
+		}
+	}
+	if d.SchemaVersion > 0 && d.SchemaVersion <= LatestVersion() {
+		want, err := schemaIndexes(ctx, d.SchemaVersion)
+		if err != nil {
+			return nil, err
+		}
+		have, err := indexes(ctx, db)
+		if err != nil {
+			return nil, err
+		}
+		for name, stmt := range want {
+			if _, ok := have[name]; !ok {
+				d.MissingIndexes = append(d.MissingIndexes, stmt)
+			}
+		}
+		slices.Sort(d.MissingIndexes)
+	}
+
+	var newest sql.NullString
+	if err := db.QueryRowContext(ctx, `SELECT MAX(stored_at) FROM inventories`).Scan(&newest); err != nil {
+		return nil, fmt.Errorf("read newest inventory: %w", err)
+	}
+	if newest.Valid {
+		d.NewestStoredAt, _ = time.Parse(time.RFC3339, newest.String)
+	}
+
+	rows, err := db.QueryContext(ctx,
+		`SELECT hostname, collected_at, stored_at FROM inventories
+		 WHERE id IN (SELECT MAX(id) FROM inventories GROUP BY site, hostname)
+		 ORDER BY hostname`)
+	if err != nil {
+		return nil, fmt.Errorf("read latest inventories: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var hostname, collectedAt, storedAt string
+		if err := rows.Scan(&hostname, &collectedAt, &storedAt); err != nil {
+			return nil, fmt.Errorf("read latest inventories: %w", err)
+		}
+		collected, err1 := time.Parse(time.RFC3339, collectedAt)
+		stored, err2 := time.Parse(time.RFC3339, storedAt)
+		if err1 == nil && err2 == nil && collected.Sub(stored) > maxSkew {
+			d.ClockAhead = append(d.ClockAhead, hostname)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("read latest inventories: %w", err)
+	}
+	return d, nil
+}
+
+// schemaIndexes returns the indexes that migrating an empty database to
+// version creates.
+func schemaIndexes(ctx context.Context, version int) (map[string]string, error) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		return nil, fmt.Errorf("open schema database: %w", err)
+	}
+	defer db.Close()
+	// Every connection to :memory: has its own database.
+	db.SetMaxOpenConns(1)
+
+	if _, err := migrateTo(ctx, db, version); err != nil {
+		return nil, fmt.Errorf("build reference schema: %w", err)
+	}
+	return indexes(ctx, db)
+}
+
+// indexes returns the explicitly created indexes by name, with the
+// statement that created them.
+func indexes(ctx context.Context, q querier) (map[string]string, error) {
+	rows, err := q.QueryContext(ctx, `SELECT name, sql FROM sqlite_master WHERE type = 'index' AND sql IS NOT NULL`)
+	if err != nil {
+		return nil, fmt.Errorf("list indexes: %w", err)
+	}
+	defer rows.Close()
+
+	idx := make(map[string]string)
+	for rows.Next() {
+		var name, stmt string
+		if err := rows.Scan(&name, &stmt); err != nil {
+			return nil, fmt.Errorf("list indexes: %w", err)
+		}
+		idx[name] = stmt
+	}
+	return idx, rows.Err()
+}
+
+// fileSize returns the size of the file at path, 0 if it does not exist.
+func fileSize(path string) int64 {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return 0
+	}
+	return fi.Size()
+}
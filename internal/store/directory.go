@@ -0,0 +1,51 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// HostDirectory is what a directory (Active Directory or LDAP) holds about
+// a host and the user last logged on to it.
+type HostDirectory struct {
+	Site     string
+	Hostname string
+	// OU is the DN of the container holding the computer object.
+	OU          string
+	Description string
+	// Username is the account looked up, as reported by the host's latest
+	// inventory.
+	Username        string
+	UserDisplayName string
+	Department      string
+	UpdatedAt       time.Time
+}
+
+// UpsertHostDirectory stores d as the directory information of its host,
+// replacing the previous one, and sets its UpdatedAt.
+func (s *Store) UpsertHostDirectory(ctx context.Context, d *HostDirectory) error {
+	d.UpdatedAt = time.Now().UTC()
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO host_directory (site, hostname, ou, description, username, user_display_name, department, updated_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		 ON CONFLICT (site, hostname) DO UPDATE SET
+		   ou = excluded.ou, description = excluded.description, username = excluded.username,
+		   user_display_name = excluded.user_display_name, department = excluded.department,
+		   updated_at = excluded.updated_at`,
+		d.Site, d.Hostname, d.OU, d.Description, d.Username, d.UserDisplayName, d.Department,
+		d.UpdatedAt.Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("upsert host directory: %w", err)
+	}
+	return nil
+}
+
+// DeleteHostDirectory removes the directory information of the host.
+func (s *Store) DeleteHostDirectory(ctx context.Context, site, hostname string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM host_directory WHERE site = ? AND hostname = ?`, site, hostname)
+	if err != nil {
+		return fmt.Errorf("delete host directory: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SetHostFields sets the custom fields of a host, such as its owner,
+// location or cost center, leaving its other fields as they are. Fields
+// with an empty value are removed.
+func (s *Store) SetHostFields(ctx context.Context, site, hostname string, fields map[string]string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	for name, value := range fields {
+		if value == "" {
+			_, err = tx.ExecContext(ctx, `DELETE FROM host_fields WHERE site = ? AND hostname = ? AND name = ?`, site, hostname, name)
+		} else {
+			_, err = tx.ExecContext(ctx,
+				`INSERT INTO host_fields (site, hostname, name, value, updated_at) VALUES (?, ?, ?, ?, ?)
+				 ON CONFLICT (site, hostname, name) DO UPDATE SET value = excluded.value, updated_at = excluded.updated_at
+				 WHERE value != excluded.value`,
+				site, hostname, name, value, now)
+		}
+		if err != nil {
+			return fmt.Errorf("set host field %s: %w", name, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// loadHostFields sets the Fields of hosts.
+func (s *Store) loadHostFields(ctx context.Context, hosts []HostRecord) error {
+	if len(hosts) == 0 {
+		return nil
+	}
+	index := make(map[[2]string]*HostRecord, len(hosts))
+	pairs := make([]string, len(hosts))
+	args := make([]any, 0, 2*len(hosts))
+	for i := range hosts {
+		h := &hosts[i]
+		index[[2]string{h.Site, h.Hostname}] = h
+		pairs[i] = "(?, ?)"
+		args = append(args, h.Site, h.Hostname)
+	}
+
+	rows, err := s.read.QueryContext(ctx,
+		`SELECT site, hostname, name, value FROM host_fields
+		 WHERE (site, hostname) IN (VALUES `+strings.Join(pairs, ", ")+`)`, args...)
+	if err != nil {
+		return fmt.Errorf("load host fields: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var site, hostname, name, value string
+		if err := rows.Scan(&site, &hostname, &name, &value); err != nil {
+			return err
+		}
+		h := index[[2]string{site, hostname}]
+		if h == nil {
+			continue
+		}
+		if h.Fields == nil {
+			h.Fields = make(map[string]string)
+		}
+		h.Fields[name] = value
+	}
+	return rows.Err()
+}
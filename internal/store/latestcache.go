@@ -0,0 +1,175 @@
+package store
+
+import (
+	"container/list"
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+// latestCache caches the results of GetLatestByHostname for the most
+// recently looked up hosts, including that a host has no inventory.
+// Storing, deleting or purging inventories through the Store invalidates
+// it; entries also expire after a TTL, which bounds how long writes by other
+// processes go unnoticed.
+type latestCache struct {
+	size int
+	ttl  time.Duration
+
+	mu sync.Mutex
+	// gen is incremented by every invalidation, so that a lookup that raced
+	// with a write does not cache what it read before the write.
+	gen uint64
+	// lru holds a *latestHost per host, most recently used first.
+	lru   *list.List
+	hosts map[string]*list.Element
+}
+
+// latestHost is the cached lookups of one hostname, by site scope.
+type latestHost struct {
+	hostname string
+	scopes   map[string]latestResult
+}
+
+type latestResult struct {
+	// rec is nil if the host has no inventory within the scope.
+	rec     *InventoryRecord
+	expires time.Time
+}
+
+// CacheLatest enables caching of up to size hosts in GetLatestByHostname,
+// for ttl each. It must be called before the Store is used concurrently.
+func (s *Store) CacheLatest(size int, ttl time.Duration) {
+	if size <= 0 || ttl <= 0 {
+		s.latest = nil
+		return
+	}
+	s.latest = &latestCache{
+		size:  size,
+		ttl:   ttl,
+		lru:   list.New(),
+		hosts: make(map[string]*list.Element),
+	}
+}
+
+// GetLatestByHostname retrieves the most recent inventory for a hostname.
+func (s *Store) GetLatestByHostname(ctx context.Context, hostname string, sites []string) (*InventoryRecord, error) {
+	if s.latest == nil {
+		return s.getLatest(ctx, "hostname", hostname, sites)
+	}
+
+	scope := scopeKey(sites)
+	if rec, ok := s.latest.get(hostname, scope); ok {
+		if rec == nil {
+			return nil, sql.ErrNoRows
+		}
+		return rec, nil
+	}
+	gen := s.latest.generation()
+	rec, err := s.getLatest(ctx, "hostname", hostname, sites)
+	if err == nil || errors.Is(err, sql.ErrNoRows) {
+		s.latest.put(hostname, scope, rec, gen)
+	}
+	return rec, err
+}
+
+// scopeKey identifies the site restriction sites: nil is every site.
+func scopeKey(sites []string) string {
+	if sites == nil {
+		return "*"
+	}
+	return "=" + strings.Join(sites, "\x00")
+}
+
+// get returns a copy of the cached result for hostname within scope, and
+// whether there is one.
+func (c *latestCache) get(hostname, scope string) (*InventoryRecord, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.hosts[hostname]
+	if !ok {
+		return nil, false
+	}
+	h := e.Value.(*latestHost)
+	res, ok := h.scopes[scope]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(res.expires) {
+		delete(h.scopes, scope)
+		return nil, false
+	}
+	c.lru.MoveToFront(e)
+	if res.rec == nil {
+		return nil, true
+	}
+	rec := *res.rec
+	return &rec, true
+}
+
+func (c *latestCache) generation() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.gen
+}
+
+// put caches rec, or nil for no inventory, as the result for hostname
+// within scope, unless the cache was invalidated since generation gen.
+func (c *latestCache) put(hostname, scope string, rec *InventoryRecord, gen uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if gen != c.gen {
+		return
+	}
+	var h *latestHost
+	if e, ok := c.hosts[hostname]; ok {
+		c.lru.MoveToFront(e)
+		h = e.Value.(*latestHost)
+	} else {
+		h = &latestHost{hostname: hostname, scopes: make(map[string]latestResult)}
+		c.hosts[hostname] = c.lru.PushFront(h)
+		if c.lru.Len() > c.size {
+			oldest := c.lru.Back()
+			c.lru.Remove(oldest)
+			delete(c.hosts, oldest.Value.(*latestHost).hostname)
+		}
+	}
+	if rec != nil {
+		copied := *rec
+		rec = &copied
+	}
+	h.scopes[scope] = latestResult{rec: rec, expires: time.Now().Add(c.ttl)}
+}
+
+// invalidate drops the results for hostname.
+func (c *latestCache) invalidate(hostname string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.gen++
+	if e, ok := c.hosts[hostname]; ok {
+		c.lru.Remove(e)
+		delete(c.hosts, hostname)
+	}
+}
+
+// invalidateAll drops every result.
+func (c *latestCache) invalidateAll() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.gen++
+	c.lru.Init()
+	clear(c.hosts)
+}
@@ -0,0 +1,206 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"modernc.org/sqlite"
+)
+
+// backuper is implemented by the modernc SQLite driver connection.
+type backuper interface {
+	NewBackup(dstURI string) (*sqlite.Backup, error)
+}
+
+// Backup writes a consistent copy of the database to path with SQLite's
+// online backup API, so that it can run while the collector is serving.
+// The copy is written next to path and renamed into place once complete.
+func (s *Store) Backup(ctx context.Context, path string) error {
+	tmp := path + ".tmp"
+	os.Remove(tmp)
+
+	conn, err := s.read.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("backup database: %w", err)
+	}
+	defer conn.Close()
+
+	err = conn.Raw(func(dc any) error {
+		b, ok := dc.(backuper)
+		if !ok {
+			return errors.New("driver does not support online backup")
+		}
+		bk, err := b.NewBackup(tmp)
+		if err != nil {
+			return err
+		}
+		// Copy every page in one step, so the copy is a single snapshot
+		// even if the collector writes meanwhile.
+		if _, err := bk.Step(-1); err != nil {
+			bk.Finish()
+			return err
+		}
+		dst, err := bk.Commit()
+		if err != nil {
+			return err
+		}
+		defer dst.Close()
+		// The copy inherits WAL mode from the source; switch it back to a
+		// rollback journal so that it is one self-contained file.
+		execer, ok := dst.(driver.ExecerContext)
+		if !ok {
+			return errors.New("driver does not support exec")
+		}
+		_, err = execer.ExecContext(ctx, `PRAGMA journal_mode=DELETE`, nil)
+		return err
+	})
+	if err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("backup database: %w", err)
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("backup database: %w", err)
+	}
+	return nil
+}
+
+// VerifyResult is the outcome of Verify.
+type VerifyResult struct {
+	// Problems lists the integrity errors found; it is empty for a sound
+	// database.
+	Problems    []string
+	Inventories int
+	Alerts      int
+}
+
+// Verify checks the integrity of the database file at path, such as a
+// backup, without modifying it, and counts its records.
+func Verify(ctx context.Context, path string) (*VerifyResult, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("open database: %w", err)
+	}
+	db, err := sql.Open("sqlite", "file:"+path+"?mode=ro")
+	if err != nil {
+		return nil, fmt.Errorf("open database: %w", err)
+	}
+	defer db.Close()
+
+	rows, err := db.QueryContext(ctx, `PRAGMA integrity_check`)
+	if err != nil {
+		return nil, fmt.Errorf("check integrity: %w", err)
+	}
+	defer rows.Close()
+
+	var res VerifyResult
+	for rows.Next() {
+		var msg string
+		if err := rows.Scan(&msg); err != nil {
+			return nil, fmt.Errorf("check integrity: %w", err)
+		}
+		if msg != "ok" {
+			res.Problems = append(res.Problems, msg)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("check integrity: %w", err)
+	}
+
+	if err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM inventories`).Scan(&res.Inventories); err != nil {
+		return nil, fmt.Errorf("count inventories: %w", err)
+	}
+	if err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM alerts`).Scan(&res.Alerts); err != nil {
+		return nil, fmt.Errorf("count alerts: %w", err)
+	}
+	return &res, nil
+}
+
+// Compact rebuilds the database file with VACUUM and truncates the
+// write-ahead log, returning the on-disk size of both before and after.
+// VACUUM also switches databases created without incremental auto-vacuum
+// over to it (see Open).
+func (s *Store) Compact(ctx context.Context) (before, after int64, err error) {
+	var path string
+	if err := s.db.QueryRowContext(ctx, `SELECT file FROM pragma_database_list WHERE name = 'main'`).Scan(&path); err != nil {
+		return 0, 0, fmt.Errorf("locate database file: %w", err)
+	}
+
+	before = diskSize(path)
+	if _, err := s.db.ExecContext(ctx, `VACUUM`); err != nil {
+		return 0, 0, fmt.Errorf("vacuum database: %w", err)
+	}
+	if _, err := s.db.ExecContext(ctx, `PRAGMA wal_checkpoint(TRUNCATE)`); err != nil {
+		return 0, 0, fmt.Errorf("checkpoint database: %w", err)
+	}
+	return before, diskSize(path), nil
+}
+
+// incrementalVacuum returns the free pages of the database to the file
+// system. It does nothing unless the database uses incremental auto-vacuum.
+func (s *Store) incrementalVacuum(ctx context.Context) error {
+	// The pragma frees a page per step; read it to the end.
+	rows, err := s.db.QueryContext(ctx, `PRAGMA incremental_vacuum`)
+	if err != nil {
+		return fmt.Errorf("reclaim free pages: %w", err)
+	}
+	for rows.Next() {
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return fmt.Errorf("reclaim free pages: %w", err)
+	}
+	return rows.Close()
+}
+
+// diskSize returns the combined size of the database file at path and its
+// write-ahead log.
+func diskSize(path string) int64 {
+	var n int64
+	for _, p := range []string{path, path + "-wal"} {
+		if fi, err := os.Stat(p); err == nil {
+			n += fi.Size()
+		}
+	}
+	return n
+}
+
+// CheckAccess checks, without creating or modifying anything, that the
+// collector can open the database at path: an existing file must be a
+// readable and writable SQLite database, and its directory must be writable
+// for the journal and write-ahead log.
+func CheckAccess(ctx context.Context, path string) error {
+	dir := filepath.Dir(path)
+	probe, err := os.CreateTemp(dir, ".inventory-check-*")
+	if err != nil {
+		return fmt.Errorf("database directory %s is not writable: %w", dir, err)
+	}
+	probe.Close()
+	os.Remove(probe.Name())
+
+	if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	f, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return fmt.Errorf("open database: %w", err)
+	}
+	f.Close()
+
+	db, err := sql.Open("sqlite", "file:"+path+"?mode=ro")
+	if err != nil {
+		return fmt.Errorf("open database: %w", err)
+	}
+	defer db.Close()
+	var n int
+	if err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM sqlite_master`).Scan(&n); err != nil {
+		return fmt.Errorf("read database: %w", err)
+	}
+	return nil
+}
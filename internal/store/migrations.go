@@ -1,6 +1,28 @@
 package store
 
-const createTableSQL = `
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// migration is a versioned schema change. Versions are consecutive from 1;
+// down is nil for migrations that cannot be reverted.
+type migration struct {
+	version int
+	name    string
+	up      func(ctx context.Context, tx *sql.Tx) error
+	down    func(ctx context.Context, tx *sql.Tx) error
+}
+
+// migrations lists every schema change in order. Append new ones; never
+// edit or reorder applied ones.
+var migrations = []migration{
+	{
+		version: 1,
+		name:    "create inventories and alerts",
+		up: execSQL(`
 CREATE TABLE IF NOT EXISTS inventories (
     id              INTEGER PRIMARY KEY AUTOINCREMENT,
     hostname        TEXT NOT NULL,
@@ -16,4 +38,452 @@ CREATE INDEX IF NOT EXISTS idx_inventories_hostname ON inventories(hostname);
 CREATE INDEX IF NOT EXISTS idx_inventories_system_uuid ON inventories(system_uuid);
 CREATE INDEX IF NOT EXISTS idx_inventories_collected_at ON inventories(collected_at);
 CREATE INDEX IF NOT EXISTS idx_inventories_username ON inventories(username);
-`
+CREATE INDEX IF NOT EXISTS idx_inventories_system_serial ON inventories(system_serial);
+
+CREATE TABLE IF NOT EXISTS alerts (
+    id              INTEGER PRIMARY KEY AUTOINCREMENT,
+    hostname        TEXT NOT NULL,
+    inventory_id    INTEGER NOT NULL,
+    rule            TEXT NOT NULL,
+    severity        TEXT NOT NULL,
+    message         TEXT NOT NULL,
+    created_at      TEXT NOT NULL,
+    acknowledged    INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_alerts_hostname ON alerts(hostname);
+CREATE INDEX IF NOT EXISTS idx_alerts_created_at ON alerts(created_at);
+`),
+	},
+	{
+		version: 2,
+		name:    "add site to inventories and alerts",
+		up: func(ctx context.Context, tx *sql.Tx) error {
+			for _, table := range []string{"inventories", "alerts"} {
+				if err := addColumn(ctx, tx, table, "site", "TEXT NOT NULL DEFAULT ''"); err != nil {
+					return err
+				}
+			}
+			return execSQL(`
+CREATE INDEX IF NOT EXISTS idx_inventories_site_hostname ON inventories(site, hostname);
+CREATE INDEX IF NOT EXISTS idx_alerts_site ON alerts(site);
+`)(ctx, tx)
+		},
+		down: execSQL(`
+DROP INDEX IF EXISTS idx_inventories_site_hostname;
+DROP INDEX IF EXISTS idx_alerts_site;
+ALTER TABLE inventories DROP COLUMN site;
+ALTER TABLE alerts DROP COLUMN site;
+`),
+	},
+	{
+		version: 3,
+		name:    "create api_tokens",
+		up: execSQL(`
+CREATE TABLE IF NOT EXISTS api_tokens (
+    id              INTEGER PRIMARY KEY AUTOINCREMENT,
+    name            TEXT NOT NULL,
+    role            TEXT NOT NULL,
+    sites           TEXT NOT NULL DEFAULT '[]',
+    token_hash      TEXT NOT NULL UNIQUE,
+    created_at      TEXT NOT NULL,
+    expires_at      TEXT NOT NULL DEFAULT '',
+    revoked_at      TEXT NOT NULL DEFAULT ''
+);
+`),
+		down: execSQL(`DROP TABLE IF EXISTS api_tokens;`),
+	},
+	{
+		version: 4,
+		name:    "create outbox",
+		up: execSQL(`
+CREATE TABLE IF NOT EXISTS outbox (
+    id              INTEGER PRIMARY KEY AUTOINCREMENT,
+    event_key       TEXT NOT NULL,
+    payload         BLOB NOT NULL,
+    created_at      TEXT NOT NULL
+);
+`),
+		down: execSQL(`DROP TABLE IF EXISTS outbox;`),
+	},
+	{
+		version: 5,
+		name:    "add sink to outbox",
+		up: func(ctx context.Context, tx *sql.Tx) error {
+			if err := addColumn(ctx, tx, "outbox", "sink", "TEXT NOT NULL DEFAULT 'kafka'"); err != nil {
+				return err
+			}
+			return execSQL(`CREATE INDEX IF NOT EXISTS idx_outbox_sink ON outbox(sink, id);`)(ctx, tx)
+		},
+		down: execSQL(`
+DROP INDEX IF EXISTS idx_outbox_sink;
+ALTER TABLE outbox DROP COLUMN sink;
+`),
+	},
+	{
+		version: 6,
+		name:    "add event_type to outbox",
+		up: func(ctx context.Context, tx *sql.Tx) error {
+			return addColumn(ctx, tx, "outbox", "event_type", "TEXT NOT NULL DEFAULT 'inventory.submitted'")
+		},
+		down: execSQL(`ALTER TABLE outbox DROP COLUMN event_type;`),
+	},
+	{
+		version: 7,
+		name:    "create webhook_deliveries",
+		up: execSQL(`
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id              INTEGER PRIMARY KEY AUTOINCREMENT,
+    url             TEXT NOT NULL,
+    payload         BLOB NOT NULL,
+    status          TEXT NOT NULL,
+    attempts        INTEGER NOT NULL DEFAULT 0,
+    status_code     INTEGER NOT NULL DEFAULT 0,
+    last_error      TEXT NOT NULL DEFAULT '',
+    created_at      TEXT NOT NULL,
+    last_attempt_at TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_status ON webhook_deliveries(status);
+`),
+		down: execSQL(`DROP TABLE IF EXISTS webhook_deliveries;`),
+	},
+	{
+		version: 8,
+		name:    "create host_directory",
+		up: execSQL(`
+CREATE TABLE IF NOT EXISTS host_directory (
+    site              TEXT NOT NULL DEFAULT '',
+    hostname          TEXT NOT NULL,
+    ou                TEXT NOT NULL DEFAULT '',
+    description       TEXT NOT NULL DEFAULT '',
+    username          TEXT NOT NULL DEFAULT '',
+    user_display_name TEXT NOT NULL DEFAULT '',
+    department        TEXT NOT NULL DEFAULT '',
+    updated_at        TEXT NOT NULL,
+    PRIMARY KEY (site, hostname)
+);
+`),
+		down: execSQL(`DROP TABLE IF EXISTS host_directory;`),
+	},
+	{
+		version: 9,
+		name:    "add device_class to inventories",
+		up: func(ctx context.Context, tx *sql.Tx) error {
+			if err := addColumn(ctx, tx, "inventories", "device_class", "TEXT NOT NULL DEFAULT 'computer'"); err != nil {
+				return err
+			}
+			_, err := tx.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS idx_inventories_device_class ON inventories(device_class)`)
+			return err
+		},
+		down: execSQL(`
+DROP INDEX IF EXISTS idx_inventories_device_class;
+ALTER TABLE inventories DROP COLUMN device_class;
+`),
+	},
+	{
+		version: 10,
+		name:    "add source to inventories",
+		up: func(ctx context.Context, tx *sql.Tx) error {
+			if err := addColumn(ctx, tx, "inventories", "source", "TEXT NOT NULL DEFAULT 'agent'"); err != nil {
+				return err
+			}
+			_, err := tx.ExecContext(ctx, `UPDATE inventories SET source = 'snmp' WHERE device_class != 'computer'`)
+			return err
+		},
+		down: execSQL(`ALTER TABLE inventories DROP COLUMN source;`),
+	},
+	{
+		version: 11,
+		name:    "create host_fields",
+		up: execSQL(`
+CREATE TABLE IF NOT EXISTS host_fields (
+    site       TEXT NOT NULL DEFAULT '',
+    hostname   TEXT NOT NULL,
+    name       TEXT NOT NULL,
+    value      TEXT NOT NULL,
+    updated_at TEXT NOT NULL,
+    PRIMARY KEY (site, hostname, name)
+);
+`),
+		down: execSQL(`DROP TABLE IF EXISTS host_fields;`),
+	},
+	{
+		version: 12,
+		name:    "add inventory_pb to inventories",
+		up: func(ctx context.Context, tx *sql.Tx) error {
+			return addColumn(ctx, tx, "inventories", "inventory_pb", "BLOB")
+		},
+		down: execSQL(`ALTER TABLE inventories DROP COLUMN inventory_pb;`),
+	},
+	{
+		version: 13,
+		name:    "add summary index to inventories",
+		up: execSQL(`
+CREATE INDEX IF NOT EXISTS idx_inventories_summary ON inventories(
+    collected_at, id, site, hostname, username, system_uuid, system_serial, stored_at, device_class, source
+);
+DROP INDEX IF EXISTS idx_inventories_collected_at;
+`),
+		down: execSQL(`
+CREATE INDEX IF NOT EXISTS idx_inventories_collected_at ON inventories(collected_at);
+DROP INDEX IF EXISTS idx_inventories_summary;
+`),
+	},
+	{
+		version: 14,
+		name:    "add submission_uuid to inventories",
+		up: func(ctx context.Context, tx *sql.Tx) error {
+			if err := addColumn(ctx, tx, "inventories", "submission_uuid", "TEXT"); err != nil {
+				return err
+			}
+			_, err := tx.ExecContext(ctx, `CREATE UNIQUE INDEX IF NOT EXISTS idx_inventories_submission
+    ON inventories(site, submission_uuid) WHERE submission_uuid IS NOT NULL`)
+			return err
+		},
+		down: execSQL(`
+DROP INDEX IF EXISTS idx_inventories_submission;
+ALTER TABLE inventories DROP COLUMN submission_uuid;
+`),
+	},
+}
+
+// LatestVersion is the schema version this build migrates databases to.
+func LatestVersion() int {
+	return migrations[len(migrations)-1].version
+}
+
+// Migration describes a schema migration and whether it is applied.
+type Migration struct {
+	Version int
+	Name    string
+	// AppliedAt is zero for pending migrations.
+	AppliedAt time.Time
+}
+
+// migrate brings the database up to the latest schema version.
+func migrate(db *sql.DB) error {
+	_, err := migrateTo(context.Background(), db, LatestVersion())
+	return err
+}
+
+// Migrations returns every known migration in order, with the time each
+// applied one was applied.
+func (s *Store) Migrations(ctx context.Context) ([]Migration, error) {
+	applied, err := appliedMigrations(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]Migration, len(migrations))
+	for i, m := range migrations {
+		list[i] = Migration{Version: m.version, Name: m.name, AppliedAt: applied[m.version]}
+	}
+	return list, nil
+}
+
+// SchemaVersion returns the version of the newest applied migration, 0 for
+// an empty database.
+func (s *Store) SchemaVersion(ctx context.Context) (int, error) {
+	applied, err := appliedMigrations(ctx, s.db)
+	if err != nil {
+		return 0, err
+	}
+	return schemaVersion(applied), nil
+}
+
+// MigrateTo applies or reverts migrations until the schema is at version,
+// each in its own transaction, and returns them in the order they ran.
+func (s *Store) MigrateTo(ctx context.Context, version int) ([]Migration, error) {
+	return migrateTo(ctx, s.db, version)
+}
+
+func migrateTo(ctx context.Context, db *sql.DB, version int) ([]Migration, error) {
+	if version < 0 || version > LatestVersion() {
+		return nil, fmt.Errorf("unknown schema version %d (latest is %d)", version, LatestVersion())
+	}
+	applied, err := appliedMigrations(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	current := schemaVersion(applied)
+
+	var ran []Migration
+	for _, m := range migrations {
+		if m.version > current && m.version <= version {
+			if err := runMigration(ctx, db, m, true); err != nil {
+				return ran, err
+			}
+			ran = append(ran, Migration{Version: m.version, Name: m.name, AppliedAt: time.Now().UTC()})
+		}
+	}
+	for i := len(migrations) - 1; i >= 0; i-- {
+		m := migrations[i]
+		if m.version <= current && m.version > version {
+			if m.down == nil {
+				return ran, fmt.Errorf("migration %d (%s) cannot be reverted", m.version, m.name)
+			}
+			if err := runMigration(ctx, db, m, false); err != nil {
+				return ran, err
+			}
+			ran = append(ran, Migration{Version: m.version, Name: m.name})
+		}
+	}
+	return ran, nil
+}
+
+func runMigration(ctx context.Context, db *sql.DB, m migration, up bool) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if up {
+		if err := m.up(ctx, tx); err != nil {
+			return fmt.Errorf("apply migration %d (%s): %w", m.version, m.name, err)
+		}
+		_, err = tx.ExecContext(ctx, `INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)`,
+			m.version, m.name, time.Now().UTC().Format(time.RFC3339))
+	} else {
+		if err := m.down(ctx, tx); err != nil {
+			return fmt.Errorf("revert migration %d (%s): %w", m.version, m.name, err)
+		}
+		_, err = tx.ExecContext(ctx, `DELETE FROM schema_migrations WHERE version = ?`, m.version)
+	}
+	if err != nil {
+		return fmt.Errorf("record migration %d: %w", m.version, err)
+	}
+	return tx.Commit()
+}
+
+// appliedMigrations returns the applied migration versions with the time
+// they were applied, creating the schema_migrations table if needed.
+func appliedMigrations(ctx context.Context, db *sql.DB) (map[int]time.Time, error) {
+	if err := baseline(ctx, db); err != nil {
+		return nil, err
+	}
+
+	rows, err := db.QueryContext(ctx, `SELECT version, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, fmt.Errorf("read schema migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int]time.Time)
+	for rows.Next() {
+		var version int
+		var appliedAt string
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+		applied[version], _ = time.Parse(time.RFC3339, appliedAt)
+	}
+	return applied, rows.Err()
+}
+
+func schemaVersion(applied map[int]time.Time) int {
+	var version int
+	for v := range applied {
+		version = max(version, v)
+	}
+	return version
+}
+
+// baseline creates the schema_migrations table. In databases created before
+// migrations were versioned it records the migrations whose changes are
+// already present.
+func baseline(ctx context.Context, db *sql.DB) error {
+	exists, err := hasTable(ctx, db, "schema_migrations")
+	if err != nil || exists {
+		return err
+	}
+	if _, err := db.ExecContext(ctx, `
+CREATE TABLE schema_migrations (
+    version         INTEGER PRIMARY KEY,
+    name            TEXT NOT NULL,
+    applied_at      TEXT NOT NULL
+)`); err != nil {
+		return fmt.Errorf("create schema_migrations: %w", err)
+	}
+
+	present := map[int]func() (bool, error){
+		1: func() (bool, error) { return hasTable(ctx, db, "inventories") },
+		2: func() (bool, error) { return hasColumn(ctx, db, "inventories", "site") },
+		3: func() (bool, error) { return hasTable(ctx, db, "api_tokens") },
+		4: func() (bool, error) { return hasTable(ctx, db, "outbox") },
+		5: func() (bool, error) { return hasColumn(ctx, db, "outbox", "sink") },
+		6: func() (bool, error) { return hasColumn(ctx, db, "outbox", "event_type") },
+	}
+	for _, m := range migrations {
+		check, ok := present[m.version]
+		if !ok {
+			break
+		}
+		found, err := check()
+		if err != nil {
+			return err
+		}
+		if !found {
+			break
+		}
+		if _, err := db.ExecContext(ctx, `INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)`,
+			m.version, m.name, time.Now().UTC().Format(time.RFC3339)); err != nil {
+			return fmt.Errorf("record migration %d: %w", m.version, err)
+		}
+	}
+	return nil
+}
+
+// execSQL returns a migration step that executes query.
+func execSQL(query string) func(ctx context.Context, tx *sql.Tx) error {
+	return func(ctx context.Context, tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, query)
+		return err
+	}
+}
+
+// querier is implemented by *sql.DB and *sql.Tx.
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// addColumn adds a column unless it exists. SQLite has no ADD COLUMN IF NOT
+// EXISTS.
+func addColumn(ctx context.Context, tx *sql.Tx, table, column, definition string) error {
+	exists, err := hasColumn(ctx, tx, table, column)
+	if err != nil || exists {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition)); err != nil {
+		return fmt.Errorf("add column %s.%s: %w", table, column, err)
+	}
+	return nil
+}
+
+func hasTable(ctx context.Context, q querier, table string) (bool, error) {
+	var n int
+	if err := q.QueryRowContext(ctx, `SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?`, table).Scan(&n); err != nil {
+		return false, fmt.Errorf("inspect schema: %w", err)
+	}
+	return n > 0, nil
+}
+
+func hasColumn(ctx context.Context, q querier, table, column string) (bool, error) {
+	rows, err := q.QueryContext(ctx, fmt.Sprintf("SELECT name FROM pragma_table_info('%s')", table))
+	if err != nil {
+		return false, fmt.Errorf("inspect table %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return false, err
+		}
+		if name == column {
+			return true, nil
+		}
+	}
+	return false, rows.Err()
+}
@@ -0,0 +1,93 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// OutboxEvent is an event waiting to be published to an external sink.
+type OutboxEvent struct {
+	ID int64
+	// Type is the kind of event, e.g. inventory.submitted.
+	Type string
+	// Key partitions the events, e.g. by system UUID.
+	Key       string
+	Payload   []byte
+	CreatedAt time.Time
+}
+
+// OutboxMessage is an event to queue for publishing to Sink.
+type OutboxMessage struct {
+	Sink    string
+	Type    string
+	Key     string
+	Payload []byte
+}
+
+// InsertOutbox queues an event of eventType with key and payload for
+// publishing to sink.
+func (s *Store) InsertOutbox(ctx context.Context, sink, eventType, key string, payload []byte) error {
+	m := OutboxMessage{Sink: sink, Type: eventType, Key: key, Payload: payload}
+	return insertOutbox(ctx, s.db, m, time.Now().UTC())
+}
+
+func insertOutbox(ctx context.Context, db execer, m OutboxMessage, createdAt time.Time) error {
+	_, err := db.ExecContext(ctx,
+		`INSERT INTO outbox (sink, event_type, event_key, payload, created_at) VALUES (?, ?, ?, ?, ?)`,
+		m.Sink, m.Type, m.Key, m.Payload, createdAt.Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("insert outbox event: %w", err)
+	}
+	return nil
+}
+
+// PendingOutbox returns up to limit events queued for sink, oldest first.
+func (s *Store) PendingOutbox(ctx context.Context, sink string, limit int) ([]OutboxEvent, error) {
+	rows, err := s.read.QueryContext(ctx,
+		`SELECT id, event_type, event_key, payload, created_at FROM outbox WHERE sink = ? ORDER BY id LIMIT ?`, sink, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list outbox events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []OutboxEvent
+	for rows.Next() {
+		var e OutboxEvent
+		var createdAt string
+		if err := rows.Scan(&e.ID, &e.Type, &e.Key, &e.Payload, &createdAt); err != nil {
+			return nil, err
+		}
+		e.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
+
+// DeleteOutbox removes the events queued for sink up to and including ID
+// upTo, once they are published.
+func (s *Store) DeleteOutbox(ctx context.Context, sink string, upTo int64) error {
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM outbox WHERE sink = ? AND id <= ?`, sink, upTo); err != nil {
+		return fmt.Errorf("delete outbox events: %w", err)
+	}
+	return nil
+}
+
+// PruneOutbox removes the events queued for sinks other than the given
+// ones, e.g. after a sink was disabled, and returns their number.
+func (s *Store) PruneOutbox(ctx context.Context, sinks []string) (int64, error) {
+	query := `DELETE FROM outbox`
+	args := make([]any, len(sinks))
+	if len(sinks) > 0 {
+		query += ` WHERE sink NOT IN (?` + strings.Repeat(`, ?`, len(sinks)-1) + `)`
+		for i, sink := range sinks {
+			args[i] = sink
+		}
+	}
+	result, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("prune outbox events: %w", err)
+	}
+	return result.RowsAffected()
+}
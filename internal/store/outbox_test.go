@@ -0,0 +1,52 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestInsertEvents checks that the events of a record are queued in the
+// outbox if and only if the record is stored.
+func TestInsertEvents(t *testing.T) {
+	s, err := New(filepath.Join(t.TempDir(), "inventory.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	ctx := context.Background()
+
+	record := func(i int) *InventoryRecord {
+		rec := benchRecord(i, 10)
+		rec.SubmissionUUID = "0f8d3b1e-6a4c-4f7e-9a51-2c8e7d6b5a40"
+		rec.Events = func(id int64, storedAt time.Time) []OutboxMessage {
+			payload := fmt.Sprintf("%d %s", id, storedAt.Format(time.RFC3339))
+			return []OutboxMessage{{Sink: "kafka", Type: "inventory.submitted", Key: rec.SystemUUID, Payload: []byte(payload)}}
+		}
+		return rec
+	}
+
+	id, storedAt, err := s.Insert(ctx, record(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A retried submission is refused, and so is its event.
+	if _, _, err := s.Insert(ctx, record(1)); !errors.Is(err, ErrDuplicateSubmission) {
+		t.Fatalf("retried submission: %v, want ErrDuplicateSubmission", err)
+	}
+	if _, _, err := s.InsertBatch(ctx, []*InventoryRecord{benchRecord(2, 10), record(3)}); !errors.Is(err, ErrDuplicateSubmission) {
+		t.Fatalf("batch with a retried submission: %v, want ErrDuplicateSubmission", err)
+	}
+
+	events, err := s.PendingOutbox(ctx, "kafka", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := fmt.Sprintf("%d %s", id, storedAt.Format(time.RFC3339))
+	if len(events) != 1 || string(events[0].Payload) != want {
+		t.Fatalf("queued events %+v, want one with payload %q", events, want)
+	}
+}
@@ -0,0 +1,271 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Identity columns checked by FindDuplicates.
+const (
+	DuplicateSystemSerial = "system_serial"
+	DuplicateSystemUUID   = "system_uuid"
+)
+
+// DuplicateGroup is a set of hosts that report the same identity value.
+type DuplicateGroup struct {
+	Field     string
+	Value     string
+	Hostnames []string
+}
+
+// hostnameSep separates hostnames inside GROUP_CONCAT output; the unit
+// separator cannot appear in a valid hostname.
+const hostnameSep = "\x1f"
+
+// FindDuplicates returns every non-empty system serial and system UUID that
+// is shared by more than one host. Only each host's most recent inventory is
+// considered so that hardware which moved between hosts is not reported.
+// A non-nil sites restricts the report to hosts of those sites.
+func (s *Store) FindDuplicates(ctx context.Context, sites []string) ([]DuplicateGroup, error) {
+	var groups []DuplicateGroup
+	for _, column := range []string{DuplicateSystemSerial, DuplicateSystemUUID} {
+		g, err := s.findDuplicates(ctx, column, sites)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, g...)
+	}
+	return groups, nil
+}
+
+// findDuplicates runs the duplicate query for one identity column. column
+// must be one of the Duplicate* constants; it is interpolated into the SQL.
+func (s *Store) findDuplicates(ctx context.Context, column string, sites []string) ([]DuplicateGroup, error) {
+	where, args := buildWhere(ListFilter{Sites: sites})
+	query := fmt.Sprintf(`WITH latest AS (
+			SELECT hostname, system_uuid, system_serial, MAX(collected_at)
+			FROM inventories%[2]s GROUP BY site, hostname
+		)
+		SELECT %[1]s, GROUP_CONCAT(hostname, char(31))
+		FROM latest WHERE %[1]s != ''
+		GROUP BY %[1]s HAVING COUNT(*) > 1
+		ORDER BY COUNT(*) DESC, %[1]s`, column, where)
+
+	rows, err := s.read.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("find duplicate %s: %w", column, err)
+	}
+	defer rows.Close()
+
+	var groups []DuplicateGroup
+	for rows.Next() {
+		var value, hostnames string
+		if err := rows.Scan(&value, &hostnames); err != nil {
+			return nil, err
+		}
+		groups = append(groups, DuplicateGroup{
+			Field:     column,
+			Value:     value,
+			Hostnames: strings.Split(hostnames, hostnameSep),
+		})
+	}
+	return groups, rows.Err()
+}
+
+// ModuleStats summarizes one collection module across hosts.
+type ModuleStats struct {
+	Module        string
+	Hosts         int
+	Failures      int
+	AvgDurationMs int64
+	MaxDurationMs int64
+}
+
+// CollectionStats aggregates the collection telemetry of each host's most
+// recent inventory by module, slowest modules first. Inventories submitted
+// by agents without telemetry are skipped. A non-nil sites restricts the
+// report to hosts of those sites.
+func (s *Store) CollectionStats(ctx context.Context, sites []string) ([]ModuleStats, error) {
+	where, args := buildWhere(ListFilter{Sites: sites})
+	// Stored inventories are protojson, so fields are camelCase and int64
+	// durations are strings.
+	query := fmt.Sprintf(`WITH latest AS (
+			SELECT inventory_json, MAX(collected_at)
+			FROM inventories%s GROUP BY site, hostname
+		)
+		SELECT json_extract(m.value, '$.name') AS module,
+			COUNT(*),
+			SUM(COALESCE(json_extract(m.value, '$.error'), '') != ''),
+			CAST(AVG(CAST(COALESCE(json_extract(m.value, '$.durationMs'), 0) AS INTEGER)) AS INTEGER),
+			MAX(CAST(COALESCE(json_extract(m.value, '$.durationMs'), 0) AS INTEGER))
+		FROM latest, json_each(latest.inventory_json, '$.telemetry.modules') AS m
+		GROUP BY module
+		ORDER BY 4 DESC, module`, where)
+
+	rows, err := s.read.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("collection stats: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []ModuleStats
+	for rows.Next() {
+		var m ModuleStats
+		if err := rows.Scan(&m.Module, &m.Hosts, &m.Failures, &m.AvgDurationMs, &m.MaxDurationMs); err != nil {
+			return nil, err
+		}
+		stats = append(stats, m)
+	}
+	return stats, rows.Err()
+}
+
+// Count is the number of hosts sharing one value.
+type Count struct {
+	Value string
+	Hosts int
+}
+
+// FleetStats breaks down the latest inventory of each host.
+type FleetStats struct {
+	Hosts  int
+	Models []Count
+	// Memory buckets installed memory, rounded up to a power of two GiB.
+	Memory []Count
+	// HostKeys lists the hosts as site and hostname pairs.
+	HostKeys [][2]string
+}
+
+// FleetStats aggregates the model and memory size of each host's most
+// recent inventory. A non-nil sites restricts the report to hosts of those
+// sites.
+func (s *Store) FleetStats(ctx context.Context, sites []string) (*FleetStats, error) {
+	where, args := buildWhere(ListFilter{Sites: sites})
+	query := fmt.Sprintf(`WITH latest AS (
+			SELECT site, hostname, inventory_json, MAX(collected_at)
+			FROM inventories%s GROUP BY site, hostname
+		)
+		SELECT site, hostname,
+			TRIM(COALESCE(json_extract(inventory_json, '$.system.manufacturer'), '') || ' ' ||
+				COALESCE(json_extract(inventory_json, '$.system.productName'), '')),
+			CAST(COALESCE(json_extract(inventory_json, '$.memory.totalPhysicalBytes'), 0) AS INTEGER)
+		FROM latest`, where)
+
+	rows, err := s.read.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("fleet stats: %w", err)
+	}
+	defer rows.Close()
+
+	var stats FleetStats
+	models, memory := map[string]int{}, map[string]int{}
+	for rows.Next() {
+		var site, hostname, model string
+		var memBytes int64
+		if err := rows.Scan(&site, &hostname, &model, &memBytes); err != nil {
+			return nil, err
+		}
+		stats.Hosts++
+		stats.HostKeys = append(stats.HostKeys, [2]string{site, hostname})
+		if model == "" {
+			model = "unknown"
+		}
+		models[model]++
+		memory[memoryBucket(memBytes)]++
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	stats.Models = SortCounts(models)
+	stats.Memory = SortCounts(memory)
+	return &stats, nil
+}
+
+// memoryBucket rounds n bytes up to a power of two GiB, e.g. "16 GiB".
+func memoryBucket(n int64) string {
+	if n <= 0 {
+		return "unknown"
+	}
+	gib := int64(1)
+	for gib<<30 < n {
+		gib <<= 1
+	}
+	return fmt.Sprintf("%d GiB", gib)
+}
+
+// SortCounts turns a value to count map into a list, most common first.
+func SortCounts(m map[string]int) []Count {
+	counts := make([]Count, 0, len(m))
+	for v, n := range m {
+		counts = append(counts, Count{Value: v, Hosts: n})
+	}
+	slices.SortFunc(counts, func(a, b Count) int {
+		if a.Hosts != b.Hosts {
+			return b.Hosts - a.Hosts
+		}
+		return strings.Compare(a.Value, b.Value)
+	})
+	return counts
+}
+
+// HostFacts are the facts of a host's most recent inventory, and its
+// directory container and department when enriched.
+type HostFacts struct {
+	Site         string
+	Hostname     string
+	LatestID     int64
+	Username     string
+	SystemUUID   string
+	DeviceClass  string
+	Manufacturer string
+	Model        string
+	SerialNumber string
+	Processor    string
+	MemoryBytes  int64
+	LastSeen     time.Time
+	OU           string
+	Department   string
+}
+
+// ListHostFacts returns the facts of each host's most recent inventory. A
+// non-nil sites restricts the list to hosts of those sites.
+func (s *Store) ListHostFacts(ctx context.Context, sites []string) ([]HostFacts, error) {
+	where, args := buildWhere(ListFilter{Sites: sites})
+	query := fmt.Sprintf(`WITH latest AS (
+			SELECT id, site, hostname, username, system_uuid, device_class, system_serial, inventory_json,
+				MAX(collected_at) AS last_seen
+			FROM inventories%s GROUP BY site, hostname
+		)
+		SELECT l.id, l.site, l.hostname, l.username, l.system_uuid, l.device_class, l.system_serial, l.last_seen,
+			COALESCE(json_extract(l.inventory_json, '$.system.manufacturer'), ''),
+			COALESCE(json_extract(l.inventory_json, '$.system.productName'), ''),
+			COALESCE(json_extract(l.inventory_json, '$.processors[0].version'), ''),
+			CAST(COALESCE(json_extract(l.inventory_json, '$.memory.totalPhysicalBytes'), 0) AS INTEGER),
+			COALESCE(d.ou, ''), COALESCE(d.department, '')
+		FROM latest l
+		LEFT JOIN host_directory d ON d.site = l.site AND d.hostname = l.hostname
+		ORDER BY l.site, l.hostname`, where)
+
+	rows, err := s.read.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("host facts: %w", err)
+	}
+	defer rows.Close()
+
+	var facts []HostFacts
+	for rows.Next() {
+		var f HostFacts
+		var lastSeen string
+		if err := rows.Scan(&f.LatestID, &f.Site, &f.Hostname, &f.Username, &f.SystemUUID, &f.DeviceClass,
+			&f.SerialNumber, &lastSeen, &f.Manufacturer, &f.Model, &f.Processor, &f.MemoryBytes,
+			&f.OU, &f.Department); err != nil {
+			return nil, err
+		}
+		f.LastSeen, _ = time.Parse(time.RFC3339, lastSeen)
+		facts = append(facts, f)
+	}
+	return facts, rows.Err()
+}
@@ -0,0 +1,86 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// SubmissionBucket counts the submissions of one interval of a
+// SubmissionSeries.
+type SubmissionBucket struct {
+	Start time.Time
+	// Inventories is the number of inventories collected in the interval,
+	// and Hosts the number of distinct hosts they came from.
+	Inventories int
+	Hosts       int
+	// NewHosts is the number of hosts whose first inventory was collected
+	// in the interval.
+	NewHosts int
+}
+
+// SubmissionSeries counts the inventories collected from from to to in
+// intervals of step, aligned to multiples of step since the Unix epoch, and
+// returns every interval, including empty ones, oldest first. A non-nil
+// sites restricts the counts to hosts of those sites.
+func (s *Store) SubmissionSeries(ctx context.Context, sites []string, from, to time.Time, step time.Duration) ([]SubmissionBucket, error) {
+	secs := int64(step / time.Second)
+	if secs <= 0 {
+		return nil, fmt.Errorf("step %s is shorter than a second", step)
+	}
+	first := from.Unix() / secs * secs
+	last := to.Unix() / secs * secs
+	buckets := make([]SubmissionBucket, 0, (last-first)/secs+1)
+	index := make(map[int64]int)
+	for t := first; t <= last; t += secs {
+		index[t] = len(buckets)
+		buckets = append(buckets, SubmissionBucket{Start: time.Unix(t, 0).UTC()})
+	}
+
+	where, args := buildWhere(ListFilter{Sites: sites, CollectedAfter: &from, CollectedBefore: &to})
+	rows, err := s.read.QueryContext(ctx,
+		`SELECT CAST(strftime('%s', collected_at) AS INTEGER) / ? * ? AS bucket,
+		        COUNT(*), COUNT(DISTINCT site || '/' || hostname)
+		 FROM inventories`+where+` GROUP BY bucket`,
+		append([]any{secs, secs}, args...)...)
+	if err != nil {
+		return nil, fmt.Errorf("count submissions: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var start int64
+		var inventories, hosts int
+		if err := rows.Scan(&start, &inventories, &hosts); err != nil {
+			return nil, err
+		}
+		if i, ok := index[start]; ok {
+			buckets[i].Inventories = inventories
+			buckets[i].Hosts = hosts
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	where, args = buildWhere(ListFilter{Sites: sites})
+	rows, err = s.read.QueryContext(ctx,
+		`SELECT CAST(strftime('%s', first_seen) AS INTEGER) / ? * ? AS bucket, COUNT(*)
+		 FROM (SELECT MIN(collected_at) AS first_seen FROM inventories`+where+` GROUP BY site, hostname)
+		 WHERE first_seen >= ? AND first_seen <= ? GROUP BY bucket`,
+		append(append([]any{secs, secs}, args...), from.UTC().Format(time.RFC3339), to.UTC().Format(time.RFC3339))...)
+	if err != nil {
+		return nil, fmt.Errorf("count new hosts: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var start int64
+		var hosts int
+		if err := rows.Scan(&start, &hosts); err != nil {
+			return nil, err
+		}
+		if i, ok := index[start]; ok {
+			buckets[i].NewHosts = hosts
+		}
+	}
+	return buckets, rows.Err()
+}
@@ -3,112 +3,429 @@ package store
 import (
 	"context"
 	"database/sql"
+	"encoding/base64"
+	"errors"
 	"fmt"
+	"iter"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
 	"time"
 
-	_ "modernc.org/sqlite"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
 )
 
 // InventoryRecord represents a stored inventory row.
 type InventoryRecord struct {
-	ID            int64
-	Hostname      string
-	Username      string
-	SystemUUID    string
-	SystemSerial  string
-	CollectedAt   time.Time
-	StoredAt      time.Time
+	ID           int64
+	Site         string
+	Hostname     string
+	Username     string
+	SystemUUID   string
+	SystemSerial string
+	CollectedAt  time.Time
+	StoredAt     time.Time
+	// DeviceClass is DeviceComputer for agent inventories, or the class of
+	// a device polled over SNMP.
+	DeviceClass string
+	// Source is where the inventory came from, e.g. SourceAgent.
+	Source        string
 	InventoryJSON string
+	// InventoryProto is the binary protobuf encoding of the inventory, kept
+	// next to InventoryJSON so that reads skip parsing JSON. It is nil for
+	// inventories stored before it was kept, and loaded along with
+	// InventoryJSON.
+	InventoryProto []byte
+	// SubmissionUUID is the client's ID of the submission that stored the
+	// inventory, if it sent one. It is unique per site.
+	SubmissionUUID string
+	// Events, if set, returns the events to queue in the outbox for the
+	// record once it has an ID. They are inserted in the transaction that
+	// stores the record, so that both or neither are stored.
+	Events func(id int64, storedAt time.Time) []OutboxMessage
+}
+
+// ErrDuplicateSubmission is returned by Insert and InsertBatch for a record
+// whose SubmissionUUID the site already stored an inventory under.
+var ErrDuplicateSubmission = errors.New("duplicate submission")
+
+// DeviceComputer is the device class of inventories submitted by agents.
+const DeviceComputer = "computer"
+
+// deviceClass returns class, or DeviceComputer if it is empty.
+func deviceClass(class string) string {
+	if class == "" {
+		return DeviceComputer
+	}
+	return class
+}
+
+// Inventory sources.
+const (
+	SourceAgent = "agent"
+	SourceSNMP  = "snmp"
+	// SourceSCCM and SourceIntune mark inventories imported from
+	// Configuration Manager and Intune.
+	SourceSCCM   = "sccm"
+	SourceIntune = "intune"
+)
+
+// source returns src, or SourceAgent if it is empty.
+func source(src string) string {
+	if src == "" {
+		return SourceAgent
+	}
+	return src
 }
 
 // ListFilter holds optional query parameters for listing inventories.
+// Sites restricts results to the given sites; nil means every site.
 type ListFilter struct {
+	Sites           []string
 	Hostname        string
 	Username        string
 	SystemUUID      string
 	CollectedAfter  *time.Time
 	CollectedBefore *time.Time
+	DeviceClass     string
 	PageSize        int
 	Page            int
+	// WithJSON loads InventoryJSON for each record; List leaves it empty
+	// otherwise.
+	WithJSON bool
+	// After starts the page after the cursor instead of at Page.
+	After *ListCursor
+	// ExactCount counts the matching inventories for List even if their
+	// count is cached (see CacheCounts).
+	ExactCount bool
+}
+
+// ListCursor is the position of an inventory in List order: newest
+// collected first, then highest ID first.
+type ListCursor struct {
+	CollectedAt time.Time
+	ID          int64
+}
+
+// Next returns the cursor after records, a page listed with f, or nil if it
+// is the last page. A full page may be followed by an empty one.
+func (f ListFilter) Next(records []InventoryRecord) *ListCursor {
+	pageSize, _ := pageBounds(f.PageSize, f.Page)
+	if len(records) == 0 || len(records) < pageSize {
+		return nil
+	}
+	last := records[len(records)-1]
+	return &ListCursor{CollectedAt: last.CollectedAt, ID: last.ID}
+}
+
+// Token encodes c as an opaque page token.
+func (c ListCursor) Token() string {
+	return base64.RawURLEncoding.EncodeToString(
+		[]byte(c.CollectedAt.UTC().Format(time.RFC3339) + "," + strconv.FormatInt(c.ID, 10)))
+}
+
+// ParseListToken decodes a page token of ListCursor.Token.
+func ParseListToken(token string) (ListCursor, error) {
+	invalid := errors.New("invalid page token")
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return ListCursor{}, invalid
+	}
+	at, id, ok := strings.Cut(string(b), ",")
+	if !ok {
+		return ListCursor{}, invalid
+	}
+	var c ListCursor
+	if c.CollectedAt, err = time.Parse(time.RFC3339, at); err != nil {
+		return ListCursor{}, invalid
+	}
+	if c.ID, err = strconv.ParseInt(id, 10, 64); err != nil {
+		return ListCursor{}, invalid
+	}
+	return c, nil
+}
+
+// HostRecord is a per-host rollup pointing at the latest inventory.
+type HostRecord struct {
+	Site       string
+	Hostname   string
+	Username   string
+	SystemUUID string
+	LastSeen   time.Time
+	LatestID   int64
+	// Directory is nil unless the host was found in the directory.
+	Directory *HostDirectory
+	// Fields is the custom fields of the host (see SetHostFields).
+	Fields map[string]string
+}
+
+// HostFilter holds optional query parameters for listing hosts.
+type HostFilter struct {
+	Sites []string
+	// Hostname and DeviceClass restrict the hosts to those with
+	// inventories that match them, and SeenAfter to those with an inventory
+	// collected at or after it.
+	Hostname    string
+	DeviceClass string
+	SeenAfter   *time.Time
+	PageSize    int
+	Page        int
+	// Offset, if positive, starts the page after that many hosts instead of
+	// at Page.
+	Offset int
 }
 
 // Store provides CRUD operations for inventory records.
+//
+// Writes go through db, a single connection, so that they never contend for
+// SQLite's write lock; queries go through read, a pool of read-only
+// connections. With the write-ahead log, readers see the last committed
+// state and neither wait for a write in progress nor hold one up.
 type Store struct {
-	db *sql.DB
+	db   *sql.DB
+	read *sql.DB
+	// latest caches GetLatestByHostname; nil disables it (see CacheLatest).
+	latest *latestCache
+	// counts caches the totals of List; nil disables it (see CacheCounts).
+	counts *countCache
+	// purgeBatch and purgePause pace Purge (see PurgeBatches).
+	purgeBatch int
+	purgePause time.Duration
 }
 
 // New opens the SQLite database at path and runs migrations.
 func New(path string) (*Store, error) {
-	db, err := sql.Open("sqlite", path+"?_pragma=journal_mode(wal)&_pragma=busy_timeout(5000)")
+	s, err := Open(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := migrate(s.db); err != nil {
+		s.Close()
+		return nil, fmt.Errorf("run migrations: %w", err)
+	}
+	return s, nil
+}
+
+// Open opens the SQLite database at path without migrating it.
+func Open(path string) (*Store, error) {
+	// Incremental auto-vacuum lets Purge return freed pages to the file
+	// system as it goes. It applies to new databases; VACUUM (Compact)
+	// converts existing ones.
+	db, err := sql.Open("sqlite", path+"?_pragma=auto_vacuum(incremental)&_pragma=journal_mode(wal)&_pragma=busy_timeout(5000)")
 	if err != nil {
 		return nil, fmt.Errorf("open database: %w", err)
 	}
 
 	db.SetMaxOpenConns(1)
 
-	if _, err := db.Exec(createTableSQL); err != nil {
+	// The writer switches the file to the write-ahead log, which persists,
+	// before any reader connects; readers only refuse writes.
+	if err := db.Ping(); err != nil {
 		db.Close()
-		return nil, fmt.Errorf("run migrations: %w", err)
+		return nil, fmt.Errorf("open database: %w", err)
+	}
+	read, err := sql.Open("sqlite", path+"?_pragma=busy_timeout(5000)&_pragma=query_only(1)")
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("open database: %w", err)
 	}
 
-	return &Store{db: db}, nil
+	// Queries are CPU-bound once the pages are cached, so more readers than
+	// processors only slice the CPU more finely.
+	readConns := max(2, runtime.GOMAXPROCS(0))
+	read.SetMaxOpenConns(readConns)
+	read.SetMaxIdleConns(readConns)
+
+	return &Store{db: db, read: read, purgeBatch: DefaultPurgeBatchSize, purgePause: DefaultPurgePause}, nil
 }
 
-// Close closes the database connection.
+// OpenCurrent opens the SQLite database at path without migrating it and
+// fails unless its schema is at LatestVersion, for deployments that migrate
+// explicitly.
+func OpenCurrent(path string) (*Store, error) {
+	s, err := Open(path)
+	if err != nil {
+		return nil, err
+	}
+	version, err := s.SchemaVersion(context.Background())
+	if err != nil {
+		s.Close()
+		return nil, fmt.Errorf("read schema version: %w", err)
+	}
+	if version != LatestVersion() {
+		s.Close()
+		return nil, fmt.Errorf("database schema is at version %d, this release needs %d; run \"inventory-collector db migrate\"", version, LatestVersion())
+	}
+	return s, nil
+}
+
+// Close closes the database connections.
 func (s *Store) Close() error {
-	return s.db.Close()
+	return errors.Join(s.read.Close(), s.db.Close())
 }
 
 // Insert stores an inventory record and returns the new ID and stored_at time.
 func (s *Store) Insert(ctx context.Context, rec *InventoryRecord) (int64, time.Time, error) {
+	ids, storedAt, err := s.InsertBatch(ctx, []*InventoryRecord{rec})
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	return ids[0], storedAt, nil
+}
+
+// InsertBatch stores the records in a single transaction and returns their
+// IDs, in order, and the common stored_at time. Either all records are
+// stored or none.
+func (s *Store) InsertBatch(ctx context.Context, recs []*InventoryRecord) ([]int64, time.Time, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
 	storedAt := time.Now().UTC()
-	result, err := s.db.ExecContext(ctx,
-		`INSERT INTO inventories (hostname, username, system_uuid, system_serial, collected_at, stored_at, inventory_json)
-		 VALUES (?, ?, ?, ?, ?, ?, ?)`,
+	ids := make([]int64, len(recs))
+	for i, rec := range recs {
+		if ids[i], err = insertRecord(ctx, tx, rec, storedAt); err != nil {
+			return nil, time.Time{}, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, time.Time{}, fmt.Errorf("commit transaction: %w", err)
+	}
+	for _, rec := range recs {
+		s.latest.invalidate(rec.Hostname)
+	}
+	return ids, storedAt, nil
+}
+
+// execer is implemented by *sql.DB and *sql.Tx.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+func insertRecord(ctx context.Context, db execer, rec *InventoryRecord, storedAt time.Time) (int64, error) {
+	result, err := db.ExecContext(ctx,
+		`INSERT INTO inventories (site, hostname, username, system_uuid, system_serial, collected_at, stored_at, device_class, source, inventory_json, inventory_pb, submission_uuid)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		rec.Site,
 		rec.Hostname,
 		rec.Username,
 		rec.SystemUUID,
 		rec.SystemSerial,
 		rec.CollectedAt.UTC().Format(time.RFC3339),
 		storedAt.Format(time.RFC3339),
+		deviceClass(rec.DeviceClass),
+		source(rec.Source),
 		rec.InventoryJSON,
+		rec.InventoryProto,
+		sql.NullString{String: rec.SubmissionUUID, Valid: rec.SubmissionUUID != ""},
 	)
 	if err != nil {
-		return 0, time.Time{}, fmt.Errorf("insert inventory: %w", err)
+		if isUniqueViolation(err) && rec.SubmissionUUID != "" {
+			return 0, fmt.Errorf("insert inventory: %w", ErrDuplicateSubmission)
+		}
+		return 0, fmt.Errorf("insert inventory: %w", err)
 	}
 
 	id, err := result.LastInsertId()
 	if err != nil {
-		return 0, time.Time{}, fmt.Errorf("get last insert id: %w", err)
+		return 0, fmt.Errorf("get last insert id: %w", err)
+	}
+
+	if rec.Events != nil {
+		for _, m := range rec.Events(id, storedAt) {
+			if err := insertOutbox(ctx, db, m, storedAt); err != nil {
+				return 0, err
+			}
+		}
 	}
+	return id, nil
+}
+
+// isUniqueViolation reports whether err is SQLite's for breaking a UNIQUE
+// constraint.
+func isUniqueViolation(err error) bool {
+	var se *sqlite.Error
+	return errors.As(err, &se) && se.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE
+}
+
+// FindSubmission returns the ID and stored_at time of the inventory the site
+// stored under the submission UUID, or sql.ErrNoRows if there is none.
+func (s *Store) FindSubmission(ctx context.Context, site, submissionUUID string) (int64, time.Time, error) {
+	var id int64
+	var storedAt string
+	err := s.read.QueryRowContext(ctx,
+		`SELECT id, stored_at FROM inventories WHERE site = ? AND submission_uuid = ?`,
+		site, submissionUUID).Scan(&id, &storedAt)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	t, err := time.Parse(time.RFC3339, storedAt)
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("parse stored_at: %w", err)
+	}
+	return id, t, nil
+}
 
-	return id, storedAt, nil
+// Get retrieves an inventory record by ID. A non-nil sites restricts the
+// lookup to those sites, as do the sites arguments of the methods below.
+func (s *Store) Get(ctx context.Context, id int64, sites []string) (*InventoryRecord, error) {
+	scope, args := siteScope(sites)
+	row := s.read.QueryRowContext(ctx,
+		`SELECT id, site, hostname, username, system_uuid, system_serial, collected_at, stored_at, device_class, source, inventory_json, inventory_pb
+		 FROM inventories WHERE id = ?`+scope, append([]any{id}, args...)...)
+
+	return scanRecord(row)
 }
 
-// Get retrieves an inventory record by ID.
-func (s *Store) Get(ctx context.Context, id int64) (*InventoryRecord, error) {
-	row := s.db.QueryRowContext(ctx,
-		`SELECT id, hostname, username, system_uuid, system_serial, collected_at, stored_at, inventory_json
-		 FROM inventories WHERE id = ?`, id)
+// GetLatestBySystemUUID retrieves the most recent inventory for an SMBIOS system UUID.
+func (s *Store) GetLatestBySystemUUID(ctx context.Context, systemUUID string, sites []string) (*InventoryRecord, error) {
+	return s.getLatest(ctx, "system_uuid", systemUUID, sites)
+}
+
+// GetLatestBySerial retrieves the most recent inventory for a system serial number.
+func (s *Store) GetLatestBySerial(ctx context.Context, serial string, sites []string) (*InventoryRecord, error) {
+	return s.getLatest(ctx, "system_serial", serial, sites)
+}
+
+// getLatest returns the newest inventory whose column equals value. column
+// is interpolated into the SQL and must be a constant.
+func (s *Store) getLatest(ctx context.Context, column, value string, sites []string) (*InventoryRecord, error) {
+	scope, args := siteScope(sites)
+	row := s.read.QueryRowContext(ctx,
+		`SELECT id, site, hostname, username, system_uuid, system_serial, collected_at, stored_at, device_class, source, inventory_json, inventory_pb
+		 FROM inventories WHERE `+column+` = ?`+scope+` ORDER BY collected_at DESC LIMIT 1`,
+		append([]any{value}, args...)...)
 
 	return scanRecord(row)
 }
 
-// GetLatestByHostname retrieves the most recent inventory for a hostname.
-func (s *Store) GetLatestByHostname(ctx context.Context, hostname string) (*InventoryRecord, error) {
-	row := s.db.QueryRowContext(ctx,
-		`SELECT id, hostname, username, system_uuid, system_serial, collected_at, stored_at, inventory_json
-		 FROM inventories WHERE hostname = ? ORDER BY collected_at DESC LIMIT 1`, hostname)
+// GetPrevious retrieves the inventory of the same site and hostname that was
+// collected immediately before rec.
+func (s *Store) GetPrevious(ctx context.Context, rec *InventoryRecord) (*InventoryRecord, error) {
+	collectedAt := rec.CollectedAt.UTC().Format(time.RFC3339)
+	row := s.read.QueryRowContext(ctx,
+		`SELECT id, site, hostname, username, system_uuid, system_serial, collected_at, stored_at, device_class, source, inventory_json, inventory_pb
+		 FROM inventories
+		 WHERE site = ? AND hostname = ? AND (collected_at < ? OR (collected_at = ? AND id < ?))
+		 ORDER BY collected_at DESC, id DESC LIMIT 1`,
+		rec.Site, rec.Hostname, collectedAt, collectedAt, rec.ID)
 
 	return scanRecord(row)
 }
 
 // Delete removes an inventory record by ID.
-func (s *Store) Delete(ctx context.Context, id int64) error {
-	result, err := s.db.ExecContext(ctx, `DELETE FROM inventories WHERE id = ?`, id)
+func (s *Store) Delete(ctx context.Context, id int64, sites []string) error {
+	scope, args := siteScope(sites)
+	result, err := s.db.ExecContext(ctx, `DELETE FROM inventories WHERE id = ?`+scope, append([]any{id}, args...)...)
 	if err != nil {
 		return fmt.Errorf("delete inventory: %w", err)
 	}
+	s.latest.invalidateAll()
+	s.counts.invalidate()
 
 	n, err := result.RowsAffected()
 	if err != nil {
@@ -121,35 +438,109 @@ func (s *Store) Delete(ctx context.Context, id int64) error {
 	return nil
 }
 
-// List returns inventory summaries matching the given filter.
+// List returns inventory summaries matching the given filter, and the
+// total number of them. The total may be cached (see CacheCounts).
 func (s *Store) List(ctx context.Context, f ListFilter) ([]InventoryRecord, int, error) {
 	where, args := buildWhere(f)
+	countGen := s.counts.generation()
+	records, err := s.listPage(ctx, f, where, args)
+	if err != nil {
+		return nil, 0, err
+	}
 
-	// Count total matching rows.
+	// A page short of pageSize ends the list, so the total is known
+	// without counting, unless the page is after a cursor or past the end.
+	pageSize, offset := pageBounds(f.PageSize, f.Page)
+	key := countKey(where, args)
+	if f.After == nil && len(records) < pageSize && (len(records) > 0 || offset == 0) {
+		total := offset + len(records)
+		s.counts.put(key, total, countGen)
+		return records, total, nil
+	}
+	if !f.ExactCount {
+		if total, ok := s.counts.get(key); ok {
+			return records, total, nil
+		}
+	}
 	var total int
-	countQuery := "SELECT COUNT(*) FROM inventories" + where
-	if err := s.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+	if err := s.read.QueryRowContext(ctx, "SELECT COUNT(*) FROM inventories"+where, args...).Scan(&total); err != nil {
 		return nil, 0, fmt.Errorf("count inventories: %w", err)
 	}
+	s.counts.put(key, total, countGen)
+	return records, total, nil
+}
 
-	// Fetch page.
-	pageSize := f.PageSize
-	if pageSize <= 0 {
-		pageSize = 50
+// iteratePageSize is the number of inventories Iterate reads at a time.
+const iteratePageSize = 100
+
+// Iterate yields the inventories matching f in List order, starting after
+// f.After if set; f.PageSize and f.Page are ignored. It reads them a page at
+// a time, each page after the last inventory of the previous one, so that
+// neither the inventories nor a read transaction are held for the whole
+// iteration. Iteration stops at the first error.
+func (s *Store) Iterate(ctx context.Context, f ListFilter) iter.Seq2[*InventoryRecord, error] {
+	return func(yield func(*InventoryRecord, error) bool) {
+		where, args := buildWhere(f)
+		f.PageSize, f.Page = iteratePageSize, 1
+		for {
+			records, err := s.listPage(ctx, f, where, args)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			for i := range records {
+				if !yield(&records[i], nil) {
+					return
+				}
+			}
+			if f.After = f.Next(records); f.After == nil {
+				return
+			}
+		}
 	}
-	page := f.Page
-	if page <= 0 {
-		page = 1
+}
+
+// listPage returns the page of f, whose conditions buildWhere turned into
+// where and args.
+func (s *Store) listPage(ctx context.Context, f ListFilter, where string, args []any) ([]InventoryRecord, error) {
+	// Fetch page, after the cursor rather than at an offset if there is
+	// one. Index entries end with the ID, so the collected_at index serves
+	// both the order and the cursor.
+	args = args[:len(args):len(args)]
+	pageSize, offset := pageBounds(f.PageSize, f.Page)
+	if f.After != nil {
+		at := f.After.CollectedAt.UTC().Format(time.RFC3339)
+		cond := "collected_at <= ? AND (collected_at < ? OR id < ?)"
+		if where == "" {
+			where = " WHERE " + cond
+		} else {
+			where += " AND " + cond
+		}
+		args = append(args, at, at, f.After.ID)
+		offset = 0
 	}
-	offset := (page - 1) * pageSize
 
-	query := `SELECT id, hostname, username, system_uuid, system_serial, collected_at, stored_at, ''
-		FROM inventories` + where + ` ORDER BY collected_at DESC LIMIT ? OFFSET ?`
+	jsonColumns := "'', NULL"
+	if f.WithJSON {
+		jsonColumns = "inventory_json, inventory_pb"
+	}
+	// idx_inventories_summary holds every column but the JSON in list
+	// order, so pages are read from it without touching the wide rows.
+	// SQLite would rather look up a site or device class through its own
+	// index and then read and sort every matching row; only the selective
+	// filters are left to their indexes.
+	from := "inventories"
+	if f.Hostname == "" && f.Username == "" && f.SystemUUID == "" {
+		from = "inventories INDEXED BY idx_inventories_summary"
+	}
+
+	query := `SELECT id, site, hostname, username, system_uuid, system_serial, collected_at, stored_at, device_class, source, ` + jsonColumns + `
+		FROM ` + from + where + ` ORDER BY collected_at DESC, id DESC LIMIT ? OFFSET ?`
 	args = append(args, pageSize, offset)
 
-	rows, err := s.db.QueryContext(ctx, query, args...)
+	rows, err := s.read.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, 0, fmt.Errorf("list inventories: %w", err)
+		return nil, fmt.Errorf("list inventories: %w", err)
 	}
 	defer rows.Close()
 
@@ -157,28 +548,346 @@ func (s *Store) List(ctx context.Context, f ListFilter) ([]InventoryRecord, int,
 	for rows.Next() {
 		rec, err := scanRecordFromRows(rows)
 		if err != nil {
-			return nil, 0, err
+			return nil, err
 		}
 		records = append(records, *rec)
 	}
+	return records, rows.Err()
+}
 
-	return records, total, rows.Err()
+// ListAfter returns up to limit inventories with an ID above afterID,
+// including InventoryJSON, in ID order.
+func (s *Store) ListAfter(ctx context.Context, afterID int64, limit int) ([]InventoryRecord, error) {
+	rows, err := s.read.QueryContext(ctx,
+		`SELECT id, site, hostname, username, system_uuid, system_serial, collected_at, stored_at, device_class, source, inventory_json, inventory_pb
+		 FROM inventories WHERE id > ? ORDER BY id LIMIT ?`, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list inventories: %w", err)
+	}
+	defer rows.Close()
+
+	var records []InventoryRecord
+	for rows.Next() {
+		rec, err := scanRecordFromRows(rows)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, *rec)
+	}
+	return records, rows.Err()
 }
 
-// Purge deletes inventory records older than the given duration.
-func (s *Store) Purge(ctx context.Context, olderThan time.Duration) (int64, error) {
-	cutoff := time.Now().UTC().Add(-olderThan).Format(time.RFC3339)
-	result, err := s.db.ExecContext(ctx, `DELETE FROM inventories WHERE collected_at < ?`, cutoff)
+// ListHosts returns one row per host, carrying the ID, username and
+// collection time of its most recent inventory, its directory information
+// and its custom fields, ordered by last seen (newest first), then site and
+// hostname. Hosts are identified by site and hostname.
+func (s *Store) ListHosts(ctx context.Context, f HostFilter) ([]HostRecord, int, error) {
+	where, args := buildWhere(ListFilter{
+		Sites:          f.Sites,
+		Hostname:       f.Hostname,
+		DeviceClass:    f.DeviceClass,
+		CollectedAfter: f.SeenAfter,
+	})
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM (SELECT 1 FROM inventories` + where + ` GROUP BY site, hostname)`
+	if err := s.read.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count hosts: %w", err)
+	}
+
+	limit, offset := pageBounds(f.PageSize, f.Page)
+	if f.Offset > 0 {
+		offset = f.Offset
+	}
+
+	// SQLite returns the bare columns from the row that holds MAX(collected_at).
+	rows, err := s.read.QueryContext(ctx,
+		`SELECT h.id, h.site, h.hostname, h.username, h.system_uuid, h.last_seen,
+		        d.ou, d.description, d.username, d.user_display_name, d.department, d.updated_at
+		 FROM (SELECT id, site, hostname, username, system_uuid, MAX(collected_at) AS last_seen
+		       FROM inventories`+where+` GROUP BY site, hostname) h
+		 LEFT JOIN host_directory d ON d.site = h.site AND d.hostname = h.hostname
+		 ORDER BY h.last_seen DESC, h.site, h.hostname LIMIT ? OFFSET ?`,
+		append(args, limit, offset)...)
 	if err != nil {
-		return 0, fmt.Errorf("purge inventories: %w", err)
+		return nil, 0, fmt.Errorf("list hosts: %w", err)
+	}
+	defer rows.Close()
+
+	var hosts []HostRecord
+	for rows.Next() {
+		var h HostRecord
+		var lastSeen string
+		var ou, description, username, displayName, department, updatedAt sql.NullString
+		if err := rows.Scan(&h.LatestID, &h.Site, &h.Hostname, &h.Username, &h.SystemUUID, &lastSeen,
+			&ou, &description, &username, &displayName, &department, &updatedAt); err != nil {
+			return nil, 0, err
+		}
+		h.LastSeen, _ = time.Parse(time.RFC3339, lastSeen)
+		if updatedAt.Valid {
+			h.Directory = &HostDirectory{
+				Site:            h.Site,
+				Hostname:        h.Hostname,
+				OU:              ou.String,
+				Description:     description.String,
+				Username:        username.String,
+				UserDisplayName: displayName.String,
+				Department:      department.String,
+			}
+			h.Directory.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt.String)
+		}
+		hosts = append(hosts, h)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	if err := s.loadHostFields(ctx, hosts); err != nil {
+		return nil, 0, err
 	}
-	return result.RowsAffected()
+	return hosts, total, nil
+}
+
+// PurgeFilter selects the records removed by Purge. At least one of
+// OlderThan, Hostname, HostnameRegexp and SystemUUID must be set.
+type PurgeFilter struct {
+	// OlderThan selects inventories collected, and alerts raised, longer
+	// ago than this (0 = any age).
+	OlderThan time.Duration
+	// Hostname is a hostname, or a glob pattern such as LAB-* that selects
+	// every matching host (see IsGlob).
+	Hostname string
+	// HostnameRegexp selects the hosts whose hostname it matches.
+	HostnameRegexp *regexp.Regexp
+	SystemUUID     string
+	// Sites restricts the purge to these sites (nil = all sites).
+	Sites []string
+	// DryRun counts the matching records without deleting them.
+	DryRun bool
+	// Progress, if set, is called with the running totals after each batch
+	// of deletions.
+	Progress func(PurgeResult)
+}
+
+// Defaults of PurgeBatches.
+const (
+	DefaultPurgeBatchSize = 1000
+	DefaultPurgePause     = 100 * time.Millisecond
+)
+
+// PurgeBatches makes Purge delete at most size records per transaction and
+// wait pause between transactions, so that submissions are not held up
+// behind the writer for the whole of a large purge. A size of 0 or less
+// restores DefaultPurgeBatchSize. It must be called before the Store is used
+// concurrently.
+func (s *Store) PurgeBatches(size int, pause time.Duration) {
+	if size <= 0 {
+		size = DefaultPurgeBatchSize
+	}
+	s.purgeBatch, s.purgePause = size, max(pause, 0)
+}
+
+// PurgeResult is the number of records removed by Purge, or that would be
+// removed in a dry run.
+type PurgeResult struct {
+	Inventories int64
+	Alerts      int64
+	// Hosts is the number of distinct hosts whose inventories matched.
+	Hosts int64
+}
+
+// IsGlob reports whether a hostname is a glob pattern, i.e. contains *, ?
+// or [. The pattern syntax is SQLite's GLOB, which is case-sensitive.
+func IsGlob(hostname string) bool {
+	return strings.ContainsAny(hostname, "*?[")
+}
+
+// Purge deletes the inventories matching f, and the alerts raised for the
+// same hosts and sites in the same period, in batches (see PurgeBatches).
+// If it fails part way, the batches deleted until then stay deleted and are
+// counted in the result.
+func (s *Store) Purge(ctx context.Context, f PurgeFilter) (PurgeResult, error) {
+	if f.OlderThan <= 0 && f.Hostname == "" && f.HostnameRegexp == nil && f.SystemUUID == "" {
+		return PurgeResult{}, errors.New("purge needs an age, hostname or system UUID")
+	}
+
+	var invConds, alertConds []string
+	var invArgs, alertArgs []any
+	if f.OlderThan > 0 {
+		cutoff := time.Now().UTC().Add(-f.OlderThan).Format(time.RFC3339)
+		invConds, invArgs = append(invConds, "collected_at < ?"), append(invArgs, cutoff)
+		alertConds, alertArgs = append(alertConds, "created_at < ?"), append(alertArgs, cutoff)
+	}
+	if f.Hostname != "" {
+		cond := "hostname = ?"
+		if IsGlob(f.Hostname) {
+			cond = "hostname GLOB ?"
+		}
+		invConds, invArgs = append(invConds, cond), append(invArgs, f.Hostname)
+		alertConds, alertArgs = append(alertConds, cond), append(alertArgs, f.Hostname)
+	}
+	if f.HostnameRegexp != nil {
+		// SQLite has no regular expressions; match the stored hostnames.
+		hosts, err := s.matchHostnames(ctx, f.HostnameRegexp, f.Sites)
+		if err != nil {
+			return PurgeResult{}, err
+		}
+		if len(hosts) == 0 {
+			return PurgeResult{}, nil
+		}
+		cond := "hostname IN (?" + strings.Repeat(", ?", len(hosts)-1) + ")"
+		invConds, invArgs = append(invConds, cond), append(invArgs, hosts...)
+		alertConds, alertArgs = append(alertConds, cond), append(alertArgs, hosts...)
+	}
+	if f.SystemUUID != "" {
+		invConds, invArgs = append(invConds, "system_uuid = ?"), append(invArgs, f.SystemUUID)
+		// Alerts do not record the UUID; match those of the host's inventories.
+		alertConds = append(alertConds, "inventory_id IN (SELECT id FROM inventories WHERE system_uuid = ?)")
+		alertArgs = append(alertArgs, f.SystemUUID)
+	}
+	if f.Sites != nil {
+		cond, siteArgs := siteCondition(f.Sites)
+		invConds, invArgs = append(invConds, cond), append(invArgs, siteArgs...)
+		alertConds, alertArgs = append(alertConds, cond), append(alertArgs, siteArgs...)
+	}
+	invWhere := " WHERE " + strings.Join(invConds, " AND ")
+	alertWhere := " WHERE " + strings.Join(alertConds, " AND ")
+	hostsQuery := "SELECT COUNT(*) FROM (SELECT DISTINCT site, hostname FROM inventories" + invWhere + ")"
+
+	var res PurgeResult
+	if f.DryRun {
+		if err := s.read.QueryRowContext(ctx, "SELECT COUNT(*) FROM inventories"+invWhere, invArgs...).Scan(&res.Inventories); err != nil {
+			return res, fmt.Errorf("count inventories: %w", err)
+		}
+		if err := s.read.QueryRowContext(ctx, hostsQuery, invArgs...).Scan(&res.Hosts); err != nil {
+			return res, fmt.Errorf("count hosts: %w", err)
+		}
+		if err := s.read.QueryRowContext(ctx, "SELECT COUNT(*) FROM alerts"+alertWhere, alertArgs...).Scan(&res.Alerts); err != nil {
+			return res, fmt.Errorf("count alerts: %w", err)
+		}
+		return res, nil
+	}
+
+	if err := s.read.QueryRowContext(ctx, hostsQuery, invArgs...).Scan(&res.Hosts); err != nil {
+		return res, fmt.Errorf("count hosts: %w", err)
+	}
+	// Alerts go first: selecting them by UUID needs the inventories.
+	if err := s.purgeBatches(ctx, "alerts", alertWhere, alertArgs, &res, &res.Alerts, f.Progress); err != nil {
+		return res, err
+	}
+	if err := s.purgeBatches(ctx, "inventories", invWhere, invArgs, &res, &res.Inventories, f.Progress); err != nil {
+		return res, err
+	}
+	return res, nil
+}
+
+// purgeBatches deletes the rows of table matching where, a batch per
+// transaction, adding their number to *n and reporting res to progress
+// after each batch.
+func (s *Store) purgeBatches(ctx context.Context, table, where string, args []any, res *PurgeResult, n *int64, progress func(PurgeResult)) error {
+	query := "DELETE FROM " + table + " WHERE id IN (SELECT id FROM " + table + where + " LIMIT ?)"
+	args = append(args[:len(args):len(args)], s.purgeBatch)
+	for {
+		result, err := s.db.ExecContext(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("purge %s: %w", table, err)
+		}
+		deleted, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("rows affected: %w", err)
+		}
+		if deleted == 0 {
+			return nil
+		}
+		*n += deleted
+		if table == "inventories" {
+			s.latest.invalidateAll()
+			s.counts.invalidate()
+		}
+		if err := s.incrementalVacuum(ctx); err != nil {
+			return err
+		}
+		if progress != nil {
+			progress(*res)
+		}
+		if deleted < int64(s.purgeBatch) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(s.purgePause):
+		}
+	}
+}
+
+// matchHostnames returns the distinct stored hostnames in sites that re
+// matches.
+func (s *Store) matchHostnames(ctx context.Context, re *regexp.Regexp, sites []string) ([]any, error) {
+	scope, args := siteScope(sites)
+	rows, err := s.read.QueryContext(ctx, "SELECT DISTINCT hostname FROM inventories WHERE 1"+scope, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list hostnames: %w", err)
+	}
+	defer rows.Close()
+
+	var hosts []any
+	for rows.Next() {
+		var h string
+		if err := rows.Scan(&h); err != nil {
+			return nil, fmt.Errorf("list hostnames: %w", err)
+		}
+		if re.MatchString(h) {
+			hosts = append(hosts, h)
+		}
+	}
+	return hosts, rows.Err()
+}
+
+// pageBounds normalises 1-based page parameters into LIMIT/OFFSET values.
+func pageBounds(pageSize, page int) (int, int) {
+	if pageSize <= 0 {
+		pageSize = 50
+	}
+	if page <= 0 {
+		page = 1
+	}
+	return pageSize, (page - 1) * pageSize
+}
+
+// siteScope returns an " AND site IN (...)" clause restricting a query to
+// sites, or an empty clause when sites is nil.
+func siteScope(sites []string) (string, []any) {
+	if sites == nil {
+		return "", nil
+	}
+	cond, args := siteCondition(sites)
+	return " AND " + cond, args
+}
+
+// siteCondition builds the site membership condition for a non-nil sites.
+func siteCondition(sites []string) (string, []any) {
+	if len(sites) == 0 {
+		return "0", nil
+	}
+	args := make([]any, len(sites))
+	for i, site := range sites {
+		args[i] = site
+	}
+	return "site IN (?" + strings.Repeat(", ?", len(sites)-1) + ")", args
 }
 
 func buildWhere(f ListFilter) (string, []any) {
 	var conditions []string
 	var args []any
 
+	if f.Sites != nil {
+		cond, siteArgs := siteCondition(f.Sites)
+		conditions = append(conditions, cond)
+		args = append(args, siteArgs...)
+	}
+
 	if f.Hostname != "" {
 		conditions = append(conditions, "hostname = ?")
 		args = append(args, f.Hostname)
@@ -191,6 +900,10 @@ func buildWhere(f ListFilter) (string, []any) {
 		conditions = append(conditions, "system_uuid = ?")
 		args = append(args, f.SystemUUID)
 	}
+	if f.DeviceClass != "" {
+		conditions = append(conditions, "device_class = ?")
+		args = append(args, f.DeviceClass)
+	}
 	if f.CollectedAfter != nil {
 		conditions = append(conditions, "collected_at >= ?")
 		args = append(args, f.CollectedAfter.UTC().Format(time.RFC3339))
@@ -221,7 +934,7 @@ type scanner interface {
 func scanRecord(row *sql.Row) (*InventoryRecord, error) {
 	var rec InventoryRecord
 	var collectedAt, storedAt string
-	err := row.Scan(&rec.ID, &rec.Hostname, &rec.Username, &rec.SystemUUID, &rec.SystemSerial, &collectedAt, &storedAt, &rec.InventoryJSON)
+	err := row.Scan(&rec.ID, &rec.Site, &rec.Hostname, &rec.Username, &rec.SystemUUID, &rec.SystemSerial, &collectedAt, &storedAt, &rec.DeviceClass, &rec.Source, &rec.InventoryJSON, &rec.InventoryProto)
 	if err != nil {
 		return nil, err
 	}
@@ -235,7 +948,7 @@ func scanRecord(row *sql.Row) (*InventoryRecord, error) {
 func scanRecordFromRows(rows *sql.Rows) (*InventoryRecord, error) {
 	var rec InventoryRecord
 	var collectedAt, storedAt string
-	err := rows.Scan(&rec.ID, &rec.Hostname, &rec.Username, &rec.SystemUUID, &rec.SystemSerial, &collectedAt, &storedAt, &rec.InventoryJSON)
+	err := rows.Scan(&rec.ID, &rec.Site, &rec.Hostname, &rec.Username, &rec.SystemUUID, &rec.SystemSerial, &collectedAt, &storedAt, &rec.DeviceClass, &rec.Source, &rec.InventoryJSON, &rec.InventoryProto)
 	if err != nil {
 		return nil, err
 	}
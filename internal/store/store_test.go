@@ -0,0 +1,241 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// benchInventoryJSON is the stored JSON of a typical desktop inventory.
+const benchInventoryJSON = `{"hostname":"%s","system":{"manufacturer":"Dell Inc.","productName":"OptiPlex 7090","serialNumber":"%s","uuid":"%s"},` +
+	`"processors":[{"socketDesignation":"CPU1","manufacturer":"Intel(R) Corporation","version":"Intel(R) Core(TM) i7-11700 CPU @ 2.50GHz","coreCount":8,"threadCount":16}],` +
+	`"memory":{"totalPhysicalBytes":"34359738368","modules":[{"deviceLocator":"DIMM A1","capacityBytes":"17179869184","memoryType":"DDR4","speedMtS":3200},` +
+	`{"deviceLocator":"DIMM B1","capacityBytes":"17179869184","memoryType":"DDR4","speedMtS":3200}]},"schemaVersion":1}`
+
+// newBenchStore opens a migrated store in a temporary directory.
+func newBenchStore(b *testing.B) *Store {
+	b.Helper()
+	s, err := New(filepath.Join(b.TempDir(), "inventory.db"))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Cleanup(func() { s.Close() })
+	return s
+}
+
+// benchRecord returns the i-th inventory of a fleet of hosts hosts, each
+// submitted once a day.
+func benchRecord(i, hosts int) *InventoryRecord {
+	host := i % hosts
+	hostname := fmt.Sprintf("ws-%05d", host)
+	serial := fmt.Sprintf("SN%08d", host)
+	uuid := fmt.Sprintf("4c4c4544-0000-1000-8000-%012d", host)
+	return &InventoryRecord{
+		Site:          "hq",
+		Hostname:      hostname,
+		Username:      "jdoe",
+		SystemUUID:    uuid,
+		SystemSerial:  serial,
+		CollectedAt:   time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC).Add(time.Duration(i) * time.Minute),
+		DeviceClass:   DeviceComputer,
+		Source:        SourceAgent,
+		InventoryJSON: fmt.Sprintf(benchInventoryJSON, hostname, serial, uuid),
+	}
+}
+
+// seedBench stores n inventories of hosts hosts.
+func seedBench(b *testing.B, s *Store, n, hosts int) {
+	b.Helper()
+	recs := make([]*InventoryRecord, 0, 500)
+	for i := range n {
+		recs = append(recs, benchRecord(i, hosts))
+		if len(recs) == cap(recs) || i == n-1 {
+			if _, _, err := s.InsertBatch(context.Background(), recs); err != nil {
+				b.Fatal(err)
+			}
+			recs = recs[:0]
+		}
+	}
+}
+
+func BenchmarkInsert(b *testing.B) {
+	s := newBenchStore(b)
+	ctx := context.Background()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, _, err := s.Insert(ctx, benchRecord(i, 1000)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkInsertBatch(b *testing.B) {
+	const batch = 100
+	s := newBenchStore(b)
+	ctx := context.Background()
+	recs := make([]*InventoryRecord, batch)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j := range recs {
+			recs[j] = benchRecord(i*batch+j, 1000)
+		}
+		if _, _, err := s.InsertBatch(ctx, recs); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ReportMetric(float64(b.N*batch)/b.Elapsed().Seconds(), "inventories/s")
+}
+
+func BenchmarkList(b *testing.B) {
+	s := newBenchStore(b)
+	seedBench(b, s, 20000, 1000)
+	ctx := context.Background()
+
+	for _, bm := range []struct {
+		name string
+		f    ListFilter
+	}{
+		{"FirstPage", ListFilter{PageSize: 50}},
+		{"LastPage", ListFilter{PageSize: 50, Page: 20000 / 50}},
+		{"Host", ListFilter{Hostname: "ws-00042", PageSize: 50}},
+		{"WithJSON", ListFilter{PageSize: 50, WithJSON: true}},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, _, err := s.List(ctx, bm.f); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+
+	// Token walks the whole history page by page, as clients paging
+	// through it with next_page_token do.
+	b.Run("Token", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			f := ListFilter{PageSize: 500}
+			for {
+				records, _, err := s.List(ctx, f)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if f.After = f.Next(records); f.After == nil {
+					break
+				}
+			}
+		}
+	})
+}
+
+func BenchmarkListHosts(b *testing.B) {
+	s := newBenchStore(b)
+	seedBench(b, s, 20000, 1000)
+	ctx := context.Background()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, _, err := s.ListHosts(ctx, HostFilter{PageSize: 50}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkMixedLoad measures the latency of List and Get, issued by
+// readers at readRate a second in all, while a writer stores writeRate
+// inventories a second, reporting its median and p99. SharedConn
+// runs the queries on the writer's connection, as the store did before it
+// had a read pool, for comparison.
+func BenchmarkMixedLoad(b *testing.B) {
+	const readers, readRate, writeRate = 8, 1000, 500
+	for _, bm := range []struct {
+		name   string
+		shared bool
+	}{
+		{"SharedConn", true},
+		{"ReadPool", false},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			s := newBenchStore(b)
+			seedBench(b, s, 5000, 500)
+			if bm.shared {
+				read := s.read
+				s.read = s.db
+				b.Cleanup(func() { s.read = read })
+			}
+
+			// Inventories of servers run to tens of kilobytes.
+			oemStrings := `,"oemStrings":["` + strings.Repeat("x", 48<<10) + `"]}`
+			ctx, stop := context.WithCancel(context.Background())
+			var writes atomic.Int64
+			writerDone := make(chan struct{})
+			go func() {
+				defer close(writerDone)
+				tick := time.NewTicker(time.Second / writeRate)
+				defer tick.Stop()
+				for i := 5000; ; i++ {
+					select {
+					case <-tick.C:
+					case <-ctx.Done():
+						return
+					}
+					rec := benchRecord(i, 500)
+					rec.InventoryJSON = strings.TrimSuffix(rec.InventoryJSON, "}") + oemStrings
+					if _, _, err := s.Insert(ctx, rec); err != nil && ctx.Err() == nil {
+						b.Error(err)
+						return
+					}
+					writes.Add(1)
+				}
+			}()
+
+			var next atomic.Int64
+			latencies := make([][]time.Duration, readers)
+			var wg sync.WaitGroup
+			b.ResetTimer()
+			for r := range readers {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					tick := time.NewTicker(readers * time.Second / readRate)
+					defer tick.Stop()
+					for range tick.C {
+						i := next.Add(1)
+						if i > int64(b.N) {
+							return
+						}
+						start := time.Now()
+						var err error
+						if i%2 == 0 {
+							_, _, err = s.List(ctx, ListFilter{Hostname: fmt.Sprintf("ws-%05d", i%500), PageSize: 50})
+						} else {
+							_, err = s.Get(ctx, 1+i%5000, nil)
+						}
+						if err != nil {
+							b.Error(err)
+							return
+						}
+						latencies[r] = append(latencies[r], time.Since(start))
+					}
+				}()
+			}
+			wg.Wait()
+			b.StopTimer()
+			stop()
+			<-writerDone
+
+			all := slices.Concat(latencies...)
+			slices.Sort(all)
+			ms := func(q float64) float64 {
+				return float64(all[int(q*float64(len(all)-1))]) / float64(time.Millisecond)
+			}
+			b.ReportMetric(ms(0.5), "p50-ms")
+			b.ReportMetric(ms(0.99), "p99-ms")
+			b.ReportMetric(float64(writes.Load())/b.Elapsed().Seconds(), "writes/s")
+		})
+	}
+}
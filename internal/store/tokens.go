@@ -0,0 +1,103 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// TokenRecord represents a managed API token. Only the SHA-256 hash of the
+// token itself is stored.
+type TokenRecord struct {
+	ID   int64
+	Name string
+	Role string
+	// Sites restricts the token to these sites; empty means all sites.
+	Sites     []string
+	Hash      string
+	CreatedAt time.Time
+	// ExpiresAt and RevokedAt are zero when not set.
+	ExpiresAt time.Time
+	RevokedAt time.Time
+}
+
+// InsertToken stores a new token, filling in its ID and creation time.
+func (s *Store) InsertToken(ctx context.Context, t *TokenRecord) error {
+	sites, err := json.Marshal(append([]string{}, t.Sites...))
+	if err != nil {
+		return fmt.Errorf("encode token sites: %w", err)
+	}
+
+	createdAt := time.Now().UTC()
+	result, err := s.db.ExecContext(ctx,
+		`INSERT INTO api_tokens (name, role, sites, token_hash, created_at, expires_at)
+		 VALUES (?, ?, ?, ?, ?, ?)`,
+		t.Name, t.Role, string(sites), t.Hash, createdAt.Format(time.RFC3339), formatOptionalTime(t.ExpiresAt))
+	if err != nil {
+		return fmt.Errorf("insert token: %w", err)
+	}
+	if t.ID, err = result.LastInsertId(); err != nil {
+		return fmt.Errorf("get last insert id: %w", err)
+	}
+	t.CreatedAt = createdAt
+	return nil
+}
+
+// ListTokens returns every token, including revoked and expired ones,
+// newest first.
+func (s *Store) ListTokens(ctx context.Context) ([]TokenRecord, error) {
+	rows, err := s.read.QueryContext(ctx,
+		`SELECT id, name, role, sites, token_hash, created_at, expires_at, revoked_at
+		 FROM api_tokens ORDER BY id DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("list tokens: %w", err)
+	}
+	defer rows.Close()
+
+	var tokens []TokenRecord
+	for rows.Next() {
+		var t TokenRecord
+		var sites, createdAt, expiresAt, revokedAt string
+		if err := rows.Scan(&t.ID, &t.Name, &t.Role, &sites, &t.Hash, &createdAt, &expiresAt, &revokedAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(sites), &t.Sites); err != nil {
+			return nil, fmt.Errorf("decode sites of token %d: %w", t.ID, err)
+		}
+		t.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+		t.ExpiresAt, _ = time.Parse(time.RFC3339, expiresAt)
+		t.RevokedAt, _ = time.Parse(time.RFC3339, revokedAt)
+		tokens = append(tokens, t)
+	}
+	return tokens, rows.Err()
+}
+
+// RevokeToken marks a token as revoked. It returns sql.ErrNoRows if there
+// is no such token or it is already revoked.
+func (s *Store) RevokeToken(ctx context.Context, id int64) error {
+	result, err := s.db.ExecContext(ctx,
+		`UPDATE api_tokens SET revoked_at = ? WHERE id = ? AND revoked_at = ''`,
+		time.Now().UTC().Format(time.RFC3339), id)
+	if err != nil {
+		return fmt.Errorf("revoke token: %w", err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("rows affected: %w", err)
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// formatOptionalTime formats t for storage, or returns "" for the zero time.
+func formatOptionalTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
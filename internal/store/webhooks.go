@@ -0,0 +1,125 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Webhook delivery states.
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryFailed    = "failed"
+)
+
+// WebhookDelivery records a payload posted, or to be posted, to a webhook
+// endpoint and the outcome of the last attempt.
+type WebhookDelivery struct {
+	ID       int64
+	URL      string
+	Payload  []byte
+	Status   string
+	Attempts int
+	// StatusCode is the HTTP status of the last attempt, 0 if there was no
+	// response.
+	StatusCode int
+	LastError  string
+	CreatedAt  time.Time
+	// LastAttemptAt is zero before the first attempt.
+	LastAttemptAt time.Time
+}
+
+// WebhookDeliveryFilter holds optional query parameters for listing
+// deliveries.
+type WebhookDeliveryFilter struct {
+	Status   string
+	PageSize int
+	Page     int
+}
+
+// InsertWebhookDelivery stores a pending delivery, filling in its ID,
+// status and creation time.
+func (s *Store) InsertWebhookDelivery(ctx context.Context, d *WebhookDelivery) error {
+	createdAt := time.Now().UTC()
+	result, err := s.db.ExecContext(ctx,
+		`INSERT INTO webhook_deliveries (url, payload, status, created_at) VALUES (?, ?, ?, ?)`,
+		d.URL, d.Payload, DeliveryPending, createdAt.Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("insert webhook delivery: %w", err)
+	}
+	if d.ID, err = result.LastInsertId(); err != nil {
+		return fmt.Errorf("get last insert id: %w", err)
+	}
+	d.Status = DeliveryPending
+	d.CreatedAt = createdAt
+	return nil
+}
+
+// UpdateWebhookDelivery records the outcome of an attempt: the status,
+// attempt count, response status, error and time of d.
+func (s *Store) UpdateWebhookDelivery(ctx context.Context, d *WebhookDelivery) error {
+	_, err := s.db.ExecContext(ctx,
+		`UPDATE webhook_deliveries SET status = ?, attempts = ?, status_code = ?, last_error = ?, last_attempt_at = ? WHERE id = ?`,
+		d.Status, d.Attempts, d.StatusCode, d.LastError, formatOptionalTime(d.LastAttemptAt), d.ID)
+	if err != nil {
+		return fmt.Errorf("update webhook delivery: %w", err)
+	}
+	return nil
+}
+
+// GetWebhookDelivery returns the delivery with ID id, including its
+// payload. The error wraps sql.ErrNoRows if there is none.
+func (s *Store) GetWebhookDelivery(ctx context.Context, id int64) (*WebhookDelivery, error) {
+	row := s.read.QueryRowContext(ctx,
+		`SELECT id, url, payload, status, attempts, status_code, last_error, created_at, last_attempt_at
+		 FROM webhook_deliveries WHERE id = ?`, id)
+	return scanDelivery(row)
+}
+
+// ListWebhookDeliveries returns the deliveries matching the filter, newest
+// first, without their payloads.
+func (s *Store) ListWebhookDeliveries(ctx context.Context, f WebhookDeliveryFilter) ([]WebhookDelivery, int, error) {
+	where := ""
+	var args []any
+	if f.Status != "" {
+		where = " WHERE status = ?"
+		args = append(args, f.Status)
+	}
+
+	var total int
+	if err := s.read.QueryRowContext(ctx, "SELECT COUNT(*) FROM webhook_deliveries"+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count webhook deliveries: %w", err)
+	}
+
+	limit, offset := pageBounds(f.PageSize, f.Page)
+	rows, err := s.read.QueryContext(ctx,
+		`SELECT id, url, '', status, attempts, status_code, last_error, created_at, last_attempt_at
+		 FROM webhook_deliveries`+where+` ORDER BY id DESC LIMIT ? OFFSET ?`,
+		append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []WebhookDelivery
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		deliveries = append(deliveries, *d)
+	}
+	return deliveries, total, rows.Err()
+}
+
+func scanDelivery(row scanner) (*WebhookDelivery, error) {
+	var d WebhookDelivery
+	var createdAt, lastAttemptAt string
+	if err := row.Scan(&d.ID, &d.URL, &d.Payload, &d.Status, &d.Attempts, &d.StatusCode, &d.LastError, &createdAt, &lastAttemptAt); err != nil {
+		return nil, fmt.Errorf("read webhook delivery: %w", err)
+	}
+	d.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+	d.LastAttemptAt, _ = time.Parse(time.RFC3339, lastAttemptAt)
+	return &d, nil
+}
@@ -0,0 +1,81 @@
+// Package tenant scopes requests to one or more sites so a single collector
+// can serve several customers. Callers authenticated with a site-bound token
+// carry the token's sites in their context; all other callers are unrestricted.
+package tenant
+
+import (
+	"context"
+	"crypto/subtle"
+	"slices"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type scopeKey struct{}
+
+// Tokens maps a site-bound token to the sites it grants access to.
+type Tokens map[string][]string
+
+// Lookup returns the sites bound to token. Every entry is compared in
+// constant time so the match position is not observable.
+func (t Tokens) Lookup(token string) ([]string, bool) {
+	var sites []string
+	found := false
+	for k, v := range t {
+		if subtle.ConstantTimeCompare([]byte(k), []byte(token)) == 1 {
+			sites, found = v, true
+		}
+	}
+	return sites, found
+}
+
+// NewContext returns a context restricted to the given sites.
+func NewContext(ctx context.Context, sites []string) context.Context {
+	return context.WithValue(ctx, scopeKey{}, sites)
+}
+
+// FromContext returns the sites ctx is restricted to. restricted is false
+// when the caller may access every site.
+func FromContext(ctx context.Context) (sites []string, restricted bool) {
+	sites, restricted = ctx.Value(scopeKey{}).([]string)
+	return sites, restricted
+}
+
+// Allowed reports whether the caller may access records of site.
+func Allowed(ctx context.Context, site string) bool {
+	sites, restricted := FromContext(ctx)
+	return !restricted || slices.Contains(sites, site)
+}
+
+// Filter returns the sites a query should be limited to. An empty requested
+// site expands to the caller's scope; nil means no restriction.
+func Filter(ctx context.Context, requested string) ([]string, error) {
+	sites, restricted := FromContext(ctx)
+	if requested == "" {
+		return sites, nil
+	}
+	if restricted && !slices.Contains(sites, requested) {
+		return nil, status.Errorf(codes.PermissionDenied, "access to site %q denied", requested)
+	}
+	return []string{requested}, nil
+}
+
+// Resolve picks the single site a write or command applies to. A caller bound
+// to exactly one site is assigned it when none is declared.
+func Resolve(ctx context.Context, declared string) (string, error) {
+	sites, restricted := FromContext(ctx)
+	if !restricted {
+		return declared, nil
+	}
+	if declared == "" {
+		if len(sites) == 1 {
+			return sites[0], nil
+		}
+		return "", status.Error(codes.InvalidArgument, "site is required")
+	}
+	if !slices.Contains(sites, declared) {
+		return "", status.Errorf(codes.PermissionDenied, "access to site %q denied", declared)
+	}
+	return declared, nil
+}
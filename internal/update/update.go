@@ -0,0 +1,215 @@
+// Package update installs agent releases advertised by the collector. A
+// release is only installed when it is newer than the running agent, built
+// for its platform, and its manifest verifies against the update key the
+// agent was configured with and names the digest of the downloaded
+// executable.
+package update
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+)
+
+const (
+	// maxSize bounds the downloaded executable.
+	maxSize = 256 << 20
+
+	downloadTimeout = 10 * time.Minute
+)
+
+// ErrRestart is returned once a new executable is in place; the process must
+// exit so that the service manager starts the new version.
+var ErrRestart = errors.New("agent updated, restart required")
+
+// Updater downloads, verifies and installs agent releases.
+type Updater struct {
+	// Key verifies release signatures. Without a key updates are refused.
+	Key ed25519.PublicKey
+	// Version is the running agent version; releases of the same version
+	// are ignored, and older ones refused.
+	Version string
+	// Proxy is the proxy URL for downloads: empty uses the environment,
+	// "none" connects directly.
+	Proxy string
+}
+
+// manifestFormat identifies the layout of Manifest, so that it can change
+// without old signatures verifying for new manifests.
+const manifestFormat = "tangra-agent-update/v1"
+
+// Manifest returns the release manifest of u, which its signature covers:
+// the version, the platform and the SHA-256 digest of the executable, one
+// per line. Release tooling signs it with the private update key.
+func Manifest(u *collectorv1.AgentUpdate) []byte {
+	return fmt.Appendf(nil, "%s\nversion %s\nos %s\narch %s\nsha256 %s\n",
+		manifestFormat, u.GetVersion(), u.GetOs(), u.GetArch(), strings.ToLower(u.GetSha256()))
+}
+
+// Sign returns the signature of u's manifest with the private update key.
+func Sign(key ed25519.PrivateKey, u *collectorv1.AgentUpdate) []byte {
+	return ed25519.Sign(key, Manifest(u))
+}
+
+// ParseKey decodes a base64 Ed25519 public key. An empty string yields nil.
+func ParseKey(s string) (ed25519.PublicKey, error) {
+	if s == "" {
+		return nil, nil
+	}
+	b, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil || len(b) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid update key: expected a base64 Ed25519 public key")
+	}
+	return ed25519.PublicKey(b), nil
+}
+
+// Apply installs u in place of the running executable and returns
+// ErrRestart, or nil when u does not apply to this agent: it is the running
+// version or for another platform.
+func (up *Updater) Apply(ctx context.Context, u *collectorv1.AgentUpdate) error {
+	if u == nil || u.Version == "" || u.Version == up.Version {
+		return nil
+	}
+	if u.Os == "" || u.Arch == "" {
+		return errors.New("update does not name the platform it was built for")
+	}
+	if u.Os != runtime.GOOS || u.Arch != runtime.GOARCH {
+		return nil
+	}
+	want, err := up.verify(u)
+	if err != nil {
+		return err
+	}
+
+	data, err := up.download(ctx, u.DownloadUrl)
+	if err != nil {
+		return err
+	}
+	if sum := sha256.Sum256(data); !bytes.Equal(sum[:], want) {
+		return fmt.Errorf("digest mismatch: got %x", sum)
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("locate executable: %w", err)
+	}
+	if err := replace(exe, data); err != nil {
+		return err
+	}
+	return ErrRestart
+}
+
+// verify checks that u is signed with the update key and newer than the
+// running version, and returns the executable's digest its manifest names.
+func (up *Updater) verify(u *collectorv1.AgentUpdate) ([]byte, error) {
+	if len(up.Key) == 0 {
+		return nil, errors.New("no update key configured; refusing to install unsigned update")
+	}
+	want, err := hex.DecodeString(u.Sha256)
+	if err != nil || len(want) != sha256.Size {
+		return nil, fmt.Errorf("invalid sha256 %q", u.Sha256)
+	}
+	if !ed25519.Verify(up.Key, Manifest(u), u.Signature) {
+		return nil, errors.New("signature verification failed")
+	}
+
+	next, err := parseVersion(u.Version)
+	if err != nil {
+		return nil, err
+	}
+	running, err := parseVersion(up.Version)
+	if err != nil {
+		return nil, fmt.Errorf("running version: %w; refusing to update", err)
+	}
+	if next.compare(running) <= 0 {
+		return nil, fmt.Errorf("refusing to install %s, which is not newer than the running %s", u.Version, up.Version)
+	}
+	return want, nil
+}
+
+func (up *Updater) download(ctx context.Context, rawURL string) ([]byte, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	switch up.Proxy {
+	case "":
+	case "none":
+		transport.Proxy = nil
+	default:
+		u, err := url.Parse(up.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL %q", up.Proxy)
+		}
+		transport.Proxy = http.ProxyURL(u)
+	}
+	client := &http.Client{Transport: transport, Timeout: downloadTimeout}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("download update: %w", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("download update: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("download update: %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("download update: %w", err)
+	}
+	if len(data) > maxSize {
+		return nil, fmt.Errorf("download update: larger than %d bytes", maxSize)
+	}
+	return data, nil
+}
+
+// replace swaps exe for data. The running executable is renamed to .old
+// rather than overwritten, which Windows does not allow.
+func replace(exe string, data []byte) error {
+	info, err := os.Stat(exe)
+	if err != nil {
+		return fmt.Errorf("stat executable: %w", err)
+	}
+
+	newPath, oldPath := exe+".new", exe+".old"
+	if err := os.WriteFile(newPath, data, info.Mode().Perm()); err != nil {
+		return fmt.Errorf("write new executable: %w", err)
+	}
+
+	os.Remove(oldPath)
+	if err := os.Rename(exe, oldPath); err != nil {
+		os.Remove(newPath)
+		return fmt.Errorf("move current executable aside: %w", err)
+	}
+	if err := os.Rename(newPath, exe); err != nil {
+		// Put the running version back so the service still starts.
+		os.Rename(oldPath, exe)
+		return fmt.Errorf("install new executable: %w", err)
+	}
+	return nil
+}
+
+// Cleanup removes the executable left behind by a previous update. It is a
+// no-op when there is none.
+func Cleanup() {
+	if exe, err := os.Executable(); err == nil {
+		os.Remove(exe + ".old")
+	}
+}
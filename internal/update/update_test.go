@@ -0,0 +1,110 @@
+package update
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"runtime"
+	"strings"
+	"testing"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+)
+
+func TestVerify(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256([]byte("agent"))
+	release := func(version string) *collectorv1.AgentUpdate {
+		u := &collectorv1.AgentUpdate{
+			Version:     version,
+			DownloadUrl: "https://updates.example.com/agent",
+			Sha256:      hex.EncodeToString(sum[:]),
+			Os:          runtime.GOOS,
+			Arch:        runtime.GOARCH,
+		}
+		u.Signature = Sign(priv, u)
+		return u
+	}
+
+	tests := []struct {
+		name    string
+		running string
+		update  func() *collectorv1.AgentUpdate
+		err     string
+	}{
+		{"Newer", "v1.4.0", func() *collectorv1.AgentUpdate { return release("v1.5.0") }, ""},
+		{"ReleaseOfPreRelease", "v1.5.0-rc.1", func() *collectorv1.AgentUpdate { return release("v1.5.0") }, ""},
+		{"Older", "v1.5.0", func() *collectorv1.AgentUpdate { return release("v1.4.2") }, "not newer"},
+		{"SameBuild", "v1.5.0+a", func() *collectorv1.AgentUpdate { return release("v1.5.0+b") }, "not newer"},
+		{"UnknownRunning", "dev", func() *collectorv1.AgentUpdate { return release("v1.5.0") }, "running version"},
+		{"VersionChanged", "v1.4.0", func() *collectorv1.AgentUpdate {
+			u := release("v1.3.0")
+			u.Version = "v1.9.0"
+			return u
+		}, "signature"},
+		{"PlatformChanged", "v1.4.0", func() *collectorv1.AgentUpdate {
+			u := release("v1.5.0")
+			u.Arch = "other"
+			return u
+		}, "signature"},
+		{"DigestChanged", "v1.4.0", func() *collectorv1.AgentUpdate {
+			u := release("v1.5.0")
+			u.Sha256 = strings.Repeat("0", 64)
+			return u
+		}, "signature"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			up := &Updater{Key: pub, Version: tc.running}
+			_, err := up.verify(tc.update())
+			switch {
+			case tc.err == "" && err != nil:
+				t.Fatalf("verify: %v", err)
+			case tc.err != "" && (err == nil || !strings.Contains(err.Error(), tc.err)):
+				t.Fatalf("verify: got %v, want an error containing %q", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestApplyOtherPlatform(t *testing.T) {
+	up := &Updater{Key: make(ed25519.PublicKey, ed25519.PublicKeySize), Version: "v1.4.0"}
+	u := &collectorv1.AgentUpdate{Version: "v1.5.0", Os: "plan9", Arch: runtime.GOARCH}
+	if err := up.Apply(context.Background(), u); err != nil {
+		t.Errorf("release for another platform: %v", err)
+	}
+	u.Os = ""
+	if err := up.Apply(context.Background(), u); err == nil {
+		t.Error("release without a platform was accepted")
+	}
+}
+
+func TestVersionCompare(t *testing.T) {
+	// Each version orders before the next.
+	ordered := []string{
+		"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta",
+		"1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "v1.0.1", "1.2.0", "2.0.0",
+	}
+	for i := 1; i < len(ordered); i++ {
+		a, err := parseVersion(ordered[i-1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := parseVersion(ordered[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if a.compare(b) != -1 || b.compare(a) != 1 {
+			t.Errorf("%s does not order before %s", ordered[i-1], ordered[i])
+		}
+	}
+	for _, s := range []string{"dev", "1.2", "v1.2.x", "1.2.3-", "abc123"} {
+		if _, err := parseVersion(s); err == nil {
+			t.Errorf("parsed %q", s)
+		}
+	}
+}
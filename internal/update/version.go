@@ -0,0 +1,77 @@
+package update
+
+import (
+	"cmp"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// version is a parsed semantic version, e.g. v1.4.0 or 1.5.0-rc.1; build
+// metadata after a "+" is ignored, as it does not order versions.
+type version struct {
+	core [3]uint64
+	pre  []string
+}
+
+func parseVersion(s string) (version, error) {
+	var v version
+	rest, _, _ := strings.Cut(strings.TrimPrefix(s, "v"), "+")
+	rest, pre, hasPre := strings.Cut(rest, "-")
+	parts := strings.Split(rest, ".")
+	if len(parts) != 3 {
+		return v, fmt.Errorf("version %q is not of the form MAJOR.MINOR.PATCH", s)
+	}
+	for i, p := range parts {
+		n, err := strconv.ParseUint(p, 10, 64)
+		if err != nil {
+			return v, fmt.Errorf("version %q is not of the form MAJOR.MINOR.PATCH", s)
+		}
+		v.core[i] = n
+	}
+	if hasPre {
+		if pre == "" {
+			return v, fmt.Errorf("version %q has an empty pre-release", s)
+		}
+		v.pre = strings.Split(pre, ".")
+	}
+	return v, nil
+}
+
+// compare returns -1, 0 or +1 as v orders before, with or after w, by the
+// precedence rules of Semantic Versioning: a pre-release orders before its
+// release, and pre-release identifiers compare numerically where both are
+// numbers.
+func (v version) compare(w version) int {
+	for i := range v.core {
+		if v.core[i] != w.core[i] {
+			return cmp.Compare(v.core[i], w.core[i])
+		}
+	}
+	switch {
+	case len(v.pre) == 0 && len(w.pre) == 0:
+		return 0
+	case len(v.pre) == 0:
+		return 1
+	case len(w.pre) == 0:
+		return -1
+	}
+	for i := 0; i < len(v.pre) && i < len(w.pre); i++ {
+		a, b := v.pre[i], w.pre[i]
+		if a == b {
+			continue
+		}
+		an, aErr := strconv.ParseUint(a, 10, 64)
+		bn, bErr := strconv.ParseUint(b, 10, 64)
+		switch {
+		case aErr == nil && bErr == nil:
+			return cmp.Compare(an, bn)
+		case aErr == nil:
+			return -1
+		case bErr == nil:
+			return 1
+		}
+		return strings.Compare(a, b)
+	}
+	return cmp.Compare(len(v.pre), len(w.pre))
+}
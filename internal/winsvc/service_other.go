@@ -5,6 +5,8 @@ package winsvc
 import (
 	"context"
 	"errors"
+
+	"github.com/go-tangra/go-tangra-inventory/internal/logging"
 )
 
 // IsWindowsService always returns false on non-Windows platforms.
@@ -16,7 +18,7 @@ func RunService(_ string, _ func(ctx context.Context) error) error {
 }
 
 // SetupEventLog is a no-op on non-Windows platforms.
-func SetupEventLog(_ string) {}
+func SetupEventLog(_ string, _ logging.Options) {}
 
 // Install is not supported on non-Windows platforms.
 func Install(_, _, _, _ string, _ []string) error {
@@ -28,6 +30,16 @@ func Uninstall(_ string) error {
 	return errors.New("windows service uninstall is not supported on this platform")
 }
 
+// InstallTask is not supported on non-Windows platforms.
+func InstallTask(_, _, _ string, _ []string, _ string) error {
+	return errors.New("scheduled task install is not supported on this platform")
+}
+
+// UninstallTask is not supported on non-Windows platforms.
+func UninstallTask(_ string) error {
+	return errors.New("scheduled task uninstall is not supported on this platform")
+}
+
 // ExePath returns the path to the currently running executable.
 func ExePath() (string, error) {
 	return "", errors.New("ExePath is only used on Windows")
@@ -3,36 +3,82 @@
 package winsvc
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
-	"log"
+	"io"
+	"log/slog"
 	"os"
+	"strings"
 	"time"
 
+	"github.com/go-tangra/go-tangra-inventory/internal/logging"
+
 	"golang.org/x/sys/windows/svc"
 	"golang.org/x/sys/windows/svc/eventlog"
 	"golang.org/x/sys/windows/svc/mgr"
 )
 
-// eventLogWriter wraps an eventlog.Log so standard log.Printf calls
-// are written to the Windows Event Log as informational messages.
-type eventLogWriter struct {
+// eventLogHandler is a slog.Handler that writes each record to the Windows
+// Event Log as an error, warning or informational entry by level. Records are
+// formatted by a handler from logging.Options; attributes and groups added
+// with WithAttrs and WithGroup are replayed onto it per record.
+type eventLogHandler struct {
 	elog *eventlog.Log
+	opts logging.Options
+	wrap func(slog.Handler) slog.Handler
 }
 
-func (w *eventLogWriter) Write(p []byte) (int, error) {
-	err := w.elog.Info(1, string(p))
+func (h *eventLogHandler) format(w io.Writer) slog.Handler {
+	// Options were validated when the process logger was set up.
+	inner, err := h.opts.NewHandler(w, true)
 	if err != nil {
-		return 0, err
+		inner, _ = logging.Options{}.NewHandler(w, true)
+	}
+	return h.wrap(inner)
+}
+
+func (h *eventLogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.format(io.Discard).Enabled(ctx, level)
+}
+
+func (h *eventLogHandler) Handle(ctx context.Context, r slog.Record) error {
+	var buf bytes.Buffer
+	if err := h.format(&buf).Handle(ctx, r); err != nil {
+		return err
 	}
-	return len(p), nil
+	msg := strings.TrimSuffix(buf.String(), "\n")
+
+	switch {
+	case r.Level >= slog.LevelError:
+		return h.elog.Error(1, msg)
+	case r.Level >= slog.LevelWarn:
+		return h.elog.Warning(1, msg)
+	default:
+		return h.elog.Info(1, msg)
+	}
+}
+
+func (h *eventLogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	wrap := h.wrap
+	return &eventLogHandler{elog: h.elog, opts: h.opts, wrap: func(inner slog.Handler) slog.Handler {
+		return wrap(inner).WithAttrs(attrs)
+	}}
+}
+
+func (h *eventLogHandler) WithGroup(name string) slog.Handler {
+	wrap := h.wrap
+	return &eventLogHandler{elog: h.elog, opts: h.opts, wrap: func(inner slog.Handler) slog.Handler {
+		return wrap(inner).WithGroup(name)
+	}}
 }
 
 // SetupEventLog ensures the named event log source is registered, then
-// opens it and redirects the standard logger output to it.  Event log
-// entries carry their own timestamps, so log flags are cleared.
-func SetupEventLog(name string) {
+// opens it and makes it the destination of the default slog logger, with
+// entry severities following record levels. Event log entries carry their
+// own timestamps, so the time attribute is omitted.
+func SetupEventLog(name string, opts logging.Options) {
 	// Ensure the event source is registered (idempotent — ignores "already exists").
 	// This covers the MSI install path where ServiceInstall doesn't create the source.
 	_ = eventlog.InstallAsEventCreate(name, eventlog.Error|eventlog.Warning|eventlog.Info)
@@ -41,8 +87,11 @@ func SetupEventLog(name string) {
 	if err != nil {
 		return // fall back to default stderr logging
 	}
-	log.SetOutput(&eventLogWriter{elog: elog})
-	log.SetFlags(0)
+	slog.SetDefault(slog.New(&eventLogHandler{
+		elog: elog,
+		opts: opts,
+		wrap: func(inner slog.Handler) slog.Handler { return inner },
+	}))
 }
 
 // IsWindowsService reports whether the process is running as a
@@ -81,7 +130,7 @@ func (h *serviceHandler) Execute(args []string, req <-chan svc.ChangeRequest, st
 			// run function returned on its own.
 			status <- svc.Status{State: svc.StopPending}
 			if err != nil {
-				log.Printf("Service %s stopped with error: %v", h.name, err)
+				slog.Error("Service stopped with error", "service", h.name, logging.Err(err))
 				return false, 1
 			}
 			return false, 0
@@ -97,7 +146,7 @@ func (h *serviceHandler) Execute(args []string, req <-chan svc.ChangeRequest, st
 				select {
 				case <-errCh:
 				case <-time.After(30 * time.Second):
-					log.Printf("Service %s: timed out waiting for graceful shutdown", h.name)
+					slog.Warn("Timed out waiting for graceful shutdown", "service", h.name)
 				}
 				return false, 0
 			}
@@ -146,11 +195,14 @@ func Install(name, displayName, description, exePath string, args []string) erro
 		{Type: mgr.ServiceRestart, Delay: 30 * time.Second},
 		{Type: mgr.NoAction},
 	}, 86400) // reset period: 1 day
+	// Also restart after a non-zero exit, which is how the agent hands over
+	// to a newly installed version after a self-update.
+	_ = s.SetRecoveryActionsOnNonCrashFailures(true)
 
 	// Register event log source.
 	if err := eventlog.InstallAsEventCreate(name, eventlog.Error|eventlog.Warning|eventlog.Info); err != nil {
 		// Non-fatal: the service itself is installed.
-		log.Printf("Warning: could not install event log source: %v", err)
+		slog.Warn("Could not install event log source", logging.Err(err))
 	}
 
 	return nil
@@ -0,0 +1,104 @@
+//go:build windows
+
+package winsvc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+	"unicode/utf16"
+)
+
+// taskXML is a Task Scheduler definition that runs exePath daily at the
+// given local time as LocalSystem, whether or not a user is logged on. A
+// run missed while the machine was off starts as soon as it is back.
+const taskXML = `<?xml version="1.0" encoding="UTF-16"?>
+<Task version="1.2" xmlns="http://schemas.microsoft.com/windows/2004/02/mit/task">
+  <RegistrationInfo>
+    <Description>%s</Description>
+  </RegistrationInfo>
+  <Triggers>
+    <CalendarTrigger>
+      <StartBoundary>2000-01-01T%s:00</StartBoundary>
+      <Enabled>true</Enabled>
+      <ScheduleByDay>
+        <DaysInterval>1</DaysInterval>
+      </ScheduleByDay>
+    </CalendarTrigger>
+  </Triggers>
+  <Principals>
+    <Principal id="Author">
+      <UserId>S-1-5-18</UserId>
+      <RunLevel>HighestAvailable</RunLevel>
+    </Principal>
+  </Principals>
+  <Settings>
+    <MultipleInstancesPolicy>IgnoreNew</MultipleInstancesPolicy>
+    <DisallowStartIfOnBatteries>false</DisallowStartIfOnBatteries>
+    <StopIfGoingOnBatteries>false</StopIfGoingOnBatteries>
+    <StartWhenAvailable>true</StartWhenAvailable>
+    <RunOnlyIfNetworkAvailable>true</RunOnlyIfNetworkAvailable>
+    <ExecutionTimeLimit>PT2H</ExecutionTimeLimit>
+    <Enabled>true</Enabled>
+  </Settings>
+  <Actions Context="Author">
+    <Exec>
+      <Command>%s</Command>
+      <Arguments>%s</Arguments>
+    </Exec>
+  </Actions>
+</Task>
+`
+
+// InstallTask registers a scheduled task that runs exePath with args daily
+// at the local time at, formatted as HH:MM. An existing task of the same
+// name is replaced.
+func InstallTask(name, description, exePath string, args []string, at string) error {
+	quoted := make([]string, len(args))
+	for i, a := range args {
+		quoted[i] = syscall.EscapeArg(a)
+	}
+	def := fmt.Sprintf(taskXML, escapeXML(description), at, escapeXML(exePath), escapeXML(strings.Join(quoted, " ")))
+
+	// schtasks reads task definitions as UTF-16 with a byte order mark.
+	f, err := os.CreateTemp("", "tangra-task-*.xml")
+	if err != nil {
+		return fmt.Errorf("write task definition: %w", err)
+	}
+	defer os.Remove(f.Name())
+	buf := []uint16{0xfeff}
+	buf = append(buf, utf16.Encode([]rune(def))...)
+	err = binary.Write(f, binary.LittleEndian, buf)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return fmt.Errorf("write task definition: %w", err)
+	}
+
+	return schtasks("/Create", "/TN", name, "/XML", f.Name(), "/F")
+}
+
+// UninstallTask removes the named scheduled task.
+func UninstallTask(name string) error {
+	return schtasks("/Delete", "/TN", name, "/F")
+}
+
+func schtasks(args ...string) error {
+	out, err := exec.Command("schtasks.exe", args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("schtasks %s: %w: %s", strings.ToLower(strings.TrimPrefix(args[0], "/")), err, bytes.TrimSpace(out))
+	}
+	return nil
+}
+
+func escapeXML(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
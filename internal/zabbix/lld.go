@@ -0,0 +1,122 @@
+// Package zabbix builds Zabbix low-level discovery (LLD) data from the
+// latest inventories: one entry per host, or per hardware component of a
+// host, with its properties as LLD macros for item and trigger prototypes.
+package zabbix
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	collectorv1 "github.com/go-tangra/go-tangra-inventory/gen/go/inventory/collector/v1"
+	"github.com/go-tangra/go-tangra-inventory/internal/store"
+)
+
+// Discovery kinds.
+const (
+	KindHosts      = "hosts"
+	KindProcessors = "processors"
+	KindMemory     = "memory"
+	KindMonitors   = "monitors"
+)
+
+// Kinds lists the discovery kinds; every kind but KindHosts discovers the
+// components of one host.
+var Kinds = []string{KindHosts, KindProcessors, KindMemory, KindMonitors}
+
+// ErrNoData is returned for kinds of components inventories do not carry.
+var ErrNoData = errors.New("inventories carry no data for this kind")
+
+// unavailable are the component kinds asked for that inventories do not
+// carry.
+var unavailable = map[string]bool{"disks": true, "nics": true}
+
+// Entry is one discovered entity: LLD macro names, such as {#HOST}, to
+// values.
+type Entry map[string]string
+
+// CheckKind returns nil if kind can be discovered, ErrNoData if it names
+// components inventories do not carry, and an error naming the kinds
+// otherwise.
+func CheckKind(kind string) error {
+	for _, k := range Kinds {
+		if k == kind {
+			return nil
+		}
+	}
+	if unavailable[kind] {
+		return fmt.Errorf("%s: %w", kind, ErrNoData)
+	}
+	return fmt.Errorf("unknown kind %q (use %s)", kind, strings.Join(Kinds, ", "))
+}
+
+// Hosts returns an entry per host.
+func Hosts(facts []store.HostFacts) []Entry {
+	entries := make([]Entry, 0, len(facts))
+	for _, f := range facts {
+		entries = append(entries, Entry{
+			"{#HOST}":         f.Hostname,
+			"{#SITE}":         f.Site,
+			"{#DEVICE_CLASS}": f.DeviceClass,
+			"{#MANUFACTURER}": f.Manufacturer,
+			"{#MODEL}":        f.Model,
+			"{#SERIAL}":       f.SerialNumber,
+			"{#UUID}":         f.SystemUUID,
+			"{#INVENTORY_ID}": strconv.FormatInt(f.LatestID, 10),
+		})
+	}
+	return entries
+}
+
+// Components returns an entry per component of kind in inv, which must not
+// be KindHosts. Empty processor sockets and memory slots are left out.
+func Components(kind string, inv *collectorv1.Inventory) ([]Entry, error) {
+	entries := []Entry{}
+	switch kind {
+	case KindProcessors:
+		for _, p := range inv.GetProcessors() {
+			if !p.SocketPopulated {
+				continue
+			}
+			entries = append(entries, Entry{
+				"{#CPU.SOCKET}":       p.SocketDesignation,
+				"{#CPU.MANUFACTURER}": p.Manufacturer,
+				"{#CPU.MODEL}":        strings.Join(strings.Fields(p.Version), " "),
+				"{#CPU.CORES}":        strconv.FormatUint(uint64(p.CoreCount), 10),
+				"{#CPU.THREADS}":      strconv.FormatUint(uint64(p.ThreadCount), 10),
+				"{#CPU.MAXSPEED}":     strconv.FormatUint(uint64(p.MaxSpeedMhz), 10),
+			})
+		}
+	case KindMemory:
+		for _, m := range inv.GetMemory().GetModules() {
+			if m.CapacityBytes == 0 {
+				continue
+			}
+			entries = append(entries, Entry{
+				"{#MEM.LOCATOR}":      m.DeviceLocator,
+				"{#MEM.BANK}":         m.BankLocator,
+				"{#MEM.SIZE}":         strconv.FormatUint(m.CapacityBytes, 10),
+				"{#MEM.TYPE}":         m.MemoryType,
+				"{#MEM.SPEED}":        strconv.FormatUint(uint64(m.SpeedMtS), 10),
+				"{#MEM.MANUFACTURER}": m.Manufacturer,
+				"{#MEM.SERIAL}":       m.SerialNumber,
+				"{#MEM.PARTNUMBER}":   strings.TrimSpace(m.PartNumber),
+			})
+		}
+	case KindMonitors:
+		for _, m := range inv.GetMonitor() {
+			entries = append(entries, Entry{
+				"{#MONITOR.MANUFACTURER}": m.Manufacturer,
+				"{#MONITOR.MODEL}":        m.Model,
+				"{#MONITOR.SERIAL}":       m.SerialNumber,
+			})
+		}
+	default:
+		if err := CheckKind(kind); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%s are not components", kind)
+	}
+	return entries, nil
+}